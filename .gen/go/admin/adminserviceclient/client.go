@@ -36,23 +36,65 @@ import (
 
 // Interface is a client for the AdminService service.
 type Interface interface {
+	CloseShard(
+		ctx context.Context,
+		Request *shared.CloseShardRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	DescribeHistoryHost(
 		ctx context.Context,
 		Request *shared.DescribeHistoryHostRequest,
 		opts ...yarpc.CallOption,
 	) (*shared.DescribeHistoryHostResponse, error)
 
+	DescribeShard(
+		ctx context.Context,
+		Request *shared.DescribeShardRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeShardResponse, error)
+
 	DescribeWorkflowExecution(
 		ctx context.Context,
 		Request *admin.DescribeWorkflowExecutionRequest,
 		opts ...yarpc.CallOption,
 	) (*admin.DescribeWorkflowExecutionResponse, error)
 
+	DrainHistoryHost(
+		ctx context.Context,
+		Request *shared.DrainHistoryHostRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	GetWorkflowExecutionRawHistory(
 		ctx context.Context,
 		GetRequest *admin.GetWorkflowExecutionRawHistoryRequest,
 		opts ...yarpc.CallOption,
 	) (*admin.GetWorkflowExecutionRawHistoryResponse, error)
+
+	ListQueueTasks(
+		ctx context.Context,
+		Request *shared.ListQueueTasksRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.ListQueueTasksResponse, error)
+
+	PromoteTaskListBuildID(
+		ctx context.Context,
+		Request *shared.PromoteTaskListBuildIDRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	RegenerateWorkflowTasks(
+		ctx context.Context,
+		Request *admin.RegenerateWorkflowTasksRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	RemoveQueueTask(
+		ctx context.Context,
+		Request *shared.RemoveQueueTaskRequest,
+		opts ...yarpc.CallOption,
+	) error
 }
 
 // New builds a new client for the AdminService service.
@@ -79,6 +121,29 @@ type client struct {
 	c thrift.Client
 }
 
+func (c client) CloseShard(
+	ctx context.Context,
+	_Request *shared.CloseShardRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_CloseShard_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_CloseShard_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_CloseShard_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeHistoryHost(
 	ctx context.Context,
 	_Request *shared.DescribeHistoryHostRequest,
@@ -102,6 +167,29 @@ func (c client) DescribeHistoryHost(
 	return
 }
 
+func (c client) DescribeShard(
+	ctx context.Context,
+	_Request *shared.DescribeShardRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeShardResponse, err error) {
+
+	args := admin.AdminService_DescribeShard_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DescribeShard_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_DescribeShard_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeWorkflowExecution(
 	ctx context.Context,
 	_Request *admin.DescribeWorkflowExecutionRequest,
@@ -125,6 +213,29 @@ func (c client) DescribeWorkflowExecution(
 	return
 }
 
+func (c client) DrainHistoryHost(
+	ctx context.Context,
+	_Request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_DrainHistoryHost_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_DrainHistoryHost_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_DrainHistoryHost_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) GetWorkflowExecutionRawHistory(
 	ctx context.Context,
 	_GetRequest *admin.GetWorkflowExecutionRawHistoryRequest,
@@ -147,3 +258,95 @@ func (c client) GetWorkflowExecutionRawHistory(
 	success, err = admin.AdminService_GetWorkflowExecutionRawHistory_Helper.UnwrapResponse(&result)
 	return
 }
+
+func (c client) ListQueueTasks(
+	ctx context.Context,
+	_Request *shared.ListQueueTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.ListQueueTasksResponse, err error) {
+
+	args := admin.AdminService_ListQueueTasks_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_ListQueueTasks_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = admin.AdminService_ListQueueTasks_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) PromoteTaskListBuildID(
+	ctx context.Context,
+	_Request *shared.PromoteTaskListBuildIDRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_PromoteTaskListBuildID_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_PromoteTaskListBuildID_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_PromoteTaskListBuildID_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) RegenerateWorkflowTasks(
+	ctx context.Context,
+	_Request *admin.RegenerateWorkflowTasksRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_RegenerateWorkflowTasks_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_RegenerateWorkflowTasks_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_RegenerateWorkflowTasks_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) RemoveQueueTask(
+	ctx context.Context,
+	_Request *shared.RemoveQueueTaskRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := admin.AdminService_RemoveQueueTask_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result admin.AdminService_RemoveQueueTask_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = admin.AdminService_RemoveQueueTask_Helper.UnwrapResponse(&result)
+	return
+}
@@ -34,20 +34,55 @@ import (
 
 // Interface is the server-side interface for the AdminService service.
 type Interface interface {
+	CloseShard(
+		ctx context.Context,
+		Request *shared.CloseShardRequest,
+	) error
+
 	DescribeHistoryHost(
 		ctx context.Context,
 		Request *shared.DescribeHistoryHostRequest,
 	) (*shared.DescribeHistoryHostResponse, error)
 
+	DescribeShard(
+		ctx context.Context,
+		Request *shared.DescribeShardRequest,
+	) (*shared.DescribeShardResponse, error)
+
 	DescribeWorkflowExecution(
 		ctx context.Context,
 		Request *admin.DescribeWorkflowExecutionRequest,
 	) (*admin.DescribeWorkflowExecutionResponse, error)
 
+	DrainHistoryHost(
+		ctx context.Context,
+		Request *shared.DrainHistoryHostRequest,
+	) error
+
 	GetWorkflowExecutionRawHistory(
 		ctx context.Context,
 		GetRequest *admin.GetWorkflowExecutionRawHistoryRequest,
 	) (*admin.GetWorkflowExecutionRawHistoryResponse, error)
+
+	ListQueueTasks(
+		ctx context.Context,
+		Request *shared.ListQueueTasksRequest,
+	) (*shared.ListQueueTasksResponse, error)
+
+	PromoteTaskListBuildID(
+		ctx context.Context,
+		Request *shared.PromoteTaskListBuildIDRequest,
+	) error
+
+	RegenerateWorkflowTasks(
+		ctx context.Context,
+		Request *admin.RegenerateWorkflowTasksRequest,
+	) error
+
+	RemoveQueueTask(
+		ctx context.Context,
+		Request *shared.RemoveQueueTaskRequest,
+	) error
 }
 
 // New prepares an implementation of the AdminService service for
@@ -61,6 +96,17 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 		Name: "AdminService",
 		Methods: []thrift.Method{
 
+			thrift.Method{
+				Name: "CloseShard",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.CloseShard),
+				},
+				Signature:    "CloseShard(Request *shared.CloseShardRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "DescribeHistoryHost",
 				HandlerSpec: thrift.HandlerSpec{
@@ -72,6 +118,17 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: admin.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "DescribeShard",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DescribeShard),
+				},
+				Signature:    "DescribeShard(Request *shared.DescribeShardRequest) (*shared.DescribeShardResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "DescribeWorkflowExecution",
 				HandlerSpec: thrift.HandlerSpec{
@@ -83,6 +140,17 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: admin.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "DrainHistoryHost",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DrainHistoryHost),
+				},
+				Signature:    "DrainHistoryHost(Request *shared.DrainHistoryHostRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "GetWorkflowExecutionRawHistory",
 				HandlerSpec: thrift.HandlerSpec{
@@ -93,16 +161,79 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				Signature:    "GetWorkflowExecutionRawHistory(GetRequest *admin.GetWorkflowExecutionRawHistoryRequest) (*admin.GetWorkflowExecutionRawHistoryResponse)",
 				ThriftModule: admin.ThriftModule,
 			},
+
+			thrift.Method{
+				Name: "ListQueueTasks",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ListQueueTasks),
+				},
+				Signature:    "ListQueueTasks(Request *shared.ListQueueTasksRequest) (*shared.ListQueueTasksResponse)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "PromoteTaskListBuildID",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.PromoteTaskListBuildID),
+				},
+				Signature:    "PromoteTaskListBuildID(Request *shared.PromoteTaskListBuildIDRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "RegenerateWorkflowTasks",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.RegenerateWorkflowTasks),
+				},
+				Signature:    "RegenerateWorkflowTasks(Request *admin.RegenerateWorkflowTasksRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "RemoveQueueTask",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.RemoveQueueTask),
+				},
+				Signature:    "RemoveQueueTask(Request *shared.RemoveQueueTaskRequest)",
+				ThriftModule: admin.ThriftModule,
+			},
 		},
 	}
 
-	procedures := make([]transport.Procedure, 0, 3)
+	procedures := make([]transport.Procedure, 0, 10)
 	procedures = append(procedures, thrift.BuildProcedures(service, opts...)...)
 	return procedures
 }
 
 type handler struct{ impl Interface }
 
+func (h handler) CloseShard(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_CloseShard_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.CloseShard(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_CloseShard_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) DescribeHistoryHost(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args admin.AdminService_DescribeHistoryHost_Args
 	if err := args.FromWire(body); err != nil {
@@ -122,6 +253,25 @@ func (h handler) DescribeHistoryHost(ctx context.Context, body wire.Value) (thri
 	return response, err
 }
 
+func (h handler) DescribeShard(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DescribeShard_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeShard(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DescribeShard_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) DescribeWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args admin.AdminService_DescribeWorkflowExecution_Args
 	if err := args.FromWire(body); err != nil {
@@ -141,6 +291,25 @@ func (h handler) DescribeWorkflowExecution(ctx context.Context, body wire.Value)
 	return response, err
 }
 
+func (h handler) DrainHistoryHost(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_DrainHistoryHost_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.DrainHistoryHost(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_DrainHistoryHost_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) GetWorkflowExecutionRawHistory(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args admin.AdminService_GetWorkflowExecutionRawHistory_Args
 	if err := args.FromWire(body); err != nil {
@@ -159,3 +328,79 @@ func (h handler) GetWorkflowExecutionRawHistory(ctx context.Context, body wire.V
 	}
 	return response, err
 }
+
+func (h handler) ListQueueTasks(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_ListQueueTasks_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.ListQueueTasks(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_ListQueueTasks_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) PromoteTaskListBuildID(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_PromoteTaskListBuildID_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.PromoteTaskListBuildID(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_PromoteTaskListBuildID_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) RegenerateWorkflowTasks(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_RegenerateWorkflowTasks_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.RegenerateWorkflowTasks(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_RegenerateWorkflowTasks_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) RemoveQueueTask(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args admin.AdminService_RemoveQueueTask_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.RemoveQueueTask(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := admin.AdminService_RemoveQueueTask_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
@@ -63,6 +63,37 @@ func (m *MockClient) EXPECT() *_MockClientRecorder {
 	return m.recorder
 }
 
+// CloseShard responds to a CloseShard call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().CloseShard(gomock.Any(), ...).Return(...)
+// 	... := client.CloseShard(...)
+func (m *MockClient) CloseShard(
+	ctx context.Context,
+	_Request *shared.CloseShardRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "CloseShard", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) CloseShard(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "CloseShard", args...)
+}
+
 // DescribeHistoryHost responds to a DescribeHistoryHost call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -96,6 +127,39 @@ func (mr *_MockClientRecorder) DescribeHistoryHost(
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeHistoryHost", args...)
 }
 
+// DescribeShard responds to a DescribeShard call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DescribeShard(gomock.Any(), ...).Return(...)
+// 	... := client.DescribeShard(...)
+func (m *MockClient) DescribeShard(
+	ctx context.Context,
+	_Request *shared.DescribeShardRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeShardResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeShard", args...)
+	success, _ = ret[i].(*shared.DescribeShardResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeShard(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeShard", args...)
+}
+
 // DescribeWorkflowExecution responds to a DescribeWorkflowExecution call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -129,6 +193,37 @@ func (mr *_MockClientRecorder) DescribeWorkflowExecution(
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeWorkflowExecution", args...)
 }
 
+// DrainHistoryHost responds to a DrainHistoryHost call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DrainHistoryHost(gomock.Any(), ...).Return(...)
+// 	... := client.DrainHistoryHost(...)
+func (m *MockClient) DrainHistoryHost(
+	ctx context.Context,
+	_Request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DrainHistoryHost", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DrainHistoryHost(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DrainHistoryHost", args...)
+}
+
 // GetWorkflowExecutionRawHistory responds to a GetWorkflowExecutionRawHistory call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -161,3 +256,129 @@ func (mr *_MockClientRecorder) GetWorkflowExecutionRawHistory(
 	args := append([]interface{}{ctx, _GetRequest}, opts...)
 	return mr.mock.ctrl.RecordCall(mr.mock, "GetWorkflowExecutionRawHistory", args...)
 }
+
+// ListQueueTasks responds to a ListQueueTasks call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().ListQueueTasks(gomock.Any(), ...).Return(...)
+// 	... := client.ListQueueTasks(...)
+func (m *MockClient) ListQueueTasks(
+	ctx context.Context,
+	_Request *shared.ListQueueTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.ListQueueTasksResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ListQueueTasks", args...)
+	success, _ = ret[i].(*shared.ListQueueTasksResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ListQueueTasks(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ListQueueTasks", args...)
+}
+
+// PromoteTaskListBuildID responds to a PromoteTaskListBuildID call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().PromoteTaskListBuildID(gomock.Any(), ...).Return(...)
+// 	... := client.PromoteTaskListBuildID(...)
+func (m *MockClient) PromoteTaskListBuildID(
+	ctx context.Context,
+	_Request *shared.PromoteTaskListBuildIDRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "PromoteTaskListBuildID", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) PromoteTaskListBuildID(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "PromoteTaskListBuildID", args...)
+}
+
+// RegenerateWorkflowTasks responds to a RegenerateWorkflowTasks call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().RegenerateWorkflowTasks(gomock.Any(), ...).Return(...)
+// 	... := client.RegenerateWorkflowTasks(...)
+func (m *MockClient) RegenerateWorkflowTasks(
+	ctx context.Context,
+	_Request *admin.RegenerateWorkflowTasksRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "RegenerateWorkflowTasks", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) RegenerateWorkflowTasks(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "RegenerateWorkflowTasks", args...)
+}
+
+// RemoveQueueTask responds to a RemoveQueueTask call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().RemoveQueueTask(gomock.Any(), ...).Return(...)
+// 	... := client.RemoveQueueTask(...)
+func (m *MockClient) RemoveQueueTask(
+	ctx context.Context,
+	_Request *shared.RemoveQueueTaskRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "RemoveQueueTask", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) RemoveQueueTask(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "RemoveQueueTask", args...)
+}
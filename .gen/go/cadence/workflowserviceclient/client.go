@@ -84,6 +84,18 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*shared.ListOpenWorkflowExecutionsResponse, error)
 
+	PauseWorkflowExecution(
+		ctx context.Context,
+		PauseRequest *shared.PauseWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	PollForActivityCancellation(
+		ctx context.Context,
+		PollRequest *shared.PollForActivityCancellationRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.PollForActivityCancellationResponse, error)
+
 	PollForActivityTask(
 		ctx context.Context,
 		PollRequest *shared.PollForActivityTaskRequest,
@@ -216,6 +228,12 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) error
 
+	UnpauseWorkflowExecution(
+		ctx context.Context,
+		UnpauseRequest *shared.UnpauseWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	UpdateDomain(
 		ctx context.Context,
 		UpdateRequest *shared.UpdateDomainRequest,
@@ -431,6 +449,52 @@ func (c client) ListOpenWorkflowExecutions(
 	return
 }
 
+func (c client) PauseWorkflowExecution(
+	ctx context.Context,
+	_PauseRequest *shared.PauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := cadence.WorkflowService_PauseWorkflowExecution_Helper.Args(_PauseRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result cadence.WorkflowService_PauseWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = cadence.WorkflowService_PauseWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) PollForActivityCancellation(
+	ctx context.Context,
+	_PollRequest *shared.PollForActivityCancellationRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.PollForActivityCancellationResponse, err error) {
+
+	args := cadence.WorkflowService_PollForActivityCancellation_Helper.Args(_PollRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result cadence.WorkflowService_PollForActivityCancellation_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = cadence.WorkflowService_PollForActivityCancellation_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) PollForActivityTask(
 	ctx context.Context,
 	_PollRequest *shared.PollForActivityTaskRequest,
@@ -937,6 +1001,29 @@ func (c client) TerminateWorkflowExecution(
 	return
 }
 
+func (c client) UnpauseWorkflowExecution(
+	ctx context.Context,
+	_UnpauseRequest *shared.UnpauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := cadence.WorkflowService_UnpauseWorkflowExecution_Helper.Args(_UnpauseRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result cadence.WorkflowService_UnpauseWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = cadence.WorkflowService_UnpauseWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) UpdateDomain(
 	ctx context.Context,
 	_UpdateRequest *shared.UpdateDomainRequest,
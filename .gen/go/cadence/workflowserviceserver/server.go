@@ -74,6 +74,16 @@ type Interface interface {
 		ListRequest *shared.ListOpenWorkflowExecutionsRequest,
 	) (*shared.ListOpenWorkflowExecutionsResponse, error)
 
+	PauseWorkflowExecution(
+		ctx context.Context,
+		PauseRequest *shared.PauseWorkflowExecutionRequest,
+	) error
+
+	PollForActivityCancellation(
+		ctx context.Context,
+		PollRequest *shared.PollForActivityCancellationRequest,
+	) (*shared.PollForActivityCancellationResponse, error)
+
 	PollForActivityTask(
 		ctx context.Context,
 		PollRequest *shared.PollForActivityTaskRequest,
@@ -184,6 +194,11 @@ type Interface interface {
 		TerminateRequest *shared.TerminateWorkflowExecutionRequest,
 	) error
 
+	UnpauseWorkflowExecution(
+		ctx context.Context,
+		UnpauseRequest *shared.UnpauseWorkflowExecutionRequest,
+	) error
+
 	UpdateDomain(
 		ctx context.Context,
 		UpdateRequest *shared.UpdateDomainRequest,
@@ -289,6 +304,28 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: cadence.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "PauseWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.PauseWorkflowExecution),
+				},
+				Signature:    "PauseWorkflowExecution(PauseRequest *shared.PauseWorkflowExecutionRequest)",
+				ThriftModule: cadence.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "PollForActivityCancellation",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.PollForActivityCancellation),
+				},
+				Signature:    "PollForActivityCancellation(PollRequest *shared.PollForActivityCancellationRequest) (*shared.PollForActivityCancellationResponse)",
+				ThriftModule: cadence.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "PollForActivityTask",
 				HandlerSpec: thrift.HandlerSpec{
@@ -531,6 +568,17 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: cadence.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "UnpauseWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.UnpauseWorkflowExecution),
+				},
+				Signature:    "UnpauseWorkflowExecution(UnpauseRequest *shared.UnpauseWorkflowExecutionRequest)",
+				ThriftModule: cadence.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "UpdateDomain",
 				HandlerSpec: thrift.HandlerSpec{
@@ -544,7 +592,7 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 		},
 	}
 
-	procedures := make([]transport.Procedure, 0, 31)
+	procedures := make([]transport.Procedure, 0, 34)
 	procedures = append(procedures, thrift.BuildProcedures(service, opts...)...)
 	return procedures
 }
@@ -703,6 +751,44 @@ func (h handler) ListOpenWorkflowExecutions(ctx context.Context, body wire.Value
 	return response, err
 }
 
+func (h handler) PauseWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args cadence.WorkflowService_PauseWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.PauseWorkflowExecution(ctx, args.PauseRequest)
+
+	hadError := err != nil
+	result, err := cadence.WorkflowService_PauseWorkflowExecution_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) PollForActivityCancellation(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args cadence.WorkflowService_PollForActivityCancellation_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.PollForActivityCancellation(ctx, args.PollRequest)
+
+	hadError := err != nil
+	result, err := cadence.WorkflowService_PollForActivityCancellation_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) PollForActivityTask(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args cadence.WorkflowService_PollForActivityTask_Args
 	if err := args.FromWire(body); err != nil {
@@ -1121,6 +1207,25 @@ func (h handler) TerminateWorkflowExecution(ctx context.Context, body wire.Value
 	return response, err
 }
 
+func (h handler) UnpauseWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args cadence.WorkflowService_UnpauseWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.UnpauseWorkflowExecution(ctx, args.UnpauseRequest)
+
+	hadError := err != nil
+	result, err := cadence.WorkflowService_UnpauseWorkflowExecution_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) UpdateDomain(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args cadence.WorkflowService_UpdateDomain_Args
 	if err := args.FromWire(body); err != nil {
@@ -324,6 +324,70 @@ func (mr *_MockClientRecorder) ListOpenWorkflowExecutions(
 	return mr.mock.ctrl.RecordCall(mr.mock, "ListOpenWorkflowExecutions", args...)
 }
 
+// PauseWorkflowExecution responds to a PauseWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().PauseWorkflowExecution(gomock.Any(), ...).Return(...)
+// 	... := client.PauseWorkflowExecution(...)
+func (m *MockClient) PauseWorkflowExecution(
+	ctx context.Context,
+	_PauseRequest *shared.PauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _PauseRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "PauseWorkflowExecution", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) PauseWorkflowExecution(
+	ctx interface{},
+	_PauseRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _PauseRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "PauseWorkflowExecution", args...)
+}
+
+// PollForActivityCancellation responds to a PollForActivityCancellation call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().PollForActivityCancellation(gomock.Any(), ...).Return(...)
+// 	... := client.PollForActivityCancellation(...)
+func (m *MockClient) PollForActivityCancellation(
+	ctx context.Context,
+	_PollRequest *shared.PollForActivityCancellationRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.PollForActivityCancellationResponse, err error) {
+
+	args := []interface{}{ctx, _PollRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "PollForActivityCancellation", args...)
+	success, _ = ret[i].(*shared.PollForActivityCancellationResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) PollForActivityCancellation(
+	ctx interface{},
+	_PollRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _PollRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "PollForActivityCancellation", args...)
+}
+
 // PollForActivityTask responds to a PollForActivityTask call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -1026,6 +1090,37 @@ func (mr *_MockClientRecorder) TerminateWorkflowExecution(
 	return mr.mock.ctrl.RecordCall(mr.mock, "TerminateWorkflowExecution", args...)
 }
 
+// UnpauseWorkflowExecution responds to a UnpauseWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().UnpauseWorkflowExecution(gomock.Any(), ...).Return(...)
+// 	... := client.UnpauseWorkflowExecution(...)
+func (m *MockClient) UnpauseWorkflowExecution(
+	ctx context.Context,
+	_UnpauseRequest *shared.UnpauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _UnpauseRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "UnpauseWorkflowExecution", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) UnpauseWorkflowExecution(
+	ctx interface{},
+	_UnpauseRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _UnpauseRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "UnpauseWorkflowExecution", args...)
+}
+
 // UpdateDomain responds to a UpdateDomain call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
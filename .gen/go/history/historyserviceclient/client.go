@@ -36,6 +36,12 @@ import (
 
 // Interface is a client for the HistoryService service.
 type Interface interface {
+	CloseShard(
+		ctx context.Context,
+		Request *shared.CloseShardRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	DescribeHistoryHost(
 		ctx context.Context,
 		Request *shared.DescribeHistoryHostRequest,
@@ -48,18 +54,48 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*history.DescribeMutableStateResponse, error)
 
+	DescribeShard(
+		ctx context.Context,
+		Request *shared.DescribeShardRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.DescribeShardResponse, error)
+
 	DescribeWorkflowExecution(
 		ctx context.Context,
 		DescribeRequest *history.DescribeWorkflowExecutionRequest,
 		opts ...yarpc.CallOption,
 	) (*shared.DescribeWorkflowExecutionResponse, error)
 
+	DrainHistoryHost(
+		ctx context.Context,
+		Request *shared.DrainHistoryHostRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	GetMutableState(
 		ctx context.Context,
 		GetRequest *history.GetMutableStateRequest,
 		opts ...yarpc.CallOption,
 	) (*history.GetMutableStateResponse, error)
 
+	ListQueueTasks(
+		ctx context.Context,
+		Request *shared.ListQueueTasksRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.ListQueueTasksResponse, error)
+
+	PauseWorkflowExecution(
+		ctx context.Context,
+		PauseRequest *history.PauseWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	QueryWorkflow(
+		ctx context.Context,
+		QueryRequest *history.QueryWorkflowRequest,
+		opts ...yarpc.CallOption,
+	) (*history.QueryWorkflowResponse, error)
+
 	RecordActivityTaskHeartbeat(
 		ctx context.Context,
 		HeartbeatRequest *history.RecordActivityTaskHeartbeatRequest,
@@ -84,6 +120,18 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*history.RecordDecisionTaskStartedResponse, error)
 
+	RegenerateWorkflowTasks(
+		ctx context.Context,
+		Request *history.RegenerateWorkflowTasksRequest,
+		opts ...yarpc.CallOption,
+	) error
+
+	RemoveQueueTask(
+		ctx context.Context,
+		Request *shared.RemoveQueueTaskRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	RemoveSignalMutableState(
 		ctx context.Context,
 		RemoveRequest *history.RemoveSignalMutableStateRequest,
@@ -191,6 +239,12 @@ type Interface interface {
 		TerminateRequest *history.TerminateWorkflowExecutionRequest,
 		opts ...yarpc.CallOption,
 	) error
+
+	UnpauseWorkflowExecution(
+		ctx context.Context,
+		UnpauseRequest *history.UnpauseWorkflowExecutionRequest,
+		opts ...yarpc.CallOption,
+	) error
 }
 
 // New builds a new client for the HistoryService service.
@@ -217,6 +271,29 @@ type client struct {
 	c thrift.Client
 }
 
+func (c client) CloseShard(
+	ctx context.Context,
+	_Request *shared.CloseShardRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_CloseShard_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_CloseShard_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_CloseShard_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeHistoryHost(
 	ctx context.Context,
 	_Request *shared.DescribeHistoryHostRequest,
@@ -263,6 +340,29 @@ func (c client) DescribeMutableState(
 	return
 }
 
+func (c client) DescribeShard(
+	ctx context.Context,
+	_Request *shared.DescribeShardRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeShardResponse, err error) {
+
+	args := history.HistoryService_DescribeShard_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_DescribeShard_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_DescribeShard_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) DescribeWorkflowExecution(
 	ctx context.Context,
 	_DescribeRequest *history.DescribeWorkflowExecutionRequest,
@@ -286,6 +386,29 @@ func (c client) DescribeWorkflowExecution(
 	return
 }
 
+func (c client) DrainHistoryHost(
+	ctx context.Context,
+	_Request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_DrainHistoryHost_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_DrainHistoryHost_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_DrainHistoryHost_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) GetMutableState(
 	ctx context.Context,
 	_GetRequest *history.GetMutableStateRequest,
@@ -309,6 +432,75 @@ func (c client) GetMutableState(
 	return
 }
 
+func (c client) ListQueueTasks(
+	ctx context.Context,
+	_Request *shared.ListQueueTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.ListQueueTasksResponse, err error) {
+
+	args := history.HistoryService_ListQueueTasks_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_ListQueueTasks_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_ListQueueTasks_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) PauseWorkflowExecution(
+	ctx context.Context,
+	_PauseRequest *history.PauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_PauseWorkflowExecution_Helper.Args(_PauseRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_PauseWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_PauseWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) QueryWorkflow(
+	ctx context.Context,
+	_QueryRequest *history.QueryWorkflowRequest,
+	opts ...yarpc.CallOption,
+) (success *history.QueryWorkflowResponse, err error) {
+
+	args := history.HistoryService_QueryWorkflow_Helper.Args(_QueryRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_QueryWorkflow_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = history.HistoryService_QueryWorkflow_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) RecordActivityTaskHeartbeat(
 	ctx context.Context,
 	_HeartbeatRequest *history.RecordActivityTaskHeartbeatRequest,
@@ -401,6 +593,52 @@ func (c client) RecordDecisionTaskStarted(
 	return
 }
 
+func (c client) RegenerateWorkflowTasks(
+	ctx context.Context,
+	_Request *history.RegenerateWorkflowTasksRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_RegenerateWorkflowTasks_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_RegenerateWorkflowTasks_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_RegenerateWorkflowTasks_Helper.UnwrapResponse(&result)
+	return
+}
+
+func (c client) RemoveQueueTask(
+	ctx context.Context,
+	_Request *shared.RemoveQueueTaskRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_RemoveQueueTask_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_RemoveQueueTask_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_RemoveQueueTask_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) RemoveSignalMutableState(
 	ctx context.Context,
 	_RemoveRequest *history.RemoveSignalMutableStateRequest,
@@ -814,3 +1052,26 @@ func (c client) TerminateWorkflowExecution(
 	err = history.HistoryService_TerminateWorkflowExecution_Helper.UnwrapResponse(&result)
 	return
 }
+
+func (c client) UnpauseWorkflowExecution(
+	ctx context.Context,
+	_UnpauseRequest *history.UnpauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := history.HistoryService_UnpauseWorkflowExecution_Helper.Args(_UnpauseRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result history.HistoryService_UnpauseWorkflowExecution_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = history.HistoryService_UnpauseWorkflowExecution_Helper.UnwrapResponse(&result)
+	return
+}
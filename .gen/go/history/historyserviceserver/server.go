@@ -34,6 +34,11 @@ import (
 
 // Interface is the server-side interface for the HistoryService service.
 type Interface interface {
+	CloseShard(
+		ctx context.Context,
+		Request *shared.CloseShardRequest,
+	) error
+
 	DescribeHistoryHost(
 		ctx context.Context,
 		Request *shared.DescribeHistoryHostRequest,
@@ -44,16 +49,41 @@ type Interface interface {
 		Request *history.DescribeMutableStateRequest,
 	) (*history.DescribeMutableStateResponse, error)
 
+	DescribeShard(
+		ctx context.Context,
+		Request *shared.DescribeShardRequest,
+	) (*shared.DescribeShardResponse, error)
+
 	DescribeWorkflowExecution(
 		ctx context.Context,
 		DescribeRequest *history.DescribeWorkflowExecutionRequest,
 	) (*shared.DescribeWorkflowExecutionResponse, error)
 
+	DrainHistoryHost(
+		ctx context.Context,
+		Request *shared.DrainHistoryHostRequest,
+	) error
+
 	GetMutableState(
 		ctx context.Context,
 		GetRequest *history.GetMutableStateRequest,
 	) (*history.GetMutableStateResponse, error)
 
+	ListQueueTasks(
+		ctx context.Context,
+		Request *shared.ListQueueTasksRequest,
+	) (*shared.ListQueueTasksResponse, error)
+
+	PauseWorkflowExecution(
+		ctx context.Context,
+		PauseRequest *history.PauseWorkflowExecutionRequest,
+	) error
+
+	QueryWorkflow(
+		ctx context.Context,
+		QueryRequest *history.QueryWorkflowRequest,
+	) (*history.QueryWorkflowResponse, error)
+
 	RecordActivityTaskHeartbeat(
 		ctx context.Context,
 		HeartbeatRequest *history.RecordActivityTaskHeartbeatRequest,
@@ -74,6 +104,16 @@ type Interface interface {
 		AddRequest *history.RecordDecisionTaskStartedRequest,
 	) (*history.RecordDecisionTaskStartedResponse, error)
 
+	RegenerateWorkflowTasks(
+		ctx context.Context,
+		Request *history.RegenerateWorkflowTasksRequest,
+	) error
+
+	RemoveQueueTask(
+		ctx context.Context,
+		Request *shared.RemoveQueueTaskRequest,
+	) error
+
 	RemoveSignalMutableState(
 		ctx context.Context,
 		RemoveRequest *history.RemoveSignalMutableStateRequest,
@@ -163,6 +203,11 @@ type Interface interface {
 		ctx context.Context,
 		TerminateRequest *history.TerminateWorkflowExecutionRequest,
 	) error
+
+	UnpauseWorkflowExecution(
+		ctx context.Context,
+		UnpauseRequest *history.UnpauseWorkflowExecutionRequest,
+	) error
 }
 
 // New prepares an implementation of the HistoryService service for
@@ -176,6 +221,17 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 		Name: "HistoryService",
 		Methods: []thrift.Method{
 
+			thrift.Method{
+				Name: "CloseShard",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.CloseShard),
+				},
+				Signature:    "CloseShard(Request *shared.CloseShardRequest)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "DescribeHistoryHost",
 				HandlerSpec: thrift.HandlerSpec{
@@ -198,6 +254,17 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: history.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "DescribeShard",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DescribeShard),
+				},
+				Signature:    "DescribeShard(Request *shared.DescribeShardRequest) (*shared.DescribeShardResponse)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "DescribeWorkflowExecution",
 				HandlerSpec: thrift.HandlerSpec{
@@ -209,6 +276,17 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: history.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "DrainHistoryHost",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.DrainHistoryHost),
+				},
+				Signature:    "DrainHistoryHost(Request *shared.DrainHistoryHostRequest)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "GetMutableState",
 				HandlerSpec: thrift.HandlerSpec{
@@ -220,6 +298,39 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: history.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "ListQueueTasks",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.ListQueueTasks),
+				},
+				Signature:    "ListQueueTasks(Request *shared.ListQueueTasksRequest) (*shared.ListQueueTasksResponse)",
+				ThriftModule: history.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "PauseWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.PauseWorkflowExecution),
+				},
+				Signature:    "PauseWorkflowExecution(PauseRequest *history.PauseWorkflowExecutionRequest)",
+				ThriftModule: history.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "QueryWorkflow",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.QueryWorkflow),
+				},
+				Signature:    "QueryWorkflow(QueryRequest *history.QueryWorkflowRequest) (*history.QueryWorkflowResponse)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "RecordActivityTaskHeartbeat",
 				HandlerSpec: thrift.HandlerSpec{
@@ -264,6 +375,28 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				ThriftModule: history.ThriftModule,
 			},
 
+			thrift.Method{
+				Name: "RegenerateWorkflowTasks",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.RegenerateWorkflowTasks),
+				},
+				Signature:    "RegenerateWorkflowTasks(Request *history.RegenerateWorkflowTasksRequest)",
+				ThriftModule: history.ThriftModule,
+			},
+
+			thrift.Method{
+				Name: "RemoveQueueTask",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.RemoveQueueTask),
+				},
+				Signature:    "RemoveQueueTask(Request *shared.RemoveQueueTaskRequest)",
+				ThriftModule: history.ThriftModule,
+			},
+
 			thrift.Method{
 				Name: "RemoveSignalMutableState",
 				HandlerSpec: thrift.HandlerSpec{
@@ -461,16 +594,46 @@ func New(impl Interface, opts ...thrift.RegisterOption) []transport.Procedure {
 				Signature:    "TerminateWorkflowExecution(TerminateRequest *history.TerminateWorkflowExecutionRequest)",
 				ThriftModule: history.ThriftModule,
 			},
+
+			thrift.Method{
+				Name: "UnpauseWorkflowExecution",
+				HandlerSpec: thrift.HandlerSpec{
+
+					Type:  transport.Unary,
+					Unary: thrift.UnaryHandler(h.UnpauseWorkflowExecution),
+				},
+				Signature:    "UnpauseWorkflowExecution(UnpauseRequest *history.UnpauseWorkflowExecutionRequest)",
+				ThriftModule: history.ThriftModule,
+			},
 		},
 	}
 
-	procedures := make([]transport.Procedure, 0, 26)
+	procedures := make([]transport.Procedure, 0, 35)
 	procedures = append(procedures, thrift.BuildProcedures(service, opts...)...)
 	return procedures
 }
 
 type handler struct{ impl Interface }
 
+func (h handler) CloseShard(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_CloseShard_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.CloseShard(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := history.HistoryService_CloseShard_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) DescribeHistoryHost(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_DescribeHistoryHost_Args
 	if err := args.FromWire(body); err != nil {
@@ -509,6 +672,25 @@ func (h handler) DescribeMutableState(ctx context.Context, body wire.Value) (thr
 	return response, err
 }
 
+func (h handler) DescribeShard(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_DescribeShard_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.DescribeShard(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := history.HistoryService_DescribeShard_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) DescribeWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_DescribeWorkflowExecution_Args
 	if err := args.FromWire(body); err != nil {
@@ -528,6 +710,25 @@ func (h handler) DescribeWorkflowExecution(ctx context.Context, body wire.Value)
 	return response, err
 }
 
+func (h handler) DrainHistoryHost(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_DrainHistoryHost_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.DrainHistoryHost(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := history.HistoryService_DrainHistoryHost_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) GetMutableState(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_GetMutableState_Args
 	if err := args.FromWire(body); err != nil {
@@ -547,6 +748,63 @@ func (h handler) GetMutableState(ctx context.Context, body wire.Value) (thrift.R
 	return response, err
 }
 
+func (h handler) ListQueueTasks(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_ListQueueTasks_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.ListQueueTasks(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := history.HistoryService_ListQueueTasks_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) PauseWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_PauseWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.PauseWorkflowExecution(ctx, args.PauseRequest)
+
+	hadError := err != nil
+	result, err := history.HistoryService_PauseWorkflowExecution_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) QueryWorkflow(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_QueryWorkflow_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	success, err := h.impl.QueryWorkflow(ctx, args.QueryRequest)
+
+	hadError := err != nil
+	result, err := history.HistoryService_QueryWorkflow_Helper.WrapResponse(success, err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) RecordActivityTaskHeartbeat(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_RecordActivityTaskHeartbeat_Args
 	if err := args.FromWire(body); err != nil {
@@ -623,6 +881,44 @@ func (h handler) RecordDecisionTaskStarted(ctx context.Context, body wire.Value)
 	return response, err
 }
 
+func (h handler) RegenerateWorkflowTasks(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_RegenerateWorkflowTasks_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.RegenerateWorkflowTasks(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := history.HistoryService_RegenerateWorkflowTasks_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
+func (h handler) RemoveQueueTask(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_RemoveQueueTask_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.RemoveQueueTask(ctx, args.Request)
+
+	hadError := err != nil
+	result, err := history.HistoryService_RemoveQueueTask_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
+
 func (h handler) RemoveSignalMutableState(ctx context.Context, body wire.Value) (thrift.Response, error) {
 	var args history.HistoryService_RemoveSignalMutableState_Args
 	if err := args.FromWire(body); err != nil {
@@ -964,3 +1260,22 @@ func (h handler) TerminateWorkflowExecution(ctx context.Context, body wire.Value
 	}
 	return response, err
 }
+
+func (h handler) UnpauseWorkflowExecution(ctx context.Context, body wire.Value) (thrift.Response, error) {
+	var args history.HistoryService_UnpauseWorkflowExecution_Args
+	if err := args.FromWire(body); err != nil {
+		return thrift.Response{}, err
+	}
+
+	err := h.impl.UnpauseWorkflowExecution(ctx, args.UnpauseRequest)
+
+	hadError := err != nil
+	result, err := history.HistoryService_UnpauseWorkflowExecution_Helper.WrapResponse(err)
+
+	var response thrift.Response
+	if err == nil {
+		response.IsApplicationError = hadError
+		response.Body = result
+	}
+	return response, err
+}
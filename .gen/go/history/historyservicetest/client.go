@@ -63,6 +63,37 @@ func (m *MockClient) EXPECT() *_MockClientRecorder {
 	return m.recorder
 }
 
+// CloseShard responds to a CloseShard call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().CloseShard(gomock.Any(), ...).Return(...)
+// 	... := client.CloseShard(...)
+func (m *MockClient) CloseShard(
+	ctx context.Context,
+	_Request *shared.CloseShardRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "CloseShard", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) CloseShard(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "CloseShard", args...)
+}
+
 // DescribeHistoryHost responds to a DescribeHistoryHost call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -129,6 +160,39 @@ func (mr *_MockClientRecorder) DescribeMutableState(
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeMutableState", args...)
 }
 
+// DescribeShard responds to a DescribeShard call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DescribeShard(gomock.Any(), ...).Return(...)
+// 	... := client.DescribeShard(...)
+func (m *MockClient) DescribeShard(
+	ctx context.Context,
+	_Request *shared.DescribeShardRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.DescribeShardResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DescribeShard", args...)
+	success, _ = ret[i].(*shared.DescribeShardResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DescribeShard(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeShard", args...)
+}
+
 // DescribeWorkflowExecution responds to a DescribeWorkflowExecution call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -162,6 +226,37 @@ func (mr *_MockClientRecorder) DescribeWorkflowExecution(
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeWorkflowExecution", args...)
 }
 
+// DrainHistoryHost responds to a DrainHistoryHost call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().DrainHistoryHost(gomock.Any(), ...).Return(...)
+// 	... := client.DrainHistoryHost(...)
+func (m *MockClient) DrainHistoryHost(
+	ctx context.Context,
+	_Request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "DrainHistoryHost", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) DrainHistoryHost(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "DrainHistoryHost", args...)
+}
+
 // GetMutableState responds to a GetMutableState call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -195,6 +290,103 @@ func (mr *_MockClientRecorder) GetMutableState(
 	return mr.mock.ctrl.RecordCall(mr.mock, "GetMutableState", args...)
 }
 
+// ListQueueTasks responds to a ListQueueTasks call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().ListQueueTasks(gomock.Any(), ...).Return(...)
+// 	... := client.ListQueueTasks(...)
+func (m *MockClient) ListQueueTasks(
+	ctx context.Context,
+	_Request *shared.ListQueueTasksRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.ListQueueTasksResponse, err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "ListQueueTasks", args...)
+	success, _ = ret[i].(*shared.ListQueueTasksResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) ListQueueTasks(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "ListQueueTasks", args...)
+}
+
+// PauseWorkflowExecution responds to a PauseWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().PauseWorkflowExecution(gomock.Any(), ...).Return(...)
+// 	... := client.PauseWorkflowExecution(...)
+func (m *MockClient) PauseWorkflowExecution(
+	ctx context.Context,
+	_PauseRequest *history.PauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _PauseRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "PauseWorkflowExecution", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) PauseWorkflowExecution(
+	ctx interface{},
+	_PauseRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _PauseRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "PauseWorkflowExecution", args...)
+}
+
+// QueryWorkflow responds to a QueryWorkflow call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().QueryWorkflow(gomock.Any(), ...).Return(...)
+// 	... := client.QueryWorkflow(...)
+func (m *MockClient) QueryWorkflow(
+	ctx context.Context,
+	_QueryRequest *history.QueryWorkflowRequest,
+	opts ...yarpc.CallOption,
+) (success *history.QueryWorkflowResponse, err error) {
+
+	args := []interface{}{ctx, _QueryRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "QueryWorkflow", args...)
+	success, _ = ret[i].(*history.QueryWorkflowResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) QueryWorkflow(
+	ctx interface{},
+	_QueryRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _QueryRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "QueryWorkflow", args...)
+}
+
 // RecordActivityTaskHeartbeat responds to a RecordActivityTaskHeartbeat call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -325,6 +517,68 @@ func (mr *_MockClientRecorder) RecordDecisionTaskStarted(
 	return mr.mock.ctrl.RecordCall(mr.mock, "RecordDecisionTaskStarted", args...)
 }
 
+// RegenerateWorkflowTasks responds to a RegenerateWorkflowTasks call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().RegenerateWorkflowTasks(gomock.Any(), ...).Return(...)
+// 	... := client.RegenerateWorkflowTasks(...)
+func (m *MockClient) RegenerateWorkflowTasks(
+	ctx context.Context,
+	_Request *history.RegenerateWorkflowTasksRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "RegenerateWorkflowTasks", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) RegenerateWorkflowTasks(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "RegenerateWorkflowTasks", args...)
+}
+
+// RemoveQueueTask responds to a RemoveQueueTask call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().RemoveQueueTask(gomock.Any(), ...).Return(...)
+// 	... := client.RemoveQueueTask(...)
+func (m *MockClient) RemoveQueueTask(
+	ctx context.Context,
+	_Request *shared.RemoveQueueTaskRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "RemoveQueueTask", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) RemoveQueueTask(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "RemoveQueueTask", args...)
+}
+
 // RemoveSignalMutableState responds to a RemoveSignalMutableState call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -892,3 +1146,34 @@ func (mr *_MockClientRecorder) TerminateWorkflowExecution(
 	args := append([]interface{}{ctx, _TerminateRequest}, opts...)
 	return mr.mock.ctrl.RecordCall(mr.mock, "TerminateWorkflowExecution", args...)
 }
+
+// UnpauseWorkflowExecution responds to a UnpauseWorkflowExecution call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().UnpauseWorkflowExecution(gomock.Any(), ...).Return(...)
+// 	... := client.UnpauseWorkflowExecution(...)
+func (m *MockClient) UnpauseWorkflowExecution(
+	ctx context.Context,
+	_UnpauseRequest *history.UnpauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _UnpauseRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "UnpauseWorkflowExecution", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) UnpauseWorkflowExecution(
+	ctx interface{},
+	_UnpauseRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _UnpauseRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "UnpauseWorkflowExecution", args...)
+}
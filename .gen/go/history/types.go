@@ -918,21 +918,24 @@ func (v *GetMutableStateRequest) GetExpectedNextEventId() (o int64) {
 }
 
 type GetMutableStateResponse struct {
-	Execution                            *shared.WorkflowExecution          `json:"execution,omitempty"`
-	WorkflowType                         *shared.WorkflowType               `json:"workflowType,omitempty"`
-	NextEventId                          *int64                             `json:"NextEventId,omitempty"`
-	PreviousStartedEventId               *int64                             `json:"PreviousStartedEventId,omitempty"`
-	LastFirstEventId                     *int64                             `json:"LastFirstEventId,omitempty"`
-	TaskList                             *shared.TaskList                   `json:"taskList,omitempty"`
-	StickyTaskList                       *shared.TaskList                   `json:"stickyTaskList,omitempty"`
-	ClientLibraryVersion                 *string                            `json:"clientLibraryVersion,omitempty"`
-	ClientFeatureVersion                 *string                            `json:"clientFeatureVersion,omitempty"`
-	ClientImpl                           *string                            `json:"clientImpl,omitempty"`
-	IsWorkflowRunning                    *bool                              `json:"isWorkflowRunning,omitempty"`
-	StickyTaskListScheduleToStartTimeout *int32                             `json:"stickyTaskListScheduleToStartTimeout,omitempty"`
-	EventStoreVersion                    *int32                             `json:"eventStoreVersion,omitempty"`
-	BranchToken                          []byte                             `json:"branchToken,omitempty"`
-	ReplicationInfo                      map[string]*shared.ReplicationInfo `json:"replicationInfo,omitempty"`
+	Execution                            *shared.WorkflowExecution            `json:"execution,omitempty"`
+	WorkflowType                         *shared.WorkflowType                 `json:"workflowType,omitempty"`
+	NextEventId                          *int64                               `json:"NextEventId,omitempty"`
+	PreviousStartedEventId               *int64                               `json:"PreviousStartedEventId,omitempty"`
+	LastFirstEventId                     *int64                               `json:"LastFirstEventId,omitempty"`
+	TaskList                             *shared.TaskList                     `json:"taskList,omitempty"`
+	StickyTaskList                       *shared.TaskList                     `json:"stickyTaskList,omitempty"`
+	ClientLibraryVersion                 *string                              `json:"clientLibraryVersion,omitempty"`
+	ClientFeatureVersion                 *string                              `json:"clientFeatureVersion,omitempty"`
+	ClientImpl                           *string                              `json:"clientImpl,omitempty"`
+	IsWorkflowRunning                    *bool                                `json:"isWorkflowRunning,omitempty"`
+	StickyTaskListScheduleToStartTimeout *int32                               `json:"stickyTaskListScheduleToStartTimeout,omitempty"`
+	EventStoreVersion                    *int32                               `json:"eventStoreVersion,omitempty"`
+	BranchToken                          []byte                               `json:"branchToken,omitempty"`
+	ReplicationInfo                      map[string]*shared.ReplicationInfo   `json:"replicationInfo,omitempty"`
+	WorkflowCloseStatus                  *shared.WorkflowExecutionCloseStatus `json:"workflowCloseStatus,omitempty"`
+	HasPendingDecision                   *bool                                `json:"hasPendingDecision,omitempty"`
+	BuildID                              *string                              `json:"buildID,omitempty"`
 }
 
 type _Map_String_ReplicationInfo_MapItemList map[string]*shared.ReplicationInfo
@@ -990,7 +993,7 @@ func (_Map_String_ReplicationInfo_MapItemList) Close() {}
 //   }
 func (v *GetMutableStateResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [15]wire.Field
+		fields [18]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -1116,6 +1119,30 @@ func (v *GetMutableStateResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 140, Value: w}
 		i++
 	}
+	if v.WorkflowCloseStatus != nil {
+		w, err = v.WorkflowCloseStatus.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 150, Value: w}
+		i++
+	}
+	if v.HasPendingDecision != nil {
+		w, err = wire.NewValueBool(*(v.HasPendingDecision)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 160, Value: w}
+		i++
+	}
+	if v.BuildID != nil {
+		w, err = wire.NewValueString(*(v.BuildID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 170, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -1166,6 +1193,12 @@ func _Map_String_ReplicationInfo_Read(m wire.MapItemList) (map[string]*shared.Re
 	return o, err
 }
 
+func _WorkflowExecutionCloseStatus_Read(w wire.Value) (shared.WorkflowExecutionCloseStatus, error) {
+	var v shared.WorkflowExecutionCloseStatus
+	err := v.FromWire(w)
+	return v, err
+}
+
 // FromWire deserializes a GetMutableStateResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -1325,6 +1358,36 @@ func (v *GetMutableStateResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 150:
+			if field.Value.Type() == wire.TI32 {
+				var x shared.WorkflowExecutionCloseStatus
+				x, err = _WorkflowExecutionCloseStatus_Read(field.Value)
+				v.WorkflowCloseStatus = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 160:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.HasPendingDecision = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 170:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.BuildID = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -1339,7 +1402,7 @@ func (v *GetMutableStateResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [15]string
+	var fields [18]string
 	i := 0
 	if v.Execution != nil {
 		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
@@ -1401,6 +1464,18 @@ func (v *GetMutableStateResponse) String() string {
 		fields[i] = fmt.Sprintf("ReplicationInfo: %v", v.ReplicationInfo)
 		i++
 	}
+	if v.WorkflowCloseStatus != nil {
+		fields[i] = fmt.Sprintf("WorkflowCloseStatus: %v", *(v.WorkflowCloseStatus))
+		i++
+	}
+	if v.HasPendingDecision != nil {
+		fields[i] = fmt.Sprintf("HasPendingDecision: %v", *(v.HasPendingDecision))
+		i++
+	}
+	if v.BuildID != nil {
+		fields[i] = fmt.Sprintf("BuildID: %v", *(v.BuildID))
+		i++
+	}
 
 	return fmt.Sprintf("GetMutableStateResponse{%v}", strings.Join(fields[:i], ", "))
 }
@@ -1442,6 +1517,16 @@ func _Map_String_ReplicationInfo_Equals(lhs, rhs map[string]*shared.ReplicationI
 	return true
 }
 
+func _WorkflowExecutionCloseStatus_EqualsPtr(lhs, rhs *shared.WorkflowExecutionCloseStatus) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
 // Equals returns true if all the fields of this GetMutableStateResponse match the
 // provided GetMutableStateResponse.
 //
@@ -1497,6 +1582,15 @@ func (v *GetMutableStateResponse) Equals(rhs *GetMutableStateResponse) bool {
 	if !((v.ReplicationInfo == nil && rhs.ReplicationInfo == nil) || (v.ReplicationInfo != nil && rhs.ReplicationInfo != nil && _Map_String_ReplicationInfo_Equals(v.ReplicationInfo, rhs.ReplicationInfo))) {
 		return false
 	}
+	if !_WorkflowExecutionCloseStatus_EqualsPtr(v.WorkflowCloseStatus, rhs.WorkflowCloseStatus) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.HasPendingDecision, rhs.HasPendingDecision) {
+		return false
+	}
+	if !_String_EqualsPtr(v.BuildID, rhs.BuildID) {
+		return false
+	}
 
 	return true
 }
@@ -1563,6 +1657,15 @@ func (v *GetMutableStateResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (e
 	if v.ReplicationInfo != nil {
 		err = multierr.Append(err, enc.AddObject("replicationInfo", (_Map_String_ReplicationInfo_Zapper)(v.ReplicationInfo)))
 	}
+	if v.WorkflowCloseStatus != nil {
+		err = multierr.Append(err, enc.AddObject("workflowCloseStatus", *v.WorkflowCloseStatus))
+	}
+	if v.HasPendingDecision != nil {
+		enc.AddBool("hasPendingDecision", *v.HasPendingDecision)
+	}
+	if v.BuildID != nil {
+		enc.AddString("buildID", *v.BuildID)
+	}
 	return err
 }
 
@@ -1716,6 +1819,36 @@ func (v *GetMutableStateResponse) GetReplicationInfo() (o map[string]*shared.Rep
 	return
 }
 
+// GetWorkflowCloseStatus returns the value of WorkflowCloseStatus if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetWorkflowCloseStatus() (o shared.WorkflowExecutionCloseStatus) {
+	if v.WorkflowCloseStatus != nil {
+		return *v.WorkflowCloseStatus
+	}
+
+	return
+}
+
+// GetHasPendingDecision returns the value of HasPendingDecision if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetHasPendingDecision() (o bool) {
+	if v.HasPendingDecision != nil {
+		return *v.HasPendingDecision
+	}
+
+	return
+}
+
+// GetBuildID returns the value of BuildID if it is set or its
+// zero value if it is unset.
+func (v *GetMutableStateResponse) GetBuildID() (o string) {
+	if v.BuildID != nil {
+		return *v.BuildID
+	}
+
+	return
+}
+
 type ParentExecutionInfo struct {
 	DomainUUID  *string                   `json:"domainUUID,omitempty"`
 	Domain      *string                   `json:"domain,omitempty"`
@@ -1964,12 +2097,12 @@ func (v *ParentExecutionInfo) GetInitiatedId() (o int64) {
 	return
 }
 
-type RecordActivityTaskHeartbeatRequest struct {
-	DomainUUID       *string                                    `json:"domainUUID,omitempty"`
-	HeartbeatRequest *shared.RecordActivityTaskHeartbeatRequest `json:"heartbeatRequest,omitempty"`
+type PauseWorkflowExecutionRequest struct {
+	DomainUUID   *string                               `json:"domainUUID,omitempty"`
+	PauseRequest *shared.PauseWorkflowExecutionRequest `json:"pauseRequest,omitempty"`
 }
 
-// ToWire translates a RecordActivityTaskHeartbeatRequest struct into a Thrift-level intermediate
+// ToWire translates a PauseWorkflowExecutionRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -1984,7 +2117,7 @@ type RecordActivityTaskHeartbeatRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordActivityTaskHeartbeatRequest) ToWire() (wire.Value, error) {
+func (v *PauseWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	var (
 		fields [2]wire.Field
 		i      int = 0
@@ -2000,8 +2133,8 @@ func (v *RecordActivityTaskHeartbeatRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.HeartbeatRequest != nil {
-		w, err = v.HeartbeatRequest.ToWire()
+	if v.PauseRequest != nil {
+		w, err = v.PauseRequest.ToWire()
 		if err != nil {
 			return w, err
 		}
@@ -2012,17 +2145,17 @@ func (v *RecordActivityTaskHeartbeatRequest) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _RecordActivityTaskHeartbeatRequest_Read(w wire.Value) (*shared.RecordActivityTaskHeartbeatRequest, error) {
-	var v shared.RecordActivityTaskHeartbeatRequest
+func _PauseWorkflowExecutionRequest_Read(w wire.Value) (*shared.PauseWorkflowExecutionRequest, error) {
+	var v shared.PauseWorkflowExecutionRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a RecordActivityTaskHeartbeatRequest struct from its Thrift-level
+// FromWire deserializes a PauseWorkflowExecutionRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordActivityTaskHeartbeatRequest struct
+// An error is returned if we were unable to build a PauseWorkflowExecutionRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -2030,12 +2163,12 @@ func _RecordActivityTaskHeartbeatRequest_Read(w wire.Value) (*shared.RecordActiv
 //     return nil, err
 //   }
 //
-//   var v RecordActivityTaskHeartbeatRequest
+//   var v PauseWorkflowExecutionRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordActivityTaskHeartbeatRequest) FromWire(w wire.Value) error {
+func (v *PauseWorkflowExecutionRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -2052,7 +2185,7 @@ func (v *RecordActivityTaskHeartbeatRequest) FromWire(w wire.Value) error {
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.HeartbeatRequest, err = _RecordActivityTaskHeartbeatRequest_Read(field.Value)
+				v.PauseRequest, err = _PauseWorkflowExecutionRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -2064,9 +2197,9 @@ func (v *RecordActivityTaskHeartbeatRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RecordActivityTaskHeartbeatRequest
+// String returns a readable string representation of a PauseWorkflowExecutionRequest
 // struct.
-func (v *RecordActivityTaskHeartbeatRequest) String() string {
+func (v *PauseWorkflowExecutionRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -2077,19 +2210,19 @@ func (v *RecordActivityTaskHeartbeatRequest) String() string {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.HeartbeatRequest != nil {
-		fields[i] = fmt.Sprintf("HeartbeatRequest: %v", v.HeartbeatRequest)
+	if v.PauseRequest != nil {
+		fields[i] = fmt.Sprintf("PauseRequest: %v", v.PauseRequest)
 		i++
 	}
 
-	return fmt.Sprintf("RecordActivityTaskHeartbeatRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("PauseWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordActivityTaskHeartbeatRequest match the
-// provided RecordActivityTaskHeartbeatRequest.
+// Equals returns true if all the fields of this PauseWorkflowExecutionRequest match the
+// provided PauseWorkflowExecutionRequest.
 //
 // This function performs a deep comparison.
-func (v *RecordActivityTaskHeartbeatRequest) Equals(rhs *RecordActivityTaskHeartbeatRequest) bool {
+func (v *PauseWorkflowExecutionRequest) Equals(rhs *PauseWorkflowExecutionRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -2098,7 +2231,7 @@ func (v *RecordActivityTaskHeartbeatRequest) Equals(rhs *RecordActivityTaskHeart
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !((v.HeartbeatRequest == nil && rhs.HeartbeatRequest == nil) || (v.HeartbeatRequest != nil && rhs.HeartbeatRequest != nil && v.HeartbeatRequest.Equals(rhs.HeartbeatRequest))) {
+	if !((v.PauseRequest == nil && rhs.PauseRequest == nil) || (v.PauseRequest != nil && rhs.PauseRequest != nil && v.PauseRequest.Equals(rhs.PauseRequest))) {
 		return false
 	}
 
@@ -2106,23 +2239,23 @@ func (v *RecordActivityTaskHeartbeatRequest) Equals(rhs *RecordActivityTaskHeart
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of RecordActivityTaskHeartbeatRequest.
-func (v *RecordActivityTaskHeartbeatRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of PauseWorkflowExecutionRequest.
+func (v *PauseWorkflowExecutionRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
 	if v.DomainUUID != nil {
 		enc.AddString("domainUUID", *v.DomainUUID)
 	}
-	if v.HeartbeatRequest != nil {
-		err = multierr.Append(err, enc.AddObject("heartbeatRequest", v.HeartbeatRequest))
+	if v.PauseRequest != nil {
+		err = multierr.Append(err, enc.AddObject("pauseRequest", v.PauseRequest))
 	}
 	return err
 }
 
 // GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskHeartbeatRequest) GetDomainUUID() (o string) {
+func (v *PauseWorkflowExecutionRequest) GetDomainUUID() (o string) {
 	if v.DomainUUID != nil {
 		return *v.DomainUUID
 	}
@@ -2130,26 +2263,22 @@ func (v *RecordActivityTaskHeartbeatRequest) GetDomainUUID() (o string) {
 	return
 }
 
-// GetHeartbeatRequest returns the value of HeartbeatRequest if it is set or its
+// GetPauseRequest returns the value of PauseRequest if it is set or its
 // zero value if it is unset.
-func (v *RecordActivityTaskHeartbeatRequest) GetHeartbeatRequest() (o *shared.RecordActivityTaskHeartbeatRequest) {
-	if v.HeartbeatRequest != nil {
-		return v.HeartbeatRequest
+func (v *PauseWorkflowExecutionRequest) GetPauseRequest() (o *shared.PauseWorkflowExecutionRequest) {
+	if v.PauseRequest != nil {
+		return v.PauseRequest
 	}
 
 	return
 }
 
-type RecordActivityTaskStartedRequest struct {
-	DomainUUID        *string                            `json:"domainUUID,omitempty"`
-	WorkflowExecution *shared.WorkflowExecution          `json:"workflowExecution,omitempty"`
-	ScheduleId        *int64                             `json:"scheduleId,omitempty"`
-	TaskId            *int64                             `json:"taskId,omitempty"`
-	RequestId         *string                            `json:"requestId,omitempty"`
-	PollRequest       *shared.PollForActivityTaskRequest `json:"pollRequest,omitempty"`
+type QueryWorkflowRequest struct {
+	DomainUUID *string                      `json:"domainUUID,omitempty"`
+	Request    *shared.QueryWorkflowRequest `json:"request,omitempty"`
 }
 
-// ToWire translates a RecordActivityTaskStartedRequest struct into a Thrift-level intermediate
+// ToWire translates a QueryWorkflowRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
 //
@@ -2164,9 +2293,9 @@ type RecordActivityTaskStartedRequest struct {
 //   if err := binaryProtocol.Encode(x, writer); err != nil {
 //     return err
 //   }
-func (v *RecordActivityTaskStartedRequest) ToWire() (wire.Value, error) {
+func (v *QueryWorkflowRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -2180,61 +2309,29 @@ func (v *RecordActivityTaskStartedRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		w, err = v.WorkflowExecution.ToWire()
+	if v.Request != nil {
+		w, err = v.Request.ToWire()
 		if err != nil {
 			return w, err
 		}
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
-	if v.ScheduleId != nil {
-		w, err = wire.NewValueI64(*(v.ScheduleId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 30, Value: w}
-		i++
-	}
-	if v.TaskId != nil {
-		w, err = wire.NewValueI64(*(v.TaskId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 40, Value: w}
-		i++
-	}
-	if v.RequestId != nil {
-		w, err = wire.NewValueString(*(v.RequestId)), error(nil)
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 45, Value: w}
-		i++
-	}
-	if v.PollRequest != nil {
-		w, err = v.PollRequest.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 50, Value: w}
-		i++
-	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _PollForActivityTaskRequest_Read(w wire.Value) (*shared.PollForActivityTaskRequest, error) {
-	var v shared.PollForActivityTaskRequest
+func _QueryWorkflowRequest_Read(w wire.Value) (*shared.QueryWorkflowRequest, error) {
+	var v shared.QueryWorkflowRequest
 	err := v.FromWire(w)
 	return &v, err
 }
 
-// FromWire deserializes a RecordActivityTaskStartedRequest struct from its Thrift-level
+// FromWire deserializes a QueryWorkflowRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a RecordActivityTaskStartedRequest struct
+// An error is returned if we were unable to build a QueryWorkflowRequest struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -2242,12 +2339,12 @@ func _PollForActivityTaskRequest_Read(w wire.Value) (*shared.PollForActivityTask
 //     return nil, err
 //   }
 //
-//   var v RecordActivityTaskStartedRequest
+//   var v QueryWorkflowRequest
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *RecordActivityTaskStartedRequest) FromWire(w wire.Value) error {
+func (v *QueryWorkflowRequest) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -2264,45 +2361,7 @@ func (v *RecordActivityTaskStartedRequest) FromWire(w wire.Value) error {
 			}
 		case 20:
 			if field.Value.Type() == wire.TStruct {
-				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
-				if err != nil {
-					return err
-				}
-
-			}
-		case 30:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.ScheduleId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 40:
-			if field.Value.Type() == wire.TI64 {
-				var x int64
-				x, err = field.Value.GetI64(), error(nil)
-				v.TaskId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 45:
-			if field.Value.Type() == wire.TBinary {
-				var x string
-				x, err = field.Value.GetString(), error(nil)
-				v.RequestId = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		case 50:
-			if field.Value.Type() == wire.TStruct {
-				v.PollRequest, err = _PollForActivityTaskRequest_Read(field.Value)
+				v.Request, err = _QueryWorkflowRequest_Read(field.Value)
 				if err != nil {
 					return err
 				}
@@ -2314,48 +2373,32 @@ func (v *RecordActivityTaskStartedRequest) FromWire(w wire.Value) error {
 	return nil
 }
 
-// String returns a readable string representation of a RecordActivityTaskStartedRequest
+// String returns a readable string representation of a QueryWorkflowRequest
 // struct.
-func (v *RecordActivityTaskStartedRequest) String() string {
+func (v *QueryWorkflowRequest) String() string {
 	if v == nil {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [2]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
 		i++
 	}
-	if v.WorkflowExecution != nil {
-		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
-		i++
-	}
-	if v.ScheduleId != nil {
-		fields[i] = fmt.Sprintf("ScheduleId: %v", *(v.ScheduleId))
-		i++
-	}
-	if v.TaskId != nil {
-		fields[i] = fmt.Sprintf("TaskId: %v", *(v.TaskId))
-		i++
-	}
-	if v.RequestId != nil {
-		fields[i] = fmt.Sprintf("RequestId: %v", *(v.RequestId))
-		i++
-	}
-	if v.PollRequest != nil {
-		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
+	if v.Request != nil {
+		fields[i] = fmt.Sprintf("Request: %v", v.Request)
 		i++
 	}
 
-	return fmt.Sprintf("RecordActivityTaskStartedRequest{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("QueryWorkflowRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this RecordActivityTaskStartedRequest match the
-// provided RecordActivityTaskStartedRequest.
+// Equals returns true if all the fields of this QueryWorkflowRequest match the
+// provided QueryWorkflowRequest.
 //
 // This function performs a deep comparison.
-func (v *RecordActivityTaskStartedRequest) Equals(rhs *RecordActivityTaskStartedRequest) bool {
+func (v *QueryWorkflowRequest) Equals(rhs *QueryWorkflowRequest) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -2364,19 +2407,7 @@ func (v *RecordActivityTaskStartedRequest) Equals(rhs *RecordActivityTaskStarted
 	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
 		return false
 	}
-	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.ScheduleId, rhs.ScheduleId) {
-		return false
-	}
-	if !_I64_EqualsPtr(v.TaskId, rhs.TaskId) {
-		return false
-	}
-	if !_String_EqualsPtr(v.RequestId, rhs.RequestId) {
-		return false
-	}
-	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
+	if !((v.Request == nil && rhs.Request == nil) || (v.Request != nil && rhs.Request != nil && v.Request.Equals(rhs.Request))) {
 		return false
 	}
 
@@ -2384,16 +2415,607 @@ func (v *RecordActivityTaskStartedRequest) Equals(rhs *RecordActivityTaskStarted
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of RecordActivityTaskStartedRequest.
-func (v *RecordActivityTaskStartedRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of QueryWorkflowRequest.
+func (v *QueryWorkflowRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
 	if v.DomainUUID != nil {
 		enc.AddString("domainUUID", *v.DomainUUID)
 	}
-	if v.WorkflowExecution != nil {
-		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
+	if v.Request != nil {
+		err = multierr.Append(err, enc.AddObject("request", v.Request))
+	}
+	return err
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// GetRequest returns the value of Request if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowRequest) GetRequest() (o *shared.QueryWorkflowRequest) {
+	if v.Request != nil {
+		return v.Request
+	}
+
+	return
+}
+
+type QueryWorkflowResponse struct {
+	Response *shared.QueryWorkflowResponse `json:"response,omitempty"`
+}
+
+// ToWire translates a QueryWorkflowResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *QueryWorkflowResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Response != nil {
+		w, err = v.Response.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _QueryWorkflowResponse_Read(w wire.Value) (*shared.QueryWorkflowResponse, error) {
+	var v shared.QueryWorkflowResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a QueryWorkflowResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a QueryWorkflowResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v QueryWorkflowResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *QueryWorkflowResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TStruct {
+				v.Response, err = _QueryWorkflowResponse_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a QueryWorkflowResponse
+// struct.
+func (v *QueryWorkflowResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.Response != nil {
+		fields[i] = fmt.Sprintf("Response: %v", v.Response)
+		i++
+	}
+
+	return fmt.Sprintf("QueryWorkflowResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this QueryWorkflowResponse match the
+// provided QueryWorkflowResponse.
+//
+// This function performs a deep comparison.
+func (v *QueryWorkflowResponse) Equals(rhs *QueryWorkflowResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Response == nil && rhs.Response == nil) || (v.Response != nil && rhs.Response != nil && v.Response.Equals(rhs.Response))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of QueryWorkflowResponse.
+func (v *QueryWorkflowResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Response != nil {
+		err = multierr.Append(err, enc.AddObject("response", v.Response))
+	}
+	return err
+}
+
+// GetResponse returns the value of Response if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowResponse) GetResponse() (o *shared.QueryWorkflowResponse) {
+	if v.Response != nil {
+		return v.Response
+	}
+
+	return
+}
+
+type RecordActivityTaskHeartbeatRequest struct {
+	DomainUUID       *string                                    `json:"domainUUID,omitempty"`
+	HeartbeatRequest *shared.RecordActivityTaskHeartbeatRequest `json:"heartbeatRequest,omitempty"`
+}
+
+// ToWire translates a RecordActivityTaskHeartbeatRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RecordActivityTaskHeartbeatRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.HeartbeatRequest != nil {
+		w, err = v.HeartbeatRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _RecordActivityTaskHeartbeatRequest_Read(w wire.Value) (*shared.RecordActivityTaskHeartbeatRequest, error) {
+	var v shared.RecordActivityTaskHeartbeatRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a RecordActivityTaskHeartbeatRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RecordActivityTaskHeartbeatRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RecordActivityTaskHeartbeatRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *RecordActivityTaskHeartbeatRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.HeartbeatRequest, err = _RecordActivityTaskHeartbeatRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a RecordActivityTaskHeartbeatRequest
+// struct.
+func (v *RecordActivityTaskHeartbeatRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.HeartbeatRequest != nil {
+		fields[i] = fmt.Sprintf("HeartbeatRequest: %v", v.HeartbeatRequest)
+		i++
+	}
+
+	return fmt.Sprintf("RecordActivityTaskHeartbeatRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this RecordActivityTaskHeartbeatRequest match the
+// provided RecordActivityTaskHeartbeatRequest.
+//
+// This function performs a deep comparison.
+func (v *RecordActivityTaskHeartbeatRequest) Equals(rhs *RecordActivityTaskHeartbeatRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.HeartbeatRequest == nil && rhs.HeartbeatRequest == nil) || (v.HeartbeatRequest != nil && rhs.HeartbeatRequest != nil && v.HeartbeatRequest.Equals(rhs.HeartbeatRequest))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of RecordActivityTaskHeartbeatRequest.
+func (v *RecordActivityTaskHeartbeatRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.HeartbeatRequest != nil {
+		err = multierr.Append(err, enc.AddObject("heartbeatRequest", v.HeartbeatRequest))
+	}
+	return err
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *RecordActivityTaskHeartbeatRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// GetHeartbeatRequest returns the value of HeartbeatRequest if it is set or its
+// zero value if it is unset.
+func (v *RecordActivityTaskHeartbeatRequest) GetHeartbeatRequest() (o *shared.RecordActivityTaskHeartbeatRequest) {
+	if v.HeartbeatRequest != nil {
+		return v.HeartbeatRequest
+	}
+
+	return
+}
+
+type RecordActivityTaskStartedRequest struct {
+	DomainUUID        *string                            `json:"domainUUID,omitempty"`
+	WorkflowExecution *shared.WorkflowExecution          `json:"workflowExecution,omitempty"`
+	ScheduleId        *int64                             `json:"scheduleId,omitempty"`
+	TaskId            *int64                             `json:"taskId,omitempty"`
+	RequestId         *string                            `json:"requestId,omitempty"`
+	PollRequest       *shared.PollForActivityTaskRequest `json:"pollRequest,omitempty"`
+}
+
+// ToWire translates a RecordActivityTaskStartedRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RecordActivityTaskStartedRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [6]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.ScheduleId != nil {
+		w, err = wire.NewValueI64(*(v.ScheduleId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.TaskId != nil {
+		w, err = wire.NewValueI64(*(v.TaskId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.RequestId != nil {
+		w, err = wire.NewValueString(*(v.RequestId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 45, Value: w}
+		i++
+	}
+	if v.PollRequest != nil {
+		w, err = v.PollRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _PollForActivityTaskRequest_Read(w wire.Value) (*shared.PollForActivityTaskRequest, error) {
+	var v shared.PollForActivityTaskRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a RecordActivityTaskStartedRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RecordActivityTaskStartedRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RecordActivityTaskStartedRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *RecordActivityTaskStartedRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ScheduleId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.TaskId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 45:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RequestId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TStruct {
+				v.PollRequest, err = _PollForActivityTaskRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a RecordActivityTaskStartedRequest
+// struct.
+func (v *RecordActivityTaskStartedRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [6]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+		i++
+	}
+	if v.ScheduleId != nil {
+		fields[i] = fmt.Sprintf("ScheduleId: %v", *(v.ScheduleId))
+		i++
+	}
+	if v.TaskId != nil {
+		fields[i] = fmt.Sprintf("TaskId: %v", *(v.TaskId))
+		i++
+	}
+	if v.RequestId != nil {
+		fields[i] = fmt.Sprintf("RequestId: %v", *(v.RequestId))
+		i++
+	}
+	if v.PollRequest != nil {
+		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
+		i++
+	}
+
+	return fmt.Sprintf("RecordActivityTaskStartedRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this RecordActivityTaskStartedRequest match the
+// provided RecordActivityTaskStartedRequest.
+//
+// This function performs a deep comparison.
+func (v *RecordActivityTaskStartedRequest) Equals(rhs *RecordActivityTaskStartedRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.ScheduleId, rhs.ScheduleId) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.TaskId, rhs.TaskId) {
+		return false
+	}
+	if !_String_EqualsPtr(v.RequestId, rhs.RequestId) {
+		return false
+	}
+	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of RecordActivityTaskStartedRequest.
+func (v *RecordActivityTaskStartedRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.WorkflowExecution != nil {
+		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
 	}
 	if v.ScheduleId != nil {
 		enc.AddInt64("scheduleId", *v.ScheduleId)
@@ -3456,19 +4078,59 @@ func (v *RecordDecisionTaskStartedRequest) GetPollRequest() (o *shared.PollForDe
 }
 
 type RecordDecisionTaskStartedResponse struct {
-	WorkflowType              *shared.WorkflowType          `json:"workflowType,omitempty"`
-	PreviousStartedEventId    *int64                        `json:"previousStartedEventId,omitempty"`
-	ScheduledEventId          *int64                        `json:"scheduledEventId,omitempty"`
-	StartedEventId            *int64                        `json:"startedEventId,omitempty"`
-	NextEventId               *int64                        `json:"nextEventId,omitempty"`
-	Attempt                   *int64                        `json:"attempt,omitempty"`
-	StickyExecutionEnabled    *bool                         `json:"stickyExecutionEnabled,omitempty"`
-	DecisionInfo              *shared.TransientDecisionInfo `json:"decisionInfo,omitempty"`
-	WorkflowExecutionTaskList *shared.TaskList              `json:"WorkflowExecutionTaskList,omitempty"`
-	EventStoreVersion         *int32                        `json:"eventStoreVersion,omitempty"`
-	BranchToken               []byte                        `json:"branchToken,omitempty"`
+	WorkflowType              *shared.WorkflowType             `json:"workflowType,omitempty"`
+	PreviousStartedEventId    *int64                           `json:"previousStartedEventId,omitempty"`
+	ScheduledEventId          *int64                           `json:"scheduledEventId,omitempty"`
+	StartedEventId            *int64                           `json:"startedEventId,omitempty"`
+	NextEventId               *int64                           `json:"nextEventId,omitempty"`
+	Attempt                   *int64                           `json:"attempt,omitempty"`
+	StickyExecutionEnabled    *bool                            `json:"stickyExecutionEnabled,omitempty"`
+	DecisionInfo              *shared.TransientDecisionInfo    `json:"decisionInfo,omitempty"`
+	WorkflowExecutionTaskList *shared.TaskList                 `json:"WorkflowExecutionTaskList,omitempty"`
+	EventStoreVersion         *int32                           `json:"eventStoreVersion,omitempty"`
+	BranchToken               []byte                           `json:"branchToken,omitempty"`
+	ScheduleAttempt           *int64                           `json:"scheduleAttempt,omitempty"`
+	Queries                   map[string]*shared.WorkflowQuery `json:"queries,omitempty"`
+}
+
+type _Map_String_WorkflowQuery_MapItemList map[string]*shared.WorkflowQuery
+
+func (m _Map_String_WorkflowQuery_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_WorkflowQuery_MapItemList) Size() int {
+	return len(m)
 }
 
+func (_Map_String_WorkflowQuery_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_WorkflowQuery_MapItemList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_Map_String_WorkflowQuery_MapItemList) Close() {}
+
 // ToWire translates a RecordDecisionTaskStartedResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -3486,7 +4148,7 @@ type RecordDecisionTaskStartedResponse struct {
 //   }
 func (v *RecordDecisionTaskStartedResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [11]wire.Field
+		fields [13]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -3580,6 +4242,22 @@ func (v *RecordDecisionTaskStartedResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 110, Value: w}
 		i++
 	}
+	if v.ScheduleAttempt != nil {
+		w, err = wire.NewValueI64(*(v.ScheduleAttempt)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 120, Value: w}
+		i++
+	}
+	if v.Queries != nil {
+		w, err = wire.NewValueMap(_Map_String_WorkflowQuery_MapItemList(v.Queries)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 130, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -3590,6 +4268,40 @@ func _TransientDecisionInfo_Read(w wire.Value) (*shared.TransientDecisionInfo, e
 	return &v, err
 }
 
+func _WorkflowQuery_Read(w wire.Value) (*shared.WorkflowQuery, error) {
+	var v shared.WorkflowQuery
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _Map_String_WorkflowQuery_Read(m wire.MapItemList) (map[string]*shared.WorkflowQuery, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make(map[string]*shared.WorkflowQuery, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := _WorkflowQuery_Read(x.Value)
+		if err != nil {
+			return err
+		}
+
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
 // FromWire deserializes a RecordDecisionTaskStartedResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -3713,6 +4425,24 @@ func (v *RecordDecisionTaskStartedResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 120:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.ScheduleAttempt = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 130:
+			if field.Value.Type() == wire.TMap {
+				v.Queries, err = _Map_String_WorkflowQuery_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -3727,7 +4457,7 @@ func (v *RecordDecisionTaskStartedResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [11]string
+	var fields [13]string
 	i := 0
 	if v.WorkflowType != nil {
 		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
@@ -3773,10 +4503,35 @@ func (v *RecordDecisionTaskStartedResponse) String() string {
 		fields[i] = fmt.Sprintf("BranchToken: %v", v.BranchToken)
 		i++
 	}
+	if v.ScheduleAttempt != nil {
+		fields[i] = fmt.Sprintf("ScheduleAttempt: %v", *(v.ScheduleAttempt))
+		i++
+	}
+	if v.Queries != nil {
+		fields[i] = fmt.Sprintf("Queries: %v", v.Queries)
+		i++
+	}
 
 	return fmt.Sprintf("RecordDecisionTaskStartedResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
+func _Map_String_WorkflowQuery_Equals(lhs, rhs map[string]*shared.WorkflowQuery) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
 // Equals returns true if all the fields of this RecordDecisionTaskStartedResponse match the
 // provided RecordDecisionTaskStartedResponse.
 //
@@ -3820,10 +4575,27 @@ func (v *RecordDecisionTaskStartedResponse) Equals(rhs *RecordDecisionTaskStarte
 	if !((v.BranchToken == nil && rhs.BranchToken == nil) || (v.BranchToken != nil && rhs.BranchToken != nil && bytes.Equal(v.BranchToken, rhs.BranchToken))) {
 		return false
 	}
+	if !_I64_EqualsPtr(v.ScheduleAttempt, rhs.ScheduleAttempt) {
+		return false
+	}
+	if !((v.Queries == nil && rhs.Queries == nil) || (v.Queries != nil && rhs.Queries != nil && _Map_String_WorkflowQuery_Equals(v.Queries, rhs.Queries))) {
+		return false
+	}
 
 	return true
 }
 
+type _Map_String_WorkflowQuery_Zapper map[string]*shared.WorkflowQuery
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of _Map_String_WorkflowQuery_Zapper.
+func (m _Map_String_WorkflowQuery_Zapper) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	for k, v := range m {
+		err = multierr.Append(err, enc.AddObject((string)(k), v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of RecordDecisionTaskStartedResponse.
 func (v *RecordDecisionTaskStartedResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -3863,6 +4635,12 @@ func (v *RecordDecisionTaskStartedResponse) MarshalLogObject(enc zapcore.ObjectE
 	if v.BranchToken != nil {
 		enc.AddString("branchToken", base64.StdEncoding.EncodeToString(v.BranchToken))
 	}
+	if v.ScheduleAttempt != nil {
+		enc.AddInt64("scheduleAttempt", *v.ScheduleAttempt)
+	}
+	if v.Queries != nil {
+		err = multierr.Append(err, enc.AddObject("queries", (_Map_String_WorkflowQuery_Zapper)(v.Queries)))
+	}
 	return err
 }
 
@@ -3943,34 +4721,224 @@ func (v *RecordDecisionTaskStartedResponse) GetDecisionInfo() (o *shared.Transie
 		return v.DecisionInfo
 	}
 
-	return
+	return
+}
+
+// GetWorkflowExecutionTaskList returns the value of WorkflowExecutionTaskList if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetWorkflowExecutionTaskList() (o *shared.TaskList) {
+	if v.WorkflowExecutionTaskList != nil {
+		return v.WorkflowExecutionTaskList
+	}
+
+	return
+}
+
+// GetEventStoreVersion returns the value of EventStoreVersion if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetEventStoreVersion() (o int32) {
+	if v.EventStoreVersion != nil {
+		return *v.EventStoreVersion
+	}
+
+	return
+}
+
+// GetBranchToken returns the value of BranchToken if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetBranchToken() (o []byte) {
+	if v.BranchToken != nil {
+		return v.BranchToken
+	}
+
+	return
+}
+
+// GetScheduleAttempt returns the value of ScheduleAttempt if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetScheduleAttempt() (o int64) {
+	if v.ScheduleAttempt != nil {
+		return *v.ScheduleAttempt
+	}
+
+	return
+}
+
+// GetQueries returns the value of Queries if it is set or its
+// zero value if it is unset.
+func (v *RecordDecisionTaskStartedResponse) GetQueries() (o map[string]*shared.WorkflowQuery) {
+	if v.Queries != nil {
+		return v.Queries
+	}
+
+	return
+}
+
+type RegenerateWorkflowTasksRequest struct {
+	DomainUUID *string                   `json:"domainUUID,omitempty"`
+	Execution  *shared.WorkflowExecution `json:"execution,omitempty"`
+}
+
+// ToWire translates a RegenerateWorkflowTasksRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RegenerateWorkflowTasksRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a RegenerateWorkflowTasksRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RegenerateWorkflowTasksRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RegenerateWorkflowTasksRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *RegenerateWorkflowTasksRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a RegenerateWorkflowTasksRequest
+// struct.
+func (v *RegenerateWorkflowTasksRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+
+	return fmt.Sprintf("RegenerateWorkflowTasksRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this RegenerateWorkflowTasksRequest match the
+// provided RegenerateWorkflowTasksRequest.
+//
+// This function performs a deep comparison.
+func (v *RegenerateWorkflowTasksRequest) Equals(rhs *RegenerateWorkflowTasksRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+
+	return true
 }
 
-// GetWorkflowExecutionTaskList returns the value of WorkflowExecutionTaskList if it is set or its
-// zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetWorkflowExecutionTaskList() (o *shared.TaskList) {
-	if v.WorkflowExecutionTaskList != nil {
-		return v.WorkflowExecutionTaskList
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of RegenerateWorkflowTasksRequest.
+func (v *RegenerateWorkflowTasksRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
 	}
-
-	return
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.Execution != nil {
+		err = multierr.Append(err, enc.AddObject("execution", v.Execution))
+	}
+	return err
 }
 
-// GetEventStoreVersion returns the value of EventStoreVersion if it is set or its
+// GetDomainUUID returns the value of DomainUUID if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetEventStoreVersion() (o int32) {
-	if v.EventStoreVersion != nil {
-		return *v.EventStoreVersion
+func (v *RegenerateWorkflowTasksRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
 	}
 
 	return
 }
 
-// GetBranchToken returns the value of BranchToken if it is set or its
+// GetExecution returns the value of Execution if it is set or its
 // zero value if it is unset.
-func (v *RecordDecisionTaskStartedResponse) GetBranchToken() (o []byte) {
-	if v.BranchToken != nil {
-		return v.BranchToken
+func (v *RegenerateWorkflowTasksRequest) GetExecution() (o *shared.WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
 	}
 
 	return
@@ -6581,9 +7549,39 @@ func (v *RespondDecisionTaskCompletedRequest) GetCompleteRequest() (o *shared.Re
 }
 
 type RespondDecisionTaskCompletedResponse struct {
-	StartedResponse *RecordDecisionTaskStartedResponse `json:"startedResponse,omitempty"`
+	StartedResponse *RecordDecisionTaskStartedResponse    `json:"startedResponse,omitempty"`
+	ActivityTasks   []*shared.PollForActivityTaskResponse `json:"activityTasks,omitempty"`
+}
+
+type _List_PollForActivityTaskResponse_ValueList []*shared.PollForActivityTaskResponse
+
+func (v _List_PollForActivityTaskResponse_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_PollForActivityTaskResponse_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_PollForActivityTaskResponse_ValueList) ValueType() wire.Type {
+	return wire.TStruct
 }
 
+func (_List_PollForActivityTaskResponse_ValueList) Close() {}
+
 // ToWire translates a RespondDecisionTaskCompletedResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -6601,7 +7599,7 @@ type RespondDecisionTaskCompletedResponse struct {
 //   }
 func (v *RespondDecisionTaskCompletedResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [1]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -6615,6 +7613,14 @@ func (v *RespondDecisionTaskCompletedResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
+	if v.ActivityTasks != nil {
+		w, err = wire.NewValueList(_List_PollForActivityTaskResponse_ValueList(v.ActivityTasks)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -6625,6 +7631,30 @@ func _RecordDecisionTaskStartedResponse_Read(w wire.Value) (*RecordDecisionTaskS
 	return &v, err
 }
 
+func _PollForActivityTaskResponse_Read(w wire.Value) (*shared.PollForActivityTaskResponse, error) {
+	var v shared.PollForActivityTaskResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_PollForActivityTaskResponse_Read(l wire.ValueList) ([]*shared.PollForActivityTaskResponse, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*shared.PollForActivityTaskResponse, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _PollForActivityTaskResponse_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
 // FromWire deserializes a RespondDecisionTaskCompletedResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -6654,6 +7684,14 @@ func (v *RespondDecisionTaskCompletedResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 20:
+			if field.Value.Type() == wire.TList {
+				v.ActivityTasks, err = _List_PollForActivityTaskResponse_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -6668,16 +7706,35 @@ func (v *RespondDecisionTaskCompletedResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [1]string
+	var fields [2]string
 	i := 0
 	if v.StartedResponse != nil {
 		fields[i] = fmt.Sprintf("StartedResponse: %v", v.StartedResponse)
 		i++
 	}
+	if v.ActivityTasks != nil {
+		fields[i] = fmt.Sprintf("ActivityTasks: %v", v.ActivityTasks)
+		i++
+	}
 
 	return fmt.Sprintf("RespondDecisionTaskCompletedResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
+func _List_PollForActivityTaskResponse_Equals(lhs, rhs []*shared.PollForActivityTaskResponse) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this RespondDecisionTaskCompletedResponse match the
 // provided RespondDecisionTaskCompletedResponse.
 //
@@ -6691,10 +7748,24 @@ func (v *RespondDecisionTaskCompletedResponse) Equals(rhs *RespondDecisionTaskCo
 	if !((v.StartedResponse == nil && rhs.StartedResponse == nil) || (v.StartedResponse != nil && rhs.StartedResponse != nil && v.StartedResponse.Equals(rhs.StartedResponse))) {
 		return false
 	}
+	if !((v.ActivityTasks == nil && rhs.ActivityTasks == nil) || (v.ActivityTasks != nil && rhs.ActivityTasks != nil && _List_PollForActivityTaskResponse_Equals(v.ActivityTasks, rhs.ActivityTasks))) {
+		return false
+	}
 
 	return true
 }
 
+type _List_PollForActivityTaskResponse_Zapper []*shared.PollForActivityTaskResponse
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_PollForActivityTaskResponse_Zapper.
+func (l _List_PollForActivityTaskResponse_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of RespondDecisionTaskCompletedResponse.
 func (v *RespondDecisionTaskCompletedResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -6704,6 +7775,9 @@ func (v *RespondDecisionTaskCompletedResponse) MarshalLogObject(enc zapcore.Obje
 	if v.StartedResponse != nil {
 		err = multierr.Append(err, enc.AddObject("startedResponse", v.StartedResponse))
 	}
+	if v.ActivityTasks != nil {
+		err = multierr.Append(err, enc.AddArray("activityTasks", (_List_PollForActivityTaskResponse_Zapper)(v.ActivityTasks)))
+	}
 	return err
 }
 
@@ -6717,6 +7791,16 @@ func (v *RespondDecisionTaskCompletedResponse) GetStartedResponse() (o *RecordDe
 	return
 }
 
+// GetActivityTasks returns the value of ActivityTasks if it is set or its
+// zero value if it is unset.
+func (v *RespondDecisionTaskCompletedResponse) GetActivityTasks() (o []*shared.PollForActivityTaskResponse) {
+	if v.ActivityTasks != nil {
+		return v.ActivityTasks
+	}
+
+	return
+}
+
 type RespondDecisionTaskFailedRequest struct {
 	DomainUUID    *string                                  `json:"domainUUID,omitempty"`
 	FailedRequest *shared.RespondDecisionTaskFailedRequest `json:"failedRequest,omitempty"`
@@ -9078,3 +10162,179 @@ func (v *TerminateWorkflowExecutionRequest) GetTerminateRequest() (o *shared.Ter
 
 	return
 }
+
+type UnpauseWorkflowExecutionRequest struct {
+	DomainUUID     *string                                 `json:"domainUUID,omitempty"`
+	UnpauseRequest *shared.UnpauseWorkflowExecutionRequest `json:"unpauseRequest,omitempty"`
+}
+
+// ToWire translates a UnpauseWorkflowExecutionRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *UnpauseWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.UnpauseRequest != nil {
+		w, err = v.UnpauseRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _UnpauseWorkflowExecutionRequest_Read(w wire.Value) (*shared.UnpauseWorkflowExecutionRequest, error) {
+	var v shared.UnpauseWorkflowExecutionRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a UnpauseWorkflowExecutionRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a UnpauseWorkflowExecutionRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v UnpauseWorkflowExecutionRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *UnpauseWorkflowExecutionRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.UnpauseRequest, err = _UnpauseWorkflowExecutionRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a UnpauseWorkflowExecutionRequest
+// struct.
+func (v *UnpauseWorkflowExecutionRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.UnpauseRequest != nil {
+		fields[i] = fmt.Sprintf("UnpauseRequest: %v", v.UnpauseRequest)
+		i++
+	}
+
+	return fmt.Sprintf("UnpauseWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this UnpauseWorkflowExecutionRequest match the
+// provided UnpauseWorkflowExecutionRequest.
+//
+// This function performs a deep comparison.
+func (v *UnpauseWorkflowExecutionRequest) Equals(rhs *UnpauseWorkflowExecutionRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.UnpauseRequest == nil && rhs.UnpauseRequest == nil) || (v.UnpauseRequest != nil && rhs.UnpauseRequest != nil && v.UnpauseRequest.Equals(rhs.UnpauseRequest))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of UnpauseWorkflowExecutionRequest.
+func (v *UnpauseWorkflowExecutionRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.UnpauseRequest != nil {
+		err = multierr.Append(err, enc.AddObject("unpauseRequest", v.UnpauseRequest))
+	}
+	return err
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *UnpauseWorkflowExecutionRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// GetUnpauseRequest returns the value of UnpauseRequest if it is set or its
+// zero value if it is unset.
+func (v *UnpauseWorkflowExecutionRequest) GetUnpauseRequest() (o *shared.UnpauseWorkflowExecutionRequest) {
+	if v.UnpauseRequest != nil {
+		return v.UnpauseRequest
+	}
+
+	return
+}
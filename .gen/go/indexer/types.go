@@ -35,6 +35,329 @@ import (
 	"strings"
 )
 
+type DLQMessage struct {
+	Message     *Message `json:"message,omitempty"`
+	Status      *int32   `json:"status,omitempty"`
+	ErrorType   *string  `json:"errorType,omitempty"`
+	ErrorReason *string  `json:"errorReason,omitempty"`
+	Timestamp   *int64   `json:"timestamp,omitempty"`
+}
+
+// ToWire translates a DLQMessage struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DLQMessage) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Message != nil {
+		w, err = v.Message.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Status != nil {
+		w, err = wire.NewValueI32(*(v.Status)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.ErrorType != nil {
+		w, err = wire.NewValueString(*(v.ErrorType)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.ErrorReason != nil {
+		w, err = wire.NewValueString(*(v.ErrorReason)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.Timestamp != nil {
+		w, err = wire.NewValueI64(*(v.Timestamp)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _Message_Read(w wire.Value) (*Message, error) {
+	var v Message
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a DLQMessage struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DLQMessage struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DLQMessage
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DLQMessage) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TStruct {
+				v.Message, err = _Message_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Status = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ErrorType = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ErrorReason = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.Timestamp = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DLQMessage
+// struct.
+func (v *DLQMessage) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Message != nil {
+		fields[i] = fmt.Sprintf("Message: %v", v.Message)
+		i++
+	}
+	if v.Status != nil {
+		fields[i] = fmt.Sprintf("Status: %v", *(v.Status))
+		i++
+	}
+	if v.ErrorType != nil {
+		fields[i] = fmt.Sprintf("ErrorType: %v", *(v.ErrorType))
+		i++
+	}
+	if v.ErrorReason != nil {
+		fields[i] = fmt.Sprintf("ErrorReason: %v", *(v.ErrorReason))
+		i++
+	}
+	if v.Timestamp != nil {
+		fields[i] = fmt.Sprintf("Timestamp: %v", *(v.Timestamp))
+		i++
+	}
+
+	return fmt.Sprintf("DLQMessage{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _I32_EqualsPtr(lhs, rhs *int32) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _String_EqualsPtr(lhs, rhs *string) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _I64_EqualsPtr(lhs, rhs *int64) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this DLQMessage match the
+// provided DLQMessage.
+//
+// This function performs a deep comparison.
+func (v *DLQMessage) Equals(rhs *DLQMessage) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Message == nil && rhs.Message == nil) || (v.Message != nil && rhs.Message != nil && v.Message.Equals(rhs.Message))) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.Status, rhs.Status) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ErrorType, rhs.ErrorType) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ErrorReason, rhs.ErrorReason) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.Timestamp, rhs.Timestamp) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of DLQMessage.
+func (v *DLQMessage) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Message != nil {
+		err = multierr.Append(err, enc.AddObject("message", v.Message))
+	}
+	if v.Status != nil {
+		enc.AddInt32("status", *v.Status)
+	}
+	if v.ErrorType != nil {
+		enc.AddString("errorType", *v.ErrorType)
+	}
+	if v.ErrorReason != nil {
+		enc.AddString("errorReason", *v.ErrorReason)
+	}
+	if v.Timestamp != nil {
+		enc.AddInt64("timestamp", *v.Timestamp)
+	}
+	return err
+}
+
+// GetMessage returns the value of Message if it is set or its
+// zero value if it is unset.
+func (v *DLQMessage) GetMessage() (o *Message) {
+	if v.Message != nil {
+		return v.Message
+	}
+
+	return
+}
+
+// GetStatus returns the value of Status if it is set or its
+// zero value if it is unset.
+func (v *DLQMessage) GetStatus() (o int32) {
+	if v.Status != nil {
+		return *v.Status
+	}
+
+	return
+}
+
+// GetErrorType returns the value of ErrorType if it is set or its
+// zero value if it is unset.
+func (v *DLQMessage) GetErrorType() (o string) {
+	if v.ErrorType != nil {
+		return *v.ErrorType
+	}
+
+	return
+}
+
+// GetErrorReason returns the value of ErrorReason if it is set or its
+// zero value if it is unset.
+func (v *DLQMessage) GetErrorReason() (o string) {
+	if v.ErrorReason != nil {
+		return *v.ErrorReason
+	}
+
+	return
+}
+
+// GetTimestamp returns the value of Timestamp if it is set or its
+// zero value if it is unset.
+func (v *DLQMessage) GetTimestamp() (o int64) {
+	if v.Timestamp != nil {
+		return *v.Timestamp
+	}
+
+	return
+}
+
 type Field struct {
 	Type       *FieldType `json:"type,omitempty"`
 	StringData *string    `json:"stringData,omitempty"`
@@ -214,26 +537,6 @@ func _FieldType_EqualsPtr(lhs, rhs *FieldType) bool {
 	return lhs == nil && rhs == nil
 }
 
-func _String_EqualsPtr(lhs, rhs *string) bool {
-	if lhs != nil && rhs != nil {
-
-		x := *lhs
-		y := *rhs
-		return (x == y)
-	}
-	return lhs == nil && rhs == nil
-}
-
-func _I64_EqualsPtr(lhs, rhs *int64) bool {
-	if lhs != nil && rhs != nil {
-
-		x := *lhs
-		y := *rhs
-		return (x == y)
-	}
-	return lhs == nil && rhs == nil
-}
-
 func _Bool_EqualsPtr(lhs, rhs *bool) bool {
 	if lhs != nil && rhs != nil {
 
@@ -403,36 +403,6 @@ func (v *MatchingService_AddActivityTask_Result) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _BadRequestError_Read(w wire.Value) (*shared.BadRequestError, error) {
-	var v shared.BadRequestError
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _InternalServiceError_Read(w wire.Value) (*shared.InternalServiceError, error) {
-	var v shared.InternalServiceError
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _ServiceBusyError_Read(w wire.Value) (*shared.ServiceBusyError, error) {
-	var v shared.ServiceBusyError
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _LimitExceededError_Read(w wire.Value) (*shared.LimitExceededError, error) {
-	var v shared.LimitExceededError
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _DomainNotActiveError_Read(w wire.Value) (*shared.DomainNotActiveError, error) {
-	var v shared.DomainNotActiveError
-	err := v.FromWire(w)
-	return &v, err
-}
-
 // FromWire deserializes a MatchingService_AddActivityTask_Result struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
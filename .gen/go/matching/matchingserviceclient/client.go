@@ -36,6 +36,12 @@ import (
 
 // Interface is a client for the MatchingService service.
 type Interface interface {
+	AddActivityCancellation(
+		ctx context.Context,
+		AddRequest *matching.AddActivityCancellationRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	AddActivityTask(
 		ctx context.Context,
 		AddRequest *matching.AddActivityTaskRequest,
@@ -60,6 +66,12 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*shared.DescribeTaskListResponse, error)
 
+	PollForActivityCancellation(
+		ctx context.Context,
+		PollRequest *matching.PollForActivityCancellationRequest,
+		opts ...yarpc.CallOption,
+	) (*shared.PollForActivityCancellationResponse, error)
+
 	PollForActivityTask(
 		ctx context.Context,
 		PollRequest *matching.PollForActivityTaskRequest,
@@ -72,6 +84,12 @@ type Interface interface {
 		opts ...yarpc.CallOption,
 	) (*matching.PollForDecisionTaskResponse, error)
 
+	PromoteTaskListBuildID(
+		ctx context.Context,
+		Request *matching.PromoteTaskListBuildIDRequest,
+		opts ...yarpc.CallOption,
+	) error
+
 	QueryWorkflow(
 		ctx context.Context,
 		QueryRequest *matching.QueryWorkflowRequest,
@@ -109,6 +127,29 @@ type client struct {
 	c thrift.Client
 }
 
+func (c client) AddActivityCancellation(
+	ctx context.Context,
+	_AddRequest *matching.AddActivityCancellationRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := matching.MatchingService_AddActivityCancellation_Helper.Args(_AddRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result matching.MatchingService_AddActivityCancellation_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = matching.MatchingService_AddActivityCancellation_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) AddActivityTask(
 	ctx context.Context,
 	_AddRequest *matching.AddActivityTaskRequest,
@@ -201,6 +242,29 @@ func (c client) DescribeTaskList(
 	return
 }
 
+func (c client) PollForActivityCancellation(
+	ctx context.Context,
+	_PollRequest *matching.PollForActivityCancellationRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.PollForActivityCancellationResponse, err error) {
+
+	args := matching.MatchingService_PollForActivityCancellation_Helper.Args(_PollRequest)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result matching.MatchingService_PollForActivityCancellation_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	success, err = matching.MatchingService_PollForActivityCancellation_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) PollForActivityTask(
 	ctx context.Context,
 	_PollRequest *matching.PollForActivityTaskRequest,
@@ -247,6 +311,29 @@ func (c client) PollForDecisionTask(
 	return
 }
 
+func (c client) PromoteTaskListBuildID(
+	ctx context.Context,
+	_Request *matching.PromoteTaskListBuildIDRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := matching.MatchingService_PromoteTaskListBuildID_Helper.Args(_Request)
+
+	var body wire.Value
+	body, err = c.c.Call(ctx, args, opts...)
+	if err != nil {
+		return
+	}
+
+	var result matching.MatchingService_PromoteTaskListBuildID_Result
+	if err = result.FromWire(body); err != nil {
+		return
+	}
+
+	err = matching.MatchingService_PromoteTaskListBuildID_Helper.UnwrapResponse(&result)
+	return
+}
+
 func (c client) QueryWorkflow(
 	ctx context.Context,
 	_QueryRequest *matching.QueryWorkflowRequest,
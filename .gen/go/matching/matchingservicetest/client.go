@@ -63,6 +63,37 @@ func (m *MockClient) EXPECT() *_MockClientRecorder {
 	return m.recorder
 }
 
+// AddActivityCancellation responds to a AddActivityCancellation call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().AddActivityCancellation(gomock.Any(), ...).Return(...)
+// 	... := client.AddActivityCancellation(...)
+func (m *MockClient) AddActivityCancellation(
+	ctx context.Context,
+	_AddRequest *matching.AddActivityCancellationRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _AddRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "AddActivityCancellation", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) AddActivityCancellation(
+	ctx interface{},
+	_AddRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _AddRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "AddActivityCancellation", args...)
+}
+
 // AddActivityTask responds to a AddActivityTask call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -189,6 +220,39 @@ func (mr *_MockClientRecorder) DescribeTaskList(
 	return mr.mock.ctrl.RecordCall(mr.mock, "DescribeTaskList", args...)
 }
 
+// PollForActivityCancellation responds to a PollForActivityCancellation call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().PollForActivityCancellation(gomock.Any(), ...).Return(...)
+// 	... := client.PollForActivityCancellation(...)
+func (m *MockClient) PollForActivityCancellation(
+	ctx context.Context,
+	_PollRequest *matching.PollForActivityCancellationRequest,
+	opts ...yarpc.CallOption,
+) (success *shared.PollForActivityCancellationResponse, err error) {
+
+	args := []interface{}{ctx, _PollRequest}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "PollForActivityCancellation", args...)
+	success, _ = ret[i].(*shared.PollForActivityCancellationResponse)
+	i++
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) PollForActivityCancellation(
+	ctx interface{},
+	_PollRequest interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _PollRequest}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "PollForActivityCancellation", args...)
+}
+
 // PollForActivityTask responds to a PollForActivityTask call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
@@ -255,6 +319,37 @@ func (mr *_MockClientRecorder) PollForDecisionTask(
 	return mr.mock.ctrl.RecordCall(mr.mock, "PollForDecisionTask", args...)
 }
 
+// PromoteTaskListBuildID responds to a PromoteTaskListBuildID call based on the mock expectations. This
+// call will fail if the mock does not expect this call. Use EXPECT to expect
+// a call to this function.
+//
+// 	client.EXPECT().PromoteTaskListBuildID(gomock.Any(), ...).Return(...)
+// 	... := client.PromoteTaskListBuildID(...)
+func (m *MockClient) PromoteTaskListBuildID(
+	ctx context.Context,
+	_Request *matching.PromoteTaskListBuildIDRequest,
+	opts ...yarpc.CallOption,
+) (err error) {
+
+	args := []interface{}{ctx, _Request}
+	for _, o := range opts {
+		args = append(args, o)
+	}
+	i := 0
+	ret := m.ctrl.Call(m, "PromoteTaskListBuildID", args...)
+	err, _ = ret[i].(error)
+	return
+}
+
+func (mr *_MockClientRecorder) PromoteTaskListBuildID(
+	ctx interface{},
+	_Request interface{},
+	opts ...interface{},
+) *gomock.Call {
+	args := append([]interface{}{ctx, _Request}, opts...)
+	return mr.mock.ctrl.RecordCall(mr.mock, "PromoteTaskListBuildID", args...)
+}
+
 // QueryWorkflow responds to a QueryWorkflow call based on the mock expectations. This
 // call will fail if the mock does not expect this call. Use EXPECT to expect
 // a call to this function.
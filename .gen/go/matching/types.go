@@ -34,6 +34,350 @@ import (
 	"strings"
 )
 
+type AddActivityCancellationRequest struct {
+	DomainUUID *string                   `json:"domainUUID,omitempty"`
+	Execution  *shared.WorkflowExecution `json:"execution,omitempty"`
+	TaskList   *shared.TaskList          `json:"taskList,omitempty"`
+	Identity   *string                   `json:"identity,omitempty"`
+	TaskToken  []byte                    `json:"taskToken,omitempty"`
+	ActivityId *string                   `json:"activityId,omitempty"`
+}
+
+// ToWire translates a AddActivityCancellationRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *AddActivityCancellationRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [6]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Execution != nil {
+		w, err = v.Execution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.TaskList != nil {
+		w, err = v.TaskList.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.TaskToken != nil {
+		w, err = wire.NewValueBinary(v.TaskToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.ActivityId != nil {
+		w, err = wire.NewValueString(*(v.ActivityId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _WorkflowExecution_Read(w wire.Value) (*shared.WorkflowExecution, error) {
+	var v shared.WorkflowExecution
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _TaskList_Read(w wire.Value) (*shared.TaskList, error) {
+	var v shared.TaskList
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a AddActivityCancellationRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a AddActivityCancellationRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v AddActivityCancellationRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *AddActivityCancellationRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.Execution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TStruct {
+				v.TaskList, err = _TaskList_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBinary {
+				v.TaskToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ActivityId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a AddActivityCancellationRequest
+// struct.
+func (v *AddActivityCancellationRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [6]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.Execution != nil {
+		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
+		i++
+	}
+	if v.TaskList != nil {
+		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
+		i++
+	}
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+		i++
+	}
+	if v.TaskToken != nil {
+		fields[i] = fmt.Sprintf("TaskToken: %v", v.TaskToken)
+		i++
+	}
+	if v.ActivityId != nil {
+		fields[i] = fmt.Sprintf("ActivityId: %v", *(v.ActivityId))
+		i++
+	}
+
+	return fmt.Sprintf("AddActivityCancellationRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _String_EqualsPtr(lhs, rhs *string) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return (x == y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this AddActivityCancellationRequest match the
+// provided AddActivityCancellationRequest.
+//
+// This function performs a deep comparison.
+func (v *AddActivityCancellationRequest) Equals(rhs *AddActivityCancellationRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.Execution == nil && rhs.Execution == nil) || (v.Execution != nil && rhs.Execution != nil && v.Execution.Equals(rhs.Execution))) {
+		return false
+	}
+	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+		return false
+	}
+	if !((v.TaskToken == nil && rhs.TaskToken == nil) || (v.TaskToken != nil && rhs.TaskToken != nil && bytes.Equal(v.TaskToken, rhs.TaskToken))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ActivityId, rhs.ActivityId) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of AddActivityCancellationRequest.
+func (v *AddActivityCancellationRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.Execution != nil {
+		err = multierr.Append(err, enc.AddObject("execution", v.Execution))
+	}
+	if v.TaskList != nil {
+		err = multierr.Append(err, enc.AddObject("taskList", v.TaskList))
+	}
+	if v.Identity != nil {
+		enc.AddString("identity", *v.Identity)
+	}
+	if v.TaskToken != nil {
+		enc.AddString("taskToken", base64.StdEncoding.EncodeToString(v.TaskToken))
+	}
+	if v.ActivityId != nil {
+		enc.AddString("activityId", *v.ActivityId)
+	}
+	return err
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *AddActivityCancellationRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// GetExecution returns the value of Execution if it is set or its
+// zero value if it is unset.
+func (v *AddActivityCancellationRequest) GetExecution() (o *shared.WorkflowExecution) {
+	if v.Execution != nil {
+		return v.Execution
+	}
+
+	return
+}
+
+// GetTaskList returns the value of TaskList if it is set or its
+// zero value if it is unset.
+func (v *AddActivityCancellationRequest) GetTaskList() (o *shared.TaskList) {
+	if v.TaskList != nil {
+		return v.TaskList
+	}
+
+	return
+}
+
+// GetIdentity returns the value of Identity if it is set or its
+// zero value if it is unset.
+func (v *AddActivityCancellationRequest) GetIdentity() (o string) {
+	if v.Identity != nil {
+		return *v.Identity
+	}
+
+	return
+}
+
+// GetTaskToken returns the value of TaskToken if it is set or its
+// zero value if it is unset.
+func (v *AddActivityCancellationRequest) GetTaskToken() (o []byte) {
+	if v.TaskToken != nil {
+		return v.TaskToken
+	}
+
+	return
+}
+
+// GetActivityId returns the value of ActivityId if it is set or its
+// zero value if it is unset.
+func (v *AddActivityCancellationRequest) GetActivityId() (o string) {
+	if v.ActivityId != nil {
+		return *v.ActivityId
+	}
+
+	return
+}
+
 type AddActivityTaskRequest struct {
 	DomainUUID                    *string                   `json:"domainUUID,omitempty"`
 	Execution                     *shared.WorkflowExecution `json:"execution,omitempty"`
@@ -41,6 +385,7 @@ type AddActivityTaskRequest struct {
 	TaskList                      *shared.TaskList          `json:"taskList,omitempty"`
 	ScheduleId                    *int64                    `json:"scheduleId,omitempty"`
 	ScheduleToStartTimeoutSeconds *int32                    `json:"scheduleToStartTimeoutSeconds,omitempty"`
+	Priority                      *int32                    `json:"priority,omitempty"`
 }
 
 // ToWire translates a AddActivityTaskRequest struct into a Thrift-level intermediate
@@ -60,7 +405,7 @@ type AddActivityTaskRequest struct {
 //   }
 func (v *AddActivityTaskRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -114,22 +459,18 @@ func (v *AddActivityTaskRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 60, Value: w}
 		i++
 	}
+	if v.Priority != nil {
+		w, err = wire.NewValueI32(*(v.Priority)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _WorkflowExecution_Read(w wire.Value) (*shared.WorkflowExecution, error) {
-	var v shared.WorkflowExecution
-	err := v.FromWire(w)
-	return &v, err
-}
-
-func _TaskList_Read(w wire.Value) (*shared.TaskList, error) {
-	var v shared.TaskList
-	err := v.FromWire(w)
-	return &v, err
-}
-
 // FromWire deserializes a AddActivityTaskRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -207,6 +548,16 @@ func (v *AddActivityTaskRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 70:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Priority = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -221,7 +572,7 @@ func (v *AddActivityTaskRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [7]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
@@ -247,18 +598,12 @@ func (v *AddActivityTaskRequest) String() string {
 		fields[i] = fmt.Sprintf("ScheduleToStartTimeoutSeconds: %v", *(v.ScheduleToStartTimeoutSeconds))
 		i++
 	}
-
-	return fmt.Sprintf("AddActivityTaskRequest{%v}", strings.Join(fields[:i], ", "))
-}
-
-func _String_EqualsPtr(lhs, rhs *string) bool {
-	if lhs != nil && rhs != nil {
-
-		x := *lhs
-		y := *rhs
-		return (x == y)
+	if v.Priority != nil {
+		fields[i] = fmt.Sprintf("Priority: %v", *(v.Priority))
+		i++
 	}
-	return lhs == nil && rhs == nil
+
+	return fmt.Sprintf("AddActivityTaskRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
 func _I64_EqualsPtr(lhs, rhs *int64) bool {
@@ -309,6 +654,9 @@ func (v *AddActivityTaskRequest) Equals(rhs *AddActivityTaskRequest) bool {
 	if !_I32_EqualsPtr(v.ScheduleToStartTimeoutSeconds, rhs.ScheduleToStartTimeoutSeconds) {
 		return false
 	}
+	if !_I32_EqualsPtr(v.Priority, rhs.Priority) {
+		return false
+	}
 
 	return true
 }
@@ -337,6 +685,9 @@ func (v *AddActivityTaskRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (er
 	if v.ScheduleToStartTimeoutSeconds != nil {
 		enc.AddInt32("scheduleToStartTimeoutSeconds", *v.ScheduleToStartTimeoutSeconds)
 	}
+	if v.Priority != nil {
+		enc.AddInt32("priority", *v.Priority)
+	}
 	return err
 }
 
@@ -400,12 +751,24 @@ func (v *AddActivityTaskRequest) GetScheduleToStartTimeoutSeconds() (o int32) {
 	return
 }
 
+// GetPriority returns the value of Priority if it is set or its
+// zero value if it is unset.
+func (v *AddActivityTaskRequest) GetPriority() (o int32) {
+	if v.Priority != nil {
+		return *v.Priority
+	}
+
+	return
+}
+
 type AddDecisionTaskRequest struct {
 	DomainUUID                    *string                   `json:"domainUUID,omitempty"`
 	Execution                     *shared.WorkflowExecution `json:"execution,omitempty"`
 	TaskList                      *shared.TaskList          `json:"taskList,omitempty"`
 	ScheduleId                    *int64                    `json:"scheduleId,omitempty"`
 	ScheduleToStartTimeoutSeconds *int32                    `json:"scheduleToStartTimeoutSeconds,omitempty"`
+	Priority                      *int32                    `json:"priority,omitempty"`
+	BuildID                       *string                   `json:"buildID,omitempty"`
 }
 
 // ToWire translates a AddDecisionTaskRequest struct into a Thrift-level intermediate
@@ -425,7 +788,7 @@ type AddDecisionTaskRequest struct {
 //   }
 func (v *AddDecisionTaskRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [5]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -471,6 +834,22 @@ func (v *AddDecisionTaskRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 50, Value: w}
 		i++
 	}
+	if v.Priority != nil {
+		w, err = wire.NewValueI32(*(v.Priority)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+	if v.BuildID != nil {
+		w, err = wire.NewValueString(*(v.BuildID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -542,6 +921,26 @@ func (v *AddDecisionTaskRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 60:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Priority = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 70:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.BuildID = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -556,7 +955,7 @@ func (v *AddDecisionTaskRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [5]string
+	var fields [7]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
@@ -578,6 +977,14 @@ func (v *AddDecisionTaskRequest) String() string {
 		fields[i] = fmt.Sprintf("ScheduleToStartTimeoutSeconds: %v", *(v.ScheduleToStartTimeoutSeconds))
 		i++
 	}
+	if v.Priority != nil {
+		fields[i] = fmt.Sprintf("Priority: %v", *(v.Priority))
+		i++
+	}
+	if v.BuildID != nil {
+		fields[i] = fmt.Sprintf("BuildID: %v", *(v.BuildID))
+		i++
+	}
 
 	return fmt.Sprintf("AddDecisionTaskRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -607,6 +1014,12 @@ func (v *AddDecisionTaskRequest) Equals(rhs *AddDecisionTaskRequest) bool {
 	if !_I32_EqualsPtr(v.ScheduleToStartTimeoutSeconds, rhs.ScheduleToStartTimeoutSeconds) {
 		return false
 	}
+	if !_I32_EqualsPtr(v.Priority, rhs.Priority) {
+		return false
+	}
+	if !_String_EqualsPtr(v.BuildID, rhs.BuildID) {
+		return false
+	}
 
 	return true
 }
@@ -632,6 +1045,12 @@ func (v *AddDecisionTaskRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (er
 	if v.ScheduleToStartTimeoutSeconds != nil {
 		enc.AddInt32("scheduleToStartTimeoutSeconds", *v.ScheduleToStartTimeoutSeconds)
 	}
+	if v.Priority != nil {
+		enc.AddInt32("priority", *v.Priority)
+	}
+	if v.BuildID != nil {
+		enc.AddString("buildID", *v.BuildID)
+	}
 	return err
 }
 
@@ -685,6 +1104,26 @@ func (v *AddDecisionTaskRequest) GetScheduleToStartTimeoutSeconds() (o int32) {
 	return
 }
 
+// GetPriority returns the value of Priority if it is set or its
+// zero value if it is unset.
+func (v *AddDecisionTaskRequest) GetPriority() (o int32) {
+	if v.Priority != nil {
+		return *v.Priority
+	}
+
+	return
+}
+
+// GetBuildID returns the value of BuildID if it is set or its
+// zero value if it is unset.
+func (v *AddDecisionTaskRequest) GetBuildID() (o string) {
+	if v.BuildID != nil {
+		return *v.BuildID
+	}
+
+	return
+}
+
 type CancelOutstandingPollRequest struct {
 	DomainUUID   *string          `json:"domainUUID,omitempty"`
 	TaskListType *int32           `json:"taskListType,omitempty"`
@@ -1089,21 +1528,236 @@ func (v *DescribeTaskListRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (e
 	return err
 }
 
-// GetDomainUUID returns the value of DomainUUID if it is set or its
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *DescribeTaskListRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// GetDescRequest returns the value of DescRequest if it is set or its
+// zero value if it is unset.
+func (v *DescribeTaskListRequest) GetDescRequest() (o *shared.DescribeTaskListRequest) {
+	if v.DescRequest != nil {
+		return v.DescRequest
+	}
+
+	return
+}
+
+type PollForActivityCancellationRequest struct {
+	DomainUUID  *string                                    `json:"domainUUID,omitempty"`
+	PollerID    *string                                    `json:"pollerID,omitempty"`
+	PollRequest *shared.PollForActivityCancellationRequest `json:"pollRequest,omitempty"`
+}
+
+// ToWire translates a PollForActivityCancellationRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *PollForActivityCancellationRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.PollerID != nil {
+		w, err = wire.NewValueString(*(v.PollerID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 15, Value: w}
+		i++
+	}
+	if v.PollRequest != nil {
+		w, err = v.PollRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _PollForActivityCancellationRequest_Read(w wire.Value) (*shared.PollForActivityCancellationRequest, error) {
+	var v shared.PollForActivityCancellationRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a PollForActivityCancellationRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a PollForActivityCancellationRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v PollForActivityCancellationRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *PollForActivityCancellationRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 15:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.PollerID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.PollRequest, err = _PollForActivityCancellationRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a PollForActivityCancellationRequest
+// struct.
+func (v *PollForActivityCancellationRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.PollerID != nil {
+		fields[i] = fmt.Sprintf("PollerID: %v", *(v.PollerID))
+		i++
+	}
+	if v.PollRequest != nil {
+		fields[i] = fmt.Sprintf("PollRequest: %v", v.PollRequest)
+		i++
+	}
+
+	return fmt.Sprintf("PollForActivityCancellationRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this PollForActivityCancellationRequest match the
+// provided PollForActivityCancellationRequest.
+//
+// This function performs a deep comparison.
+func (v *PollForActivityCancellationRequest) Equals(rhs *PollForActivityCancellationRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !_String_EqualsPtr(v.PollerID, rhs.PollerID) {
+		return false
+	}
+	if !((v.PollRequest == nil && rhs.PollRequest == nil) || (v.PollRequest != nil && rhs.PollRequest != nil && v.PollRequest.Equals(rhs.PollRequest))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of PollForActivityCancellationRequest.
+func (v *PollForActivityCancellationRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.PollerID != nil {
+		enc.AddString("pollerID", *v.PollerID)
+	}
+	if v.PollRequest != nil {
+		err = multierr.Append(err, enc.AddObject("pollRequest", v.PollRequest))
+	}
+	return err
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *PollForActivityCancellationRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// GetPollerID returns the value of PollerID if it is set or its
 // zero value if it is unset.
-func (v *DescribeTaskListRequest) GetDomainUUID() (o string) {
-	if v.DomainUUID != nil {
-		return *v.DomainUUID
+func (v *PollForActivityCancellationRequest) GetPollerID() (o string) {
+	if v.PollerID != nil {
+		return *v.PollerID
 	}
 
 	return
 }
 
-// GetDescRequest returns the value of DescRequest if it is set or its
+// GetPollRequest returns the value of PollRequest if it is set or its
 // zero value if it is unset.
-func (v *DescribeTaskListRequest) GetDescRequest() (o *shared.DescribeTaskListRequest) {
-	if v.DescRequest != nil {
-		return v.DescRequest
+func (v *PollForActivityCancellationRequest) GetPollRequest() (o *shared.PollForActivityCancellationRequest) {
+	if v.PollRequest != nil {
+		return v.PollRequest
 	}
 
 	return
@@ -1540,22 +2194,61 @@ func (v *PollForDecisionTaskRequest) GetPollRequest() (o *shared.PollForDecision
 }
 
 type PollForDecisionTaskResponse struct {
-	TaskToken                 []byte                        `json:"taskToken,omitempty"`
-	WorkflowExecution         *shared.WorkflowExecution     `json:"workflowExecution,omitempty"`
-	WorkflowType              *shared.WorkflowType          `json:"workflowType,omitempty"`
-	PreviousStartedEventId    *int64                        `json:"previousStartedEventId,omitempty"`
-	StartedEventId            *int64                        `json:"startedEventId,omitempty"`
-	Attempt                   *int64                        `json:"attempt,omitempty"`
-	NextEventId               *int64                        `json:"nextEventId,omitempty"`
-	BacklogCountHint          *int64                        `json:"backlogCountHint,omitempty"`
-	StickyExecutionEnabled    *bool                         `json:"stickyExecutionEnabled,omitempty"`
-	Query                     *shared.WorkflowQuery         `json:"query,omitempty"`
-	DecisionInfo              *shared.TransientDecisionInfo `json:"decisionInfo,omitempty"`
-	WorkflowExecutionTaskList *shared.TaskList              `json:"WorkflowExecutionTaskList,omitempty"`
-	EventStoreVersion         *int32                        `json:"eventStoreVersion,omitempty"`
-	BranchToken               []byte                        `json:"branchToken,omitempty"`
+	TaskToken                 []byte                           `json:"taskToken,omitempty"`
+	WorkflowExecution         *shared.WorkflowExecution        `json:"workflowExecution,omitempty"`
+	WorkflowType              *shared.WorkflowType             `json:"workflowType,omitempty"`
+	PreviousStartedEventId    *int64                           `json:"previousStartedEventId,omitempty"`
+	StartedEventId            *int64                           `json:"startedEventId,omitempty"`
+	Attempt                   *int64                           `json:"attempt,omitempty"`
+	NextEventId               *int64                           `json:"nextEventId,omitempty"`
+	BacklogCountHint          *int64                           `json:"backlogCountHint,omitempty"`
+	StickyExecutionEnabled    *bool                            `json:"stickyExecutionEnabled,omitempty"`
+	Query                     *shared.WorkflowQuery            `json:"query,omitempty"`
+	DecisionInfo              *shared.TransientDecisionInfo    `json:"decisionInfo,omitempty"`
+	WorkflowExecutionTaskList *shared.TaskList                 `json:"WorkflowExecutionTaskList,omitempty"`
+	EventStoreVersion         *int32                           `json:"eventStoreVersion,omitempty"`
+	BranchToken               []byte                           `json:"branchToken,omitempty"`
+	Queries                   map[string]*shared.WorkflowQuery `json:"queries,omitempty"`
+}
+
+type _Map_String_WorkflowQuery_MapItemList map[string]*shared.WorkflowQuery
+
+func (m _Map_String_WorkflowQuery_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_WorkflowQuery_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_WorkflowQuery_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_WorkflowQuery_MapItemList) ValueType() wire.Type {
+	return wire.TStruct
 }
 
+func (_Map_String_WorkflowQuery_MapItemList) Close() {}
+
 // ToWire translates a PollForDecisionTaskResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -1573,7 +2266,7 @@ type PollForDecisionTaskResponse struct {
 //   }
 func (v *PollForDecisionTaskResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [14]wire.Field
+		fields [15]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -1691,6 +2384,14 @@ func (v *PollForDecisionTaskResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 120, Value: w}
 		i++
 	}
+	if v.Queries != nil {
+		w, err = wire.NewValueMap(_Map_String_WorkflowQuery_MapItemList(v.Queries)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 130, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -1713,6 +2414,34 @@ func _TransientDecisionInfo_Read(w wire.Value) (*shared.TransientDecisionInfo, e
 	return &v, err
 }
 
+func _Map_String_WorkflowQuery_Read(m wire.MapItemList) (map[string]*shared.WorkflowQuery, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make(map[string]*shared.WorkflowQuery, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := _WorkflowQuery_Read(x.Value)
+		if err != nil {
+			return err
+		}
+
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
 // FromWire deserializes a PollForDecisionTaskResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -1860,6 +2589,14 @@ func (v *PollForDecisionTaskResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 130:
+			if field.Value.Type() == wire.TMap {
+				v.Queries, err = _Map_String_WorkflowQuery_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -1874,7 +2611,7 @@ func (v *PollForDecisionTaskResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [14]string
+	var fields [15]string
 	i := 0
 	if v.TaskToken != nil {
 		fields[i] = fmt.Sprintf("TaskToken: %v", v.TaskToken)
@@ -1932,6 +2669,10 @@ func (v *PollForDecisionTaskResponse) String() string {
 		fields[i] = fmt.Sprintf("BranchToken: %v", v.BranchToken)
 		i++
 	}
+	if v.Queries != nil {
+		fields[i] = fmt.Sprintf("Queries: %v", v.Queries)
+		i++
+	}
 
 	return fmt.Sprintf("PollForDecisionTaskResponse{%v}", strings.Join(fields[:i], ", "))
 }
@@ -1946,6 +2687,23 @@ func _Bool_EqualsPtr(lhs, rhs *bool) bool {
 	return lhs == nil && rhs == nil
 }
 
+func _Map_String_WorkflowQuery_Equals(lhs, rhs map[string]*shared.WorkflowQuery) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
 // Equals returns true if all the fields of this PollForDecisionTaskResponse match the
 // provided PollForDecisionTaskResponse.
 //
@@ -1998,10 +2756,24 @@ func (v *PollForDecisionTaskResponse) Equals(rhs *PollForDecisionTaskResponse) b
 	if !((v.BranchToken == nil && rhs.BranchToken == nil) || (v.BranchToken != nil && rhs.BranchToken != nil && bytes.Equal(v.BranchToken, rhs.BranchToken))) {
 		return false
 	}
+	if !((v.Queries == nil && rhs.Queries == nil) || (v.Queries != nil && rhs.Queries != nil && _Map_String_WorkflowQuery_Equals(v.Queries, rhs.Queries))) {
+		return false
+	}
 
 	return true
 }
 
+type _Map_String_WorkflowQuery_Zapper map[string]*shared.WorkflowQuery
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of _Map_String_WorkflowQuery_Zapper.
+func (m _Map_String_WorkflowQuery_Zapper) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	for k, v := range m {
+		err = multierr.Append(err, enc.AddObject((string)(k), v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of PollForDecisionTaskResponse.
 func (v *PollForDecisionTaskResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -2050,6 +2822,9 @@ func (v *PollForDecisionTaskResponse) MarshalLogObject(enc zapcore.ObjectEncoder
 	if v.BranchToken != nil {
 		enc.AddString("branchToken", base64.StdEncoding.EncodeToString(v.BranchToken))
 	}
+	if v.Queries != nil {
+		err = multierr.Append(err, enc.AddObject("queries", (_Map_String_WorkflowQuery_Zapper)(v.Queries)))
+	}
 	return err
 }
 
@@ -2193,10 +2968,197 @@ func (v *PollForDecisionTaskResponse) GetBranchToken() (o []byte) {
 	return
 }
 
+// GetQueries returns the value of Queries if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetQueries() (o map[string]*shared.WorkflowQuery) {
+	if v.Queries != nil {
+		return v.Queries
+	}
+
+	return
+}
+
+type PromoteTaskListBuildIDRequest struct {
+	DomainUUID     *string                               `json:"domainUUID,omitempty"`
+	PromoteRequest *shared.PromoteTaskListBuildIDRequest `json:"promoteRequest,omitempty"`
+}
+
+// ToWire translates a PromoteTaskListBuildIDRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *PromoteTaskListBuildIDRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.DomainUUID != nil {
+		w, err = wire.NewValueString(*(v.DomainUUID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.PromoteRequest != nil {
+		w, err = v.PromoteRequest.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _PromoteTaskListBuildIDRequest_Read(w wire.Value) (*shared.PromoteTaskListBuildIDRequest, error) {
+	var v shared.PromoteTaskListBuildIDRequest
+	err := v.FromWire(w)
+	return &v, err
+}
+
+// FromWire deserializes a PromoteTaskListBuildIDRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a PromoteTaskListBuildIDRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v PromoteTaskListBuildIDRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *PromoteTaskListBuildIDRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.DomainUUID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.PromoteRequest, err = _PromoteTaskListBuildIDRequest_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a PromoteTaskListBuildIDRequest
+// struct.
+func (v *PromoteTaskListBuildIDRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.DomainUUID != nil {
+		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
+		i++
+	}
+	if v.PromoteRequest != nil {
+		fields[i] = fmt.Sprintf("PromoteRequest: %v", v.PromoteRequest)
+		i++
+	}
+
+	return fmt.Sprintf("PromoteTaskListBuildIDRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this PromoteTaskListBuildIDRequest match the
+// provided PromoteTaskListBuildIDRequest.
+//
+// This function performs a deep comparison.
+func (v *PromoteTaskListBuildIDRequest) Equals(rhs *PromoteTaskListBuildIDRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.DomainUUID, rhs.DomainUUID) {
+		return false
+	}
+	if !((v.PromoteRequest == nil && rhs.PromoteRequest == nil) || (v.PromoteRequest != nil && rhs.PromoteRequest != nil && v.PromoteRequest.Equals(rhs.PromoteRequest))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of PromoteTaskListBuildIDRequest.
+func (v *PromoteTaskListBuildIDRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.DomainUUID != nil {
+		enc.AddString("domainUUID", *v.DomainUUID)
+	}
+	if v.PromoteRequest != nil {
+		err = multierr.Append(err, enc.AddObject("promoteRequest", v.PromoteRequest))
+	}
+	return err
+}
+
+// GetDomainUUID returns the value of DomainUUID if it is set or its
+// zero value if it is unset.
+func (v *PromoteTaskListBuildIDRequest) GetDomainUUID() (o string) {
+	if v.DomainUUID != nil {
+		return *v.DomainUUID
+	}
+
+	return
+}
+
+// GetPromoteRequest returns the value of PromoteRequest if it is set or its
+// zero value if it is unset.
+func (v *PromoteTaskListBuildIDRequest) GetPromoteRequest() (o *shared.PromoteTaskListBuildIDRequest) {
+	if v.PromoteRequest != nil {
+		return v.PromoteRequest
+	}
+
+	return
+}
+
 type QueryWorkflowRequest struct {
 	DomainUUID   *string                      `json:"domainUUID,omitempty"`
 	TaskList     *shared.TaskList             `json:"taskList,omitempty"`
 	QueryRequest *shared.QueryWorkflowRequest `json:"queryRequest,omitempty"`
+	BuildID      *string                      `json:"buildID,omitempty"`
 }
 
 // ToWire translates a QueryWorkflowRequest struct into a Thrift-level intermediate
@@ -2216,7 +3178,7 @@ type QueryWorkflowRequest struct {
 //   }
 func (v *QueryWorkflowRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -2246,6 +3208,14 @@ func (v *QueryWorkflowRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
+	if v.BuildID != nil {
+		w, err = wire.NewValueString(*(v.BuildID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -2303,6 +3273,16 @@ func (v *QueryWorkflowRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.BuildID = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -2317,7 +3297,7 @@ func (v *QueryWorkflowRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [4]string
 	i := 0
 	if v.DomainUUID != nil {
 		fields[i] = fmt.Sprintf("DomainUUID: %v", *(v.DomainUUID))
@@ -2331,6 +3311,10 @@ func (v *QueryWorkflowRequest) String() string {
 		fields[i] = fmt.Sprintf("QueryRequest: %v", v.QueryRequest)
 		i++
 	}
+	if v.BuildID != nil {
+		fields[i] = fmt.Sprintf("BuildID: %v", *(v.BuildID))
+		i++
+	}
 
 	return fmt.Sprintf("QueryWorkflowRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -2354,6 +3338,9 @@ func (v *QueryWorkflowRequest) Equals(rhs *QueryWorkflowRequest) bool {
 	if !((v.QueryRequest == nil && rhs.QueryRequest == nil) || (v.QueryRequest != nil && rhs.QueryRequest != nil && v.QueryRequest.Equals(rhs.QueryRequest))) {
 		return false
 	}
+	if !_String_EqualsPtr(v.BuildID, rhs.BuildID) {
+		return false
+	}
 
 	return true
 }
@@ -2373,6 +3360,9 @@ func (v *QueryWorkflowRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err
 	if v.QueryRequest != nil {
 		err = multierr.Append(err, enc.AddObject("queryRequest", v.QueryRequest))
 	}
+	if v.BuildID != nil {
+		enc.AddString("buildID", *v.BuildID)
+	}
 	return err
 }
 
@@ -2406,6 +3396,16 @@ func (v *QueryWorkflowRequest) GetQueryRequest() (o *shared.QueryWorkflowRequest
 	return
 }
 
+// GetBuildID returns the value of BuildID if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowRequest) GetBuildID() (o string) {
+	if v.BuildID != nil {
+		return *v.BuildID
+	}
+
+	return
+}
+
 type RespondQueryTaskCompletedRequest struct {
 	DomainUUID       *string                                  `json:"domainUUID,omitempty"`
 	TaskList         *shared.TaskList                         `json:"taskList,omitempty"`
@@ -1192,6 +1192,7 @@ type ActivityTaskScheduledEventAttributes struct {
 	HeartbeatTimeoutSeconds       *int32        `json:"heartbeatTimeoutSeconds,omitempty"`
 	DecisionTaskCompletedEventId  *int64        `json:"decisionTaskCompletedEventId,omitempty"`
 	RetryPolicy                   *RetryPolicy  `json:"retryPolicy,omitempty"`
+	Priority                      *int32        `json:"priority,omitempty"`
 }
 
 // ToWire translates a ActivityTaskScheduledEventAttributes struct into a Thrift-level intermediate
@@ -1211,7 +1212,7 @@ type ActivityTaskScheduledEventAttributes struct {
 //   }
 func (v *ActivityTaskScheduledEventAttributes) ToWire() (wire.Value, error) {
 	var (
-		fields [11]wire.Field
+		fields [12]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -1305,6 +1306,14 @@ func (v *ActivityTaskScheduledEventAttributes) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 110, Value: w}
 		i++
 	}
+	if v.Priority != nil {
+		w, err = wire.NewValueI32(*(v.Priority)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 120, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -1450,6 +1459,16 @@ func (v *ActivityTaskScheduledEventAttributes) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 120:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Priority = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -1464,7 +1483,7 @@ func (v *ActivityTaskScheduledEventAttributes) String() string {
 		return "<nil>"
 	}
 
-	var fields [11]string
+	var fields [12]string
 	i := 0
 	if v.ActivityId != nil {
 		fields[i] = fmt.Sprintf("ActivityId: %v", *(v.ActivityId))
@@ -1510,6 +1529,10 @@ func (v *ActivityTaskScheduledEventAttributes) String() string {
 		fields[i] = fmt.Sprintf("RetryPolicy: %v", v.RetryPolicy)
 		i++
 	}
+	if v.Priority != nil {
+		fields[i] = fmt.Sprintf("Priority: %v", *(v.Priority))
+		i++
+	}
 
 	return fmt.Sprintf("ActivityTaskScheduledEventAttributes{%v}", strings.Join(fields[:i], ", "))
 }
@@ -1567,6 +1590,9 @@ func (v *ActivityTaskScheduledEventAttributes) Equals(rhs *ActivityTaskScheduled
 	if !((v.RetryPolicy == nil && rhs.RetryPolicy == nil) || (v.RetryPolicy != nil && rhs.RetryPolicy != nil && v.RetryPolicy.Equals(rhs.RetryPolicy))) {
 		return false
 	}
+	if !_I32_EqualsPtr(v.Priority, rhs.Priority) {
+		return false
+	}
 
 	return true
 }
@@ -1610,6 +1636,9 @@ func (v *ActivityTaskScheduledEventAttributes) MarshalLogObject(enc zapcore.Obje
 	if v.RetryPolicy != nil {
 		err = multierr.Append(err, enc.AddObject("retryPolicy", v.RetryPolicy))
 	}
+	if v.Priority != nil {
+		enc.AddInt32("priority", *v.Priority)
+	}
 	return err
 }
 
@@ -1723,6 +1752,16 @@ func (v *ActivityTaskScheduledEventAttributes) GetRetryPolicy() (o *RetryPolicy)
 	return
 }
 
+// GetPriority returns the value of Priority if it is set or its
+// zero value if it is unset.
+func (v *ActivityTaskScheduledEventAttributes) GetPriority() (o int32) {
+	if v.Priority != nil {
+		return *v.Priority
+	}
+
+	return
+}
+
 type ActivityTaskStartedEventAttributes struct {
 	ScheduledEventId *int64  `json:"scheduledEventId,omitempty"`
 	Identity         *string `json:"identity,omitempty"`
@@ -5698,6 +5737,139 @@ func (v *ChildWorkflowExecutionTimedOutEventAttributes) GetStartedEventId() (o i
 	return
 }
 
+type CloseShardRequest struct {
+	ShardID *int32 `json:"shardID,omitempty"`
+}
+
+// ToWire translates a CloseShardRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *CloseShardRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ShardID != nil {
+		w, err = wire.NewValueI32(*(v.ShardID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a CloseShardRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a CloseShardRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v CloseShardRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *CloseShardRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ShardID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a CloseShardRequest
+// struct.
+func (v *CloseShardRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.ShardID != nil {
+		fields[i] = fmt.Sprintf("ShardID: %v", *(v.ShardID))
+		i++
+	}
+
+	return fmt.Sprintf("CloseShardRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this CloseShardRequest match the
+// provided CloseShardRequest.
+//
+// This function performs a deep comparison.
+func (v *CloseShardRequest) Equals(rhs *CloseShardRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_I32_EqualsPtr(v.ShardID, rhs.ShardID) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of CloseShardRequest.
+func (v *CloseShardRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.ShardID != nil {
+		enc.AddInt32("shardID", *v.ShardID)
+	}
+	return err
+}
+
+// GetShardID returns the value of ShardID if it is set or its
+// zero value if it is unset.
+func (v *CloseShardRequest) GetShardID() (o int32) {
+	if v.ShardID != nil {
+		return *v.ShardID
+	}
+
+	return
+}
+
 type ClusterReplicationConfiguration struct {
 	ClusterName *string `json:"clusterName,omitempty"`
 }
@@ -5831,6 +6003,207 @@ func (v *ClusterReplicationConfiguration) GetClusterName() (o string) {
 	return
 }
 
+type CompatibleBuildSet struct {
+	BuildIDs []string `json:"buildIDs,omitempty"`
+}
+
+type _List_String_ValueList []string
+
+func (v _List_String_ValueList) ForEach(f func(wire.Value) error) error {
+	for _, x := range v {
+		w, err := wire.NewValueString(x), error(nil)
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_String_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_String_ValueList) ValueType() wire.Type {
+	return wire.TBinary
+}
+
+func (_List_String_ValueList) Close() {}
+
+// ToWire translates a CompatibleBuildSet struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *CompatibleBuildSet) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.BuildIDs != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.BuildIDs)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _List_String_Read(l wire.ValueList) ([]string, error) {
+	if l.ValueType() != wire.TBinary {
+		return nil, nil
+	}
+
+	o := make([]string, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := x.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
+// FromWire deserializes a CompatibleBuildSet struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a CompatibleBuildSet struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v CompatibleBuildSet
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *CompatibleBuildSet) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.BuildIDs, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a CompatibleBuildSet
+// struct.
+func (v *CompatibleBuildSet) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.BuildIDs != nil {
+		fields[i] = fmt.Sprintf("BuildIDs: %v", v.BuildIDs)
+		i++
+	}
+
+	return fmt.Sprintf("CompatibleBuildSet{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _List_String_Equals(lhs, rhs []string) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !(lv == rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Equals returns true if all the fields of this CompatibleBuildSet match the
+// provided CompatibleBuildSet.
+//
+// This function performs a deep comparison.
+func (v *CompatibleBuildSet) Equals(rhs *CompatibleBuildSet) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.BuildIDs == nil && rhs.BuildIDs == nil) || (v.BuildIDs != nil && rhs.BuildIDs != nil && _List_String_Equals(v.BuildIDs, rhs.BuildIDs))) {
+		return false
+	}
+
+	return true
+}
+
+type _List_String_Zapper []string
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_String_Zapper.
+func (l _List_String_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		enc.AppendString(v)
+	}
+	return err
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of CompatibleBuildSet.
+func (v *CompatibleBuildSet) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.BuildIDs != nil {
+		err = multierr.Append(err, enc.AddArray("buildIDs", (_List_String_Zapper)(v.BuildIDs)))
+	}
+	return err
+}
+
+// GetBuildIDs returns the value of BuildIDs if it is set or its
+// zero value if it is unset.
+func (v *CompatibleBuildSet) GetBuildIDs() (o []string) {
+	if v.BuildIDs != nil {
+		return v.BuildIDs
+	}
+
+	return
+}
+
 type CompleteWorkflowExecutionDecisionAttributes struct {
 	Result []byte `json:"result,omitempty"`
 }
@@ -10832,6 +11205,350 @@ func (v *DescribeHistoryHostResponse) GetAddress() (o string) {
 	return
 }
 
+type DescribeShardRequest struct {
+	ShardID *int32 `json:"shardID,omitempty"`
+}
+
+// ToWire translates a DescribeShardRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DescribeShardRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ShardID != nil {
+		w, err = wire.NewValueI32(*(v.ShardID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a DescribeShardRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DescribeShardRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DescribeShardRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DescribeShardRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ShardID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DescribeShardRequest
+// struct.
+func (v *DescribeShardRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.ShardID != nil {
+		fields[i] = fmt.Sprintf("ShardID: %v", *(v.ShardID))
+		i++
+	}
+
+	return fmt.Sprintf("DescribeShardRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this DescribeShardRequest match the
+// provided DescribeShardRequest.
+//
+// This function performs a deep comparison.
+func (v *DescribeShardRequest) Equals(rhs *DescribeShardRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_I32_EqualsPtr(v.ShardID, rhs.ShardID) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of DescribeShardRequest.
+func (v *DescribeShardRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.ShardID != nil {
+		enc.AddInt32("shardID", *v.ShardID)
+	}
+	return err
+}
+
+// GetShardID returns the value of ShardID if it is set or its
+// zero value if it is unset.
+func (v *DescribeShardRequest) GetShardID() (o int32) {
+	if v.ShardID != nil {
+		return *v.ShardID
+	}
+
+	return
+}
+
+type DescribeShardResponse struct {
+	ShardID             *int32  `json:"shardID,omitempty"`
+	HostAddress         *string `json:"hostAddress,omitempty"`
+	ShardInfoInDatabase *string `json:"shardInfoInDatabase,omitempty"`
+}
+
+// ToWire translates a DescribeShardResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DescribeShardResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ShardID != nil {
+		w, err = wire.NewValueI32(*(v.ShardID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.HostAddress != nil {
+		w, err = wire.NewValueString(*(v.HostAddress)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.ShardInfoInDatabase != nil {
+		w, err = wire.NewValueString(*(v.ShardInfoInDatabase)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a DescribeShardResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DescribeShardResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DescribeShardResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DescribeShardResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ShardID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.HostAddress = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ShardInfoInDatabase = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DescribeShardResponse
+// struct.
+func (v *DescribeShardResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.ShardID != nil {
+		fields[i] = fmt.Sprintf("ShardID: %v", *(v.ShardID))
+		i++
+	}
+	if v.HostAddress != nil {
+		fields[i] = fmt.Sprintf("HostAddress: %v", *(v.HostAddress))
+		i++
+	}
+	if v.ShardInfoInDatabase != nil {
+		fields[i] = fmt.Sprintf("ShardInfoInDatabase: %v", *(v.ShardInfoInDatabase))
+		i++
+	}
+
+	return fmt.Sprintf("DescribeShardResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this DescribeShardResponse match the
+// provided DescribeShardResponse.
+//
+// This function performs a deep comparison.
+func (v *DescribeShardResponse) Equals(rhs *DescribeShardResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_I32_EqualsPtr(v.ShardID, rhs.ShardID) {
+		return false
+	}
+	if !_String_EqualsPtr(v.HostAddress, rhs.HostAddress) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ShardInfoInDatabase, rhs.ShardInfoInDatabase) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of DescribeShardResponse.
+func (v *DescribeShardResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.ShardID != nil {
+		enc.AddInt32("shardID", *v.ShardID)
+	}
+	if v.HostAddress != nil {
+		enc.AddString("hostAddress", *v.HostAddress)
+	}
+	if v.ShardInfoInDatabase != nil {
+		enc.AddString("shardInfoInDatabase", *v.ShardInfoInDatabase)
+	}
+	return err
+}
+
+// GetShardID returns the value of ShardID if it is set or its
+// zero value if it is unset.
+func (v *DescribeShardResponse) GetShardID() (o int32) {
+	if v.ShardID != nil {
+		return *v.ShardID
+	}
+
+	return
+}
+
+// GetHostAddress returns the value of HostAddress if it is set or its
+// zero value if it is unset.
+func (v *DescribeShardResponse) GetHostAddress() (o string) {
+	if v.HostAddress != nil {
+		return *v.HostAddress
+	}
+
+	return
+}
+
+// GetShardInfoInDatabase returns the value of ShardInfoInDatabase if it is set or its
+// zero value if it is unset.
+func (v *DescribeShardResponse) GetShardInfoInDatabase() (o string) {
+	if v.ShardInfoInDatabase != nil {
+		return *v.ShardInfoInDatabase
+	}
+
+	return
+}
+
 type DescribeTaskListRequest struct {
 	Domain       *string       `json:"domain,omitempty"`
 	TaskList     *TaskList     `json:"taskList,omitempty"`
@@ -11058,7 +11775,8 @@ func (v *DescribeTaskListRequest) GetTaskListType() (o TaskListType) {
 }
 
 type DescribeTaskListResponse struct {
-	Pollers []*PollerInfo `json:"pollers,omitempty"`
+	Pollers   []*PollerInfo         `json:"pollers,omitempty"`
+	BuildSets []*CompatibleBuildSet `json:"buildSets,omitempty"`
 }
 
 type _List_PollerInfo_ValueList []*PollerInfo
@@ -11090,6 +11808,35 @@ func (_List_PollerInfo_ValueList) ValueType() wire.Type {
 
 func (_List_PollerInfo_ValueList) Close() {}
 
+type _List_CompatibleBuildSet_ValueList []*CompatibleBuildSet
+
+func (v _List_CompatibleBuildSet_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_CompatibleBuildSet_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_CompatibleBuildSet_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_CompatibleBuildSet_ValueList) Close() {}
+
 // ToWire translates a DescribeTaskListResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -11107,7 +11854,7 @@ func (_List_PollerInfo_ValueList) Close() {}
 //   }
 func (v *DescribeTaskListResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [1]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -11121,6 +11868,14 @@ func (v *DescribeTaskListResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
+	if v.BuildSets != nil {
+		w, err = wire.NewValueList(_List_CompatibleBuildSet_ValueList(v.BuildSets)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -11149,6 +11904,30 @@ func _List_PollerInfo_Read(l wire.ValueList) ([]*PollerInfo, error) {
 	return o, err
 }
 
+func _CompatibleBuildSet_Read(w wire.Value) (*CompatibleBuildSet, error) {
+	var v CompatibleBuildSet
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_CompatibleBuildSet_Read(l wire.ValueList) ([]*CompatibleBuildSet, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*CompatibleBuildSet, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _CompatibleBuildSet_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
 // FromWire deserializes a DescribeTaskListResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -11178,6 +11957,14 @@ func (v *DescribeTaskListResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 20:
+			if field.Value.Type() == wire.TList {
+				v.BuildSets, err = _List_CompatibleBuildSet_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -11192,12 +11979,16 @@ func (v *DescribeTaskListResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [1]string
+	var fields [2]string
 	i := 0
 	if v.Pollers != nil {
 		fields[i] = fmt.Sprintf("Pollers: %v", v.Pollers)
 		i++
 	}
+	if v.BuildSets != nil {
+		fields[i] = fmt.Sprintf("BuildSets: %v", v.BuildSets)
+		i++
+	}
 
 	return fmt.Sprintf("DescribeTaskListResponse{%v}", strings.Join(fields[:i], ", "))
 }
@@ -11217,6 +12008,21 @@ func _List_PollerInfo_Equals(lhs, rhs []*PollerInfo) bool {
 	return true
 }
 
+func _List_CompatibleBuildSet_Equals(lhs, rhs []*CompatibleBuildSet) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this DescribeTaskListResponse match the
 // provided DescribeTaskListResponse.
 //
@@ -11230,6 +12036,9 @@ func (v *DescribeTaskListResponse) Equals(rhs *DescribeTaskListResponse) bool {
 	if !((v.Pollers == nil && rhs.Pollers == nil) || (v.Pollers != nil && rhs.Pollers != nil && _List_PollerInfo_Equals(v.Pollers, rhs.Pollers))) {
 		return false
 	}
+	if !((v.BuildSets == nil && rhs.BuildSets == nil) || (v.BuildSets != nil && rhs.BuildSets != nil && _List_CompatibleBuildSet_Equals(v.BuildSets, rhs.BuildSets))) {
+		return false
+	}
 
 	return true
 }
@@ -11245,6 +12054,17 @@ func (l _List_PollerInfo_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err
 	return err
 }
 
+type _List_CompatibleBuildSet_Zapper []*CompatibleBuildSet
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_CompatibleBuildSet_Zapper.
+func (l _List_CompatibleBuildSet_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of DescribeTaskListResponse.
 func (v *DescribeTaskListResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -11254,6 +12074,9 @@ func (v *DescribeTaskListResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (
 	if v.Pollers != nil {
 		err = multierr.Append(err, enc.AddArray("pollers", (_List_PollerInfo_Zapper)(v.Pollers)))
 	}
+	if v.BuildSets != nil {
+		err = multierr.Append(err, enc.AddArray("buildSets", (_List_CompatibleBuildSet_Zapper)(v.BuildSets)))
+	}
 	return err
 }
 
@@ -11267,6 +12090,16 @@ func (v *DescribeTaskListResponse) GetPollers() (o []*PollerInfo) {
 	return
 }
 
+// GetBuildSets returns the value of BuildSets if it is set or its
+// zero value if it is unset.
+func (v *DescribeTaskListResponse) GetBuildSets() (o []*CompatibleBuildSet) {
+	if v.BuildSets != nil {
+		return v.BuildSets
+	}
+
+	return
+}
+
 type DescribeWorkflowExecutionRequest struct {
 	Domain    *string            `json:"domain,omitempty"`
 	Execution *WorkflowExecution `json:"execution,omitempty"`
@@ -13427,6 +14260,139 @@ func (v *DomainStatus) UnmarshalJSON(text []byte) error {
 	}
 }
 
+type DrainHistoryHostRequest struct {
+	HostAddress *string `json:"hostAddress,omitempty"`
+}
+
+// ToWire translates a DrainHistoryHostRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *DrainHistoryHostRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.HostAddress != nil {
+		w, err = wire.NewValueString(*(v.HostAddress)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a DrainHistoryHostRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a DrainHistoryHostRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v DrainHistoryHostRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *DrainHistoryHostRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.HostAddress = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a DrainHistoryHostRequest
+// struct.
+func (v *DrainHistoryHostRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.HostAddress != nil {
+		fields[i] = fmt.Sprintf("HostAddress: %v", *(v.HostAddress))
+		i++
+	}
+
+	return fmt.Sprintf("DrainHistoryHostRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this DrainHistoryHostRequest match the
+// provided DrainHistoryHostRequest.
+//
+// This function performs a deep comparison.
+func (v *DrainHistoryHostRequest) Equals(rhs *DrainHistoryHostRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.HostAddress, rhs.HostAddress) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of DrainHistoryHostRequest.
+func (v *DrainHistoryHostRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.HostAddress != nil {
+		enc.AddString("hostAddress", *v.HostAddress)
+	}
+	return err
+}
+
+// GetHostAddress returns the value of HostAddress if it is set or its
+// zero value if it is unset.
+func (v *DrainHistoryHostRequest) GetHostAddress() (o string) {
+	if v.HostAddress != nil {
+		return *v.HostAddress
+	}
+
+	return
+}
+
 type EncodingType int32
 
 const (
@@ -13758,6 +14724,8 @@ const (
 	EventTypeSignalExternalWorkflowExecutionInitiated        EventType = 38
 	EventTypeSignalExternalWorkflowExecutionFailed           EventType = 39
 	EventTypeExternalWorkflowExecutionSignaled               EventType = 40
+	EventTypeWorkflowExecutionPaused                         EventType = 41
+	EventTypeWorkflowExecutionUnpaused                       EventType = 42
 )
 
 // EventType_Values returns all recognized values of EventType.
@@ -13804,6 +14772,8 @@ func EventType_Values() []EventType {
 		EventTypeSignalExternalWorkflowExecutionInitiated,
 		EventTypeSignalExternalWorkflowExecutionFailed,
 		EventTypeExternalWorkflowExecutionSignaled,
+		EventTypeWorkflowExecutionPaused,
+		EventTypeWorkflowExecutionUnpaused,
 	}
 }
 
@@ -13937,6 +14907,12 @@ func (v *EventType) UnmarshalText(value []byte) error {
 	case "ExternalWorkflowExecutionSignaled":
 		*v = EventTypeExternalWorkflowExecutionSignaled
 		return nil
+	case "WorkflowExecutionPaused":
+		*v = EventTypeWorkflowExecutionPaused
+		return nil
+	case "WorkflowExecutionUnpaused":
+		*v = EventTypeWorkflowExecutionUnpaused
+		return nil
 	default:
 		val, err := strconv.ParseInt(s, 10, 32)
 		if err != nil {
@@ -14037,6 +15013,10 @@ func (v EventType) MarshalText() ([]byte, error) {
 		return []byte("SignalExternalWorkflowExecutionFailed"), nil
 	case 40:
 		return []byte("ExternalWorkflowExecutionSignaled"), nil
+	case 41:
+		return []byte("WorkflowExecutionPaused"), nil
+	case 42:
+		return []byte("WorkflowExecutionUnpaused"), nil
 	}
 	return []byte(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -14130,6 +15110,10 @@ func (v EventType) MarshalLogObject(enc zapcore.ObjectEncoder) error {
 		enc.AddString("name", "SignalExternalWorkflowExecutionFailed")
 	case 40:
 		enc.AddString("name", "ExternalWorkflowExecutionSignaled")
+	case 41:
+		enc.AddString("name", "WorkflowExecutionPaused")
+	case 42:
+		enc.AddString("name", "WorkflowExecutionUnpaused")
 	}
 	return nil
 }
@@ -14252,6 +15236,10 @@ func (v EventType) String() string {
 		return "SignalExternalWorkflowExecutionFailed"
 	case 40:
 		return "ExternalWorkflowExecutionSignaled"
+	case 41:
+		return "WorkflowExecutionPaused"
+	case 42:
+		return "WorkflowExecutionUnpaused"
 	}
 	return fmt.Sprintf("EventType(%d)", w)
 }
@@ -14352,6 +15340,10 @@ func (v EventType) MarshalJSON() ([]byte, error) {
 		return ([]byte)("\"SignalExternalWorkflowExecutionFailed\""), nil
 	case 40:
 		return ([]byte)("\"ExternalWorkflowExecutionSignaled\""), nil
+	case 41:
+		return ([]byte)("\"WorkflowExecutionPaused\""), nil
+	case 42:
+		return ([]byte)("\"WorkflowExecutionUnpaused\""), nil
 	}
 	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
 }
@@ -16511,6 +17503,8 @@ type HistoryEvent struct {
 	SignalExternalWorkflowExecutionInitiatedEventAttributes        *SignalExternalWorkflowExecutionInitiatedEventAttributes        `json:"signalExternalWorkflowExecutionInitiatedEventAttributes,omitempty"`
 	SignalExternalWorkflowExecutionFailedEventAttributes           *SignalExternalWorkflowExecutionFailedEventAttributes           `json:"signalExternalWorkflowExecutionFailedEventAttributes,omitempty"`
 	ExternalWorkflowExecutionSignaledEventAttributes               *ExternalWorkflowExecutionSignaledEventAttributes               `json:"externalWorkflowExecutionSignaledEventAttributes,omitempty"`
+	WorkflowExecutionPausedEventAttributes                         *WorkflowExecutionPausedEventAttributes                         `json:"workflowExecutionPausedEventAttributes,omitempty"`
+	WorkflowExecutionUnpausedEventAttributes                       *WorkflowExecutionUnpausedEventAttributes                       `json:"workflowExecutionUnpausedEventAttributes,omitempty"`
 }
 
 // ToWire translates a HistoryEvent struct into a Thrift-level intermediate
@@ -16530,7 +17524,7 @@ type HistoryEvent struct {
 //   }
 func (v *HistoryEvent) ToWire() (wire.Value, error) {
 	var (
-		fields [45]wire.Field
+		fields [47]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -16896,6 +17890,22 @@ func (v *HistoryEvent) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 440, Value: w}
 		i++
 	}
+	if v.WorkflowExecutionPausedEventAttributes != nil {
+		w, err = v.WorkflowExecutionPausedEventAttributes.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 450, Value: w}
+		i++
+	}
+	if v.WorkflowExecutionUnpausedEventAttributes != nil {
+		w, err = v.WorkflowExecutionUnpausedEventAttributes.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 460, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -17152,6 +18162,18 @@ func _ExternalWorkflowExecutionSignaledEventAttributes_Read(w wire.Value) (*Exte
 	return &v, err
 }
 
+func _WorkflowExecutionPausedEventAttributes_Read(w wire.Value) (*WorkflowExecutionPausedEventAttributes, error) {
+	var v WorkflowExecutionPausedEventAttributes
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _WorkflowExecutionUnpausedEventAttributes_Read(w wire.Value) (*WorkflowExecutionUnpausedEventAttributes, error) {
+	var v WorkflowExecutionUnpausedEventAttributes
+	err := v.FromWire(w)
+	return &v, err
+}
+
 // FromWire deserializes a HistoryEvent struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -17541,6 +18563,22 @@ func (v *HistoryEvent) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 450:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecutionPausedEventAttributes, err = _WorkflowExecutionPausedEventAttributes_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 460:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecutionUnpausedEventAttributes, err = _WorkflowExecutionUnpausedEventAttributes_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -17555,7 +18593,7 @@ func (v *HistoryEvent) String() string {
 		return "<nil>"
 	}
 
-	var fields [45]string
+	var fields [47]string
 	i := 0
 	if v.EventId != nil {
 		fields[i] = fmt.Sprintf("EventId: %v", *(v.EventId))
@@ -17737,6 +18775,14 @@ func (v *HistoryEvent) String() string {
 		fields[i] = fmt.Sprintf("ExternalWorkflowExecutionSignaledEventAttributes: %v", v.ExternalWorkflowExecutionSignaledEventAttributes)
 		i++
 	}
+	if v.WorkflowExecutionPausedEventAttributes != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecutionPausedEventAttributes: %v", v.WorkflowExecutionPausedEventAttributes)
+		i++
+	}
+	if v.WorkflowExecutionUnpausedEventAttributes != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecutionUnpausedEventAttributes: %v", v.WorkflowExecutionUnpausedEventAttributes)
+		i++
+	}
 
 	return fmt.Sprintf("HistoryEvent{%v}", strings.Join(fields[:i], ", "))
 }
@@ -17896,6 +18942,12 @@ func (v *HistoryEvent) Equals(rhs *HistoryEvent) bool {
 	if !((v.ExternalWorkflowExecutionSignaledEventAttributes == nil && rhs.ExternalWorkflowExecutionSignaledEventAttributes == nil) || (v.ExternalWorkflowExecutionSignaledEventAttributes != nil && rhs.ExternalWorkflowExecutionSignaledEventAttributes != nil && v.ExternalWorkflowExecutionSignaledEventAttributes.Equals(rhs.ExternalWorkflowExecutionSignaledEventAttributes))) {
 		return false
 	}
+	if !((v.WorkflowExecutionPausedEventAttributes == nil && rhs.WorkflowExecutionPausedEventAttributes == nil) || (v.WorkflowExecutionPausedEventAttributes != nil && rhs.WorkflowExecutionPausedEventAttributes != nil && v.WorkflowExecutionPausedEventAttributes.Equals(rhs.WorkflowExecutionPausedEventAttributes))) {
+		return false
+	}
+	if !((v.WorkflowExecutionUnpausedEventAttributes == nil && rhs.WorkflowExecutionUnpausedEventAttributes == nil) || (v.WorkflowExecutionUnpausedEventAttributes != nil && rhs.WorkflowExecutionUnpausedEventAttributes != nil && v.WorkflowExecutionUnpausedEventAttributes.Equals(rhs.WorkflowExecutionUnpausedEventAttributes))) {
+		return false
+	}
 
 	return true
 }
@@ -18041,6 +19093,12 @@ func (v *HistoryEvent) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v.ExternalWorkflowExecutionSignaledEventAttributes != nil {
 		err = multierr.Append(err, enc.AddObject("externalWorkflowExecutionSignaledEventAttributes", v.ExternalWorkflowExecutionSignaledEventAttributes))
 	}
+	if v.WorkflowExecutionPausedEventAttributes != nil {
+		err = multierr.Append(err, enc.AddObject("workflowExecutionPausedEventAttributes", v.WorkflowExecutionPausedEventAttributes))
+	}
+	if v.WorkflowExecutionUnpausedEventAttributes != nil {
+		err = multierr.Append(err, enc.AddObject("workflowExecutionUnpausedEventAttributes", v.WorkflowExecutionUnpausedEventAttributes))
+	}
 	return err
 }
 
@@ -18494,6 +19552,26 @@ func (v *HistoryEvent) GetExternalWorkflowExecutionSignaledEventAttributes() (o
 	return
 }
 
+// GetWorkflowExecutionPausedEventAttributes returns the value of WorkflowExecutionPausedEventAttributes if it is set or its
+// zero value if it is unset.
+func (v *HistoryEvent) GetWorkflowExecutionPausedEventAttributes() (o *WorkflowExecutionPausedEventAttributes) {
+	if v.WorkflowExecutionPausedEventAttributes != nil {
+		return v.WorkflowExecutionPausedEventAttributes
+	}
+
+	return
+}
+
+// GetWorkflowExecutionUnpausedEventAttributes returns the value of WorkflowExecutionUnpausedEventAttributes if it is set or its
+// zero value if it is unset.
+func (v *HistoryEvent) GetWorkflowExecutionUnpausedEventAttributes() (o *WorkflowExecutionUnpausedEventAttributes) {
+	if v.WorkflowExecutionUnpausedEventAttributes != nil {
+		return v.WorkflowExecutionUnpausedEventAttributes
+	}
+
+	return
+}
+
 type HistoryEventFilterType int32
 
 const (
@@ -19558,8 +20636,12 @@ func (v *ListClosedWorkflowExecutionsResponse) GetNextPageToken() (o []byte) {
 }
 
 type ListDomainsRequest struct {
-	PageSize      *int32 `json:"pageSize,omitempty"`
-	NextPageToken []byte `json:"nextPageToken,omitempty"`
+	PageSize          *int32            `json:"pageSize,omitempty"`
+	NextPageToken     []byte            `json:"nextPageToken,omitempty"`
+	Status            *DomainStatus     `json:"status,omitempty"`
+	ActiveClusterName *string           `json:"activeClusterName,omitempty"`
+	IsGlobalDomain    *bool             `json:"isGlobalDomain,omitempty"`
+	Data              map[string]string `json:"data,omitempty"`
 }
 
 // ToWire translates a ListDomainsRequest struct into a Thrift-level intermediate
@@ -19579,7 +20661,7 @@ type ListDomainsRequest struct {
 //   }
 func (v *ListDomainsRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [6]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -19601,6 +20683,38 @@ func (v *ListDomainsRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
+	if v.Status != nil {
+		w, err = v.Status.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.ActiveClusterName != nil {
+		w, err = wire.NewValueString(*(v.ActiveClusterName)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.IsGlobalDomain != nil {
+		w, err = wire.NewValueBool(*(v.IsGlobalDomain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.Data != nil {
+		w, err = wire.NewValueMap(_Map_String_String_MapItemList(v.Data)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -19644,6 +20758,44 @@ func (v *ListDomainsRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 30:
+			if field.Value.Type() == wire.TI32 {
+				var x DomainStatus
+				x, err = _DomainStatus_Read(field.Value)
+				v.Status = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ActiveClusterName = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.IsGlobalDomain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TMap {
+				v.Data, err = _Map_String_String_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -19658,7 +20810,7 @@ func (v *ListDomainsRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [2]string
+	var fields [6]string
 	i := 0
 	if v.PageSize != nil {
 		fields[i] = fmt.Sprintf("PageSize: %v", *(v.PageSize))
@@ -19668,6 +20820,22 @@ func (v *ListDomainsRequest) String() string {
 		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
 		i++
 	}
+	if v.Status != nil {
+		fields[i] = fmt.Sprintf("Status: %v", *(v.Status))
+		i++
+	}
+	if v.ActiveClusterName != nil {
+		fields[i] = fmt.Sprintf("ActiveClusterName: %v", *(v.ActiveClusterName))
+		i++
+	}
+	if v.IsGlobalDomain != nil {
+		fields[i] = fmt.Sprintf("IsGlobalDomain: %v", *(v.IsGlobalDomain))
+		i++
+	}
+	if v.Data != nil {
+		fields[i] = fmt.Sprintf("Data: %v", v.Data)
+		i++
+	}
 
 	return fmt.Sprintf("ListDomainsRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -19688,6 +20856,18 @@ func (v *ListDomainsRequest) Equals(rhs *ListDomainsRequest) bool {
 	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
 		return false
 	}
+	if !_DomainStatus_EqualsPtr(v.Status, rhs.Status) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ActiveClusterName, rhs.ActiveClusterName) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.IsGlobalDomain, rhs.IsGlobalDomain) {
+		return false
+	}
+	if !((v.Data == nil && rhs.Data == nil) || (v.Data != nil && rhs.Data != nil && _Map_String_String_Equals(v.Data, rhs.Data))) {
+		return false
+	}
 
 	return true
 }
@@ -19704,6 +20884,18 @@ func (v *ListDomainsRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err er
 	if v.NextPageToken != nil {
 		enc.AddString("nextPageToken", base64.StdEncoding.EncodeToString(v.NextPageToken))
 	}
+	if v.Status != nil {
+		err = multierr.Append(err, enc.AddObject("status", *v.Status))
+	}
+	if v.ActiveClusterName != nil {
+		enc.AddString("activeClusterName", *v.ActiveClusterName)
+	}
+	if v.IsGlobalDomain != nil {
+		enc.AddBool("isGlobalDomain", *v.IsGlobalDomain)
+	}
+	if v.Data != nil {
+		err = multierr.Append(err, enc.AddObject("data", (_Map_String_String_Zapper)(v.Data)))
+	}
 	return err
 }
 
@@ -19727,6 +20919,46 @@ func (v *ListDomainsRequest) GetNextPageToken() (o []byte) {
 	return
 }
 
+// GetStatus returns the value of Status if it is set or its
+// zero value if it is unset.
+func (v *ListDomainsRequest) GetStatus() (o DomainStatus) {
+	if v.Status != nil {
+		return *v.Status
+	}
+
+	return
+}
+
+// GetActiveClusterName returns the value of ActiveClusterName if it is set or its
+// zero value if it is unset.
+func (v *ListDomainsRequest) GetActiveClusterName() (o string) {
+	if v.ActiveClusterName != nil {
+		return *v.ActiveClusterName
+	}
+
+	return
+}
+
+// GetIsGlobalDomain returns the value of IsGlobalDomain if it is set or its
+// zero value if it is unset.
+func (v *ListDomainsRequest) GetIsGlobalDomain() (o bool) {
+	if v.IsGlobalDomain != nil {
+		return *v.IsGlobalDomain
+	}
+
+	return
+}
+
+// GetData returns the value of Data if it is set or its
+// zero value if it is unset.
+func (v *ListDomainsRequest) GetData() (o map[string]string) {
+	if v.Data != nil {
+		return v.Data
+	}
+
+	return
+}
+
 type ListDomainsResponse struct {
 	Domains       []*DescribeDomainResponse `json:"domains,omitempty"`
 	NextPageToken []byte                    `json:"nextPageToken,omitempty"`
@@ -20462,6 +21694,516 @@ func (v *ListOpenWorkflowExecutionsResponse) GetNextPageToken() (o []byte) {
 	return
 }
 
+type ListQueueTasksRequest struct {
+	ShardID       *int32         `json:"shardID,omitempty"`
+	TaskType      *QueueTaskType `json:"taskType,omitempty"`
+	WorkflowID    *string        `json:"workflowID,omitempty"`
+	RunID         *string        `json:"runID,omitempty"`
+	PageSize      *int32         `json:"pageSize,omitempty"`
+	NextPageToken []byte         `json:"nextPageToken,omitempty"`
+}
+
+// ToWire translates a ListQueueTasksRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ListQueueTasksRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [6]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ShardID != nil {
+		w, err = wire.NewValueI32(*(v.ShardID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.TaskType != nil {
+		w, err = v.TaskType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.WorkflowID != nil {
+		w, err = wire.NewValueString(*(v.WorkflowID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.RunID != nil {
+		w, err = wire.NewValueString(*(v.RunID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.PageSize != nil {
+		w, err = wire.NewValueI32(*(v.PageSize)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 60, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+func _QueueTaskType_Read(w wire.Value) (QueueTaskType, error) {
+	var v QueueTaskType
+	err := v.FromWire(w)
+	return v, err
+}
+
+// FromWire deserializes a ListQueueTasksRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ListQueueTasksRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ListQueueTasksRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ListQueueTasksRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ShardID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI32 {
+				var x QueueTaskType
+				x, err = _QueueTaskType_Read(field.Value)
+				v.TaskType = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.WorkflowID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.RunID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.PageSize = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 60:
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ListQueueTasksRequest
+// struct.
+func (v *ListQueueTasksRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [6]string
+	i := 0
+	if v.ShardID != nil {
+		fields[i] = fmt.Sprintf("ShardID: %v", *(v.ShardID))
+		i++
+	}
+	if v.TaskType != nil {
+		fields[i] = fmt.Sprintf("TaskType: %v", *(v.TaskType))
+		i++
+	}
+	if v.WorkflowID != nil {
+		fields[i] = fmt.Sprintf("WorkflowID: %v", *(v.WorkflowID))
+		i++
+	}
+	if v.RunID != nil {
+		fields[i] = fmt.Sprintf("RunID: %v", *(v.RunID))
+		i++
+	}
+	if v.PageSize != nil {
+		fields[i] = fmt.Sprintf("PageSize: %v", *(v.PageSize))
+		i++
+	}
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+		i++
+	}
+
+	return fmt.Sprintf("ListQueueTasksRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+func _QueueTaskType_EqualsPtr(lhs, rhs *QueueTaskType) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+// Equals returns true if all the fields of this ListQueueTasksRequest match the
+// provided ListQueueTasksRequest.
+//
+// This function performs a deep comparison.
+func (v *ListQueueTasksRequest) Equals(rhs *ListQueueTasksRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_I32_EqualsPtr(v.ShardID, rhs.ShardID) {
+		return false
+	}
+	if !_QueueTaskType_EqualsPtr(v.TaskType, rhs.TaskType) {
+		return false
+	}
+	if !_String_EqualsPtr(v.WorkflowID, rhs.WorkflowID) {
+		return false
+	}
+	if !_String_EqualsPtr(v.RunID, rhs.RunID) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.PageSize, rhs.PageSize) {
+		return false
+	}
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of ListQueueTasksRequest.
+func (v *ListQueueTasksRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.ShardID != nil {
+		enc.AddInt32("shardID", *v.ShardID)
+	}
+	if v.TaskType != nil {
+		err = multierr.Append(err, enc.AddObject("taskType", *v.TaskType))
+	}
+	if v.WorkflowID != nil {
+		enc.AddString("workflowID", *v.WorkflowID)
+	}
+	if v.RunID != nil {
+		enc.AddString("runID", *v.RunID)
+	}
+	if v.PageSize != nil {
+		enc.AddInt32("pageSize", *v.PageSize)
+	}
+	if v.NextPageToken != nil {
+		enc.AddString("nextPageToken", base64.StdEncoding.EncodeToString(v.NextPageToken))
+	}
+	return err
+}
+
+// GetShardID returns the value of ShardID if it is set or its
+// zero value if it is unset.
+func (v *ListQueueTasksRequest) GetShardID() (o int32) {
+	if v.ShardID != nil {
+		return *v.ShardID
+	}
+
+	return
+}
+
+// GetTaskType returns the value of TaskType if it is set or its
+// zero value if it is unset.
+func (v *ListQueueTasksRequest) GetTaskType() (o QueueTaskType) {
+	if v.TaskType != nil {
+		return *v.TaskType
+	}
+
+	return
+}
+
+// GetWorkflowID returns the value of WorkflowID if it is set or its
+// zero value if it is unset.
+func (v *ListQueueTasksRequest) GetWorkflowID() (o string) {
+	if v.WorkflowID != nil {
+		return *v.WorkflowID
+	}
+
+	return
+}
+
+// GetRunID returns the value of RunID if it is set or its
+// zero value if it is unset.
+func (v *ListQueueTasksRequest) GetRunID() (o string) {
+	if v.RunID != nil {
+		return *v.RunID
+	}
+
+	return
+}
+
+// GetPageSize returns the value of PageSize if it is set or its
+// zero value if it is unset.
+func (v *ListQueueTasksRequest) GetPageSize() (o int32) {
+	if v.PageSize != nil {
+		return *v.PageSize
+	}
+
+	return
+}
+
+// GetNextPageToken returns the value of NextPageToken if it is set or its
+// zero value if it is unset.
+func (v *ListQueueTasksRequest) GetNextPageToken() (o []byte) {
+	if v.NextPageToken != nil {
+		return v.NextPageToken
+	}
+
+	return
+}
+
+type ListQueueTasksResponse struct {
+	Tasks         []string `json:"tasks,omitempty"`
+	NextPageToken []byte   `json:"nextPageToken,omitempty"`
+}
+
+// ToWire translates a ListQueueTasksResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *ListQueueTasksResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [2]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Tasks != nil {
+		w, err = wire.NewValueList(_List_String_ValueList(v.Tasks)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.NextPageToken != nil {
+		w, err = wire.NewValueBinary(v.NextPageToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a ListQueueTasksResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a ListQueueTasksResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v ListQueueTasksResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *ListQueueTasksResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TList {
+				v.Tasks, err = _List_String_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				v.NextPageToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a ListQueueTasksResponse
+// struct.
+func (v *ListQueueTasksResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [2]string
+	i := 0
+	if v.Tasks != nil {
+		fields[i] = fmt.Sprintf("Tasks: %v", v.Tasks)
+		i++
+	}
+	if v.NextPageToken != nil {
+		fields[i] = fmt.Sprintf("NextPageToken: %v", v.NextPageToken)
+		i++
+	}
+
+	return fmt.Sprintf("ListQueueTasksResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this ListQueueTasksResponse match the
+// provided ListQueueTasksResponse.
+//
+// This function performs a deep comparison.
+func (v *ListQueueTasksResponse) Equals(rhs *ListQueueTasksResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.Tasks == nil && rhs.Tasks == nil) || (v.Tasks != nil && rhs.Tasks != nil && _List_String_Equals(v.Tasks, rhs.Tasks))) {
+		return false
+	}
+	if !((v.NextPageToken == nil && rhs.NextPageToken == nil) || (v.NextPageToken != nil && rhs.NextPageToken != nil && bytes.Equal(v.NextPageToken, rhs.NextPageToken))) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of ListQueueTasksResponse.
+func (v *ListQueueTasksResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Tasks != nil {
+		err = multierr.Append(err, enc.AddArray("tasks", (_List_String_Zapper)(v.Tasks)))
+	}
+	if v.NextPageToken != nil {
+		enc.AddString("nextPageToken", base64.StdEncoding.EncodeToString(v.NextPageToken))
+	}
+	return err
+}
+
+// GetTasks returns the value of Tasks if it is set or its
+// zero value if it is unset.
+func (v *ListQueueTasksResponse) GetTasks() (o []string) {
+	if v.Tasks != nil {
+		return v.Tasks
+	}
+
+	return
+}
+
+// GetNextPageToken returns the value of NextPageToken if it is set or its
+// zero value if it is unset.
+func (v *ListQueueTasksResponse) GetNextPageToken() (o []byte) {
+	if v.NextPageToken != nil {
+		return v.NextPageToken
+	}
+
+	return
+}
+
 type MarkerRecordedEventAttributes struct {
 	MarkerName                   *string `json:"markerName,omitempty"`
 	Details                      []byte  `json:"details,omitempty"`
@@ -20714,6 +22456,291 @@ func (v *MarkerRecordedEventAttributes) GetHeader() (o *Header) {
 	return
 }
 
+type PauseWorkflowExecutionRequest struct {
+	Domain            *string            `json:"domain,omitempty"`
+	WorkflowExecution *WorkflowExecution `json:"workflowExecution,omitempty"`
+	Reason            *string            `json:"reason,omitempty"`
+	Details           []byte             `json:"details,omitempty"`
+	Identity          *string            `json:"identity,omitempty"`
+}
+
+// ToWire translates a PauseWorkflowExecutionRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *PauseWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Reason != nil {
+		w, err = wire.NewValueString(*(v.Reason)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Details != nil {
+		w, err = wire.NewValueBinary(v.Details), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a PauseWorkflowExecutionRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a PauseWorkflowExecutionRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v PauseWorkflowExecutionRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *PauseWorkflowExecutionRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Reason = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				v.Details, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a PauseWorkflowExecutionRequest
+// struct.
+func (v *PauseWorkflowExecutionRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+		i++
+	}
+	if v.Reason != nil {
+		fields[i] = fmt.Sprintf("Reason: %v", *(v.Reason))
+		i++
+	}
+	if v.Details != nil {
+		fields[i] = fmt.Sprintf("Details: %v", v.Details)
+		i++
+	}
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+		i++
+	}
+
+	return fmt.Sprintf("PauseWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this PauseWorkflowExecutionRequest match the
+// provided PauseWorkflowExecutionRequest.
+//
+// This function performs a deep comparison.
+func (v *PauseWorkflowExecutionRequest) Equals(rhs *PauseWorkflowExecutionRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Reason, rhs.Reason) {
+		return false
+	}
+	if !((v.Details == nil && rhs.Details == nil) || (v.Details != nil && rhs.Details != nil && bytes.Equal(v.Details, rhs.Details))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of PauseWorkflowExecutionRequest.
+func (v *PauseWorkflowExecutionRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
+	}
+	if v.WorkflowExecution != nil {
+		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
+	}
+	if v.Reason != nil {
+		enc.AddString("reason", *v.Reason)
+	}
+	if v.Details != nil {
+		enc.AddString("details", base64.StdEncoding.EncodeToString(v.Details))
+	}
+	if v.Identity != nil {
+		enc.AddString("identity", *v.Identity)
+	}
+	return err
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *PauseWorkflowExecutionRequest) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// zero value if it is unset.
+func (v *PauseWorkflowExecutionRequest) GetWorkflowExecution() (o *WorkflowExecution) {
+	if v.WorkflowExecution != nil {
+		return v.WorkflowExecution
+	}
+
+	return
+}
+
+// GetReason returns the value of Reason if it is set or its
+// zero value if it is unset.
+func (v *PauseWorkflowExecutionRequest) GetReason() (o string) {
+	if v.Reason != nil {
+		return *v.Reason
+	}
+
+	return
+}
+
+// GetDetails returns the value of Details if it is set or its
+// zero value if it is unset.
+func (v *PauseWorkflowExecutionRequest) GetDetails() (o []byte) {
+	if v.Details != nil {
+		return v.Details
+	}
+
+	return
+}
+
+// GetIdentity returns the value of Identity if it is set or its
+// zero value if it is unset.
+func (v *PauseWorkflowExecutionRequest) GetIdentity() (o string) {
+	if v.Identity != nil {
+		return *v.Identity
+	}
+
+	return
+}
+
 type PendingActivityInfo struct {
 	ActivityID             *string               `json:"activityID,omitempty"`
 	ActivityType           *ActivityType         `json:"activityType,omitempty"`
@@ -21277,6 +23304,422 @@ func (v *PendingActivityState) UnmarshalJSON(text []byte) error {
 	}
 }
 
+type PollForActivityCancellationRequest struct {
+	Domain   *string   `json:"domain,omitempty"`
+	TaskList *TaskList `json:"taskList,omitempty"`
+	Identity *string   `json:"identity,omitempty"`
+}
+
+// ToWire translates a PollForActivityCancellationRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *PollForActivityCancellationRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.TaskList != nil {
+		w, err = v.TaskList.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a PollForActivityCancellationRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a PollForActivityCancellationRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v PollForActivityCancellationRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *PollForActivityCancellationRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.TaskList, err = _TaskList_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a PollForActivityCancellationRequest
+// struct.
+func (v *PollForActivityCancellationRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.TaskList != nil {
+		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
+		i++
+	}
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+		i++
+	}
+
+	return fmt.Sprintf("PollForActivityCancellationRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this PollForActivityCancellationRequest match the
+// provided PollForActivityCancellationRequest.
+//
+// This function performs a deep comparison.
+func (v *PollForActivityCancellationRequest) Equals(rhs *PollForActivityCancellationRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of PollForActivityCancellationRequest.
+func (v *PollForActivityCancellationRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
+	}
+	if v.TaskList != nil {
+		err = multierr.Append(err, enc.AddObject("taskList", v.TaskList))
+	}
+	if v.Identity != nil {
+		enc.AddString("identity", *v.Identity)
+	}
+	return err
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *PollForActivityCancellationRequest) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// GetTaskList returns the value of TaskList if it is set or its
+// zero value if it is unset.
+func (v *PollForActivityCancellationRequest) GetTaskList() (o *TaskList) {
+	if v.TaskList != nil {
+		return v.TaskList
+	}
+
+	return
+}
+
+// GetIdentity returns the value of Identity if it is set or its
+// zero value if it is unset.
+func (v *PollForActivityCancellationRequest) GetIdentity() (o string) {
+	if v.Identity != nil {
+		return *v.Identity
+	}
+
+	return
+}
+
+type PollForActivityCancellationResponse struct {
+	TaskToken         []byte             `json:"taskToken,omitempty"`
+	WorkflowExecution *WorkflowExecution `json:"workflowExecution,omitempty"`
+	ActivityId        *string            `json:"activityId,omitempty"`
+}
+
+// ToWire translates a PollForActivityCancellationResponse struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *PollForActivityCancellationResponse) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.TaskToken != nil {
+		w, err = wire.NewValueBinary(v.TaskToken), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.ActivityId != nil {
+		w, err = wire.NewValueString(*(v.ActivityId)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a PollForActivityCancellationResponse struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a PollForActivityCancellationResponse struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v PollForActivityCancellationResponse
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *PollForActivityCancellationResponse) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				v.TaskToken, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ActivityId = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a PollForActivityCancellationResponse
+// struct.
+func (v *PollForActivityCancellationResponse) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.TaskToken != nil {
+		fields[i] = fmt.Sprintf("TaskToken: %v", v.TaskToken)
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+		i++
+	}
+	if v.ActivityId != nil {
+		fields[i] = fmt.Sprintf("ActivityId: %v", *(v.ActivityId))
+		i++
+	}
+
+	return fmt.Sprintf("PollForActivityCancellationResponse{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this PollForActivityCancellationResponse match the
+// provided PollForActivityCancellationResponse.
+//
+// This function performs a deep comparison.
+func (v *PollForActivityCancellationResponse) Equals(rhs *PollForActivityCancellationResponse) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !((v.TaskToken == nil && rhs.TaskToken == nil) || (v.TaskToken != nil && rhs.TaskToken != nil && bytes.Equal(v.TaskToken, rhs.TaskToken))) {
+		return false
+	}
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ActivityId, rhs.ActivityId) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of PollForActivityCancellationResponse.
+func (v *PollForActivityCancellationResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.TaskToken != nil {
+		enc.AddString("taskToken", base64.StdEncoding.EncodeToString(v.TaskToken))
+	}
+	if v.WorkflowExecution != nil {
+		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
+	}
+	if v.ActivityId != nil {
+		enc.AddString("activityId", *v.ActivityId)
+	}
+	return err
+}
+
+// GetTaskToken returns the value of TaskToken if it is set or its
+// zero value if it is unset.
+func (v *PollForActivityCancellationResponse) GetTaskToken() (o []byte) {
+	if v.TaskToken != nil {
+		return v.TaskToken
+	}
+
+	return
+}
+
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// zero value if it is unset.
+func (v *PollForActivityCancellationResponse) GetWorkflowExecution() (o *WorkflowExecution) {
+	if v.WorkflowExecution != nil {
+		return v.WorkflowExecution
+	}
+
+	return
+}
+
+// GetActivityId returns the value of ActivityId if it is set or its
+// zero value if it is unset.
+func (v *PollForActivityCancellationResponse) GetActivityId() (o string) {
+	if v.ActivityId != nil {
+		return *v.ActivityId
+	}
+
+	return
+}
+
 type PollForActivityTaskRequest struct {
 	Domain           *string           `json:"domain,omitempty"`
 	TaskList         *TaskList         `json:"taskList,omitempty"`
@@ -22197,9 +24640,10 @@ func (v *PollForActivityTaskResponse) GetWorkflowDomain() (o string) {
 }
 
 type PollForDecisionTaskRequest struct {
-	Domain   *string   `json:"domain,omitempty"`
-	TaskList *TaskList `json:"taskList,omitempty"`
-	Identity *string   `json:"identity,omitempty"`
+	Domain         *string   `json:"domain,omitempty"`
+	TaskList       *TaskList `json:"taskList,omitempty"`
+	Identity       *string   `json:"identity,omitempty"`
+	BinaryChecksum *string   `json:"binaryChecksum,omitempty"`
 }
 
 // ToWire translates a PollForDecisionTaskRequest struct into a Thrift-level intermediate
@@ -22219,7 +24663,7 @@ type PollForDecisionTaskRequest struct {
 //   }
 func (v *PollForDecisionTaskRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [4]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -22249,6 +24693,14 @@ func (v *PollForDecisionTaskRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
+	if v.BinaryChecksum != nil {
+		w, err = wire.NewValueString(*(v.BinaryChecksum)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -22302,6 +24754,16 @@ func (v *PollForDecisionTaskRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.BinaryChecksum = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -22316,7 +24778,7 @@ func (v *PollForDecisionTaskRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [4]string
 	i := 0
 	if v.Domain != nil {
 		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
@@ -22330,6 +24792,10 @@ func (v *PollForDecisionTaskRequest) String() string {
 		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
 		i++
 	}
+	if v.BinaryChecksum != nil {
+		fields[i] = fmt.Sprintf("BinaryChecksum: %v", *(v.BinaryChecksum))
+		i++
+	}
 
 	return fmt.Sprintf("PollForDecisionTaskRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -22353,6 +24819,9 @@ func (v *PollForDecisionTaskRequest) Equals(rhs *PollForDecisionTaskRequest) boo
 	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
 		return false
 	}
+	if !_String_EqualsPtr(v.BinaryChecksum, rhs.BinaryChecksum) {
+		return false
+	}
 
 	return true
 }
@@ -22372,6 +24841,9 @@ func (v *PollForDecisionTaskRequest) MarshalLogObject(enc zapcore.ObjectEncoder)
 	if v.Identity != nil {
 		enc.AddString("identity", *v.Identity)
 	}
+	if v.BinaryChecksum != nil {
+		enc.AddString("binaryChecksum", *v.BinaryChecksum)
+	}
 	return err
 }
 
@@ -22405,20 +24877,69 @@ func (v *PollForDecisionTaskRequest) GetIdentity() (o string) {
 	return
 }
 
+// GetBinaryChecksum returns the value of BinaryChecksum if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskRequest) GetBinaryChecksum() (o string) {
+	if v.BinaryChecksum != nil {
+		return *v.BinaryChecksum
+	}
+
+	return
+}
+
 type PollForDecisionTaskResponse struct {
-	TaskToken                 []byte             `json:"taskToken,omitempty"`
-	WorkflowExecution         *WorkflowExecution `json:"workflowExecution,omitempty"`
-	WorkflowType              *WorkflowType      `json:"workflowType,omitempty"`
-	PreviousStartedEventId    *int64             `json:"previousStartedEventId,omitempty"`
-	StartedEventId            *int64             `json:"startedEventId,omitempty"`
-	Attempt                   *int64             `json:"attempt,omitempty"`
-	BacklogCountHint          *int64             `json:"backlogCountHint,omitempty"`
-	History                   *History           `json:"history,omitempty"`
-	NextPageToken             []byte             `json:"nextPageToken,omitempty"`
-	Query                     *WorkflowQuery     `json:"query,omitempty"`
-	WorkflowExecutionTaskList *TaskList          `json:"WorkflowExecutionTaskList,omitempty"`
+	TaskToken                 []byte                    `json:"taskToken,omitempty"`
+	WorkflowExecution         *WorkflowExecution        `json:"workflowExecution,omitempty"`
+	WorkflowType              *WorkflowType             `json:"workflowType,omitempty"`
+	PreviousStartedEventId    *int64                    `json:"previousStartedEventId,omitempty"`
+	StartedEventId            *int64                    `json:"startedEventId,omitempty"`
+	Attempt                   *int64                    `json:"attempt,omitempty"`
+	BacklogCountHint          *int64                    `json:"backlogCountHint,omitempty"`
+	History                   *History                  `json:"history,omitempty"`
+	NextPageToken             []byte                    `json:"nextPageToken,omitempty"`
+	Query                     *WorkflowQuery            `json:"query,omitempty"`
+	WorkflowExecutionTaskList *TaskList                 `json:"WorkflowExecutionTaskList,omitempty"`
+	Queries                   map[string]*WorkflowQuery `json:"queries,omitempty"`
+}
+
+type _Map_String_WorkflowQuery_MapItemList map[string]*WorkflowQuery
+
+func (m _Map_String_WorkflowQuery_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
+func (m _Map_String_WorkflowQuery_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_WorkflowQuery_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_WorkflowQuery_MapItemList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_Map_String_WorkflowQuery_MapItemList) Close() {}
+
 // ToWire translates a PollForDecisionTaskResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -22436,7 +24957,7 @@ type PollForDecisionTaskResponse struct {
 //   }
 func (v *PollForDecisionTaskResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [11]wire.Field
+		fields [12]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -22530,6 +25051,14 @@ func (v *PollForDecisionTaskResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 90, Value: w}
 		i++
 	}
+	if v.Queries != nil {
+		w, err = wire.NewValueMap(_Map_String_WorkflowQuery_MapItemList(v.Queries)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 100, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -22540,6 +25069,34 @@ func _WorkflowQuery_Read(w wire.Value) (*WorkflowQuery, error) {
 	return &v, err
 }
 
+func _Map_String_WorkflowQuery_Read(m wire.MapItemList) (map[string]*WorkflowQuery, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make(map[string]*WorkflowQuery, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := _WorkflowQuery_Read(x.Value)
+		if err != nil {
+			return err
+		}
+
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
 // FromWire deserializes a PollForDecisionTaskResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -22657,6 +25214,14 @@ func (v *PollForDecisionTaskResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 100:
+			if field.Value.Type() == wire.TMap {
+				v.Queries, err = _Map_String_WorkflowQuery_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -22671,7 +25236,7 @@ func (v *PollForDecisionTaskResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [11]string
+	var fields [12]string
 	i := 0
 	if v.TaskToken != nil {
 		fields[i] = fmt.Sprintf("TaskToken: %v", v.TaskToken)
@@ -22717,10 +25282,31 @@ func (v *PollForDecisionTaskResponse) String() string {
 		fields[i] = fmt.Sprintf("WorkflowExecutionTaskList: %v", v.WorkflowExecutionTaskList)
 		i++
 	}
+	if v.Queries != nil {
+		fields[i] = fmt.Sprintf("Queries: %v", v.Queries)
+		i++
+	}
 
 	return fmt.Sprintf("PollForDecisionTaskResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
+func _Map_String_WorkflowQuery_Equals(lhs, rhs map[string]*WorkflowQuery) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
 // Equals returns true if all the fields of this PollForDecisionTaskResponse match the
 // provided PollForDecisionTaskResponse.
 //
@@ -22764,10 +25350,24 @@ func (v *PollForDecisionTaskResponse) Equals(rhs *PollForDecisionTaskResponse) b
 	if !((v.WorkflowExecutionTaskList == nil && rhs.WorkflowExecutionTaskList == nil) || (v.WorkflowExecutionTaskList != nil && rhs.WorkflowExecutionTaskList != nil && v.WorkflowExecutionTaskList.Equals(rhs.WorkflowExecutionTaskList))) {
 		return false
 	}
+	if !((v.Queries == nil && rhs.Queries == nil) || (v.Queries != nil && rhs.Queries != nil && _Map_String_WorkflowQuery_Equals(v.Queries, rhs.Queries))) {
+		return false
+	}
 
 	return true
 }
 
+type _Map_String_WorkflowQuery_Zapper map[string]*WorkflowQuery
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of _Map_String_WorkflowQuery_Zapper.
+func (m _Map_String_WorkflowQuery_Zapper) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	for k, v := range m {
+		err = multierr.Append(err, enc.AddObject((string)(k), v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of PollForDecisionTaskResponse.
 func (v *PollForDecisionTaskResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -22807,6 +25407,9 @@ func (v *PollForDecisionTaskResponse) MarshalLogObject(enc zapcore.ObjectEncoder
 	if v.WorkflowExecutionTaskList != nil {
 		err = multierr.Append(err, enc.AddObject("WorkflowExecutionTaskList", v.WorkflowExecutionTaskList))
 	}
+	if v.Queries != nil {
+		err = multierr.Append(err, enc.AddObject("queries", (_Map_String_WorkflowQuery_Zapper)(v.Queries)))
+	}
 	return err
 }
 
@@ -22920,9 +25523,20 @@ func (v *PollForDecisionTaskResponse) GetWorkflowExecutionTaskList() (o *TaskLis
 	return
 }
 
+// GetQueries returns the value of Queries if it is set or its
+// zero value if it is unset.
+func (v *PollForDecisionTaskResponse) GetQueries() (o map[string]*WorkflowQuery) {
+	if v.Queries != nil {
+		return v.Queries
+	}
+
+	return
+}
+
 type PollerInfo struct {
 	LastAccessTime *int64  `json:"lastAccessTime,omitempty"`
 	Identity       *string `json:"identity,omitempty"`
+	BinaryChecksum *string `json:"binaryChecksum,omitempty"`
 }
 
 // ToWire translates a PollerInfo struct into a Thrift-level intermediate
@@ -22942,7 +25556,7 @@ type PollerInfo struct {
 //   }
 func (v *PollerInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [2]wire.Field
+		fields [3]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -22964,6 +25578,14 @@ func (v *PollerInfo) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 20, Value: w}
 		i++
 	}
+	if v.BinaryChecksum != nil {
+		w, err = wire.NewValueString(*(v.BinaryChecksum)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -23009,6 +25631,16 @@ func (v *PollerInfo) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.BinaryChecksum = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -23023,7 +25655,7 @@ func (v *PollerInfo) String() string {
 		return "<nil>"
 	}
 
-	var fields [2]string
+	var fields [3]string
 	i := 0
 	if v.LastAccessTime != nil {
 		fields[i] = fmt.Sprintf("LastAccessTime: %v", *(v.LastAccessTime))
@@ -23033,6 +25665,10 @@ func (v *PollerInfo) String() string {
 		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
 		i++
 	}
+	if v.BinaryChecksum != nil {
+		fields[i] = fmt.Sprintf("BinaryChecksum: %v", *(v.BinaryChecksum))
+		i++
+	}
 
 	return fmt.Sprintf("PollerInfo{%v}", strings.Join(fields[:i], ", "))
 }
@@ -23053,6 +25689,9 @@ func (v *PollerInfo) Equals(rhs *PollerInfo) bool {
 	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
 		return false
 	}
+	if !_String_EqualsPtr(v.BinaryChecksum, rhs.BinaryChecksum) {
+		return false
+	}
 
 	return true
 }
@@ -23069,6 +25708,9 @@ func (v *PollerInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v.Identity != nil {
 		enc.AddString("identity", *v.Identity)
 	}
+	if v.BinaryChecksum != nil {
+		enc.AddString("binaryChecksum", *v.BinaryChecksum)
+	}
 	return err
 }
 
@@ -23092,6 +25734,435 @@ func (v *PollerInfo) GetIdentity() (o string) {
 	return
 }
 
+// GetBinaryChecksum returns the value of BinaryChecksum if it is set or its
+// zero value if it is unset.
+func (v *PollerInfo) GetBinaryChecksum() (o string) {
+	if v.BinaryChecksum != nil {
+		return *v.BinaryChecksum
+	}
+
+	return
+}
+
+type PromoteTaskListBuildIDRequest struct {
+	Domain            *string   `json:"domain,omitempty"`
+	TaskList          *TaskList `json:"taskList,omitempty"`
+	BuildID           *string   `json:"buildID,omitempty"`
+	CompatibleBuildID *string   `json:"compatibleBuildID,omitempty"`
+}
+
+// ToWire translates a PromoteTaskListBuildIDRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *PromoteTaskListBuildIDRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.TaskList != nil {
+		w, err = v.TaskList.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.BuildID != nil {
+		w, err = wire.NewValueString(*(v.BuildID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.CompatibleBuildID != nil {
+		w, err = wire.NewValueString(*(v.CompatibleBuildID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a PromoteTaskListBuildIDRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a PromoteTaskListBuildIDRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v PromoteTaskListBuildIDRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *PromoteTaskListBuildIDRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.TaskList, err = _TaskList_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.BuildID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.CompatibleBuildID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a PromoteTaskListBuildIDRequest
+// struct.
+func (v *PromoteTaskListBuildIDRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.TaskList != nil {
+		fields[i] = fmt.Sprintf("TaskList: %v", v.TaskList)
+		i++
+	}
+	if v.BuildID != nil {
+		fields[i] = fmt.Sprintf("BuildID: %v", *(v.BuildID))
+		i++
+	}
+	if v.CompatibleBuildID != nil {
+		fields[i] = fmt.Sprintf("CompatibleBuildID: %v", *(v.CompatibleBuildID))
+		i++
+	}
+
+	return fmt.Sprintf("PromoteTaskListBuildIDRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this PromoteTaskListBuildIDRequest match the
+// provided PromoteTaskListBuildIDRequest.
+//
+// This function performs a deep comparison.
+func (v *PromoteTaskListBuildIDRequest) Equals(rhs *PromoteTaskListBuildIDRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.TaskList == nil && rhs.TaskList == nil) || (v.TaskList != nil && rhs.TaskList != nil && v.TaskList.Equals(rhs.TaskList))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.BuildID, rhs.BuildID) {
+		return false
+	}
+	if !_String_EqualsPtr(v.CompatibleBuildID, rhs.CompatibleBuildID) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of PromoteTaskListBuildIDRequest.
+func (v *PromoteTaskListBuildIDRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
+	}
+	if v.TaskList != nil {
+		err = multierr.Append(err, enc.AddObject("taskList", v.TaskList))
+	}
+	if v.BuildID != nil {
+		enc.AddString("buildID", *v.BuildID)
+	}
+	if v.CompatibleBuildID != nil {
+		enc.AddString("compatibleBuildID", *v.CompatibleBuildID)
+	}
+	return err
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *PromoteTaskListBuildIDRequest) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// GetTaskList returns the value of TaskList if it is set or its
+// zero value if it is unset.
+func (v *PromoteTaskListBuildIDRequest) GetTaskList() (o *TaskList) {
+	if v.TaskList != nil {
+		return v.TaskList
+	}
+
+	return
+}
+
+// GetBuildID returns the value of BuildID if it is set or its
+// zero value if it is unset.
+func (v *PromoteTaskListBuildIDRequest) GetBuildID() (o string) {
+	if v.BuildID != nil {
+		return *v.BuildID
+	}
+
+	return
+}
+
+// GetCompatibleBuildID returns the value of CompatibleBuildID if it is set or its
+// zero value if it is unset.
+func (v *PromoteTaskListBuildIDRequest) GetCompatibleBuildID() (o string) {
+	if v.CompatibleBuildID != nil {
+		return *v.CompatibleBuildID
+	}
+
+	return
+}
+
+type QueryConsistencyLevel int32
+
+const (
+	QueryConsistencyLevelEventual QueryConsistencyLevel = 0
+	QueryConsistencyLevelStrong   QueryConsistencyLevel = 1
+)
+
+// QueryConsistencyLevel_Values returns all recognized values of QueryConsistencyLevel.
+func QueryConsistencyLevel_Values() []QueryConsistencyLevel {
+	return []QueryConsistencyLevel{
+		QueryConsistencyLevelEventual,
+		QueryConsistencyLevelStrong,
+	}
+}
+
+// UnmarshalText tries to decode QueryConsistencyLevel from a byte slice
+// containing its name.
+//
+//   var v QueryConsistencyLevel
+//   err := v.UnmarshalText([]byte("EVENTUAL"))
+func (v *QueryConsistencyLevel) UnmarshalText(value []byte) error {
+	switch s := string(value); s {
+	case "EVENTUAL":
+		*v = QueryConsistencyLevelEventual
+		return nil
+	case "STRONG":
+		*v = QueryConsistencyLevelStrong
+		return nil
+	default:
+		val, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", s, "QueryConsistencyLevel", err)
+		}
+		*v = QueryConsistencyLevel(val)
+		return nil
+	}
+}
+
+// MarshalText encodes QueryConsistencyLevel to text.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements the TextMarshaler interface.
+func (v QueryConsistencyLevel) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("EVENTUAL"), nil
+	case 1:
+		return []byte("STRONG"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of QueryConsistencyLevel.
+// Enums are logged as objects, where the value is logged with key "value", and
+// if this value's name is known, the name is logged with key "name".
+func (v QueryConsistencyLevel) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt32("value", int32(v))
+	switch int32(v) {
+	case 0:
+		enc.AddString("name", "EVENTUAL")
+	case 1:
+		enc.AddString("name", "STRONG")
+	}
+	return nil
+}
+
+// Ptr returns a pointer to this enum value.
+func (v QueryConsistencyLevel) Ptr() *QueryConsistencyLevel {
+	return &v
+}
+
+// ToWire translates QueryConsistencyLevel into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// Enums are represented as 32-bit integers over the wire.
+func (v QueryConsistencyLevel) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+// FromWire deserializes QueryConsistencyLevel from its Thrift-level
+// representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TI32)
+//   if err != nil {
+//     return QueryConsistencyLevel(0), err
+//   }
+//
+//   var v QueryConsistencyLevel
+//   if err := v.FromWire(x); err != nil {
+//     return QueryConsistencyLevel(0), err
+//   }
+//   return v, nil
+func (v *QueryConsistencyLevel) FromWire(w wire.Value) error {
+	*v = (QueryConsistencyLevel)(w.GetI32())
+	return nil
+}
+
+// String returns a readable string representation of QueryConsistencyLevel.
+func (v QueryConsistencyLevel) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "EVENTUAL"
+	case 1:
+		return "STRONG"
+	}
+	return fmt.Sprintf("QueryConsistencyLevel(%d)", w)
+}
+
+// Equals returns true if this QueryConsistencyLevel value matches the provided
+// value.
+func (v QueryConsistencyLevel) Equals(rhs QueryConsistencyLevel) bool {
+	return v == rhs
+}
+
+// MarshalJSON serializes QueryConsistencyLevel into JSON.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v QueryConsistencyLevel) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"EVENTUAL\""), nil
+	case 1:
+		return ([]byte)("\"STRONG\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON attempts to decode QueryConsistencyLevel from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *QueryConsistencyLevel) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "QueryConsistencyLevel")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "QueryConsistencyLevel")
+		}
+		*v = (QueryConsistencyLevel)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "QueryConsistencyLevel")
+	}
+}
+
 type QueryFailedError struct {
 	Message string `json:"message,required"`
 }
@@ -23221,6 +26292,310 @@ func (v *QueryFailedError) Error() string {
 	return v.String()
 }
 
+type QueryRejectCondition int32
+
+const (
+	QueryRejectConditionNotOpen             QueryRejectCondition = 0
+	QueryRejectConditionNotCompletedCleanly QueryRejectCondition = 1
+)
+
+// QueryRejectCondition_Values returns all recognized values of QueryRejectCondition.
+func QueryRejectCondition_Values() []QueryRejectCondition {
+	return []QueryRejectCondition{
+		QueryRejectConditionNotOpen,
+		QueryRejectConditionNotCompletedCleanly,
+	}
+}
+
+// UnmarshalText tries to decode QueryRejectCondition from a byte slice
+// containing its name.
+//
+//   var v QueryRejectCondition
+//   err := v.UnmarshalText([]byte("NOT_OPEN"))
+func (v *QueryRejectCondition) UnmarshalText(value []byte) error {
+	switch s := string(value); s {
+	case "NOT_OPEN":
+		*v = QueryRejectConditionNotOpen
+		return nil
+	case "NOT_COMPLETED_CLEANLY":
+		*v = QueryRejectConditionNotCompletedCleanly
+		return nil
+	default:
+		val, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", s, "QueryRejectCondition", err)
+		}
+		*v = QueryRejectCondition(val)
+		return nil
+	}
+}
+
+// MarshalText encodes QueryRejectCondition to text.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements the TextMarshaler interface.
+func (v QueryRejectCondition) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("NOT_OPEN"), nil
+	case 1:
+		return []byte("NOT_COMPLETED_CLEANLY"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of QueryRejectCondition.
+// Enums are logged as objects, where the value is logged with key "value", and
+// if this value's name is known, the name is logged with key "name".
+func (v QueryRejectCondition) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt32("value", int32(v))
+	switch int32(v) {
+	case 0:
+		enc.AddString("name", "NOT_OPEN")
+	case 1:
+		enc.AddString("name", "NOT_COMPLETED_CLEANLY")
+	}
+	return nil
+}
+
+// Ptr returns a pointer to this enum value.
+func (v QueryRejectCondition) Ptr() *QueryRejectCondition {
+	return &v
+}
+
+// ToWire translates QueryRejectCondition into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// Enums are represented as 32-bit integers over the wire.
+func (v QueryRejectCondition) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+// FromWire deserializes QueryRejectCondition from its Thrift-level
+// representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TI32)
+//   if err != nil {
+//     return QueryRejectCondition(0), err
+//   }
+//
+//   var v QueryRejectCondition
+//   if err := v.FromWire(x); err != nil {
+//     return QueryRejectCondition(0), err
+//   }
+//   return v, nil
+func (v *QueryRejectCondition) FromWire(w wire.Value) error {
+	*v = (QueryRejectCondition)(w.GetI32())
+	return nil
+}
+
+// String returns a readable string representation of QueryRejectCondition.
+func (v QueryRejectCondition) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "NOT_OPEN"
+	case 1:
+		return "NOT_COMPLETED_CLEANLY"
+	}
+	return fmt.Sprintf("QueryRejectCondition(%d)", w)
+}
+
+// Equals returns true if this QueryRejectCondition value matches the provided
+// value.
+func (v QueryRejectCondition) Equals(rhs QueryRejectCondition) bool {
+	return v == rhs
+}
+
+// MarshalJSON serializes QueryRejectCondition into JSON.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v QueryRejectCondition) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"NOT_OPEN\""), nil
+	case 1:
+		return ([]byte)("\"NOT_COMPLETED_CLEANLY\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON attempts to decode QueryRejectCondition from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *QueryRejectCondition) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "QueryRejectCondition")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "QueryRejectCondition")
+		}
+		*v = (QueryRejectCondition)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "QueryRejectCondition")
+	}
+}
+
+type QueryRejected struct {
+	CloseStatus *WorkflowExecutionCloseStatus `json:"closeStatus,omitempty"`
+}
+
+// ToWire translates a QueryRejected struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *QueryRejected) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.CloseStatus != nil {
+		w, err = v.CloseStatus.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a QueryRejected struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a QueryRejected struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v QueryRejected
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *QueryRejected) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x WorkflowExecutionCloseStatus
+				x, err = _WorkflowExecutionCloseStatus_Read(field.Value)
+				v.CloseStatus = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a QueryRejected
+// struct.
+func (v *QueryRejected) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.CloseStatus != nil {
+		fields[i] = fmt.Sprintf("CloseStatus: %v", *(v.CloseStatus))
+		i++
+	}
+
+	return fmt.Sprintf("QueryRejected{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this QueryRejected match the
+// provided QueryRejected.
+//
+// This function performs a deep comparison.
+func (v *QueryRejected) Equals(rhs *QueryRejected) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_WorkflowExecutionCloseStatus_EqualsPtr(v.CloseStatus, rhs.CloseStatus) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of QueryRejected.
+func (v *QueryRejected) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.CloseStatus != nil {
+		err = multierr.Append(err, enc.AddObject("closeStatus", *v.CloseStatus))
+	}
+	return err
+}
+
+// GetCloseStatus returns the value of CloseStatus if it is set or its
+// zero value if it is unset.
+func (v *QueryRejected) GetCloseStatus() (o WorkflowExecutionCloseStatus) {
+	if v.CloseStatus != nil {
+		return *v.CloseStatus
+	}
+
+	return
+}
+
 type QueryTaskCompletedType int32
 
 const (
@@ -23393,9 +26768,11 @@ func (v *QueryTaskCompletedType) UnmarshalJSON(text []byte) error {
 }
 
 type QueryWorkflowRequest struct {
-	Domain    *string            `json:"domain,omitempty"`
-	Execution *WorkflowExecution `json:"execution,omitempty"`
-	Query     *WorkflowQuery     `json:"query,omitempty"`
+	Domain                *string                `json:"domain,omitempty"`
+	Execution             *WorkflowExecution     `json:"execution,omitempty"`
+	Query                 *WorkflowQuery         `json:"query,omitempty"`
+	QueryRejectCondition  *QueryRejectCondition  `json:"queryRejectCondition,omitempty"`
+	QueryConsistencyLevel *QueryConsistencyLevel `json:"queryConsistencyLevel,omitempty"`
 }
 
 // ToWire translates a QueryWorkflowRequest struct into a Thrift-level intermediate
@@ -23415,7 +26792,7 @@ type QueryWorkflowRequest struct {
 //   }
 func (v *QueryWorkflowRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [3]wire.Field
+		fields [5]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -23445,10 +26822,38 @@ func (v *QueryWorkflowRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 30, Value: w}
 		i++
 	}
+	if v.QueryRejectCondition != nil {
+		w, err = v.QueryRejectCondition.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.QueryConsistencyLevel != nil {
+		w, err = v.QueryConsistencyLevel.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
+func _QueryRejectCondition_Read(w wire.Value) (QueryRejectCondition, error) {
+	var v QueryRejectCondition
+	err := v.FromWire(w)
+	return v, err
+}
+
+func _QueryConsistencyLevel_Read(w wire.Value) (QueryConsistencyLevel, error) {
+	var v QueryConsistencyLevel
+	err := v.FromWire(w)
+	return v, err
+}
+
 // FromWire deserializes a QueryWorkflowRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -23496,6 +26901,26 @@ func (v *QueryWorkflowRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 40:
+			if field.Value.Type() == wire.TI32 {
+				var x QueryRejectCondition
+				x, err = _QueryRejectCondition_Read(field.Value)
+				v.QueryRejectCondition = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TI32 {
+				var x QueryConsistencyLevel
+				x, err = _QueryConsistencyLevel_Read(field.Value)
+				v.QueryConsistencyLevel = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -23510,7 +26935,7 @@ func (v *QueryWorkflowRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [3]string
+	var fields [5]string
 	i := 0
 	if v.Domain != nil {
 		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
@@ -23524,10 +26949,38 @@ func (v *QueryWorkflowRequest) String() string {
 		fields[i] = fmt.Sprintf("Query: %v", v.Query)
 		i++
 	}
+	if v.QueryRejectCondition != nil {
+		fields[i] = fmt.Sprintf("QueryRejectCondition: %v", *(v.QueryRejectCondition))
+		i++
+	}
+	if v.QueryConsistencyLevel != nil {
+		fields[i] = fmt.Sprintf("QueryConsistencyLevel: %v", *(v.QueryConsistencyLevel))
+		i++
+	}
 
 	return fmt.Sprintf("QueryWorkflowRequest{%v}", strings.Join(fields[:i], ", "))
 }
 
+func _QueryRejectCondition_EqualsPtr(lhs, rhs *QueryRejectCondition) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
+func _QueryConsistencyLevel_EqualsPtr(lhs, rhs *QueryConsistencyLevel) bool {
+	if lhs != nil && rhs != nil {
+
+		x := *lhs
+		y := *rhs
+		return x.Equals(y)
+	}
+	return lhs == nil && rhs == nil
+}
+
 // Equals returns true if all the fields of this QueryWorkflowRequest match the
 // provided QueryWorkflowRequest.
 //
@@ -23547,6 +27000,12 @@ func (v *QueryWorkflowRequest) Equals(rhs *QueryWorkflowRequest) bool {
 	if !((v.Query == nil && rhs.Query == nil) || (v.Query != nil && rhs.Query != nil && v.Query.Equals(rhs.Query))) {
 		return false
 	}
+	if !_QueryRejectCondition_EqualsPtr(v.QueryRejectCondition, rhs.QueryRejectCondition) {
+		return false
+	}
+	if !_QueryConsistencyLevel_EqualsPtr(v.QueryConsistencyLevel, rhs.QueryConsistencyLevel) {
+		return false
+	}
 
 	return true
 }
@@ -23566,6 +27025,12 @@ func (v *QueryWorkflowRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err
 	if v.Query != nil {
 		err = multierr.Append(err, enc.AddObject("query", v.Query))
 	}
+	if v.QueryRejectCondition != nil {
+		err = multierr.Append(err, enc.AddObject("queryRejectCondition", *v.QueryRejectCondition))
+	}
+	if v.QueryConsistencyLevel != nil {
+		err = multierr.Append(err, enc.AddObject("queryConsistencyLevel", *v.QueryConsistencyLevel))
+	}
 	return err
 }
 
@@ -23599,8 +27064,29 @@ func (v *QueryWorkflowRequest) GetQuery() (o *WorkflowQuery) {
 	return
 }
 
+// GetQueryRejectCondition returns the value of QueryRejectCondition if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowRequest) GetQueryRejectCondition() (o QueryRejectCondition) {
+	if v.QueryRejectCondition != nil {
+		return *v.QueryRejectCondition
+	}
+
+	return
+}
+
+// GetQueryConsistencyLevel returns the value of QueryConsistencyLevel if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowRequest) GetQueryConsistencyLevel() (o QueryConsistencyLevel) {
+	if v.QueryConsistencyLevel != nil {
+		return *v.QueryConsistencyLevel
+	}
+
+	return
+}
+
 type QueryWorkflowResponse struct {
-	QueryResult []byte `json:"queryResult,omitempty"`
+	QueryResult   []byte         `json:"queryResult,omitempty"`
+	QueryRejected *QueryRejected `json:"queryRejected,omitempty"`
 }
 
 // ToWire translates a QueryWorkflowResponse struct into a Thrift-level intermediate
@@ -23620,7 +27106,7 @@ type QueryWorkflowResponse struct {
 //   }
 func (v *QueryWorkflowResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [1]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -23634,10 +27120,24 @@ func (v *QueryWorkflowResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
+	if v.QueryRejected != nil {
+		w, err = v.QueryRejected.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
+func _QueryRejected_Read(w wire.Value) (*QueryRejected, error) {
+	var v QueryRejected
+	err := v.FromWire(w)
+	return &v, err
+}
+
 // FromWire deserializes a QueryWorkflowResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -23667,6 +27167,14 @@ func (v *QueryWorkflowResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.QueryRejected, err = _QueryRejected_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -23681,12 +27189,16 @@ func (v *QueryWorkflowResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [1]string
+	var fields [2]string
 	i := 0
 	if v.QueryResult != nil {
 		fields[i] = fmt.Sprintf("QueryResult: %v", v.QueryResult)
 		i++
 	}
+	if v.QueryRejected != nil {
+		fields[i] = fmt.Sprintf("QueryRejected: %v", v.QueryRejected)
+		i++
+	}
 
 	return fmt.Sprintf("QueryWorkflowResponse{%v}", strings.Join(fields[:i], ", "))
 }
@@ -23704,6 +27216,9 @@ func (v *QueryWorkflowResponse) Equals(rhs *QueryWorkflowResponse) bool {
 	if !((v.QueryResult == nil && rhs.QueryResult == nil) || (v.QueryResult != nil && rhs.QueryResult != nil && bytes.Equal(v.QueryResult, rhs.QueryResult))) {
 		return false
 	}
+	if !((v.QueryRejected == nil && rhs.QueryRejected == nil) || (v.QueryRejected != nil && rhs.QueryRejected != nil && v.QueryRejected.Equals(rhs.QueryRejected))) {
+		return false
+	}
 
 	return true
 }
@@ -23717,6 +27232,9 @@ func (v *QueryWorkflowResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err
 	if v.QueryResult != nil {
 		enc.AddString("queryResult", base64.StdEncoding.EncodeToString(v.QueryResult))
 	}
+	if v.QueryRejected != nil {
+		err = multierr.Append(err, enc.AddObject("queryRejected", v.QueryRejected))
+	}
 	return err
 }
 
@@ -23730,6 +27248,200 @@ func (v *QueryWorkflowResponse) GetQueryResult() (o []byte) {
 	return
 }
 
+// GetQueryRejected returns the value of QueryRejected if it is set or its
+// zero value if it is unset.
+func (v *QueryWorkflowResponse) GetQueryRejected() (o *QueryRejected) {
+	if v.QueryRejected != nil {
+		return v.QueryRejected
+	}
+
+	return
+}
+
+type QueueTaskType int32
+
+const (
+	QueueTaskTypeTransfer    QueueTaskType = 0
+	QueueTaskTypeTimer       QueueTaskType = 1
+	QueueTaskTypeReplication QueueTaskType = 2
+)
+
+// QueueTaskType_Values returns all recognized values of QueueTaskType.
+func QueueTaskType_Values() []QueueTaskType {
+	return []QueueTaskType{
+		QueueTaskTypeTransfer,
+		QueueTaskTypeTimer,
+		QueueTaskTypeReplication,
+	}
+}
+
+// UnmarshalText tries to decode QueueTaskType from a byte slice
+// containing its name.
+//
+//   var v QueueTaskType
+//   err := v.UnmarshalText([]byte("TRANSFER"))
+func (v *QueueTaskType) UnmarshalText(value []byte) error {
+	switch s := string(value); s {
+	case "TRANSFER":
+		*v = QueueTaskTypeTransfer
+		return nil
+	case "TIMER":
+		*v = QueueTaskTypeTimer
+		return nil
+	case "REPLICATION":
+		*v = QueueTaskTypeReplication
+		return nil
+	default:
+		val, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return fmt.Errorf("unknown enum value %q for %q: %v", s, "QueueTaskType", err)
+		}
+		*v = QueueTaskType(val)
+		return nil
+	}
+}
+
+// MarshalText encodes QueueTaskType to text.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements the TextMarshaler interface.
+func (v QueueTaskType) MarshalText() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return []byte("TRANSFER"), nil
+	case 1:
+		return []byte("TIMER"), nil
+	case 2:
+		return []byte("REPLICATION"), nil
+	}
+	return []byte(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of QueueTaskType.
+// Enums are logged as objects, where the value is logged with key "value", and
+// if this value's name is known, the name is logged with key "name".
+func (v QueueTaskType) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	enc.AddInt32("value", int32(v))
+	switch int32(v) {
+	case 0:
+		enc.AddString("name", "TRANSFER")
+	case 1:
+		enc.AddString("name", "TIMER")
+	case 2:
+		enc.AddString("name", "REPLICATION")
+	}
+	return nil
+}
+
+// Ptr returns a pointer to this enum value.
+func (v QueueTaskType) Ptr() *QueueTaskType {
+	return &v
+}
+
+// ToWire translates QueueTaskType into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// Enums are represented as 32-bit integers over the wire.
+func (v QueueTaskType) ToWire() (wire.Value, error) {
+	return wire.NewValueI32(int32(v)), nil
+}
+
+// FromWire deserializes QueueTaskType from its Thrift-level
+// representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TI32)
+//   if err != nil {
+//     return QueueTaskType(0), err
+//   }
+//
+//   var v QueueTaskType
+//   if err := v.FromWire(x); err != nil {
+//     return QueueTaskType(0), err
+//   }
+//   return v, nil
+func (v *QueueTaskType) FromWire(w wire.Value) error {
+	*v = (QueueTaskType)(w.GetI32())
+	return nil
+}
+
+// String returns a readable string representation of QueueTaskType.
+func (v QueueTaskType) String() string {
+	w := int32(v)
+	switch w {
+	case 0:
+		return "TRANSFER"
+	case 1:
+		return "TIMER"
+	case 2:
+		return "REPLICATION"
+	}
+	return fmt.Sprintf("QueueTaskType(%d)", w)
+}
+
+// Equals returns true if this QueueTaskType value matches the provided
+// value.
+func (v QueueTaskType) Equals(rhs QueueTaskType) bool {
+	return v == rhs
+}
+
+// MarshalJSON serializes QueueTaskType into JSON.
+//
+// If the enum value is recognized, its name is returned. Otherwise,
+// its integer value is returned.
+//
+// This implements json.Marshaler.
+func (v QueueTaskType) MarshalJSON() ([]byte, error) {
+	switch int32(v) {
+	case 0:
+		return ([]byte)("\"TRANSFER\""), nil
+	case 1:
+		return ([]byte)("\"TIMER\""), nil
+	case 2:
+		return ([]byte)("\"REPLICATION\""), nil
+	}
+	return ([]byte)(strconv.FormatInt(int64(v), 10)), nil
+}
+
+// UnmarshalJSON attempts to decode QueueTaskType from its JSON
+// representation.
+//
+// This implementation supports both, numeric and string inputs. If a
+// string is provided, it must be a known enum name.
+//
+// This implements json.Unmarshaler.
+func (v *QueueTaskType) UnmarshalJSON(text []byte) error {
+	d := json.NewDecoder(bytes.NewReader(text))
+	d.UseNumber()
+	t, err := d.Token()
+	if err != nil {
+		return err
+	}
+
+	switch w := t.(type) {
+	case json.Number:
+		x, err := w.Int64()
+		if err != nil {
+			return err
+		}
+		if x > math.MaxInt32 {
+			return fmt.Errorf("enum overflow from JSON %q for %q", text, "QueueTaskType")
+		}
+		if x < math.MinInt32 {
+			return fmt.Errorf("enum underflow from JSON %q for %q", text, "QueueTaskType")
+		}
+		*v = (QueueTaskType)(x)
+		return nil
+	case string:
+		return v.UnmarshalText([]byte(w))
+	default:
+		return fmt.Errorf("invalid JSON value %q (%T) to unmarshal into %q", t, t, "QueueTaskType")
+	}
+}
+
 type RecordActivityTaskHeartbeatByIDRequest struct {
 	Domain     *string `json:"domain,omitempty"`
 	WorkflowID *string `json:"workflowID,omitempty"`
@@ -25122,6 +28834,256 @@ func (v *RegisterDomainRequest) GetArchivalBucketName() (o string) {
 	return
 }
 
+type RemoveQueueTaskRequest struct {
+	ShardID             *int32         `json:"shardID,omitempty"`
+	TaskType            *QueueTaskType `json:"taskType,omitempty"`
+	TaskID              *int64         `json:"taskID,omitempty"`
+	VisibilityTimestamp *int64         `json:"visibilityTimestamp,omitempty"`
+}
+
+// ToWire translates a RemoveQueueTaskRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *RemoveQueueTaskRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [4]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ShardID != nil {
+		w, err = wire.NewValueI32(*(v.ShardID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.TaskType != nil {
+		w, err = v.TaskType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.TaskID != nil {
+		w, err = wire.NewValueI64(*(v.TaskID)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.VisibilityTimestamp != nil {
+		w, err = wire.NewValueI64(*(v.VisibilityTimestamp)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a RemoveQueueTaskRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a RemoveQueueTaskRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v RemoveQueueTaskRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *RemoveQueueTaskRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.ShardID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TI32 {
+				var x QueueTaskType
+				x, err = _QueueTaskType_Read(field.Value)
+				v.TaskType = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.TaskID = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TI64 {
+				var x int64
+				x, err = field.Value.GetI64(), error(nil)
+				v.VisibilityTimestamp = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a RemoveQueueTaskRequest
+// struct.
+func (v *RemoveQueueTaskRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [4]string
+	i := 0
+	if v.ShardID != nil {
+		fields[i] = fmt.Sprintf("ShardID: %v", *(v.ShardID))
+		i++
+	}
+	if v.TaskType != nil {
+		fields[i] = fmt.Sprintf("TaskType: %v", *(v.TaskType))
+		i++
+	}
+	if v.TaskID != nil {
+		fields[i] = fmt.Sprintf("TaskID: %v", *(v.TaskID))
+		i++
+	}
+	if v.VisibilityTimestamp != nil {
+		fields[i] = fmt.Sprintf("VisibilityTimestamp: %v", *(v.VisibilityTimestamp))
+		i++
+	}
+
+	return fmt.Sprintf("RemoveQueueTaskRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this RemoveQueueTaskRequest match the
+// provided RemoveQueueTaskRequest.
+//
+// This function performs a deep comparison.
+func (v *RemoveQueueTaskRequest) Equals(rhs *RemoveQueueTaskRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_I32_EqualsPtr(v.ShardID, rhs.ShardID) {
+		return false
+	}
+	if !_QueueTaskType_EqualsPtr(v.TaskType, rhs.TaskType) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.TaskID, rhs.TaskID) {
+		return false
+	}
+	if !_I64_EqualsPtr(v.VisibilityTimestamp, rhs.VisibilityTimestamp) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of RemoveQueueTaskRequest.
+func (v *RemoveQueueTaskRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.ShardID != nil {
+		enc.AddInt32("shardID", *v.ShardID)
+	}
+	if v.TaskType != nil {
+		err = multierr.Append(err, enc.AddObject("taskType", *v.TaskType))
+	}
+	if v.TaskID != nil {
+		enc.AddInt64("taskID", *v.TaskID)
+	}
+	if v.VisibilityTimestamp != nil {
+		enc.AddInt64("visibilityTimestamp", *v.VisibilityTimestamp)
+	}
+	return err
+}
+
+// GetShardID returns the value of ShardID if it is set or its
+// zero value if it is unset.
+func (v *RemoveQueueTaskRequest) GetShardID() (o int32) {
+	if v.ShardID != nil {
+		return *v.ShardID
+	}
+
+	return
+}
+
+// GetTaskType returns the value of TaskType if it is set or its
+// zero value if it is unset.
+func (v *RemoveQueueTaskRequest) GetTaskType() (o QueueTaskType) {
+	if v.TaskType != nil {
+		return *v.TaskType
+	}
+
+	return
+}
+
+// GetTaskID returns the value of TaskID if it is set or its
+// zero value if it is unset.
+func (v *RemoveQueueTaskRequest) GetTaskID() (o int64) {
+	if v.TaskID != nil {
+		return *v.TaskID
+	}
+
+	return
+}
+
+// GetVisibilityTimestamp returns the value of VisibilityTimestamp if it is set or its
+// zero value if it is unset.
+func (v *RemoveQueueTaskRequest) GetVisibilityTimestamp() (o int64) {
+	if v.VisibilityTimestamp != nil {
+		return *v.VisibilityTimestamp
+	}
+
+	return
+}
+
 type ReplicationInfo struct {
 	Version     *int64 `json:"version,omitempty"`
 	LastEventId *int64 `json:"lastEventId,omitempty"`
@@ -29156,14 +33118,15 @@ func (v *RespondActivityTaskFailedRequest) GetIdentity() (o string) {
 }
 
 type RespondDecisionTaskCompletedRequest struct {
-	TaskToken                  []byte                     `json:"taskToken,omitempty"`
-	Decisions                  []*Decision                `json:"decisions,omitempty"`
-	ExecutionContext           []byte                     `json:"executionContext,omitempty"`
-	Identity                   *string                    `json:"identity,omitempty"`
-	StickyAttributes           *StickyExecutionAttributes `json:"stickyAttributes,omitempty"`
-	ReturnNewDecisionTask      *bool                      `json:"returnNewDecisionTask,omitempty"`
-	ForceCreateNewDecisionTask *bool                      `json:"forceCreateNewDecisionTask,omitempty"`
-	BinaryChecksum             *string                    `json:"binaryChecksum,omitempty"`
+	TaskToken                  []byte                          `json:"taskToken,omitempty"`
+	Decisions                  []*Decision                     `json:"decisions,omitempty"`
+	ExecutionContext           []byte                          `json:"executionContext,omitempty"`
+	Identity                   *string                         `json:"identity,omitempty"`
+	StickyAttributes           *StickyExecutionAttributes      `json:"stickyAttributes,omitempty"`
+	ReturnNewDecisionTask      *bool                           `json:"returnNewDecisionTask,omitempty"`
+	ForceCreateNewDecisionTask *bool                           `json:"forceCreateNewDecisionTask,omitempty"`
+	BinaryChecksum             *string                         `json:"binaryChecksum,omitempty"`
+	QueryResults               map[string]*WorkflowQueryResult `json:"queryResults,omitempty"`
 }
 
 type _List_Decision_ValueList []*Decision
@@ -29195,6 +33158,44 @@ func (_List_Decision_ValueList) ValueType() wire.Type {
 
 func (_List_Decision_ValueList) Close() {}
 
+type _Map_String_WorkflowQueryResult_MapItemList map[string]*WorkflowQueryResult
+
+func (m _Map_String_WorkflowQueryResult_MapItemList) ForEach(f func(wire.MapItem) error) error {
+	for k, v := range m {
+		if v == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", k)
+		}
+		kw, err := wire.NewValueString(k), error(nil)
+		if err != nil {
+			return err
+		}
+
+		vw, err := v.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(wire.MapItem{Key: kw, Value: vw})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m _Map_String_WorkflowQueryResult_MapItemList) Size() int {
+	return len(m)
+}
+
+func (_Map_String_WorkflowQueryResult_MapItemList) KeyType() wire.Type {
+	return wire.TBinary
+}
+
+func (_Map_String_WorkflowQueryResult_MapItemList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_Map_String_WorkflowQueryResult_MapItemList) Close() {}
+
 // ToWire translates a RespondDecisionTaskCompletedRequest struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -29212,7 +33213,7 @@ func (_List_Decision_ValueList) Close() {}
 //   }
 func (v *RespondDecisionTaskCompletedRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [8]wire.Field
+		fields [9]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -29282,6 +33283,14 @@ func (v *RespondDecisionTaskCompletedRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 80, Value: w}
 		i++
 	}
+	if v.QueryResults != nil {
+		w, err = wire.NewValueMap(_Map_String_WorkflowQueryResult_MapItemList(v.QueryResults)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 90, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -29316,6 +33325,40 @@ func _StickyExecutionAttributes_Read(w wire.Value) (*StickyExecutionAttributes,
 	return &v, err
 }
 
+func _WorkflowQueryResult_Read(w wire.Value) (*WorkflowQueryResult, error) {
+	var v WorkflowQueryResult
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _Map_String_WorkflowQueryResult_Read(m wire.MapItemList) (map[string]*WorkflowQueryResult, error) {
+	if m.KeyType() != wire.TBinary {
+		return nil, nil
+	}
+
+	if m.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make(map[string]*WorkflowQueryResult, m.Size())
+	err := m.ForEach(func(x wire.MapItem) error {
+		k, err := x.Key.GetString(), error(nil)
+		if err != nil {
+			return err
+		}
+
+		v, err := _WorkflowQueryResult_Read(x.Value)
+		if err != nil {
+			return err
+		}
+
+		o[k] = v
+		return nil
+	})
+	m.Close()
+	return o, err
+}
+
 // FromWire deserializes a RespondDecisionTaskCompletedRequest struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -29409,6 +33452,14 @@ func (v *RespondDecisionTaskCompletedRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 90:
+			if field.Value.Type() == wire.TMap {
+				v.QueryResults, err = _Map_String_WorkflowQueryResult_Read(field.Value.GetMap())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -29423,7 +33474,7 @@ func (v *RespondDecisionTaskCompletedRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [8]string
+	var fields [9]string
 	i := 0
 	if v.TaskToken != nil {
 		fields[i] = fmt.Sprintf("TaskToken: %v", v.TaskToken)
@@ -29457,6 +33508,10 @@ func (v *RespondDecisionTaskCompletedRequest) String() string {
 		fields[i] = fmt.Sprintf("BinaryChecksum: %v", *(v.BinaryChecksum))
 		i++
 	}
+	if v.QueryResults != nil {
+		fields[i] = fmt.Sprintf("QueryResults: %v", v.QueryResults)
+		i++
+	}
 
 	return fmt.Sprintf("RespondDecisionTaskCompletedRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -29476,6 +33531,23 @@ func _List_Decision_Equals(lhs, rhs []*Decision) bool {
 	return true
 }
 
+func _Map_String_WorkflowQueryResult_Equals(lhs, rhs map[string]*WorkflowQueryResult) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for lk, lv := range lhs {
+		rv, ok := rhs[lk]
+		if !ok {
+			return false
+		}
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+	return true
+}
+
 // Equals returns true if all the fields of this RespondDecisionTaskCompletedRequest match the
 // provided RespondDecisionTaskCompletedRequest.
 //
@@ -29510,6 +33582,9 @@ func (v *RespondDecisionTaskCompletedRequest) Equals(rhs *RespondDecisionTaskCom
 	if !_String_EqualsPtr(v.BinaryChecksum, rhs.BinaryChecksum) {
 		return false
 	}
+	if !((v.QueryResults == nil && rhs.QueryResults == nil) || (v.QueryResults != nil && rhs.QueryResults != nil && _Map_String_WorkflowQueryResult_Equals(v.QueryResults, rhs.QueryResults))) {
+		return false
+	}
 
 	return true
 }
@@ -29525,6 +33600,17 @@ func (l _List_Decision_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err er
 	return err
 }
 
+type _Map_String_WorkflowQueryResult_Zapper map[string]*WorkflowQueryResult
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of _Map_String_WorkflowQueryResult_Zapper.
+func (m _Map_String_WorkflowQueryResult_Zapper) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	for k, v := range m {
+		err = multierr.Append(err, enc.AddObject((string)(k), v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of RespondDecisionTaskCompletedRequest.
 func (v *RespondDecisionTaskCompletedRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -29555,6 +33641,9 @@ func (v *RespondDecisionTaskCompletedRequest) MarshalLogObject(enc zapcore.Objec
 	if v.BinaryChecksum != nil {
 		enc.AddString("binaryChecksum", *v.BinaryChecksum)
 	}
+	if v.QueryResults != nil {
+		err = multierr.Append(err, enc.AddObject("queryResults", (_Map_String_WorkflowQueryResult_Zapper)(v.QueryResults)))
+	}
 	return err
 }
 
@@ -29638,10 +33727,50 @@ func (v *RespondDecisionTaskCompletedRequest) GetBinaryChecksum() (o string) {
 	return
 }
 
+// GetQueryResults returns the value of QueryResults if it is set or its
+// zero value if it is unset.
+func (v *RespondDecisionTaskCompletedRequest) GetQueryResults() (o map[string]*WorkflowQueryResult) {
+	if v.QueryResults != nil {
+		return v.QueryResults
+	}
+
+	return
+}
+
 type RespondDecisionTaskCompletedResponse struct {
-	DecisionTask *PollForDecisionTaskResponse `json:"decisionTask,omitempty"`
+	DecisionTask  *PollForDecisionTaskResponse   `json:"decisionTask,omitempty"`
+	ActivityTasks []*PollForActivityTaskResponse `json:"activityTasks,omitempty"`
 }
 
+type _List_PollForActivityTaskResponse_ValueList []*PollForActivityTaskResponse
+
+func (v _List_PollForActivityTaskResponse_ValueList) ForEach(f func(wire.Value) error) error {
+	for i, x := range v {
+		if x == nil {
+			return fmt.Errorf("invalid [%v]: value is nil", i)
+		}
+		w, err := x.ToWire()
+		if err != nil {
+			return err
+		}
+		err = f(w)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (v _List_PollForActivityTaskResponse_ValueList) Size() int {
+	return len(v)
+}
+
+func (_List_PollForActivityTaskResponse_ValueList) ValueType() wire.Type {
+	return wire.TStruct
+}
+
+func (_List_PollForActivityTaskResponse_ValueList) Close() {}
+
 // ToWire translates a RespondDecisionTaskCompletedResponse struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -29659,7 +33788,7 @@ type RespondDecisionTaskCompletedResponse struct {
 //   }
 func (v *RespondDecisionTaskCompletedResponse) ToWire() (wire.Value, error) {
 	var (
-		fields [1]wire.Field
+		fields [2]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -29673,6 +33802,14 @@ func (v *RespondDecisionTaskCompletedResponse) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 10, Value: w}
 		i++
 	}
+	if v.ActivityTasks != nil {
+		w, err = wire.NewValueList(_List_PollForActivityTaskResponse_ValueList(v.ActivityTasks)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -29683,6 +33820,30 @@ func _PollForDecisionTaskResponse_Read(w wire.Value) (*PollForDecisionTaskRespon
 	return &v, err
 }
 
+func _PollForActivityTaskResponse_Read(w wire.Value) (*PollForActivityTaskResponse, error) {
+	var v PollForActivityTaskResponse
+	err := v.FromWire(w)
+	return &v, err
+}
+
+func _List_PollForActivityTaskResponse_Read(l wire.ValueList) ([]*PollForActivityTaskResponse, error) {
+	if l.ValueType() != wire.TStruct {
+		return nil, nil
+	}
+
+	o := make([]*PollForActivityTaskResponse, 0, l.Size())
+	err := l.ForEach(func(x wire.Value) error {
+		i, err := _PollForActivityTaskResponse_Read(x)
+		if err != nil {
+			return err
+		}
+		o = append(o, i)
+		return nil
+	})
+	l.Close()
+	return o, err
+}
+
 // FromWire deserializes a RespondDecisionTaskCompletedResponse struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -29712,6 +33873,14 @@ func (v *RespondDecisionTaskCompletedResponse) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 20:
+			if field.Value.Type() == wire.TList {
+				v.ActivityTasks, err = _List_PollForActivityTaskResponse_Read(field.Value.GetList())
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -29726,16 +33895,35 @@ func (v *RespondDecisionTaskCompletedResponse) String() string {
 		return "<nil>"
 	}
 
-	var fields [1]string
+	var fields [2]string
 	i := 0
 	if v.DecisionTask != nil {
 		fields[i] = fmt.Sprintf("DecisionTask: %v", v.DecisionTask)
 		i++
 	}
+	if v.ActivityTasks != nil {
+		fields[i] = fmt.Sprintf("ActivityTasks: %v", v.ActivityTasks)
+		i++
+	}
 
 	return fmt.Sprintf("RespondDecisionTaskCompletedResponse{%v}", strings.Join(fields[:i], ", "))
 }
 
+func _List_PollForActivityTaskResponse_Equals(lhs, rhs []*PollForActivityTaskResponse) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+
+	for i, lv := range lhs {
+		rv := rhs[i]
+		if !lv.Equals(rv) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // Equals returns true if all the fields of this RespondDecisionTaskCompletedResponse match the
 // provided RespondDecisionTaskCompletedResponse.
 //
@@ -29749,10 +33937,24 @@ func (v *RespondDecisionTaskCompletedResponse) Equals(rhs *RespondDecisionTaskCo
 	if !((v.DecisionTask == nil && rhs.DecisionTask == nil) || (v.DecisionTask != nil && rhs.DecisionTask != nil && v.DecisionTask.Equals(rhs.DecisionTask))) {
 		return false
 	}
+	if !((v.ActivityTasks == nil && rhs.ActivityTasks == nil) || (v.ActivityTasks != nil && rhs.ActivityTasks != nil && _List_PollForActivityTaskResponse_Equals(v.ActivityTasks, rhs.ActivityTasks))) {
+		return false
+	}
 
 	return true
 }
 
+type _List_PollForActivityTaskResponse_Zapper []*PollForActivityTaskResponse
+
+// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
+// fast logging of _List_PollForActivityTaskResponse_Zapper.
+func (l _List_PollForActivityTaskResponse_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
+	for _, v := range l {
+		err = multierr.Append(err, enc.AppendObject(v))
+	}
+	return err
+}
+
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of RespondDecisionTaskCompletedResponse.
 func (v *RespondDecisionTaskCompletedResponse) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -29762,6 +33964,9 @@ func (v *RespondDecisionTaskCompletedResponse) MarshalLogObject(enc zapcore.Obje
 	if v.DecisionTask != nil {
 		err = multierr.Append(err, enc.AddObject("decisionTask", v.DecisionTask))
 	}
+	if v.ActivityTasks != nil {
+		err = multierr.Append(err, enc.AddArray("activityTasks", (_List_PollForActivityTaskResponse_Zapper)(v.ActivityTasks)))
+	}
 	return err
 }
 
@@ -29775,6 +33980,16 @@ func (v *RespondDecisionTaskCompletedResponse) GetDecisionTask() (o *PollForDeci
 	return
 }
 
+// GetActivityTasks returns the value of ActivityTasks if it is set or its
+// zero value if it is unset.
+func (v *RespondDecisionTaskCompletedResponse) GetActivityTasks() (o []*PollForActivityTaskResponse) {
+	if v.ActivityTasks != nil {
+		return v.ActivityTasks
+	}
+
+	return
+}
+
 type RespondDecisionTaskFailedRequest struct {
 	TaskToken []byte                   `json:"taskToken,omitempty"`
 	Cause     *DecisionTaskFailedCause `json:"cause,omitempty"`
@@ -30292,32 +34507,6 @@ type RetryPolicy struct {
 	ExpirationIntervalInSeconds *int32   `json:"expirationIntervalInSeconds,omitempty"`
 }
 
-type _List_String_ValueList []string
-
-func (v _List_String_ValueList) ForEach(f func(wire.Value) error) error {
-	for _, x := range v {
-		w, err := wire.NewValueString(x), error(nil)
-		if err != nil {
-			return err
-		}
-		err = f(w)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-func (v _List_String_ValueList) Size() int {
-	return len(v)
-}
-
-func (_List_String_ValueList) ValueType() wire.Type {
-	return wire.TBinary
-}
-
-func (_List_String_ValueList) Close() {}
-
 // ToWire translates a RetryPolicy struct into a Thrift-level intermediate
 // representation. This intermediate representation may be serialized
 // into bytes using a ThriftRW protocol implementation.
@@ -30393,24 +34582,6 @@ func (v *RetryPolicy) ToWire() (wire.Value, error) {
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-func _List_String_Read(l wire.ValueList) ([]string, error) {
-	if l.ValueType() != wire.TBinary {
-		return nil, nil
-	}
-
-	o := make([]string, 0, l.Size())
-	err := l.ForEach(func(x wire.Value) error {
-		i, err := x.GetString(), error(nil)
-		if err != nil {
-			return err
-		}
-		o = append(o, i)
-		return nil
-	})
-	l.Close()
-	return o, err
-}
-
 // FromWire deserializes a RetryPolicy struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
@@ -30544,21 +34715,6 @@ func _Double_EqualsPtr(lhs, rhs *float64) bool {
 	return lhs == nil && rhs == nil
 }
 
-func _List_String_Equals(lhs, rhs []string) bool {
-	if len(lhs) != len(rhs) {
-		return false
-	}
-
-	for i, lv := range lhs {
-		rv := rhs[i]
-		if !(lv == rv) {
-			return false
-		}
-	}
-
-	return true
-}
-
 // Equals returns true if all the fields of this RetryPolicy match the
 // provided RetryPolicy.
 //
@@ -30591,17 +34747,6 @@ func (v *RetryPolicy) Equals(rhs *RetryPolicy) bool {
 	return true
 }
 
-type _List_String_Zapper []string
-
-// MarshalLogArray implements zapcore.ArrayMarshaler, enabling
-// fast logging of _List_String_Zapper.
-func (l _List_String_Zapper) MarshalLogArray(enc zapcore.ArrayEncoder) (err error) {
-	for _, v := range l {
-		enc.AppendString(v)
-	}
-	return err
-}
-
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
 // fast logging of RetryPolicy.
 func (v *RetryPolicy) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
@@ -30985,6 +35130,8 @@ type ScheduleActivityTaskDecisionAttributes struct {
 	StartToCloseTimeoutSeconds    *int32        `json:"startToCloseTimeoutSeconds,omitempty"`
 	HeartbeatTimeoutSeconds       *int32        `json:"heartbeatTimeoutSeconds,omitempty"`
 	RetryPolicy                   *RetryPolicy  `json:"retryPolicy,omitempty"`
+	Priority                      *int32        `json:"priority,omitempty"`
+	RequestEagerExecution         *bool         `json:"requestEagerExecution,omitempty"`
 }
 
 // ToWire translates a ScheduleActivityTaskDecisionAttributes struct into a Thrift-level intermediate
@@ -31004,7 +35151,7 @@ type ScheduleActivityTaskDecisionAttributes struct {
 //   }
 func (v *ScheduleActivityTaskDecisionAttributes) ToWire() (wire.Value, error) {
 	var (
-		fields [10]wire.Field
+		fields [12]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -31090,6 +35237,22 @@ func (v *ScheduleActivityTaskDecisionAttributes) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 70, Value: w}
 		i++
 	}
+	if v.Priority != nil {
+		w, err = wire.NewValueI32(*(v.Priority)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 80, Value: w}
+		i++
+	}
+	if v.RequestEagerExecution != nil {
+		w, err = wire.NewValueBool(*(v.RequestEagerExecution)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 90, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -31207,6 +35370,26 @@ func (v *ScheduleActivityTaskDecisionAttributes) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 80:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Priority = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 90:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.RequestEagerExecution = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -31221,7 +35404,7 @@ func (v *ScheduleActivityTaskDecisionAttributes) String() string {
 		return "<nil>"
 	}
 
-	var fields [10]string
+	var fields [12]string
 	i := 0
 	if v.ActivityId != nil {
 		fields[i] = fmt.Sprintf("ActivityId: %v", *(v.ActivityId))
@@ -31263,6 +35446,14 @@ func (v *ScheduleActivityTaskDecisionAttributes) String() string {
 		fields[i] = fmt.Sprintf("RetryPolicy: %v", v.RetryPolicy)
 		i++
 	}
+	if v.Priority != nil {
+		fields[i] = fmt.Sprintf("Priority: %v", *(v.Priority))
+		i++
+	}
+	if v.RequestEagerExecution != nil {
+		fields[i] = fmt.Sprintf("RequestEagerExecution: %v", *(v.RequestEagerExecution))
+		i++
+	}
 
 	return fmt.Sprintf("ScheduleActivityTaskDecisionAttributes{%v}", strings.Join(fields[:i], ", "))
 }
@@ -31307,6 +35498,12 @@ func (v *ScheduleActivityTaskDecisionAttributes) Equals(rhs *ScheduleActivityTas
 	if !((v.RetryPolicy == nil && rhs.RetryPolicy == nil) || (v.RetryPolicy != nil && rhs.RetryPolicy != nil && v.RetryPolicy.Equals(rhs.RetryPolicy))) {
 		return false
 	}
+	if !_I32_EqualsPtr(v.Priority, rhs.Priority) {
+		return false
+	}
+	if !_Bool_EqualsPtr(v.RequestEagerExecution, rhs.RequestEagerExecution) {
+		return false
+	}
 
 	return true
 }
@@ -31347,6 +35544,12 @@ func (v *ScheduleActivityTaskDecisionAttributes) MarshalLogObject(enc zapcore.Ob
 	if v.RetryPolicy != nil {
 		err = multierr.Append(err, enc.AddObject("retryPolicy", v.RetryPolicy))
 	}
+	if v.Priority != nil {
+		enc.AddInt32("priority", *v.Priority)
+	}
+	if v.RequestEagerExecution != nil {
+		enc.AddBool("requestEagerExecution", *v.RequestEagerExecution)
+	}
 	return err
 }
 
@@ -31450,6 +35653,26 @@ func (v *ScheduleActivityTaskDecisionAttributes) GetRetryPolicy() (o *RetryPolic
 	return
 }
 
+// GetPriority returns the value of Priority if it is set or its
+// zero value if it is unset.
+func (v *ScheduleActivityTaskDecisionAttributes) GetPriority() (o int32) {
+	if v.Priority != nil {
+		return *v.Priority
+	}
+
+	return
+}
+
+// GetRequestEagerExecution returns the value of RequestEagerExecution if it is set or its
+// zero value if it is unset.
+func (v *ScheduleActivityTaskDecisionAttributes) GetRequestEagerExecution() (o bool) {
+	if v.RequestEagerExecution != nil {
+		return *v.RequestEagerExecution
+	}
+
+	return
+}
+
 type ServiceBusyError struct {
 	Message string `json:"message,required"`
 }
@@ -32776,6 +36999,7 @@ type SignalWithStartWorkflowExecutionRequest struct {
 	Control                             []byte                 `json:"control,omitempty"`
 	RetryPolicy                         *RetryPolicy           `json:"retryPolicy,omitempty"`
 	CronSchedule                        *string                `json:"cronSchedule,omitempty"`
+	DelayStartSeconds                   *int32                 `json:"delayStartSeconds,omitempty"`
 }
 
 // ToWire translates a SignalWithStartWorkflowExecutionRequest struct into a Thrift-level intermediate
@@ -32795,7 +37019,7 @@ type SignalWithStartWorkflowExecutionRequest struct {
 //   }
 func (v *SignalWithStartWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [15]wire.Field
+		fields [16]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -32921,6 +37145,14 @@ func (v *SignalWithStartWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 150, Value: w}
 		i++
 	}
+	if v.DelayStartSeconds != nil {
+		w, err = wire.NewValueI32(*(v.DelayStartSeconds)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 160, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -33090,6 +37322,16 @@ func (v *SignalWithStartWorkflowExecutionRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 160:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.DelayStartSeconds = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -33104,7 +37346,7 @@ func (v *SignalWithStartWorkflowExecutionRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [15]string
+	var fields [16]string
 	i := 0
 	if v.Domain != nil {
 		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
@@ -33166,6 +37408,10 @@ func (v *SignalWithStartWorkflowExecutionRequest) String() string {
 		fields[i] = fmt.Sprintf("CronSchedule: %v", *(v.CronSchedule))
 		i++
 	}
+	if v.DelayStartSeconds != nil {
+		fields[i] = fmt.Sprintf("DelayStartSeconds: %v", *(v.DelayStartSeconds))
+		i++
+	}
 
 	return fmt.Sprintf("SignalWithStartWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -33235,6 +37481,9 @@ func (v *SignalWithStartWorkflowExecutionRequest) Equals(rhs *SignalWithStartWor
 	if !_String_EqualsPtr(v.CronSchedule, rhs.CronSchedule) {
 		return false
 	}
+	if !_I32_EqualsPtr(v.DelayStartSeconds, rhs.DelayStartSeconds) {
+		return false
+	}
 
 	return true
 }
@@ -33290,6 +37539,9 @@ func (v *SignalWithStartWorkflowExecutionRequest) MarshalLogObject(enc zapcore.O
 	if v.CronSchedule != nil {
 		enc.AddString("cronSchedule", *v.CronSchedule)
 	}
+	if v.DelayStartSeconds != nil {
+		enc.AddInt32("delayStartSeconds", *v.DelayStartSeconds)
+	}
 	return err
 }
 
@@ -33443,6 +37695,16 @@ func (v *SignalWithStartWorkflowExecutionRequest) GetCronSchedule() (o string) {
 	return
 }
 
+// GetDelayStartSeconds returns the value of DelayStartSeconds if it is set or its
+// zero value if it is unset.
+func (v *SignalWithStartWorkflowExecutionRequest) GetDelayStartSeconds() (o int32) {
+	if v.DelayStartSeconds != nil {
+		return *v.DelayStartSeconds
+	}
+
+	return
+}
+
 type SignalWorkflowExecutionRequest struct {
 	Domain            *string            `json:"domain,omitempty"`
 	WorkflowExecution *WorkflowExecution `json:"workflowExecution,omitempty"`
@@ -35700,6 +39962,8 @@ type StartWorkflowExecutionRequest struct {
 	ChildPolicy                         *ChildPolicy           `json:"childPolicy,omitempty"`
 	RetryPolicy                         *RetryPolicy           `json:"retryPolicy,omitempty"`
 	CronSchedule                        *string                `json:"cronSchedule,omitempty"`
+	DelayStartSeconds                   *int32                 `json:"delayStartSeconds,omitempty"`
+	Priority                            *int32                 `json:"priority,omitempty"`
 }
 
 // ToWire translates a StartWorkflowExecutionRequest struct into a Thrift-level intermediate
@@ -35719,7 +39983,7 @@ type StartWorkflowExecutionRequest struct {
 //   }
 func (v *StartWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 	var (
-		fields [13]wire.Field
+		fields [15]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -35829,6 +40093,22 @@ func (v *StartWorkflowExecutionRequest) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 130, Value: w}
 		i++
 	}
+	if v.DelayStartSeconds != nil {
+		w, err = wire.NewValueI32(*(v.DelayStartSeconds)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 140, Value: w}
+		i++
+	}
+	if v.Priority != nil {
+		w, err = wire.NewValueI32(*(v.Priority)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 150, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -35976,6 +40256,26 @@ func (v *StartWorkflowExecutionRequest) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 140:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.DelayStartSeconds = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 150:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Priority = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -35990,7 +40290,7 @@ func (v *StartWorkflowExecutionRequest) String() string {
 		return "<nil>"
 	}
 
-	var fields [13]string
+	var fields [15]string
 	i := 0
 	if v.Domain != nil {
 		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
@@ -36044,6 +40344,14 @@ func (v *StartWorkflowExecutionRequest) String() string {
 		fields[i] = fmt.Sprintf("CronSchedule: %v", *(v.CronSchedule))
 		i++
 	}
+	if v.DelayStartSeconds != nil {
+		fields[i] = fmt.Sprintf("DelayStartSeconds: %v", *(v.DelayStartSeconds))
+		i++
+	}
+	if v.Priority != nil {
+		fields[i] = fmt.Sprintf("Priority: %v", *(v.Priority))
+		i++
+	}
 
 	return fmt.Sprintf("StartWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
 }
@@ -36097,6 +40405,12 @@ func (v *StartWorkflowExecutionRequest) Equals(rhs *StartWorkflowExecutionReques
 	if !_String_EqualsPtr(v.CronSchedule, rhs.CronSchedule) {
 		return false
 	}
+	if !_I32_EqualsPtr(v.DelayStartSeconds, rhs.DelayStartSeconds) {
+		return false
+	}
+	if !_I32_EqualsPtr(v.Priority, rhs.Priority) {
+		return false
+	}
 
 	return true
 }
@@ -36146,6 +40460,12 @@ func (v *StartWorkflowExecutionRequest) MarshalLogObject(enc zapcore.ObjectEncod
 	if v.CronSchedule != nil {
 		enc.AddString("cronSchedule", *v.CronSchedule)
 	}
+	if v.DelayStartSeconds != nil {
+		enc.AddInt32("delayStartSeconds", *v.DelayStartSeconds)
+	}
+	if v.Priority != nil {
+		enc.AddInt32("priority", *v.Priority)
+	}
 	return err
 }
 
@@ -36279,6 +40599,26 @@ func (v *StartWorkflowExecutionRequest) GetCronSchedule() (o string) {
 	return
 }
 
+// GetDelayStartSeconds returns the value of DelayStartSeconds if it is set or its
+// zero value if it is unset.
+func (v *StartWorkflowExecutionRequest) GetDelayStartSeconds() (o int32) {
+	if v.DelayStartSeconds != nil {
+		return *v.DelayStartSeconds
+	}
+
+	return
+}
+
+// GetPriority returns the value of Priority if it is set or its
+// zero value if it is unset.
+func (v *StartWorkflowExecutionRequest) GetPriority() (o int32) {
+	if v.Priority != nil {
+		return *v.Priority
+	}
+
+	return
+}
+
 type StartWorkflowExecutionResponse struct {
 	RunId *string `json:"runId,omitempty"`
 }
@@ -38528,6 +42868,291 @@ func (v *TransientDecisionInfo) GetStartedEvent() (o *HistoryEvent) {
 	return
 }
 
+type UnpauseWorkflowExecutionRequest struct {
+	Domain            *string            `json:"domain,omitempty"`
+	WorkflowExecution *WorkflowExecution `json:"workflowExecution,omitempty"`
+	Reason            *string            `json:"reason,omitempty"`
+	Details           []byte             `json:"details,omitempty"`
+	Identity          *string            `json:"identity,omitempty"`
+}
+
+// ToWire translates a UnpauseWorkflowExecutionRequest struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *UnpauseWorkflowExecutionRequest) ToWire() (wire.Value, error) {
+	var (
+		fields [5]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Domain != nil {
+		w, err = wire.NewValueString(*(v.Domain)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		w, err = v.WorkflowExecution.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Reason != nil {
+		w, err = wire.NewValueString(*(v.Reason)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+	if v.Details != nil {
+		w, err = wire.NewValueBinary(v.Details), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 40, Value: w}
+		i++
+	}
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 50, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a UnpauseWorkflowExecutionRequest struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a UnpauseWorkflowExecutionRequest struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v UnpauseWorkflowExecutionRequest
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *UnpauseWorkflowExecutionRequest) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Domain = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TStruct {
+				v.WorkflowExecution, err = _WorkflowExecution_Read(field.Value)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Reason = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 40:
+			if field.Value.Type() == wire.TBinary {
+				v.Details, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 50:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a UnpauseWorkflowExecutionRequest
+// struct.
+func (v *UnpauseWorkflowExecutionRequest) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [5]string
+	i := 0
+	if v.Domain != nil {
+		fields[i] = fmt.Sprintf("Domain: %v", *(v.Domain))
+		i++
+	}
+	if v.WorkflowExecution != nil {
+		fields[i] = fmt.Sprintf("WorkflowExecution: %v", v.WorkflowExecution)
+		i++
+	}
+	if v.Reason != nil {
+		fields[i] = fmt.Sprintf("Reason: %v", *(v.Reason))
+		i++
+	}
+	if v.Details != nil {
+		fields[i] = fmt.Sprintf("Details: %v", v.Details)
+		i++
+	}
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+		i++
+	}
+
+	return fmt.Sprintf("UnpauseWorkflowExecutionRequest{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this UnpauseWorkflowExecutionRequest match the
+// provided UnpauseWorkflowExecutionRequest.
+//
+// This function performs a deep comparison.
+func (v *UnpauseWorkflowExecutionRequest) Equals(rhs *UnpauseWorkflowExecutionRequest) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Domain, rhs.Domain) {
+		return false
+	}
+	if !((v.WorkflowExecution == nil && rhs.WorkflowExecution == nil) || (v.WorkflowExecution != nil && rhs.WorkflowExecution != nil && v.WorkflowExecution.Equals(rhs.WorkflowExecution))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Reason, rhs.Reason) {
+		return false
+	}
+	if !((v.Details == nil && rhs.Details == nil) || (v.Details != nil && rhs.Details != nil && bytes.Equal(v.Details, rhs.Details))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of UnpauseWorkflowExecutionRequest.
+func (v *UnpauseWorkflowExecutionRequest) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Domain != nil {
+		enc.AddString("domain", *v.Domain)
+	}
+	if v.WorkflowExecution != nil {
+		err = multierr.Append(err, enc.AddObject("workflowExecution", v.WorkflowExecution))
+	}
+	if v.Reason != nil {
+		enc.AddString("reason", *v.Reason)
+	}
+	if v.Details != nil {
+		enc.AddString("details", base64.StdEncoding.EncodeToString(v.Details))
+	}
+	if v.Identity != nil {
+		enc.AddString("identity", *v.Identity)
+	}
+	return err
+}
+
+// GetDomain returns the value of Domain if it is set or its
+// zero value if it is unset.
+func (v *UnpauseWorkflowExecutionRequest) GetDomain() (o string) {
+	if v.Domain != nil {
+		return *v.Domain
+	}
+
+	return
+}
+
+// GetWorkflowExecution returns the value of WorkflowExecution if it is set or its
+// zero value if it is unset.
+func (v *UnpauseWorkflowExecutionRequest) GetWorkflowExecution() (o *WorkflowExecution) {
+	if v.WorkflowExecution != nil {
+		return v.WorkflowExecution
+	}
+
+	return
+}
+
+// GetReason returns the value of Reason if it is set or its
+// zero value if it is unset.
+func (v *UnpauseWorkflowExecutionRequest) GetReason() (o string) {
+	if v.Reason != nil {
+		return *v.Reason
+	}
+
+	return
+}
+
+// GetDetails returns the value of Details if it is set or its
+// zero value if it is unset.
+func (v *UnpauseWorkflowExecutionRequest) GetDetails() (o []byte) {
+	if v.Details != nil {
+		return v.Details
+	}
+
+	return
+}
+
+// GetIdentity returns the value of Identity if it is set or its
+// zero value if it is unset.
+func (v *UnpauseWorkflowExecutionRequest) GetIdentity() (o string) {
+	if v.Identity != nil {
+		return *v.Identity
+	}
+
+	return
+}
+
 type UpdateDomainInfo struct {
 	Description *string           `json:"description,omitempty"`
 	OwnerEmail  *string           `json:"ownerEmail,omitempty"`
@@ -41656,6 +46281,7 @@ type WorkflowExecutionInfo struct {
 	CloseTime     *int64                        `json:"closeTime,omitempty"`
 	CloseStatus   *WorkflowExecutionCloseStatus `json:"closeStatus,omitempty"`
 	HistoryLength *int64                        `json:"historyLength,omitempty"`
+	Paused        *bool                         `json:"paused,omitempty"`
 }
 
 // ToWire translates a WorkflowExecutionInfo struct into a Thrift-level intermediate
@@ -41675,7 +46301,7 @@ type WorkflowExecutionInfo struct {
 //   }
 func (v *WorkflowExecutionInfo) ToWire() (wire.Value, error) {
 	var (
-		fields [6]wire.Field
+		fields [7]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -41729,6 +46355,14 @@ func (v *WorkflowExecutionInfo) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 60, Value: w}
 		i++
 	}
+	if v.Paused != nil {
+		w, err = wire.NewValueBool(*(v.Paused)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 70, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -41810,6 +46444,16 @@ func (v *WorkflowExecutionInfo) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 70:
+			if field.Value.Type() == wire.TBool {
+				var x bool
+				x, err = field.Value.GetBool(), error(nil)
+				v.Paused = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -41824,7 +46468,7 @@ func (v *WorkflowExecutionInfo) String() string {
 		return "<nil>"
 	}
 
-	var fields [6]string
+	var fields [7]string
 	i := 0
 	if v.Execution != nil {
 		fields[i] = fmt.Sprintf("Execution: %v", v.Execution)
@@ -41850,6 +46494,10 @@ func (v *WorkflowExecutionInfo) String() string {
 		fields[i] = fmt.Sprintf("HistoryLength: %v", *(v.HistoryLength))
 		i++
 	}
+	if v.Paused != nil {
+		fields[i] = fmt.Sprintf("Paused: %v", *(v.Paused))
+		i++
+	}
 
 	return fmt.Sprintf("WorkflowExecutionInfo{%v}", strings.Join(fields[:i], ", "))
 }
@@ -41882,6 +46530,9 @@ func (v *WorkflowExecutionInfo) Equals(rhs *WorkflowExecutionInfo) bool {
 	if !_I64_EqualsPtr(v.HistoryLength, rhs.HistoryLength) {
 		return false
 	}
+	if !_Bool_EqualsPtr(v.Paused, rhs.Paused) {
+		return false
+	}
 
 	return true
 }
@@ -41910,6 +46561,9 @@ func (v *WorkflowExecutionInfo) MarshalLogObject(enc zapcore.ObjectEncoder) (err
 	if v.HistoryLength != nil {
 		enc.AddInt64("historyLength", *v.HistoryLength)
 	}
+	if v.Paused != nil {
+		enc.AddBool("paused", *v.Paused)
+	}
 	return err
 }
 
@@ -41973,6 +46627,225 @@ func (v *WorkflowExecutionInfo) GetHistoryLength() (o int64) {
 	return
 }
 
+// GetPaused returns the value of Paused if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionInfo) GetPaused() (o bool) {
+	if v.Paused != nil {
+		return *v.Paused
+	}
+
+	return
+}
+
+type WorkflowExecutionPausedEventAttributes struct {
+	Reason   *string `json:"reason,omitempty"`
+	Details  []byte  `json:"details,omitempty"`
+	Identity *string `json:"identity,omitempty"`
+}
+
+// ToWire translates a WorkflowExecutionPausedEventAttributes struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *WorkflowExecutionPausedEventAttributes) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Reason != nil {
+		w, err = wire.NewValueString(*(v.Reason)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Details != nil {
+		w, err = wire.NewValueBinary(v.Details), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a WorkflowExecutionPausedEventAttributes struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a WorkflowExecutionPausedEventAttributes struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v WorkflowExecutionPausedEventAttributes
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *WorkflowExecutionPausedEventAttributes) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Reason = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				v.Details, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a WorkflowExecutionPausedEventAttributes
+// struct.
+func (v *WorkflowExecutionPausedEventAttributes) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.Reason != nil {
+		fields[i] = fmt.Sprintf("Reason: %v", *(v.Reason))
+		i++
+	}
+	if v.Details != nil {
+		fields[i] = fmt.Sprintf("Details: %v", v.Details)
+		i++
+	}
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+		i++
+	}
+
+	return fmt.Sprintf("WorkflowExecutionPausedEventAttributes{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this WorkflowExecutionPausedEventAttributes match the
+// provided WorkflowExecutionPausedEventAttributes.
+//
+// This function performs a deep comparison.
+func (v *WorkflowExecutionPausedEventAttributes) Equals(rhs *WorkflowExecutionPausedEventAttributes) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Reason, rhs.Reason) {
+		return false
+	}
+	if !((v.Details == nil && rhs.Details == nil) || (v.Details != nil && rhs.Details != nil && bytes.Equal(v.Details, rhs.Details))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of WorkflowExecutionPausedEventAttributes.
+func (v *WorkflowExecutionPausedEventAttributes) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Reason != nil {
+		enc.AddString("reason", *v.Reason)
+	}
+	if v.Details != nil {
+		enc.AddString("details", base64.StdEncoding.EncodeToString(v.Details))
+	}
+	if v.Identity != nil {
+		enc.AddString("identity", *v.Identity)
+	}
+	return err
+}
+
+// GetReason returns the value of Reason if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionPausedEventAttributes) GetReason() (o string) {
+	if v.Reason != nil {
+		return *v.Reason
+	}
+
+	return
+}
+
+// GetDetails returns the value of Details if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionPausedEventAttributes) GetDetails() (o []byte) {
+	if v.Details != nil {
+		return v.Details
+	}
+
+	return
+}
+
+// GetIdentity returns the value of Identity if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionPausedEventAttributes) GetIdentity() (o string) {
+	if v.Identity != nil {
+		return *v.Identity
+	}
+
+	return
+}
+
 type WorkflowExecutionSignaledEventAttributes struct {
 	SignalName *string `json:"signalName,omitempty"`
 	Input      []byte  `json:"input,omitempty"`
@@ -42203,6 +47076,7 @@ type WorkflowExecutionStartedEventAttributes struct {
 	ExpirationTimestamp                 *int64                  `json:"expirationTimestamp,omitempty"`
 	CronSchedule                        *string                 `json:"cronSchedule,omitempty"`
 	FirstDecisionTaskBackoffSeconds     *int32                  `json:"firstDecisionTaskBackoffSeconds,omitempty"`
+	Priority                            *int32                  `json:"priority,omitempty"`
 }
 
 // ToWire translates a WorkflowExecutionStartedEventAttributes struct into a Thrift-level intermediate
@@ -42222,7 +47096,7 @@ type WorkflowExecutionStartedEventAttributes struct {
 //   }
 func (v *WorkflowExecutionStartedEventAttributes) ToWire() (wire.Value, error) {
 	var (
-		fields [20]wire.Field
+		fields [21]wire.Field
 		i      int = 0
 		w      wire.Value
 		err    error
@@ -42388,6 +47262,14 @@ func (v *WorkflowExecutionStartedEventAttributes) ToWire() (wire.Value, error) {
 		fields[i] = wire.Field{ID: 110, Value: w}
 		i++
 	}
+	if v.Priority != nil {
+		w, err = wire.NewValueI32(*(v.Priority)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 120, Value: w}
+		i++
+	}
 
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
@@ -42599,6 +47481,16 @@ func (v *WorkflowExecutionStartedEventAttributes) FromWire(w wire.Value) error {
 					return err
 				}
 
+			}
+		case 120:
+			if field.Value.Type() == wire.TI32 {
+				var x int32
+				x, err = field.Value.GetI32(), error(nil)
+				v.Priority = &x
+				if err != nil {
+					return err
+				}
+
 			}
 		}
 	}
@@ -42613,7 +47505,7 @@ func (v *WorkflowExecutionStartedEventAttributes) String() string {
 		return "<nil>"
 	}
 
-	var fields [20]string
+	var fields [21]string
 	i := 0
 	if v.WorkflowType != nil {
 		fields[i] = fmt.Sprintf("WorkflowType: %v", v.WorkflowType)
@@ -42695,6 +47587,10 @@ func (v *WorkflowExecutionStartedEventAttributes) String() string {
 		fields[i] = fmt.Sprintf("FirstDecisionTaskBackoffSeconds: %v", *(v.FirstDecisionTaskBackoffSeconds))
 		i++
 	}
+	if v.Priority != nil {
+		fields[i] = fmt.Sprintf("Priority: %v", *(v.Priority))
+		i++
+	}
 
 	return fmt.Sprintf("WorkflowExecutionStartedEventAttributes{%v}", strings.Join(fields[:i], ", "))
 }
@@ -42769,6 +47665,9 @@ func (v *WorkflowExecutionStartedEventAttributes) Equals(rhs *WorkflowExecutionS
 	if !_I32_EqualsPtr(v.FirstDecisionTaskBackoffSeconds, rhs.FirstDecisionTaskBackoffSeconds) {
 		return false
 	}
+	if !_I32_EqualsPtr(v.Priority, rhs.Priority) {
+		return false
+	}
 
 	return true
 }
@@ -42839,6 +47738,9 @@ func (v *WorkflowExecutionStartedEventAttributes) MarshalLogObject(enc zapcore.O
 	if v.FirstDecisionTaskBackoffSeconds != nil {
 		enc.AddInt32("firstDecisionTaskBackoffSeconds", *v.FirstDecisionTaskBackoffSeconds)
 	}
+	if v.Priority != nil {
+		enc.AddInt32("priority", *v.Priority)
+	}
 	return err
 }
 
@@ -43042,6 +47944,16 @@ func (v *WorkflowExecutionStartedEventAttributes) GetFirstDecisionTaskBackoffSec
 	return
 }
 
+// GetPriority returns the value of Priority if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionStartedEventAttributes) GetPriority() (o int32) {
+	if v.Priority != nil {
+		return *v.Priority
+	}
+
+	return
+}
+
 type WorkflowExecutionTerminatedEventAttributes struct {
 	Reason   *string `json:"reason,omitempty"`
 	Details  []byte  `json:"details,omitempty"`
@@ -43099,11 +48011,353 @@ func (v *WorkflowExecutionTerminatedEventAttributes) ToWire() (wire.Value, error
 	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
 }
 
-// FromWire deserializes a WorkflowExecutionTerminatedEventAttributes struct from its Thrift-level
+// FromWire deserializes a WorkflowExecutionTerminatedEventAttributes struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a WorkflowExecutionTerminatedEventAttributes struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v WorkflowExecutionTerminatedEventAttributes
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *WorkflowExecutionTerminatedEventAttributes) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Reason = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				v.Details, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.Identity = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a WorkflowExecutionTerminatedEventAttributes
+// struct.
+func (v *WorkflowExecutionTerminatedEventAttributes) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.Reason != nil {
+		fields[i] = fmt.Sprintf("Reason: %v", *(v.Reason))
+		i++
+	}
+	if v.Details != nil {
+		fields[i] = fmt.Sprintf("Details: %v", v.Details)
+		i++
+	}
+	if v.Identity != nil {
+		fields[i] = fmt.Sprintf("Identity: %v", *(v.Identity))
+		i++
+	}
+
+	return fmt.Sprintf("WorkflowExecutionTerminatedEventAttributes{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this WorkflowExecutionTerminatedEventAttributes match the
+// provided WorkflowExecutionTerminatedEventAttributes.
+//
+// This function performs a deep comparison.
+func (v *WorkflowExecutionTerminatedEventAttributes) Equals(rhs *WorkflowExecutionTerminatedEventAttributes) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_String_EqualsPtr(v.Reason, rhs.Reason) {
+		return false
+	}
+	if !((v.Details == nil && rhs.Details == nil) || (v.Details != nil && rhs.Details != nil && bytes.Equal(v.Details, rhs.Details))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.Identity, rhs.Identity) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of WorkflowExecutionTerminatedEventAttributes.
+func (v *WorkflowExecutionTerminatedEventAttributes) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.Reason != nil {
+		enc.AddString("reason", *v.Reason)
+	}
+	if v.Details != nil {
+		enc.AddString("details", base64.StdEncoding.EncodeToString(v.Details))
+	}
+	if v.Identity != nil {
+		enc.AddString("identity", *v.Identity)
+	}
+	return err
+}
+
+// GetReason returns the value of Reason if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionTerminatedEventAttributes) GetReason() (o string) {
+	if v.Reason != nil {
+		return *v.Reason
+	}
+
+	return
+}
+
+// GetDetails returns the value of Details if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionTerminatedEventAttributes) GetDetails() (o []byte) {
+	if v.Details != nil {
+		return v.Details
+	}
+
+	return
+}
+
+// GetIdentity returns the value of Identity if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionTerminatedEventAttributes) GetIdentity() (o string) {
+	if v.Identity != nil {
+		return *v.Identity
+	}
+
+	return
+}
+
+type WorkflowExecutionTimedOutEventAttributes struct {
+	TimeoutType *TimeoutType `json:"timeoutType,omitempty"`
+}
+
+// ToWire translates a WorkflowExecutionTimedOutEventAttributes struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *WorkflowExecutionTimedOutEventAttributes) ToWire() (wire.Value, error) {
+	var (
+		fields [1]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.TimeoutType != nil {
+		w, err = v.TimeoutType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a WorkflowExecutionTimedOutEventAttributes struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a WorkflowExecutionTimedOutEventAttributes struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v WorkflowExecutionTimedOutEventAttributes
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *WorkflowExecutionTimedOutEventAttributes) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x TimeoutType
+				x, err = _TimeoutType_Read(field.Value)
+				v.TimeoutType = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a WorkflowExecutionTimedOutEventAttributes
+// struct.
+func (v *WorkflowExecutionTimedOutEventAttributes) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [1]string
+	i := 0
+	if v.TimeoutType != nil {
+		fields[i] = fmt.Sprintf("TimeoutType: %v", *(v.TimeoutType))
+		i++
+	}
+
+	return fmt.Sprintf("WorkflowExecutionTimedOutEventAttributes{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this WorkflowExecutionTimedOutEventAttributes match the
+// provided WorkflowExecutionTimedOutEventAttributes.
+//
+// This function performs a deep comparison.
+func (v *WorkflowExecutionTimedOutEventAttributes) Equals(rhs *WorkflowExecutionTimedOutEventAttributes) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_TimeoutType_EqualsPtr(v.TimeoutType, rhs.TimeoutType) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of WorkflowExecutionTimedOutEventAttributes.
+func (v *WorkflowExecutionTimedOutEventAttributes) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.TimeoutType != nil {
+		err = multierr.Append(err, enc.AddObject("timeoutType", *v.TimeoutType))
+	}
+	return err
+}
+
+// GetTimeoutType returns the value of TimeoutType if it is set or its
+// zero value if it is unset.
+func (v *WorkflowExecutionTimedOutEventAttributes) GetTimeoutType() (o TimeoutType) {
+	if v.TimeoutType != nil {
+		return *v.TimeoutType
+	}
+
+	return
+}
+
+type WorkflowExecutionUnpausedEventAttributes struct {
+	Reason   *string `json:"reason,omitempty"`
+	Details  []byte  `json:"details,omitempty"`
+	Identity *string `json:"identity,omitempty"`
+}
+
+// ToWire translates a WorkflowExecutionUnpausedEventAttributes struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *WorkflowExecutionUnpausedEventAttributes) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.Reason != nil {
+		w, err = wire.NewValueString(*(v.Reason)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Details != nil {
+		w, err = wire.NewValueBinary(v.Details), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.Identity != nil {
+		w, err = wire.NewValueString(*(v.Identity)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a WorkflowExecutionUnpausedEventAttributes struct from its Thrift-level
 // representation. The Thrift-level representation may be obtained
 // from a ThriftRW protocol implementation.
 //
-// An error is returned if we were unable to build a WorkflowExecutionTerminatedEventAttributes struct
+// An error is returned if we were unable to build a WorkflowExecutionUnpausedEventAttributes struct
 // from the provided intermediate representation.
 //
 //   x, err := binaryProtocol.Decode(reader, wire.TStruct)
@@ -43111,12 +48365,12 @@ func (v *WorkflowExecutionTerminatedEventAttributes) ToWire() (wire.Value, error
 //     return nil, err
 //   }
 //
-//   var v WorkflowExecutionTerminatedEventAttributes
+//   var v WorkflowExecutionUnpausedEventAttributes
 //   if err := v.FromWire(x); err != nil {
 //     return nil, err
 //   }
 //   return &v, nil
-func (v *WorkflowExecutionTerminatedEventAttributes) FromWire(w wire.Value) error {
+func (v *WorkflowExecutionUnpausedEventAttributes) FromWire(w wire.Value) error {
 	var err error
 
 	for _, field := range w.GetStruct().Fields {
@@ -43155,9 +48409,9 @@ func (v *WorkflowExecutionTerminatedEventAttributes) FromWire(w wire.Value) erro
 	return nil
 }
 
-// String returns a readable string representation of a WorkflowExecutionTerminatedEventAttributes
+// String returns a readable string representation of a WorkflowExecutionUnpausedEventAttributes
 // struct.
-func (v *WorkflowExecutionTerminatedEventAttributes) String() string {
+func (v *WorkflowExecutionUnpausedEventAttributes) String() string {
 	if v == nil {
 		return "<nil>"
 	}
@@ -43177,14 +48431,14 @@ func (v *WorkflowExecutionTerminatedEventAttributes) String() string {
 		i++
 	}
 
-	return fmt.Sprintf("WorkflowExecutionTerminatedEventAttributes{%v}", strings.Join(fields[:i], ", "))
+	return fmt.Sprintf("WorkflowExecutionUnpausedEventAttributes{%v}", strings.Join(fields[:i], ", "))
 }
 
-// Equals returns true if all the fields of this WorkflowExecutionTerminatedEventAttributes match the
-// provided WorkflowExecutionTerminatedEventAttributes.
+// Equals returns true if all the fields of this WorkflowExecutionUnpausedEventAttributes match the
+// provided WorkflowExecutionUnpausedEventAttributes.
 //
 // This function performs a deep comparison.
-func (v *WorkflowExecutionTerminatedEventAttributes) Equals(rhs *WorkflowExecutionTerminatedEventAttributes) bool {
+func (v *WorkflowExecutionUnpausedEventAttributes) Equals(rhs *WorkflowExecutionUnpausedEventAttributes) bool {
 	if v == nil {
 		return rhs == nil
 	} else if rhs == nil {
@@ -43204,8 +48458,8 @@ func (v *WorkflowExecutionTerminatedEventAttributes) Equals(rhs *WorkflowExecuti
 }
 
 // MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of WorkflowExecutionTerminatedEventAttributes.
-func (v *WorkflowExecutionTerminatedEventAttributes) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+// fast logging of WorkflowExecutionUnpausedEventAttributes.
+func (v *WorkflowExecutionUnpausedEventAttributes) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
 	if v == nil {
 		return nil
 	}
@@ -43223,7 +48477,7 @@ func (v *WorkflowExecutionTerminatedEventAttributes) MarshalLogObject(enc zapcor
 
 // GetReason returns the value of Reason if it is set or its
 // zero value if it is unset.
-func (v *WorkflowExecutionTerminatedEventAttributes) GetReason() (o string) {
+func (v *WorkflowExecutionUnpausedEventAttributes) GetReason() (o string) {
 	if v.Reason != nil {
 		return *v.Reason
 	}
@@ -43233,7 +48487,7 @@ func (v *WorkflowExecutionTerminatedEventAttributes) GetReason() (o string) {
 
 // GetDetails returns the value of Details if it is set or its
 // zero value if it is unset.
-func (v *WorkflowExecutionTerminatedEventAttributes) GetDetails() (o []byte) {
+func (v *WorkflowExecutionUnpausedEventAttributes) GetDetails() (o []byte) {
 	if v.Details != nil {
 		return v.Details
 	}
@@ -43243,7 +48497,7 @@ func (v *WorkflowExecutionTerminatedEventAttributes) GetDetails() (o []byte) {
 
 // GetIdentity returns the value of Identity if it is set or its
 // zero value if it is unset.
-func (v *WorkflowExecutionTerminatedEventAttributes) GetIdentity() (o string) {
+func (v *WorkflowExecutionUnpausedEventAttributes) GetIdentity() (o string) {
 	if v.Identity != nil {
 		return *v.Identity
 	}
@@ -43251,139 +48505,6 @@ func (v *WorkflowExecutionTerminatedEventAttributes) GetIdentity() (o string) {
 	return
 }
 
-type WorkflowExecutionTimedOutEventAttributes struct {
-	TimeoutType *TimeoutType `json:"timeoutType,omitempty"`
-}
-
-// ToWire translates a WorkflowExecutionTimedOutEventAttributes struct into a Thrift-level intermediate
-// representation. This intermediate representation may be serialized
-// into bytes using a ThriftRW protocol implementation.
-//
-// An error is returned if the struct or any of its fields failed to
-// validate.
-//
-//   x, err := v.ToWire()
-//   if err != nil {
-//     return err
-//   }
-//
-//   if err := binaryProtocol.Encode(x, writer); err != nil {
-//     return err
-//   }
-func (v *WorkflowExecutionTimedOutEventAttributes) ToWire() (wire.Value, error) {
-	var (
-		fields [1]wire.Field
-		i      int = 0
-		w      wire.Value
-		err    error
-	)
-
-	if v.TimeoutType != nil {
-		w, err = v.TimeoutType.ToWire()
-		if err != nil {
-			return w, err
-		}
-		fields[i] = wire.Field{ID: 10, Value: w}
-		i++
-	}
-
-	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
-}
-
-// FromWire deserializes a WorkflowExecutionTimedOutEventAttributes struct from its Thrift-level
-// representation. The Thrift-level representation may be obtained
-// from a ThriftRW protocol implementation.
-//
-// An error is returned if we were unable to build a WorkflowExecutionTimedOutEventAttributes struct
-// from the provided intermediate representation.
-//
-//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
-//   if err != nil {
-//     return nil, err
-//   }
-//
-//   var v WorkflowExecutionTimedOutEventAttributes
-//   if err := v.FromWire(x); err != nil {
-//     return nil, err
-//   }
-//   return &v, nil
-func (v *WorkflowExecutionTimedOutEventAttributes) FromWire(w wire.Value) error {
-	var err error
-
-	for _, field := range w.GetStruct().Fields {
-		switch field.ID {
-		case 10:
-			if field.Value.Type() == wire.TI32 {
-				var x TimeoutType
-				x, err = _TimeoutType_Read(field.Value)
-				v.TimeoutType = &x
-				if err != nil {
-					return err
-				}
-
-			}
-		}
-	}
-
-	return nil
-}
-
-// String returns a readable string representation of a WorkflowExecutionTimedOutEventAttributes
-// struct.
-func (v *WorkflowExecutionTimedOutEventAttributes) String() string {
-	if v == nil {
-		return "<nil>"
-	}
-
-	var fields [1]string
-	i := 0
-	if v.TimeoutType != nil {
-		fields[i] = fmt.Sprintf("TimeoutType: %v", *(v.TimeoutType))
-		i++
-	}
-
-	return fmt.Sprintf("WorkflowExecutionTimedOutEventAttributes{%v}", strings.Join(fields[:i], ", "))
-}
-
-// Equals returns true if all the fields of this WorkflowExecutionTimedOutEventAttributes match the
-// provided WorkflowExecutionTimedOutEventAttributes.
-//
-// This function performs a deep comparison.
-func (v *WorkflowExecutionTimedOutEventAttributes) Equals(rhs *WorkflowExecutionTimedOutEventAttributes) bool {
-	if v == nil {
-		return rhs == nil
-	} else if rhs == nil {
-		return false
-	}
-	if !_TimeoutType_EqualsPtr(v.TimeoutType, rhs.TimeoutType) {
-		return false
-	}
-
-	return true
-}
-
-// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
-// fast logging of WorkflowExecutionTimedOutEventAttributes.
-func (v *WorkflowExecutionTimedOutEventAttributes) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
-	if v == nil {
-		return nil
-	}
-	if v.TimeoutType != nil {
-		err = multierr.Append(err, enc.AddObject("timeoutType", *v.TimeoutType))
-	}
-	return err
-}
-
-// GetTimeoutType returns the value of TimeoutType if it is set or its
-// zero value if it is unset.
-func (v *WorkflowExecutionTimedOutEventAttributes) GetTimeoutType() (o TimeoutType) {
-	if v.TimeoutType != nil {
-		return *v.TimeoutType
-	}
-
-	return
-}
-
 type WorkflowIdReusePolicy int32
 
 const (
@@ -43738,6 +48859,215 @@ func (v *WorkflowQuery) GetQueryArgs() (o []byte) {
 	return
 }
 
+type WorkflowQueryResult struct {
+	ResultType   *QueryTaskCompletedType `json:"resultType,omitempty"`
+	Answer       []byte                  `json:"answer,omitempty"`
+	ErrorMessage *string                 `json:"errorMessage,omitempty"`
+}
+
+// ToWire translates a WorkflowQueryResult struct into a Thrift-level intermediate
+// representation. This intermediate representation may be serialized
+// into bytes using a ThriftRW protocol implementation.
+//
+// An error is returned if the struct or any of its fields failed to
+// validate.
+//
+//   x, err := v.ToWire()
+//   if err != nil {
+//     return err
+//   }
+//
+//   if err := binaryProtocol.Encode(x, writer); err != nil {
+//     return err
+//   }
+func (v *WorkflowQueryResult) ToWire() (wire.Value, error) {
+	var (
+		fields [3]wire.Field
+		i      int = 0
+		w      wire.Value
+		err    error
+	)
+
+	if v.ResultType != nil {
+		w, err = v.ResultType.ToWire()
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 10, Value: w}
+		i++
+	}
+	if v.Answer != nil {
+		w, err = wire.NewValueBinary(v.Answer), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 20, Value: w}
+		i++
+	}
+	if v.ErrorMessage != nil {
+		w, err = wire.NewValueString(*(v.ErrorMessage)), error(nil)
+		if err != nil {
+			return w, err
+		}
+		fields[i] = wire.Field{ID: 30, Value: w}
+		i++
+	}
+
+	return wire.NewValueStruct(wire.Struct{Fields: fields[:i]}), nil
+}
+
+// FromWire deserializes a WorkflowQueryResult struct from its Thrift-level
+// representation. The Thrift-level representation may be obtained
+// from a ThriftRW protocol implementation.
+//
+// An error is returned if we were unable to build a WorkflowQueryResult struct
+// from the provided intermediate representation.
+//
+//   x, err := binaryProtocol.Decode(reader, wire.TStruct)
+//   if err != nil {
+//     return nil, err
+//   }
+//
+//   var v WorkflowQueryResult
+//   if err := v.FromWire(x); err != nil {
+//     return nil, err
+//   }
+//   return &v, nil
+func (v *WorkflowQueryResult) FromWire(w wire.Value) error {
+	var err error
+
+	for _, field := range w.GetStruct().Fields {
+		switch field.ID {
+		case 10:
+			if field.Value.Type() == wire.TI32 {
+				var x QueryTaskCompletedType
+				x, err = _QueryTaskCompletedType_Read(field.Value)
+				v.ResultType = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		case 20:
+			if field.Value.Type() == wire.TBinary {
+				v.Answer, err = field.Value.GetBinary(), error(nil)
+				if err != nil {
+					return err
+				}
+
+			}
+		case 30:
+			if field.Value.Type() == wire.TBinary {
+				var x string
+				x, err = field.Value.GetString(), error(nil)
+				v.ErrorMessage = &x
+				if err != nil {
+					return err
+				}
+
+			}
+		}
+	}
+
+	return nil
+}
+
+// String returns a readable string representation of a WorkflowQueryResult
+// struct.
+func (v *WorkflowQueryResult) String() string {
+	if v == nil {
+		return "<nil>"
+	}
+
+	var fields [3]string
+	i := 0
+	if v.ResultType != nil {
+		fields[i] = fmt.Sprintf("ResultType: %v", *(v.ResultType))
+		i++
+	}
+	if v.Answer != nil {
+		fields[i] = fmt.Sprintf("Answer: %v", v.Answer)
+		i++
+	}
+	if v.ErrorMessage != nil {
+		fields[i] = fmt.Sprintf("ErrorMessage: %v", *(v.ErrorMessage))
+		i++
+	}
+
+	return fmt.Sprintf("WorkflowQueryResult{%v}", strings.Join(fields[:i], ", "))
+}
+
+// Equals returns true if all the fields of this WorkflowQueryResult match the
+// provided WorkflowQueryResult.
+//
+// This function performs a deep comparison.
+func (v *WorkflowQueryResult) Equals(rhs *WorkflowQueryResult) bool {
+	if v == nil {
+		return rhs == nil
+	} else if rhs == nil {
+		return false
+	}
+	if !_QueryTaskCompletedType_EqualsPtr(v.ResultType, rhs.ResultType) {
+		return false
+	}
+	if !((v.Answer == nil && rhs.Answer == nil) || (v.Answer != nil && rhs.Answer != nil && bytes.Equal(v.Answer, rhs.Answer))) {
+		return false
+	}
+	if !_String_EqualsPtr(v.ErrorMessage, rhs.ErrorMessage) {
+		return false
+	}
+
+	return true
+}
+
+// MarshalLogObject implements zapcore.ObjectMarshaler, enabling
+// fast logging of WorkflowQueryResult.
+func (v *WorkflowQueryResult) MarshalLogObject(enc zapcore.ObjectEncoder) (err error) {
+	if v == nil {
+		return nil
+	}
+	if v.ResultType != nil {
+		err = multierr.Append(err, enc.AddObject("resultType", *v.ResultType))
+	}
+	if v.Answer != nil {
+		enc.AddString("answer", base64.StdEncoding.EncodeToString(v.Answer))
+	}
+	if v.ErrorMessage != nil {
+		enc.AddString("errorMessage", *v.ErrorMessage)
+	}
+	return err
+}
+
+// GetResultType returns the value of ResultType if it is set or its
+// zero value if it is unset.
+func (v *WorkflowQueryResult) GetResultType() (o QueryTaskCompletedType) {
+	if v.ResultType != nil {
+		return *v.ResultType
+	}
+
+	return
+}
+
+// GetAnswer returns the value of Answer if it is set or its
+// zero value if it is unset.
+func (v *WorkflowQueryResult) GetAnswer() (o []byte) {
+	if v.Answer != nil {
+		return v.Answer
+	}
+
+	return
+}
+
+// GetErrorMessage returns the value of ErrorMessage if it is set or its
+// zero value if it is unset.
+func (v *WorkflowQueryResult) GetErrorMessage() (o string) {
+	if v.ErrorMessage != nil {
+		return *v.ErrorMessage
+	}
+
+	return
+}
+
 type WorkflowType struct {
 	Name *string `json:"name,omitempty"`
 }
@@ -103,6 +103,118 @@ func (c *clientImpl) GetWorkflowExecutionRawHistory(
 	return client.GetWorkflowExecutionRawHistory(ctx, request, opts...)
 }
 
+func (c *clientImpl) CloseShard(
+	ctx context.Context,
+	request *shared.CloseShardRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.CloseShard(ctx, request, opts...)
+}
+
+func (c *clientImpl) DescribeShard(
+	ctx context.Context,
+	request *shared.DescribeShardRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeShardResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DescribeShard(ctx, request, opts...)
+}
+
+func (c *clientImpl) DrainHistoryHost(
+	ctx context.Context,
+	request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DrainHistoryHost(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListQueueTasks(
+	ctx context.Context,
+	request *shared.ListQueueTasksRequest,
+	opts ...yarpc.CallOption,
+) (*shared.ListQueueTasksResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.ListQueueTasks(ctx, request, opts...)
+}
+
+func (c *clientImpl) RemoveQueueTask(
+	ctx context.Context,
+	request *shared.RemoveQueueTaskRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.RemoveQueueTask(ctx, request, opts...)
+}
+
+func (c *clientImpl) RegenerateWorkflowTasks(
+	ctx context.Context,
+	request *admin.RegenerateWorkflowTasksRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.RegenerateWorkflowTasks(ctx, request, opts...)
+}
+
+func (c *clientImpl) PromoteTaskListBuildID(
+	ctx context.Context,
+	request *shared.PromoteTaskListBuildIDRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.PromoteTaskListBuildID(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	if parent == nil {
 		return context.WithTimeout(context.Background(), c.timeout)
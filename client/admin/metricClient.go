@@ -97,3 +97,129 @@ func (c *metricClient) GetWorkflowExecutionRawHistory(
 	}
 	return resp, err
 }
+
+func (c *metricClient) CloseShard(
+	ctx context.Context,
+	request *shared.CloseShardRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.AdminClientCloseShardScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientCloseShardScope, metrics.CadenceClientLatency)
+	err := c.client.CloseShard(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientCloseShardScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
+func (c *metricClient) DescribeShard(
+	ctx context.Context,
+	request *shared.DescribeShardRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeShardResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDescribeShardScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDescribeShardScope, metrics.CadenceClientLatency)
+	resp, err := c.client.DescribeShard(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDescribeShardScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) DrainHistoryHost(
+	ctx context.Context,
+	request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.AdminClientDrainHistoryHostScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientDrainHistoryHostScope, metrics.CadenceClientLatency)
+	err := c.client.DrainHistoryHost(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientDrainHistoryHostScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
+func (c *metricClient) ListQueueTasks(
+	ctx context.Context,
+	request *shared.ListQueueTasksRequest,
+	opts ...yarpc.CallOption,
+) (*shared.ListQueueTasksResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.AdminClientListQueueTasksScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientListQueueTasksScope, metrics.CadenceClientLatency)
+	resp, err := c.client.ListQueueTasks(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientListQueueTasksScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
+func (c *metricClient) RemoveQueueTask(
+	ctx context.Context,
+	request *shared.RemoveQueueTaskRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.AdminClientRemoveQueueTaskScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientRemoveQueueTaskScope, metrics.CadenceClientLatency)
+	err := c.client.RemoveQueueTask(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientRemoveQueueTaskScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
+func (c *metricClient) RegenerateWorkflowTasks(
+	ctx context.Context,
+	request *admin.RegenerateWorkflowTasksRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.AdminClientRegenerateWorkflowTasksScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientRegenerateWorkflowTasksScope, metrics.CadenceClientLatency)
+	err := c.client.RegenerateWorkflowTasks(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientRegenerateWorkflowTasksScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
+func (c *metricClient) PromoteTaskListBuildID(
+	ctx context.Context,
+	request *shared.PromoteTaskListBuildIDRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.AdminClientPromoteTaskListBuildIDScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.AdminClientPromoteTaskListBuildIDScope, metrics.CadenceClientLatency)
+	err := c.client.PromoteTaskListBuildID(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.AdminClientPromoteTaskListBuildIDScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
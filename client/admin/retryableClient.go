@@ -93,3 +93,95 @@ func (c *retryableClient) GetWorkflowExecutionRawHistory(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) CloseShard(
+	ctx context.Context,
+	request *shared.CloseShardRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.CloseShard(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) DescribeShard(
+	ctx context.Context,
+	request *shared.DescribeShardRequest,
+	opts ...yarpc.CallOption,
+) (*shared.DescribeShardResponse, error) {
+
+	var resp *shared.DescribeShardResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeShard(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DrainHistoryHost(
+	ctx context.Context,
+	request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.DrainHistoryHost(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) ListQueueTasks(
+	ctx context.Context,
+	request *shared.ListQueueTasksRequest,
+	opts ...yarpc.CallOption,
+) (*shared.ListQueueTasksResponse, error) {
+
+	var resp *shared.ListQueueTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListQueueTasks(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) RemoveQueueTask(
+	ctx context.Context,
+	request *shared.RemoveQueueTaskRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.RemoveQueueTask(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) RegenerateWorkflowTasks(
+	ctx context.Context,
+	request *admin.RegenerateWorkflowTasksRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.RegenerateWorkflowTasks(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) PromoteTaskListBuildID(
+	ctx context.Context,
+	request *shared.PromoteTaskListBuildIDRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.PromoteTaskListBuildID(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
@@ -21,10 +21,12 @@
 package client
 
 import (
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"regexp"
 
+	tcg "github.com/uber/tchannel-go"
 	"go.uber.org/yarpc"
 	"go.uber.org/yarpc/transport/tchannel"
 
@@ -34,6 +36,7 @@ import (
 	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/client/public"
 	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/service/config"
 )
 
 const (
@@ -66,6 +69,7 @@ type (
 	}
 
 	ipDispatcherProvider struct {
+		tlsConfig *tls.Config
 	}
 )
 
@@ -178,6 +182,12 @@ func NewIPYarpcDispatcherProvider() DispatcherProvider {
 	return &ipDispatcherProvider{}
 }
 
+// NewIPYarpcDispatcherProviderWithTLS create a dispatcher provider which handles with IP address,
+// connections made by the dispatchers are secured with the given TLS config
+func NewIPYarpcDispatcherProviderWithTLS(tlsConfig *tls.Config) DispatcherProvider {
+	return &ipDispatcherProvider{tlsConfig: tlsConfig}
+}
+
 func (p *ipDispatcherProvider) Get(name string, address string) (*yarpc.Dispatcher, error) {
 	match, err := regexp.MatchString(ipPortRegex, address)
 	if err != nil {
@@ -187,11 +197,7 @@ func (p *ipDispatcherProvider) Get(name string, address string) (*yarpc.Dispatch
 		return nil, errors.New("invalid ip:port address")
 	}
 
-	channel, err := tchannel.NewChannelTransport(
-		tchannel.ServiceName(crossDCCaller),
-		// this aim to get rid of the annoying popup about accepting incoming network connections
-		tchannel.ListenAddr("127.0.0.1:0"),
-	)
+	channel, err := p.newChannelTransport()
 	if err != nil {
 		return nil, err
 	}
@@ -208,3 +214,21 @@ func (p *ipDispatcherProvider) Get(name string, address string) (*yarpc.Dispatch
 	}
 	return dispatcher, nil
 }
+
+func (p *ipDispatcherProvider) newChannelTransport() (*tchannel.ChannelTransport, error) {
+	opts := []tchannel.TransportOption{
+		tchannel.ServiceName(crossDCCaller),
+		// this aim to get rid of the annoying popup about accepting incoming network connections
+		tchannel.ListenAddr("127.0.0.1:0"),
+	}
+	if p.tlsConfig != nil {
+		ch, err := tcg.NewChannel(crossDCCaller, &tcg.ChannelOptions{
+			Dialer: config.NewTLSDialer(p.tlsConfig),
+		})
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, tchannel.WithChannel(ch))
+	}
+	return tchannel.NewChannelTransport(opts...)
+}
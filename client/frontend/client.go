@@ -188,6 +188,22 @@ func (c *clientImpl) ListOpenWorkflowExecutions(
 	return client.ListOpenWorkflowExecutions(ctx, request, opts...)
 }
 
+func (c *clientImpl) PollForActivityCancellation(
+	ctx context.Context,
+	request *shared.PollForActivityCancellationRequest,
+	opts ...yarpc.CallOption,
+) (*shared.PollForActivityCancellationResponse, error) {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createLongPollContext(ctx)
+	defer cancel()
+	return client.PollForActivityCancellation(ctx, request, opts...)
+}
+
 func (c *clientImpl) PollForActivityTask(
 	ctx context.Context,
 	request *shared.PollForActivityTaskRequest,
@@ -540,6 +556,38 @@ func (c *clientImpl) TerminateWorkflowExecution(
 	return client.TerminateWorkflowExecution(ctx, request, opts...)
 }
 
+func (c *clientImpl) PauseWorkflowExecution(
+	ctx context.Context,
+	request *shared.PauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.PauseWorkflowExecution(ctx, request, opts...)
+}
+
+func (c *clientImpl) UnpauseWorkflowExecution(
+	ctx context.Context,
+	request *shared.UnpauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getRandomClient()
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.UnpauseWorkflowExecution(ctx, request, opts...)
+}
+
 func (c *clientImpl) UpdateDomain(
 	ctx context.Context,
 	request *shared.UpdateDomainRequest,
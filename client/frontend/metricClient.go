@@ -186,6 +186,24 @@ func (c *metricClient) ListOpenWorkflowExecutions(
 	return resp, err
 }
 
+func (c *metricClient) PollForActivityCancellation(
+	ctx context.Context,
+	request *shared.PollForActivityCancellationRequest,
+	opts ...yarpc.CallOption,
+) (*shared.PollForActivityCancellationResponse, error) {
+
+	c.metricsClient.IncCounter(metrics.FrontendClientPollForActivityCancellationScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.FrontendClientPollForActivityCancellationScope, metrics.CadenceClientLatency)
+	resp, err := c.client.PollForActivityCancellation(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.FrontendClientPollForActivityCancellationScope, metrics.CadenceClientFailures)
+	}
+	return resp, err
+}
+
 func (c *metricClient) PollForActivityTask(
 	ctx context.Context,
 	request *shared.PollForActivityTaskRequest,
@@ -582,6 +600,42 @@ func (c *metricClient) TerminateWorkflowExecution(
 	return err
 }
 
+func (c *metricClient) PauseWorkflowExecution(
+	ctx context.Context,
+	request *shared.PauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.FrontendClientPauseWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.FrontendClientPauseWorkflowExecutionScope, metrics.CadenceClientLatency)
+	err := c.client.PauseWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.FrontendClientPauseWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
+func (c *metricClient) UnpauseWorkflowExecution(
+	ctx context.Context,
+	request *shared.UnpauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	c.metricsClient.IncCounter(metrics.FrontendClientUnpauseWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.FrontendClientUnpauseWorkflowExecutionScope, metrics.CadenceClientLatency)
+	err := c.client.UnpauseWorkflowExecution(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.FrontendClientUnpauseWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+	return err
+}
+
 func (c *metricClient) UpdateDomain(
 	ctx context.Context,
 	request *shared.UpdateDomainRequest,
@@ -170,6 +170,22 @@ func (c *retryableClient) ListOpenWorkflowExecutions(
 	return resp, err
 }
 
+func (c *retryableClient) PollForActivityCancellation(
+	ctx context.Context,
+	request *shared.PollForActivityCancellationRequest,
+	opts ...yarpc.CallOption,
+) (*shared.PollForActivityCancellationResponse, error) {
+
+	var resp *shared.PollForActivityCancellationResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.PollForActivityCancellation(ctx, request, opts...)
+		return err
+	}
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) PollForActivityTask(
 	ctx context.Context,
 	request *shared.PollForActivityTaskRequest,
@@ -474,6 +490,30 @@ func (c *retryableClient) TerminateWorkflowExecution(
 	return backoff.Retry(op, c.policy, c.isRetryable)
 }
 
+func (c *retryableClient) PauseWorkflowExecution(
+	ctx context.Context,
+	request *shared.PauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.PauseWorkflowExecution(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) UnpauseWorkflowExecution(
+	ctx context.Context,
+	request *shared.UnpauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption,
+) error {
+
+	op := func() error {
+		return c.client.UnpauseWorkflowExecution(ctx, request, opts...)
+	}
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
 func (c *retryableClient) UpdateDomain(
 	ctx context.Context,
 	request *shared.UpdateDomainRequest,
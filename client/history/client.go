@@ -107,6 +107,30 @@ func (c *clientImpl) GetMutableState(
 	return response, nil
 }
 
+func (c *clientImpl) QueryWorkflow(
+	ctx context.Context,
+	request *h.QueryWorkflowRequest,
+	opts ...yarpc.CallOption) (*h.QueryWorkflowResponse, error) {
+	client, err := c.getClientForWorkflowID(request.Request.Execution.GetWorkflowId())
+	if err != nil {
+		return nil, err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	var response *h.QueryWorkflowResponse
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.QueryWorkflow(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
 func (c *clientImpl) DescribeHistoryHost(
 	ctx context.Context,
 	request *workflow.DescribeHistoryHostRequest,
@@ -146,6 +170,120 @@ func (c *clientImpl) DescribeHistoryHost(
 	return response, nil
 }
 
+func (c *clientImpl) CloseShard(
+	ctx context.Context,
+	request *workflow.CloseShardRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getClientForShardID(int(request.GetShardID()))
+	if err != nil {
+		return err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.CloseShard(ctx, request, opts...)
+	}
+	return c.executeWithRedirect(ctx, client, op)
+}
+
+func (c *clientImpl) DescribeShard(
+	ctx context.Context,
+	request *workflow.DescribeShardRequest,
+	opts ...yarpc.CallOption) (*workflow.DescribeShardResponse, error) {
+	client, err := c.getClientForShardID(int(request.GetShardID()))
+	if err != nil {
+		return nil, err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	var response *workflow.DescribeShardResponse
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.DescribeShard(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) DrainHistoryHost(
+	ctx context.Context,
+	request *workflow.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption) error {
+	ret, err := c.clients.GetClientForClientKey(request.GetHostAddress())
+	if err != nil {
+		return err
+	}
+	client := ret.(historyserviceclient.Interface)
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.DrainHistoryHost(ctx, request, opts...)
+}
+
+func (c *clientImpl) ListQueueTasks(
+	ctx context.Context,
+	request *workflow.ListQueueTasksRequest,
+	opts ...yarpc.CallOption) (*workflow.ListQueueTasksResponse, error) {
+	client, err := c.getClientForShardID(int(request.GetShardID()))
+	if err != nil {
+		return nil, err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	var response *workflow.ListQueueTasksResponse
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		var err error
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		response, err = client.ListQueueTasks(ctx, request, opts...)
+		return err
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (c *clientImpl) RemoveQueueTask(
+	ctx context.Context,
+	request *workflow.RemoveQueueTaskRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getClientForShardID(int(request.GetShardID()))
+	if err != nil {
+		return err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.RemoveQueueTask(ctx, request, opts...)
+	}
+	return c.executeWithRedirect(ctx, client, op)
+}
+
+func (c *clientImpl) RegenerateWorkflowTasks(
+	ctx context.Context,
+	request *h.RegenerateWorkflowTasksRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getClientForWorkflowID(request.Execution.GetWorkflowId())
+	if err != nil {
+		return err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.RegenerateWorkflowTasks(ctx, request, opts...)
+	}
+	return c.executeWithRedirect(ctx, client, op)
+}
+
 func (c *clientImpl) DescribeMutableState(
 	ctx context.Context,
 	request *h.DescribeMutableStateRequest,
@@ -503,6 +641,42 @@ func (c *clientImpl) TerminateWorkflowExecution(
 	return err
 }
 
+func (c *clientImpl) PauseWorkflowExecution(
+	ctx context.Context,
+	request *h.PauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getClientForWorkflowID(*request.PauseRequest.WorkflowExecution.WorkflowId)
+	if err != nil {
+		return err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.PauseWorkflowExecution(ctx, request, opts...)
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	return err
+}
+
+func (c *clientImpl) UnpauseWorkflowExecution(
+	ctx context.Context,
+	request *h.UnpauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+	client, err := c.getClientForWorkflowID(*request.UnpauseRequest.WorkflowExecution.WorkflowId)
+	if err != nil {
+		return err
+	}
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	op := func(ctx context.Context, client historyserviceclient.Interface) error {
+		ctx, cancel := c.createContext(ctx)
+		defer cancel()
+		return client.UnpauseWorkflowExecution(ctx, request, opts...)
+	}
+	err = c.executeWithRedirect(ctx, client, op)
+	return err
+}
+
 func (c *clientImpl) ResetWorkflowExecution(
 	ctx context.Context,
 	request *h.ResetWorkflowExecutionRequest,
@@ -70,6 +70,48 @@ func (c *metricClient) DescribeHistoryHost(
 	return resp, err
 }
 
+func (c *metricClient) CloseShard(
+	context context.Context,
+	request *shared.CloseShardRequest,
+	opts ...yarpc.CallOption) error {
+	return c.client.CloseShard(context, request, opts...)
+}
+
+func (c *metricClient) DescribeShard(
+	context context.Context,
+	request *shared.DescribeShardRequest,
+	opts ...yarpc.CallOption) (*shared.DescribeShardResponse, error) {
+	return c.client.DescribeShard(context, request, opts...)
+}
+
+func (c *metricClient) DrainHistoryHost(
+	context context.Context,
+	request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption) error {
+	return c.client.DrainHistoryHost(context, request, opts...)
+}
+
+func (c *metricClient) ListQueueTasks(
+	context context.Context,
+	request *shared.ListQueueTasksRequest,
+	opts ...yarpc.CallOption) (*shared.ListQueueTasksResponse, error) {
+	return c.client.ListQueueTasks(context, request, opts...)
+}
+
+func (c *metricClient) RemoveQueueTask(
+	context context.Context,
+	request *shared.RemoveQueueTaskRequest,
+	opts ...yarpc.CallOption) error {
+	return c.client.RemoveQueueTask(context, request, opts...)
+}
+
+func (c *metricClient) RegenerateWorkflowTasks(
+	context context.Context,
+	request *h.RegenerateWorkflowTasksRequest,
+	opts ...yarpc.CallOption) error {
+	return c.client.RegenerateWorkflowTasks(context, request, opts...)
+}
+
 func (c *metricClient) DescribeMutableState(
 	context context.Context,
 	request *h.DescribeMutableStateRequest,
@@ -96,6 +138,23 @@ func (c *metricClient) GetMutableState(
 	return resp, err
 }
 
+func (c *metricClient) QueryWorkflow(
+	context context.Context,
+	request *h.QueryWorkflowRequest,
+	opts ...yarpc.CallOption) (*h.QueryWorkflowResponse, error) {
+	c.metricsClient.IncCounter(metrics.HistoryClientQueryWorkflowScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientQueryWorkflowScope, metrics.CadenceClientLatency)
+	resp, err := c.client.QueryWorkflow(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientQueryWorkflowScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) ResetStickyTaskList(
 	context context.Context,
 	request *h.ResetStickyTaskListRequest,
@@ -351,6 +410,40 @@ func (c *metricClient) TerminateWorkflowExecution(
 	return err
 }
 
+func (c *metricClient) PauseWorkflowExecution(
+	context context.Context,
+	request *h.PauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientPauseWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientPauseWorkflowExecutionScope, metrics.CadenceClientLatency)
+	err := c.client.PauseWorkflowExecution(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientPauseWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+
+	return err
+}
+
+func (c *metricClient) UnpauseWorkflowExecution(
+	context context.Context,
+	request *h.UnpauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.HistoryClientUnpauseWorkflowExecutionScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.HistoryClientUnpauseWorkflowExecutionScope, metrics.CadenceClientLatency)
+	err := c.client.UnpauseWorkflowExecution(context, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.HistoryClientUnpauseWorkflowExecutionScope, metrics.CadenceClientFailures)
+	}
+
+	return err
+}
+
 func (c *metricClient) ResetWorkflowExecution(
 	context context.Context,
 	request *h.ResetWorkflowExecutionRequest,
@@ -78,6 +78,86 @@ func (c *retryableClient) DescribeHistoryHost(
 	return resp, err
 }
 
+func (c *retryableClient) CloseShard(
+	ctx context.Context,
+	request *shared.CloseShardRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.CloseShard(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) DescribeShard(
+	ctx context.Context,
+	request *shared.DescribeShardRequest,
+	opts ...yarpc.CallOption) (*shared.DescribeShardResponse, error) {
+
+	var resp *shared.DescribeShardResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.DescribeShard(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) DrainHistoryHost(
+	ctx context.Context,
+	request *shared.DrainHistoryHostRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.DrainHistoryHost(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) ListQueueTasks(
+	ctx context.Context,
+	request *shared.ListQueueTasksRequest,
+	opts ...yarpc.CallOption) (*shared.ListQueueTasksResponse, error) {
+
+	var resp *shared.ListQueueTasksResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.ListQueueTasks(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
+func (c *retryableClient) RemoveQueueTask(
+	ctx context.Context,
+	request *shared.RemoveQueueTaskRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.RemoveQueueTask(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) RegenerateWorkflowTasks(
+	ctx context.Context,
+	request *h.RegenerateWorkflowTasksRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.RegenerateWorkflowTasks(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
 func (c *retryableClient) DescribeMutableState(
 	ctx context.Context,
 	request *h.DescribeMutableStateRequest,
@@ -110,6 +190,22 @@ func (c *retryableClient) GetMutableState(
 	return resp, err
 }
 
+func (c *retryableClient) QueryWorkflow(
+	ctx context.Context,
+	request *h.QueryWorkflowRequest,
+	opts ...yarpc.CallOption) (*h.QueryWorkflowResponse, error) {
+
+	var resp *h.QueryWorkflowResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.QueryWorkflow(ctx, request, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) ResetStickyTaskList(
 	ctx context.Context,
 	request *h.ResetStickyTaskListRequest,
@@ -318,6 +414,30 @@ func (c *retryableClient) TerminateWorkflowExecution(
 	return backoff.Retry(op, c.policy, c.isRetryable)
 }
 
+func (c *retryableClient) PauseWorkflowExecution(
+	ctx context.Context,
+	request *h.PauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.PauseWorkflowExecution(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
+func (c *retryableClient) UnpauseWorkflowExecution(
+	ctx context.Context,
+	request *h.UnpauseWorkflowExecutionRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.UnpauseWorkflowExecution(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
 func (c *retryableClient) ResetWorkflowExecution(
 	ctx context.Context,
 	request *h.ResetWorkflowExecutionRequest,
@@ -59,6 +59,20 @@ func NewClient(
 	}
 }
 
+func (c *clientImpl) AddActivityCancellation(
+	ctx context.Context,
+	addRequest *m.AddActivityCancellationRequest,
+	opts ...yarpc.CallOption) error {
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getClientForTasklist(addRequest.TaskList.GetName())
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.AddActivityCancellation(ctx, addRequest, opts...)
+}
+
 func (c *clientImpl) AddActivityTask(
 	ctx context.Context,
 	addRequest *m.AddActivityTaskRequest,
@@ -87,6 +101,20 @@ func (c *clientImpl) AddDecisionTask(
 	return client.AddDecisionTask(ctx, addRequest, opts...)
 }
 
+func (c *clientImpl) PollForActivityCancellation(
+	ctx context.Context,
+	pollRequest *m.PollForActivityCancellationRequest,
+	opts ...yarpc.CallOption) (*workflow.PollForActivityCancellationResponse, error) {
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getClientForTasklist(pollRequest.PollRequest.TaskList.GetName())
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := c.createLongPollContext(ctx)
+	defer cancel()
+	return client.PollForActivityCancellation(ctx, pollRequest, opts...)
+}
+
 func (c *clientImpl) PollForActivityTask(
 	ctx context.Context,
 	pollRequest *m.PollForActivityTaskRequest,
@@ -159,6 +187,17 @@ func (c *clientImpl) DescribeTaskList(ctx context.Context, request *m.DescribeTa
 	return client.DescribeTaskList(ctx, request, opts...)
 }
 
+func (c *clientImpl) PromoteTaskListBuildID(ctx context.Context, request *m.PromoteTaskListBuildIDRequest, opts ...yarpc.CallOption) error {
+	opts = common.AggregateYarpcOptions(ctx, opts...)
+	client, err := c.getClientForTasklist(request.PromoteRequest.TaskList.GetName())
+	if err != nil {
+		return err
+	}
+	ctx, cancel := c.createContext(ctx)
+	defer cancel()
+	return client.PromoteTaskListBuildID(ctx, request, opts...)
+}
+
 func (c *clientImpl) createContext(parent context.Context) (context.Context, context.CancelFunc) {
 	if parent == nil {
 		return context.WithTimeout(context.Background(), c.timeout)
@@ -44,6 +44,23 @@ func NewMetricClient(client Client, metricsClient metrics.Client) Client {
 	}
 }
 
+func (c *metricClient) AddActivityCancellation(
+	ctx context.Context,
+	addRequest *m.AddActivityCancellationRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.MatchingClientAddActivityCancellationScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientAddActivityCancellationScope, metrics.CadenceClientLatency)
+	err := c.client.AddActivityCancellation(ctx, addRequest, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientAddActivityCancellationScope, metrics.CadenceClientFailures)
+	}
+
+	return err
+}
+
 func (c *metricClient) AddActivityTask(
 	ctx context.Context,
 	addRequest *m.AddActivityTaskRequest,
@@ -78,6 +95,23 @@ func (c *metricClient) AddDecisionTask(
 	return err
 }
 
+func (c *metricClient) PollForActivityCancellation(
+	ctx context.Context,
+	pollRequest *m.PollForActivityCancellationRequest,
+	opts ...yarpc.CallOption) (*workflow.PollForActivityCancellationResponse, error) {
+	c.metricsClient.IncCounter(metrics.MatchingClientPollForActivityCancellationScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientPollForActivityCancellationScope, metrics.CadenceClientLatency)
+	resp, err := c.client.PollForActivityCancellation(ctx, pollRequest, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientPollForActivityCancellationScope, metrics.CadenceClientFailures)
+	}
+
+	return resp, err
+}
+
 func (c *metricClient) PollForActivityTask(
 	ctx context.Context,
 	pollRequest *m.PollForActivityTaskRequest,
@@ -179,3 +213,20 @@ func (c *metricClient) DescribeTaskList(
 
 	return resp, err
 }
+
+func (c *metricClient) PromoteTaskListBuildID(
+	ctx context.Context,
+	request *m.PromoteTaskListBuildIDRequest,
+	opts ...yarpc.CallOption) error {
+	c.metricsClient.IncCounter(metrics.MatchingClientPromoteTaskListBuildIDScope, metrics.CadenceClientRequests)
+
+	sw := c.metricsClient.StartTimer(metrics.MatchingClientPromoteTaskListBuildIDScope, metrics.CadenceClientLatency)
+	err := c.client.PromoteTaskListBuildID(ctx, request, opts...)
+	sw.Stop()
+
+	if err != nil {
+		c.metricsClient.IncCounter(metrics.MatchingClientPromoteTaskListBuildIDScope, metrics.CadenceClientFailures)
+	}
+
+	return err
+}
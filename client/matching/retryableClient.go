@@ -46,6 +46,17 @@ func NewRetryableClient(client Client, policy backoff.RetryPolicy, isRetryable b
 	}
 }
 
+func (c *retryableClient) AddActivityCancellation(
+	ctx context.Context,
+	addRequest *m.AddActivityCancellationRequest,
+	opts ...yarpc.CallOption) error {
+	op := func() error {
+		return c.client.AddActivityCancellation(ctx, addRequest, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
+
 func (c *retryableClient) AddActivityTask(
 	ctx context.Context,
 	addRequest *m.AddActivityTaskRequest,
@@ -69,6 +80,22 @@ func (c *retryableClient) AddDecisionTask(
 	return backoff.Retry(op, c.policy, c.isRetryable)
 }
 
+func (c *retryableClient) PollForActivityCancellation(
+	ctx context.Context,
+	pollRequest *m.PollForActivityCancellationRequest,
+	opts ...yarpc.CallOption) (*workflow.PollForActivityCancellationResponse, error) {
+
+	var resp *workflow.PollForActivityCancellationResponse
+	op := func() error {
+		var err error
+		resp, err = c.client.PollForActivityCancellation(ctx, pollRequest, opts...)
+		return err
+	}
+
+	err := backoff.Retry(op, c.policy, c.isRetryable)
+	return resp, err
+}
+
 func (c *retryableClient) PollForActivityTask(
 	ctx context.Context,
 	pollRequest *m.PollForActivityTaskRequest,
@@ -156,3 +183,15 @@ func (c *retryableClient) DescribeTaskList(
 	err := backoff.Retry(op, c.policy, c.isRetryable)
 	return resp, err
 }
+
+func (c *retryableClient) PromoteTaskListBuildID(
+	ctx context.Context,
+	request *m.PromoteTaskListBuildIDRequest,
+	opts ...yarpc.CallOption) error {
+
+	op := func() error {
+		return c.client.PromoteTaskListBuildID(ctx, request, opts...)
+	}
+
+	return backoff.Retry(op, c.policy, c.isRetryable)
+}
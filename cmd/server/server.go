@@ -105,9 +105,16 @@ func (s *server) startService() common.Daemon {
 	params.Logger = s.cfg.Log.NewBarkLogger()
 	params.PersistenceConfig = s.cfg.Persistence
 
-	params.RingpopFactory, err = s.cfg.Ringpop.NewFactory()
-	if err != nil {
-		log.Fatalf("error creating ringpop factory: %v", err)
+	if s.cfg.Membership.IsRingpop() {
+		params.RingpopFactory, err = s.cfg.Ringpop.NewFactory()
+		if err != nil {
+			log.Fatalf("error creating ringpop factory: %v", err)
+		}
+	} else {
+		params.MembershipFactory, err = s.cfg.Membership.NewFactory(params.Logger)
+		if err != nil {
+			log.Fatalf("error creating membership factory: %v", err)
+		}
 	}
 
 	params.DynamicConfig = dynamicconfig.NewNopClient()
@@ -115,7 +122,10 @@ func (s *server) startService() common.Daemon {
 
 	svcCfg := s.cfg.Services[s.name]
 	params.MetricScope = svcCfg.Metrics.NewScope()
-	params.RPCFactory = svcCfg.RPC.NewFactory(params.Name, params.Logger)
+	rpcFactory := svcCfg.RPC.NewFactory(params.Name, params.Logger)
+	params.RPCFactory = rpcFactory
+	params.HTTPGatewayAddress = rpcFactory.GetHTTPGatewayAddress()
+	params.HTTPGatewayTLS = rpcFactory.GetHTTPGatewayTLSConfig()
 	params.PProfInitializer = svcCfg.PProf.NewInitializer(params.Logger)
 	enableGlobalDomain := dc.GetBoolProperty(dynamicconfig.EnableGlobalDomain, s.cfg.ClustersInfo.EnableGlobalDomain)
 	enableArchival := dc.GetBoolProperty(dynamicconfig.EnableArchival, s.cfg.Archival.Enabled)
@@ -132,7 +142,15 @@ func (s *server) startService() common.Daemon {
 		enableArchival,
 		s.cfg.Archival.Filestore.DefaultBucket.Name,
 	)
-	params.DispatcherProvider = client.NewIPYarpcDispatcherProvider()
+	if svcCfg.RPC.TLS.Enabled {
+		tlsConfig, err := svcCfg.RPC.TLS.NewClientConfig()
+		if err != nil {
+			log.Fatalf("error creating TLS config for dispatcher provider: %v", err)
+		}
+		params.DispatcherProvider = client.NewIPYarpcDispatcherProviderWithTLS(tlsConfig)
+	} else {
+		params.DispatcherProvider = client.NewIPYarpcDispatcherProvider()
+	}
 	// TODO: We need to switch Cadence to use zap logger, until then just pass zap.NewNop
 
 	params.MetricsClient = metrics.NewClient(params.MetricScope, service.GetMetricsServiceIdx(params.Name, params.Logger))
@@ -160,7 +178,7 @@ func (s *server) startService() common.Daemon {
 		}
 	}
 
-	if params.ClusterMetadata.IsArchivalEnabled() {
+	if params.ClusterMetadata.IsArchivalEnabled() || s.cfg.Persistence.PayloadOffload.Enabled {
 		params.BlobstoreClient, err = filestore.NewClient(&s.cfg.Archival.Filestore, params.Logger)
 		if err != nil {
 			log.Fatalf("error creating blobstore: %v", err)
@@ -39,6 +39,7 @@ type (
 	// Feature provides information about client's capibility
 	Feature interface {
 		SupportStickyQuery() bool
+		SupportConsistentQuery() bool
 	}
 
 	// FeatureImpl is used for determining the client's capibility.
@@ -74,6 +75,12 @@ func (feature *FeatureImpl) SupportStickyQuery() bool {
 	return feature.featureVersion.major > 0
 }
 
+// SupportConsistentQuery whether a client can answer queries delivered with a decision task
+func (feature *FeatureImpl) SupportConsistentQuery() bool {
+	return feature.featureVersion.major > 1 ||
+		(feature.featureVersion.major == 1 && feature.featureVersion.minor > 0)
+}
+
 func parseVersion(versionStr string) version {
 	var major int64
 	var minor int64
@@ -55,3 +55,16 @@ func (s *FeatureSuite) TestSupportStickyQuery() {
 	feature = NewFeatureImpl(libVersion, featureVersion, lang)
 	s.False(feature.SupportStickyQuery(), "Should not support sticky query")
 }
+
+func (s *FeatureSuite) TestSupportConsistentQuery() {
+	for featureVersion, supported := range map[string]bool{
+		"":      false,
+		"0.5.0": false,
+		"1.0.0": false,
+		"1.1.0": true,
+		"2.0.0": true,
+	} {
+		feature := NewFeatureImpl("0.5.0", featureVersion, "go")
+		s.Equal(supported, feature.SupportConsistentQuery(), featureVersion)
+	}
+}
@@ -52,12 +52,17 @@ const (
 	WorkerServiceName = "cadence-worker"
 )
 
+// SystemDomainName is the name of the domain of the system workflows run by the worker service
+const SystemDomainName = "cadence-system"
+
 // Data encoding types
 const (
-	EncodingTypeJSON     EncodingType = "json"
-	EncodingTypeThriftRW              = "thriftrw"
-	EncodingTypeGob                   = "gob"
-	EncodingTypeUnknown               = "unknow"
+	EncodingTypeJSON           EncodingType = "json"
+	EncodingTypeThriftRW                    = "thriftrw"
+	EncodingTypeThriftRWSnappy              = "thriftrw-snappy"
+	EncodingTypeThriftRWGzip                = "thriftrw-gzip"
+	EncodingTypeGob                         = "gob"
+	EncodingTypeUnknown                     = "unknow"
 )
 
 // NoRetryBackoff is used to represent backoff when no retry is needed
@@ -71,6 +76,9 @@ type (
 // MaxTaskTimeout is maximum task timeout allowed. 366 days in seconds
 const MaxTaskTimeout = 31622400
 
+// MaxTaskPriority is the highest dispatch priority of workflows and activities, priorities range from 0 to MaxTaskPriority
+const MaxTaskPriority = 1000
+
 const (
 	// GetHistoryWarnSizeLimit is the threshold for emitting warn log
 	GetHistoryWarnSizeLimit = 500 * 1024 // Warn when size goes over 500KB
@@ -78,7 +86,13 @@ const (
 	GetHistoryMaxPageSize = 1000
 )
 
+// PayloadOffloadBlobSizeLimitError is the default per event blob size limit when large payloads are
+// offloaded to the blobstore, it is bounded by the size of the requests the services can handle
+const PayloadOffloadBlobSizeLimitError = 32 * 1024 * 1024
+
 const (
 	// VisibilityAppName is used to find kafka topics and ES indexName for visibility
 	VisibilityAppName = "visibility"
+	// VisibilityDLQAppName is used to find kafka topics for visibility messages which failed to be indexed to ES
+	VisibilityDLQAppName = "visibility-dlq"
 )
@@ -0,0 +1,78 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package domaindeletion
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+type (
+	// Params are the input of the domain deletion workflow
+	Params struct {
+		DomainName string
+		// Remote is set when the workflow is started by the deletion of a
+		// global domain in the master cluster, to delete the local copy of it
+		Remote bool
+	}
+)
+
+const (
+	// WorkflowIDPrefix is the prefix of the ids of the domain deletion workflows
+	WorkflowIDPrefix = "cadence-sys-domain-deleter-"
+	// WorkflowTypeName is the type of the domain deletion workflow
+	WorkflowTypeName = "cadence-sys-domain-deleter-workflow"
+	// TaskListName is the task list of the domain deletion workflow
+	TaskListName = "cadence-sys-domain-deleter-tl"
+
+	workflowStartToCloseTimeout = 30 * 24 * time.Hour
+	decisionTaskTimeout         = time.Minute
+)
+
+// WorkflowID returns the id of the deletion workflow of the given domain
+func WorkflowID(domainName string) string {
+	return WorkflowIDPrefix + domainName
+}
+
+// NewStartWorkflowRequest returns the request which starts the deletion workflow of the
+// domain, the workflow runs in the system domain of the cluster the request is sent to
+func NewStartWorkflowRequest(params Params, identity string) (*shared.StartWorkflowExecutionRequest, error) {
+	input, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	return &shared.StartWorkflowExecutionRequest{
+		Domain:                              common.StringPtr(common.SystemDomainName),
+		WorkflowId:                          common.StringPtr(WorkflowID(params.DomainName)),
+		WorkflowType:                        &shared.WorkflowType{Name: common.StringPtr(WorkflowTypeName)},
+		TaskList:                            &shared.TaskList{Name: common.StringPtr(TaskListName)},
+		Input:                               input,
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(int32(workflowStartToCloseTimeout.Seconds())),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(int32(decisionTaskTimeout.Seconds())),
+		Identity:                            common.StringPtr(identity),
+		RequestId:                           common.StringPtr(uuid.New()),
+		WorkflowIdReusePolicy:               shared.WorkflowIdReusePolicyAllowDuplicate.Ptr(),
+	}, nil
+}
@@ -33,6 +33,7 @@ type (
 	Client interface {
 		Search(ctx context.Context, p *SearchParameters) (*elastic.SearchResult, error)
 		RunBulkProcessor(ctx context.Context, p *BulkProcessorParameters) (*elastic.BulkProcessor, error)
+		DeleteByQuery(ctx context.Context, p *DeleteByQueryParameters) (*elastic.BulkIndexByScrollResponse, error)
 	}
 
 	// SearchParameters holds all required and optional parameters for executing a search
@@ -44,6 +45,14 @@ type (
 		Sorter   []elastic.Sorter
 	}
 
+	// DeleteByQueryParameters holds all required and optional parameters for executing a delete by query
+	DeleteByQueryParameters struct {
+		Index string
+		Query elastic.Query
+		// Size is the max number of documents to delete, all matching documents are deleted if 0
+		Size int
+	}
+
 	// BulkProcessorParameters holds all required and optional parameters for executing bulk service
 	BulkProcessorParameters struct {
 		Name          string
@@ -105,3 +114,15 @@ func (c *elasticWrapper) RunBulkProcessor(ctx context.Context, p *BulkProcessorP
 		After(p.AfterFunc).
 		Do(ctx)
 }
+
+func (c *elasticWrapper) DeleteByQuery(ctx context.Context, p *DeleteByQueryParameters) (*elastic.BulkIndexByScrollResponse, error) {
+	deleteService := c.client.DeleteByQuery(p.Index).
+		Query(p.Query).
+		ProceedOnVersionConflict()
+
+	if p.Size != 0 {
+		deleteService.Size(p.Size)
+	}
+
+	return deleteService.Do(ctx)
+}
@@ -31,6 +31,29 @@ type Client struct {
 	mock.Mock
 }
 
+// DeleteByQuery provides a mock function with given fields: ctx, p
+func (_m *Client) DeleteByQuery(ctx context.Context, p *elasticsearch.DeleteByQueryParameters) (*elastic.BulkIndexByScrollResponse, error) {
+	ret := _m.Called(ctx, p)
+
+	var r0 *elastic.BulkIndexByScrollResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *elasticsearch.DeleteByQueryParameters) *elastic.BulkIndexByScrollResponse); ok {
+		r0 = rf(ctx, p)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*elastic.BulkIndexByScrollResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *elasticsearch.DeleteByQueryParameters) error); ok {
+		r1 = rf(ctx, p)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // RunBulkProcessor provides a mock function with given fields: ctx, p
 func (_m *Client) RunBulkProcessor(ctx context.Context, p *elasticsearch.BulkProcessorParameters) (*elastic.BulkProcessor, error) {
 	ret := _m.Called(ctx, p)
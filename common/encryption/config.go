@@ -0,0 +1,51 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encryption
+
+import (
+	"errors"
+)
+
+type (
+	// Config describes the configuration of the file based key provider
+	Config struct {
+		// Enabled indicates whether payloads are encrypted at rest
+		Enabled bool `yaml:"enabled"`
+		// KeyFile is the path of the yaml file containing the keys
+		KeyFile string `yaml:"keyFile"`
+	}
+
+	// keyFile is the content of the key file
+	keyFile struct {
+		// CurrentKeyID is the id of the key new payloads are encrypted with
+		CurrentKeyID string `yaml:"currentKeyID"`
+		// Keys maps key ids to base64 encoded AES-256 keys
+		Keys map[string]string `yaml:"keys"`
+	}
+)
+
+// Validate validates config
+func (c *Config) Validate() error {
+	if len(c.KeyFile) == 0 {
+		return errors.New("empty key file")
+	}
+	return nil
+}
@@ -0,0 +1,230 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encryption
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+type (
+	// UnknownKeyError is returned when a payload was encrypted with a key the key provider does not have
+	UnknownKeyError struct {
+		KeyID string
+	}
+
+	aesEncryptor struct {
+		keyProvider KeyProvider
+	}
+
+	noopEncryptor struct{}
+)
+
+const (
+	// keySize is the size of AES-256 keys
+	keySize = 32
+	// maxKeyIDLength is the max length of a key id, the length is stored in a single byte of the envelope
+	maxKeyIDLength = 255
+	// checksumSize is the size of the crc32 checksum following the header of an envelope
+	checksumSize = 4
+)
+
+const (
+	// envelopeTypePlain wraps a plaintext payload which itself starts with the envelope prefix,
+	// so that user payloads such as heartbeat details can never be mistaken for an envelope
+	envelopeTypePlain byte = 0x00
+	// envelopeTypeAES wraps a payload encrypted with AES-256-GCM
+	envelopeTypeAES byte = 0x01
+)
+
+// envelopePrefix starts every envelope and is followed by the envelope type. Its leading zero byte never
+// starts a json document, and a thriftrw struct starting with a zero byte is the empty struct, which is a
+// single byte long. Plaintext payloads starting with it are wrapped in a plain envelope on write.
+// Payloads written before encryption was enabled may still start with it, so an envelope is only
+// recognized if the checksum following its header matches, anything else is returned as is.
+var envelopePrefix = []byte{0x00, 'c', 'e'}
+
+// envelopeMagic starts every encrypted payload
+var envelopeMagic = append(append([]byte{}, envelopePrefix...), envelopeTypeAES)
+
+// plainEnvelopeMagic starts every escaped plaintext payload
+var plainEnvelopeMagic = append(append([]byte{}, envelopePrefix...), envelopeTypePlain)
+
+var errEncryptionNotEnabled = errors.New("payload is encrypted but encryption is not enabled")
+
+// NewAESEncryptor returns an encryptor using AES-256-GCM with the keys of the given key provider.
+// Encrypted payloads have the layout: magic | key id length | key id | header checksum | nonce | sealed data
+func NewAESEncryptor(keyProvider KeyProvider) Encryptor {
+	return &aesEncryptor{
+		keyProvider: keyProvider,
+	}
+}
+
+// NewNoopEncryptor returns an encryptor that does not encrypt payloads, it fails to decrypt encrypted ones
+func NewNoopEncryptor() Encryptor {
+	return &noopEncryptor{}
+}
+
+// IsEncrypted returns true if the given payload is wrapped in an encryption envelope
+func IsEncrypted(data []byte) bool {
+	_, _, ok := parseEncrypted(data)
+	return ok
+}
+
+// IsEnvelope returns true if the given payload is wrapped in any envelope and must be passed to Decrypt
+func IsEnvelope(data []byte) bool {
+	if _, ok := unwrapPlain(data); ok {
+		return true
+	}
+	return IsEncrypted(data)
+}
+
+// wrapPlain wraps a plaintext payload in a plain envelope if it could be mistaken for an envelope,
+// the layout of a plain envelope is: magic | payload checksum | payload
+func wrapPlain(data []byte) []byte {
+	if !bytes.HasPrefix(data, envelopePrefix) {
+		return data
+	}
+	envelope := make([]byte, 0, len(plainEnvelopeMagic)+checksumSize+len(data))
+	envelope = append(envelope, plainEnvelopeMagic...)
+	envelope = appendChecksum(envelope, data)
+	return append(envelope, data...)
+}
+
+// unwrapPlain returns the payload of a plain envelope, and false if the payload is not in a plain envelope
+func unwrapPlain(data []byte) ([]byte, bool) {
+	if !bytes.HasPrefix(data, plainEnvelopeMagic) || len(data) < len(plainEnvelopeMagic)+checksumSize {
+		return nil, false
+	}
+	payload := data[len(plainEnvelopeMagic)+checksumSize:]
+	if !bytes.Equal(data[len(plainEnvelopeMagic):len(plainEnvelopeMagic)+checksumSize], appendChecksum(nil, payload)) {
+		return nil, false
+	}
+	return payload, true
+}
+
+// parseEncrypted returns the key id and the nonce followed by the sealed data of an encryption envelope,
+// and false if the payload does not start with a valid encryption envelope header
+func parseEncrypted(data []byte) (string, []byte, bool) {
+	if !bytes.HasPrefix(data, envelopeMagic) || len(data) == len(envelopeMagic) {
+		return "", nil, false
+	}
+	headerLength := len(envelopeMagic) + 1 + int(data[len(envelopeMagic)])
+	if len(data) < headerLength+checksumSize {
+		return "", nil, false
+	}
+	if !bytes.Equal(data[headerLength:headerLength+checksumSize], appendChecksum(nil, data[:headerLength])) {
+		return "", nil, false
+	}
+	return string(data[len(envelopeMagic)+1 : headerLength]), data[headerLength+checksumSize:], true
+}
+
+// appendChecksum appends the crc32 checksum of the given data to the envelope
+func appendChecksum(envelope []byte, data []byte) []byte {
+	var checksum [checksumSize]byte
+	binary.BigEndian.PutUint32(checksum[:], crc32.ChecksumIEEE(data))
+	return append(envelope, checksum[:]...)
+}
+
+func (e *aesEncryptor) Encrypt(data []byte) ([]byte, error) {
+	if len(data) == 0 {
+		return data, nil
+	}
+	keyID := e.keyProvider.CurrentKeyID()
+	gcm, err := e.newGCM(keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	envelope := make([]byte, 0, len(envelopeMagic)+1+len(keyID)+checksumSize+len(nonce)+len(data)+gcm.Overhead())
+	envelope = append(envelope, envelopeMagic...)
+	envelope = append(envelope, byte(len(keyID)))
+	envelope = append(envelope, keyID...)
+	envelope = appendChecksum(envelope, envelope)
+	envelope = append(envelope, nonce...)
+	return gcm.Seal(envelope, nonce, data, nil), nil
+}
+
+func (e *aesEncryptor) Decrypt(data []byte) ([]byte, error) {
+	if plaintext, ok := unwrapPlain(data); ok {
+		return plaintext, nil
+	}
+	keyID, rest, ok := parseEncrypted(data)
+	if !ok {
+		// payloads written before encryption was enabled
+		return data, nil
+	}
+
+	gcm, err := e.newGCM(keyID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < gcm.NonceSize() {
+		return nil, errors.New("corrupted encryption envelope, nonce is truncated")
+	}
+	nonce := rest[:gcm.NonceSize()]
+	plaintext, err := gcm.Open(nil, nonce, rest[gcm.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt payload with key %v: %v", keyID, err)
+	}
+	return plaintext, nil
+}
+
+func (e *aesEncryptor) newGCM(keyID string) (cipher.AEAD, error) {
+	key, err := e.keyProvider.GetKey(keyID)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func (e *noopEncryptor) Encrypt(data []byte) ([]byte, error) {
+	return wrapPlain(data), nil
+}
+
+func (e *noopEncryptor) Decrypt(data []byte) ([]byte, error) {
+	if plaintext, ok := unwrapPlain(data); ok {
+		return plaintext, nil
+	}
+	if IsEncrypted(data) {
+		return nil, errEncryptionNotEnabled
+	}
+	return data, nil
+}
+
+func (e *UnknownKeyError) Error() string {
+	return fmt.Sprintf("unknown encryption key %v", e.KeyID)
+}
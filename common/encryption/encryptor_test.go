@@ -0,0 +1,217 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encryption
+
+import (
+	"encoding/base64"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type EncryptorSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+type staticKeyProvider struct {
+	currentKeyID string
+	keys         map[string][]byte
+}
+
+func TestEncryptorSuite(t *testing.T) {
+	suite.Run(t, new(EncryptorSuite))
+}
+
+func (s *EncryptorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *EncryptorSuite) TestRoundTrip() {
+	encryptor := NewAESEncryptor(newStaticKeyProvider("key1", "key1"))
+	payload := []byte(`[{"eventId":1}]`)
+
+	encrypted, err := encryptor.Encrypt(payload)
+	s.NoError(err)
+	s.True(IsEncrypted(encrypted))
+	s.NotContains(string(encrypted), `"eventId"`)
+
+	decrypted, err := encryptor.Decrypt(encrypted)
+	s.NoError(err)
+	s.Equal(payload, decrypted)
+}
+
+func (s *EncryptorSuite) TestEmptyPayload() {
+	encryptor := NewAESEncryptor(newStaticKeyProvider("key1", "key1"))
+	encrypted, err := encryptor.Encrypt(nil)
+	s.NoError(err)
+	s.Nil(encrypted)
+	decrypted, err := encryptor.Decrypt(encrypted)
+	s.NoError(err)
+	s.Nil(decrypted)
+}
+
+func (s *EncryptorSuite) TestLegacyPayload() {
+	encryptor := NewAESEncryptor(newStaticKeyProvider("key1", "key1"))
+	payload := []byte(`[{"eventId":1}]`)
+	decrypted, err := encryptor.Decrypt(payload)
+	s.NoError(err)
+	s.Equal(payload, decrypted)
+}
+
+func (s *EncryptorSuite) TestKeyRotation() {
+	oldEncryptor := NewAESEncryptor(newStaticKeyProvider("key1", "key1"))
+	payload := []byte("heartbeat details")
+	encrypted, err := oldEncryptor.Encrypt(payload)
+	s.NoError(err)
+
+	newEncryptor := NewAESEncryptor(newStaticKeyProvider("key2", "key1", "key2"))
+	decrypted, err := newEncryptor.Decrypt(encrypted)
+	s.NoError(err)
+	s.Equal(payload, decrypted)
+
+	reencrypted, err := newEncryptor.Encrypt(payload)
+	s.NoError(err)
+	_, err = oldEncryptor.Decrypt(reencrypted)
+	s.Error(err)
+	s.IsType(&UnknownKeyError{}, err)
+}
+
+func (s *EncryptorSuite) TestTamperedPayload() {
+	encryptor := NewAESEncryptor(newStaticKeyProvider("key1", "key1"))
+	encrypted, err := encryptor.Encrypt([]byte("signal input"))
+	s.NoError(err)
+	encrypted[len(encrypted)-1] ^= 0xff
+	_, err = encryptor.Decrypt(encrypted)
+	s.Error(err)
+
+	headerLength := len(envelopeMagic) + 1 + len("key1") + checksumSize
+	_, err = encryptor.Decrypt(encrypted[:headerLength+2])
+	s.Error(err)
+}
+
+func (s *EncryptorSuite) TestNoopEncryptor() {
+	encryptor := NewNoopEncryptor()
+	payload := []byte("workflow input")
+	encrypted, err := encryptor.Encrypt(payload)
+	s.NoError(err)
+	s.Equal(payload, encrypted)
+	decrypted, err := encryptor.Decrypt(payload)
+	s.NoError(err)
+	s.Equal(payload, decrypted)
+
+	encrypted, err = NewAESEncryptor(newStaticKeyProvider("key1", "key1")).Encrypt(payload)
+	s.NoError(err)
+	_, err = encryptor.Decrypt(encrypted)
+	s.Equal(errEncryptionNotEnabled, err)
+}
+
+func (s *EncryptorSuite) TestPayloadWithEnvelopePrefix() {
+	payload := append(append([]byte{}, envelopeMagic...), []byte("user heartbeat details")...)
+	for _, encryptor := range []Encryptor{NewNoopEncryptor(), NewAESEncryptor(newStaticKeyProvider("key1", "key1"))} {
+		encrypted, err := encryptor.Encrypt(payload)
+		s.NoError(err)
+		s.True(IsEnvelope(encrypted))
+		decrypted, err := encryptor.Decrypt(encrypted)
+		s.NoError(err)
+		s.Equal(payload, decrypted)
+	}
+}
+
+func (s *EncryptorSuite) TestLegacyPayloadWithEnvelopePrefix() {
+	payloads := [][]byte{
+		append(append([]byte{}, plainEnvelopeMagic...), []byte("legacy heartbeat details")...),
+		append(append([]byte{}, envelopeMagic...), []byte("legacy heartbeat details")...),
+		append(append([]byte{}, envelopeMagic...), 4, 'k', 'e', 'y', '1'),
+	}
+	for _, payload := range payloads {
+		s.False(IsEnvelope(payload))
+		for _, encryptor := range []Encryptor{NewNoopEncryptor(), NewAESEncryptor(newStaticKeyProvider("key1", "key1"))} {
+			decrypted, err := encryptor.Decrypt(payload)
+			s.NoError(err)
+			s.Equal(payload, decrypted)
+		}
+	}
+}
+
+func (s *EncryptorSuite) TestFileKeyProvider() {
+	dir, err := ioutil.TempDir("", "TestFileKeyProvider")
+	s.NoError(err)
+	defer os.RemoveAll(dir)
+
+	key := base64.StdEncoding.EncodeToString(make([]byte, keySize))
+	testCases := []struct {
+		content string
+		isValid bool
+	}{
+		{content: "currentKeyID: key1\nkeys:\n  key1: " + key + "\n", isValid: true},
+		{content: "currentKeyID: key2\nkeys:\n  key1: " + key + "\n", isValid: false},
+		{content: "currentKeyID: key1\nkeys:\n  key1: not-base64\n", isValid: false},
+		{content: "currentKeyID: key1\nkeys:\n  key1: " + base64.StdEncoding.EncodeToString([]byte("short")) + "\n", isValid: false},
+	}
+	for i, tc := range testCases {
+		path := filepath.Join(dir, "keys.yaml")
+		s.NoError(ioutil.WriteFile(path, []byte(tc.content), 0600))
+		provider, err := NewFileKeyProvider(&Config{Enabled: true, KeyFile: path})
+		if !tc.isValid {
+			s.Error(err, "test case %v", i)
+			continue
+		}
+		s.NoError(err, "test case %v", i)
+		s.Equal("key1", provider.CurrentKeyID())
+		_, err = provider.GetKey("key1")
+		s.NoError(err)
+		_, err = provider.GetKey("key2")
+		s.IsType(&UnknownKeyError{}, err)
+	}
+
+	_, err = NewFileKeyProvider(&Config{Enabled: true})
+	s.Error(err)
+}
+
+func newStaticKeyProvider(currentKeyID string, keyIDs ...string) KeyProvider {
+	p := &staticKeyProvider{
+		currentKeyID: currentKeyID,
+		keys:         make(map[string][]byte),
+	}
+	for i, keyID := range keyIDs {
+		key := make([]byte, keySize)
+		key[0] = byte(i + 1)
+		p.keys[keyID] = key
+	}
+	return p
+}
+
+func (p *staticKeyProvider) CurrentKeyID() string {
+	return p.currentKeyID
+}
+
+func (p *staticKeyProvider) GetKey(keyID string) ([]byte, error) {
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, &UnknownKeyError{KeyID: keyID}
+	}
+	return key, nil
+}
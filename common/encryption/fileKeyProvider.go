@@ -0,0 +1,91 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encryption
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v2"
+)
+
+type (
+	fileKeyProvider struct {
+		currentKeyID string
+		keys         map[string][]byte
+	}
+)
+
+// NewFileKeyProvider returns a key provider which reads its keys from the key file of the given config.
+// The key file is a yaml file of the form:
+//
+//	currentKeyID: key2
+//	keys:
+//	  key1: <base64 encoded 32 byte key>
+//	  key2: <base64 encoded 32 byte key>
+func NewFileKeyProvider(cfg *Config) (KeyProvider, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	data, err := ioutil.ReadFile(cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	var file keyFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+
+	provider := &fileKeyProvider{
+		currentKeyID: file.CurrentKeyID,
+		keys:         make(map[string][]byte, len(file.Keys)),
+	}
+	for keyID, encoded := range file.Keys {
+		if len(keyID) > maxKeyIDLength {
+			return nil, fmt.Errorf("key id %v is longer than %v bytes", keyID, maxKeyIDLength)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode key %v: %v", keyID, err)
+		}
+		if len(key) != keySize {
+			return nil, fmt.Errorf("key %v must be %v bytes, got %v", keyID, keySize, len(key))
+		}
+		provider.keys[keyID] = key
+	}
+	if _, ok := provider.keys[provider.currentKeyID]; !ok {
+		return nil, fmt.Errorf("current key %v is not in the key file", provider.currentKeyID)
+	}
+	return provider, nil
+}
+
+func (p *fileKeyProvider) CurrentKeyID() string {
+	return p.currentKeyID
+}
+
+func (p *fileKeyProvider) GetKey(keyID string) ([]byte, error) {
+	key, ok := p.keys[keyID]
+	if !ok {
+		return nil, &UnknownKeyError{KeyID: keyID}
+	}
+	return key, nil
+}
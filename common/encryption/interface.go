@@ -0,0 +1,41 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package encryption
+
+type (
+	// KeyProvider vends the keys used to encrypt payloads at rest. Keys are identified by an id
+	// that is recorded next to every encrypted payload, so that the current key can be rotated
+	// while payloads encrypted with older keys remain readable as long as their key is provided.
+	KeyProvider interface {
+		// CurrentKeyID returns the id of the key new payloads are encrypted with
+		CurrentKeyID() string
+		// GetKey returns the key with the given id
+		GetKey(keyID string) ([]byte, error)
+	}
+
+	// Encryptor encrypts and decrypts payloads persisted by cadence
+	Encryptor interface {
+		// Encrypt encrypts the given payload and wraps it in an envelope
+		Encrypt(data []byte) ([]byte, error)
+		// Decrypt decrypts the given envelope, payloads that were not encrypted are returned as is
+		Decrypt(data []byte) ([]byte, error)
+	}
+)
@@ -103,6 +103,11 @@ const (
 	TagValueIndexerESProcessorComponent       = "indexer-es-processor"
 	TagValueESVisibilityManager               = "es-visibility-manager"
 	TagValueArchivalSystemWorkflowComponent   = "archival-system-workflow"
+	TagValueScannerComponent                  = "scanner"
+	TagValueHistoryScavengerComponent         = "history-scavenger"
+	TagValueTaskListScavengerComponent        = "tasklist-scavenger"
+	TagValueExecutionsScannerComponent        = "executions-scanner"
+	TagValueDomainDeleterComponent            = "domain-deleter"
 
 	// TagHistoryBuilderAction values
 	TagValueActionWorkflowStarted                 = "add-workflowexecution-started-event"
@@ -128,6 +133,8 @@ const (
 	TagValueActionTimerCanceled                   = "add-timer-Canceled-event"
 	TagValueActionWorkflowTerminated              = "add-workflowexecution-terminated-event"
 	TagValueActionWorkflowSignaled                = "add-workflowexecution-signaled-event"
+	TagValueActionWorkflowPaused                  = "add-workflowexecution-paused-event"
+	TagValueActionWorkflowUnpaused                = "add-workflowexecution-unpaused-event"
 	TagValueActionContinueAsNew                   = "add-continue-as-new-event"
 	TagValueActionWorkflowCanceled                = "add-workflowexecution-canceled-event"
 	TagValueActionChildExecutionStarted           = "add-childexecution-started-event"
@@ -0,0 +1,143 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	dnsLookupTimeout = 5 * time.Second
+)
+
+type (
+	staticHostProvider struct {
+		hosts map[string][]string
+	}
+
+	// dnsResolver is the subset of net.Resolver used by the dns host provider
+	dnsResolver interface {
+		LookupHost(ctx context.Context, host string) ([]string, error)
+		LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	}
+
+	dnsHostProvider struct {
+		names    map[string]string
+		resolver dnsResolver
+	}
+)
+
+var _ HostProvider = (*staticHostProvider)(nil)
+var _ HostProvider = (*dnsHostProvider)(nil)
+
+// NewStaticHostProvider returns a host provider serving a fixed list of
+// ip:port addresses for each service
+func NewStaticHostProvider(hosts map[string][]string) HostProvider {
+	return &staticHostProvider{hosts: hosts}
+}
+
+// NewDNSHostProvider returns a host provider resolving the member hosts of each service
+// from DNS. A name of the form host:port is resolved with its A/AAAA records, all the
+// addresses sharing the given port. A name of the form _service._proto.domain is
+// resolved with its SRV records, each target being resolved in turn.
+func NewDNSHostProvider(names map[string]string) HostProvider {
+	return newDNSHostProvider(names, net.DefaultResolver)
+}
+
+func newDNSHostProvider(names map[string]string, resolver dnsResolver) *dnsHostProvider {
+	return &dnsHostProvider{names: names, resolver: resolver}
+}
+
+func (p *staticHostProvider) GetHosts(service string) ([]string, error) {
+	hosts, ok := p.hosts[service]
+	if !ok {
+		return nil, ErrUnknownService
+	}
+	return hosts, nil
+}
+
+func (p *dnsHostProvider) GetHosts(service string) ([]string, error) {
+	name, ok := p.names[service]
+	if !ok {
+		return nil, ErrUnknownService
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), dnsLookupTimeout)
+	defer cancel()
+
+	var hosts []string
+	var err error
+	if isSRVName(name) {
+		hosts, err = p.lookupSRV(ctx, name)
+	} else {
+		hosts, err = p.lookupHostPort(ctx, name)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(hosts)
+	return hosts, nil
+}
+
+func (p *dnsHostProvider) lookupSRV(ctx context.Context, name string) ([]string, error) {
+	_, records, err := p.resolver.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, err
+	}
+	var hosts []string
+	for _, record := range records {
+		addrs, err := p.resolver.LookupHost(ctx, strings.TrimSuffix(record.Target, "."))
+		if err != nil {
+			return nil, err
+		}
+		port := strconv.Itoa(int(record.Port))
+		for _, addr := range addrs {
+			hosts = append(hosts, net.JoinHostPort(addr, port))
+		}
+	}
+	return hosts, nil
+}
+
+func (p *dnsHostProvider) lookupHostPort(ctx context.Context, name string) ([]string, error) {
+	host, port, err := net.SplitHostPort(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dns name %v: %v", name, err)
+	}
+	addrs, err := p.resolver.LookupHost(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	hosts := make([]string, 0, len(addrs))
+	for _, addr := range addrs {
+		hosts = append(hosts, net.JoinHostPort(addr, port))
+	}
+	return hosts, nil
+}
+
+func isSRVName(name string) bool {
+	return strings.HasPrefix(name, "_")
+}
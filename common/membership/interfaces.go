@@ -35,6 +35,9 @@ var ErrInsufficientHosts = &shared.InternalServiceError{Message: "Not enough hos
 // ErrListenerAlreadyExist is thrown on a duplicate AddListener call from the same listener
 var ErrListenerAlreadyExist = errors.New("Listener already exist for the service")
 
+// ErrEvictSelfNotSupported is thrown when the membership is not managed by the hosts themselves
+var ErrEvictSelfNotSupported = errors.New("Evicting self is not supported by the membership")
+
 type (
 
 	// ChangedEvent describes a change in membership
@@ -61,6 +64,9 @@ type (
 		AddListener(service string, name string, notifyChannel chan<- *ChangedEvent) error
 		// RemoveListener removes a listener for this service.
 		RemoveListener(service string, name string) error
+		// EvictSelf removes this host from membership, other hosts will take over its keys.
+		// The host does not re-join the membership until it is restarted.
+		EvictSelf() error
 	}
 
 	// ServiceResolver provides membership information for a specific cadence service.
@@ -75,4 +81,11 @@ type (
 		// RemoveListener removes a listener for this service.
 		RemoveListener(name string) error
 	}
+
+	// HostProvider provides the addresses of the member hosts of the cadence services,
+	// it is polled by the monitors which are not based on ringpop
+	HostProvider interface {
+		// GetHosts returns the ip:port addresses of the member hosts of the given service
+		GetHosts(service string) ([]string, error)
+	}
 )
@@ -0,0 +1,147 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+)
+
+type providerMonitor struct {
+	started  bool
+	stopped  bool
+	self     *HostInfo
+	services []string
+	rings    map[string]*providerServiceResolver
+	logger   bark.Logger
+	mutex    sync.Mutex
+}
+
+var _ Monitor = (*providerMonitor)(nil)
+
+// NewHostProviderMonitor returns a membership monitor which periodically polls the member
+// hosts of the services from the given host provider, for deployments without ringpop.
+// selfAddress and selfService identify the current host.
+func NewHostProviderMonitor(
+	selfAddress string,
+	selfService string,
+	services []string,
+	provider HostProvider,
+	refreshInterval time.Duration,
+	logger bark.Logger,
+) Monitor {
+	if refreshInterval <= 0 {
+		refreshInterval = defaultRefreshInterval
+	}
+	monitor := &providerMonitor{
+		self:     NewHostInfo(selfAddress, map[string]string{RoleKey: selfService}),
+		services: services,
+		logger:   logger,
+		rings:    make(map[string]*providerServiceResolver),
+	}
+	for _, service := range services {
+		monitor.rings[service] = newProviderServiceResolver(service, provider, refreshInterval, logger)
+	}
+	return monitor
+}
+
+func (m *providerMonitor) Start() error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.started {
+		return nil
+	}
+
+	for service, ring := range m.rings {
+		err := ring.Start()
+		if err != nil {
+			m.logger.WithField("service", service).Error("Failed to initialize ring.")
+			return err
+		}
+	}
+
+	service, _ := m.self.Label(RoleKey)
+	if ring, ok := m.rings[service]; ok && !ring.hasMember(m.self.GetAddress()) {
+		m.logger.Warnf("Host %v is not a member of service %v, it will not be assigned any key",
+			m.self.GetAddress(), service)
+	}
+
+	m.started = true
+	return nil
+}
+
+func (m *providerMonitor) Stop() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.stopped {
+		return
+	}
+
+	for _, ring := range m.rings {
+		ring.Stop()
+	}
+	m.stopped = true
+}
+
+func (m *providerMonitor) WhoAmI() (*HostInfo, error) {
+	return m.self, nil
+}
+
+// EvictSelf is not supported, the hosts are members as long as the host provider lists them
+func (m *providerMonitor) EvictSelf() error {
+	return ErrEvictSelfNotSupported
+}
+
+func (m *providerMonitor) GetResolver(service string) (ServiceResolver, error) {
+	ring, found := m.rings[service]
+	if !found {
+		return nil, ErrUnknownService
+	}
+	return ring, nil
+}
+
+func (m *providerMonitor) Lookup(service string, key string) (*HostInfo, error) {
+	ring, err := m.GetResolver(service)
+	if err != nil {
+		return nil, err
+	}
+	return ring.Lookup(key)
+}
+
+func (m *providerMonitor) AddListener(service string, name string, notifyChannel chan<- *ChangedEvent) error {
+	ring, err := m.GetResolver(service)
+	if err != nil {
+		return err
+	}
+	return ring.AddListener(name, notifyChannel)
+}
+
+func (m *providerMonitor) RemoveListener(service string, name string) error {
+	ring, err := m.GetResolver(service)
+	if err != nil {
+		return err
+	}
+	return ring.RemoveListener(name)
+}
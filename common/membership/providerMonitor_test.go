@@ -0,0 +1,296 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common/backoff"
+)
+
+type (
+	ProviderMonitorSuite struct {
+		*require.Assertions
+		suite.Suite
+		logger bark.Logger
+	}
+
+	testHostProvider struct {
+		sync.Mutex
+		hosts    map[string][]string
+		err      error
+		failures int
+	}
+
+	testDNSResolver struct {
+		hosts map[string][]string
+		srvs  map[string][]*net.SRV
+	}
+)
+
+func TestProviderMonitorSuite(t *testing.T) {
+	suite.Run(t, new(ProviderMonitorSuite))
+}
+
+func (s *ProviderMonitorSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.logger = bark.NewLoggerFromLogrus(log.New())
+}
+
+func (s *ProviderMonitorSuite) TestMonitor() {
+	provider := &testHostProvider{hosts: map[string][]string{
+		"history":  {"127.0.0.1:7934", "127.0.0.2:7934", "127.0.0.3:7934"},
+		"matching": {"127.0.0.1:7935"},
+	}}
+	monitor := NewHostProviderMonitor("127.0.0.1:7934", "history", []string{"history", "matching"},
+		provider, 10*time.Millisecond, s.logger)
+	s.NoError(monitor.Start())
+	defer monitor.Stop()
+
+	self, err := monitor.WhoAmI()
+	s.NoError(err)
+	s.Equal("127.0.0.1:7934", self.GetAddress())
+	role, _ := self.Label(RoleKey)
+	s.Equal("history", role)
+	s.Equal(ErrEvictSelfNotSupported, monitor.EvictSelf())
+
+	_, err = monitor.Lookup("frontend", "key")
+	s.Equal(ErrUnknownService, err)
+	host, err := monitor.Lookup("matching", "key")
+	s.NoError(err)
+	s.Equal("127.0.0.1:7935", host.GetAddress())
+
+	// lookups are consistent across monitors
+	other := NewHostProviderMonitor("127.0.0.2:7934", "history", []string{"history"},
+		provider, time.Minute, s.logger)
+	s.NoError(other.Start())
+	defer other.Stop()
+	for _, key := range []string{"1", "2", "3", "4", "5"} {
+		host, err := monitor.Lookup("history", key)
+		s.NoError(err)
+		otherHost, err := other.Lookup("history", key)
+		s.NoError(err)
+		s.Equal(host.GetAddress(), otherHost.GetAddress())
+	}
+
+	listenCh := make(chan *ChangedEvent, 5)
+	s.NoError(monitor.AddListener("history", "test-listener", listenCh))
+	s.Equal(ErrListenerAlreadyExist, monitor.AddListener("history", "test-listener", listenCh))
+
+	provider.setHosts("history", []string{"127.0.0.1:7934", "127.0.0.3:7934", "127.0.0.4:7934"})
+	select {
+	case e := <-listenCh:
+		s.Equal(1, len(e.HostsAdded))
+		s.Equal("127.0.0.4:7934", e.HostsAdded[0].GetAddress())
+		s.Equal(1, len(e.HostsRemoved))
+		s.Equal("127.0.0.2:7934", e.HostsRemoved[0].GetAddress())
+		s.Nil(e.HostsUpdated)
+	case <-time.After(5 * time.Second):
+		s.Fail("Timed out waiting for the membership change")
+	}
+	for _, key := range []string{"1", "2", "3", "4", "5"} {
+		host, err := monitor.Lookup("history", key)
+		s.NoError(err)
+		s.NotEqual("127.0.0.2:7934", host.GetAddress())
+	}
+
+	// failures of the provider keep the current members
+	provider.setError(errors.New("provider failure"))
+	time.Sleep(50 * time.Millisecond)
+	_, err = monitor.Lookup("history", "key")
+	s.NoError(err)
+	select {
+	case e := <-listenCh:
+		s.Fail("Unexpected membership change", "%v", e)
+	default:
+	}
+
+	s.NoError(monitor.RemoveListener("history", "test-listener"))
+}
+
+func (s *ProviderMonitorSuite) TestMonitor_NoHosts() {
+	provider := &testHostProvider{hosts: map[string][]string{"history": nil}}
+	monitor := NewHostProviderMonitor("127.0.0.1:7934", "history", []string{"history"},
+		provider, time.Minute, s.logger)
+	s.NoError(monitor.Start())
+	defer monitor.Stop()
+
+	_, err := monitor.Lookup("history", "key")
+	s.Equal(ErrInsufficientHosts, err)
+}
+
+func (s *ProviderMonitorSuite) TestMonitor_ProviderFailureOnStart() {
+	provider := &testHostProvider{
+		hosts:    map[string][]string{"history": {"127.0.0.1:7934"}},
+		err:      errors.New("provider failure"),
+		failures: 2,
+	}
+	monitor := s.newTestMonitor(provider, time.Minute)
+	s.NoError(monitor.Start())
+	defer monitor.Stop()
+
+	host, err := monitor.Lookup("history", "key")
+	s.NoError(err)
+	s.Equal("127.0.0.1:7934", host.GetAddress())
+}
+
+func (s *ProviderMonitorSuite) TestMonitor_ProviderUnavailableOnStart() {
+	provider := &testHostProvider{
+		hosts: map[string][]string{"history": {"127.0.0.1:7934"}},
+		err:   errors.New("provider failure"),
+	}
+	monitor := s.newTestMonitor(provider, 10*time.Millisecond)
+	s.NoError(monitor.Start())
+	defer monitor.Stop()
+
+	_, err := monitor.Lookup("history", "key")
+	s.Equal(ErrInsufficientHosts, err)
+
+	// the members are loaded once the provider recovers
+	provider.setError(nil)
+	for i := 0; i < 500; i++ {
+		if _, err = monitor.Lookup("history", "key"); err == nil {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	s.NoError(err)
+}
+
+func (s *ProviderMonitorSuite) TestMonitor_UnknownService() {
+	provider := &testHostProvider{hosts: map[string][]string{"history": {"127.0.0.1:7934"}}}
+	monitor := NewHostProviderMonitor("127.0.0.1:7934", "history", []string{"history", "matching"},
+		provider, time.Minute, s.logger)
+	s.Equal(ErrUnknownService, monitor.Start())
+}
+
+func (s *ProviderMonitorSuite) TestStaticHostProvider() {
+	provider := NewStaticHostProvider(map[string][]string{"history": {"127.0.0.1:7934"}})
+	hosts, err := provider.GetHosts("history")
+	s.NoError(err)
+	s.Equal([]string{"127.0.0.1:7934"}, hosts)
+	_, err = provider.GetHosts("matching")
+	s.Equal(ErrUnknownService, err)
+}
+
+func (s *ProviderMonitorSuite) TestDNSHostProvider() {
+	resolver := &testDNSResolver{
+		hosts: map[string][]string{
+			"history.cadence":    {"10.0.0.2", "10.0.0.1"},
+			"matching-0.cadence": {"10.0.1.1"},
+			"matching-1.cadence": {"10.0.1.2"},
+		},
+		srvs: map[string][]*net.SRV{
+			"_tchannel._tcp.matching.cadence": {
+				{Target: "matching-0.cadence.", Port: 7935},
+				{Target: "matching-1.cadence.", Port: 7936},
+			},
+		},
+	}
+	provider := newDNSHostProvider(map[string]string{
+		"history":  "history.cadence:7934",
+		"matching": "_tchannel._tcp.matching.cadence",
+		"frontend": "frontend.cadence",
+	}, resolver)
+
+	hosts, err := provider.GetHosts("history")
+	s.NoError(err)
+	s.Equal([]string{"10.0.0.1:7934", "10.0.0.2:7934"}, hosts)
+
+	hosts, err = provider.GetHosts("matching")
+	s.NoError(err)
+	s.Equal([]string{"10.0.1.1:7935", "10.0.1.2:7936"}, hosts)
+
+	_, err = provider.GetHosts("frontend")
+	s.Error(err)
+	_, err = provider.GetHosts("worker")
+	s.Equal(ErrUnknownService, err)
+}
+
+func (p *testHostProvider) GetHosts(service string) ([]string, error) {
+	p.Lock()
+	defer p.Unlock()
+	if p.err != nil {
+		err := p.err
+		// a limited number of failures resets the error once they are exhausted
+		if p.failures > 0 {
+			p.failures--
+			if p.failures == 0 {
+				p.err = nil
+			}
+		}
+		return nil, err
+	}
+	hosts, ok := p.hosts[service]
+	if !ok {
+		return nil, ErrUnknownService
+	}
+	return hosts, nil
+}
+
+// newTestMonitor creates a history monitor retrying the failures of the provider without delay
+func (s *ProviderMonitorSuite) newTestMonitor(provider HostProvider, refreshInterval time.Duration) Monitor {
+	monitor := NewHostProviderMonitor("127.0.0.1:7934", "history", []string{"history"},
+		provider, refreshInterval, s.logger)
+	policy := backoff.NewExponentialRetryPolicy(time.Millisecond)
+	policy.SetExpirationInterval(50 * time.Millisecond)
+	for _, ring := range monitor.(*providerMonitor).rings {
+		ring.retryPolicy = policy
+	}
+	return monitor
+}
+
+func (p *testHostProvider) setHosts(service string, hosts []string) {
+	p.Lock()
+	defer p.Unlock()
+	p.hosts[service] = hosts
+}
+
+func (p *testHostProvider) setError(err error) {
+	p.Lock()
+	defer p.Unlock()
+	p.err = err
+}
+
+func (r *testDNSResolver) LookupHost(ctx context.Context, host string) ([]string, error) {
+	addrs, ok := r.hosts[host]
+	if !ok {
+		return nil, &net.DNSError{Err: "no such host", Name: host}
+	}
+	return addrs, nil
+}
+
+func (r *testDNSResolver) LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	records, ok := r.srvs[name]
+	if !ok {
+		return "", nil, &net.DNSError{Err: "no such host", Name: name}
+	}
+	return name, records, nil
+}
@@ -0,0 +1,289 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package membership
+
+import (
+	"sync"
+	"time"
+
+	"github.com/dgryski/go-farm"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/ringpop-go/hashring"
+)
+
+const (
+	providerRetryInitialInterval    = time.Second
+	providerRetryMaxInterval        = 10 * time.Second
+	providerRetryExpirationInterval = time.Minute
+)
+
+type providerServiceResolver struct {
+	service         string
+	isStarted       bool
+	isStopped       bool
+	provider        HostProvider
+	retryPolicy     backoff.RetryPolicy
+	refreshInterval time.Duration
+	shutdownCh      chan struct{}
+	shutdownWG      sync.WaitGroup
+	logger          bark.Logger
+
+	ringLock sync.RWMutex
+	ring     *hashring.HashRing
+	members  map[string]struct{}
+
+	listenerLock sync.RWMutex
+	listeners    map[string]chan<- *ChangedEvent
+}
+
+var _ ServiceResolver = (*providerServiceResolver)(nil)
+
+func newProviderServiceResolver(
+	service string,
+	provider HostProvider,
+	refreshInterval time.Duration,
+	logger bark.Logger,
+) *providerServiceResolver {
+	return &providerServiceResolver{
+		service:         service,
+		provider:        provider,
+		retryPolicy:     createProviderRetryPolicy(),
+		refreshInterval: refreshInterval,
+		logger:          logger.WithFields(bark.Fields{"component": "ServiceResolver", RoleKey: service}),
+		ring:            hashring.New(farm.Fingerprint32, replicaPoints),
+		members:         make(map[string]struct{}),
+		listeners:       make(map[string]chan<- *ChangedEvent),
+		shutdownCh:      make(chan struct{}),
+	}
+}
+
+// Start loads the initial members and starts polling the host provider. Failures of the
+// provider, such as an unavailable dns server, are retried and the resolver starts without
+// members if they persist, the members are then loaded by the next successful refresh.
+func (r *providerServiceResolver) Start() error {
+	r.ringLock.RLock()
+	isStarted := r.isStarted
+	r.ringLock.RUnlock()
+	if isStarted {
+		return nil
+	}
+
+	// the provider is retried without holding the lock, lookups fail fast meanwhile
+	var addrs []string
+	op := func() error {
+		var err error
+		addrs, err = r.provider.GetHosts(r.service)
+		return err
+	}
+	err := backoff.Retry(op, r.retryPolicy, isProviderRetryable)
+	if err == ErrUnknownService {
+		return err
+	}
+
+	r.ringLock.Lock()
+	if r.isStarted {
+		r.ringLock.Unlock()
+		return nil
+	}
+	if err != nil {
+		r.logger.Errorf("Failed to load the members from the host provider, starting without members.  Error: %v", err)
+	} else {
+		r.updateMembersLocked(addrs)
+	}
+	r.isStarted = true
+	r.ringLock.Unlock()
+
+	r.shutdownWG.Add(1)
+	go r.refreshRingWorker()
+	return nil
+}
+
+// Stop stops the resolver
+func (r *providerServiceResolver) Stop() error {
+	r.ringLock.Lock()
+	if r.isStopped {
+		r.ringLock.Unlock()
+		return nil
+	}
+	r.isStopped = true
+	isStarted := r.isStarted
+	r.ringLock.Unlock()
+
+	// the lock is released while waiting as the refresh worker acquires it
+	if isStarted {
+		close(r.shutdownCh)
+		if success := common.AwaitWaitGroup(&r.shutdownWG, time.Minute); !success {
+			r.logger.Warn("service resolver timed out on shutdown.")
+		}
+	}
+
+	r.ringLock.Lock()
+	r.ring = hashring.New(farm.Fingerprint32, replicaPoints)
+	r.members = make(map[string]struct{})
+	r.ringLock.Unlock()
+
+	r.listenerLock.Lock()
+	r.listeners = make(map[string]chan<- *ChangedEvent)
+	r.listenerLock.Unlock()
+	return nil
+}
+
+// Lookup finds the host in the ring responsible for serving the given key
+func (r *providerServiceResolver) Lookup(key string) (*HostInfo, error) {
+	r.ringLock.RLock()
+	defer r.ringLock.RUnlock()
+	addr, found := r.ring.Lookup(key)
+	if !found {
+		return nil, ErrInsufficientHosts
+	}
+	return NewHostInfo(addr, r.getLabelsMap()), nil
+}
+
+func (r *providerServiceResolver) AddListener(name string, notifyChannel chan<- *ChangedEvent) error {
+	r.listenerLock.Lock()
+	defer r.listenerLock.Unlock()
+	_, ok := r.listeners[name]
+	if ok {
+		return ErrListenerAlreadyExist
+	}
+	r.listeners[name] = notifyChannel
+	return nil
+}
+
+func (r *providerServiceResolver) RemoveListener(name string) error {
+	r.listenerLock.Lock()
+	defer r.listenerLock.Unlock()
+	_, ok := r.listeners[name]
+	if !ok {
+		return nil
+	}
+	delete(r.listeners, name)
+	return nil
+}
+
+func (r *providerServiceResolver) hasMember(addr string) bool {
+	r.ringLock.RLock()
+	defer r.ringLock.RUnlock()
+	_, ok := r.members[addr]
+	return ok
+}
+
+func (r *providerServiceResolver) refresh() {
+	addrs, err := r.provider.GetHosts(r.service)
+	if err != nil {
+		// keep the current members, a transient failure of the provider must not empty the ring
+		r.logger.Warnf("Error during host provider refresh.  Error: %v", err)
+		return
+	}
+
+	r.ringLock.Lock()
+	event := r.updateMembersLocked(addrs)
+	r.ringLock.Unlock()
+
+	if event != nil {
+		r.logger.Info("Members of the service changed")
+		r.emitEvent(event)
+	}
+}
+
+// updateMembersLocked rebuilds the ring from the given addresses and returns the
+// resulting change, nil if the members did not change
+func (r *providerServiceResolver) updateMembersLocked(addrs []string) *ChangedEvent {
+	members := make(map[string]struct{}, len(addrs))
+	for _, addr := range addrs {
+		members[addr] = struct{}{}
+	}
+
+	event := &ChangedEvent{}
+	for addr := range members {
+		if _, ok := r.members[addr]; !ok {
+			event.HostsAdded = append(event.HostsAdded, NewHostInfo(addr, r.getLabelsMap()))
+		}
+	}
+	for addr := range r.members {
+		if _, ok := members[addr]; !ok {
+			event.HostsRemoved = append(event.HostsRemoved, NewHostInfo(addr, r.getLabelsMap()))
+		}
+	}
+	if len(event.HostsAdded) == 0 && len(event.HostsRemoved) == 0 {
+		return nil
+	}
+
+	ring := hashring.New(farm.Fingerprint32, replicaPoints)
+	for addr := range members {
+		ring.AddMembers(NewHostInfo(addr, r.getLabelsMap()))
+	}
+	r.ring = ring
+	r.members = members
+
+	r.logger.Debugf("Current members: %v", addrs)
+	return event
+}
+
+func (r *providerServiceResolver) emitEvent(event *ChangedEvent) {
+	r.listenerLock.RLock()
+	defer r.listenerLock.RUnlock()
+
+	for name, ch := range r.listeners {
+		select {
+		case ch <- event:
+		default:
+			r.logger.WithFields(bark.Fields{`listenerName`: name}).Error("Failed to send listener notification, channel full")
+		}
+	}
+}
+
+func (r *providerServiceResolver) refreshRingWorker() {
+	defer r.shutdownWG.Done()
+
+	refreshTicker := time.NewTicker(r.refreshInterval)
+	defer refreshTicker.Stop()
+
+	for {
+		select {
+		case <-r.shutdownCh:
+			return
+		case <-refreshTicker.C:
+			r.refresh()
+		}
+	}
+}
+
+func (r *providerServiceResolver) getLabelsMap() map[string]string {
+	labels := make(map[string]string)
+	labels[RoleKey] = r.service
+	return labels
+}
+
+func createProviderRetryPolicy() backoff.RetryPolicy {
+	policy := backoff.NewExponentialRetryPolicy(providerRetryInitialInterval)
+	policy.SetMaximumInterval(providerRetryMaxInterval)
+	policy.SetExpirationInterval(providerRetryExpirationInterval)
+	return policy
+}
+
+// isProviderRetryable retries every failure of the host provider except the unknown services,
+// which are configuration errors
+func isProviderRetryable(err error) bool {
+	return err != ErrUnknownService
+}
@@ -148,6 +148,10 @@ const (
 	PersistenceDeleteWorkflowExecutionScope
 	// PersistenceGetCurrentExecutionScope tracks GetCurrentExecution calls made by service to persistence layer
 	PersistenceGetCurrentExecutionScope
+	// PersistenceDeleteCurrentWorkflowExecutionScope tracks DeleteCurrentWorkflowExecution calls made by service to persistence layer
+	PersistenceDeleteCurrentWorkflowExecutionScope
+	// PersistenceListConcreteExecutionsScope tracks ListConcreteExecutions calls made by service to persistence layer
+	PersistenceListConcreteExecutionsScope
 	// PersistenceGetTransferTasksScope tracks GetTransferTasks calls made by service to persistence layer
 	PersistenceGetTransferTasksScope
 	// PersistenceGetReplicationTasksScope tracks GetReplicationTasks calls made by service to persistence layer
@@ -170,6 +174,12 @@ const (
 	PersistenceGetTasksScope
 	// PersistenceCompleteTaskScope tracks CompleteTask calls made by service to persistence layer
 	PersistenceCompleteTaskScope
+	// PersistenceCompleteTasksLessThanScope tracks CompleteTasksLessThan calls made by service to persistence layer
+	PersistenceCompleteTasksLessThanScope
+	// PersistenceListTaskListScope tracks ListTaskList calls made by service to persistence layer
+	PersistenceListTaskListScope
+	// PersistenceDeleteTaskListScope tracks DeleteTaskList calls made by service to persistence layer
+	PersistenceDeleteTaskListScope
 	// PersistenceLeaseTaskListScope tracks LeaseTaskList calls made by service to persistence layer
 	PersistenceLeaseTaskListScope
 	// PersistenceUpdateTaskListScope tracks PersistenceUpdateTaskListScope calls made by service to persistence layer
@@ -214,6 +224,8 @@ const (
 	PersistenceListClosedWorkflowExecutionsByStatusScope
 	// PersistenceGetClosedWorkflowExecutionScope tracks GetClosedWorkflowExecution calls made by service to persistence layer
 	PersistenceGetClosedWorkflowExecutionScope
+	// PersistenceDeleteDomainVisibilityScope tracks DeleteDomainVisibility calls made by service to persistence layer
+	PersistenceDeleteDomainVisibilityScope
 	// HistoryClientStartWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientStartWorkflowExecutionScope
 	// HistoryClientRecordActivityTaskHeartbeatScope tracks RPC calls to history service
@@ -230,6 +242,8 @@ const (
 	HistoryClientRespondActivityTaskCanceledScope
 	// HistoryClientGetMutableStateScope tracks RPC calls to history service
 	HistoryClientGetMutableStateScope
+	// HistoryClientQueryWorkflowScope tracks RPC calls to history service
+	HistoryClientQueryWorkflowScope
 	// HistoryClientResetStickyTaskListScope tracks RPC calls to history service
 	HistoryClientResetStickyTaskListScope
 	// HistoryClientDescribeWorkflowExecutionScope tracks RPC calls to history service
@@ -248,6 +262,10 @@ const (
 	HistoryClientRemoveSignalMutableStateScope
 	// HistoryClientTerminateWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientTerminateWorkflowExecutionScope
+	// HistoryClientPauseWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientPauseWorkflowExecutionScope
+	// HistoryClientUnpauseWorkflowExecutionScope tracks RPC calls to history service
+	HistoryClientUnpauseWorkflowExecutionScope
 	// HistoryClientResetWorkflowExecutionScope tracks RPC calls to history service
 	HistoryClientResetWorkflowExecutionScope
 	// HistoryClientScheduleDecisionTaskScope tracks RPC calls to history service
@@ -266,8 +284,12 @@ const (
 	MatchingClientPollForDecisionTaskScope
 	// MatchingClientPollForActivityTaskScope tracks RPC calls to matching service
 	MatchingClientPollForActivityTaskScope
+	// MatchingClientPollForActivityCancellationScope tracks RPC calls to matching service
+	MatchingClientPollForActivityCancellationScope
 	// MatchingClientAddActivityTaskScope tracks RPC calls to matching service
 	MatchingClientAddActivityTaskScope
+	// MatchingClientAddActivityCancellationScope tracks RPC calls to matching service
+	MatchingClientAddActivityCancellationScope
 	// MatchingClientAddDecisionTaskScope tracks RPC calls to matching service
 	MatchingClientAddDecisionTaskScope
 	// MatchingClientQueryWorkflowScope tracks RPC calls to matching service
@@ -278,6 +300,8 @@ const (
 	MatchingClientCancelOutstandingPollScope
 	// MatchingClientDescribeTaskListScope tracks RPC calls to matching service
 	MatchingClientDescribeTaskListScope
+	// MatchingClientPromoteTaskListBuildIDScope tracks RPC calls to matching service
+	MatchingClientPromoteTaskListBuildIDScope
 	// FrontendClientDeprecateDomainScope tracks RPC calls to frontend service
 	FrontendClientDeprecateDomainScope
 	// FrontendClientDescribeDomainScope tracks RPC calls to frontend service
@@ -296,6 +320,8 @@ const (
 	FrontendClientListOpenWorkflowExecutionsScope
 	// FrontendClientPollForActivityTaskScope tracks RPC calls to frontend service
 	FrontendClientPollForActivityTaskScope
+	// FrontendClientPollForActivityCancellationScope tracks RPC calls to frontend service
+	FrontendClientPollForActivityCancellationScope
 	// FrontendClientPollForDecisionTaskScope tracks RPC calls to frontend service
 	FrontendClientPollForDecisionTaskScope
 	// FrontendClientQueryWorkflowScope tracks RPC calls to frontend service
@@ -338,6 +364,10 @@ const (
 	FrontendClientStartWorkflowExecutionScope
 	// FrontendClientTerminateWorkflowExecutionScope tracks RPC calls to frontend service
 	FrontendClientTerminateWorkflowExecutionScope
+	// FrontendClientPauseWorkflowExecutionScope tracks RPC calls to frontend service
+	FrontendClientPauseWorkflowExecutionScope
+	// FrontendClientUnpauseWorkflowExecutionScope tracks RPC calls to frontend service
+	FrontendClientUnpauseWorkflowExecutionScope
 	// FrontendClientUpdateDomainScope tracks RPC calls to frontend service
 	FrontendClientUpdateDomainScope
 	// AdminClientDescribeHistoryHostScope tracks RPC calls to admin service
@@ -346,6 +376,20 @@ const (
 	AdminClientDescribeWorkflowExecutionScope
 	// AdminClientGetWorkflowExecutionRawHistoryScope tracks RPC calls to admin service
 	AdminClientGetWorkflowExecutionRawHistoryScope
+	// AdminClientCloseShardScope tracks RPC calls to admin service
+	AdminClientCloseShardScope
+	// AdminClientDescribeShardScope tracks RPC calls to admin service
+	AdminClientDescribeShardScope
+	// AdminClientDrainHistoryHostScope tracks RPC calls to admin service
+	AdminClientDrainHistoryHostScope
+	// AdminClientListQueueTasksScope tracks RPC calls to admin service
+	AdminClientListQueueTasksScope
+	// AdminClientRemoveQueueTaskScope tracks RPC calls to admin service
+	AdminClientRemoveQueueTaskScope
+	// AdminClientRegenerateWorkflowTasksScope tracks RPC calls to admin service
+	AdminClientRegenerateWorkflowTasksScope
+	// AdminClientPromoteTaskListBuildIDScope tracks RPC calls to admin service
+	AdminClientPromoteTaskListBuildIDScope
 	// PublicClientDeprecateDomainScope tracks RPC calls to frontend service
 	PublicClientDeprecateDomainScope
 	// PublicClientDescribeDomainScope tracks RPC calls to frontend service
@@ -435,6 +479,8 @@ const (
 	PersistenceCompleteForkBranchScope
 	// PersistenceGetHistoryTreeScope tracks GetHistoryTree calls made by service to persistence layer
 	PersistenceGetHistoryTreeScope
+	// PersistenceGetAllHistoryTreeBranchesScope tracks GetAllHistoryTreeBranches calls made by service to persistence layer
+	PersistenceGetAllHistoryTreeBranchesScope
 
 	// BlobstoreClientUploadScope tracks Upload calls to blobstore
 	BlobstoreClientUploadScope
@@ -469,6 +515,8 @@ const (
 	ElasticsearchListClosedWorkflowExecutionsByStatusScope
 	// ElasticsearchGetClosedWorkflowExecutionScope tracks GetClosedWorkflowExecution calls made by service to persistence layer
 	ElasticsearchGetClosedWorkflowExecutionScope
+	// ElasticsearchDeleteDomainVisibilityScope tracks DeleteDomainVisibility calls made by service to persistence layer
+	ElasticsearchDeleteDomainVisibilityScope
 
 	NumCommonScopes
 )
@@ -481,6 +529,20 @@ const (
 	AdminDescribeWorkflowExecutionScope
 	// AdminGetWorkflowExecutionRawHistoryScope is the metric scope for admin.GetWorkflowExecutionRawHistoryScope
 	AdminGetWorkflowExecutionRawHistoryScope
+	// AdminCloseShardScope is the metric scope for admin.CloseShard
+	AdminCloseShardScope
+	// AdminDescribeShardScope is the metric scope for admin.DescribeShard
+	AdminDescribeShardScope
+	// AdminDrainHistoryHostScope is the metric scope for admin.DrainHistoryHost
+	AdminDrainHistoryHostScope
+	// AdminListQueueTasksScope is the metric scope for admin.ListQueueTasks
+	AdminListQueueTasksScope
+	// AdminRemoveQueueTaskScope is the metric scope for admin.RemoveQueueTask
+	AdminRemoveQueueTaskScope
+	// AdminRegenerateWorkflowTasksScope is the metric scope for admin.RegenerateWorkflowTasks
+	AdminRegenerateWorkflowTasksScope
+	// AdminPromoteTaskListBuildIDScope is the metric scope for admin.PromoteTaskListBuildID
+	AdminPromoteTaskListBuildIDScope
 
 	NumAdminScopes
 )
@@ -493,6 +555,8 @@ const (
 	FrontendPollForDecisionTaskScope
 	// FrontendPollForActivityTaskScope is the metric scope for frontend.PollForActivityTask
 	FrontendPollForActivityTaskScope
+	// FrontendPollForActivityCancellationScope is the metric scope for frontend.PollForActivityCancellation
+	FrontendPollForActivityCancellationScope
 	// FrontendRecordActivityTaskHeartbeatScope is the metric scope for frontend.RecordActivityTaskHeartbeat
 	FrontendRecordActivityTaskHeartbeatScope
 	// FrontendRecordActivityTaskHeartbeatByIDScope is the metric scope for frontend.RespondDecisionTaskCompleted
@@ -523,6 +587,10 @@ const (
 	FrontendSignalWithStartWorkflowExecutionScope
 	// FrontendTerminateWorkflowExecutionScope is the metric scope for frontend.TerminateWorkflowExecution
 	FrontendTerminateWorkflowExecutionScope
+	// FrontendPauseWorkflowExecutionScope is the metric scope for frontend.PauseWorkflowExecution
+	FrontendPauseWorkflowExecutionScope
+	// FrontendUnpauseWorkflowExecutionScope is the metric scope for frontend.UnpauseWorkflowExecution
+	FrontendUnpauseWorkflowExecutionScope
 	// FrontendRequestCancelWorkflowExecutionScope is the metric scope for frontend.RequestCancelWorkflowExecution
 	FrontendRequestCancelWorkflowExecutionScope
 	// FrontendListOpenWorkflowExecutionsScope is the metric scope for frontend.ListOpenWorkflowExecutions
@@ -571,6 +639,8 @@ const (
 	HistoryRespondActivityTaskCanceledScope
 	// HistoryGetMutableStateScope tracks GetMutableStateScope API calls received by service
 	HistoryGetMutableStateScope
+	// HistoryQueryWorkflowScope tracks QueryWorkflow API calls received by service
+	HistoryQueryWorkflowScope
 	// HistoryResetStickyTaskListScope tracks ResetStickyTaskListScope API calls received by service
 	HistoryResetStickyTaskListScope
 	// HistoryDescribeWorkflowExecutionScope tracks DescribeWorkflowExecution API calls received by service
@@ -587,6 +657,10 @@ const (
 	HistoryRemoveSignalMutableStateScope
 	// HistoryTerminateWorkflowExecutionScope tracks TerminateWorkflowExecution API calls received by service
 	HistoryTerminateWorkflowExecutionScope
+	// HistoryPauseWorkflowExecutionScope tracks PauseWorkflowExecution API calls received by service
+	HistoryPauseWorkflowExecutionScope
+	// HistoryUnpauseWorkflowExecutionScope tracks UnpauseWorkflowExecution API calls received by service
+	HistoryUnpauseWorkflowExecutionScope
 	// HistoryScheduleDecisionTaskScope tracks ScheduleDecisionTask API calls received by service
 	HistoryScheduleDecisionTaskScope
 	// HistoryRecordChildExecutionCompletedScope tracks CompleteChildExecution API calls received by service
@@ -603,6 +677,18 @@ const (
 	HistorySyncActivityScope
 	// HistoryDescribeMutableStateScope tracks HistoryActivity API calls received by service
 	HistoryDescribeMutableStateScope
+	// HistoryCloseShardScope tracks CloseShard API calls received by service
+	HistoryCloseShardScope
+	// HistoryDescribeShardScope tracks DescribeShard API calls received by service
+	HistoryDescribeShardScope
+	// HistoryDrainHistoryHostScope tracks DrainHistoryHost API calls received by service
+	HistoryDrainHistoryHostScope
+	// HistoryListQueueTasksScope tracks ListQueueTasks API calls received by service
+	HistoryListQueueTasksScope
+	// HistoryRemoveQueueTaskScope tracks RemoveQueueTask API calls received by service
+	HistoryRemoveQueueTaskScope
+	// HistoryRegenerateWorkflowTasksScope tracks RegenerateWorkflowTasks API calls received by service
+	HistoryRegenerateWorkflowTasksScope
 	// HistoryShardControllerScope is the scope used by shard controller
 	HistoryShardControllerScope
 	// TransferQueueProcessorScope is the scope used by all metric emitted by transfer queue processor
@@ -613,6 +699,8 @@ const (
 	TransferStandbyQueueProcessorScope
 	// TransferActiveTaskActivityScope is the scope used for activity task processing by transfer queue processor
 	TransferActiveTaskActivityScope
+	// TransferActiveTaskActivityCancellationScope is the scope used for activity cancellation processing by transfer queue processor
+	TransferActiveTaskActivityCancellationScope
 	// TransferActiveTaskDecisionScope is the scope used for decision task processing by transfer queue processor
 	TransferActiveTaskDecisionScope
 	// TransferActiveTaskCloseExecutionScope is the scope used for close execution task processing by transfer queue processor
@@ -625,6 +713,8 @@ const (
 	TransferActiveTaskStartChildExecutionScope
 	// TransferStandbyTaskActivityScope is the scope used for activity task processing by transfer queue processor
 	TransferStandbyTaskActivityScope
+	// TransferStandbyTaskActivityCancellationScope is the scope used for activity cancellation processing by transfer queue processor
+	TransferStandbyTaskActivityCancellationScope
 	// TransferStandbyTaskDecisionScope is the scope used for decision task processing by transfer queue processor
 	TransferStandbyTaskDecisionScope
 	// TransferStandbyTaskCloseExecutionScope is the scope used for close execution task processing by transfer queue processor
@@ -721,8 +811,12 @@ const (
 	MatchingPollForDecisionTaskScope = iota + NumCommonScopes
 	// PollForActivityTaskScope tracks PollForActivityTask API calls received by service
 	MatchingPollForActivityTaskScope
+	// MatchingPollForActivityCancellationScope tracks PollForActivityCancellation API calls received by service
+	MatchingPollForActivityCancellationScope
 	// MatchingAddActivityTaskScope tracks AddActivityTask API calls received by service
 	MatchingAddActivityTaskScope
+	// MatchingAddActivityCancellationScope tracks AddActivityCancellation API calls received by service
+	MatchingAddActivityCancellationScope
 	// MatchingAddDecisionTaskScope tracks AddDecisionTask API calls received by service
 	MatchingAddDecisionTaskScope
 	// MatchingTaskListMgrScope is the metrics scope for matching.TaskListManager component
@@ -735,6 +829,8 @@ const (
 	MatchingCancelOutstandingPollScope
 	// MatchingDescribeTaskListScope tracks DescribeTaskList API calls received by service
 	MatchingDescribeTaskListScope
+	// MatchingPromoteTaskListBuildIDScope tracks PromoteTaskListBuildID API calls received by service
+	MatchingPromoteTaskListBuildIDScope
 
 	NumMatchingScopes
 )
@@ -763,6 +859,14 @@ const (
 	ArchivalDeleteHistoryActivityScope
 	// HistoryBlobIteratorScope is scope used by all metrics emitted by HistoryBlobIterator
 	HistoryBlobIteratorScope
+	// HistoryScavengerScope is scope used by all metrics emitted by the history scavenger
+	HistoryScavengerScope
+	// TaskListScavengerScope is scope used by all metrics emitted by the task list scavenger
+	TaskListScavengerScope
+	// ExecutionsScannerScope is scope used by all metrics emitted by the executions scanner
+	ExecutionsScannerScope
+	// DomainDeleterScope is scope used by all metrics emitted by the domain deletion workflow
+	DomainDeleterScope
 
 	NumWorkerScopes
 )
@@ -781,6 +885,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceResetWorkflowExecutionScope:                   {operation: "ResetWorkflowExecution"},
 		PersistenceDeleteWorkflowExecutionScope:                  {operation: "DeleteWorkflowExecution"},
 		PersistenceGetCurrentExecutionScope:                      {operation: "GetCurrentExecution"},
+		PersistenceDeleteCurrentWorkflowExecutionScope:           {operation: "DeleteCurrentWorkflowExecution"},
+		PersistenceListConcreteExecutionsScope:                   {operation: "ListConcreteExecutions"},
 		PersistenceGetTransferTasksScope:                         {operation: "GetTransferTasks"},
 		PersistenceGetReplicationTasksScope:                      {operation: "GetReplicationTasks"},
 		PersistenceCompleteTransferTaskScope:                     {operation: "CompleteTransferTask"},
@@ -792,6 +898,9 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceCreateTaskScope:                               {operation: "CreateTask", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceGetTasksScope:                                 {operation: "GetTasks", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceCompleteTaskScope:                             {operation: "CompleteTask", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceCompleteTasksLessThanScope:                    {operation: "CompleteTasksLessThan", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceListTaskListScope:                             {operation: "ListTaskList", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceDeleteTaskListScope:                           {operation: "DeleteTaskList", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceLeaseTaskListScope:                            {operation: "LeaseTaskList", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceUpdateTaskListScope:                           {operation: "UpdateTaskList", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceAppendHistoryEventsScope:                      {operation: "AppendHistoryEvents", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
@@ -814,12 +923,14 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		PersistenceListClosedWorkflowExecutionsByWorkflowIDScope: {operation: "ListClosedWorkflowExecutionsByWorkflowID"},
 		PersistenceListClosedWorkflowExecutionsByStatusScope:     {operation: "ListClosedWorkflowExecutionsByStatus"},
 		PersistenceGetClosedWorkflowExecutionScope:               {operation: "GetClosedWorkflowExecution"},
+		PersistenceDeleteDomainVisibilityScope:                   {operation: "DeleteDomainVisibility"},
 		PersistenceAppendHistoryNodesScope:                       {operation: "AppendHistoryNodes", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceReadHistoryBranchScope:                        {operation: "ReadHistoryBranch", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceForkHistoryBranchScope:                        {operation: "ForkHistoryBranch", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceDeleteHistoryBranchScope:                      {operation: "DeleteHistoryBranch", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceCompleteForkBranchScope:                       {operation: "CompleteForkBranch", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 		PersistenceGetHistoryTreeScope:                           {operation: "GetHistoryTree", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
+		PersistenceGetAllHistoryTreeBranchesScope:                {operation: "GetAllHistoryTreeBranches", tags: map[string]string{ShardTagName: NoneShardsTagValue}},
 
 		BlobstoreClientUploadScope:         {operation: "Upload", tags: map[string]string{CadenceRoleTagName: BlobstoreRoleTagValue}},
 		BlobstoreClientDownloadScope:       {operation: "Download", tags: map[string]string{CadenceRoleTagName: BlobstoreRoleTagValue}},
@@ -836,6 +947,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientRespondActivityTaskFailedScope:         {operation: "HistoryClientRespondActivityTaskFailed", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRespondActivityTaskCanceledScope:       {operation: "HistoryClientRespondActivityTaskCanceled", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientGetMutableStateScope:                   {operation: "HistoryClientGetMutableState", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientQueryWorkflowScope:                     {operation: "HistoryClientQueryWorkflow", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientResetStickyTaskListScope:               {operation: "HistoryClientResetStickyTaskListScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientDescribeWorkflowExecutionScope:         {operation: "HistoryClientDescribeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRecordDecisionTaskStartedScope:         {operation: "HistoryClientRecordDecisionTaskStarted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
@@ -845,6 +957,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientSignalWithStartWorkflowExecutionScope:  {operation: "HistoryClientSignalWithStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRemoveSignalMutableStateScope:          {operation: "HistoryClientRemoveSignalMutableStateScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientTerminateWorkflowExecutionScope:        {operation: "HistoryClientTerminateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientPauseWorkflowExecutionScope:            {operation: "HistoryClientPauseWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
+		HistoryClientUnpauseWorkflowExecutionScope:          {operation: "HistoryClientUnpauseWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientResetWorkflowExecutionScope:            {operation: "HistoryClientResetWorkflowExecution", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientScheduleDecisionTaskScope:              {operation: "HistoryClientScheduleDecisionTask", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		HistoryClientRecordChildExecutionCompletedScope:     {operation: "HistoryClientRecordChildExecutionCompleted", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
@@ -854,12 +968,15 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryClientSyncActivityScope:                      {operation: "HistoryClientSyncActivityScope", tags: map[string]string{CadenceRoleTagName: HistoryRoleTagValue}},
 		MatchingClientPollForDecisionTaskScope:              {operation: "MatchingClientPollForDecisionTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientPollForActivityTaskScope:              {operation: "MatchingClientPollForActivityTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientPollForActivityCancellationScope:      {operation: "MatchingClientPollForActivityCancellation", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientAddActivityTaskScope:                  {operation: "MatchingClientAddActivityTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientAddActivityCancellationScope:          {operation: "MatchingClientAddActivityCancellation", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientAddDecisionTaskScope:                  {operation: "MatchingClientAddDecisionTask", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientQueryWorkflowScope:                    {operation: "MatchingClientQueryWorkflow", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientRespondQueryTaskCompletedScope:        {operation: "MatchingClientRespondQueryTaskCompleted", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientCancelOutstandingPollScope:            {operation: "MatchingClientCancelOutstandingPoll", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		MatchingClientDescribeTaskListScope:                 {operation: "MatchingClientDescribeTaskList", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
+		MatchingClientPromoteTaskListBuildIDScope:           {operation: "MatchingClientPromoteTaskListBuildID", tags: map[string]string{CadenceRoleTagName: MatchingRoleTagValue}},
 		FrontendClientDeprecateDomainScope:                  {operation: "FrontendClientDeprecateDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeDomainScope:                   {operation: "FrontendClientDescribeDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientDescribeTaskListScope:                 {operation: "FrontendClientDescribeTaskList", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
@@ -869,6 +986,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendClientListDomainsScope:                      {operation: "FrontendClientListDomains", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientListOpenWorkflowExecutionsScope:       {operation: "FrontendClientListOpenWorkflowExecutions", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientPollForActivityTaskScope:              {operation: "FrontendClientPollForActivityTask", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientPollForActivityCancellationScope:      {operation: "FrontendClientPollForActivityCancellation", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientPollForDecisionTaskScope:              {operation: "FrontendClientPollForDecisionTask", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientQueryWorkflowScope:                    {operation: "FrontendClientQueryWorkflow", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientRecordActivityTaskHeartbeatScope:      {operation: "FrontendClientRecordActivityTaskHeartbeat", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
@@ -890,6 +1008,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendClientSignalWorkflowExecutionScope:          {operation: "FrontendClientSignalWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientStartWorkflowExecutionScope:           {operation: "FrontendClientStartWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientTerminateWorkflowExecutionScope:       {operation: "FrontendClientTerminateWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientPauseWorkflowExecutionScope:           {operation: "FrontendClientPauseWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
+		FrontendClientUnpauseWorkflowExecutionScope:         {operation: "FrontendClientUnpauseWorkflowExecution", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		FrontendClientUpdateDomainScope:                     {operation: "FrontendClientUpdateDomain", tags: map[string]string{CadenceRoleTagName: FrontendRoleTagValue}},
 		PublicClientDeprecateDomainScope:                    {operation: "PublicClientDeprecateDomain", tags: map[string]string{CadenceRoleTagName: PublicRoleTagValue}},
 		PublicClientDescribeDomainScope:                     {operation: "PublicClientDescribeDomain", tags: map[string]string{CadenceRoleTagName: PublicRoleTagValue}},
@@ -924,6 +1044,13 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminClientDescribeHistoryHostScope:                 {operation: "AdminClientDescribeHistoryHost", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
 		AdminClientDescribeWorkflowExecutionScope:           {operation: "AdminClientDescribeWorkflowExecution", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
 		AdminClientGetWorkflowExecutionRawHistoryScope:      {operation: "AdminClientGetWorkflowExecutionRawHistory", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientCloseShardScope:                          {operation: "AdminClientCloseShard", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientDescribeShardScope:                       {operation: "AdminClientDescribeShard", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientDrainHistoryHostScope:                    {operation: "AdminClientDrainHistoryHost", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientListQueueTasksScope:                      {operation: "AdminClientListQueueTasks", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientRemoveQueueTaskScope:                     {operation: "AdminClientRemoveQueueTask", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientRegenerateWorkflowTasksScope:             {operation: "AdminClientRegenerateWorkflowTasks", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
+		AdminClientPromoteTaskListBuildIDScope:              {operation: "AdminClientPromoteTaskListBuildID", tags: map[string]string{CadenceRoleTagName: AdminRoleTagValue}},
 
 		MessagingClientPublishScope:      {operation: "MessagingClientPublish"},
 		MessagingClientPublishBatchScope: {operation: "MessagingClientPublishBatch"},
@@ -944,6 +1071,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		ElasticsearchListClosedWorkflowExecutionsByWorkflowIDScope: {operation: "ListClosedWorkflowExecutionsByWorkflowID"},
 		ElasticsearchListClosedWorkflowExecutionsByStatusScope:     {operation: "ListClosedWorkflowExecutionsByStatus"},
 		ElasticsearchGetClosedWorkflowExecutionScope:               {operation: "GetClosedWorkflowExecution"},
+		ElasticsearchDeleteDomainVisibilityScope:                   {operation: "DeleteDomainVisibility"},
 	},
 	// Frontend Scope Names
 	Frontend: {
@@ -951,10 +1079,18 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		AdminDescribeHistoryHostScope:            {operation: "DescribeHistoryHost"},
 		AdminDescribeWorkflowExecutionScope:      {operation: "DescribeWorkflowExecution"},
 		AdminGetWorkflowExecutionRawHistoryScope: {operation: "GetWorkflowExecutionRawHistory"},
+		AdminCloseShardScope:                     {operation: "CloseShard"},
+		AdminDescribeShardScope:                  {operation: "DescribeShard"},
+		AdminDrainHistoryHostScope:               {operation: "DrainHistoryHost"},
+		AdminListQueueTasksScope:                 {operation: "ListQueueTasks"},
+		AdminRemoveQueueTaskScope:                {operation: "RemoveQueueTask"},
+		AdminRegenerateWorkflowTasksScope:        {operation: "RegenerateWorkflowTasks"},
+		AdminPromoteTaskListBuildIDScope:         {operation: "PromoteTaskListBuildID"},
 
 		FrontendStartWorkflowExecutionScope:           {operation: "StartWorkflowExecution"},
 		FrontendPollForDecisionTaskScope:              {operation: "PollForDecisionTask"},
 		FrontendPollForActivityTaskScope:              {operation: "PollForActivityTask"},
+		FrontendPollForActivityCancellationScope:      {operation: "PollForActivityCancellation"},
 		FrontendRecordActivityTaskHeartbeatScope:      {operation: "RecordActivityTaskHeartbeat"},
 		FrontendRecordActivityTaskHeartbeatByIDScope:  {operation: "RecordActivityTaskHeartbeatByID"},
 		FrontendRespondDecisionTaskCompletedScope:     {operation: "RespondDecisionTaskCompleted"},
@@ -970,6 +1106,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		FrontendSignalWorkflowExecutionScope:          {operation: "SignalWorkflowExecution"},
 		FrontendSignalWithStartWorkflowExecutionScope: {operation: "SignalWithStartWorkflowExecution"},
 		FrontendTerminateWorkflowExecutionScope:       {operation: "TerminateWorkflowExecution"},
+		FrontendPauseWorkflowExecutionScope:           {operation: "PauseWorkflowExecution"},
+		FrontendUnpauseWorkflowExecutionScope:         {operation: "UnpauseWorkflowExecution"},
 		FrontendResetWorkflowExecutionScope:           {operation: "ResetWorkflowExecution"},
 		FrontendRequestCancelWorkflowExecutionScope:   {operation: "RequestCancelWorkflowExecution"},
 		FrontendListOpenWorkflowExecutionsScope:       {operation: "ListOpenWorkflowExecutions"},
@@ -994,6 +1132,7 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistoryRespondActivityTaskFailedScope:        {operation: "RespondActivityTaskFailed"},
 		HistoryRespondActivityTaskCanceledScope:      {operation: "RespondActivityTaskCanceled"},
 		HistoryGetMutableStateScope:                  {operation: "GetMutableState"},
+		HistoryQueryWorkflowScope:                    {operation: "QueryWorkflow"},
 		HistoryResetStickyTaskListScope:              {operation: "ResetStickyTaskListScope"},
 		HistoryDescribeWorkflowExecutionScope:        {operation: "DescribeWorkflowExecution"},
 		HistoryRecordDecisionTaskStartedScope:        {operation: "RecordDecisionTaskStarted"},
@@ -1002,6 +1141,8 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistorySignalWithStartWorkflowExecutionScope: {operation: "SignalWithStartWorkflowExecution"},
 		HistoryRemoveSignalMutableStateScope:         {operation: "RemoveSignalMutableState"},
 		HistoryTerminateWorkflowExecutionScope:       {operation: "TerminateWorkflowExecution"},
+		HistoryPauseWorkflowExecutionScope:           {operation: "PauseWorkflowExecution"},
+		HistoryUnpauseWorkflowExecutionScope:         {operation: "UnpauseWorkflowExecution"},
 		HistoryResetWorkflowExecutionScope:           {operation: "ResetWorkflowExecution"},
 		HistoryScheduleDecisionTaskScope:             {operation: "ScheduleDecisionTask"},
 		HistoryRecordChildExecutionCompletedScope:    {operation: "RecordChildExecutionCompleted"},
@@ -1011,17 +1152,25 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		HistorySyncShardStatusScope:                  {operation: "SyncShardStatus"},
 		HistorySyncActivityScope:                     {operation: "SyncActivity"},
 		HistoryDescribeMutableStateScope:             {operation: "DescribeMutableState"},
+		HistoryCloseShardScope:                       {operation: "CloseShard"},
+		HistoryDescribeShardScope:                    {operation: "DescribeShard"},
+		HistoryDrainHistoryHostScope:                 {operation: "DrainHistoryHost"},
+		HistoryListQueueTasksScope:                   {operation: "ListQueueTasks"},
+		HistoryRemoveQueueTaskScope:                  {operation: "RemoveQueueTask"},
+		HistoryRegenerateWorkflowTasksScope:          {operation: "RegenerateWorkflowTasks"},
 		HistoryShardControllerScope:                  {operation: "ShardController"},
 		TransferQueueProcessorScope:                  {operation: "TransferQueueProcessor"},
 		TransferActiveQueueProcessorScope:            {operation: "TransferActiveQueueProcessor"},
 		TransferStandbyQueueProcessorScope:           {operation: "TransferStandbyQueueProcessor"},
 		TransferActiveTaskActivityScope:              {operation: "TransferActiveTaskActivity"},
+		TransferActiveTaskActivityCancellationScope:  {operation: "TransferActiveTaskActivityCancellation"},
 		TransferActiveTaskDecisionScope:              {operation: "TransferActiveTaskDecision"},
 		TransferActiveTaskCloseExecutionScope:        {operation: "TransferActiveTaskCloseExecution"},
 		TransferActiveTaskCancelExecutionScope:       {operation: "TransferActiveTaskCancelExecution"},
 		TransferActiveTaskSignalExecutionScope:       {operation: "TransferActiveTaskSignalExecution"},
 		TransferActiveTaskStartChildExecutionScope:   {operation: "TransferActiveTaskStartChildExecution"},
 		TransferStandbyTaskActivityScope:             {operation: "TransferStandbyTaskActivity"},
+		TransferStandbyTaskActivityCancellationScope: {operation: "TransferStandbyTaskActivityCancellation"},
 		TransferStandbyTaskDecisionScope:             {operation: "TransferStandbyTaskDecision"},
 		TransferStandbyTaskCloseExecutionScope:       {operation: "TransferStandbyTaskCloseExecution"},
 		TransferStandbyTaskCancelExecutionScope:      {operation: "TransferStandbyTaskCancelExecution"},
@@ -1067,15 +1216,18 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 	},
 	// Matching Scope Names
 	Matching: {
-		MatchingPollForDecisionTaskScope:       {operation: "PollForDecisionTask"},
-		MatchingPollForActivityTaskScope:       {operation: "PollForActivityTask"},
-		MatchingAddActivityTaskScope:           {operation: "AddActivityTask"},
-		MatchingAddDecisionTaskScope:           {operation: "AddDecisionTask"},
-		MatchingTaskListMgrScope:               {operation: "TaskListMgr"},
-		MatchingQueryWorkflowScope:             {operation: "QueryWorkflow"},
-		MatchingRespondQueryTaskCompletedScope: {operation: "RespondQueryTaskCompleted"},
-		MatchingCancelOutstandingPollScope:     {operation: "CancelOutstandingPoll"},
-		MatchingDescribeTaskListScope:          {operation: "DescribeTaskList"},
+		MatchingPollForDecisionTaskScope:         {operation: "PollForDecisionTask"},
+		MatchingPollForActivityTaskScope:         {operation: "PollForActivityTask"},
+		MatchingPollForActivityCancellationScope: {operation: "PollForActivityCancellation"},
+		MatchingAddActivityTaskScope:             {operation: "AddActivityTask"},
+		MatchingAddActivityCancellationScope:     {operation: "AddActivityCancellation"},
+		MatchingAddDecisionTaskScope:             {operation: "AddDecisionTask"},
+		MatchingTaskListMgrScope:                 {operation: "TaskListMgr"},
+		MatchingQueryWorkflowScope:               {operation: "QueryWorkflow"},
+		MatchingRespondQueryTaskCompletedScope:   {operation: "RespondQueryTaskCompleted"},
+		MatchingCancelOutstandingPollScope:       {operation: "CancelOutstandingPoll"},
+		MatchingDescribeTaskListScope:            {operation: "DescribeTaskList"},
+		MatchingPromoteTaskListBuildIDScope:      {operation: "PromoteTaskListBuildID"},
 	},
 	// Worker Scope Names
 	Worker: {
@@ -1090,6 +1242,10 @@ var ScopeDefs = map[ServiceIdx]map[int]scopeDefinition{
 		ArchivalUploadActivityScope:        {operation: "ArchivalUploadActivity"},
 		ArchivalDeleteHistoryActivityScope: {operation: "ArchivalDeleteHistoryActivity"},
 		HistoryBlobIteratorScope:           {operation: "HistoryBlobIterator"},
+		HistoryScavengerScope:              {operation: "HistoryScavenger"},
+		TaskListScavengerScope:             {operation: "TaskListScavenger"},
+		ExecutionsScannerScope:             {operation: "ExecutionsScanner"},
+		DomainDeleterScope:                 {operation: "DomainDeleter"},
 	},
 }
 
@@ -1175,6 +1331,8 @@ const (
 	DecisionTypeSignalExternalWorkflowCounter
 	MultipleCompletionDecisionsCounter
 	FailedDecisionsCounter
+	EagerActivityTaskCounter
+	DecisionHeartbeatCounter
 	StaleMutableStateCounter
 	ConcurrencyUpdateFailureCounter
 	CadenceErrEventAlreadyStartedCounter
@@ -1251,6 +1409,7 @@ const (
 	DeleteRequestCancelInfoCount
 	WorkflowRetryBackoffTimerCount
 	WorkflowCronBackoffTimerCount
+	WorkflowDelayStartBackoffTimerCount
 
 	NumHistoryMetrics
 )
@@ -1278,6 +1437,8 @@ const (
 	ReplicatorLatency
 	ESProcessorFailures
 	ESProcessorCorruptedData
+	ESProcessorDLQWrites
+	ESProcessorDLQFailures
 	IndexProcessorCorruptedData
 	SysWorkerWorkflowStarted
 	SysWorkerReceivedSignal
@@ -1301,6 +1462,24 @@ const (
 	SysWorkerBlobUploadNonRetryableFailures
 	SysWorkerDeleteHistoryV2NonRetryableFailures
 	SysWorkerDeleteHistoryV1NonRetryableFailures
+	HistoryScavengerBranchesScanned
+	HistoryScavengerBranchesSkipped
+	HistoryScavengerBranchesDeleted
+	HistoryScavengerFailures
+	TaskListScavengerTaskListsScanned
+	TaskListScavengerTaskListsDeleted
+	TaskListScavengerTasksDeleted
+	TaskListScavengerFailures
+	ExecutionsScannerExecutionsScanned
+	ExecutionsScannerExecutionsCorrupted
+	ExecutionsScannerExecutionsFixed
+	ExecutionsScannerFailures
+	DomainDeleterTaskListsDeleted
+	DomainDeleterExecutionsDeleted
+	DomainDeleterVisibilityRecordsDeleted
+	DomainDeleterArchivedHistoryBlobsDeleted
+	DomainDeleterDomainsDeleted
+	DomainDeleterFailures
 
 	NumWorkerMetrics
 )
@@ -1380,6 +1559,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		DecisionTypeChildWorkflowCounter:             {metricName: "child-workflow-decision", metricType: Counter},
 		MultipleCompletionDecisionsCounter:           {metricName: "multiple-completion-decisions", metricType: Counter},
 		FailedDecisionsCounter:                       {metricName: "failed-decisions", metricType: Counter},
+		EagerActivityTaskCounter:                     {metricName: "eager-activity-tasks", metricType: Counter},
+		DecisionHeartbeatCounter:                     {metricName: "decision-heartbeats", metricType: Counter},
 		StaleMutableStateCounter:                     {metricName: "stale-mutable-state", metricType: Counter},
 		ConcurrencyUpdateFailureCounter:              {metricName: "concurrency-update-failure", metricType: Counter},
 		CadenceErrShardOwnershipLostCounter:          {metricName: "cadence.errors.shard-ownership-lost", metricType: Counter},
@@ -1456,6 +1637,7 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		DeleteRequestCancelInfoCount:                 {metricName: "delete-request-cancel-info", metricType: Timer},
 		WorkflowRetryBackoffTimerCount:               {metricName: "workflow-retry-backoff-timer", metricType: Counter},
 		WorkflowCronBackoffTimerCount:                {metricName: "workflow-cron-backoff-timer", metricType: Counter},
+		WorkflowDelayStartBackoffTimerCount:          {metricName: "workflow-delay-start-backoff-timer", metricType: Counter},
 	},
 	Matching: {
 		PollSuccessCounter:            {metricName: "poll.success"},
@@ -1475,6 +1657,8 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		ReplicatorLatency:                                          {metricName: "replicator.latency"},
 		ESProcessorFailures:                                        {metricName: "es-processor.errors"},
 		ESProcessorCorruptedData:                                   {metricName: "es-processor.corrupted-data"},
+		ESProcessorDLQWrites:                                       {metricName: "es-processor.dlq-writes"},
+		ESProcessorDLQFailures:                                     {metricName: "es-processor.dlq-failures"},
 		IndexProcessorCorruptedData:                                {metricName: "index-processor.corrupted-data"},
 		SysWorkerWorkflowStarted:                                   {metricName: "sysworker.workflow-started"},
 		SysWorkerReceivedSignal:                                    {metricName: "sysworker.received-signal"},
@@ -1498,6 +1682,24 @@ var MetricDefs = map[ServiceIdx]map[int]metricDefinition{
 		SysWorkerBlobUploadNonRetryableFailures:                    {metricName: "sysworker.blob-upload-non-retryable-errors"},
 		SysWorkerDeleteHistoryV2NonRetryableFailures:               {metricName: "sysworker.delete-history-v2-non-retryable-errors"},
 		SysWorkerDeleteHistoryV1NonRetryableFailures:               {metricName: "sysworker.delete-history-v1-non-retryable-errors"},
+		HistoryScavengerBranchesScanned:                            {metricName: "history-scavenger.branches-scanned"},
+		HistoryScavengerBranchesSkipped:                            {metricName: "history-scavenger.branches-skipped"},
+		HistoryScavengerBranchesDeleted:                            {metricName: "history-scavenger.branches-deleted"},
+		HistoryScavengerFailures:                                   {metricName: "history-scavenger.errors"},
+		TaskListScavengerTaskListsScanned:                          {metricName: "tasklist-scavenger.tasklists-scanned"},
+		TaskListScavengerTaskListsDeleted:                          {metricName: "tasklist-scavenger.tasklists-deleted"},
+		TaskListScavengerTasksDeleted:                              {metricName: "tasklist-scavenger.tasks-deleted"},
+		TaskListScavengerFailures:                                  {metricName: "tasklist-scavenger.errors"},
+		ExecutionsScannerExecutionsScanned:                         {metricName: "executions-scanner.executions-scanned"},
+		ExecutionsScannerExecutionsCorrupted:                       {metricName: "executions-scanner.executions-corrupted"},
+		ExecutionsScannerExecutionsFixed:                           {metricName: "executions-scanner.executions-fixed"},
+		ExecutionsScannerFailures:                                  {metricName: "executions-scanner.errors"},
+		DomainDeleterTaskListsDeleted:                              {metricName: "domain-deleter.tasklists-deleted"},
+		DomainDeleterExecutionsDeleted:                             {metricName: "domain-deleter.executions-deleted"},
+		DomainDeleterVisibilityRecordsDeleted:                      {metricName: "domain-deleter.visibility-records-deleted"},
+		DomainDeleterArchivedHistoryBlobsDeleted:                   {metricName: "domain-deleter.archived-history-blobs-deleted"},
+		DomainDeleterDomainsDeleted:                                {metricName: "domain-deleter.domains-deleted"},
+		DomainDeleterFailures:                                      {metricName: "domain-deleter.errors"},
 	},
 }
 
@@ -105,3 +105,119 @@ func (_m *AdminClient) GetWorkflowExecutionRawHistory(ctx context.Context, reque
 
 	return r0, r1
 }
+
+// CloseShard provides a mock function with given fields: ctx, request
+func (_m *AdminClient) CloseShard(ctx context.Context, request *shared.CloseShardRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.CloseShardRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeShard provides a mock function with given fields: ctx, request
+func (_m *AdminClient) DescribeShard(ctx context.Context, request *shared.DescribeShardRequest, opts ...yarpc.CallOption) (*shared.DescribeShardResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.DescribeShardResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.DescribeShardRequest) *shared.DescribeShardResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeShardResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.DescribeShardRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DrainHistoryHost provides a mock function with given fields: ctx, request
+func (_m *AdminClient) DrainHistoryHost(ctx context.Context, request *shared.DrainHistoryHostRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.DrainHistoryHostRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListQueueTasks provides a mock function with given fields: ctx, request
+func (_m *AdminClient) ListQueueTasks(ctx context.Context, request *shared.ListQueueTasksRequest, opts ...yarpc.CallOption) (*shared.ListQueueTasksResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.ListQueueTasksResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.ListQueueTasksRequest) *shared.ListQueueTasksResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.ListQueueTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.ListQueueTasksRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveQueueTask provides a mock function with given fields: ctx, request
+func (_m *AdminClient) RemoveQueueTask(ctx context.Context, request *shared.RemoveQueueTaskRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.RemoveQueueTaskRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegenerateWorkflowTasks provides a mock function with given fields: ctx, request
+func (_m *AdminClient) RegenerateWorkflowTasks(ctx context.Context, request *admin.RegenerateWorkflowTasksRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *admin.RegenerateWorkflowTasksRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// PromoteTaskListBuildID provides a mock function with given fields: ctx, request
+func (_m *AdminClient) PromoteTaskListBuildID(ctx context.Context, request *shared.PromoteTaskListBuildIDRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.PromoteTaskListBuildIDRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -167,6 +167,43 @@ func (_m *ExecutionManager) DeleteWorkflowExecution(request *persistence.DeleteW
 	return r0
 }
 
+// DeleteCurrentWorkflowExecution provides a mock function with given fields: request
+func (_m *ExecutionManager) DeleteCurrentWorkflowExecution(request *persistence.DeleteCurrentWorkflowExecutionRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.DeleteCurrentWorkflowExecutionRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListConcreteExecutions provides a mock function with given fields: request
+func (_m *ExecutionManager) ListConcreteExecutions(request *persistence.ListConcreteExecutionsRequest) (*persistence.ListConcreteExecutionsResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListConcreteExecutionsResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListConcreteExecutionsRequest) *persistence.ListConcreteExecutionsResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListConcreteExecutionsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListConcreteExecutionsRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetCurrentExecution provides a mock function with given fields: request
 func (_m *ExecutionManager) GetCurrentExecution(request *persistence.GetCurrentExecutionRequest) (*persistence.GetCurrentExecutionResponse, error) {
 	ret := _m.Called(request)
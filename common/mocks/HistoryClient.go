@@ -107,6 +107,29 @@ func (_m *HistoryClient) GetMutableState(ctx context.Context, getRequest *histor
 	return r0, r1
 }
 
+// QueryWorkflow provides a mock function with given fields: ctx, queryRequest
+func (_m *HistoryClient) QueryWorkflow(ctx context.Context, queryRequest *history.QueryWorkflowRequest, opts ...yarpc.CallOption) (*history.QueryWorkflowResponse, error) {
+	ret := _m.Called(ctx, queryRequest)
+
+	var r0 *history.QueryWorkflowResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *history.QueryWorkflowRequest) *history.QueryWorkflowResponse); ok {
+		r0 = rf(ctx, queryRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*history.QueryWorkflowResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *history.QueryWorkflowRequest) error); ok {
+		r1 = rf(ctx, queryRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResetStickyTaskList provides a mock function with given fields: ctx, getRequest
 func (_m *HistoryClient) ResetStickyTaskList(ctx context.Context, request *history.ResetStickyTaskListRequest, opts ...yarpc.CallOption) (*history.ResetStickyTaskListResponse, error) {
 	ret := _m.Called(ctx, request)
@@ -405,6 +428,34 @@ func (_m *HistoryClient) TerminateWorkflowExecution(ctx context.Context, termina
 	return r0
 }
 
+// PauseWorkflowExecution provides a mock function with given fields: ctx, pauseRequest
+func (_m *HistoryClient) PauseWorkflowExecution(ctx context.Context, pauseRequest *history.PauseWorkflowExecutionRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, pauseRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.PauseWorkflowExecutionRequest) error); ok {
+		r0 = rf(ctx, pauseRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnpauseWorkflowExecution provides a mock function with given fields: ctx, unpauseRequest
+func (_m *HistoryClient) UnpauseWorkflowExecution(ctx context.Context, unpauseRequest *history.UnpauseWorkflowExecutionRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, unpauseRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.UnpauseWorkflowExecutionRequest) error); ok {
+		r0 = rf(ctx, unpauseRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetWorkflowExecution provides a mock function with given fields: ctx, terminateRequest
 func (_m *HistoryClient) ResetWorkflowExecution(ctx context.Context, resetRequest *history.ResetWorkflowExecutionRequest, opts ...yarpc.CallOption) (*shared.ResetWorkflowExecutionResponse, error) {
 	ret := _m.Called(ctx, resetRequest)
@@ -511,3 +562,105 @@ func (_m *HistoryClient) SyncActivity(ctx context.Context, request *history.Sync
 
 	return r0
 }
+
+// CloseShard provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) CloseShard(ctx context.Context, request *shared.CloseShardRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.CloseShardRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DescribeShard provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) DescribeShard(ctx context.Context, request *shared.DescribeShardRequest, opts ...yarpc.CallOption) (*shared.DescribeShardResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.DescribeShardResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.DescribeShardRequest) *shared.DescribeShardResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.DescribeShardResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.DescribeShardRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DrainHistoryHost provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) DrainHistoryHost(ctx context.Context, request *shared.DrainHistoryHostRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.DrainHistoryHostRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ListQueueTasks provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) ListQueueTasks(ctx context.Context, request *shared.ListQueueTasksRequest, opts ...yarpc.CallOption) (*shared.ListQueueTasksResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *shared.ListQueueTasksResponse
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.ListQueueTasksRequest) *shared.ListQueueTasksResponse); ok {
+		r0 = rf(ctx, request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.ListQueueTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *shared.ListQueueTasksRequest) error); ok {
+		r1 = rf(ctx, request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveQueueTask provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) RemoveQueueTask(ctx context.Context, request *shared.RemoveQueueTaskRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *shared.RemoveQueueTaskRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegenerateWorkflowTasks provides a mock function with given fields: ctx, request
+func (_m *HistoryClient) RegenerateWorkflowTasks(ctx context.Context, request *history.RegenerateWorkflowTasksRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *history.RegenerateWorkflowTasksRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
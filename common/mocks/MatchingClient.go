@@ -34,6 +34,20 @@ type MatchingClient struct {
 	mock.Mock
 }
 
+// AddActivityCancellation provides a mock function with given fields: ctx, addRequest
+func (_m *MatchingClient) AddActivityCancellation(ctx context.Context, addRequest *matching.AddActivityCancellationRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, addRequest)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *matching.AddActivityCancellationRequest) error); ok {
+		r0 = rf(ctx, addRequest)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // AddActivityTask provides a mock function with given fields: ctx, addRequest
 func (_m *MatchingClient) AddActivityTask(ctx context.Context, addRequest *matching.AddActivityTaskRequest, opts ...yarpc.CallOption) error {
 	ret := _m.Called(ctx, addRequest)
@@ -62,6 +76,31 @@ func (_m *MatchingClient) AddDecisionTask(ctx context.Context, addRequest *match
 	return r0
 }
 
+// PollForActivityCancellation provides a mock function with given fields: ctx, pollRequest
+func (_m *MatchingClient) PollForActivityCancellation(ctx context.Context,
+	pollRequest *matching.PollForActivityCancellationRequest, opts ...yarpc.CallOption) (*shared.PollForActivityCancellationResponse, error) {
+	ret := _m.Called(ctx, pollRequest)
+
+	var r0 *shared.PollForActivityCancellationResponse
+	if rf, ok := ret.Get(0).(func(context.Context,
+		*matching.PollForActivityCancellationRequest) *shared.PollForActivityCancellationResponse); ok {
+		r0 = rf(ctx, pollRequest)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.PollForActivityCancellationResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *matching.PollForActivityCancellationRequest) error); ok {
+		r1 = rf(ctx, pollRequest)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // PollForActivityTask provides a mock function with given fields: ctx, pollRequest
 func (_m *MatchingClient) PollForActivityTask(ctx context.Context,
 	pollRequest *matching.PollForActivityTaskRequest, opts ...yarpc.CallOption) (*shared.PollForActivityTaskResponse, error) {
@@ -188,3 +227,18 @@ func (_m *MatchingClient) DescribeTaskList(ctx context.Context,
 
 	return r0, r1
 }
+
+// PromoteTaskListBuildID provides a mock function with given fields: ctx, request
+func (_m *MatchingClient) PromoteTaskListBuildID(ctx context.Context,
+	request *matching.PromoteTaskListBuildIDRequest, opts ...yarpc.CallOption) error {
+	ret := _m.Called(ctx, request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *matching.PromoteTaskListBuildIDRequest) error); ok {
+		r0 = rf(ctx, request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -148,3 +148,61 @@ func (_m *TaskManager) GetTasks(request *persistence.GetTasksRequest) (*persiste
 
 	return r0, r1
 }
+
+// CompleteTasksLessThan provides a mock function with given fields: request
+func (_m *TaskManager) CompleteTasksLessThan(request *persistence.CompleteTasksLessThanRequest) (int, error) {
+	ret := _m.Called(request)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*persistence.CompleteTasksLessThanRequest) int); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.CompleteTasksLessThanRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ListTaskList provides a mock function with given fields: request
+func (_m *TaskManager) ListTaskList(request *persistence.ListTaskListRequest) (*persistence.ListTaskListResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *persistence.ListTaskListResponse
+	if rf, ok := ret.Get(0).(func(*persistence.ListTaskListRequest) *persistence.ListTaskListResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*persistence.ListTaskListResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.ListTaskListRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteTaskList provides a mock function with given fields: request
+func (_m *TaskManager) DeleteTaskList(request *persistence.DeleteTaskListRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*persistence.DeleteTaskListRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
@@ -47,6 +47,27 @@ func (_m *VisibilityManager) Close() {
 	_m.Called()
 }
 
+// DeleteDomainVisibility provides a mock function with given fields: request
+func (_m *VisibilityManager) DeleteDomainVisibility(request *persistence.DeleteDomainVisibilityRequest) (int, error) {
+	ret := _m.Called(request)
+
+	var r0 int
+	if rf, ok := ret.Get(0).(func(*persistence.DeleteDomainVisibilityRequest) int); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*persistence.DeleteDomainVisibilityRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetClosedWorkflowExecution provides a mock function with given fields: request
 func (_m *VisibilityManager) GetClosedWorkflowExecution(request *persistence.GetClosedWorkflowExecutionRequest) (*persistence.GetClosedWorkflowExecutionResponse, error) {
 	ret := _m.Called(request)
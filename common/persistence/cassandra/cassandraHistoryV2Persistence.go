@@ -52,6 +52,8 @@ const (
 
 	v2templateReadAllBranches = `SELECT branch_id, ancestors, in_progress, fork_time, info FROM history_tree WHERE tree_id = ? `
 
+	v2templateScanAllTreeBranches = `SELECT tree_id, branch_id, ancestors, fork_time, info FROM history_tree `
+
 	v2templateDeleteBranch = `DELETE FROM history_tree WHERE tree_id = ? AND branch_id = ? `
 
 	v2templateUpdateBranch = `UPDATE history_tree set in_progress = ? WHERE tree_id = ? AND branch_id = ? `
@@ -467,6 +469,56 @@ func (h *cassandraHistoryV2Persistence) GetHistoryTree(request *p.GetHistoryTree
 	}, nil
 }
 
+// GetAllHistoryTreeBranches returns all branches of all trees, page by page
+func (h *cassandraHistoryV2Persistence) GetAllHistoryTreeBranches(request *p.GetAllHistoryTreeBranchesRequest) (*p.InternalGetAllHistoryTreeBranchesResponse, error) {
+	query := h.session.Query(v2templateScanAllTreeBranches)
+
+	iter := query.PageSize(request.PageSize).PageState(request.NextPageToken).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "GetAllHistoryTreeBranches operation failed.  Not able to create query iterator.",
+		}
+	}
+	pagingToken := iter.PageState()
+
+	branches := make([]p.InternalHistoryBranchDetail, 0, request.PageSize)
+	treeUUID := gocql.UUID{}
+	branchUUID := gocql.UUID{}
+	ancsResult := []map[string]interface{}{}
+	forkTime := time.Time{}
+	info := ""
+
+	for iter.Scan(&treeUUID, &branchUUID, &ancsResult, &forkTime, &info) {
+		branch := p.InternalHistoryBranchDetail{
+			BranchInfo: workflow.HistoryBranch{
+				TreeID:    common.StringPtr(treeUUID.String()),
+				BranchID:  common.StringPtr(branchUUID.String()),
+				Ancestors: h.parseBranchAncestors(ancsResult),
+			},
+			ForkTime: forkTime,
+			Info:     info,
+		}
+		branches = append(branches, branch)
+
+		treeUUID = gocql.UUID{}
+		branchUUID = gocql.UUID{}
+		ancsResult = []map[string]interface{}{}
+		forkTime = time.Time{}
+		info = ""
+	}
+
+	if err := iter.Close(); err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("GetAllHistoryTreeBranches. Close operation failed. Error: %v", err),
+		}
+	}
+
+	return &p.InternalGetAllHistoryTreeBranchesResponse{
+		NextPageToken: pagingToken,
+		Branches:      branches,
+	}, nil
+}
+
 func (h *cassandraHistoryV2Persistence) parseBranchAncestors(ancestors []map[string]interface{}) []*workflow.HistoryBranchRange {
 	ans := make([]*workflow.HistoryBranchRange, 0, len(ancestors))
 	for _, e := range ancestors {
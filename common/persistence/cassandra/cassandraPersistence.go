@@ -162,7 +162,11 @@ const (
 		`event_store_version: ?, ` +
 		`branch_token: ?, ` +
 		`cron_schedule: ?, ` +
-		`expiration_seconds: ? ` +
+		`expiration_seconds: ?, ` +
+		`paused: ?, ` +
+		`priority: ?, ` +
+		`build_id: ?, ` +
+		`decision_heartbeat_count: ? ` +
 		`}`
 
 	templateReplicationStateType = `{` +
@@ -251,6 +255,7 @@ const (
 		`expiration_time: ?, ` +
 		`max_attempts: ?, ` +
 		`non_retriable_errors: ?, ` +
+		`priority: ?, ` +
 		`event_data_encoding: ?` +
 		`}`
 
@@ -321,14 +326,17 @@ const (
 		`name: ?, ` +
 		`type: ?, ` +
 		`ack_level: ?, ` +
-		`kind: ? ` +
+		`kind: ?, ` +
+		`last_updated: ?, ` +
+		`build_sets: ? ` +
 		`}`
 
 	templateTaskType = `{` +
 		`domain_id: ?, ` +
 		`workflow_id: ?, ` +
 		`run_id: ?, ` +
-		`schedule_id: ?` +
+		`schedule_id: ?, ` +
+		`priority: ?` +
 		`}`
 
 	templateCreateShardQuery = `INSERT INTO executions (` +
@@ -434,6 +442,11 @@ workflow_state = ? ` +
 		`and visibility_ts = ? ` +
 		`and task_id = ?`
 
+	templateListWorkflowExecutionQuery = `SELECT run_id, execution ` +
+		`FROM executions ` +
+		`WHERE shard_id = ? ` +
+		`and type = ?`
+
 	templateCheckWorkflowExecutionQuery = `UPDATE executions ` +
 		`SET next_event_id = ? ` +
 		`WHERE shard_id = ? ` +
@@ -723,6 +736,8 @@ workflow_state = ? ` +
 		`and visibility_ts = ? ` +
 		`and task_id = ? `
 
+	templateDeleteWorkflowCurrentRowQuery = templateDeleteWorkflowExecutionMutableStateQuery + `IF current_run_id = ?`
+
 	templateDeleteWorkflowExecutionSignalRequestedQuery = `UPDATE executions ` +
 		`SET signal_requested = signal_requested - ? ` +
 		`WHERE shard_id = ? ` +
@@ -804,12 +819,12 @@ workflow_state = ? ` +
 		`and visibility_ts < ?`
 
 	templateCreateTaskQuery = `INSERT INTO tasks (` +
-		`domain_id, task_list_name, task_list_type, type, task_id, task) ` +
-		`VALUES(?, ?, ?, ?, ?, ` + templateTaskType + `)`
+		`domain_id, task_list_name, task_list_type, type, task_id, task, priority) ` +
+		`VALUES(?, ?, ?, ?, ?, ` + templateTaskType + `, ?)`
 
 	templateCreateTaskWithTTLQuery = `INSERT INTO tasks (` +
-		`domain_id, task_list_name, task_list_type, type, task_id, task) ` +
-		`VALUES(?, ?, ?, ?, ?, ` + templateTaskType + `) USING TTL ?`
+		`domain_id, task_list_name, task_list_type, type, task_id, task, priority) ` +
+		`VALUES(?, ?, ?, ?, ?, ` + templateTaskType + `, ?) USING TTL ?`
 
 	templateGetTasksQuery = `SELECT task_id, task ` +
 		`FROM tasks ` +
@@ -820,6 +835,14 @@ workflow_state = ? ` +
 		`and task_id > ? ` +
 		`and task_id <= ?`
 
+	// the priority filter is applied within the task ID range of a single task list partition.
+	// Tasks written before the priority column was added have a null priority which never matches the filter,
+	// they are not read ahead. They are read back with priority 0, the lowest one, and read ahead only looks
+	// for the tasks of a higher priority than the backlog's, which is never negative.
+	templateGetTasksWithMinPriorityQuery = templateGetTasksQuery + ` ` +
+		`and priority >= ? ` +
+		`ALLOW FILTERING`
+
 	templateCompleteTaskQuery = `DELETE FROM tasks ` +
 		`WHERE domain_id = ? ` +
 		`and task_list_name = ? ` +
@@ -866,6 +889,29 @@ workflow_state = ? ` +
 		`range_id, ` +
 		`task_list ` +
 		`) VALUES (?, ?, ?, ?, ?, ?, ` + templateTaskListType + `) USING TTL ?`
+
+	templateCompleteTasksLessThanQuery = `DELETE FROM tasks ` +
+		`WHERE domain_id = ? ` +
+		`AND task_list_name = ? ` +
+		`AND task_list_type = ? ` +
+		`AND type = ? ` +
+		`AND task_id <= ?`
+
+	// templateListTaskListQuery pages through the partitions of the tasks table in token order,
+	// every partition holds one task list row and the tasks of that task list
+	templateListTaskListQuery = `SELECT DISTINCT ` +
+		`domain_id, ` +
+		`task_list_name, ` +
+		`task_list_type ` +
+		`FROM tasks`
+
+	templateDeleteTaskListQuery = `DELETE FROM tasks ` +
+		`WHERE domain_id = ? ` +
+		`AND task_list_name = ? ` +
+		`AND task_list_type = ? ` +
+		`AND type = ? ` +
+		`AND task_id = ? ` +
+		`IF range_id = ?`
 )
 
 var (
@@ -1379,6 +1425,10 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *p.Cre
 			request.BranchToken,
 			request.CronSchedule,
 			request.ExpirationSeconds,
+			false,
+			request.Priority,
+			"", // build_id (new workflow execution is not pinned to a worker build yet)
+			int64(0),
 			request.NextEventID,
 			defaultVisibilityTimestamp,
 			rowTypeExecutionTaskID)
@@ -1445,6 +1495,10 @@ func (d *cassandraPersistence) CreateWorkflowExecutionWithinBatch(request *p.Cre
 			request.BranchToken,
 			request.CronSchedule,
 			request.ExpirationSeconds,
+			false,
+			request.Priority,
+			"", // build_id (new workflow execution is not pinned to a worker build yet)
+			int64(0),
 			request.ReplicationState.CurrentVersion,
 			request.ReplicationState.StartVersion,
 			request.ReplicationState.LastWriteVersion,
@@ -1632,6 +1686,10 @@ func (d *cassandraPersistence) updateMutableState(batch *gocql.Batch, executionI
 			executionInfo.BranchToken,
 			executionInfo.CronSchedule,
 			executionInfo.ExpirationSeconds,
+			executionInfo.Paused,
+			executionInfo.Priority,
+			executionInfo.BuildID,
+			executionInfo.DecisionHeartbeatCount,
 			executionInfo.NextEventID,
 			d.shardID,
 			rowTypeExecution,
@@ -1698,6 +1756,10 @@ func (d *cassandraPersistence) updateMutableState(batch *gocql.Batch, executionI
 			executionInfo.BranchToken,
 			executionInfo.CronSchedule,
 			executionInfo.ExpirationSeconds,
+			executionInfo.Paused,
+			executionInfo.Priority,
+			executionInfo.BuildID,
+			executionInfo.DecisionHeartbeatCount,
 			replicationState.CurrentVersion,
 			replicationState.StartVersion,
 			replicationState.LastWriteVersion,
@@ -2252,6 +2314,73 @@ func (d *cassandraPersistence) DeleteWorkflowExecution(request *p.DeleteWorkflow
 	return nil
 }
 
+func (d *cassandraPersistence) DeleteCurrentWorkflowExecution(request *p.DeleteCurrentWorkflowExecutionRequest) error {
+	query := d.session.Query(templateDeleteWorkflowCurrentRowQuery,
+		d.shardID,
+		rowTypeExecution,
+		request.DomainID,
+		request.WorkflowID,
+		permanentRunID,
+		defaultVisibilityTimestamp,
+		rowTypeExecutionTaskID,
+		request.RunID)
+
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteCurrentWorkflowExecution operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteCurrentWorkflowExecution operation failed. Error: %v", err),
+		}
+	}
+
+	return nil
+}
+
+func (d *cassandraPersistence) ListConcreteExecutions(request *p.ListConcreteExecutionsRequest) (
+	*p.InternalListConcreteExecutionsResponse, error) {
+	query := d.session.Query(templateListWorkflowExecutionQuery,
+		d.shardID,
+		rowTypeExecution,
+	).PageSize(request.PageSize).PageState(request.PageToken)
+
+	iter := query.Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListConcreteExecutions operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	response := &p.InternalListConcreteExecutionsResponse{}
+	result := make(map[string]interface{})
+	for iter.MapScan(result) {
+		runID := result["run_id"].(gocql.UUID).String()
+		if runID != permanentRunID {
+			response.ExecutionInfos = append(response.ExecutionInfos, createWorkflowExecutionInfo(result["execution"].(map[string]interface{})))
+		}
+		result = make(map[string]interface{})
+	}
+	nextPageToken := iter.PageState()
+	response.NextPageToken = make([]byte, len(nextPageToken))
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListConcreteExecutions operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListConcreteExecutions operation failed. Error: %v", err),
+		}
+	}
+
+	return response, nil
+}
+
 func (d *cassandraPersistence) GetCurrentExecution(request *p.GetCurrentExecutionRequest) (*p.GetCurrentExecutionResponse,
 	error) {
 	query := d.session.Query(templateGetCurrentExecutionQuery,
@@ -2523,7 +2652,9 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 		rowTypeTaskList,
 		taskListTaskID,
 	)
+	now := time.Now()
 	var rangeID, ackLevel int64
+	var buildSets [][]string
 	var tlDB map[string]interface{}
 	err := query.Scan(&rangeID, &tlDB)
 	if err != nil {
@@ -2540,6 +2671,8 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 				request.TaskType,
 				0,
 				request.TaskListKind,
+				now,
+				nil,
 			)
 		} else if isThrottlingError(err) {
 			return nil, &workflow.ServiceBusyError{
@@ -2555,6 +2688,7 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 	} else {
 		ackLevel = tlDB["ack_level"].(int64)
 		taskListKind := tlDB["kind"].(int)
+		buildSets, _ = tlDB["build_sets"].([][]string)
 		query = d.session.Query(templateUpdateTaskListQuery,
 			rangeID+1,
 			request.DomainID,
@@ -2562,6 +2696,8 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 			request.TaskType,
 			ackLevel,
 			taskListKind,
+			now,
+			buildSets,
 			request.DomainID,
 			&request.TaskList,
 			request.TaskType,
@@ -2588,7 +2724,7 @@ func (d *cassandraPersistence) LeaseTaskList(request *p.LeaseTaskListRequest) (*
 			Msg: fmt.Sprintf("LeaseTaskList failed to apply. db rangeID %v", previousRangeID),
 		}
 	}
-	tli := &p.TaskListInfo{DomainID: request.DomainID, Name: request.TaskList, TaskType: request.TaskType, RangeID: rangeID + 1, AckLevel: ackLevel, Kind: request.TaskListKind}
+	tli := &p.TaskListInfo{DomainID: request.DomainID, Name: request.TaskList, TaskType: request.TaskType, RangeID: rangeID + 1, AckLevel: ackLevel, Kind: request.TaskListKind, LastUpdated: now, BuildSets: buildSets}
 	return &p.LeaseTaskListResponse{TaskListInfo: tli}, nil
 }
 
@@ -2609,6 +2745,8 @@ func (d *cassandraPersistence) UpdateTaskList(request *p.UpdateTaskListRequest)
 			tli.TaskType,
 			tli.AckLevel,
 			tli.Kind,
+			time.Now(),
+			tli.BuildSets,
 			stickyTaskListTTL,
 		)
 		err := query.Exec()
@@ -2632,6 +2770,8 @@ func (d *cassandraPersistence) UpdateTaskList(request *p.UpdateTaskListRequest)
 		tli.TaskType,
 		tli.AckLevel,
 		tli.Kind,
+		time.Now(),
+		tli.BuildSets,
 		tli.DomainID,
 		&tli.Name,
 		tli.TaskType,
@@ -2689,7 +2829,9 @@ func (d *cassandraPersistence) CreateTasks(request *p.CreateTasksRequest) (*p.Cr
 				domainID,
 				task.Execution.GetWorkflowId(),
 				task.Execution.GetRunId(),
-				scheduleID)
+				scheduleID,
+				task.Data.Priority,
+				task.Data.Priority)
 		} else {
 			batch.Query(templateCreateTaskWithTTLQuery,
 				domainID,
@@ -2701,6 +2843,8 @@ func (d *cassandraPersistence) CreateTasks(request *p.CreateTasksRequest) (*p.Cr
 				task.Execution.GetWorkflowId(),
 				task.Execution.GetRunId(),
 				scheduleID,
+				task.Data.Priority,
+				task.Data.Priority,
 				task.Data.ScheduleToStartTimeout)
 		}
 	}
@@ -2713,6 +2857,7 @@ func (d *cassandraPersistence) CreateTasks(request *p.CreateTasksRequest) (*p.Cr
 		taskListType,
 		ackLevel,
 		taskListKind,
+		time.Now(),
 		domainID,
 		taskList,
 		taskListType,
@@ -2751,14 +2896,28 @@ func (d *cassandraPersistence) GetTasks(request *p.GetTasksRequest) (*p.GetTasks
 	}
 
 	// Reading tasklist tasks need to be quorum level consistent, otherwise we could loose task
-	query := d.session.Query(templateGetTasksQuery,
-		request.DomainID,
-		request.TaskList,
-		request.TaskType,
-		rowTypeTask,
-		request.ReadLevel,
-		request.MaxReadLevel,
-	).PageSize(request.BatchSize)
+	var query *gocql.Query
+	if request.MinPriority != nil {
+		query = d.session.Query(templateGetTasksWithMinPriorityQuery,
+			request.DomainID,
+			request.TaskList,
+			request.TaskType,
+			rowTypeTask,
+			request.ReadLevel,
+			request.MaxReadLevel,
+			*request.MinPriority,
+		)
+	} else {
+		query = d.session.Query(templateGetTasksQuery,
+			request.DomainID,
+			request.TaskList,
+			request.TaskType,
+			rowTypeTask,
+			request.ReadLevel,
+			request.MaxReadLevel,
+		)
+	}
+	query = query.PageSize(request.BatchSize)
 
 	iter := query.Iter()
 	if iter == nil {
@@ -2818,6 +2977,120 @@ func (d *cassandraPersistence) CompleteTask(request *p.CompleteTaskRequest) erro
 	return nil
 }
 
+// From TaskManager interface
+func (d *cassandraPersistence) CompleteTasksLessThan(request *p.CompleteTasksLessThanRequest) (int, error) {
+	query := d.session.Query(templateCompleteTasksLessThanQuery,
+		request.DomainID, request.TaskListName, request.TaskType, rowTypeTask, request.TaskID)
+	err := query.Exec()
+	if err != nil {
+		if isThrottlingError(err) {
+			return 0, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("CompleteTasksLessThan operation failed. Error: %v", err),
+			}
+		}
+		return 0, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CompleteTasksLessThan operation failed. Error: %v", err),
+		}
+	}
+	return p.UnknownNumRowsAffected, nil
+}
+
+// From TaskManager interface
+func (d *cassandraPersistence) ListTaskList(request *p.ListTaskListRequest) (*p.ListTaskListResponse, error) {
+	query := d.session.Query(templateListTaskListQuery)
+	iter := query.PageSize(request.PageSize).PageState(request.PageToken).Iter()
+	if iter == nil {
+		return nil, &workflow.InternalServiceError{
+			Message: "ListTaskList operation failed.  Not able to create query iterator.",
+		}
+	}
+
+	type taskListKey struct {
+		domainID string
+		name     string
+		taskType int
+	}
+	var keys []taskListKey
+	var key taskListKey
+	for iter.Scan(&key.domainID, &key.name, &key.taskType) {
+		keys = append(keys, key)
+	}
+	nextPageToken := iter.PageState()
+	response := &p.ListTaskListResponse{
+		NextPageToken: make([]byte, len(nextPageToken)),
+	}
+	copy(response.NextPageToken, nextPageToken)
+
+	if err := iter.Close(); err != nil {
+		if isThrottlingError(err) {
+			return nil, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("ListTaskList operation failed. Error: %v", err),
+			}
+		}
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListTaskList operation failed. Error: %v", err),
+		}
+	}
+
+	for _, key := range keys {
+		query := d.session.Query(templateGetTaskList,
+			key.domainID,
+			key.name,
+			key.taskType,
+			rowTypeTaskList,
+			taskListTaskID,
+		)
+		var rangeID int64
+		var tlDB map[string]interface{}
+		if err := query.Scan(&rangeID, &tlDB); err != nil {
+			if err == gocql.ErrNotFound {
+				// the partition only holds tasks left behind by a deleted task list
+				continue
+			}
+			if isThrottlingError(err) {
+				return nil, &workflow.ServiceBusyError{
+					Message: fmt.Sprintf("ListTaskList operation failed. TaskList: %v, TaskType: %v, Error: %v",
+						key.name, key.taskType, err),
+				}
+			}
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("ListTaskList operation failed. TaskList: %v, TaskType: %v, Error: %v",
+					key.name, key.taskType, err),
+			}
+		}
+		tli := createTaskListInfo(tlDB)
+		tli.RangeID = rangeID
+		response.Items = append(response.Items, *tli)
+	}
+
+	return response, nil
+}
+
+// From TaskManager interface
+func (d *cassandraPersistence) DeleteTaskList(request *p.DeleteTaskListRequest) error {
+	query := d.session.Query(templateDeleteTaskListQuery,
+		request.DomainID, request.TaskListName, request.TaskListType, rowTypeTaskList, taskListTaskID, request.RangeID)
+
+	previous := make(map[string]interface{})
+	applied, err := query.MapScanCAS(previous)
+	if err != nil {
+		if isThrottlingError(err) {
+			return &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteTaskList operation failed. Error: %v", err),
+			}
+		}
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteTaskList operation failed. Error: %v", err),
+		}
+	}
+	if !applied {
+		return &p.ConditionFailedError{
+			Msg: fmt.Sprintf("DeleteTaskList operation failed: expected_range_id=%v but found %+v", request.RangeID, previous),
+		}
+	}
+	return nil
+}
+
 func (d *cassandraPersistence) GetTimerIndexTasks(request *p.GetTimerIndexTasksRequest) (*p.GetTimerIndexTasksResponse,
 	error) {
 	// Reading timer tasks need to be quorum level consistent, otherwise we could loose task
@@ -2884,6 +3157,11 @@ func (d *cassandraPersistence) createTransferTasks(batch *gocql.Batch, transferT
 			taskList = task.(*p.ActivityTask).TaskList
 			scheduleID = task.(*p.ActivityTask).ScheduleID
 
+		case p.TransferTaskTypeActivityCancellation:
+			targetDomainID = task.(*p.ActivityCancellationTask).DomainID
+			taskList = task.(*p.ActivityCancellationTask).TaskList
+			scheduleID = task.(*p.ActivityCancellationTask).ScheduleID
+
 		case p.TransferTaskTypeDecisionTask:
 			targetDomainID = task.(*p.DecisionTask).DomainID
 			taskList = task.(*p.DecisionTask).TaskList
@@ -3122,6 +3400,7 @@ func (d *cassandraPersistence) updateActivityInfos(batch *gocql.Batch, activityI
 			a.ExpirationTime,
 			a.MaximumAttempts,
 			a.NonRetriableErrors,
+			a.Priority,
 			encoding,
 			d.shardID,
 			rowTypeExecution,
@@ -3700,6 +3979,14 @@ func createWorkflowExecutionInfo(result map[string]interface{}) *p.InternalWorkf
 			info.CronSchedule = v.(string)
 		case "expiration_seconds":
 			info.ExpirationSeconds = int32(v.(int))
+		case "paused":
+			info.Paused = v.(bool)
+		case "priority":
+			info.Priority = int32(v.(int))
+		case "build_id":
+			info.BuildID = v.(string)
+		case "decision_heartbeat_count":
+			info.DecisionHeartbeatCount = v.(int64)
 		}
 	}
 	info.CompletionEvent = p.NewDataBlob(completionEventData, completionEventEncoding)
@@ -3883,6 +4170,8 @@ func createActivityInfo(domainID string, result map[string]interface{}) *p.Inter
 			info.ExpirationTime = v.(time.Time)
 		case "non_retriable_errors":
 			info.NonRetriableErrors = v.([]string)
+		case "priority":
+			info.Priority = (int32)(v.(int))
 		case "event_data_encoding":
 			sharedEncoding = common.EncodingType(v.(string))
 		}
@@ -4058,6 +4347,7 @@ func resetActivityInfoMap(activityInfos []*p.InternalActivityInfo) (map[int64]ma
 		aInfo["expiration_time"] = a.ExpirationTime
 		aInfo["max_attempts"] = a.MaximumAttempts
 		aInfo["non_retriable_errors"] = a.NonRetriableErrors
+		aInfo["priority"] = a.Priority
 
 		aMap[a.ScheduleID] = aInfo
 	}
@@ -4173,6 +4463,32 @@ func createTaskInfo(result map[string]interface{}) *p.TaskInfo {
 			info.RunID = v.(gocql.UUID).String()
 		case "schedule_id":
 			info.ScheduleID = v.(int64)
+		case "priority":
+			info.Priority = int32(v.(int))
+		}
+	}
+
+	return info
+}
+
+func createTaskListInfo(result map[string]interface{}) *p.TaskListInfo {
+	info := &p.TaskListInfo{}
+	for k, v := range result {
+		switch k {
+		case "domain_id":
+			info.DomainID = v.(gocql.UUID).String()
+		case "name":
+			info.Name = v.(string)
+		case "type":
+			info.TaskType = v.(int)
+		case "ack_level":
+			info.AckLevel = v.(int64)
+		case "kind":
+			info.Kind = v.(int)
+		case "last_updated":
+			info.LastUpdated = v.(time.Time)
+		case "build_sets":
+			info.BuildSets = v.([][]string)
 		}
 	}
 
@@ -126,6 +126,18 @@ const (
 		`AND start_time <= ? ` +
 		`AND status = ? `
 
+	templateDeleteDomainOpenExecutions = `DELETE FROM open_executions ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ?`
+
+	templateDeleteDomainClosedExecutions = `DELETE FROM closed_executions ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ?`
+
+	templateDeleteDomainClosedExecutionsV2 = `DELETE FROM closed_executions_v2 ` +
+		`WHERE domain_id = ? ` +
+		`AND domain_partition = ?`
+
 	templateGetClosedWorkflowExecution = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, status, history_length ` +
 		`FROM closed_executions ` +
 		`WHERE domain_id = ? ` +
@@ -141,6 +153,14 @@ type (
 	}
 )
 
+// NewVisibilityPersistenceFromSession returns VisibilityManager
+func NewVisibilityPersistenceFromSession(session *gocql.Session, logger bark.Logger) p.VisibilityManager {
+	return &cassandraVisibilityPersistence{
+		cassandraStore: cassandraStore{session: session, logger: logger},
+		lowConslevel:   gocql.One,
+	}
+}
+
 // newVisibilityPersistence is used to create an instance of VisibilityManager implementation
 func newVisibilityPersistence(cfg config.Cassandra, logger bark.Logger) (p.VisibilityManager, error) {
 	cluster := NewCassandraCluster(cfg.Hosts, cfg.Port, cfg.User, cfg.Password, cfg.Datacenter)
@@ -620,6 +640,27 @@ func (v *cassandraVisibilityPersistence) GetClosedWorkflowExecution(
 	}, nil
 }
 
+// DeleteDomainVisibility deletes the whole partition of the domain from all the visibility tables,
+// the limit of the request is ignored
+func (v *cassandraVisibilityPersistence) DeleteDomainVisibility(request *p.DeleteDomainVisibilityRequest) (int, error) {
+	batch := v.session.NewBatch(gocql.LoggedBatch)
+	batch.Query(templateDeleteDomainOpenExecutions, request.DomainUUID, domainPartition)
+	batch.Query(templateDeleteDomainClosedExecutions, request.DomainUUID, domainPartition)
+	batch.Query(templateDeleteDomainClosedExecutionsV2, request.DomainUUID, domainPartition)
+	err := v.session.ExecuteBatch(batch)
+	if err != nil {
+		if isThrottlingError(err) {
+			return 0, &workflow.ServiceBusyError{
+				Message: fmt.Sprintf("DeleteDomainVisibility operation failed. Error: %v", err),
+			}
+		}
+		return 0, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteDomainVisibility operation failed. Error: %v", err),
+		}
+	}
+	return p.UnknownNumRowsAffected, nil
+}
+
 func readOpenWorkflowExecutionRecord(iter *gocql.Iter) (*workflow.WorkflowExecutionInfo, bool) {
 	var workflowID string
 	var runID gocql.UUID
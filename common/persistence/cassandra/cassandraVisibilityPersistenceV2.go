@@ -133,6 +133,11 @@ func (v *cassandraVisibilityPersistenceV2) GetClosedWorkflowExecution(
 	return v.persistence.GetClosedWorkflowExecution(request)
 }
 
+func (v *cassandraVisibilityPersistenceV2) DeleteDomainVisibility(
+	request *p.DeleteDomainVisibilityRequest) (int, error) {
+	return v.persistence.DeleteDomainVisibility(request)
+}
+
 func (v *cassandraVisibilityPersistenceV2) ListClosedWorkflowExecutions(
 	request *p.ListWorkflowExecutionsRequest) (*p.ListWorkflowExecutionsResponse, error) {
 	query := v.session.Query(templateGetClosedWorkflowExecutionsV2,
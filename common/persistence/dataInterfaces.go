@@ -41,6 +41,10 @@ const (
 	EventStoreVersionV2 = 2
 )
 
+// UnknownNumRowsAffected is returned by range deletes when the store
+// cannot report how many rows were removed
+const UnknownNumRowsAffected = -1
+
 // Domain status
 const (
 	DomainStatusRegistered = iota
@@ -99,6 +103,7 @@ const (
 	TransferTaskTypeCancelExecution
 	TransferTaskTypeStartChildExecution
 	TransferTaskTypeSignalExecution
+	TransferTaskTypeActivityCancellation
 )
 
 // Types of replication tasks
@@ -123,6 +128,7 @@ const (
 const (
 	WorkflowBackoffTimeoutTypeRetry = iota
 	WorkflowBackoffTimeoutTypeCron
+	WorkflowBackoffTimeoutTypeDelayStart
 )
 
 const (
@@ -268,6 +274,12 @@ type (
 		BranchToken       []byte
 		CronSchedule      string
 		ExpirationSeconds int32
+		Paused            bool
+		Priority          int32
+		// BuildID is the worker build the workflow is pinned to, set by the first completed decision
+		BuildID string
+		// DecisionHeartbeatCount is the number of decision attempts which were heartbeats rather than failures
+		DecisionHeartbeatCount int64
 	}
 
 	// ReplicationState represents mutable state information for global domains.
@@ -333,12 +345,16 @@ type (
 
 	// TaskListInfo describes a state of a task list implementation.
 	TaskListInfo struct {
-		DomainID string
-		Name     string
-		TaskType int
-		RangeID  int64
-		AckLevel int64
-		Kind     int
+		DomainID    string
+		Name        string
+		TaskType    int
+		RangeID     int64
+		AckLevel    int64
+		Kind        int
+		LastUpdated time.Time
+		// BuildSets are the compatible worker build sets of a decision task list, the last set holds the
+		// default build for new workflows
+		BuildSets [][]string
 	}
 
 	// TaskInfo describes either activity or decision task
@@ -349,6 +365,8 @@ type (
 		TaskID                 int64
 		ScheduleID             int64
 		ScheduleToStartTimeout int32
+		Expiry                 time.Time
+		Priority               int32
 	}
 
 	// Task is the generic interface for workflow tasks
@@ -372,6 +390,17 @@ type (
 		Version             int64
 	}
 
+	// ActivityCancellationTask identifies a transfer task for notifying the worker of a started
+	// activity that cancellation of the activity was requested
+	ActivityCancellationTask struct {
+		VisibilityTimestamp time.Time
+		TaskID              int64
+		DomainID            string
+		TaskList            string
+		ScheduleID          int64
+		Version             int64
+	}
+
 	// DecisionTask identifies a transfer task for decision
 	DecisionTask struct {
 		VisibilityTimestamp time.Time
@@ -567,6 +596,7 @@ type (
 		ExpirationTime     time.Time
 		MaximumAttempts    int32
 		NonRetriableErrors []string
+		Priority           int32
 		// Not written to database - This is used only for deduping heartbeat timer creation
 		LastHeartbeatTimeoutVisibility int64
 	}
@@ -687,6 +717,7 @@ type (
 		BranchToken       []byte
 		CronSchedule      string
 		ExpirationSeconds int32
+		Priority          int32
 	}
 
 	// CreateWorkflowExecutionResponse is the response to CreateWorkflowExecutionRequest
@@ -817,6 +848,26 @@ type (
 		RunID      string
 	}
 
+	// DeleteCurrentWorkflowExecutionRequest is used to delete the current workflow execution
+	// pointer of a workflow, the pointer is only deleted when it still refers to RunID
+	DeleteCurrentWorkflowExecutionRequest struct {
+		DomainID   string
+		WorkflowID string
+		RunID      string
+	}
+
+	// ListConcreteExecutionsRequest is request to ListConcreteExecutions
+	ListConcreteExecutionsRequest struct {
+		PageSize  int
+		PageToken []byte
+	}
+
+	// ListConcreteExecutionsResponse is response to ListConcreteExecutions
+	ListConcreteExecutionsResponse struct {
+		ExecutionInfos []*WorkflowExecutionInfo
+		PageToken      []byte
+	}
+
 	// GetTransferTasksRequest is used to read tasks from the transfer task queue
 	GetTransferTasksRequest struct {
 		ReadLevel     int64
@@ -921,6 +972,7 @@ type (
 		MaxReadLevel int64 // inclusive
 		BatchSize    int
 		RangeID      int64
+		MinPriority  *int32 // optional, only tasks of at least this priority are read
 	}
 
 	// GetTasksResponse is the response to GetTasksRequests
@@ -934,6 +986,35 @@ type (
 		TaskID   int64
 	}
 
+	// CompleteTasksLessThanRequest contains the request params needed to invoke CompleteTasksLessThan API
+	CompleteTasksLessThanRequest struct {
+		DomainID     string
+		TaskListName string
+		TaskType     int
+		TaskID       int64 // Tasks less than or equal to this ID will be completed
+		Limit        int   // Limit on the max number of tasks that can be completed. Required param
+	}
+
+	// ListTaskListRequest contains the request params needed to invoke ListTaskList API
+	ListTaskListRequest struct {
+		PageSize  int
+		PageToken []byte
+	}
+
+	// ListTaskListResponse is the response from ListTaskList API
+	ListTaskListResponse struct {
+		Items         []TaskListInfo
+		NextPageToken []byte
+	}
+
+	// DeleteTaskListRequest contains the request params needed to invoke DeleteTaskList API
+	DeleteTaskListRequest struct {
+		DomainID     string
+		TaskListName string
+		TaskListType int
+		RangeID      int64
+	}
+
 	// GetTimerIndexTasksRequest is the request for GetTimerIndexTasks
 	// TODO: replace this with an iterator that can configure min and max index.
 	GetTimerIndexTasksRequest struct {
@@ -1291,6 +1372,32 @@ type (
 		ForkingInProgressBranches []ForkingInProgressBranch
 	}
 
+	// GetAllHistoryTreeBranchesRequest is a request of GetAllHistoryTreeBranches
+	GetAllHistoryTreeBranchesRequest struct {
+		// pagination token
+		NextPageToken []byte
+		// maximum number of branches returned per page
+		PageSize int
+	}
+
+	// HistoryBranchDetail contains detailed information of a branch
+	HistoryBranchDetail struct {
+		TreeID   string
+		BranchID string
+		// token of the branch, can be used with the other history V2 APIs
+		BranchToken []byte
+		ForkTime    time.Time
+		Info        string
+	}
+
+	// GetAllHistoryTreeBranchesResponse is a response to GetAllHistoryTreeBranches
+	GetAllHistoryTreeBranchesResponse struct {
+		// pagination token
+		NextPageToken []byte
+		// all branches of all trees
+		Branches []HistoryBranchDetail
+	}
+
 	// AppendHistoryEventsResponse is response for AppendHistoryEventsRequest
 	// Deprecated: uses V2 API-AppendHistoryNodesRequest
 	AppendHistoryEventsResponse struct {
@@ -1323,8 +1430,12 @@ type (
 		ResetMutableState(request *ResetMutableStateRequest) error
 		ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error
 		DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error
+		DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error
 		GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
 
+		// Scan related methods
+		ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*ListConcreteExecutionsResponse, error)
+
 		// Transfer task related methods
 		GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
 		CompleteTransferTask(request *CompleteTransferTaskRequest) error
@@ -1355,6 +1466,20 @@ type (
 		CreateTasks(request *CreateTasksRequest) (*CreateTasksResponse, error)
 		GetTasks(request *GetTasksRequest) (*GetTasksResponse, error)
 		CompleteTask(request *CompleteTaskRequest) error
+		// CompleteTasksLessThan completes tasks less than or equal to the given task id
+		// This API takes a limit parameter which specifies the count of maxRows that
+		// can be deleted. This parameter may be ignored by the underlying storage, but
+		// its mandatory to specify it. On success this method returns the number of rows
+		// actually deleted. If the underlying storage doesn't support "limit", all rows
+		// less than or equal to taskID will be deleted.
+		// On success, this method returns:
+		//  - number of rows actually deleted, if limit is honored
+		//  - UnknownNumRowsAffected, when all rows below value are deleted
+		CompleteTasksLessThan(request *CompleteTasksLessThanRequest) (int, error)
+		// ListTaskList returns all the task lists, one page at a time
+		ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error)
+		// DeleteTaskList deletes a task list, provided its rangeID still matches
+		DeleteTaskList(request *DeleteTaskListRequest) error
 	}
 
 	// HistoryManager is used to manage Workflow Execution HistoryEventBatch
@@ -1398,6 +1523,8 @@ type (
 		DeleteHistoryBranch(request *DeleteHistoryBranchRequest) error
 		// GetHistoryTree returns all branch information of a tree
 		GetHistoryTree(request *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error)
+		// GetAllHistoryTreeBranches returns all branches of all trees, page by page
+		GetAllHistoryTreeBranches(request *GetAllHistoryTreeBranchesRequest) (*GetAllHistoryTreeBranchesResponse, error)
 	}
 
 	// MetadataManager is used to manage metadata CRUD for domain entities
@@ -1477,6 +1604,41 @@ func (a *ActivityTask) SetVisibilityTimestamp(timestamp time.Time) {
 	a.VisibilityTimestamp = timestamp
 }
 
+// GetType returns the type of the activity cancellation task
+func (a *ActivityCancellationTask) GetType() int {
+	return TransferTaskTypeActivityCancellation
+}
+
+// GetVersion returns the version of the activity cancellation task
+func (a *ActivityCancellationTask) GetVersion() int64 {
+	return a.Version
+}
+
+// SetVersion returns the version of the activity cancellation task
+func (a *ActivityCancellationTask) SetVersion(version int64) {
+	a.Version = version
+}
+
+// GetTaskID returns the sequence ID of the activity cancellation task
+func (a *ActivityCancellationTask) GetTaskID() int64 {
+	return a.TaskID
+}
+
+// SetTaskID sets the sequence ID of the activity cancellation task
+func (a *ActivityCancellationTask) SetTaskID(id int64) {
+	a.TaskID = id
+}
+
+// GetVisibilityTimestamp get the visibility timestamp
+func (a *ActivityCancellationTask) GetVisibilityTimestamp() time.Time {
+	return a.VisibilityTimestamp
+}
+
+// SetVisibilityTimestamp set the visibility timestamp
+func (a *ActivityCancellationTask) SetVisibilityTimestamp(timestamp time.Time) {
+	a.VisibilityTimestamp = timestamp
+}
+
 // GetType returns the type of the decision task
 func (d *DecisionTask) GetType() int {
 	return TransferTaskTypeDecisionTask
@@ -189,6 +189,20 @@ func (p *visibilityMetricsClient) GetClosedWorkflowExecution(request *p.GetClose
 	return response, err
 }
 
+func (p *visibilityMetricsClient) DeleteDomainVisibility(request *p.DeleteDomainVisibilityRequest) (int, error) {
+	p.metricClient.IncCounter(metrics.ElasticsearchDeleteDomainVisibilityScope, metrics.ElasticsearchRequests)
+
+	sw := p.metricClient.StartTimer(metrics.ElasticsearchDeleteDomainVisibilityScope, metrics.ElasticsearchLatency)
+	result, err := p.persistence.DeleteDomainVisibility(request)
+	sw.Stop()
+
+	if err != nil {
+		p.updateErrorMetric(metrics.ElasticsearchDeleteDomainVisibilityScope, err)
+	}
+
+	return result, err
+}
+
 func (p *visibilityMetricsClient) updateErrorMetric(scope int, err error) {
 	switch err.(type) {
 	case *workflow.BadRequestError:
@@ -262,6 +262,22 @@ func (v *esVisibilityManager) GetClosedWorkflowExecution(
 	return response, nil
 }
 
+func (v *esVisibilityManager) DeleteDomainVisibility(request *p.DeleteDomainVisibilityRequest) (int, error) {
+	ctx := context.Background()
+	params := &es.DeleteByQueryParameters{
+		Index: v.index,
+		Query: elastic.NewMatchQuery(es.DomainID, request.DomainUUID),
+		Size:  request.Limit,
+	}
+	resp, err := v.esClient.DeleteByQuery(ctx, params)
+	if err != nil {
+		return 0, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteDomainVisibility failed. Error: %v", err),
+		}
+	}
+	return int(resp.Deleted), nil
+}
+
 func (v *esVisibilityManager) getNextPageToken(token []byte) (*esVisibilityPageToken, error) {
 	var result *esVisibilityPageToken
 	var err error
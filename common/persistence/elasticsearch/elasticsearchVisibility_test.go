@@ -297,6 +297,29 @@ func (s *ESVisibilitySuite) TestGetClosedWorkflowExecution_NoRunID() {
 	s.NoError(err)
 }
 
+func (s *ESVisibilitySuite) TestDeleteDomainVisibility() {
+	s.mockESClient.On("DeleteByQuery", mock.Anything, mock.MatchedBy(func(input *es.DeleteByQueryParameters) bool {
+		source, _ := input.Query.Source()
+		s.True(strings.Contains(fmt.Sprintf("%v", source), testDomainID))
+		s.Equal(100, input.Size)
+		return true
+	})).Return(&elastic.BulkIndexByScrollResponse{Deleted: 42}, nil).Once()
+	request := &p.DeleteDomainVisibilityRequest{
+		DomainUUID: testDomainID,
+		Limit:      100,
+	}
+	n, err := s.visibilityMgr.DeleteDomainVisibility(request)
+	s.NoError(err)
+	s.Equal(42, n)
+
+	s.mockESClient.On("DeleteByQuery", mock.Anything, mock.Anything).Return(nil, errTestESSearch).Once()
+	_, err = s.visibilityMgr.DeleteDomainVisibility(request)
+	s.Error(err)
+	_, ok := err.(*workflow.InternalServiceError)
+	s.True(ok)
+	s.True(strings.Contains(err.Error(), "DeleteDomainVisibility failed"))
+}
+
 func (s *ESVisibilitySuite) TestGetNextPageToken() {
 	token, err := s.visibilityMgr.getNextPageToken([]byte{})
 	s.Equal(0, token.From)
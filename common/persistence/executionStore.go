@@ -24,12 +24,14 @@ import (
 	"github.com/uber-common/bark"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/encryption"
 )
 
 type (
 	// executionManagerImpl implements ExecutionManager based on ExecutionStore, statsComputer and HistorySerializer
 	executionManagerImpl struct {
 		serializer    HistorySerializer
+		encryptor     encryption.Encryptor
 		persistence   ExecutionStore
 		statsComputer statsComputer
 		logger        bark.Logger
@@ -40,8 +42,19 @@ var _ ExecutionManager = (*executionManagerImpl)(nil)
 
 // NewExecutionManagerImpl returns new ExecutionManager
 func NewExecutionManagerImpl(persistence ExecutionStore, logger bark.Logger) ExecutionManager {
+	return NewExecutionManagerImplWithEncryptor(persistence, encryption.NewNoopEncryptor(), logger)
+}
+
+// NewExecutionManagerImplWithEncryptor returns new ExecutionManager which encrypts the serialized events, the
+// heartbeat details of activities, the input and control of signals and the execution context at rest
+func NewExecutionManagerImplWithEncryptor(
+	persistence ExecutionStore,
+	encryptor encryption.Encryptor,
+	logger bark.Logger,
+) ExecutionManager {
 	return &executionManagerImpl{
-		serializer:    NewHistorySerializer(),
+		serializer:    NewHistorySerializerWithEncryptor(encryptor),
+		encryptor:     encryptor,
 		persistence:   persistence,
 		statsComputer: statsComputer{},
 		logger:        logger,
@@ -66,7 +79,6 @@ func (m *executionManagerImpl) GetWorkflowExecution(request *GetWorkflowExecutio
 		State: &WorkflowMutableState{
 			TimerInfos:         response.State.TimerInfos,
 			RequestCancelInfos: response.State.RequestCancelInfos,
-			SignalInfos:        response.State.SignalInfos,
 			SignalRequestedIDs: response.State.SignalRequestedIDs,
 			ReplicationState:   response.State.ReplicationState,
 		},
@@ -80,6 +92,10 @@ func (m *executionManagerImpl) GetWorkflowExecution(request *GetWorkflowExecutio
 	if err != nil {
 		return nil, err
 	}
+	newResponse.State.SignalInfos, err = m.DeserializeSignalInfos(response.State.SignalInfos)
+	if err != nil {
+		return nil, err
+	}
 	newResponse.State.BufferedEvents, err = m.DeserializeBufferedEvents(response.State.BufferedEvents)
 	if err != nil {
 		return nil, err
@@ -102,9 +118,14 @@ func (m *executionManagerImpl) DeserializeExecutionInfo(info *InternalWorkflowEx
 	if err != nil {
 		return nil, err
 	}
+	executionContext, err := m.decrypt(info.ExecutionContext)
+	if err != nil {
+		return nil, err
+	}
 
 	newInfo := &WorkflowExecutionInfo{
-		CompletionEvent: completionEvent,
+		CompletionEvent:  completionEvent,
+		ExecutionContext: executionContext,
 
 		DomainID:                     info.DomainID,
 		WorkflowID:                   info.WorkflowID,
@@ -118,7 +139,6 @@ func (m *executionManagerImpl) DeserializeExecutionInfo(info *InternalWorkflowEx
 		WorkflowTypeName:             info.WorkflowTypeName,
 		WorkflowTimeout:              info.WorkflowTimeout,
 		DecisionTimeoutValue:         info.DecisionTimeoutValue,
-		ExecutionContext:             info.ExecutionContext,
 		State:                        info.State,
 		CloseStatus:                  info.CloseStatus,
 		LastFirstEventID:             info.LastFirstEventID,
@@ -155,6 +175,10 @@ func (m *executionManagerImpl) DeserializeExecutionInfo(info *InternalWorkflowEx
 		BranchToken:                  info.BranchToken,
 		CronSchedule:                 info.CronSchedule,
 		ExpirationSeconds:            info.ExpirationSeconds,
+		Paused:                       info.Paused,
+		Priority:                     info.Priority,
+		BuildID:                      info.BuildID,
+		DecisionHeartbeatCount:       info.DecisionHeartbeatCount,
 	}
 	return newInfo, nil
 }
@@ -250,9 +274,14 @@ func (m *executionManagerImpl) DeserializeActivityInfos(infos map[int64]*Interna
 		if err != nil {
 			return nil, err
 		}
+		details, err := m.decrypt(v.Details)
+		if err != nil {
+			return nil, err
+		}
 		a := &ActivityInfo{
 			ScheduledEvent: scheduledEvent,
 			StartedEvent:   startedEvent,
+			Details:        details,
 
 			Version:                        v.Version,
 			ScheduleID:                     v.ScheduleID,
@@ -262,7 +291,6 @@ func (m *executionManagerImpl) DeserializeActivityInfos(infos map[int64]*Interna
 			StartedTime:                    v.StartedTime,
 			ActivityID:                     v.ActivityID,
 			RequestID:                      v.RequestID,
-			Details:                        v.Details,
 			ScheduleToStartTimeout:         v.ScheduleToStartTimeout,
 			ScheduleToCloseTimeout:         v.ScheduleToCloseTimeout,
 			StartToCloseTimeout:            v.StartToCloseTimeout,
@@ -282,6 +310,7 @@ func (m *executionManagerImpl) DeserializeActivityInfos(infos map[int64]*Interna
 			ExpirationTime:                 v.ExpirationTime,
 			MaximumAttempts:                v.MaximumAttempts,
 			NonRetriableErrors:             v.NonRetriableErrors,
+			Priority:                       v.Priority,
 			LastHeartbeatTimeoutVisibility: v.LastHeartbeatTimeoutVisibility,
 		}
 		newInfos[k] = a
@@ -302,6 +331,14 @@ func (m *executionManagerImpl) UpdateWorkflowExecution(request *UpdateWorkflowEx
 	if err != nil {
 		return nil, err
 	}
+	upsertSignalInfos, err := m.SerializeUpsertSignalInfos(request.UpsertSignalInfos)
+	if err != nil {
+		return nil, err
+	}
+	continueAsNew, err := m.SerializeCreateWorkflowExecutionRequest(request.ContinueAsNew)
+	if err != nil {
+		return nil, err
+	}
 	var newBufferedEvents *DataBlob
 	if request.NewBufferedEvents != nil {
 		newBufferedEvents, err = m.serializer.SerializeBatchEvents(request.NewBufferedEvents, request.Encoding)
@@ -328,7 +365,7 @@ func (m *executionManagerImpl) UpdateWorkflowExecution(request *UpdateWorkflowEx
 		DeleteTimerTask:               request.DeleteTimerTask,
 		Condition:                     request.Condition,
 		RangeID:                       request.RangeID,
-		ContinueAsNew:                 request.ContinueAsNew,
+		ContinueAsNew:                 continueAsNew,
 		FinishExecution:               request.FinishExecution,
 		FinishedExecutionTTL:          request.FinishedExecutionTTL,
 		DeleteActivityInfos:           request.DeleteActivityInfos,
@@ -337,7 +374,7 @@ func (m *executionManagerImpl) UpdateWorkflowExecution(request *UpdateWorkflowEx
 		DeleteChildExecutionInfo:      request.DeleteChildExecutionInfo,
 		UpsertRequestCancelInfos:      request.UpsertRequestCancelInfos,
 		DeleteRequestCancelInfo:       request.DeleteRequestCancelInfo,
-		UpsertSignalInfos:             request.UpsertSignalInfos,
+		UpsertSignalInfos:             upsertSignalInfos,
 		DeleteSignalInfo:              request.DeleteSignalInfo,
 		UpsertSignalRequestedIDs:      request.UpsertSignalRequestedIDs,
 		DeleteSignalRequestedID:       request.DeleteSignalRequestedID,
@@ -422,6 +459,10 @@ func (m *executionManagerImpl) SerializeUpsertActivityInfos(infos []*ActivityInf
 		if err != nil {
 			return nil, err
 		}
+		details, err := m.encrypt(v.Details)
+		if err != nil {
+			return nil, err
+		}
 		i := &InternalActivityInfo{
 			Version:                        v.Version,
 			ScheduleID:                     v.ScheduleID,
@@ -433,7 +474,7 @@ func (m *executionManagerImpl) SerializeUpsertActivityInfos(infos []*ActivityInf
 			StartedTime:                    v.StartedTime,
 			ActivityID:                     v.ActivityID,
 			RequestID:                      v.RequestID,
-			Details:                        v.Details,
+			Details:                        details,
 			ScheduleToStartTimeout:         v.ScheduleToStartTimeout,
 			ScheduleToCloseTimeout:         v.ScheduleToCloseTimeout,
 			StartToCloseTimeout:            v.StartToCloseTimeout,
@@ -453,6 +494,7 @@ func (m *executionManagerImpl) SerializeUpsertActivityInfos(infos []*ActivityInf
 			ExpirationTime:                 v.ExpirationTime,
 			MaximumAttempts:                v.MaximumAttempts,
 			NonRetriableErrors:             v.NonRetriableErrors,
+			Priority:                       v.Priority,
 			LastHeartbeatTimeoutVisibility: v.LastHeartbeatTimeoutVisibility,
 		}
 		newInfos = append(newInfos, i)
@@ -468,6 +510,10 @@ func (m *executionManagerImpl) SerializeExecutionInfo(info *WorkflowExecutionInf
 	if err != nil {
 		return nil, err
 	}
+	executionContext, err := m.encrypt(info.ExecutionContext)
+	if err != nil {
+		return nil, err
+	}
 
 	return &InternalWorkflowExecutionInfo{
 		DomainID:                     info.DomainID,
@@ -483,7 +529,7 @@ func (m *executionManagerImpl) SerializeExecutionInfo(info *WorkflowExecutionInf
 		WorkflowTypeName:             info.WorkflowTypeName,
 		WorkflowTimeout:              info.WorkflowTimeout,
 		DecisionTimeoutValue:         info.DecisionTimeoutValue,
-		ExecutionContext:             info.ExecutionContext,
+		ExecutionContext:             executionContext,
 		State:                        info.State,
 		CloseStatus:                  info.CloseStatus,
 		LastFirstEventID:             info.LastFirstEventID,
@@ -520,9 +566,68 @@ func (m *executionManagerImpl) SerializeExecutionInfo(info *WorkflowExecutionInf
 		BranchToken:                  info.BranchToken,
 		CronSchedule:                 info.CronSchedule,
 		ExpirationSeconds:            info.ExpirationSeconds,
+		Paused:                       info.Paused,
+		Priority:                     info.Priority,
+		BuildID:                      info.BuildID,
+		DecisionHeartbeatCount:       info.DecisionHeartbeatCount,
 	}, nil
 }
 
+// SerializeUpsertSignalInfos returns copies of the signal infos with their input and control encrypted
+func (m *executionManagerImpl) SerializeUpsertSignalInfos(infos []*SignalInfo) ([]*SignalInfo, error) {
+	newInfos := make([]*SignalInfo, 0, len(infos))
+	for _, v := range infos {
+		input, err := m.encrypt(v.Input)
+		if err != nil {
+			return nil, err
+		}
+		control, err := m.encrypt(v.Control)
+		if err != nil {
+			return nil, err
+		}
+		i := *v
+		i.Input = input
+		i.Control = control
+		newInfos = append(newInfos, &i)
+	}
+	return newInfos, nil
+}
+
+// DeserializeSignalInfos decrypts the input and control of the signal infos
+func (m *executionManagerImpl) DeserializeSignalInfos(infos map[int64]*SignalInfo) (map[int64]*SignalInfo, error) {
+	newInfos := make(map[int64]*SignalInfo, len(infos))
+	for k, v := range infos {
+		input, err := m.decrypt(v.Input)
+		if err != nil {
+			return nil, err
+		}
+		control, err := m.decrypt(v.Control)
+		if err != nil {
+			return nil, err
+		}
+		v.Input = input
+		v.Control = control
+		newInfos[k] = v
+	}
+	return newInfos, nil
+}
+
+func (m *executionManagerImpl) encrypt(data []byte) ([]byte, error) {
+	data, err := m.encryptor.Encrypt(data)
+	if err != nil {
+		return nil, NewHistorySerializationError(err.Error())
+	}
+	return data, nil
+}
+
+func (m *executionManagerImpl) decrypt(data []byte) ([]byte, error) {
+	data, err := m.encryptor.Decrypt(data)
+	if err != nil {
+		return nil, NewHistoryDeserializationError(err.Error())
+	}
+	return data, nil
+}
+
 func (m *executionManagerImpl) ResetMutableState(request *ResetMutableStateRequest) error {
 	executionInfo, err := m.SerializeExecutionInfo(request.ExecutionInfo, request.Encoding)
 	if err != nil {
@@ -536,6 +641,10 @@ func (m *executionManagerImpl) ResetMutableState(request *ResetMutableStateReque
 	if err != nil {
 		return err
 	}
+	insertSignalInfos, err := m.SerializeUpsertSignalInfos(request.InsertSignalInfos)
+	if err != nil {
+		return err
+	}
 
 	newRequest := &InternalResetMutableStateRequest{
 		PrevRunID:                 request.PrevRunID,
@@ -547,7 +656,7 @@ func (m *executionManagerImpl) ResetMutableState(request *ResetMutableStateReque
 		InsertTimerInfos:          request.InsertTimerInfos,
 		InsertChildExecutionInfos: insertChildExecutionInfos,
 		InsertRequestCancelInfos:  request.InsertRequestCancelInfos,
-		InsertSignalInfos:         request.InsertSignalInfos,
+		InsertSignalInfos:         insertSignalInfos,
 		InsertSignalRequestedIDs:  request.InsertSignalRequestedIDs,
 	}
 	return m.persistence.ResetMutableState(newRequest)
@@ -572,6 +681,10 @@ func (m *executionManagerImpl) ResetWorkflowExecution(request *ResetWorkflowExec
 	if err != nil {
 		return err
 	}
+	insertSignalInfos, err := m.SerializeUpsertSignalInfos(request.InsertSignalInfos)
+	if err != nil {
+		return err
+	}
 
 	newRequest := &InternalResetWorkflowExecutionRequest{
 		PrevRunVersion: request.PrevRunVersion,
@@ -598,23 +711,63 @@ func (m *executionManagerImpl) ResetWorkflowExecution(request *ResetWorkflowExec
 		InsertTimerInfos:          request.InsertTimerInfos,
 		InsertChildExecutionInfos: insertChildExecutionInfos,
 		InsertRequestCancelInfos:  request.InsertRequestCancelInfos,
-		InsertSignalInfos:         request.InsertSignalInfos,
+		InsertSignalInfos:         insertSignalInfos,
 		InsertSignalRequestedIDs:  request.InsertSignalRequestedIDs,
 	}
 	return m.persistence.ResetWorkflowExecution(newRequest)
 }
 
 func (m *executionManagerImpl) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
-	return m.persistence.CreateWorkflowExecution(request)
+	newRequest, err := m.SerializeCreateWorkflowExecutionRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	return m.persistence.CreateWorkflowExecution(newRequest)
+}
+
+// SerializeCreateWorkflowExecutionRequest returns a copy of the request with its execution context encrypted
+func (m *executionManagerImpl) SerializeCreateWorkflowExecutionRequest(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionRequest, error) {
+	if request == nil || len(request.ExecutionContext) == 0 {
+		return request, nil
+	}
+	executionContext, err := m.encrypt(request.ExecutionContext)
+	if err != nil {
+		return nil, err
+	}
+	newRequest := *request
+	newRequest.ExecutionContext = executionContext
+	return &newRequest, nil
 }
 func (m *executionManagerImpl) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
 	return m.persistence.DeleteWorkflowExecution(request)
 }
 
+func (m *executionManagerImpl) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
+	return m.persistence.DeleteCurrentWorkflowExecution(request)
+}
+
 func (m *executionManagerImpl) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
 	return m.persistence.GetCurrentExecution(request)
 }
 
+func (m *executionManagerImpl) ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*ListConcreteExecutionsResponse, error) {
+	response, err := m.persistence.ListConcreteExecutions(request)
+	if err != nil {
+		return nil, err
+	}
+	newResponse := &ListConcreteExecutionsResponse{
+		ExecutionInfos: make([]*WorkflowExecutionInfo, len(response.ExecutionInfos)),
+		PageToken:      response.NextPageToken,
+	}
+	for i, info := range response.ExecutionInfos {
+		newResponse.ExecutionInfos[i], err = m.DeserializeExecutionInfo(info)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newResponse, nil
+}
+
 // Transfer task related methods
 func (m *executionManagerImpl) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
 	return m.persistence.GetTransferTasks(request)
@@ -0,0 +1,139 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/encryption"
+)
+
+type (
+	executionStoreSuite struct {
+		suite.Suite
+		*require.Assertions
+	}
+
+	// recordingExecutionStore keeps the last mutable state written to it and returns it on read,
+	// calling any other method panics
+	recordingExecutionStore struct {
+		ExecutionStore
+		state *InternalWorkflowMutableState
+	}
+)
+
+func TestExecutionStoreSuite(t *testing.T) {
+	s := new(executionStoreSuite)
+	suite.Run(t, s)
+}
+
+func (s *executionStoreSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *executionStoreSuite) TestPayloadsEncryptedAtRest() {
+	store := &recordingExecutionStore{}
+	encryptor := encryption.NewAESEncryptor(&testKeyProvider{key: make([]byte, 32)})
+	manager := NewExecutionManagerImplWithEncryptor(store, encryptor, bark.NewNopLogger())
+
+	details := []byte("heartbeat details")
+	input := []byte("signal input")
+	control := []byte("signal control")
+	executionContext := []byte("execution context")
+	_, err := manager.UpdateWorkflowExecution(&UpdateWorkflowExecutionRequest{
+		ExecutionInfo: &WorkflowExecutionInfo{
+			DomainID:         "domain-id",
+			WorkflowID:       "workflow-id",
+			RunID:            "run-id",
+			ExecutionContext: executionContext,
+		},
+		UpsertActivityInfos: []*ActivityInfo{{ScheduleID: 5, Details: details}},
+		UpsertSignalInfos:   []*SignalInfo{{InitiatedID: 7, Input: input, Control: control}},
+		Encoding:            common.EncodingTypeThriftRW,
+	})
+	s.NoError(err)
+
+	stored := store.state
+	s.True(encryption.IsEncrypted(stored.ExecutionInfo.ExecutionContext))
+	s.True(encryption.IsEncrypted(stored.ActivitInfos[5].Details))
+	s.True(encryption.IsEncrypted(stored.SignalInfos[7].Input))
+	s.True(encryption.IsEncrypted(stored.SignalInfos[7].Control))
+	for _, payload := range [][]byte{details, input, control, executionContext} {
+		s.False(bytes.Contains(stored.ExecutionInfo.ExecutionContext, payload))
+		s.False(bytes.Contains(stored.ActivitInfos[5].Details, payload))
+		s.False(bytes.Contains(stored.SignalInfos[7].Input, payload))
+		s.False(bytes.Contains(stored.SignalInfos[7].Control, payload))
+	}
+
+	response, err := manager.GetWorkflowExecution(&GetWorkflowExecutionRequest{})
+	s.NoError(err)
+	s.Equal(executionContext, response.State.ExecutionInfo.ExecutionContext)
+	s.Equal(details, response.State.ActivityInfos[5].Details)
+	s.Equal(input, response.State.SignalInfos[7].Input)
+	s.Equal(control, response.State.SignalInfos[7].Control)
+
+	// payloads written before encryption was enabled are still readable
+	store.state.ActivitInfos[5].Details = details
+	store.state.SignalInfos[7].Input = input
+	store.state.ExecutionInfo.ExecutionContext = executionContext
+	response, err = manager.GetWorkflowExecution(&GetWorkflowExecutionRequest{})
+	s.NoError(err)
+	s.Equal(executionContext, response.State.ExecutionInfo.ExecutionContext)
+	s.Equal(details, response.State.ActivityInfos[5].Details)
+	s.Equal(input, response.State.SignalInfos[7].Input)
+}
+
+func (s *recordingExecutionStore) UpdateWorkflowExecution(request *InternalUpdateWorkflowExecutionRequest) error {
+	s.state = &InternalWorkflowMutableState{
+		ExecutionInfo: request.ExecutionInfo,
+		ActivitInfos:  make(map[int64]*InternalActivityInfo),
+		SignalInfos:   make(map[int64]*SignalInfo),
+	}
+	for _, ai := range request.UpsertActivityInfos {
+		s.state.ActivitInfos[ai.ScheduleID] = ai
+	}
+	for _, si := range request.UpsertSignalInfos {
+		s.state.SignalInfos[si.InitiatedID] = si
+	}
+	return nil
+}
+
+func (s *recordingExecutionStore) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*InternalGetWorkflowExecutionResponse, error) {
+	state := *s.state
+	executionInfo := *state.ExecutionInfo
+	state.ExecutionInfo = &executionInfo
+	state.ActivitInfos = make(map[int64]*InternalActivityInfo)
+	for k, v := range s.state.ActivitInfos {
+		ai := *v
+		state.ActivitInfos[k] = &ai
+	}
+	state.SignalInfos = make(map[int64]*SignalInfo)
+	for k, v := range s.state.SignalInfos {
+		si := *v
+		state.SignalInfos[k] = &si
+	}
+	return &InternalGetWorkflowExecutionResponse{State: &state}, nil
+}
@@ -21,12 +21,17 @@
 package persistence
 
 import (
+	"bytes"
+	"compress/gzip"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 
+	"github.com/golang/snappy"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/codec"
+	"github.com/uber/cadence/common/encryption"
 )
 
 type (
@@ -63,13 +68,21 @@ type (
 
 	serializerImpl struct {
 		thriftrwEncoder codec.BinaryEncoder
+		encryptor       encryption.Encryptor
 	}
 )
 
 // NewHistorySerializer returns a HistorySerializer
 func NewHistorySerializer() HistorySerializer {
+	return NewHistorySerializerWithEncryptor(encryption.NewNoopEncryptor())
+}
+
+// NewHistorySerializerWithEncryptor returns a HistorySerializer which encrypts the serialized
+// events with the given encryptor, and decrypts them transparently on deserialization
+func NewHistorySerializerWithEncryptor(encryptor encryption.Encryptor) HistorySerializer {
 	return &serializerImpl{
 		thriftrwEncoder: codec.NewThriftRWEncoder(),
+		encryptor:       encryptor,
 	}
 }
 
@@ -79,7 +92,7 @@ func (t *serializerImpl) SerializeBatchEvents(events []*workflow.HistoryEvent, e
 	switch encodingType {
 	case common.EncodingTypeGob:
 		return nil, NewUnknownEncodingTypeError(encodingType)
-	case common.EncodingTypeThriftRW:
+	case common.EncodingTypeThriftRW, common.EncodingTypeThriftRWSnappy, common.EncodingTypeThriftRWGzip:
 		history := &workflow.History{
 			Events: batch.Events,
 		}
@@ -87,7 +100,11 @@ func (t *serializerImpl) SerializeBatchEvents(events []*workflow.HistoryEvent, e
 		if err != nil {
 			return nil, NewHistorySerializationError(err.Error())
 		}
-		return NewDataBlob(data, encodingType), nil
+		data, err = compress(data, encodingType)
+		if err != nil {
+			return nil, NewHistorySerializationError(err.Error())
+		}
+		return t.newDataBlob(data, encodingType)
 	default:
 		fallthrough
 	case common.EncodingTypeJSON:
@@ -95,7 +112,7 @@ func (t *serializerImpl) SerializeBatchEvents(events []*workflow.HistoryEvent, e
 		if err != nil {
 			return nil, NewHistorySerializationError(err.Error())
 		}
-		return NewDataBlob(data, common.EncodingTypeJSON), nil
+		return t.newDataBlob(data, common.EncodingTypeJSON)
 	}
 }
 
@@ -103,6 +120,10 @@ func (t *serializerImpl) DeserializeBatchEvents(data *DataBlob) ([]*workflow.His
 	if data == nil {
 		return nil, nil
 	}
+	data, err := t.decrypt(data)
+	if err != nil {
+		return nil, err
+	}
 	switch data.GetEncoding() {
 	//As backward-compatibility, unknown should be json
 	case common.EncodingTypeUnknown:
@@ -117,9 +138,13 @@ func (t *serializerImpl) DeserializeBatchEvents(data *DataBlob) ([]*workflow.His
 			return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeBatchEvents encoding: \"%v\", error: %v", data.Encoding, err.Error()))
 		}
 		return events, nil
-	case common.EncodingTypeThriftRW:
+	case common.EncodingTypeThriftRW, common.EncodingTypeThriftRWSnappy, common.EncodingTypeThriftRWGzip:
+		payload, err := decompress(data.Data, data.GetEncoding())
+		if err != nil {
+			return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeBatchEvents encoding: \"%v\", error: %v", data.Encoding, err.Error()))
+		}
 		var history workflow.History
-		err := t.thriftrwEncoder.Decode(data.Data, &history)
+		err = t.thriftrwEncoder.Decode(payload, &history)
 		if err != nil {
 			return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeBatchEvents encoding: \"%v\", error: %v", data.Encoding, err.Error()))
 		}
@@ -136,12 +161,16 @@ func (t *serializerImpl) SerializeEvent(event *workflow.HistoryEvent, encodingTy
 	switch encodingType {
 	case common.EncodingTypeGob:
 		return nil, NewUnknownEncodingTypeError(encodingType)
-	case common.EncodingTypeThriftRW:
+	case common.EncodingTypeThriftRW, common.EncodingTypeThriftRWSnappy, common.EncodingTypeThriftRWGzip:
 		data, err := t.thriftrwEncoder.Encode(event)
 		if err != nil {
 			return nil, NewHistorySerializationError(err.Error())
 		}
-		return NewDataBlob(data, encodingType), nil
+		data, err = compress(data, encodingType)
+		if err != nil {
+			return nil, NewHistorySerializationError(err.Error())
+		}
+		return t.newDataBlob(data, encodingType)
 	default:
 		fallthrough
 	case common.EncodingTypeJSON:
@@ -149,7 +178,7 @@ func (t *serializerImpl) SerializeEvent(event *workflow.HistoryEvent, encodingTy
 		if err != nil {
 			return nil, NewHistorySerializationError(err.Error())
 		}
-		return NewDataBlob(data, common.EncodingTypeJSON), nil
+		return t.newDataBlob(data, common.EncodingTypeJSON)
 	}
 }
 
@@ -160,6 +189,10 @@ func (t *serializerImpl) DeserializeEvent(data *DataBlob) (*workflow.HistoryEven
 	if len(data.Data) == 0 {
 		return nil, NewHistoryDeserializationError("DeserializeEvent empty data")
 	}
+	data, err := t.decrypt(data)
+	if err != nil {
+		return nil, err
+	}
 	var event workflow.HistoryEvent
 	switch data.GetEncoding() {
 	//As backward-compatibility, unknown should be json
@@ -171,8 +204,12 @@ func (t *serializerImpl) DeserializeEvent(data *DataBlob) (*workflow.HistoryEven
 			return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeEvent encoding: \"%v\", error: %v", data.Encoding, err.Error()))
 		}
 		return &event, nil
-	case common.EncodingTypeThriftRW:
-		err := t.thriftrwEncoder.Decode(data.Data, &event)
+	case common.EncodingTypeThriftRW, common.EncodingTypeThriftRWSnappy, common.EncodingTypeThriftRWGzip:
+		payload, err := decompress(data.Data, data.GetEncoding())
+		if err != nil {
+			return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeEvent encoding: \"%v\", error: %v", data.Encoding, err.Error()))
+		}
+		err = t.thriftrwEncoder.Decode(payload, &event)
 		if err != nil {
 			return nil, NewHistoryDeserializationError(fmt.Sprintf("DeserializeEvent encoding: \"%v\", error: %v", data.Encoding, err.Error()))
 		}
@@ -182,6 +219,65 @@ func (t *serializerImpl) DeserializeEvent(data *DataBlob) (*workflow.HistoryEven
 	}
 }
 
+func (t *serializerImpl) newDataBlob(data []byte, encodingType common.EncodingType) (*DataBlob, error) {
+	data, err := t.encryptor.Encrypt(data)
+	if err != nil {
+		return nil, NewHistorySerializationError(err.Error())
+	}
+	return NewDataBlob(data, encodingType), nil
+}
+
+// decrypt returns a copy of the blob holding the decrypted data, blobs that were
+// written before encryption was enabled are returned as is
+func (t *serializerImpl) decrypt(data *DataBlob) (*DataBlob, error) {
+	if !encryption.IsEnvelope(data.Data) {
+		return data, nil
+	}
+	plaintext, err := t.encryptor.Decrypt(data.Data)
+	if err != nil {
+		return nil, NewHistoryDeserializationError(err.Error())
+	}
+	return NewDataBlob(plaintext, data.GetEncoding()), nil
+}
+
+// compress compresses the thriftrw encoded data according to the encoding type,
+// data of uncompressed encoding types is returned as is
+func compress(data []byte, encodingType common.EncodingType) ([]byte, error) {
+	switch encodingType {
+	case common.EncodingTypeThriftRWSnappy:
+		return snappy.Encode(nil, data), nil
+	case common.EncodingTypeThriftRWGzip:
+		var buf bytes.Buffer
+		writer := gzip.NewWriter(&buf)
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	default:
+		return data, nil
+	}
+}
+
+// decompress reverts compress
+func decompress(data []byte, encodingType common.EncodingType) ([]byte, error) {
+	switch encodingType {
+	case common.EncodingTypeThriftRWSnappy:
+		return snappy.Decode(nil, data)
+	case common.EncodingTypeThriftRWGzip:
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return ioutil.ReadAll(reader)
+	default:
+		return data, nil
+	}
+}
+
 // NewUnknownEncodingTypeError returns a new instance of encoding type error
 func NewUnknownEncodingTypeError(encodingType common.EncodingType) error {
 	return &UnknownEncodingTypeError{encodingType: encodingType}
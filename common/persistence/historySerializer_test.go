@@ -21,6 +21,7 @@
 package persistence
 
 import (
+	"fmt"
 	"sync"
 	"testing"
 	"time"
@@ -31,6 +32,7 @@ import (
 	"github.com/uber-common/bark"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/encryption"
 )
 
 type (
@@ -41,6 +43,10 @@ type (
 		*require.Assertions
 		logger bark.Logger
 	}
+
+	testKeyProvider struct {
+		key []byte
+	}
 )
 
 func TestHistoryBuilderSuite(t *testing.T) {
@@ -153,3 +159,233 @@ func (s *historySerializerSuite) TestSerializer() {
 	succ := common.AwaitWaitGroup(&doneWG, 10*time.Second)
 	s.True(succ, "test timed out")
 }
+
+func (s *historySerializerSuite) TestSerializerCompressedEncodings() {
+	serializer := NewHistorySerializer()
+	events0 := newTestHistoryEvents(20)
+
+	dPlain, err := serializer.SerializeBatchEvents(events0, common.EncodingTypeThriftRW)
+	s.Nil(err)
+
+	for _, encodingType := range []common.EncodingType{common.EncodingTypeThriftRWSnappy, common.EncodingTypeThriftRWGzip} {
+		dBatch, err := serializer.SerializeBatchEvents(events0, encodingType)
+		s.Nil(err)
+		s.Equal(encodingType, dBatch.GetEncoding())
+		s.True(len(dBatch.Data) < len(dPlain.Data))
+
+		events1, err := serializer.DeserializeBatchEvents(dBatch)
+		s.Nil(err)
+		s.Equal(len(events0), len(events1))
+		for i := range events0 {
+			s.True(events0[i].Equals(events1[i]))
+		}
+
+		dEvent, err := serializer.SerializeEvent(events0[0], encodingType)
+		s.Nil(err)
+		s.Equal(encodingType, dEvent.GetEncoding())
+
+		event1, err := serializer.DeserializeEvent(dEvent)
+		s.Nil(err)
+		s.True(events0[0].Equals(event1))
+	}
+}
+
+func (s *historySerializerSuite) TestSerializerWithEncryption() {
+	plainSerializer := NewHistorySerializer()
+	serializer := NewHistorySerializerWithEncryptor(encryption.NewAESEncryptor(&testKeyProvider{key: make([]byte, 32)}))
+
+	event0 := &workflow.HistoryEvent{
+		EventId:   common.Int64Ptr(999),
+		Timestamp: common.Int64Ptr(time.Now().UnixNano()),
+		EventType: common.EventTypePtr(workflow.EventTypeActivityTaskCompleted),
+		ActivityTaskCompletedEventAttributes: &workflow.ActivityTaskCompletedEventAttributes{
+			Result:           []byte("result-1-event-1"),
+			ScheduledEventId: common.Int64Ptr(4),
+			StartedEventId:   common.Int64Ptr(5),
+			Identity:         common.StringPtr("event-1"),
+		},
+	}
+	events0 := []*workflow.HistoryEvent{event0, event0}
+
+	for _, encodingType := range []common.EncodingType{common.EncodingTypeJSON, common.EncodingTypeThriftRW} {
+		dEvent, err := serializer.SerializeEvent(event0, encodingType)
+		s.Nil(err)
+		s.True(encryption.IsEncrypted(dEvent.Data))
+		s.Equal(encodingType, dEvent.GetEncoding())
+
+		event1, err := serializer.DeserializeEvent(dEvent)
+		s.Nil(err)
+		s.True(event0.Equals(event1))
+
+		// encrypted blobs cannot be read without a key
+		_, err = plainSerializer.DeserializeEvent(dEvent)
+		s.NotNil(err)
+
+		dBatch, err := serializer.SerializeBatchEvents(events0, encodingType)
+		s.Nil(err)
+		s.True(encryption.IsEncrypted(dBatch.Data))
+
+		events1, err := serializer.DeserializeBatchEvents(dBatch)
+		s.Nil(err)
+		s.Equal(len(events0), len(events1))
+		for i := range events0 {
+			s.True(events0[i].Equals(events1[i]))
+		}
+
+		// blobs written before encryption was enabled are still readable
+		dLegacy, err := plainSerializer.SerializeBatchEvents(events0, encodingType)
+		s.Nil(err)
+		s.False(encryption.IsEncrypted(dLegacy.Data))
+
+		events2, err := serializer.DeserializeBatchEvents(dLegacy)
+		s.Nil(err)
+		s.Equal(len(events0), len(events2))
+	}
+}
+
+func (p *testKeyProvider) CurrentKeyID() string {
+	return "test-key"
+}
+
+func (p *testKeyProvider) GetKey(keyID string) ([]byte, error) {
+	if keyID != "test-key" {
+		return nil, &encryption.UnknownKeyError{KeyID: keyID}
+	}
+	return p.key, nil
+}
+
+func BenchmarkSerializeBatchEvents(b *testing.B) {
+	serializer := NewHistorySerializer()
+	for _, batchSize := range []int{1, 10, 100} {
+		events := newTestHistoryEvents(batchSize)
+		for _, encodingType := range benchmarkEncodingTypes {
+			b.Run(fmt.Sprintf("%v/%v", encodingType, batchSize), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					if _, err := serializer.SerializeBatchEvents(events, encodingType); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+func BenchmarkDeserializeBatchEvents(b *testing.B) {
+	serializer := NewHistorySerializer()
+	for _, batchSize := range []int{1, 10, 100} {
+		events := newTestHistoryEvents(batchSize)
+		for _, encodingType := range benchmarkEncodingTypes {
+			blob, err := serializer.SerializeBatchEvents(events, encodingType)
+			if err != nil {
+				b.Fatal(err)
+			}
+			b.Run(fmt.Sprintf("%v/%v", encodingType, batchSize), func(b *testing.B) {
+				b.ReportAllocs()
+				b.SetBytes(int64(len(blob.Data)))
+				for i := 0; i < b.N; i++ {
+					if _, err := serializer.DeserializeBatchEvents(blob); err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+var benchmarkEncodingTypes = []common.EncodingType{
+	common.EncodingTypeJSON,
+	common.EncodingTypeThriftRW,
+	common.EncodingTypeThriftRWSnappy,
+	common.EncodingTypeThriftRWGzip,
+}
+
+// newTestHistoryEvents returns a history resembling a workflow which runs activities one after another,
+// starting with the workflow started event followed by repeated decision and activity events
+func newTestHistoryEvents(size int) []*workflow.HistoryEvent {
+	taskList := &workflow.TaskList{Name: common.StringPtr("sample-workflow-tasklist")}
+	identity := common.StringPtr("71634@worker-host-0042.prod@sample-workflow-tasklist")
+	payload := []byte(`{"customerId":"1b7f6e3e-4a3c-4b5e-9a1d-0c1f3f5e6a7b","orderId":"order-7d3f0c","items":[{"sku":"sku-1","quantity":2},{"sku":"sku-2","quantity":1}]}`)
+	timestamp := time.Now().UnixNano()
+
+	events := make([]*workflow.HistoryEvent, 0, size)
+	newEvent := func(eventType workflow.EventType) *workflow.HistoryEvent {
+		event := &workflow.HistoryEvent{
+			EventId:   common.Int64Ptr(int64(len(events) + 1)),
+			Timestamp: common.Int64Ptr(timestamp + int64(len(events))*int64(time.Millisecond)),
+			EventType: common.EventTypePtr(eventType),
+			Version:   common.Int64Ptr(common.EmptyVersion),
+		}
+		events = append(events, event)
+		return event
+	}
+
+	event := newEvent(workflow.EventTypeWorkflowExecutionStarted)
+	event.WorkflowExecutionStartedEventAttributes = &workflow.WorkflowExecutionStartedEventAttributes{
+		WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr("sample-order-workflow")},
+		TaskList:                            taskList,
+		Input:                               payload,
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(3600),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(10),
+		Identity:                            identity,
+	}
+
+	for i := 0; len(events) < size; i++ {
+		event = newEvent(workflow.EventTypeDecisionTaskScheduled)
+		event.DecisionTaskScheduledEventAttributes = &workflow.DecisionTaskScheduledEventAttributes{
+			TaskList:                   taskList,
+			StartToCloseTimeoutSeconds: common.Int32Ptr(10),
+			Attempt:                    common.Int64Ptr(0),
+		}
+		decisionScheduledID := event.GetEventId()
+
+		event = newEvent(workflow.EventTypeDecisionTaskStarted)
+		event.DecisionTaskStartedEventAttributes = &workflow.DecisionTaskStartedEventAttributes{
+			ScheduledEventId: common.Int64Ptr(decisionScheduledID),
+			Identity:         identity,
+			RequestId:        common.StringPtr("b2e9b3c4-5a8d-4c1e-8e0f-3d4c5b6a7f80"),
+		}
+		decisionStartedID := event.GetEventId()
+
+		event = newEvent(workflow.EventTypeDecisionTaskCompleted)
+		event.DecisionTaskCompletedEventAttributes = &workflow.DecisionTaskCompletedEventAttributes{
+			ScheduledEventId: common.Int64Ptr(decisionScheduledID),
+			StartedEventId:   common.Int64Ptr(decisionStartedID),
+			Identity:         identity,
+			BinaryChecksum:   common.StringPtr("3b1f7e2c9d4a5b6c"),
+		}
+		decisionCompletedID := event.GetEventId()
+
+		event = newEvent(workflow.EventTypeActivityTaskScheduled)
+		event.ActivityTaskScheduledEventAttributes = &workflow.ActivityTaskScheduledEventAttributes{
+			ActivityId:                    common.StringPtr(fmt.Sprintf("%v", i)),
+			ActivityType:                  &workflow.ActivityType{Name: common.StringPtr("sample-order-activity")},
+			TaskList:                      taskList,
+			Input:                         payload,
+			ScheduleToCloseTimeoutSeconds: common.Int32Ptr(120),
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(60),
+			StartToCloseTimeoutSeconds:    common.Int32Ptr(60),
+			HeartbeatTimeoutSeconds:       common.Int32Ptr(20),
+			DecisionTaskCompletedEventId:  common.Int64Ptr(decisionCompletedID),
+		}
+		activityScheduledID := event.GetEventId()
+
+		event = newEvent(workflow.EventTypeActivityTaskStarted)
+		event.ActivityTaskStartedEventAttributes = &workflow.ActivityTaskStartedEventAttributes{
+			ScheduledEventId: common.Int64Ptr(activityScheduledID),
+			Identity:         identity,
+			RequestId:        common.StringPtr("c7d8e9f0-1a2b-4c3d-8e4f-5a6b7c8d9e0f"),
+			Attempt:          common.Int32Ptr(0),
+		}
+		activityStartedID := event.GetEventId()
+
+		event = newEvent(workflow.EventTypeActivityTaskCompleted)
+		event.ActivityTaskCompletedEventAttributes = &workflow.ActivityTaskCompletedEventAttributes{
+			Result:           payload,
+			ScheduledEventId: common.Int64Ptr(activityScheduledID),
+			StartedEventId:   common.Int64Ptr(activityStartedID),
+			Identity:         identity,
+		}
+	}
+	return events[:size]
+}
@@ -27,6 +27,7 @@ import (
 	"github.com/uber-common/bark"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/encryption"
 	"github.com/uber/cadence/common/logging"
 )
 
@@ -51,8 +52,13 @@ var _ HistoryManager = (*historyManagerImpl)(nil)
 
 //NewHistoryManagerImpl returns new HistoryManager
 func NewHistoryManagerImpl(persistence HistoryStore, logger bark.Logger) HistoryManager {
+	return NewHistoryManagerImplWithEncryptor(persistence, encryption.NewNoopEncryptor(), logger)
+}
+
+//NewHistoryManagerImplWithEncryptor returns new HistoryManager which encrypts the history events at rest
+func NewHistoryManagerImplWithEncryptor(persistence HistoryStore, encryptor encryption.Encryptor, logger bark.Logger) HistoryManager {
 	return &historyManagerImpl{
-		serializer:  NewHistorySerializer(),
+		serializer:  NewHistorySerializerWithEncryptor(encryptor),
 		persistence: persistence,
 		logger:      logger,
 	}
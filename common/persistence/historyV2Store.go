@@ -28,6 +28,7 @@ import (
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/codec"
+	"github.com/uber/cadence/common/encryption"
 	"github.com/uber/cadence/common/logging"
 )
 
@@ -47,8 +48,13 @@ var _ HistoryV2Manager = (*historyV2ManagerImpl)(nil)
 
 //NewHistoryV2ManagerImpl returns new HistoryManager
 func NewHistoryV2ManagerImpl(persistence HistoryV2Store, logger bark.Logger) HistoryV2Manager {
+	return NewHistoryV2ManagerImplWithEncryptor(persistence, encryption.NewNoopEncryptor(), logger)
+}
+
+//NewHistoryV2ManagerImplWithEncryptor returns new HistoryManager which encrypts the history events at rest
+func NewHistoryV2ManagerImplWithEncryptor(persistence HistoryV2Store, encryptor encryption.Encryptor, logger bark.Logger) HistoryV2Manager {
 	return &historyV2ManagerImpl{
-		historySerializer:     NewHistorySerializer(),
+		historySerializer:     NewHistorySerializerWithEncryptor(encryptor),
 		persistence:           persistence,
 		logger:                logger,
 		thrifteEncoder:        codec.NewThriftRWEncoder(),
@@ -140,6 +146,40 @@ func (m *historyV2ManagerImpl) GetHistoryTree(request *GetHistoryTreeRequest) (*
 	return m.persistence.GetHistoryTree(request)
 }
 
+// GetAllHistoryTreeBranches returns all branches of all trees, page by page
+func (m *historyV2ManagerImpl) GetAllHistoryTreeBranches(request *GetAllHistoryTreeBranchesRequest) (*GetAllHistoryTreeBranchesResponse, error) {
+	if request.PageSize <= 0 {
+		return nil, &InvalidPersistenceRequestError{
+			Msg: fmt.Sprintf("PageSize must be > 0"),
+		}
+	}
+
+	resp, err := m.persistence.GetAllHistoryTreeBranches(request)
+	if err != nil {
+		return nil, err
+	}
+
+	branches := make([]HistoryBranchDetail, 0, len(resp.Branches))
+	for _, b := range resp.Branches {
+		token, err := m.thrifteEncoder.Encode(&b.BranchInfo)
+		if err != nil {
+			return nil, err
+		}
+		branches = append(branches, HistoryBranchDetail{
+			TreeID:      b.BranchInfo.GetTreeID(),
+			BranchID:    b.BranchInfo.GetBranchID(),
+			BranchToken: token,
+			ForkTime:    b.ForkTime,
+			Info:        b.Info,
+		})
+	}
+
+	return &GetAllHistoryTreeBranchesResponse{
+		NextPageToken: resp.NextPageToken,
+		Branches:      branches,
+	}, nil
+}
+
 // AppendHistoryNodes add(or override) a node to a history branch
 func (m *historyV2ManagerImpl) AppendHistoryNodes(request *AppendHistoryNodesRequest) (*AppendHistoryNodesResponse, error) {
 	var branch workflow.HistoryBranch
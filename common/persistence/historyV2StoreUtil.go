@@ -21,9 +21,12 @@
 package persistence
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/uber-common/bark"
 	"github.com/uber/cadence/.gen/go/shared"
-	"time"
 )
 
 /*
@@ -137,3 +140,20 @@ func ReadFullPageV2Events(historyV2Mgr HistoryV2Manager, req *ReadHistoryBranchR
 		req.NextPageToken = response.NextPageToken
 	}
 }
+
+// BuildHistoryGarbageCleanupInfo combines the workflow identity into the info stored with a new history branch,
+// it is used to find the workflow of the branch when cleaning up history garbage
+func BuildHistoryGarbageCleanupInfo(domainID, workflowID, runID string) string {
+	return fmt.Sprintf("%v:%v:%v", domainID, workflowID, runID)
+}
+
+// SplitHistoryGarbageCleanupInfo returns the workflow identity from the info stored with a history branch
+func SplitHistoryGarbageCleanupInfo(info string) (domainID, workflowID, runID string, err error) {
+	// workflowID can contain ":", domainID and runID are UUIDs so split by the first and the last ":" only
+	first := strings.Index(info, ":")
+	last := strings.LastIndex(info, ":")
+	if first <= 0 || first == last || last == len(info)-1 {
+		return "", "", "", fmt.Errorf("unable to split history garbage cleanup info: %v", info)
+	}
+	return info[:first], info[first+1 : last], info[last+1:], nil
+}
@@ -0,0 +1,66 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	historyV2StoreUtilSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+	}
+)
+
+func TestHistoryV2StoreUtilSuite(t *testing.T) {
+	s := new(historyV2StoreUtilSuite)
+	suite.Run(t, s)
+}
+
+func (s *historyV2StoreUtilSuite) SetupTest() {
+	// Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+	s.Assertions = require.New(s.T())
+}
+
+func (s *historyV2StoreUtilSuite) TestHistoryGarbageCleanupInfo() {
+	domainID := "1f9a3d2c-0000-4000-8000-000000000001"
+	runID := "7c2e4b1a-0000-4000-8000-000000000002"
+
+	for _, workflowID := range []string{"wid", "wid:with:colons", ":"} {
+		info := BuildHistoryGarbageCleanupInfo(domainID, workflowID, runID)
+		d, w, r, err := SplitHistoryGarbageCleanupInfo(info)
+		s.Nil(err)
+		s.Equal(domainID, d)
+		s.Equal(workflowID, w)
+		s.Equal(runID, r)
+	}
+
+	for _, info := range []string{"", "abc", "abc:def", ":wid:run", "domain:wid:"} {
+		_, _, _, err := SplitHistoryGarbageCleanupInfo(info)
+		s.NotNil(err)
+	}
+}
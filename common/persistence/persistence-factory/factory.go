@@ -25,6 +25,8 @@ import (
 
 	"github.com/uber-common/bark"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/encryption"
 	"github.com/uber/cadence/common/metrics"
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/persistence/cassandra"
@@ -90,6 +92,8 @@ type (
 		metricsClient metrics.Client
 		logger        bark.Logger
 		datastores    map[storeType]Datastore
+		encryptor     encryption.Encryptor
+		blobstore     blobstore.Client
 	}
 
 	storeType int
@@ -125,9 +129,14 @@ var storeTypes = []storeType{
 // also contains config for individual datastores themselves.
 //
 // The objects returned by this factory enforce ratelimit and maxconns according to
-// given configuration. In addition, all objects will emit metrics automatically
+// given configuration. In addition, all objects will emit metrics automatically.
+// When encryption is enabled, history events are encrypted at rest with the keys
+// from the configured key file. When payload offload is enabled, large payloads of
+// history events and mutable state are written to the given blobstore client, they
+// are encrypted with the same keys when encryption is enabled
 func New(
 	cfg *config.Persistence,
+	blobstoreClient blobstore.Client,
 	clusterName string,
 	metricsClient metrics.Client,
 	logger bark.Logger) Factory {
@@ -135,6 +144,10 @@ func New(
 		config:        cfg,
 		metricsClient: metricsClient,
 		logger:        logger,
+		blobstore:     blobstoreClient,
+	}
+	if cfg.PayloadOffload.Enabled && blobstoreClient == nil {
+		logger.Fatal("payload offload is enabled but blobstore is not configured")
 	}
 	defaultCfg := cfg.DataStores[cfg.DefaultStore]
 	visibilityCfg := cfg.DataStores[cfg.VisibilityStore]
@@ -147,6 +160,7 @@ func New(
 		storeTypeHistory:    newStore(defaultCfg, limiters[cfg.DefaultStore], clusterName, cfg.HistoryMaxConns, logger),
 		storeTypeVisibility: newStore(visibilityCfg, limiters[cfg.VisibilityStore], clusterName, 0, logger),
 	}
+	factory.encryptor = newEncryptor(cfg.Encryption, logger)
 	return factory
 }
 
@@ -190,7 +204,11 @@ func (f *factoryImpl) NewHistoryManager() (p.HistoryManager, error) {
 	if err != nil {
 		return nil, err
 	}
-	result := p.NewHistoryManagerImpl(store, f.logger)
+	result := p.NewHistoryManagerImplWithEncryptor(store, f.encryptor, f.logger)
+	if f.config.PayloadOffload.Enabled {
+		result = p.NewHistoryPersistencePayloadOffloadClient(
+			result, f.blobstore, f.config.PayloadOffload.Bucket, f.config.PayloadOffload.ThresholdBytes, f.encryptor, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewHistoryPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -207,7 +225,11 @@ func (f *factoryImpl) NewHistoryV2Manager() (p.HistoryV2Manager, error) {
 	if err != nil {
 		return nil, err
 	}
-	result := p.NewHistoryV2ManagerImpl(store, f.logger)
+	result := p.NewHistoryV2ManagerImplWithEncryptor(store, f.encryptor, f.logger)
+	if f.config.PayloadOffload.Enabled {
+		result = p.NewHistoryV2PersistencePayloadOffloadClient(
+			result, f.blobstore, f.config.PayloadOffload.Bucket, f.config.PayloadOffload.ThresholdBytes, f.encryptor, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewHistoryV2PersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -252,7 +274,11 @@ func (f *factoryImpl) NewExecutionManager(shardID int) (p.ExecutionManager, erro
 	if err != nil {
 		return nil, err
 	}
-	result := p.NewExecutionManagerImpl(store, f.logger)
+	result := p.NewExecutionManagerImplWithEncryptor(store, f.encryptor, f.logger)
+	if f.config.PayloadOffload.Enabled {
+		result = p.NewWorkflowExecutionPersistencePayloadOffloadClient(
+			result, f.blobstore, f.config.PayloadOffload.Bucket, f.config.PayloadOffload.ThresholdBytes, f.encryptor, f.logger)
+	}
 	if ds.ratelimit != nil {
 		result = p.NewWorkflowExecutionPersistenceRateLimitedClient(result, ds.ratelimit, f.logger)
 	}
@@ -312,6 +338,17 @@ func newStore(cfg config.DataStore, tb common.TokenBucket, clusterName string, m
 	return ds
 }
 
+func newEncryptor(cfg encryption.Config, logger bark.Logger) encryption.Encryptor {
+	if !cfg.Enabled {
+		return encryption.NewNoopEncryptor()
+	}
+	keyProvider, err := encryption.NewFileKeyProvider(&cfg)
+	if err != nil {
+		logger.Fatalf("failed to load encryption keys: %v", err)
+	}
+	return encryption.NewAESEncryptor(keyProvider)
+}
+
 func newSQLStore(cfg config.SQL, clusterName string, maxConnsOverride int, logger bark.Logger) DataStoreFactory {
 	if maxConnsOverride > 0 {
 		cfg.MaxConns = maxConnsOverride
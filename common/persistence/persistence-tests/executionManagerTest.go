@@ -957,6 +957,85 @@ func (s *ExecutionManagerSuite) TestGetCurrentWorkflow() {
 	s.Empty(task1, "Expected empty task identifier.")
 }
 
+// TestDeleteCurrentWorkflowExecution test
+func (s *ExecutionManagerSuite) TestDeleteCurrentWorkflowExecution() {
+	domainID := "1a6d4a0f-5ae4-4bc6-8f3f-1b3ef8c9fd9d"
+	workflowExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("delete-current-workflow-execution-test"),
+		RunId:      common.StringPtr("f0e0b7b4-2b0a-4d3f-9a2e-6f1d4b3e8a11"),
+	}
+
+	task0, err0 := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+	s.NoError(err0)
+	s.NotNil(task0, "Expected non empty task identifier.")
+
+	// current row points at a different run, so nothing should be deleted
+	err1 := s.ExecutionManager.DeleteCurrentWorkflowExecution(&p.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: *workflowExecution.WorkflowId,
+		RunID:      "6a1e3f1c-7c4e-4d2b-b9b5-5d6f0c2a9e77",
+	})
+	s.NoError(err1)
+	runID0, err2 := s.GetCurrentWorkflowRunID(domainID, *workflowExecution.WorkflowId)
+	s.NoError(err2)
+	s.Equal(*workflowExecution.RunId, runID0)
+
+	err3 := s.ExecutionManager.DeleteCurrentWorkflowExecution(&p.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   domainID,
+		WorkflowID: *workflowExecution.WorkflowId,
+		RunID:      *workflowExecution.RunId,
+	})
+	s.NoError(err3)
+	_, err4 := s.GetCurrentWorkflowRunID(domainID, *workflowExecution.WorkflowId)
+	s.Error(err4)
+	_, ok := err4.(*gen.EntityNotExistsError)
+	s.True(ok)
+
+	// execution record should still be there
+	_, err5 := s.GetWorkflowExecutionInfo(domainID, workflowExecution)
+	s.NoError(err5)
+}
+
+// TestListConcreteExecutions test
+func (s *ExecutionManagerSuite) TestListConcreteExecutions() {
+	domainID := "8f1c6e0a-3f5b-4a8e-9a55-2f6d9c1e7b42"
+	expected := make(map[string]bool)
+	for i := 0; i < 5; i++ {
+		workflowExecution := gen.WorkflowExecution{
+			WorkflowId: common.StringPtr(fmt.Sprintf("list-concrete-executions-test-%v", i)),
+			RunId:      common.StringPtr(uuid.New()),
+		}
+		task, err := s.CreateWorkflowExecution(domainID, workflowExecution, "queue1", "wType", 20, 13, nil, 3, 0, 2, nil)
+		s.NoError(err)
+		s.NotNil(task, "Expected non empty task identifier.")
+		expected[*workflowExecution.RunId] = true
+	}
+
+	found := make(map[string]bool)
+	var pageToken []byte
+	for {
+		response, err := s.ExecutionManager.ListConcreteExecutions(&p.ListConcreteExecutionsRequest{
+			PageSize:  2,
+			PageToken: pageToken,
+		})
+		s.NoError(err)
+		s.True(len(response.ExecutionInfos) <= 2)
+		for _, info := range response.ExecutionInfos {
+			if info.DomainID != domainID {
+				continue
+			}
+			s.Equal("wType", info.WorkflowTypeName)
+			s.Equal(int64(3), info.NextEventID)
+			found[info.RunID] = true
+		}
+		if len(response.PageToken) == 0 {
+			break
+		}
+		pageToken = response.PageToken
+	}
+	s.Equal(expected, found)
+}
+
 // TestTransferTasksThroughUpdate test
 func (s *ExecutionManagerSuite) TestTransferTasksThroughUpdate() {
 	domainID := "b785a8ba-bd7d-4760-bb05-41b115f3e10a"
@@ -116,6 +116,47 @@ func (s *HistoryV2PersistenceSuite) TestGenUUIDs() {
 	s.Equal(concurrency, cnt)
 }
 
+//TestGetAllHistoryTreeBranches test
+func (s *HistoryV2PersistenceSuite) TestGetAllHistoryTreeBranches() {
+	expected := map[string]string{}
+	for i := 0; i < 3; i++ {
+		treeID := uuid.New()
+		bi, err := s.newHistoryBranch(treeID)
+		s.Nil(err)
+		info := "branchInfo-" + treeID
+		err = s.appendNewBranchAndFirstNode(bi, s.genRandomEvents([]int64{1, 2, 3}, 1), 1, info)
+		s.Nil(err)
+		branches := s.descTree(treeID)
+		s.Equal(1, len(branches))
+		expected[branches[0].GetBranchID()] = info
+	}
+
+	var token []byte
+	for {
+		resp, err := s.HistoryV2Mgr.GetAllHistoryTreeBranches(&p.GetAllHistoryTreeBranchesRequest{
+			PageSize:      2,
+			NextPageToken: token,
+		})
+		s.Nil(err)
+		s.True(len(resp.Branches) <= 2)
+		for _, b := range resp.Branches {
+			info, ok := expected[b.BranchID]
+			if !ok {
+				continue
+			}
+			s.Equal(info, b.Info)
+			s.Equal(3, len(s.read(b.BranchToken, 1, 4)))
+			s.True(p.UnixNanoToDBTimestamp(b.ForkTime.UnixNano()) > defaultVisibilityTimestamp)
+			delete(expected, b.BranchID)
+		}
+		token = resp.NextPageToken
+		if len(token) == 0 {
+			break
+		}
+	}
+	s.Equal(0, len(expected))
+}
+
 //TestConcurrentlyCreateAndAppendBranches test
 func (s *HistoryV2PersistenceSuite) TestConcurrentlyCreateAndAppendBranches() {
 	treeID := uuid.New()
@@ -21,6 +21,7 @@
 package persistencetests
 
 import (
+	"math"
 	"os"
 	"testing"
 	"time"
@@ -152,6 +153,74 @@ func (s *MatchingPersistenceSuite) TestCompleteDecisionTask() {
 	}
 }
 
+// TestGetTasksWithMinPriority test
+func (s *MatchingPersistenceSuite) TestGetTasksWithMinPriority() {
+	domainID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{WorkflowId: common.StringPtr("get-tasks-min-priority-test"),
+		RunId: common.StringPtr("6cdfb5a9-4e2a-4cc1-98a6-b8bbd8e2b0d6")}
+	taskList := "min-priority"
+	response, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: p.TaskListTypeActivity,
+	})
+	s.NoError(err)
+
+	var tasks []*p.CreateTaskInfo
+	for i, priority := range []int32{0, 0, 5, 0, 1, 5} {
+		taskID := s.GetNextSequenceNumber()
+		tasks = append(tasks, &p.CreateTaskInfo{
+			TaskID:    taskID,
+			Execution: workflowExecution,
+			Data: &p.TaskInfo{
+				DomainID:   domainID,
+				WorkflowID: workflowExecution.GetWorkflowId(),
+				RunID:      workflowExecution.GetRunId(),
+				TaskID:     taskID,
+				ScheduleID: int64(i),
+				Priority:   priority,
+			},
+		})
+	}
+	_, err = s.TaskMgr.CreateTasks(&p.CreateTasksRequest{
+		TaskListInfo: response.TaskListInfo,
+		Tasks:        tasks,
+	})
+	s.NoError(err)
+
+	// the tasks of at least the minimum priority are read in task ID order
+	minPriority := int32(1)
+	getResponse, err := s.TaskMgr.GetTasks(&p.GetTasksRequest{
+		DomainID:     domainID,
+		TaskList:     taskList,
+		TaskType:     p.TaskListTypeActivity,
+		BatchSize:    2,
+		RangeID:      response.TaskListInfo.RangeID,
+		MaxReadLevel: math.MaxInt64,
+		MinPriority:  &minPriority,
+	})
+	s.NoError(err)
+	s.Equal(2, len(getResponse.Tasks))
+	s.Equal(int64(2), getResponse.Tasks[0].ScheduleID)
+	s.Equal(int32(5), getResponse.Tasks[0].Priority)
+	s.Equal(int64(4), getResponse.Tasks[1].ScheduleID)
+	s.Equal(int32(1), getResponse.Tasks[1].Priority)
+
+	getResponse, err = s.TaskMgr.GetTasks(&p.GetTasksRequest{
+		DomainID:     domainID,
+		TaskList:     taskList,
+		TaskType:     p.TaskListTypeActivity,
+		BatchSize:    2,
+		RangeID:      response.TaskListInfo.RangeID,
+		ReadLevel:    getResponse.Tasks[1].TaskID,
+		MaxReadLevel: math.MaxInt64,
+		MinPriority:  &minPriority,
+	})
+	s.NoError(err)
+	s.Equal(1, len(getResponse.Tasks))
+	s.Equal(int64(5), getResponse.Tasks[0].ScheduleID)
+}
+
 // TestLeaseAndUpdateTaskList test
 func (s *MatchingPersistenceSuite) TestLeaseAndUpdateTaskList() {
 	domainID := "00136543-72ad-4615-b7e9-44bca9775b45"
@@ -196,6 +265,46 @@ func (s *MatchingPersistenceSuite) TestLeaseAndUpdateTaskList() {
 	s.Error(err)
 }
 
+// TestUpdateTaskListBuildSets test
+func (s *MatchingPersistenceSuite) TestUpdateTaskListBuildSets() {
+	domainID := uuid.New()
+	taskList := "build-sets"
+	response, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: p.TaskListTypeDecision,
+	})
+	s.NoError(err)
+	tli := response.TaskListInfo
+	s.EqualValues(1, tli.RangeID)
+	s.Empty(tli.BuildSets)
+
+	buildSets := [][]string{{"build1", "build2"}, {"build3"}}
+	_, err = s.TaskMgr.UpdateTaskList(&p.UpdateTaskListRequest{
+		TaskListInfo: &p.TaskListInfo{
+			DomainID:  domainID,
+			Name:      taskList,
+			TaskType:  p.TaskListTypeDecision,
+			RangeID:   tli.RangeID,
+			AckLevel:  0,
+			Kind:      p.TaskListKindNormal,
+			BuildSets: buildSets,
+		},
+	})
+	s.NoError(err)
+
+	// build sets survive the lease taken by the next owner of the task list
+	response, err = s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+		DomainID: domainID,
+		TaskList: taskList,
+		TaskType: p.TaskListTypeDecision,
+	})
+	s.NoError(err)
+	tli = response.TaskListInfo
+	s.EqualValues(2, tli.RangeID)
+	s.Equal(buildSets, tli.BuildSets)
+}
+
 // TestLeaseAndUpdateTaskListSticky test
 func (s *MatchingPersistenceSuite) TestLeaseAndUpdateTaskListSticky() {
 	domainID := uuid.New()
@@ -225,3 +334,105 @@ func (s *MatchingPersistenceSuite) TestLeaseAndUpdateTaskListSticky() {
 	})
 	s.NoError(err) // because update with ttl doesn't check rangeID
 }
+
+// TestCompleteTasksLessThan test
+func (s *MatchingPersistenceSuite) TestCompleteTasksLessThan() {
+	domainID := uuid.New()
+	workflowExecution := gen.WorkflowExecution{WorkflowId: common.StringPtr("complete-tasks-less-than-test"),
+		RunId: common.StringPtr(uuid.New())}
+	taskList := "complete-tasks-less-than-tl"
+	taskIDs, err := s.CreateActivityTasks(domainID, workflowExecution, map[int64]string{
+		10: taskList,
+		20: taskList,
+		30: taskList,
+		40: taskList,
+	})
+	s.NoError(err)
+	s.Equal(4, len(taskIDs))
+
+	resp, err := s.GetTasks(domainID, taskList, p.TaskListTypeActivity, 10)
+	s.NoError(err)
+	s.Equal(4, len(resp.Tasks))
+
+	cutoff := resp.Tasks[1].TaskID
+	nRows, err := s.TaskMgr.CompleteTasksLessThan(&p.CompleteTasksLessThanRequest{
+		DomainID:     domainID,
+		TaskListName: taskList,
+		TaskType:     p.TaskListTypeActivity,
+		TaskID:       cutoff,
+		Limit:        100,
+	})
+	s.NoError(err)
+	if nRows != p.UnknownNumRowsAffected {
+		s.Equal(2, nRows)
+	}
+
+	resp, err = s.GetTasks(domainID, taskList, p.TaskListTypeActivity, 10)
+	s.NoError(err)
+	s.Equal(2, len(resp.Tasks))
+	for _, t := range resp.Tasks {
+		s.True(t.TaskID > cutoff)
+	}
+}
+
+// TestListAndDeleteTaskList test
+func (s *MatchingPersistenceSuite) TestListAndDeleteTaskList() {
+	domainID := uuid.New()
+	taskLists := map[string]bool{"list-delete-tl-1": true, "list-delete-tl-2": true}
+	for tl := range taskLists {
+		_, err := s.TaskMgr.LeaseTaskList(&p.LeaseTaskListRequest{
+			DomainID: domainID,
+			TaskList: tl,
+			TaskType: p.TaskListTypeDecision,
+		})
+		s.NoError(err)
+	}
+
+	found := s.listTaskLists(domainID)
+	s.Equal(2, len(found))
+	for _, tli := range found {
+		s.True(taskLists[tli.Name])
+		s.EqualValues(1, tli.RangeID)
+		s.EqualValues(p.TaskListTypeDecision, tli.TaskType)
+		s.WithinDuration(time.Now(), tli.LastUpdated, time.Minute)
+	}
+
+	err := s.TaskMgr.DeleteTaskList(&p.DeleteTaskListRequest{
+		DomainID:     domainID,
+		TaskListName: "list-delete-tl-1",
+		TaskListType: p.TaskListTypeDecision,
+		RangeID:      2,
+	})
+	s.Error(err)
+	s.IsType(&p.ConditionFailedError{}, err)
+
+	err = s.TaskMgr.DeleteTaskList(&p.DeleteTaskListRequest{
+		DomainID:     domainID,
+		TaskListName: "list-delete-tl-1",
+		TaskListType: p.TaskListTypeDecision,
+		RangeID:      1,
+	})
+	s.NoError(err)
+
+	found = s.listTaskLists(domainID)
+	s.Equal(1, len(found))
+	s.Equal("list-delete-tl-2", found[0].Name)
+}
+
+func (s *MatchingPersistenceSuite) listTaskLists(domainID string) []p.TaskListInfo {
+	var result []p.TaskListInfo
+	var pageToken []byte
+	for {
+		resp, err := s.TaskMgr.ListTaskList(&p.ListTaskListRequest{PageSize: 10, PageToken: pageToken})
+		s.NoError(err)
+		for _, tli := range resp.Items {
+			if tli.DomainID == domainID {
+				result = append(result, tli)
+			}
+		}
+		if len(resp.NextPageToken) == 0 {
+			return result
+		}
+		pageToken = resp.NextPageToken
+	}
+}
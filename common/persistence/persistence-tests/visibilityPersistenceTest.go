@@ -455,3 +455,68 @@ func (s *VisibilityPersistenceSuite) TestGetClosedExecution() {
 	s.Equal(workflowExecution.WorkflowId, resp.Execution.Execution.WorkflowId)
 	s.Equal(int64(3), *resp.Execution.HistoryLength)
 }
+
+func (s *VisibilityPersistenceSuite) TestDeleteDomainVisibility() {
+	testDomainUUID := uuid.New()
+
+	// Create one open and one closed execution
+	startTime := time.Now().Add(time.Second * -5).UnixNano()
+	openExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-delete-test1"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	err0 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&p.RecordWorkflowExecutionStartedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        openExecution,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+	})
+	s.Nil(err0)
+
+	closedExecution := gen.WorkflowExecution{
+		WorkflowId: common.StringPtr("visibility-delete-test2"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	err1 := s.VisibilityMgr.RecordWorkflowExecutionStarted(&p.RecordWorkflowExecutionStartedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        closedExecution,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+	})
+	s.Nil(err1)
+	err2 := s.VisibilityMgr.RecordWorkflowExecutionClosed(&p.RecordWorkflowExecutionClosedRequest{
+		DomainUUID:       testDomainUUID,
+		Execution:        closedExecution,
+		WorkflowTypeName: "visibility-workflow",
+		StartTimestamp:   startTime,
+		CloseTimestamp:   time.Now().UnixNano(),
+		HistoryLength:    3,
+	})
+	s.Nil(err2)
+
+	// Delete one record at a time, the limit may be ignored by the store
+	for i := 0; i < 2; i++ {
+		n, err3 := s.VisibilityMgr.DeleteDomainVisibility(&p.DeleteDomainVisibilityRequest{
+			DomainUUID: testDomainUUID,
+			Limit:      1,
+		})
+		s.Nil(err3)
+		if n == p.UnknownNumRowsAffected {
+			break
+		}
+		s.Equal(1, n)
+	}
+
+	listRequest := &p.ListWorkflowExecutionsRequest{
+		DomainUUID:        testDomainUUID,
+		EarliestStartTime: startTime,
+		LatestStartTime:   time.Now().UnixNano(),
+		PageSize:          10,
+	}
+	resp, err4 := s.VisibilityMgr.ListOpenWorkflowExecutions(listRequest)
+	s.Nil(err4)
+	s.Equal(0, len(resp.Executions))
+	resp, err5 := s.VisibilityMgr.ListClosedWorkflowExecutions(listRequest)
+	s.Nil(err5)
+	s.Equal(0, len(resp.Executions))
+}
@@ -58,8 +58,12 @@ type (
 
 		CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error)
 		DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error
+		DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error
 		GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error)
 
+		// Scan related methods
+		ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*InternalListConcreteExecutionsResponse, error)
+
 		// Transfer task related methods
 		GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error)
 		CompleteTransferTask(request *CompleteTransferTaskRequest) error
@@ -110,6 +114,8 @@ type (
 		CompleteForkBranch(request *InternalCompleteForkBranchRequest) error
 		// GetHistoryTree returns all branch information of a tree
 		GetHistoryTree(request *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error)
+		// GetAllHistoryTreeBranches returns all branches of all trees, page by page
+		GetAllHistoryTreeBranches(request *GetAllHistoryTreeBranchesRequest) (*InternalGetAllHistoryTreeBranchesResponse, error)
 	}
 
 	// DataBlob represents a blob for any binary data.
@@ -174,6 +180,11 @@ type (
 		BranchToken       []byte
 		CronSchedule      string
 		ExpirationSeconds int32
+		Paused            bool
+		Priority          int32
+		BuildID           string
+		// DecisionHeartbeatCount is the number of decision attempts which were heartbeats rather than failures
+		DecisionHeartbeatCount int64
 	}
 
 	// InternalWorkflowMutableState indicates workflow related state for Persistence Interface
@@ -223,6 +234,7 @@ type (
 		ExpirationTime     time.Time
 		MaximumAttempts    int32
 		NonRetriableErrors []string
+		Priority           int32
 		// Not written to database - This is used only for deduping heartbeat timer creation
 		LastHeartbeatTimeoutVisibility int64
 	}
@@ -369,6 +381,12 @@ type (
 		State *InternalWorkflowMutableState
 	}
 
+	// InternalListConcreteExecutionsResponse is the response to ListConcreteExecutions for Persistence Interface
+	InternalListConcreteExecutionsResponse struct {
+		ExecutionInfos []*InternalWorkflowExecutionInfo
+		NextPageToken  []byte
+	}
+
 	// InternalGetWorkflowExecutionHistoryRequest is used to retrieve history of a workflow execution
 	InternalGetWorkflowExecutionHistoryRequest struct {
 		// an extra field passing from GetWorkflowExecutionHistoryRequest
@@ -444,6 +462,21 @@ type (
 		Success bool
 	}
 
+	// InternalHistoryBranchDetail contains detailed information of a branch
+	InternalHistoryBranchDetail struct {
+		BranchInfo workflow.HistoryBranch
+		ForkTime   time.Time
+		Info       string
+	}
+
+	// InternalGetAllHistoryTreeBranchesResponse is the response to GetAllHistoryTreeBranches
+	InternalGetAllHistoryTreeBranchesResponse struct {
+		// pagination token
+		NextPageToken []byte
+		// all branches of all trees
+		Branches []InternalHistoryBranchDetail
+	}
+
 	// InternalReadHistoryBranchResponse is the response to ReadHistoryBranchRequest
 	InternalReadHistoryBranchResponse struct {
 		// History events
@@ -458,7 +491,7 @@ func NewDataBlob(data []byte, encodingType common.EncodingType) *DataBlob {
 	if data == nil || len(data) == 0 {
 		return nil
 	}
-	if encodingType != "thriftrw" && data[0] == 'Y' {
+	if encodingType != "thriftrw" && !isCompressedEncoding(encodingType) && data[0] == 'Y' {
 		panic(fmt.Sprintf("Invlid incoding: \"%v\"", encodingType))
 	}
 	return &DataBlob{
@@ -486,7 +519,17 @@ func (d *DataBlob) GetEncoding() common.EncodingType {
 		return common.EncodingTypeJSON
 	case common.EncodingTypeThriftRW:
 		return common.EncodingTypeThriftRW
+	case common.EncodingTypeThriftRWSnappy:
+		return common.EncodingTypeThriftRWSnappy
+	case common.EncodingTypeThriftRWGzip:
+		return common.EncodingTypeThriftRWGzip
 	default:
 		return common.EncodingTypeUnknown
 	}
 }
+
+// isCompressedEncoding returns true if the data of the encoding type is compressed,
+// so its first byte can be any value
+func isCompressedEncoding(encodingType common.EncodingType) bool {
+	return encodingType == common.EncodingTypeThriftRWSnappy || encodingType == common.EncodingTypeThriftRWGzip
+}
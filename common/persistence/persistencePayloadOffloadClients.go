@@ -0,0 +1,870 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	farm "github.com/dgryski/go-farm"
+	"github.com/pborman/uuid"
+	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/blobstore/blob"
+	"github.com/uber/cadence/common/codec"
+	"github.com/uber/cadence/common/encryption"
+	"github.com/uber/cadence/common/logging"
+)
+
+const (
+	// payloadBlobKeyExt is the extension of the keys of offloaded payloads
+	payloadBlobKeyExt = "payload"
+	// payloadOffloadTimeout is the timeout of a single blobstore operation
+	payloadOffloadTimeout = 10 * time.Second
+)
+
+const (
+	// payloadEnvelopeInline is the type of the envelope of a payload stored inline, it is only used for
+	// the payloads which start with payloadEnvelopePrefix so that they are not mistaken for an envelope
+	payloadEnvelopeInline byte = 0x00
+	// payloadEnvelopeReference is the type of the envelope of an offloaded payload, the envelope holds
+	// the key of the blob holding the payload
+	payloadEnvelopeReference byte = 0x01
+)
+
+var (
+	// payloadEnvelopePrefix is the prefix of the envelopes payloads are stored in, the prefix is followed
+	// by the envelope type and the content of the envelope. Payloads which are neither offloaded nor start
+	// with the prefix are stored as is
+	payloadEnvelopePrefix = []byte{0x00, 'c', 'p'}
+)
+
+type (
+	// payloadOffloader writes payloads larger than threshold to the blobstore and
+	// replaces them in the history events with a reference to the blob. The blobs are
+	// encrypted with encryptor, as the payloads would have been if they were stored inline
+	payloadOffloader struct {
+		client          blobstore.Client
+		bucket          string
+		threshold       int
+		encryptor       encryption.Encryptor
+		thriftrwEncoder codec.BinaryEncoder
+		logger          bark.Logger
+	}
+
+	historyPayloadOffloadPersistenceClient struct {
+		offloader   *payloadOffloader
+		persistence HistoryManager
+		logger      bark.Logger
+	}
+
+	historyV2PayloadOffloadPersistenceClient struct {
+		offloader   *payloadOffloader
+		persistence HistoryV2Manager
+		logger      bark.Logger
+	}
+
+	workflowExecutionPayloadOffloadPersistenceClient struct {
+		offloader   *payloadOffloader
+		persistence ExecutionManager
+		logger      bark.Logger
+	}
+)
+
+var _ HistoryManager = (*historyPayloadOffloadPersistenceClient)(nil)
+var _ HistoryV2Manager = (*historyV2PayloadOffloadPersistenceClient)(nil)
+var _ ExecutionManager = (*workflowExecutionPayloadOffloadPersistenceClient)(nil)
+
+// NewHistoryPersistencePayloadOffloadClient creates a HistoryManager client which offloads
+// payloads larger than threshold to the blobstore bucket and rehydrates them on read
+func NewHistoryPersistencePayloadOffloadClient(
+	persistence HistoryManager,
+	client blobstore.Client,
+	bucket string,
+	threshold int,
+	encryptor encryption.Encryptor,
+	logger bark.Logger,
+) HistoryManager {
+	return &historyPayloadOffloadPersistenceClient{
+		offloader:   newPayloadOffloader(client, bucket, threshold, encryptor, logger),
+		persistence: persistence,
+		logger:      logger,
+	}
+}
+
+// NewHistoryV2PersistencePayloadOffloadClient creates a HistoryV2Manager client which offloads
+// payloads larger than threshold to the blobstore bucket and rehydrates them on read
+func NewHistoryV2PersistencePayloadOffloadClient(
+	persistence HistoryV2Manager,
+	client blobstore.Client,
+	bucket string,
+	threshold int,
+	encryptor encryption.Encryptor,
+	logger bark.Logger,
+) HistoryV2Manager {
+	return &historyV2PayloadOffloadPersistenceClient{
+		offloader:   newPayloadOffloader(client, bucket, threshold, encryptor, logger),
+		persistence: persistence,
+		logger:      logger,
+	}
+}
+
+// NewWorkflowExecutionPersistencePayloadOffloadClient creates an ExecutionManager client which offloads the
+// payloads larger than threshold copied into mutable state to the blobstore bucket and rehydrates them on read
+func NewWorkflowExecutionPersistencePayloadOffloadClient(
+	persistence ExecutionManager,
+	client blobstore.Client,
+	bucket string,
+	threshold int,
+	encryptor encryption.Encryptor,
+	logger bark.Logger,
+) ExecutionManager {
+	return &workflowExecutionPayloadOffloadPersistenceClient{
+		offloader:   newPayloadOffloader(client, bucket, threshold, encryptor, logger),
+		persistence: persistence,
+		logger:      logger,
+	}
+}
+
+func newPayloadOffloader(
+	client blobstore.Client,
+	bucket string,
+	threshold int,
+	encryptor encryption.Encryptor,
+	logger bark.Logger,
+) *payloadOffloader {
+	return &payloadOffloader{
+		client:          client,
+		bucket:          bucket,
+		threshold:       threshold,
+		encryptor:       encryptor,
+		thriftrwEncoder: codec.NewThriftRWEncoder(),
+		logger:          logger,
+	}
+}
+
+func (p *historyPayloadOffloadPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *historyPayloadOffloadPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *historyPayloadOffloadPersistenceClient) AppendHistoryEvents(request *AppendHistoryEventsRequest) (*AppendHistoryEventsResponse, error) {
+	keyPrefix := executionPayloadKeyPrefix(request.DomainID, request.Execution.GetWorkflowId(), request.Execution.GetRunId())
+	events, err := p.offloader.offloadEvents(keyPrefix, request.Events)
+	if err != nil {
+		return nil, err
+	}
+	newRequest := *request
+	newRequest.Events = events
+	return p.persistence.AppendHistoryEvents(&newRequest)
+}
+
+func (p *historyPayloadOffloadPersistenceClient) GetWorkflowExecutionHistory(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
+	response, err := p.persistence.GetWorkflowExecutionHistory(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.offloader.loadEvents(response.History.GetEvents()); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (p *historyPayloadOffloadPersistenceClient) GetWorkflowExecutionHistoryByBatch(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryByBatchResponse, error) {
+	response, err := p.persistence.GetWorkflowExecutionHistoryByBatch(request)
+	if err != nil {
+		return nil, err
+	}
+	for _, batch := range response.History {
+		if err := p.offloader.loadEvents(batch.GetEvents()); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+func (p *historyPayloadOffloadPersistenceClient) DeleteWorkflowExecutionHistory(request *DeleteWorkflowExecutionHistoryRequest) error {
+	if err := p.persistence.DeleteWorkflowExecutionHistory(request); err != nil {
+		return err
+	}
+	keyPrefix := executionPayloadKeyPrefix(request.DomainID, request.Execution.GetWorkflowId(), request.Execution.GetRunId())
+	return p.offloader.deletePayloads(keyPrefix)
+}
+
+func (p *historyV2PayloadOffloadPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *historyV2PayloadOffloadPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *historyV2PayloadOffloadPersistenceClient) AppendHistoryNodes(request *AppendHistoryNodesRequest) (*AppendHistoryNodesResponse, error) {
+	var branch workflow.HistoryBranch
+	if err := p.offloader.thriftrwEncoder.Decode(request.BranchToken, &branch); err != nil {
+		return nil, err
+	}
+	events, err := p.offloader.offloadEvents(treePayloadKeyPrefix(branch.GetTreeID()), request.Events)
+	if err != nil {
+		return nil, err
+	}
+	newRequest := *request
+	newRequest.Events = events
+	return p.persistence.AppendHistoryNodes(&newRequest)
+}
+
+func (p *historyV2PayloadOffloadPersistenceClient) ReadHistoryBranch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchResponse, error) {
+	response, err := p.persistence.ReadHistoryBranch(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.offloader.loadEvents(response.HistoryEvents); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (p *historyV2PayloadOffloadPersistenceClient) ReadHistoryBranchByBatch(request *ReadHistoryBranchRequest) (*ReadHistoryBranchByBatchResponse, error) {
+	response, err := p.persistence.ReadHistoryBranchByBatch(request)
+	if err != nil {
+		return nil, err
+	}
+	for _, batch := range response.History {
+		if err := p.offloader.loadEvents(batch.GetEvents()); err != nil {
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
+func (p *historyV2PayloadOffloadPersistenceClient) ForkHistoryBranch(request *ForkHistoryBranchRequest) (*ForkHistoryBranchResponse, error) {
+	return p.persistence.ForkHistoryBranch(request)
+}
+
+func (p *historyV2PayloadOffloadPersistenceClient) CompleteForkBranch(request *CompleteForkBranchRequest) error {
+	return p.persistence.CompleteForkBranch(request)
+}
+
+// DeleteHistoryBranch deletes the branch, the offloaded payloads are deleted once the last branch
+// of the tree is deleted, as the nodes of a branch can be shared with the branches forked from it
+func (p *historyV2PayloadOffloadPersistenceClient) DeleteHistoryBranch(request *DeleteHistoryBranchRequest) error {
+	var branch workflow.HistoryBranch
+	if err := p.offloader.thriftrwEncoder.Decode(request.BranchToken, &branch); err != nil {
+		return err
+	}
+	if err := p.persistence.DeleteHistoryBranch(request); err != nil {
+		return err
+	}
+	tree, err := p.persistence.GetHistoryTree(&GetHistoryTreeRequest{TreeID: branch.GetTreeID()})
+	if err != nil {
+		return err
+	}
+	if len(tree.Branches) > 0 || len(tree.ForkingInProgressBranches) > 0 {
+		return nil
+	}
+	return p.offloader.deletePayloads(treePayloadKeyPrefix(branch.GetTreeID()))
+}
+
+func (p *historyV2PayloadOffloadPersistenceClient) GetHistoryTree(request *GetHistoryTreeRequest) (*GetHistoryTreeResponse, error) {
+	return p.persistence.GetHistoryTree(request)
+}
+
+func (p *historyV2PayloadOffloadPersistenceClient) GetAllHistoryTreeBranches(request *GetAllHistoryTreeBranchesRequest) (*GetAllHistoryTreeBranchesResponse, error) {
+	return p.persistence.GetAllHistoryTreeBranches(request)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) GetName() string {
+	return p.persistence.GetName()
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) GetShardID() int {
+	return p.persistence.GetShardID()
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) Close() {
+	p.persistence.Close()
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) CreateWorkflowExecution(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionResponse, error) {
+	newRequest, err := p.offloadCreateRequest(request)
+	if err != nil {
+		return nil, err
+	}
+	return p.persistence.CreateWorkflowExecution(newRequest)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+	response, err := p.persistence.GetWorkflowExecution(request)
+	if err != nil {
+		return nil, err
+	}
+	if err := p.loadMutableState(response.State); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+	info := request.ExecutionInfo
+	keyPrefix := mutableStatePayloadKeyPrefix(info.DomainID, info.WorkflowID, info.RunID)
+	newRequest := *request
+	var err error
+	if newRequest.ExecutionInfo, err = p.offloadExecutionInfo(keyPrefix, info); err != nil {
+		return nil, err
+	}
+	if newRequest.UpsertActivityInfos, err = p.offloadActivityInfos(keyPrefix, request.UpsertActivityInfos); err != nil {
+		return nil, err
+	}
+	if newRequest.UpsertSignalInfos, err = p.offloadSignalInfos(keyPrefix, request.UpsertSignalInfos); err != nil {
+		return nil, err
+	}
+	if newRequest.NewBufferedEvents, err = p.offloader.offloadEvents(keyPrefix, request.NewBufferedEvents); err != nil {
+		return nil, err
+	}
+	if newRequest.NewBufferedReplicationTask, err = p.offloadReplicationTask(keyPrefix, request.NewBufferedReplicationTask); err != nil {
+		return nil, err
+	}
+	if request.ContinueAsNew != nil {
+		if newRequest.ContinueAsNew, err = p.offloadCreateRequest(request.ContinueAsNew); err != nil {
+			return nil, err
+		}
+	}
+	return p.persistence.UpdateWorkflowExecution(&newRequest)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) ResetMutableState(request *ResetMutableStateRequest) error {
+	info := request.ExecutionInfo
+	keyPrefix := mutableStatePayloadKeyPrefix(info.DomainID, info.WorkflowID, info.RunID)
+	newRequest := *request
+	var err error
+	if newRequest.ExecutionInfo, err = p.offloadExecutionInfo(keyPrefix, info); err != nil {
+		return err
+	}
+	if newRequest.InsertActivityInfos, err = p.offloadActivityInfos(keyPrefix, request.InsertActivityInfos); err != nil {
+		return err
+	}
+	if newRequest.InsertSignalInfos, err = p.offloadSignalInfos(keyPrefix, request.InsertSignalInfos); err != nil {
+		return err
+	}
+	return p.persistence.ResetMutableState(&newRequest)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) ResetWorkflowExecution(request *ResetWorkflowExecutionRequest) error {
+	newRequest := *request
+	var err error
+	if request.UpdateCurr {
+		info := request.CurrExecutionInfo
+		keyPrefix := mutableStatePayloadKeyPrefix(info.DomainID, info.WorkflowID, info.RunID)
+		if newRequest.CurrExecutionInfo, err = p.offloadExecutionInfo(keyPrefix, info); err != nil {
+			return err
+		}
+	}
+	info := request.InsertExecutionInfo
+	keyPrefix := mutableStatePayloadKeyPrefix(info.DomainID, info.WorkflowID, info.RunID)
+	if newRequest.InsertExecutionInfo, err = p.offloadExecutionInfo(keyPrefix, info); err != nil {
+		return err
+	}
+	if newRequest.InsertActivityInfos, err = p.offloadActivityInfos(keyPrefix, request.InsertActivityInfos); err != nil {
+		return err
+	}
+	if newRequest.InsertSignalInfos, err = p.offloadSignalInfos(keyPrefix, request.InsertSignalInfos); err != nil {
+		return err
+	}
+	return p.persistence.ResetWorkflowExecution(&newRequest)
+}
+
+// DeleteWorkflowExecution deletes the mutable state, together with all the payloads offloaded from it
+// during the lifetime of the workflow execution
+func (p *workflowExecutionPayloadOffloadPersistenceClient) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
+	if err := p.persistence.DeleteWorkflowExecution(request); err != nil {
+		return err
+	}
+	return p.offloader.deletePayloads(mutableStatePayloadKeyPrefix(request.DomainID, request.WorkflowID, request.RunID))
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
+	return p.persistence.DeleteCurrentWorkflowExecution(request)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
+	return p.persistence.GetCurrentExecution(request)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*ListConcreteExecutionsResponse, error) {
+	return p.persistence.ListConcreteExecutions(request)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) GetTransferTasks(request *GetTransferTasksRequest) (*GetTransferTasksResponse, error) {
+	return p.persistence.GetTransferTasks(request)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) CompleteTransferTask(request *CompleteTransferTaskRequest) error {
+	return p.persistence.CompleteTransferTask(request)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) RangeCompleteTransferTask(request *RangeCompleteTransferTaskRequest) error {
+	return p.persistence.RangeCompleteTransferTask(request)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) GetReplicationTasks(request *GetReplicationTasksRequest) (*GetReplicationTasksResponse, error) {
+	return p.persistence.GetReplicationTasks(request)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) CompleteReplicationTask(request *CompleteReplicationTaskRequest) error {
+	return p.persistence.CompleteReplicationTask(request)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) GetTimerIndexTasks(request *GetTimerIndexTasksRequest) (*GetTimerIndexTasksResponse, error) {
+	return p.persistence.GetTimerIndexTasks(request)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) CompleteTimerTask(request *CompleteTimerTaskRequest) error {
+	return p.persistence.CompleteTimerTask(request)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) RangeCompleteTimerTask(request *RangeCompleteTimerTaskRequest) error {
+	return p.persistence.RangeCompleteTimerTask(request)
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) offloadCreateRequest(request *CreateWorkflowExecutionRequest) (*CreateWorkflowExecutionRequest, error) {
+	if !p.offloader.needsEncoding(&request.ExecutionContext) {
+		return request, nil
+	}
+	keyPrefix := mutableStatePayloadKeyPrefix(request.DomainID, request.Execution.GetWorkflowId(), request.Execution.GetRunId())
+	executionContext, err := p.offloader.encodePayload(keyPrefix, request.ExecutionContext)
+	if err != nil {
+		return nil, err
+	}
+	newRequest := *request
+	newRequest.ExecutionContext = executionContext
+	return &newRequest, nil
+}
+
+// offloadExecutionInfo returns the execution info with the payloads encoded for storage, the execution info is
+// copied when a payload needs encoding as it belongs to the mutable state of the caller
+func (p *workflowExecutionPayloadOffloadPersistenceClient) offloadExecutionInfo(keyPrefix string, info *WorkflowExecutionInfo) (*WorkflowExecutionInfo, error) {
+	if info == nil || !p.offloader.needsEncoding(&info.ExecutionContext) {
+		return info, nil
+	}
+	executionContext, err := p.offloader.encodePayload(keyPrefix, info.ExecutionContext)
+	if err != nil {
+		return nil, err
+	}
+	infoCopy := *info
+	infoCopy.ExecutionContext = executionContext
+	return &infoCopy, nil
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) offloadActivityInfos(keyPrefix string, infos []*ActivityInfo) ([]*ActivityInfo, error) {
+	result := make([]*ActivityInfo, 0, len(infos))
+	for _, info := range infos {
+		scheduledEvent, err := p.offloader.offloadEvent(keyPrefix, info.ScheduledEvent)
+		if err != nil {
+			return nil, err
+		}
+		startedEvent, err := p.offloader.offloadEvent(keyPrefix, info.StartedEvent)
+		if err != nil {
+			return nil, err
+		}
+		details, err := p.offloader.encodePayload(keyPrefix, info.Details)
+		if err != nil {
+			return nil, err
+		}
+		infoCopy := *info
+		infoCopy.ScheduledEvent = scheduledEvent
+		infoCopy.StartedEvent = startedEvent
+		infoCopy.Details = details
+		result = append(result, &infoCopy)
+	}
+	return result, nil
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) offloadSignalInfos(keyPrefix string, infos []*SignalInfo) ([]*SignalInfo, error) {
+	result := make([]*SignalInfo, 0, len(infos))
+	for _, info := range infos {
+		input, err := p.offloader.encodePayload(keyPrefix, info.Input)
+		if err != nil {
+			return nil, err
+		}
+		control, err := p.offloader.encodePayload(keyPrefix, info.Control)
+		if err != nil {
+			return nil, err
+		}
+		infoCopy := *info
+		infoCopy.Input = input
+		infoCopy.Control = control
+		result = append(result, &infoCopy)
+	}
+	return result, nil
+}
+
+func (p *workflowExecutionPayloadOffloadPersistenceClient) offloadReplicationTask(keyPrefix string, task *BufferedReplicationTask) (*BufferedReplicationTask, error) {
+	if task == nil {
+		return nil, nil
+	}
+	history, err := p.offloader.offloadEvents(keyPrefix, task.History)
+	if err != nil {
+		return nil, err
+	}
+	newRunHistory, err := p.offloader.offloadEvents(keyPrefix, task.NewRunHistory)
+	if err != nil {
+		return nil, err
+	}
+	taskCopy := *task
+	taskCopy.History = history
+	taskCopy.NewRunHistory = newRunHistory
+	return &taskCopy, nil
+}
+
+// loadMutableState replaces the encoded payloads in the mutable state with the original payloads
+func (p *workflowExecutionPayloadOffloadPersistenceClient) loadMutableState(state *WorkflowMutableState) error {
+	var err error
+	if state.ExecutionInfo != nil {
+		if state.ExecutionInfo.ExecutionContext, err = p.offloader.decodePayload(state.ExecutionInfo.ExecutionContext); err != nil {
+			return err
+		}
+	}
+	for _, info := range state.ActivityInfos {
+		if err := p.offloader.loadEvent(info.ScheduledEvent); err != nil {
+			return err
+		}
+		if err := p.offloader.loadEvent(info.StartedEvent); err != nil {
+			return err
+		}
+		if info.Details, err = p.offloader.decodePayload(info.Details); err != nil {
+			return err
+		}
+	}
+	for _, info := range state.SignalInfos {
+		if info.Input, err = p.offloader.decodePayload(info.Input); err != nil {
+			return err
+		}
+		if info.Control, err = p.offloader.decodePayload(info.Control); err != nil {
+			return err
+		}
+	}
+	if err := p.offloader.loadEvents(state.BufferedEvents); err != nil {
+		return err
+	}
+	for _, task := range state.BufferedReplicationTasks {
+		if err := p.offloader.loadEvents(task.History); err != nil {
+			return err
+		}
+		if err := p.offloader.loadEvents(task.NewRunHistory); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// offloadEvents returns the events with the payloads encoded for storage, the events with payloads
+// which need encoding are copied so that the events of the caller are left untouched
+func (o *payloadOffloader) offloadEvents(keyPrefix string, events []*workflow.HistoryEvent) ([]*workflow.HistoryEvent, error) {
+	result := events
+	copied := false
+	for i, event := range events {
+		if !o.needsEncoding(eventPayloads(event)...) {
+			continue
+		}
+		if !copied {
+			result = make([]*workflow.HistoryEvent, len(events))
+			copy(result, events)
+			copied = true
+		}
+		eventCopy, err := o.offloadEvent(keyPrefix, event)
+		if err != nil {
+			return nil, err
+		}
+		result[i] = eventCopy
+	}
+	return result, nil
+}
+
+// offloadEvent returns a copy of the event with the payloads encoded for storage, or the event itself
+// when none of its payloads need encoding
+func (o *payloadOffloader) offloadEvent(keyPrefix string, event *workflow.HistoryEvent) (*workflow.HistoryEvent, error) {
+	if event == nil || !o.needsEncoding(eventPayloads(event)...) {
+		return event, nil
+	}
+	eventCopy, err := o.copyEvent(event)
+	if err != nil {
+		return nil, err
+	}
+	for _, payload := range eventPayloads(eventCopy) {
+		if *payload, err = o.encodePayload(keyPrefix, *payload); err != nil {
+			return nil, err
+		}
+	}
+	return eventCopy, nil
+}
+
+// loadEvents replaces the encoded payloads in the events with the original payloads
+func (o *payloadOffloader) loadEvents(events []*workflow.HistoryEvent) error {
+	for _, event := range events {
+		if err := o.loadEvent(event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *payloadOffloader) loadEvent(event *workflow.HistoryEvent) error {
+	if event == nil {
+		return nil
+	}
+	for _, payload := range eventPayloads(event) {
+		data, err := o.decodePayload(*payload)
+		if err != nil {
+			return err
+		}
+		*payload = data
+	}
+	return nil
+}
+
+// encodePayload returns the payload as it is stored: payloads larger than threshold are uploaded and
+// replaced by a reference envelope, payloads starting with the envelope prefix are wrapped in an inline
+// envelope and the other payloads are stored as is
+func (o *payloadOffloader) encodePayload(keyPrefix string, payload []byte) ([]byte, error) {
+	if len(payload) > o.threshold {
+		key, err := o.upload(keyPrefix, payload)
+		if err != nil {
+			return nil, err
+		}
+		return newPayloadEnvelope(payloadEnvelopeReference, []byte(key)), nil
+	}
+	if bytes.HasPrefix(payload, payloadEnvelopePrefix) {
+		return newPayloadEnvelope(payloadEnvelopeInline, payload), nil
+	}
+	return payload, nil
+}
+
+// decodePayload returns the original payload of a payload returned by encodePayload
+func (o *payloadOffloader) decodePayload(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, payloadEnvelopePrefix) || len(data) == len(payloadEnvelopePrefix) {
+		return data, nil
+	}
+	content := data[len(payloadEnvelopePrefix)+1:]
+	switch data[len(payloadEnvelopePrefix)] {
+	case payloadEnvelopeInline:
+		return content, nil
+	case payloadEnvelopeReference:
+		return o.download(string(content))
+	default:
+		// written before offload was enabled
+		return data, nil
+	}
+}
+
+// deletePayloads deletes all the payloads offloaded under key prefix
+func (o *payloadOffloader) deletePayloads(keyPrefix string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), payloadOffloadTimeout)
+	defer cancel()
+	keys, err := o.client.ListByPrefix(ctx, o.bucket, keyPrefix+"_")
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if _, err := o.client.Delete(ctx, o.bucket, key); err != nil {
+			o.logger.WithFields(bark.Fields{
+				logging.TagBucket:  o.bucket,
+				logging.TagBlobKey: key.String(),
+				logging.TagErr:     err,
+			}).Error("failed to delete offloaded payload")
+			return err
+		}
+	}
+	return nil
+}
+
+func (o *payloadOffloader) needsEncoding(payloads ...*[]byte) bool {
+	for _, payload := range payloads {
+		if len(*payload) > o.threshold || bytes.HasPrefix(*payload, payloadEnvelopePrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func (o *payloadOffloader) copyEvent(event *workflow.HistoryEvent) (*workflow.HistoryEvent, error) {
+	data, err := o.thriftrwEncoder.Encode(event)
+	if err != nil {
+		return nil, err
+	}
+	var eventCopy workflow.HistoryEvent
+	if err := o.thriftrwEncoder.Decode(data, &eventCopy); err != nil {
+		return nil, err
+	}
+	return &eventCopy, nil
+}
+
+func (o *payloadOffloader) upload(keyPrefix string, payload []byte) (string, error) {
+	key, err := blob.NewKey(payloadBlobKeyExt, keyPrefix, strings.Replace(uuid.New(), "-", "", -1))
+	if err != nil {
+		return "", err
+	}
+	body, err := o.encryptor.Encrypt(payload)
+	if err != nil {
+		return "", err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), payloadOffloadTimeout)
+	defer cancel()
+	if err := o.client.Upload(ctx, o.bucket, key, blob.NewBlob(body, map[string]string{})); err != nil {
+		return "", err
+	}
+	return key.String(), nil
+}
+
+func (o *payloadOffloader) download(keyStr string) ([]byte, error) {
+	key, err := blob.NewKeyFromString(keyStr)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), payloadOffloadTimeout)
+	defer cancel()
+	payload, err := o.client.Download(ctx, o.bucket, key)
+	if err != nil {
+		return nil, err
+	}
+	return o.encryptor.Decrypt(payload.Body)
+}
+
+func newPayloadEnvelope(envelopeType byte, content []byte) []byte {
+	envelope := make([]byte, 0, len(payloadEnvelopePrefix)+1+len(content))
+	envelope = append(envelope, payloadEnvelopePrefix...)
+	envelope = append(envelope, envelopeType)
+	return append(envelope, content...)
+}
+
+// executionPayloadKeyPrefix returns the key prefix of the payloads offloaded from the history of a workflow execution
+func executionPayloadKeyPrefix(domainID, workflowID, runID string) string {
+	return fmt.Sprintf("%v%v%v",
+		farm.Fingerprint64([]byte(domainID)),
+		farm.Fingerprint64([]byte(workflowID)),
+		farm.Fingerprint64([]byte(runID)),
+	)
+}
+
+// mutableStatePayloadKeyPrefix returns the key prefix of the payloads offloaded from the mutable state
+// of a workflow execution, they are kept apart from the payloads of the history as mutable state is
+// deleted before the history
+func mutableStatePayloadKeyPrefix(domainID, workflowID, runID string) string {
+	return "state" + executionPayloadKeyPrefix(domainID, workflowID, runID)
+}
+
+// treePayloadKeyPrefix returns the key prefix of the payloads offloaded from the nodes of a history tree
+func treePayloadKeyPrefix(treeID string) string {
+	return fmt.Sprintf("tree%v", farm.Fingerprint64([]byte(treeID)))
+}
+
+// eventPayloads returns pointers to the user payloads of the event
+func eventPayloads(event *workflow.HistoryEvent) []*[]byte {
+	switch event.GetEventType() {
+	case workflow.EventTypeWorkflowExecutionStarted:
+		if attr := event.WorkflowExecutionStartedEventAttributes; attr != nil {
+			return []*[]byte{&attr.Input, &attr.ContinuedFailureDetails, &attr.LastCompletionResult}
+		}
+	case workflow.EventTypeWorkflowExecutionCompleted:
+		if attr := event.WorkflowExecutionCompletedEventAttributes; attr != nil {
+			return []*[]byte{&attr.Result}
+		}
+	case workflow.EventTypeWorkflowExecutionFailed:
+		if attr := event.WorkflowExecutionFailedEventAttributes; attr != nil {
+			return []*[]byte{&attr.Details}
+		}
+	case workflow.EventTypeWorkflowExecutionContinuedAsNew:
+		if attr := event.WorkflowExecutionContinuedAsNewEventAttributes; attr != nil {
+			return []*[]byte{&attr.Input, &attr.FailureDetails, &attr.LastCompletionResult}
+		}
+	case workflow.EventTypeWorkflowExecutionCanceled:
+		if attr := event.WorkflowExecutionCanceledEventAttributes; attr != nil {
+			return []*[]byte{&attr.Details}
+		}
+	case workflow.EventTypeWorkflowExecutionTerminated:
+		if attr := event.WorkflowExecutionTerminatedEventAttributes; attr != nil {
+			return []*[]byte{&attr.Details}
+		}
+	case workflow.EventTypeWorkflowExecutionPaused:
+		if attr := event.WorkflowExecutionPausedEventAttributes; attr != nil {
+			return []*[]byte{&attr.Details}
+		}
+	case workflow.EventTypeWorkflowExecutionUnpaused:
+		if attr := event.WorkflowExecutionUnpausedEventAttributes; attr != nil {
+			return []*[]byte{&attr.Details}
+		}
+	case workflow.EventTypeWorkflowExecutionSignaled:
+		if attr := event.WorkflowExecutionSignaledEventAttributes; attr != nil {
+			return []*[]byte{&attr.Input}
+		}
+	case workflow.EventTypeActivityTaskScheduled:
+		if attr := event.ActivityTaskScheduledEventAttributes; attr != nil {
+			return []*[]byte{&attr.Input}
+		}
+	case workflow.EventTypeActivityTaskCompleted:
+		if attr := event.ActivityTaskCompletedEventAttributes; attr != nil {
+			return []*[]byte{&attr.Result}
+		}
+	case workflow.EventTypeActivityTaskFailed:
+		if attr := event.ActivityTaskFailedEventAttributes; attr != nil {
+			return []*[]byte{&attr.Details}
+		}
+	case workflow.EventTypeActivityTaskTimedOut:
+		if attr := event.ActivityTaskTimedOutEventAttributes; attr != nil {
+			return []*[]byte{&attr.Details}
+		}
+	case workflow.EventTypeActivityTaskCanceled:
+		if attr := event.ActivityTaskCanceledEventAttributes; attr != nil {
+			return []*[]byte{&attr.Details}
+		}
+	case workflow.EventTypeMarkerRecorded:
+		if attr := event.MarkerRecordedEventAttributes; attr != nil {
+			return []*[]byte{&attr.Details}
+		}
+	case workflow.EventTypeSignalExternalWorkflowExecutionInitiated:
+		if attr := event.SignalExternalWorkflowExecutionInitiatedEventAttributes; attr != nil {
+			return []*[]byte{&attr.Input}
+		}
+	case workflow.EventTypeStartChildWorkflowExecutionInitiated:
+		if attr := event.StartChildWorkflowExecutionInitiatedEventAttributes; attr != nil {
+			return []*[]byte{&attr.Input}
+		}
+	case workflow.EventTypeChildWorkflowExecutionCompleted:
+		if attr := event.ChildWorkflowExecutionCompletedEventAttributes; attr != nil {
+			return []*[]byte{&attr.Result}
+		}
+	case workflow.EventTypeChildWorkflowExecutionFailed:
+		if attr := event.ChildWorkflowExecutionFailedEventAttributes; attr != nil {
+			return []*[]byte{&attr.Details}
+		}
+	case workflow.EventTypeChildWorkflowExecutionCanceled:
+		if attr := event.ChildWorkflowExecutionCanceledEventAttributes; attr != nil {
+			return []*[]byte{&attr.Details}
+		}
+	}
+	return nil
+}
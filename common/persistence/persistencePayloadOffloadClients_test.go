@@ -0,0 +1,475 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/blobstore/blob"
+	"github.com/uber/cadence/common/codec"
+	"github.com/uber/cadence/common/encryption"
+)
+
+type (
+	payloadOffloadClientSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		blobstore      *memoryBlobstore
+		historyManager *memoryHistoryManager
+		client         HistoryManager
+	}
+
+	memoryBlobstore struct {
+		blobs map[string]*blob.Blob
+	}
+
+	memoryHistoryManager struct {
+		events []*workflow.HistoryEvent
+	}
+
+	// memoryHistoryStore keeps the serialized batches of history events
+	memoryHistoryStore struct {
+		batches []*DataBlob
+	}
+
+	// memoryExecutionManager keeps the last update request, the methods not used by the tests are not implemented
+	memoryExecutionManager struct {
+		ExecutionManager
+		request *UpdateWorkflowExecutionRequest
+	}
+)
+
+const testPayloadOffloadThreshold = 16
+
+func TestPayloadOffloadClientSuite(t *testing.T) {
+	suite.Run(t, new(payloadOffloadClientSuite))
+}
+
+func (s *payloadOffloadClientSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.blobstore = &memoryBlobstore{blobs: make(map[string]*blob.Blob)}
+	s.historyManager = &memoryHistoryManager{}
+	s.client = NewHistoryPersistencePayloadOffloadClient(
+		s.historyManager, s.blobstore, "test-bucket", testPayloadOffloadThreshold, encryption.NewNoopEncryptor(),
+		bark.NewLoggerFromLogrus(log.New()))
+}
+
+func (s *payloadOffloadClientSuite) TestOffloadAndLoad() {
+	smallPayload := []byte("small")
+	largePayload := bytes.Repeat([]byte("large"), 10)
+	events := []*workflow.HistoryEvent{
+		{
+			EventId:   common.Int64Ptr(1),
+			EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionStarted),
+			WorkflowExecutionStartedEventAttributes: &workflow.WorkflowExecutionStartedEventAttributes{
+				Input: largePayload,
+			},
+		},
+		{
+			EventId:   common.Int64Ptr(2),
+			EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionSignaled),
+			WorkflowExecutionSignaledEventAttributes: &workflow.WorkflowExecutionSignaledEventAttributes{
+				Input: smallPayload,
+			},
+		},
+		{
+			EventId:   common.Int64Ptr(3),
+			EventType: common.EventTypePtr(workflow.EventTypeDecisionTaskScheduled),
+		},
+	}
+
+	_, err := s.client.AppendHistoryEvents(s.newAppendRequest(events))
+	s.NoError(err)
+	s.Equal(1, len(s.blobstore.blobs))
+
+	// the events of the caller are not modified
+	s.Equal(largePayload, events[0].WorkflowExecutionStartedEventAttributes.Input)
+
+	// only the large payload is replaced by a reference
+	stored := s.historyManager.events
+	s.True(bytes.HasPrefix(stored[0].WorkflowExecutionStartedEventAttributes.Input,
+		newPayloadEnvelope(payloadEnvelopeReference, nil)))
+	s.True(stored[1] == events[1])
+	s.True(stored[2] == events[2])
+
+	response, err := s.client.GetWorkflowExecutionHistory(&GetWorkflowExecutionHistoryRequest{})
+	s.NoError(err)
+	s.Equal(len(events), len(response.History.Events))
+	for i := range events {
+		s.True(events[i].Equals(response.History.Events[i]))
+	}
+
+	err = s.client.DeleteWorkflowExecutionHistory(&DeleteWorkflowExecutionHistoryRequest{
+		DomainID:  "domain-id",
+		Execution: s.newAppendRequest(nil).Execution,
+	})
+	s.NoError(err)
+	s.Equal(0, len(s.blobstore.blobs))
+}
+
+func (s *payloadOffloadClientSuite) TestDeleteOnlyOwnPayloads() {
+	events := []*workflow.HistoryEvent{
+		{
+			EventId:   common.Int64Ptr(1),
+			EventType: common.EventTypePtr(workflow.EventTypeActivityTaskCompleted),
+			ActivityTaskCompletedEventAttributes: &workflow.ActivityTaskCompletedEventAttributes{
+				Result: bytes.Repeat([]byte("result"), 10),
+			},
+		},
+	}
+	_, err := s.client.AppendHistoryEvents(s.newAppendRequest(events))
+	s.NoError(err)
+
+	otherRequest := s.newAppendRequest(events)
+	otherRequest.Execution.RunId = common.StringPtr("other-run-id")
+	_, err = s.client.AppendHistoryEvents(otherRequest)
+	s.NoError(err)
+	s.Equal(2, len(s.blobstore.blobs))
+
+	err = s.client.DeleteWorkflowExecutionHistory(&DeleteWorkflowExecutionHistoryRequest{
+		DomainID:  otherRequest.DomainID,
+		Execution: otherRequest.Execution,
+	})
+	s.NoError(err)
+	s.Equal(1, len(s.blobstore.blobs))
+}
+
+func (s *payloadOffloadClientSuite) TestPayloadWithEnvelopePrefix() {
+	payloads := [][]byte{
+		newPayloadEnvelope(payloadEnvelopeReference, []byte("not-a-key")),
+		newPayloadEnvelope(payloadEnvelopeInline, []byte("x")),
+		payloadEnvelopePrefix,
+	}
+	var events []*workflow.HistoryEvent
+	for i, payload := range payloads {
+		events = append(events, &workflow.HistoryEvent{
+			EventId:   common.Int64Ptr(int64(i + 1)),
+			EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionSignaled),
+			WorkflowExecutionSignaledEventAttributes: &workflow.WorkflowExecutionSignaledEventAttributes{
+				Input: payload,
+			},
+		})
+	}
+
+	_, err := s.client.AppendHistoryEvents(s.newAppendRequest(events))
+	s.NoError(err)
+	s.Equal(0, len(s.blobstore.blobs))
+
+	response, err := s.client.GetWorkflowExecutionHistory(&GetWorkflowExecutionHistoryRequest{})
+	s.NoError(err)
+	s.Equal(len(events), len(response.History.Events))
+	for i := range events {
+		s.Equal(payloads[i], response.History.Events[i].WorkflowExecutionSignaledEventAttributes.Input)
+	}
+}
+
+func (s *payloadOffloadClientSuite) TestOffloadMutableState() {
+	executionManager := &memoryExecutionManager{}
+	client := NewWorkflowExecutionPersistencePayloadOffloadClient(
+		executionManager, s.blobstore, "test-bucket", testPayloadOffloadThreshold, encryption.NewNoopEncryptor(),
+		bark.NewLoggerFromLogrus(log.New()))
+
+	largePayload := bytes.Repeat([]byte("large"), 10)
+	scheduledEvent := &workflow.HistoryEvent{
+		EventId:   common.Int64Ptr(5),
+		EventType: common.EventTypePtr(workflow.EventTypeActivityTaskScheduled),
+		ActivityTaskScheduledEventAttributes: &workflow.ActivityTaskScheduledEventAttributes{
+			Input: largePayload,
+		},
+	}
+	bufferedEvent := &workflow.HistoryEvent{
+		EventId:   common.Int64Ptr(common.BufferedEventID),
+		EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionSignaled),
+		WorkflowExecutionSignaledEventAttributes: &workflow.WorkflowExecutionSignaledEventAttributes{
+			Input: largePayload,
+		},
+	}
+	request := &UpdateWorkflowExecutionRequest{
+		ExecutionInfo: &WorkflowExecutionInfo{
+			DomainID:         "domain-id",
+			WorkflowID:       "workflow-id",
+			RunID:            "run-id",
+			ExecutionContext: largePayload,
+		},
+		UpsertActivityInfos: []*ActivityInfo{{ScheduleID: 5, ScheduledEvent: scheduledEvent, Details: largePayload}},
+		UpsertSignalInfos:   []*SignalInfo{{InitiatedID: 6, Input: largePayload}},
+		NewBufferedEvents:   []*workflow.HistoryEvent{bufferedEvent},
+		NewBufferedReplicationTask: &BufferedReplicationTask{
+			FirstEventID: 10,
+			History:      []*workflow.HistoryEvent{scheduledEvent},
+		},
+	}
+	_, err := client.UpdateWorkflowExecution(request)
+	s.NoError(err)
+	s.Equal(6, len(s.blobstore.blobs))
+
+	// the mutable state of the caller is not modified
+	s.Equal(largePayload, request.ExecutionInfo.ExecutionContext)
+	s.Equal(largePayload, request.UpsertActivityInfos[0].Details)
+	s.Equal(largePayload, scheduledEvent.ActivityTaskScheduledEventAttributes.Input)
+
+	// only references are stored
+	stored := executionManager.request
+	reference := newPayloadEnvelope(payloadEnvelopeReference, nil)
+	s.True(bytes.HasPrefix(stored.ExecutionInfo.ExecutionContext, reference))
+	s.True(bytes.HasPrefix(stored.UpsertActivityInfos[0].ScheduledEvent.ActivityTaskScheduledEventAttributes.Input, reference))
+	s.True(bytes.HasPrefix(stored.NewBufferedEvents[0].WorkflowExecutionSignaledEventAttributes.Input, reference))
+
+	response, err := client.GetWorkflowExecution(&GetWorkflowExecutionRequest{})
+	s.NoError(err)
+	state := response.State
+	s.Equal(largePayload, state.ExecutionInfo.ExecutionContext)
+	s.True(scheduledEvent.Equals(state.ActivityInfos[5].ScheduledEvent))
+	s.Equal(largePayload, state.ActivityInfos[5].Details)
+	s.Equal(largePayload, state.SignalInfos[6].Input)
+	s.True(bufferedEvent.Equals(state.BufferedEvents[0]))
+	s.True(scheduledEvent.Equals(state.BufferedReplicationTasks[10].History[0]))
+
+	err = client.DeleteWorkflowExecution(&DeleteWorkflowExecutionRequest{
+		DomainID:   "domain-id",
+		WorkflowID: "workflow-id",
+		RunID:      "run-id",
+	})
+	s.NoError(err)
+	s.Equal(0, len(s.blobstore.blobs))
+}
+
+func (s *payloadOffloadClientSuite) TestOffloadWithEncryption() {
+	encryptor := encryption.NewAESEncryptor(&testKeyProvider{key: make([]byte, 32)})
+	// history events are encrypted by the history manager below the offload client
+	historyStore := &memoryHistoryStore{}
+	historyManager := NewHistoryManagerImplWithEncryptor(historyStore, encryptor, bark.NewLoggerFromLogrus(log.New()))
+	client := NewHistoryPersistencePayloadOffloadClient(
+		historyManager, s.blobstore, "test-bucket", testPayloadOffloadThreshold, encryptor, bark.NewLoggerFromLogrus(log.New()))
+
+	largePayload := bytes.Repeat([]byte("large"), 10)
+	events := []*workflow.HistoryEvent{
+		{
+			EventId:   common.Int64Ptr(1),
+			EventType: common.EventTypePtr(workflow.EventTypeWorkflowExecutionStarted),
+			WorkflowExecutionStartedEventAttributes: &workflow.WorkflowExecutionStartedEventAttributes{
+				Input: largePayload,
+			},
+		},
+	}
+	_, err := client.AppendHistoryEvents(s.newAppendRequest(events))
+	s.NoError(err)
+
+	// both the history events and the offloaded payload are encrypted
+	s.Equal(1, len(historyStore.batches))
+	s.True(encryption.IsEncrypted(historyStore.batches[0].Data))
+	s.Equal(1, len(s.blobstore.blobs))
+	for _, b := range s.blobstore.blobs {
+		s.True(encryption.IsEncrypted(b.Body))
+		s.False(bytes.Contains(b.Body, largePayload))
+	}
+
+	response, err := client.GetWorkflowExecutionHistory(&GetWorkflowExecutionHistoryRequest{
+		DomainID:     "domain-id",
+		Execution:    s.newAppendRequest(nil).Execution,
+		FirstEventID: 1,
+		NextEventID:  2,
+		PageSize:     10,
+	})
+	s.NoError(err)
+	s.Equal(1, len(response.History.Events))
+	s.True(events[0].Equals(response.History.Events[0]))
+}
+
+func (s *payloadOffloadClientSuite) newAppendRequest(events []*workflow.HistoryEvent) *AppendHistoryEventsRequest {
+	return &AppendHistoryEventsRequest{
+		DomainID: "domain-id",
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr("workflow-id"),
+			RunId:      common.StringPtr("run-id"),
+		},
+		Events: events,
+	}
+}
+
+func (b *memoryBlobstore) Upload(_ context.Context, _ string, key blob.Key, data *blob.Blob) error {
+	b.blobs[key.String()] = data
+	return nil
+}
+
+func (b *memoryBlobstore) Download(_ context.Context, _ string, key blob.Key) (*blob.Blob, error) {
+	data, ok := b.blobs[key.String()]
+	if !ok {
+		return nil, blobstore.ErrBlobNotExists
+	}
+	return data, nil
+}
+
+func (b *memoryBlobstore) Exists(_ context.Context, _ string, key blob.Key) (bool, error) {
+	_, ok := b.blobs[key.String()]
+	return ok, nil
+}
+
+func (b *memoryBlobstore) Delete(_ context.Context, _ string, key blob.Key) (bool, error) {
+	_, ok := b.blobs[key.String()]
+	delete(b.blobs, key.String())
+	return ok, nil
+}
+
+func (b *memoryBlobstore) ListByPrefix(_ context.Context, _ string, prefix string) ([]blob.Key, error) {
+	var keys []blob.Key
+	for str := range b.blobs {
+		if !strings.HasPrefix(str, prefix) {
+			continue
+		}
+		key, err := blob.NewKeyFromString(str)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+func (b *memoryBlobstore) BucketMetadata(_ context.Context, _ string) (*blobstore.BucketMetadataResponse, error) {
+	return &blobstore.BucketMetadataResponse{}, nil
+}
+
+func (m *memoryHistoryManager) GetName() string {
+	return "memory"
+}
+
+func (m *memoryHistoryManager) Close() {}
+
+func (m *memoryHistoryManager) AppendHistoryEvents(request *AppendHistoryEventsRequest) (*AppendHistoryEventsResponse, error) {
+	m.events = append(m.events, request.Events...)
+	return &AppendHistoryEventsResponse{}, nil
+}
+
+func (m *memoryHistoryManager) GetWorkflowExecutionHistory(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryResponse, error) {
+	// return copies, the same as events deserialized from the store
+	serializer := NewHistorySerializer()
+	data, err := serializer.SerializeBatchEvents(m.events, common.EncodingTypeThriftRW)
+	if err != nil {
+		return nil, err
+	}
+	events, err := serializer.DeserializeBatchEvents(data)
+	if err != nil {
+		return nil, err
+	}
+	return &GetWorkflowExecutionHistoryResponse{History: &workflow.History{Events: events}}, nil
+}
+
+func (m *memoryHistoryManager) GetWorkflowExecutionHistoryByBatch(request *GetWorkflowExecutionHistoryRequest) (*GetWorkflowExecutionHistoryByBatchResponse, error) {
+	response, err := m.GetWorkflowExecutionHistory(request)
+	if err != nil {
+		return nil, err
+	}
+	return &GetWorkflowExecutionHistoryByBatchResponse{History: []*workflow.History{response.History}}, nil
+}
+
+func (m *memoryHistoryManager) DeleteWorkflowExecutionHistory(request *DeleteWorkflowExecutionHistoryRequest) error {
+	m.events = nil
+	return nil
+}
+
+func (m *memoryHistoryStore) GetName() string {
+	return "memory"
+}
+
+func (m *memoryHistoryStore) Close() {}
+
+func (m *memoryHistoryStore) AppendHistoryEvents(request *InternalAppendHistoryEventsRequest) error {
+	m.batches = append(m.batches, request.Events)
+	return nil
+}
+
+func (m *memoryHistoryStore) GetWorkflowExecutionHistory(
+	request *InternalGetWorkflowExecutionHistoryRequest,
+) (*InternalGetWorkflowExecutionHistoryResponse, error) {
+	return &InternalGetWorkflowExecutionHistoryResponse{History: m.batches}, nil
+}
+
+func (m *memoryHistoryStore) DeleteWorkflowExecutionHistory(request *DeleteWorkflowExecutionHistoryRequest) error {
+	m.batches = nil
+	return nil
+}
+
+func (m *memoryExecutionManager) UpdateWorkflowExecution(request *UpdateWorkflowExecutionRequest) (*UpdateWorkflowExecutionResponse, error) {
+	m.request = request
+	return &UpdateWorkflowExecutionResponse{}, nil
+}
+
+func (m *memoryExecutionManager) DeleteWorkflowExecution(request *DeleteWorkflowExecutionRequest) error {
+	m.request = nil
+	return nil
+}
+
+func (m *memoryExecutionManager) GetWorkflowExecution(request *GetWorkflowExecutionRequest) (*GetWorkflowExecutionResponse, error) {
+	// return copies, the same as mutable state deserialized from the store
+	encoder := codec.NewThriftRWEncoder()
+	copyEvents := func(events []*workflow.HistoryEvent) []*workflow.HistoryEvent {
+		var result []*workflow.HistoryEvent
+		for _, event := range events {
+			data, err := encoder.Encode(event)
+			if err != nil {
+				panic(err)
+			}
+			var eventCopy workflow.HistoryEvent
+			if err := encoder.Decode(data, &eventCopy); err != nil {
+				panic(err)
+			}
+			result = append(result, &eventCopy)
+		}
+		return result
+	}
+
+	executionInfo := *m.request.ExecutionInfo
+	state := &WorkflowMutableState{
+		ExecutionInfo:            &executionInfo,
+		ActivityInfos:            make(map[int64]*ActivityInfo),
+		SignalInfos:              make(map[int64]*SignalInfo),
+		BufferedEvents:           copyEvents(m.request.NewBufferedEvents),
+		BufferedReplicationTasks: make(map[int64]*BufferedReplicationTask),
+	}
+	for _, info := range m.request.UpsertActivityInfos {
+		infoCopy := *info
+		infoCopy.ScheduledEvent = copyEvents([]*workflow.HistoryEvent{info.ScheduledEvent})[0]
+		state.ActivityInfos[info.ScheduleID] = &infoCopy
+	}
+	for _, info := range m.request.UpsertSignalInfos {
+		infoCopy := *info
+		state.SignalInfos[info.InitiatedID] = &infoCopy
+	}
+	if task := m.request.NewBufferedReplicationTask; task != nil {
+		taskCopy := *task
+		taskCopy.History = copyEvents(task.History)
+		state.BufferedReplicationTasks[task.FirstEventID] = &taskCopy
+	}
+	return &GetWorkflowExecutionResponse{State: state}, nil
+}
@@ -254,6 +254,22 @@ func (p *workflowExecutionRateLimitedPersistenceClient) DeleteWorkflowExecution(
 	return err
 }
 
+func (p *workflowExecutionRateLimitedPersistenceClient) DeleteCurrentWorkflowExecution(request *DeleteCurrentWorkflowExecutionRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	return p.persistence.DeleteCurrentWorkflowExecution(request)
+}
+
+func (p *workflowExecutionRateLimitedPersistenceClient) ListConcreteExecutions(request *ListConcreteExecutionsRequest) (*ListConcreteExecutionsResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	return p.persistence.ListConcreteExecutions(request)
+}
+
 func (p *workflowExecutionRateLimitedPersistenceClient) GetCurrentExecution(request *GetCurrentExecutionRequest) (*GetCurrentExecutionResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
@@ -370,6 +386,30 @@ func (p *taskRateLimitedPersistenceClient) CompleteTask(request *CompleteTaskReq
 	return err
 }
 
+func (p *taskRateLimitedPersistenceClient) CompleteTasksLessThan(request *CompleteTasksLessThanRequest) (int, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return 0, ErrPersistenceLimitExceeded
+	}
+
+	return p.persistence.CompleteTasksLessThan(request)
+}
+
+func (p *taskRateLimitedPersistenceClient) ListTaskList(request *ListTaskListRequest) (*ListTaskListResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+
+	return p.persistence.ListTaskList(request)
+}
+
+func (p *taskRateLimitedPersistenceClient) DeleteTaskList(request *DeleteTaskListRequest) error {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return ErrPersistenceLimitExceeded
+	}
+
+	return p.persistence.DeleteTaskList(request)
+}
+
 func (p *taskRateLimitedPersistenceClient) LeaseTaskList(request *LeaseTaskListRequest) (*LeaseTaskListResponse, error) {
 	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
 		return nil, ErrPersistenceLimitExceeded
@@ -601,6 +641,14 @@ func (p *visibilityRateLimitedPersistenceClient) GetClosedWorkflowExecution(requ
 	return response, err
 }
 
+func (p *visibilityRateLimitedPersistenceClient) DeleteDomainVisibility(request *DeleteDomainVisibilityRequest) (int, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return 0, ErrPersistenceLimitExceeded
+	}
+
+	return p.persistence.DeleteDomainVisibility(request)
+}
+
 func (p *visibilityRateLimitedPersistenceClient) Close() {
 	p.persistence.Close()
 }
@@ -665,3 +713,12 @@ func (p *historyV2RateLimitedPersistenceClient) GetHistoryTree(request *GetHisto
 	response, err := p.persistence.GetHistoryTree(request)
 	return response, err
 }
+
+// GetAllHistoryTreeBranches returns all branches of all trees, page by page
+func (p *historyV2RateLimitedPersistenceClient) GetAllHistoryTreeBranches(request *GetAllHistoryTreeBranchesRequest) (*GetAllHistoryTreeBranchesResponse, error) {
+	if ok, _ := p.rateLimiter.TryConsume(1); !ok {
+		return nil, ErrPersistenceLimitExceeded
+	}
+	response, err := p.persistence.GetAllHistoryTreeBranches(request)
+	return response, err
+}
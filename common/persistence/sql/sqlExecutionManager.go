@@ -168,7 +168,7 @@ func (m *sqlExecutionManager) GetWorkflowExecution(request *p.GetWorkflowExecuti
 	domainID := sqldb.MustParseUUID(request.DomainID)
 	runID := sqldb.MustParseUUID(*request.Execution.RunId)
 	wfID := *request.Execution.WorkflowId
-	execution, err := m.db.SelectFromExecutions(&sqldb.ExecutionsFilter{
+	executions, err := m.db.SelectFromExecutions(&sqldb.ExecutionsFilter{
 		ShardID: m.shardID, DomainID: domainID, WorkflowID: wfID, RunID: runID})
 
 	if err != nil {
@@ -184,51 +184,11 @@ func (m *sqlExecutionManager) GetWorkflowExecution(request *p.GetWorkflowExecuti
 		}
 	}
 
+	execution := &executions[0]
 	var state p.InternalWorkflowMutableState
-	state.ExecutionInfo = &p.InternalWorkflowExecutionInfo{
-		DomainID:                     execution.DomainID.String(),
-		WorkflowID:                   execution.WorkflowID,
-		RunID:                        execution.RunID.String(),
-		TaskList:                     execution.TaskList,
-		WorkflowTypeName:             execution.WorkflowTypeName,
-		WorkflowTimeout:              int32(execution.WorkflowTimeoutSeconds),
-		DecisionTimeoutValue:         int32(execution.DecisionTaskTimeoutMinutes),
-		State:                        int(execution.State),
-		CloseStatus:                  int(execution.CloseStatus),
-		LastFirstEventID:             execution.LastFirstEventID,
-		NextEventID:                  execution.NextEventID,
-		LastProcessedEvent:           execution.LastProcessedEvent,
-		StartTimestamp:               execution.StartTime,
-		LastUpdatedTimestamp:         execution.LastUpdatedTime,
-		CreateRequestID:              execution.CreateRequestID,
-		DecisionVersion:              execution.DecisionVersion,
-		DecisionScheduleID:           execution.DecisionScheduleID,
-		DecisionStartedID:            execution.DecisionStartedID,
-		DecisionRequestID:            execution.DecisionRequestID,
-		DecisionTimeout:              int32(execution.DecisionTimeout),
-		DecisionAttempt:              execution.DecisionAttempt,
-		DecisionTimestamp:            execution.DecisionTimestamp,
-		StickyTaskList:               execution.StickyTaskList,
-		StickyScheduleToStartTimeout: int32(execution.StickyScheduleToStartTimeout),
-		ClientLibraryVersion:         execution.ClientLibraryVersion,
-		ClientFeatureVersion:         execution.ClientFeatureVersion,
-		ClientImpl:                   execution.ClientImpl,
-		SignalCount:                  int32(execution.SignalCount),
-		HistorySize:                  execution.HistorySize,
-		CronSchedule:                 execution.CronSchedule,
-		CompletionEventBatchID:       common.EmptyEventID,
-		HasRetryPolicy:               execution.HasRetryPolicy,
-		Attempt:                      int32(execution.Attempt),
-		InitialInterval:              int32(execution.InitialInterval),
-		BackoffCoefficient:           execution.BackoffCoefficient,
-		MaximumInterval:              int32(execution.MaximumInterval),
-		MaximumAttempts:              int32(execution.MaximumAttempts),
-		ExpirationSeconds:            int32(execution.ExpirationSeconds),
-		ExpirationTime:               execution.ExpirationTime,
-	}
-
-	if execution.ExecutionContext != nil && len(*execution.ExecutionContext) > 0 {
-		state.ExecutionInfo.ExecutionContext = *execution.ExecutionContext
+	state.ExecutionInfo, err = executionsRowToInfo(execution)
+	if err != nil {
+		return nil, err
 	}
 
 	if execution.LastWriteEventID != nil {
@@ -247,39 +207,6 @@ func (m *sqlExecutionManager) GetWorkflowExecution(request *p.GetWorkflowExecuti
 		}
 	}
 
-	if execution.ParentDomainID != nil {
-		state.ExecutionInfo.ParentDomainID = execution.ParentDomainID.String()
-		state.ExecutionInfo.ParentWorkflowID = *execution.ParentWorkflowID
-		state.ExecutionInfo.ParentRunID = execution.ParentRunID.String()
-		state.ExecutionInfo.InitiatedID = *execution.InitiatedID
-		if state.ExecutionInfo.CompletionEvent != nil {
-			state.ExecutionInfo.CompletionEvent = nil
-		}
-	}
-
-	if execution.CancelRequested != nil && (*execution.CancelRequested != 0) {
-		state.ExecutionInfo.CancelRequested = true
-		state.ExecutionInfo.CancelRequestID = *execution.CancelRequestID
-	}
-
-	if execution.CompletionEventBatchID != nil {
-		state.ExecutionInfo.CompletionEventBatchID = *execution.CompletionEventBatchID
-	}
-
-	if execution.CompletionEvent != nil {
-		state.ExecutionInfo.CompletionEvent = p.NewDataBlob(*execution.CompletionEvent,
-			common.EncodingType(*execution.CompletionEventEncoding))
-	}
-
-	if execution.NonRetryableErrors != nil {
-		err := gobDeserialize(execution.NonRetryableErrors, &state.ExecutionInfo.NonRetriableErrors)
-		if err != nil {
-			return nil, &workflow.InternalServiceError{
-				Message: fmt.Sprintf("GetWorkflowExecution: failed to deserialize nonRetryableErrors: %v", err),
-			}
-		}
-	}
-
 	{
 		var err error
 		state.ActivitInfos, err = getActivityInfoMap(m.db,
@@ -395,6 +322,90 @@ func (m *sqlExecutionManager) GetWorkflowExecution(request *p.GetWorkflowExecuti
 	return &p.InternalGetWorkflowExecutionResponse{State: &state}, nil
 }
 
+func executionsRowToInfo(execution *sqldb.ExecutionsRow) (*p.InternalWorkflowExecutionInfo, error) {
+	info := &p.InternalWorkflowExecutionInfo{
+		DomainID:                     execution.DomainID.String(),
+		WorkflowID:                   execution.WorkflowID,
+		RunID:                        execution.RunID.String(),
+		TaskList:                     execution.TaskList,
+		WorkflowTypeName:             execution.WorkflowTypeName,
+		WorkflowTimeout:              int32(execution.WorkflowTimeoutSeconds),
+		DecisionTimeoutValue:         int32(execution.DecisionTaskTimeoutMinutes),
+		State:                        int(execution.State),
+		CloseStatus:                  int(execution.CloseStatus),
+		LastFirstEventID:             execution.LastFirstEventID,
+		NextEventID:                  execution.NextEventID,
+		LastProcessedEvent:           execution.LastProcessedEvent,
+		StartTimestamp:               execution.StartTime,
+		LastUpdatedTimestamp:         execution.LastUpdatedTime,
+		CreateRequestID:              execution.CreateRequestID,
+		DecisionVersion:              execution.DecisionVersion,
+		DecisionScheduleID:           execution.DecisionScheduleID,
+		DecisionStartedID:            execution.DecisionStartedID,
+		DecisionRequestID:            execution.DecisionRequestID,
+		DecisionTimeout:              int32(execution.DecisionTimeout),
+		DecisionAttempt:              execution.DecisionAttempt,
+		DecisionTimestamp:            execution.DecisionTimestamp,
+		StickyTaskList:               execution.StickyTaskList,
+		StickyScheduleToStartTimeout: int32(execution.StickyScheduleToStartTimeout),
+		ClientLibraryVersion:         execution.ClientLibraryVersion,
+		ClientFeatureVersion:         execution.ClientFeatureVersion,
+		ClientImpl:                   execution.ClientImpl,
+		SignalCount:                  int32(execution.SignalCount),
+		HistorySize:                  execution.HistorySize,
+		CronSchedule:                 execution.CronSchedule,
+		CompletionEventBatchID:       common.EmptyEventID,
+		HasRetryPolicy:               execution.HasRetryPolicy,
+		Attempt:                      int32(execution.Attempt),
+		InitialInterval:              int32(execution.InitialInterval),
+		BackoffCoefficient:           execution.BackoffCoefficient,
+		MaximumInterval:              int32(execution.MaximumInterval),
+		MaximumAttempts:              int32(execution.MaximumAttempts),
+		ExpirationSeconds:            int32(execution.ExpirationSeconds),
+		ExpirationTime:               execution.ExpirationTime,
+		Paused:                       execution.Paused,
+		Priority:                     int32(execution.Priority),
+		BuildID:                      execution.BuildID,
+		DecisionHeartbeatCount:       execution.DecisionHeartbeatCount,
+	}
+
+	if execution.ExecutionContext != nil && len(*execution.ExecutionContext) > 0 {
+		info.ExecutionContext = *execution.ExecutionContext
+	}
+
+	if execution.ParentDomainID != nil {
+		info.ParentDomainID = execution.ParentDomainID.String()
+		info.ParentWorkflowID = *execution.ParentWorkflowID
+		info.ParentRunID = execution.ParentRunID.String()
+		info.InitiatedID = *execution.InitiatedID
+	}
+
+	if execution.CancelRequested != nil && (*execution.CancelRequested != 0) {
+		info.CancelRequested = true
+		info.CancelRequestID = *execution.CancelRequestID
+	}
+
+	if execution.CompletionEventBatchID != nil {
+		info.CompletionEventBatchID = *execution.CompletionEventBatchID
+	}
+
+	if execution.CompletionEvent != nil {
+		info.CompletionEvent = p.NewDataBlob(*execution.CompletionEvent,
+			common.EncodingType(*execution.CompletionEventEncoding))
+	}
+
+	if execution.NonRetryableErrors != nil {
+		err := gobDeserialize(execution.NonRetryableErrors, &info.NonRetriableErrors)
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("GetWorkflowExecution: failed to deserialize nonRetryableErrors: %v", err),
+			}
+		}
+	}
+
+	return info, nil
+}
+
 func getBufferedEvents(
 	db sqldb.Interface, shardID int, domainID sqldb.UUID, workflowID string, runID sqldb.UUID) ([]*p.DataBlob, error) {
 	rows, err := db.SelectFromBufferedEvents(&sqldb.BufferedEventsFilter{
@@ -858,6 +869,91 @@ func (m *sqlExecutionManager) DeleteWorkflowExecution(request *p.DeleteWorkflowE
 	})
 }
 
+// its possible for a new run of the same workflow to have started after the run we are deleting
+// here was finished. In that case, current_executions table will have the same workflowID but different
+// runID. The following code will delete the row from current_executions if and only if the runID is
+// same as the one we are trying to delete here
+func (m *sqlExecutionManager) DeleteCurrentWorkflowExecution(request *p.DeleteCurrentWorkflowExecutionRequest) error {
+	domainID := sqldb.MustParseUUID(request.DomainID)
+	runID := sqldb.MustParseUUID(request.RunID)
+	_, err := m.db.DeleteFromCurrentExecutions(&sqldb.CurrentExecutionsFilter{
+		ShardID:    int64(m.shardID),
+		DomainID:   domainID,
+		WorkflowID: request.WorkflowID,
+		RunID:      runID,
+	})
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteCurrentWorkflowExecution operation failed. Error: %v", err),
+		}
+	}
+	return nil
+}
+
+type executionsPageToken struct {
+	DomainID   string
+	WorkflowID string
+	RunID      string
+}
+
+func (t *executionsPageToken) serialize() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+func (t *executionsPageToken) deserialize(payload []byte) error {
+	return json.Unmarshal(payload, t)
+}
+
+func (m *sqlExecutionManager) ListConcreteExecutions(request *p.ListConcreteExecutionsRequest) (*p.InternalListConcreteExecutionsResponse, error) {
+	pageToken := &executionsPageToken{DomainID: minUUID, RunID: minUUID}
+	if len(request.PageToken) > 0 {
+		if err := pageToken.deserialize(request.PageToken); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("error deserializing executionsPageToken: %v", err),
+			}
+		}
+	}
+
+	domainID := sqldb.MustParseUUID(pageToken.DomainID)
+	runID := sqldb.MustParseUUID(pageToken.RunID)
+	rows, err := m.db.SelectFromExecutions(&sqldb.ExecutionsFilter{
+		ShardID:               m.shardID,
+		DomainIDGreaterThan:   &domainID,
+		WorkflowIDGreaterThan: &pageToken.WorkflowID,
+		RunIDGreaterThan:      &runID,
+		PageSize:              &request.PageSize,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListConcreteExecutions operation failed. Error: %v", err),
+		}
+	}
+
+	response := &p.InternalListConcreteExecutionsResponse{}
+	for i := range rows {
+		info, err := executionsRowToInfo(&rows[i])
+		if err != nil {
+			return nil, err
+		}
+		response.ExecutionInfos = append(response.ExecutionInfos, info)
+	}
+
+	if len(rows) == request.PageSize {
+		last := rows[len(rows)-1]
+		pageToken = &executionsPageToken{
+			DomainID:   last.DomainID.String(),
+			WorkflowID: last.WorkflowID,
+			RunID:      last.RunID.String(),
+		}
+		if response.NextPageToken, err = pageToken.serialize(); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("error serializing executionsPageToken: %v", err),
+			}
+		}
+	}
+	return response, nil
+}
+
 func (m *sqlExecutionManager) GetCurrentExecution(request *p.GetCurrentExecutionRequest) (*p.GetCurrentExecutionResponse, error) {
 	row, err := m.db.SelectFromCurrentExecutions(&sqldb.CurrentExecutionsFilter{
 		ShardID:    int64(m.shardID),
@@ -1190,6 +1286,7 @@ func createExecution(
 		MaximumAttempts:              int(request.MaximumAttempts),
 		ExpirationSeconds:            int(request.ExpirationSeconds),
 		ExpirationTime:               request.ExpirationTime,
+		Priority:                     int(request.Priority),
 	}
 
 	if request.ReplicationState != nil {
@@ -1355,6 +1452,11 @@ func createTransferTasks(tx sqldb.Tx, transferTasks []p.Task, shardID int, domai
 			transferTasksRows[i].TaskList = task.(*p.ActivityTask).TaskList
 			transferTasksRows[i].ScheduleID = task.(*p.ActivityTask).ScheduleID
 
+		case p.TransferTaskTypeActivityCancellation:
+			transferTasksRows[i].TargetDomainID = sqldb.MustParseUUID(task.(*p.ActivityCancellationTask).DomainID)
+			transferTasksRows[i].TaskList = task.(*p.ActivityCancellationTask).TaskList
+			transferTasksRows[i].ScheduleID = task.(*p.ActivityCancellationTask).ScheduleID
+
 		case p.TransferTaskTypeDecisionTask:
 			transferTasksRows[i].TargetDomainID = sqldb.MustParseUUID(task.(*p.DecisionTask).DomainID)
 			transferTasksRows[i].TaskList = task.(*p.DecisionTask).TaskList
@@ -1667,6 +1769,10 @@ func updateExecution(tx sqldb.Tx,
 		MaximumAttempts:              int(executionInfo.MaximumAttempts),
 		ExpirationSeconds:            int(executionInfo.ExpirationSeconds),
 		ExpirationTime:               executionInfo.ExpirationTime,
+		Paused:                       executionInfo.Paused,
+		Priority:                     int(executionInfo.Priority),
+		BuildID:                      executionInfo.BuildID,
+		DecisionHeartbeatCount:       executionInfo.DecisionHeartbeatCount,
 	}
 
 	if executionInfo.ExecutionContext != nil {
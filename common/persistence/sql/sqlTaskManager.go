@@ -21,7 +21,9 @@
 package sql
 
 import (
+	"encoding/json"
 	"fmt"
+	"math"
 
 	"github.com/uber-common/bark"
 
@@ -56,22 +58,25 @@ func newTaskPersistence(cfg config.SQL, log bark.Logger) (persistence.TaskManage
 func (m *sqlTaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest) (*persistence.LeaseTaskListResponse, error) {
 	var rangeID int64
 	var ackLevel int64
+	now := time.Now()
 	domainID := sqldb.MustParseUUID(request.DomainID)
-	row, err := m.db.SelectFromTaskLists(&sqldb.TaskListsFilter{
+	rows, err := m.db.SelectFromTaskLists(&sqldb.TaskListsFilter{
 		DomainID: domainID,
 		Name:     request.TaskList,
 		TaskType: int64(request.TaskType)})
 	if err != nil {
 		if err == sql.ErrNoRows {
-			row = &sqldb.TaskListsRow{
-				DomainID: domainID,
-				Name:     request.TaskList,
-				TaskType: int64(request.TaskType),
-				AckLevel: ackLevel,
-				Kind:     int64(request.TaskListKind),
-				ExpiryTs: time.Time{},
+			tlRow := sqldb.TaskListsRow{
+				DomainID:    domainID,
+				Name:        request.TaskList,
+				TaskType:    int64(request.TaskType),
+				AckLevel:    ackLevel,
+				Kind:        int64(request.TaskListKind),
+				ExpiryTs:    time.Time{},
+				LastUpdated: now,
 			}
-			if _, err := m.db.InsertIntoTaskLists(row); err != nil {
+			rows = []sqldb.TaskListsRow{tlRow}
+			if _, err := m.db.InsertIntoTaskLists(&tlRow); err != nil {
 				return nil, &workflow.InternalServiceError{
 					Message: fmt.Sprintf("LeaseTaskList operation failed. Failed to make task list %v of type %v. Error: %v", request.TaskList, request.TaskType, err),
 				}
@@ -83,6 +88,7 @@ func (m *sqlTaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest
 		}
 	}
 
+	row := rows[0]
 	var resp *persistence.LeaseTaskListResponse
 	err = m.txExecute("LeaseTaskList", func(tx sqldb.Tx) error {
 		rangeID = row.RangeID
@@ -94,14 +100,20 @@ func (m *sqlTaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest
 		if err1 != nil {
 			return err1
 		}
+		buildSets, err1 := deserializeBuildSets(row.BuildSets)
+		if err1 != nil {
+			return err1
+		}
 		result, err1 := tx.UpdateTaskLists(&sqldb.TaskListsRow{
-			DomainID: row.DomainID,
-			RangeID:  row.RangeID + 1,
-			Name:     row.Name,
-			TaskType: row.TaskType,
-			AckLevel: row.AckLevel,
-			Kind:     row.Kind,
-			ExpiryTs: row.ExpiryTs,
+			DomainID:    row.DomainID,
+			RangeID:     row.RangeID + 1,
+			Name:        row.Name,
+			TaskType:    row.TaskType,
+			AckLevel:    row.AckLevel,
+			Kind:        row.Kind,
+			ExpiryTs:    row.ExpiryTs,
+			LastUpdated: now,
+			BuildSets:   row.BuildSets,
 		})
 		if err1 != nil {
 			return err1
@@ -114,12 +126,14 @@ func (m *sqlTaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest
 			return fmt.Errorf("%v rows affected instead of 1", rowsAffected)
 		}
 		resp = &persistence.LeaseTaskListResponse{TaskListInfo: &persistence.TaskListInfo{
-			DomainID: request.DomainID,
-			Name:     request.TaskList,
-			TaskType: request.TaskType,
-			RangeID:  rangeID + 1,
-			AckLevel: ackLevel,
-			Kind:     request.TaskListKind,
+			DomainID:    request.DomainID,
+			Name:        request.TaskList,
+			TaskType:    request.TaskType,
+			RangeID:     rangeID + 1,
+			AckLevel:    ackLevel,
+			Kind:        request.TaskListKind,
+			LastUpdated: now,
+			BuildSets:   buildSets,
 		}}
 		return nil
 	})
@@ -127,16 +141,25 @@ func (m *sqlTaskManager) LeaseTaskList(request *persistence.LeaseTaskListRequest
 }
 
 func (m *sqlTaskManager) UpdateTaskList(request *persistence.UpdateTaskListRequest) (*persistence.UpdateTaskListResponse, error) {
+	now := time.Now()
 	domainID := sqldb.MustParseUUID(request.TaskListInfo.DomainID)
+	buildSets, err := serializeBuildSets(request.TaskListInfo.BuildSets)
+	if err != nil {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("UpdateTaskList operation failed. Failed to serialize build sets. Error: %v", err),
+		}
+	}
 	if request.TaskListInfo.Kind == persistence.TaskListKindSticky {
 		if _, err := m.db.ReplaceIntoTaskLists(&sqldb.TaskListsRow{
-			DomainID: domainID,
-			RangeID:  request.TaskListInfo.RangeID,
-			Name:     request.TaskListInfo.Name,
-			TaskType: int64(request.TaskListInfo.TaskType),
-			AckLevel: request.TaskListInfo.AckLevel,
-			Kind:     int64(request.TaskListInfo.Kind),
-			ExpiryTs: stickyTaskListTTL(),
+			DomainID:    domainID,
+			RangeID:     request.TaskListInfo.RangeID,
+			Name:        request.TaskListInfo.Name,
+			TaskType:    int64(request.TaskListInfo.TaskType),
+			AckLevel:    request.TaskListInfo.AckLevel,
+			Kind:        int64(request.TaskListInfo.Kind),
+			ExpiryTs:    stickyTaskListTTL(),
+			LastUpdated: now,
+			BuildSets:   buildSets,
 		}); err != nil {
 			return nil, &workflow.InternalServiceError{
 				Message: fmt.Sprintf("UpdateTaskList operation failed. Failed to make sticky task list. Error: %v", err),
@@ -144,20 +167,22 @@ func (m *sqlTaskManager) UpdateTaskList(request *persistence.UpdateTaskListReque
 		}
 	}
 	var resp *persistence.UpdateTaskListResponse
-	err := m.txExecute("UpdateTaskList", func(tx sqldb.Tx) error {
+	err = m.txExecute("UpdateTaskList", func(tx sqldb.Tx) error {
 		err1 := lockTaskList(
 			tx, domainID, request.TaskListInfo.Name, request.TaskListInfo.TaskType, request.TaskListInfo.RangeID)
 		if err1 != nil {
 			return err1
 		}
 		result, err1 := tx.UpdateTaskLists(&sqldb.TaskListsRow{
-			DomainID: domainID,
-			RangeID:  request.TaskListInfo.RangeID,
-			Name:     request.TaskListInfo.Name,
-			TaskType: int64(request.TaskListInfo.TaskType),
-			AckLevel: request.TaskListInfo.AckLevel,
-			Kind:     int64(request.TaskListInfo.Kind),
-			ExpiryTs: time.Time{},
+			DomainID:    domainID,
+			RangeID:     request.TaskListInfo.RangeID,
+			Name:        request.TaskListInfo.Name,
+			TaskType:    int64(request.TaskListInfo.TaskType),
+			AckLevel:    request.TaskListInfo.AckLevel,
+			Kind:        int64(request.TaskListInfo.Kind),
+			ExpiryTs:    time.Time{},
+			LastUpdated: now,
+			BuildSets:   buildSets,
 		})
 		if err1 != nil {
 			return err1
@@ -191,6 +216,7 @@ func (m *sqlTaskManager) CreateTasks(request *persistence.CreateTasksRequest) (*
 			TaskType:     int64(request.TaskListInfo.TaskType),
 			TaskID:       v.TaskID,
 			ExpiryTs:     expiryTime,
+			Priority:     int64(v.Data.Priority),
 		}
 	}
 	var resp *persistence.CreateTasksResponse
@@ -213,14 +239,19 @@ func (m *sqlTaskManager) CreateTasks(request *persistence.CreateTasksRequest) (*
 }
 
 func (m *sqlTaskManager) GetTasks(request *persistence.GetTasksRequest) (*persistence.GetTasksResponse, error) {
-	rows, err := m.db.SelectFromTasks(&sqldb.TasksFilter{
+	filter := &sqldb.TasksFilter{
 		DomainID:     sqldb.MustParseUUID(request.DomainID),
 		TaskListName: request.TaskList,
 		TaskType:     int64(request.TaskType),
 		MinTaskID:    &request.ReadLevel,
 		MaxTaskID:    &request.MaxReadLevel,
 		PageSize:     &request.BatchSize,
-	})
+	}
+	if request.MinPriority != nil {
+		minPriority := int64(*request.MinPriority)
+		filter.MinPriority = &minPriority
+	}
+	rows, err := m.db.SelectFromTasks(filter)
 	if err != nil {
 		return nil, &workflow.InternalServiceError{
 			Message: fmt.Sprintf("GetTasks operation failed. Failed to get rows. Error: %v", err),
@@ -235,6 +266,8 @@ func (m *sqlTaskManager) GetTasks(request *persistence.GetTasksRequest) (*persis
 			RunID:      v.RunID.String(),
 			TaskID:     v.TaskID,
 			ScheduleID: v.ScheduleID,
+			Expiry:     v.ExpiryTs,
+			Priority:   int32(v.Priority),
 		}
 	}
 
@@ -256,6 +289,139 @@ func (m *sqlTaskManager) CompleteTask(request *persistence.CompleteTaskRequest)
 	return nil
 }
 
+func (m *sqlTaskManager) CompleteTasksLessThan(request *persistence.CompleteTasksLessThanRequest) (int, error) {
+	result, err := m.db.DeleteFromTasks(&sqldb.TasksFilter{
+		DomainID:             sqldb.MustParseUUID(request.DomainID),
+		TaskListName:         request.TaskListName,
+		TaskType:             int64(request.TaskType),
+		TaskIDLessThanEquals: &request.TaskID,
+		Limit:                &request.Limit,
+	})
+	if err != nil {
+		return 0, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("CompleteTasksLessThan operation failed. Error: %v", err),
+		}
+	}
+	nRows, err := result.RowsAffected()
+	if err != nil {
+		return 0, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("rowsAffected returned error: %v", err),
+		}
+	}
+	return int(nRows), nil
+}
+
+func serializeBuildSets(buildSets [][]string) ([]byte, error) {
+	if len(buildSets) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(buildSets)
+}
+
+func deserializeBuildSets(payload []byte) ([][]string, error) {
+	if len(payload) == 0 {
+		return nil, nil
+	}
+	var buildSets [][]string
+	err := json.Unmarshal(payload, &buildSets)
+	return buildSets, err
+}
+
+type taskListPageToken struct {
+	DomainID string
+	Name     string
+	TaskType int64
+}
+
+func (t *taskListPageToken) serialize() ([]byte, error) {
+	return json.Marshal(t)
+}
+
+func (t *taskListPageToken) deserialize(payload []byte) error {
+	return json.Unmarshal(payload, t)
+}
+
+func (m *sqlTaskManager) ListTaskList(request *persistence.ListTaskListRequest) (*persistence.ListTaskListResponse, error) {
+	pageToken := &taskListPageToken{DomainID: minUUID, TaskType: math.MinInt64}
+	if len(request.PageToken) > 0 {
+		if err := pageToken.deserialize(request.PageToken); err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("error deserializing taskListPageToken: %v", err),
+			}
+		}
+	}
+
+	domainID := sqldb.MustParseUUID(pageToken.DomainID)
+	rows, err := m.db.SelectFromTaskLists(&sqldb.TaskListsFilter{
+		DomainIDGreaterThan: &domainID,
+		NameGreaterThan:     &pageToken.Name,
+		TaskTypeGreaterThan: &pageToken.TaskType,
+		PageSize:            &request.PageSize,
+	})
+	if err != nil && err != sql.ErrNoRows {
+		return nil, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("ListTaskList operation failed. Error: %v", err),
+		}
+	}
+
+	resp := &persistence.ListTaskListResponse{Items: make([]persistence.TaskListInfo, len(rows))}
+	for i, row := range rows {
+		resp.Items[i] = persistence.TaskListInfo{
+			DomainID:    row.DomainID.String(),
+			Name:        row.Name,
+			TaskType:    int(row.TaskType),
+			RangeID:     row.RangeID,
+			AckLevel:    row.AckLevel,
+			Kind:        int(row.Kind),
+			LastUpdated: row.LastUpdated,
+		}
+	}
+
+	if len(rows) == request.PageSize {
+		last := rows[len(rows)-1]
+		pageToken = &taskListPageToken{
+			DomainID: last.DomainID.String(),
+			Name:     last.Name,
+			TaskType: last.TaskType,
+		}
+		nextToken, err := pageToken.serialize()
+		if err != nil {
+			return nil, &workflow.InternalServiceError{
+				Message: fmt.Sprintf("ListTaskList: error serializing page token: %v", err),
+			}
+		}
+		resp.NextPageToken = nextToken
+	}
+
+	return resp, nil
+}
+
+func (m *sqlTaskManager) DeleteTaskList(request *persistence.DeleteTaskListRequest) error {
+	result, err := m.db.DeleteFromTaskLists(&sqldb.TaskListsFilter{
+		DomainID: sqldb.MustParseUUID(request.DomainID),
+		Name:     request.TaskListName,
+		TaskType: int64(request.TaskListType),
+		RangeID:  &request.RangeID,
+	})
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteTaskList operation failed. Error: %v", err),
+		}
+	}
+	nRows, err := result.RowsAffected()
+	if err != nil {
+		return &workflow.InternalServiceError{
+			Message: fmt.Sprintf("rowsAffected returned error: %v", err),
+		}
+	}
+	if nRows != 1 {
+		return &persistence.ConditionFailedError{
+			Msg: fmt.Sprintf("DeleteTaskList operation failed: expected to delete 1 row, but deleted %v rows", nRows),
+		}
+	}
+	return nil
+}
+
 func lockTaskList(tx sqldb.Tx, domainID sqldb.UUID, name string, taskListType int, oldRangeID int64) error {
 	rangeID, err := tx.LockTaskLists(&sqldb.TaskListsFilter{DomainID: domainID, Name: name, TaskType: int64(taskListType)})
 	if err != nil {
@@ -271,6 +437,9 @@ func lockTaskList(tx sqldb.Tx, domainID sqldb.UUID, name string, taskListType in
 	return nil
 }
 
+// minUUID is the lowest possible domain id, used as the starting point when paging through task lists
+const minUUID = "00000000-0000-0000-0000-000000000000"
+
 func stickyTaskListTTL() time.Time {
 	return time.Now().Add(24 * time.Hour)
 }
@@ -234,6 +234,25 @@ func (s *sqlVisibilityStore) GetClosedWorkflowExecution(request *p.GetClosedWork
 	return &p.GetClosedWorkflowExecutionResponse{Execution: rowToInfo(&rows[0])}, nil
 }
 
+func (s *sqlVisibilityStore) DeleteDomainVisibility(request *p.DeleteDomainVisibilityRequest) (int, error) {
+	result, err := s.db.DeleteFromVisibility(&sqldb.VisibilityFilter{
+		DomainID: request.DomainUUID,
+		PageSize: common.IntPtr(request.Limit),
+	})
+	if err != nil {
+		return 0, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("DeleteDomainVisibility operation failed. Error: %v", err),
+		}
+	}
+	nRows, err := result.RowsAffected()
+	if err != nil {
+		return 0, &workflow.InternalServiceError{
+			Message: fmt.Sprintf("rowsAffected returned error: %v", err),
+		}
+	}
+	return int(nRows), nil
+}
+
 func rowToInfo(row *sqldb.VisibilityRow) *workflow.WorkflowExecutionInfo {
 	info := &workflow.WorkflowExecutionInfo{
 		Execution: &workflow.WorkflowExecution{
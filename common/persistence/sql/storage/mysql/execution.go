@@ -66,7 +66,11 @@ maximum_interval,
 maximum_attempts,
 expiration_seconds,
 expiration_time,
-non_retryable_errors
+non_retryable_errors,
+paused,
+priority,
+build_id,
+decision_heartbeat_count
 `
 
 	executionsColumnsTags = `:shard_id,
@@ -108,7 +112,11 @@ non_retryable_errors
 :maximum_attempts,
 :expiration_seconds,
 :expiration_time,
-:non_retryable_errors`
+:non_retryable_errors,
+:paused,
+:priority,
+:build_id,
+:decision_heartbeat_count`
 
 	executionsBlobColumns = `completion_event,
 execution_context`
@@ -205,7 +213,11 @@ maximum_interval = :maximum_interval,
 maximum_attempts = :maximum_attempts,
 expiration_seconds = :expiration_seconds,
 expiration_time = :expiration_time,
-non_retryable_errors = :non_retryable_errors
+non_retryable_errors = :non_retryable_errors,
+paused = :paused,
+priority = :priority,
+build_id = :build_id,
+decision_heartbeat_count = :decision_heartbeat_count
 
 WHERE
 shard_id = :shard_id AND
@@ -226,6 +238,17 @@ domain_id = ? AND
 workflow_id = ? AND
 run_id = ?`
 
+	listExecutionQry = `SELECT ` +
+		executionsColumns + "," +
+		executionsBlobColumns + "," +
+		executionsNonblobParentColumns + "," +
+		executionsCancelColumns + "," +
+		executionsReplicationStateColumns +
+		` FROM executions WHERE
+shard_id = ? AND
+(domain_id, workflow_id, run_id) > (?, ?, ?)
+ORDER BY domain_id, workflow_id, run_id LIMIT ?`
+
 	deleteExecutionQry = `DELETE FROM executions WHERE
 shard_id = ? AND
 domain_id = ? AND
@@ -415,8 +438,17 @@ func (mdb *DB) UpdateExecutions(row *sqldb.ExecutionsRow) (sql.Result, error) {
 	return mdb.conn.NamedExec(updateExecutionQry, row)
 }
 
-// SelectFromExecutions reads a single row from executions table
-func (mdb *DB) SelectFromExecutions(filter *sqldb.ExecutionsFilter) (*sqldb.ExecutionsRow, error) {
+// SelectFromExecutions reads one or more rows from executions table
+func (mdb *DB) SelectFromExecutions(filter *sqldb.ExecutionsFilter) ([]sqldb.ExecutionsRow, error) {
+	switch {
+	case filter.PageSize != nil:
+		return mdb.rangeSelectFromExecutions(filter)
+	default:
+		return mdb.selectFromExecutions(filter)
+	}
+}
+
+func (mdb *DB) selectFromExecutions(filter *sqldb.ExecutionsFilter) ([]sqldb.ExecutionsRow, error) {
 	var row sqldb.ExecutionsRow
 	err := mdb.conn.Get(&row, getExecutionQry, filter.ShardID, filter.DomainID, filter.WorkflowID, filter.RunID)
 	if err != nil {
@@ -425,7 +457,22 @@ func (mdb *DB) SelectFromExecutions(filter *sqldb.ExecutionsFilter) (*sqldb.Exec
 	row.StartTime = mdb.converter.FromMySQLDateTime(row.StartTime)
 	row.LastUpdatedTime = mdb.converter.FromMySQLDateTime(row.LastUpdatedTime)
 	row.ExpirationTime = mdb.converter.FromMySQLDateTime(row.ExpirationTime)
-	return &row, err
+	return []sqldb.ExecutionsRow{row}, err
+}
+
+func (mdb *DB) rangeSelectFromExecutions(filter *sqldb.ExecutionsFilter) ([]sqldb.ExecutionsRow, error) {
+	var rows []sqldb.ExecutionsRow
+	err := mdb.conn.Select(&rows, listExecutionQry, filter.ShardID,
+		*filter.DomainIDGreaterThan, *filter.WorkflowIDGreaterThan, *filter.RunIDGreaterThan, *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].StartTime = mdb.converter.FromMySQLDateTime(rows[i].StartTime)
+		rows[i].LastUpdatedTime = mdb.converter.FromMySQLDateTime(rows[i].LastUpdatedTime)
+		rows[i].ExpirationTime = mdb.converter.FromMySQLDateTime(rows[i].ExpirationTime)
+	}
+	return rows, nil
 }
 
 // DeleteFromExecutions deletes a single row from executions table
@@ -135,6 +135,7 @@ var (
 		"expiration_time",
 		"max_attempts",
 		"non_retriable_errors",
+		"priority",
 	}
 	activityInfoTableName = "activity_info_maps"
 	activityInfoKey       = "schedule_id"
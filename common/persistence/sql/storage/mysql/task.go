@@ -22,13 +22,14 @@ package mysql
 
 import (
 	"database/sql"
+	"fmt"
 
 	"github.com/uber/cadence/common/persistence/sql/storage/sqldb"
 )
 
 const (
-	taskListCreatePart = `INTO task_lists(domain_id, range_id, name, task_type, ack_level, kind, expiry_ts) ` +
-		`VALUES (:domain_id, :range_id, :name, :task_type, :ack_level, :kind, :expiry_ts)`
+	taskListCreatePart = `INTO task_lists(domain_id, range_id, name, task_type, ack_level, kind, expiry_ts, last_updated, build_sets) ` +
+		`VALUES (:domain_id, :range_id, :name, :task_type, :ack_level, :kind, :expiry_ts, :last_updated, :build_sets)`
 
 	// (default range ID: initialRangeID == 1)
 	createTaskListQry = `INSERT ` + taskListCreatePart
@@ -42,30 +43,50 @@ name = :name,
 task_type = :task_type,
 ack_level = :ack_level,
 kind = :kind,
-expiry_ts = :expiry_ts
+expiry_ts = :expiry_ts,
+last_updated = :last_updated,
+build_sets = :build_sets
 WHERE
 domain_id = :domain_id AND
 name = :name AND
 task_type = :task_type
 `
 
-	getTaskListQry = `SELECT domain_id, range_id, name, task_type, ack_level, kind, expiry_ts ` +
-		`FROM task_lists ` +
+	listTaskListPart = `SELECT domain_id, range_id, name, task_type, ack_level, kind, expiry_ts, last_updated, build_sets ` +
+		`FROM task_lists `
+
+	getTaskListQry = listTaskListPart +
 		`WHERE domain_id = ? AND name = ? AND task_type = ?`
 
+	listTaskListQry = listTaskListPart +
+		`WHERE (domain_id, name, task_type) > (?, ?, ?) ` +
+		`ORDER BY domain_id, name, task_type LIMIT ?`
+
+	deleteTaskListQry = `DELETE FROM task_lists ` +
+		`WHERE domain_id = ? AND name = ? AND task_type = ? AND range_id = ?`
+
 	lockTaskListQry = `SELECT range_id FROM task_lists ` +
 		`WHERE domain_id = ? AND name = ? AND task_type = ? FOR UPDATE`
 
-	getTaskQry = `SELECT workflow_id, run_id, schedule_id, task_id ` +
+	getTaskQry = `SELECT workflow_id, run_id, schedule_id, task_id, priority ` +
 		`FROM tasks ` +
 		`WHERE domain_id = ? AND task_list_name = ? AND task_type = ? AND task_id > ? AND task_id <= ? LIMIT ?`
 
+	getTaskWithMinPriorityQry = `SELECT workflow_id, run_id, schedule_id, task_id, priority ` +
+		`FROM tasks ` +
+		`WHERE domain_id = ? AND task_list_name = ? AND task_type = ? AND priority >= ? AND task_id > ? AND task_id <= ? ` +
+		`ORDER BY task_id LIMIT ?`
+
 	createTaskQry = `INSERT INTO ` +
-		`tasks(domain_id, workflow_id, run_id, schedule_id, task_list_name, task_type, task_id, expiry_ts) ` +
-		`VALUES(:domain_id, :workflow_id, :run_id, :schedule_id, :task_list_name, :task_type, :task_id, :expiry_ts)`
+		`tasks(domain_id, workflow_id, run_id, schedule_id, task_list_name, task_type, task_id, expiry_ts, priority) ` +
+		`VALUES(:domain_id, :workflow_id, :run_id, :schedule_id, :task_list_name, :task_type, :task_id, :expiry_ts, :priority)`
 
 	deleteTaskQry = `DELETE FROM tasks ` +
 		`WHERE domain_id = ? AND task_list_name = ? AND task_type = ? AND task_id = ?`
+
+	rangeDeleteTaskQry = `DELETE FROM tasks ` +
+		`WHERE domain_id = ? AND task_list_name = ? AND task_type = ? AND task_id <= ? ` +
+		`ORDER BY domain_id,task_list_name,task_type,task_id LIMIT ?`
 )
 
 // InsertIntoTasks inserts one or more rows into tasks table
@@ -80,8 +101,13 @@ func (mdb *DB) InsertIntoTasks(rows []sqldb.TasksRow) (sql.Result, error) {
 func (mdb *DB) SelectFromTasks(filter *sqldb.TasksFilter) ([]sqldb.TasksRow, error) {
 	var err error
 	var rows []sqldb.TasksRow
-	err = mdb.conn.Select(&rows, getTaskQry, filter.DomainID,
-		filter.TaskListName, filter.TaskType, *filter.MinTaskID, *filter.MaxTaskID, *filter.PageSize)
+	if filter.MinPriority != nil {
+		err = mdb.conn.Select(&rows, getTaskWithMinPriorityQry, filter.DomainID, filter.TaskListName, filter.TaskType,
+			*filter.MinPriority, *filter.MinTaskID, *filter.MaxTaskID, *filter.PageSize)
+	} else {
+		err = mdb.conn.Select(&rows, getTaskQry, filter.DomainID,
+			filter.TaskListName, filter.TaskType, *filter.MinTaskID, *filter.MaxTaskID, *filter.PageSize)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -93,29 +119,48 @@ func (mdb *DB) SelectFromTasks(filter *sqldb.TasksFilter) ([]sqldb.TasksRow, err
 
 // DeleteFromTasks deletes one or more rows from tasks table
 func (mdb *DB) DeleteFromTasks(filter *sqldb.TasksFilter) (sql.Result, error) {
+	if filter.TaskIDLessThanEquals != nil {
+		if filter.Limit == nil || *filter.Limit == 0 {
+			return nil, fmt.Errorf("missing limit parameter")
+		}
+		return mdb.conn.Exec(rangeDeleteTaskQry,
+			filter.DomainID, filter.TaskListName, filter.TaskType, *filter.TaskIDLessThanEquals, *filter.Limit)
+	}
 	return mdb.conn.Exec(deleteTaskQry, filter.DomainID, filter.TaskListName, filter.TaskType, *filter.TaskID)
 }
 
 // InsertIntoTaskLists inserts one or more rows into task_lists table
 func (mdb *DB) InsertIntoTaskLists(row *sqldb.TaskListsRow) (sql.Result, error) {
 	row.ExpiryTs = mdb.converter.ToMySQLDateTime(row.ExpiryTs)
+	row.LastUpdated = mdb.converter.ToMySQLDateTime(row.LastUpdated)
 	return mdb.conn.NamedExec(createTaskListQry, row)
 }
 
 // ReplaceIntoTaskLists replaces one or more rows in task_lists table
 func (mdb *DB) ReplaceIntoTaskLists(row *sqldb.TaskListsRow) (sql.Result, error) {
 	row.ExpiryTs = mdb.converter.ToMySQLDateTime(row.ExpiryTs)
+	row.LastUpdated = mdb.converter.ToMySQLDateTime(row.LastUpdated)
 	return mdb.conn.NamedExec(replaceTaskListQry, row)
 }
 
 // UpdateTaskLists updates a row in task_lists table
 func (mdb *DB) UpdateTaskLists(row *sqldb.TaskListsRow) (sql.Result, error) {
 	row.ExpiryTs = mdb.converter.ToMySQLDateTime(row.ExpiryTs)
+	row.LastUpdated = mdb.converter.ToMySQLDateTime(row.LastUpdated)
 	return mdb.conn.NamedExec(updateTaskListQry, row)
 }
 
 // SelectFromTaskLists reads one or more rows from task_lists table
-func (mdb *DB) SelectFromTaskLists(filter *sqldb.TaskListsFilter) (*sqldb.TaskListsRow, error) {
+func (mdb *DB) SelectFromTaskLists(filter *sqldb.TaskListsFilter) ([]sqldb.TaskListsRow, error) {
+	switch {
+	case filter.PageSize != nil:
+		return mdb.rangeSelectFromTaskLists(filter)
+	default:
+		return mdb.selectFromTaskLists(filter)
+	}
+}
+
+func (mdb *DB) selectFromTaskLists(filter *sqldb.TaskListsFilter) ([]sqldb.TaskListsRow, error) {
 	var err error
 	var row sqldb.TaskListsRow
 	err = mdb.conn.Get(&row, getTaskListQry, filter.DomainID, filter.Name, filter.TaskType)
@@ -123,12 +168,28 @@ func (mdb *DB) SelectFromTaskLists(filter *sqldb.TaskListsFilter) (*sqldb.TaskLi
 		return nil, err
 	}
 	row.ExpiryTs = mdb.converter.FromMySQLDateTime(row.ExpiryTs)
-	return &row, err
+	row.LastUpdated = mdb.converter.FromMySQLDateTime(row.LastUpdated)
+	return []sqldb.TaskListsRow{row}, err
+}
+
+func (mdb *DB) rangeSelectFromTaskLists(filter *sqldb.TaskListsFilter) ([]sqldb.TaskListsRow, error) {
+	var err error
+	var rows []sqldb.TaskListsRow
+	err = mdb.conn.Select(&rows, listTaskListQry,
+		*filter.DomainIDGreaterThan, *filter.NameGreaterThan, *filter.TaskTypeGreaterThan, *filter.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	for i := range rows {
+		rows[i].ExpiryTs = mdb.converter.FromMySQLDateTime(rows[i].ExpiryTs)
+		rows[i].LastUpdated = mdb.converter.FromMySQLDateTime(rows[i].LastUpdated)
+	}
+	return rows, nil
 }
 
 // DeleteFromTaskLists deletes a row from task_lists table
 func (mdb *DB) DeleteFromTaskLists(filter *sqldb.TaskListsFilter) (sql.Result, error) {
-	return mdb.conn.Exec(deleteTaskQry, filter.DomainID, filter.Name, filter.TaskType)
+	return mdb.conn.Exec(deleteTaskListQry, filter.DomainID, filter.Name, filter.TaskType, *filter.RangeID)
 }
 
 // LockTaskLists locks a row in task_lists table
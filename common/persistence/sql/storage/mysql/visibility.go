@@ -66,6 +66,8 @@ const (
 
 	templateGetClosedWorkflowExecutionsByStatus = templateClosedSelect + `AND close_status = ?` + templateConditions
 
+	templateDeleteWorkflowExecutions = `DELETE FROM executions_visibility WHERE domain_id = ? LIMIT ?`
+
 	templateGetClosedWorkflowExecution = `SELECT workflow_id, run_id, start_time, close_time, workflow_type_name, close_status, history_length
 		 FROM executions_visibility
 		 WHERE domain_id = ? AND close_status IS NOT NULL
@@ -177,3 +179,8 @@ func (mdb *DB) SelectFromVisibility(filter *sqldb.VisibilityFilter) ([]sqldb.Vis
 	}
 	return rows, err
 }
+
+// DeleteFromVisibility deletes up to pageSize rows of the domain from visibility table
+func (mdb *DB) DeleteFromVisibility(filter *sqldb.VisibilityFilter) (sql.Result, error) {
+	return mdb.conn.Exec(templateDeleteWorkflowExecutions, filter.DomainID, *filter.PageSize)
+}
@@ -167,15 +167,23 @@ type (
 		ExpirationSeconds            int
 		ExpirationTime               time.Time
 		NonRetryableErrors           []byte
+		Paused                       bool
+		Priority                     int
+		BuildID                      string
+		DecisionHeartbeatCount       int64
 	}
 
 	// ExecutionsFilter contains the column names within domain table that
 	// can be used to filter results through a WHERE clause
 	ExecutionsFilter struct {
-		ShardID    int
-		DomainID   UUID
-		WorkflowID string
-		RunID      UUID
+		ShardID               int
+		DomainID              UUID
+		WorkflowID            string
+		RunID                 UUID
+		DomainIDGreaterThan   *UUID
+		WorkflowIDGreaterThan *string
+		RunIDGreaterThan      *UUID
+		PageSize              *int
 	}
 
 	// CurrentExecutionsRow represents a row in current_executions table
@@ -229,37 +237,50 @@ type (
 		RunID        UUID
 		ScheduleID   int64
 		ExpiryTs     time.Time
+		Priority     int64
 	}
 
 	// TasksFilter contains the column names within domain table that
 	// can be used to filter results through a WHERE clause
 	TasksFilter struct {
-		DomainID     UUID
-		TaskListName string
-		TaskType     int64
-		TaskID       *int64
-		MinTaskID    *int64
-		MaxTaskID    *int64
-		PageSize     *int
+		DomainID             UUID
+		TaskListName         string
+		TaskType             int64
+		TaskID               *int64
+		MinTaskID            *int64
+		MaxTaskID            *int64
+		TaskIDLessThanEquals *int64
+		MinPriority          *int64
+		Limit                *int
+		PageSize             *int
 	}
 
 	// TaskListsRow represents a row in task_lists table
 	TaskListsRow struct {
-		DomainID UUID
-		Name     string
-		TaskType int64
-		RangeID  int64
-		AckLevel int64
-		Kind     int64
-		ExpiryTs time.Time
+		DomainID    UUID
+		Name        string
+		TaskType    int64
+		RangeID     int64
+		AckLevel    int64
+		Kind        int64
+		ExpiryTs    time.Time
+		LastUpdated time.Time
+		BuildSets   []byte
 	}
 
 	// TaskListsFilter contains the column names within domain table that
-	// can be used to filter results through a WHERE clause
+	// can be used to filter results through a WHERE clause. When PageSize
+	// is not nil, the *GreaterThan fields are used to page through all
+	// task lists instead of looking up a single one
 	TaskListsFilter struct {
-		DomainID UUID
-		Name     string
-		TaskType int64
+		DomainID            UUID
+		Name                string
+		TaskType            int64
+		RangeID             *int64
+		DomainIDGreaterThan *UUID
+		NameGreaterThan     *string
+		TaskTypeGreaterThan *int64
+		PageSize            *int
 	}
 
 	// ReplicationTasksRow represents a row in replication_tasks table
@@ -374,6 +395,7 @@ type (
 		ExpirationTime           time.Time
 		MaxAttempts              int64
 		NonRetriableErrors       *[]byte
+		Priority                 int64
 	}
 
 	// ActivityInfoMapsFilter contains the column names within domain table that
@@ -580,14 +602,23 @@ type (
 		// SelectFromTasks retrieves one or more rows from the tasks table
 		// Required filter params - {domainID, tasklistName, taskType, minTaskID, maxTaskID, pageSize}
 		SelectFromTasks(filter *TasksFilter) ([]TasksRow, error)
-		// DeleteFromTasks deletes a row from tasks table
-		// Required filter params - {domainID, tasklistName, taskType, taskID}
+		// DeleteFromTasks deletes one or more rows from tasks table
+		// Required filter params - {domainID, tasklistName, taskType}
+		// When taskID is not nil, a single row is deleted. When
+		// taskIDLessThanEquals and limit are not nil, up to limit rows
+		// with task_id <= taskIDLessThanEquals are deleted.
 		DeleteFromTasks(filter *TasksFilter) (sql.Result, error)
 
 		InsertIntoTaskLists(row *TaskListsRow) (sql.Result, error)
 		ReplaceIntoTaskLists(row *TaskListsRow) (sql.Result, error)
 		UpdateTaskLists(row *TaskListsRow) (sql.Result, error)
-		SelectFromTaskLists(filter *TaskListsFilter) (*TaskListsRow, error)
+		// SelectFromTaskLists returns one or more rows from task_lists table
+		// Required filter params - {domainID, name, taskType} to read a single row,
+		// or {domainIDGreaterThan, nameGreaterThan, taskTypeGreaterThan, pageSize}
+		// to read a page of rows
+		SelectFromTaskLists(filter *TaskListsFilter) ([]TaskListsRow, error)
+		// DeleteFromTaskLists deletes a row from task_lists table
+		// Required filter params - {domainID, name, taskType, rangeID}
 		DeleteFromTaskLists(filter *TaskListsFilter) (sql.Result, error)
 		LockTaskLists(filter *TaskListsFilter) (int64, error)
 
@@ -599,7 +630,11 @@ type (
 
 		InsertIntoExecutions(row *ExecutionsRow) (sql.Result, error)
 		UpdateExecutions(row *ExecutionsRow) (sql.Result, error)
-		SelectFromExecutions(filter *ExecutionsFilter) (*ExecutionsRow, error)
+		// SelectFromExecutions returns one or more rows from executions table
+		// Required filter params - {shardID, domainID, workflowID, runID} to read a single row,
+		// or {shardID, domainIDGreaterThan, workflowIDGreaterThan, runIDGreaterThan, pageSize}
+		// to read a page of rows
+		SelectFromExecutions(filter *ExecutionsFilter) ([]ExecutionsRow, error)
 		DeleteFromExecutions(filter *ExecutionsFilter) (sql.Result, error)
 		LockExecutions(filter *ExecutionsFilter) (int, error)
 
@@ -730,6 +765,9 @@ type (
 		//   - OPTIONALLY specify one of following params
 		//     - workflowID, workflowTypeName, closeStatus (along with closed=true)
 		SelectFromVisibility(filter *VisibilityFilter) ([]VisibilityRow, error)
+		// DeleteFromVisibility deletes one or more rows from visibility table
+		// Required filter params - {domainID, pageSize}
+		DeleteFromVisibility(filter *VisibilityFilter) (sql.Result, error)
 	}
 
 	// Tx defines the API for a SQL transaction
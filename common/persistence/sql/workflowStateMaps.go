@@ -74,6 +74,7 @@ func updateActivityInfos(tx sqldb.Tx,
 				MaxInterval:              int64(v.MaximumInterval),
 				ExpirationTime:           v.ExpirationTime,
 				MaxAttempts:              int64(v.MaximumAttempts),
+				Priority:                 int64(v.Priority),
 			}
 			if v.StartedEvent != nil {
 				rows[i].StartedEvent = &v.StartedEvent.Data
@@ -184,6 +185,7 @@ func getActivityInfoMap(db sqldb.Interface,
 			MaximumInterval:          int32(v.MaxInterval),
 			ExpirationTime:           v.ExpirationTime,
 			MaximumAttempts:          int32(v.MaxAttempts),
+			Priority:                 int32(v.Priority),
 		}
 		if v.StartedEvent != nil {
 			info.StartedEvent = persistence.NewDataBlob(*v.StartedEvent, common.EncodingType(v.ScheduledEventEncoding))
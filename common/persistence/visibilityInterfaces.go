@@ -108,6 +108,13 @@ type (
 		Execution *s.WorkflowExecutionInfo
 	}
 
+	// DeleteDomainVisibilityRequest is used to delete the visibility records of a domain
+	DeleteDomainVisibilityRequest struct {
+		DomainUUID string
+		Domain     string // domain name is not persisted, but used as config filter key
+		Limit      int
+	}
+
 	// VisibilityManager is used to manage the visibility store
 	VisibilityManager interface {
 		Closeable
@@ -122,5 +129,11 @@ type (
 		ListClosedWorkflowExecutionsByWorkflowID(request *ListWorkflowExecutionsByWorkflowIDRequest) (*ListWorkflowExecutionsResponse, error)
 		ListClosedWorkflowExecutionsByStatus(request *ListClosedWorkflowExecutionsByStatusRequest) (*ListWorkflowExecutionsResponse, error)
 		GetClosedWorkflowExecution(request *GetClosedWorkflowExecutionRequest) (*GetClosedWorkflowExecutionResponse, error)
+		// DeleteDomainVisibility deletes up to limit visibility records of the given domain, open or closed.
+		// Like TaskManager.CompleteTasksLessThan, the limit may be ignored by the underlying storage.
+		// On success, this method returns:
+		//  - number of records actually deleted, if limit is honored
+		//  - UnknownNumRowsAffected, when all records of the domain are deleted
+		DeleteDomainVisibility(request *DeleteDomainVisibilityRequest) (int, error)
 	}
 )
@@ -202,6 +202,10 @@ func (p *visibilitySamplingClient) GetClosedWorkflowExecution(request *GetClosed
 	return p.persistence.GetClosedWorkflowExecution(request)
 }
 
+func (p *visibilitySamplingClient) DeleteDomainVisibility(request *DeleteDomainVisibilityRequest) (int, error) {
+	return p.persistence.DeleteDomainVisibility(request)
+}
+
 func (p *visibilitySamplingClient) Close() {
 	p.persistence.Close()
 }
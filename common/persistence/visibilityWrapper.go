@@ -105,6 +105,19 @@ func (v *visibilityManagerWrapper) GetClosedWorkflowExecution(request *GetClosed
 	return manager.GetClosedWorkflowExecution(request)
 }
 
+// DeleteDomainVisibility deletes the records from both stores, the ElasticSearch records
+// are only deleted once all the records of the domain are gone from the DB
+func (v *visibilityManagerWrapper) DeleteDomainVisibility(request *DeleteDomainVisibilityRequest) (int, error) {
+	n, err := v.visibilityManager.DeleteDomainVisibility(request)
+	if err != nil || v.esVisibilityManager == nil {
+		return n, err
+	}
+	if n != UnknownNumRowsAffected && n >= request.Limit {
+		return n, nil
+	}
+	return v.esVisibilityManager.DeleteDomainVisibility(request)
+}
+
 func (v *visibilityManagerWrapper) chooseVisibilityManagerForDomain(domain string) VisibilityManager {
 	var visibilityMgr VisibilityManager
 	if v.enableReadVisibilityFromES(domain) && v.esVisibilityManager != nil {
@@ -22,11 +22,13 @@ package config
 
 import (
 	"encoding/json"
+	"fmt"
 	"github.com/uber/cadence/common/blobstore/filestore"
 	"time"
 
 	"github.com/uber-go/tally/m3"
 	"github.com/uber/cadence/common/elasticsearch"
+	"github.com/uber/cadence/common/encryption"
 	"github.com/uber/cadence/common/messaging"
 	"github.com/uber/cadence/common/service/dynamicconfig"
 	"github.com/uber/ringpop-go/discovery"
@@ -37,6 +39,8 @@ type (
 	Config struct {
 		// Ringpop is the ringpop related configuration
 		Ringpop Ringpop `yaml:"ringpop"`
+		// Membership is the configuration of the membership, ringpop is used by default
+		Membership Membership `yaml:"membership"`
 		// Persistence contains the configuration for cadence datastores
 		Persistence Persistence `yaml:"persistence"`
 		// Log is the logging config
@@ -75,6 +79,9 @@ type (
 	RPC struct {
 		// Port is the port  on which the channel will bind to
 		Port int `yaml:"port"`
+		// HTTPPort is the port on which the HTTP/JSON gateway of the frontend will bind to,
+		// the gateway is disabled when the port is not set
+		HTTPPort int `yaml:"httpPort"`
 		// BindOnLocalHost is true if localhost is the bind address
 		BindOnLocalHost bool `yaml:"bindOnLocalHost"`
 		// BindOnIP can be used to bind service on specific ip (eg. `0.0.0.0`) -
@@ -85,6 +92,30 @@ type (
 		DisableLogging bool `yaml:"disableLogging"`
 		// LogLevel is the desired log level
 		LogLevel string `yaml:"logLevel"`
+		// TLS is the tls configuration of the inbound and outbound connections
+		TLS TLS `yaml:"tls"`
+	}
+
+	// TLS describes the tls configuration of rpc
+	TLS struct {
+		// Enabled indicates whether the connections are encrypted with tls
+		Enabled bool `yaml:"enabled"`
+		// CertFile is the path of the PEM encoded certificate, it is presented both to the clients
+		// and to the servers which require client authentication
+		CertFile string `yaml:"certFile"`
+		// KeyFile is the path of the PEM encoded private key of the certificate
+		KeyFile string `yaml:"keyFile"`
+		// CaFile is the path of the PEM encoded CA certificates used to verify the peers,
+		// the system CAs are used to verify the servers when not set
+		CaFile string `yaml:"caFile"`
+		// RequireClientAuth enables mutual tls, the clients must present a certificate signed by the CA
+		RequireClientAuth bool `yaml:"requireClientAuth"`
+		// AllowedClientNames restricts mutual tls to the clients whose certificate common name
+		// or DNS names are listed, all the clients with a valid certificate are allowed when empty
+		AllowedClientNames []string `yaml:"allowedClientNames"`
+		// ServerName is the name verified in the certificates of the servers, the host of the
+		// dialed address is verified when not set
+		ServerName string `yaml:"serverName"`
 	}
 
 	// Ringpop contains the ringpop config items
@@ -103,6 +134,23 @@ type (
 		DiscoveryProvider discovery.DiscoverProvider `yaml:"-"`
 	}
 
+	// Membership contains the config items of the membership of the cadence services
+	Membership struct {
+		// Mode is the membership mode, one of ringpop (default), static or dns
+		Mode MembershipMode `yaml:"mode"`
+		// Hosts is the map of service name to the ip:port addresses of its hosts, used by the static mode
+		Hosts map[string][]string `yaml:"hosts"`
+		// DNSNames is the map of service name to the dns name of its hosts, used by the dns mode.
+		// A name of the form host:port is resolved with A/AAAA records, a name of the
+		// form _service._proto.domain is resolved with SRV records
+		DNSNames map[string]string `yaml:"dnsNames"`
+		// RefreshInterval is the interval at which the hosts are reloaded
+		RefreshInterval time.Duration `yaml:"refreshInterval"`
+	}
+
+	// MembershipMode is an enum type for the membership mode
+	MembershipMode int
+
 	// Persistence contains the configuration for data store / persistence layer
 	Persistence struct {
 		// DefaultStore is the name of the default data store to use
@@ -119,6 +167,20 @@ type (
 		DataStores map[string]DataStore `yaml:"datastores"`
 		// VisibilityConfig is config for visibility sampling
 		VisibilityConfig *VisibilityConfig
+		// Encryption is the config for encryption of payloads at rest
+		Encryption encryption.Config `yaml:"encryption"`
+		// PayloadOffload is the config for offloading large history and mutable state payloads to the blobstore
+		PayloadOffload PayloadOffload `yaml:"payloadOffload"`
+	}
+
+	// PayloadOffload is the config for offloading large history and mutable state payloads to the blobstore
+	PayloadOffload struct {
+		// Enabled indicates whether large payloads are offloaded, the blobstore of archival is used
+		Enabled bool `yaml:"enabled"`
+		// Bucket is the blobstore bucket the payloads are written to
+		Bucket string `yaml:"bucket"`
+		// ThresholdBytes is the size above which a payload is offloaded
+		ThresholdBytes int `yaml:"thresholdBytes"`
 	}
 
 	// DataStore is the configuration for a single datastore
@@ -270,7 +332,18 @@ type (
 
 // Validate validates this config
 func (c *Config) Validate() error {
-	return c.Persistence.Validate()
+	if err := c.Persistence.Validate(); err != nil {
+		return err
+	}
+	if err := c.Membership.Validate(); err != nil {
+		return err
+	}
+	for name, service := range c.Services {
+		if err := service.RPC.TLS.Validate(); err != nil {
+			return fmt.Errorf("services: %v: %v", name, err)
+		}
+	}
+	return nil
 }
 
 // String converts the config object into a string
@@ -0,0 +1,179 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/membership"
+	"go.uber.org/yarpc"
+)
+
+const (
+	// MembershipModeRingpop represents the membership gossiped by ringpop
+	MembershipModeRingpop MembershipMode = iota
+	// MembershipModeStatic represents the membership listed in the configuration
+	MembershipModeStatic
+	// MembershipModeDNS represents the membership resolved from dns
+	MembershipModeDNS
+)
+
+const (
+	membershipServicePrefix = "cadence-"
+)
+
+// membershipServices are the cadence services which must be listed by the static and dns modes,
+// every service looks up the members of the others
+var membershipServices = []string{
+	common.FrontendServiceName,
+	common.HistoryServiceName,
+	common.MatchingServiceName,
+	common.WorkerServiceName,
+}
+
+// MembershipFactory creates the membership monitors which are not based on ringpop
+type MembershipFactory struct {
+	config *Membership
+	logger bark.Logger
+}
+
+// UnmarshalYAML is called by the yaml package to convert
+// the config YAML into a MembershipMode.
+func (m *MembershipMode) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+	var err error
+	*m, err = parseMembershipMode(s)
+	return err
+}
+
+// parseMembershipMode reads a string value and returns a membership mode.
+func parseMembershipMode(s string) (MembershipMode, error) {
+	switch strings.ToLower(s) {
+	case "", "ringpop":
+		return MembershipModeRingpop, nil
+	case "static":
+		return MembershipModeStatic, nil
+	case "dns":
+		return MembershipModeDNS, nil
+	}
+	return MembershipModeRingpop, fmt.Errorf("invalid membership mode %v", s)
+}
+
+// Validate validates the membership config
+func (m *Membership) Validate() error {
+	switch m.Mode {
+	case MembershipModeRingpop:
+		return nil
+	case MembershipModeStatic:
+		for _, service := range membershipServices {
+			name := strings.TrimPrefix(service, membershipServicePrefix)
+			if len(m.Hosts[name]) == 0 {
+				return fmt.Errorf("membership: hosts of service %v are required by the static mode", name)
+			}
+		}
+	case MembershipModeDNS:
+		for _, service := range membershipServices {
+			name := strings.TrimPrefix(service, membershipServicePrefix)
+			if len(m.DNSNames[name]) == 0 {
+				return fmt.Errorf("membership: dnsNames of service %v are required by the dns mode", name)
+			}
+		}
+	default:
+		return errors.New("membership: unknown mode")
+	}
+	return nil
+}
+
+// IsRingpop returns true if the membership is gossiped by ringpop
+func (m *Membership) IsRingpop() bool {
+	return m.Mode == MembershipModeRingpop
+}
+
+// NewFactory builds a membership factory conforming
+// to the underlying configuration
+func (m *Membership) NewFactory(logger bark.Logger) (*MembershipFactory, error) {
+	if m.IsRingpop() {
+		return nil, errors.New("membership: ringpop membership is created by the ringpop factory")
+	}
+	if err := m.Validate(); err != nil {
+		return nil, err
+	}
+	return &MembershipFactory{config: m, logger: logger}, nil
+}
+
+// CreateMembershipMonitor is the implementation for MembershipFactory.CreateMembershipMonitor
+func (factory *MembershipFactory) CreateMembershipMonitor(
+	serviceName string,
+	services []string,
+	dispatcher *yarpc.Dispatcher,
+) (membership.Monitor, error) {
+	ch, err := getChannel(dispatcher)
+	if err != nil {
+		return nil, err
+	}
+	// use actual listen port (in case service is bound to :0 or 0.0.0.0:0)
+	selfAddress := ch.PeerInfo().HostPort
+
+	var provider membership.HostProvider
+	switch factory.config.Mode {
+	case MembershipModeStatic:
+		provider = membership.NewStaticHostProvider(hostsWithServicePrefix(factory.config.Hosts))
+	case MembershipModeDNS:
+		provider = membership.NewDNSHostProvider(dnsNamesWithServicePrefix(factory.config.DNSNames))
+	default:
+		return nil, errors.New("membership: unknown mode")
+	}
+	return membership.NewHostProviderMonitor(
+		selfAddress,
+		serviceName,
+		services,
+		provider,
+		factory.config.RefreshInterval,
+		factory.logger,
+	), nil
+}
+
+// hostsWithServicePrefix converts the keys of the config, the short names of the services,
+// to the full names used by the membership
+func hostsWithServicePrefix(hosts map[string][]string) map[string][]string {
+	result := make(map[string][]string, len(hosts))
+	for service, addrs := range hosts {
+		result[membershipServicePrefix+service] = addrs
+	}
+	return result
+}
+
+// dnsNamesWithServicePrefix converts the keys of the config, the short names of the services,
+// to the full names used by the membership
+func dnsNamesWithServicePrefix(names map[string]string) map[string]string {
+	result := make(map[string]string, len(names))
+	for service, name := range names {
+		result[membershipServicePrefix+service] = name
+	}
+	return result
+}
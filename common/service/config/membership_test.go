@@ -0,0 +1,89 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"gopkg.in/yaml.v2"
+)
+
+type MembershipSuite struct {
+	*require.Assertions
+	suite.Suite
+}
+
+func TestMembershipSuite(t *testing.T) {
+	suite.Run(t, new(MembershipSuite))
+}
+
+func (s *MembershipSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+}
+
+func (s *MembershipSuite) TestStaticMode() {
+	var cfg Membership
+	err := yaml.Unmarshal([]byte(`
+mode: static
+hosts:
+  frontend: ["127.0.0.1:7933"]
+  history: ["127.0.0.1:7934"]
+  matching: ["127.0.0.1:7935"]
+  worker: ["127.0.0.1:7939"]
+`), &cfg)
+	s.NoError(err)
+	s.Equal(MembershipModeStatic, cfg.Mode)
+	s.NoError(cfg.Validate())
+
+	delete(cfg.Hosts, "worker")
+	s.Error(cfg.Validate())
+	cfg.Hosts["worker"] = []string{}
+	s.Error(cfg.Validate())
+}
+
+func (s *MembershipSuite) TestDNSMode() {
+	var cfg Membership
+	err := yaml.Unmarshal([]byte(`
+mode: dns
+dnsNames:
+  frontend: "frontend.cadence:7933"
+  history: "_tchannel._tcp.history.cadence"
+  matching: "matching.cadence:7935"
+  worker: "worker.cadence:7939"
+`), &cfg)
+	s.NoError(err)
+	s.Equal(MembershipModeDNS, cfg.Mode)
+	s.NoError(cfg.Validate())
+
+	delete(cfg.DNSNames, "matching")
+	s.Error(cfg.Validate())
+}
+
+func (s *MembershipSuite) TestRingpopMode() {
+	var cfg Membership
+	s.NoError(yaml.Unmarshal([]byte(`mode: ringpop`), &cfg))
+	s.True(cfg.IsRingpop())
+	s.NoError(cfg.Validate())
+	_, err := cfg.NewFactory(nil)
+	s.Error(err)
+}
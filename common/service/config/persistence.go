@@ -50,5 +50,18 @@ func (c *Persistence) Validate() error {
 			return fmt.Errorf("persistce: datastore %v: only one of SQL or cassandra can be specified", st)
 		}
 	}
+	if c.Encryption.Enabled {
+		if err := c.Encryption.Validate(); err != nil {
+			return fmt.Errorf("persistence: encryption: %v", err)
+		}
+	}
+	if c.PayloadOffload.Enabled {
+		if len(c.PayloadOffload.Bucket) == 0 {
+			return fmt.Errorf("persistence: payloadOffload: empty bucket")
+		}
+		if c.PayloadOffload.ThresholdBytes <= 0 {
+			return fmt.Errorf("persistence: payloadOffload: thresholdBytes must be positive")
+		}
+	}
 	return nil
 }
@@ -129,7 +129,7 @@ func newRingpopFactory(rpConfig *Ringpop) (*RingpopFactory, error) {
 func (factory *RingpopFactory) CreateRingpop(dispatcher *yarpc.Dispatcher) (*ringpop.Ringpop, error) {
 	var ch *tcg.Channel
 	var err error
-	if ch, err = factory.getChannel(dispatcher); err != nil {
+	if ch, err = getChannel(dispatcher); err != nil {
 		return nil, err
 	}
 
@@ -155,7 +155,8 @@ func (factory *RingpopFactory) CreateRingpop(dispatcher *yarpc.Dispatcher) (*rin
 	return rp, nil
 }
 
-func (factory *RingpopFactory) getChannel(dispatcher *yarpc.Dispatcher) (*tcg.Channel, error) {
+// getChannel returns the tchannel of the first inbound of the dispatcher
+func getChannel(dispatcher *yarpc.Dispatcher) (*tcg.Channel, error) {
 	t := dispatcher.Inbounds()[0].Transports()[0].(*tchannel.ChannelTransport)
 	ty := reflect.ValueOf(t.Channel())
 	var ch *tcg.Channel
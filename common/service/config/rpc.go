@@ -21,10 +21,12 @@
 package config
 
 import (
+	"crypto/tls"
 	"fmt"
 	"net"
 
 	"github.com/uber-common/bark"
+	tcg "github.com/uber/tchannel-go"
 	"go.uber.org/yarpc"
 	"go.uber.org/yarpc/transport/tchannel"
 )
@@ -53,9 +55,13 @@ func (d *RPCFactory) CreateDispatcher() *yarpc.Dispatcher {
 	// Setup dispatcher for onebox
 	var err error
 	hostAddress := fmt.Sprintf("%v:%v", d.getListenIP(), d.config.Port)
-	d.ch, err = tchannel.NewChannelTransport(
-		tchannel.ServiceName(d.serviceName),
-		tchannel.ListenAddr(hostAddress))
+	if d.config.TLS.Enabled {
+		d.ch, err = d.newTLSChannelTransport(hostAddress)
+	} else {
+		d.ch, err = tchannel.NewChannelTransport(
+			tchannel.ServiceName(d.serviceName),
+			tchannel.ListenAddr(hostAddress))
+	}
 	if err != nil {
 		d.logger.WithField("error", err).Fatal("Failed to create transport channel")
 	}
@@ -67,6 +73,61 @@ func (d *RPCFactory) CreateDispatcher() *yarpc.Dispatcher {
 	})
 }
 
+// newTLSChannelTransport creates a channel transport whose inbound and outbound
+// connections are all secured with TLS. The channel is already serving when the
+// transport is returned, the transport only takes care of the handlers. The identity
+// of the peer is available to the handlers with PeerIdentityFromContext.
+func (d *RPCFactory) newTLSChannelTransport(hostAddress string) (*tchannel.ChannelTransport, error) {
+	serverConfig, err := d.config.TLS.NewServerConfig()
+	if err != nil {
+		return nil, err
+	}
+	clientConfig, err := d.config.TLS.NewClientConfig()
+	if err != nil {
+		return nil, err
+	}
+	ch, err := tcg.NewChannel(d.serviceName, &tcg.ChannelOptions{
+		Dialer:      NewTLSDialer(clientConfig),
+		ConnContext: peerIdentityConnContext,
+	})
+	if err != nil {
+		return nil, err
+	}
+	listener, err := tls.Listen("tcp", hostAddress, serverConfig)
+	if err != nil {
+		ch.Close()
+		return nil, err
+	}
+	if err := ch.Serve(listener); err != nil {
+		ch.Close()
+		return nil, err
+	}
+	d.logger.Infof("Enabled TLS on RPC channel for '%v', client authentication required: %v",
+		d.serviceName, d.config.TLS.RequireClientAuth)
+	return tchannel.NewChannelTransport(tchannel.WithChannel(ch))
+}
+
+// GetHTTPGatewayTLSConfig returns the tls config of the HTTP gateway, nil if tls is disabled
+func (d *RPCFactory) GetHTTPGatewayTLSConfig() *tls.Config {
+	if !d.config.TLS.Enabled || d.config.HTTPPort <= 0 {
+		return nil
+	}
+	serverConfig, err := d.config.TLS.NewServerConfig()
+	if err != nil {
+		d.logger.WithField("error", err).Fatal("Failed to create TLS config for HTTP gateway")
+	}
+	return serverConfig
+}
+
+// GetHTTPGatewayAddress returns the address the HTTP gateway listens on,
+// empty if the HTTP gateway is disabled
+func (d *RPCFactory) GetHTTPGatewayAddress() string {
+	if d.config.HTTPPort <= 0 {
+		return ""
+	}
+	return fmt.Sprintf("%v:%v", d.getListenIP(), d.config.HTTPPort)
+}
+
 // CreateDispatcherForOutbound creates a dispatcher for outbound connection
 func (d *RPCFactory) CreateDispatcherForOutbound(
 	callerName, serviceName, hostName string) *yarpc.Dispatcher {
@@ -0,0 +1,209 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"time"
+)
+
+type peerIdentityContextKey struct{}
+
+// Validate validates the tls config
+func (t *TLS) Validate() error {
+	if !t.Enabled {
+		return nil
+	}
+	if len(t.CertFile) == 0 || len(t.KeyFile) == 0 {
+		return errors.New("tls: certFile and keyFile are required")
+	}
+	if t.RequireClientAuth && len(t.CaFile) == 0 {
+		return errors.New("tls: caFile is required to verify client certificates")
+	}
+	if len(t.AllowedClientNames) > 0 && !t.RequireClientAuth {
+		return errors.New("tls: allowedClientNames requires requireClientAuth")
+	}
+	return nil
+}
+
+// NewServerConfig returns the tls config of the inbound connections
+func (t *TLS) NewServerConfig() (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	config := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if t.RequireClientAuth {
+		pool, err := loadCertPool(t.CaFile)
+		if err != nil {
+			return nil, err
+		}
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+		if len(t.AllowedClientNames) > 0 {
+			config.VerifyPeerCertificate = newClientNameVerifier(t.AllowedClientNames)
+		}
+	}
+	return config, nil
+}
+
+// NewClientConfig returns the tls config of the outbound connections, the certificate
+// is presented to the servers which require client authentication
+func (t *TLS) NewClientConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName: t.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if len(t.CaFile) > 0 {
+		pool, err := loadCertPool(t.CaFile)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	if len(t.CertFile) > 0 || len(t.KeyFile) > 0 {
+		cert, err := tls.LoadX509KeyPair(t.CertFile, t.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		config.Certificates = []tls.Certificate{cert}
+	}
+	return config, nil
+}
+
+// NewTLSDialer returns a dialer which establishes tls connections with the given config,
+// when the config has no server name the host of the dialed address is verified
+func NewTLSDialer(config *tls.Config) func(ctx context.Context, network, hostPort string) (net.Conn, error) {
+	return func(ctx context.Context, network, hostPort string) (net.Conn, error) {
+		dialer := &net.Dialer{}
+		conn, err := dialer.DialContext(ctx, network, hostPort)
+		if err != nil {
+			return nil, err
+		}
+		connConfig := config
+		if len(config.ServerName) == 0 {
+			host, _, err := net.SplitHostPort(hostPort)
+			if err != nil {
+				conn.Close()
+				return nil, err
+			}
+			connConfig = config.Clone()
+			connConfig.ServerName = host
+		}
+		if deadline, ok := ctx.Deadline(); ok {
+			conn.SetDeadline(deadline)
+		}
+		tlsConn := tls.Client(conn, connConfig)
+		if err := tlsConn.Handshake(); err != nil {
+			conn.Close()
+			return nil, err
+		}
+		conn.SetDeadline(time.Time{})
+		return tlsConn, nil
+	}
+}
+
+// PeerIdentity returns the identity of the peer of a tls connection, the common name of its
+// verified certificate or its first DNS name when the common name is empty. The peer has no
+// identity when it did not present a certificate verified against the CA.
+func PeerIdentity(state tls.ConnectionState) (string, bool) {
+	if len(state.VerifiedChains) == 0 || len(state.VerifiedChains[0]) == 0 {
+		return "", false
+	}
+	cert := state.VerifiedChains[0][0]
+	if len(cert.Subject.CommonName) > 0 {
+		return cert.Subject.CommonName, true
+	}
+	if len(cert.DNSNames) > 0 {
+		return cert.DNSNames[0], true
+	}
+	return "", false
+}
+
+// NewPeerIdentityContext returns a copy of ctx carrying the identity of the tls peer of the request
+func NewPeerIdentityContext(ctx context.Context, identity string) context.Context {
+	return context.WithValue(ctx, peerIdentityContextKey{}, identity)
+}
+
+// PeerIdentityFromContext returns the identity of the tls peer of the request, see PeerIdentity
+func PeerIdentityFromContext(ctx context.Context) (string, bool) {
+	identity, ok := ctx.Value(peerIdentityContextKey{}).(string)
+	return identity, ok
+}
+
+// peerIdentityConnContext adds the identity of the peer of a tls connection to the base
+// context of the calls received on the connection
+func peerIdentityConnContext(ctx context.Context, conn net.Conn) context.Context {
+	tlsConn, ok := conn.(*tls.Conn)
+	if !ok {
+		return ctx
+	}
+	if identity, ok := PeerIdentity(tlsConn.ConnectionState()); ok {
+		return NewPeerIdentityContext(ctx, identity)
+	}
+	return ctx
+}
+
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	caPEM, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("tls: no certificate found in %v", caFile)
+	}
+	return pool, nil
+}
+
+// newClientNameVerifier returns a verifier which accepts the client certificates whose
+// common name or one of the DNS names is in allowed names
+func newClientNameVerifier(allowedNames []string) func([][]byte, [][]*x509.Certificate) error {
+	allowed := make(map[string]struct{}, len(allowedNames))
+	for _, name := range allowedNames {
+		allowed[name] = struct{}{}
+	}
+	return func(_ [][]byte, verifiedChains [][]*x509.Certificate) error {
+		for _, chain := range verifiedChains {
+			if len(chain) == 0 {
+				continue
+			}
+			if _, ok := allowed[chain[0].Subject.CommonName]; ok {
+				return nil
+			}
+			for _, name := range chain[0].DNSNames {
+				if _, ok := allowed[name]; ok {
+					return nil
+				}
+			}
+		}
+		return errors.New("tls: client certificate is not allowed")
+	}
+}
@@ -0,0 +1,251 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package config
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	tcg "github.com/uber/tchannel-go"
+	"github.com/uber/tchannel-go/raw"
+)
+
+type (
+	TLSSuite struct {
+		*require.Assertions
+		suite.Suite
+		dir    string
+		caCert *x509.Certificate
+		caKey  *ecdsa.PrivateKey
+
+		listeners []net.Listener
+	}
+
+	// peerIdentityHandler responds with the peer identity of the calls
+	peerIdentityHandler struct{}
+)
+
+func TestTLSSuite(t *testing.T) {
+	suite.Run(t, new(TLSSuite))
+}
+
+func (s *TLSSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.caCert, s.caKey, s.listeners = nil, nil, nil
+	dir, err := ioutil.TempDir("", "tls.test")
+	s.NoError(err)
+	s.dir = dir
+	s.caCert, s.caKey = s.writeCert("ca", &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "cadence-ca"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	})
+	s.writeCert("server", s.leafCert(2, "cadence-frontend"))
+	s.writeCert("allowed", s.leafCert(3, "allowed-client"))
+	s.writeCert("other", s.leafCert(4, "other-client"))
+}
+
+func (s *TLSSuite) TearDownTest() {
+	for _, listener := range s.listeners {
+		listener.Close()
+	}
+	os.RemoveAll(s.dir)
+}
+
+func (s *TLSSuite) TestValidate() {
+	s.NoError((&TLS{}).Validate())
+	s.Error((&TLS{Enabled: true}).Validate())
+	s.Error((&TLS{Enabled: true, CertFile: s.path("server.pem")}).Validate())
+	s.Error((&TLS{Enabled: true, CertFile: s.path("server.pem"), KeyFile: s.path("server.key"), RequireClientAuth: true}).Validate())
+	s.NoError(s.serverTLS(nil).Validate())
+}
+
+func (s *TLSSuite) TestMutualTLS() {
+	address := s.serve(s.serverTLS(nil))
+	s.NoError(s.dial(address, "allowed"))
+	s.NoError(s.dial(address, "other"))
+	s.Error(s.dial(address, ""))
+}
+
+func (s *TLSSuite) TestMutualTLS_AllowedClientNames() {
+	address := s.serve(s.serverTLS([]string{"allowed-client"}))
+	s.NoError(s.dial(address, "allowed"))
+	s.Error(s.dial(address, "other"))
+}
+
+func (s *TLSSuite) TestPeerIdentityContext() {
+	_, ok := PeerIdentityFromContext(context.Background())
+	s.False(ok)
+	identity, ok := PeerIdentityFromContext(NewPeerIdentityContext(context.Background(), "allowed-client"))
+	s.True(ok)
+	s.Equal("allowed-client", identity)
+}
+
+func (s *TLSSuite) TestPeerIdentity() {
+	_, ok := PeerIdentity(tls.ConnectionState{})
+	s.False(ok)
+	cert := s.leafCert(5, "")
+	cert.DNSNames = []string{"dns-client"}
+	identity, ok := PeerIdentity(tls.ConnectionState{VerifiedChains: [][]*x509.Certificate{{cert}}})
+	s.True(ok)
+	s.Equal("dns-client", identity)
+}
+
+func (s *TLSSuite) TestTLSChannelTransport_PeerIdentity() {
+	factory := newRPCFactory(&RPC{TLS: *s.serverTLS(nil)}, "cadence-frontend", bark.NewNopLogger())
+	transport, err := factory.newTLSChannelTransport("127.0.0.1:0")
+	s.NoError(err)
+	serverCh := transport.Channel()
+	defer serverCh.Close()
+	serverCh.GetSubChannel("cadence-frontend").Register(raw.Wrap(peerIdentityHandler{}), "identity")
+
+	clientConfig, err := (&TLS{
+		CaFile:   s.path("ca.pem"),
+		CertFile: s.path("allowed.pem"),
+		KeyFile:  s.path("allowed.key"),
+	}).NewClientConfig()
+	s.NoError(err)
+	clientCh, err := tcg.NewChannel("cadence-client", &tcg.ChannelOptions{Dialer: NewTLSDialer(clientConfig)})
+	s.NoError(err)
+	defer clientCh.Close()
+
+	ctx, cancel := tcg.NewContext(5 * time.Second)
+	defer cancel()
+	_, identity, _, err := raw.Call(ctx, clientCh, serverCh.PeerInfo().HostPort, "cadence-frontend", "identity", nil, nil)
+	s.NoError(err)
+	s.Equal("allowed-client", string(identity))
+}
+
+func (s *TLSSuite) serverTLS(allowedClientNames []string) *TLS {
+	return &TLS{
+		Enabled:            true,
+		CertFile:           s.path("server.pem"),
+		KeyFile:            s.path("server.key"),
+		CaFile:             s.path("ca.pem"),
+		RequireClientAuth:  true,
+		AllowedClientNames: allowedClientNames,
+	}
+}
+
+// serve accepts TLS connections and writes a single byte on each of them,
+// the byte is only received by the client once the handshake succeeded
+func (s *TLSSuite) serve(cfg *TLS) string {
+	serverConfig, err := cfg.NewServerConfig()
+	s.NoError(err)
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverConfig)
+	s.NoError(err)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				conn.Write([]byte{1})
+				conn.Close()
+			}()
+		}
+	}()
+	s.listeners = append(s.listeners, listener)
+	return listener.Addr().String()
+}
+
+// dial connects with the client certificate of the given name, no certificate if empty
+func (s *TLSSuite) dial(address string, name string) error {
+	cfg := &TLS{CaFile: s.path("ca.pem")}
+	if name != "" {
+		cfg.CertFile = s.path(name + ".pem")
+		cfg.KeyFile = s.path(name + ".key")
+	}
+	clientConfig, err := cfg.NewClientConfig()
+	s.NoError(err)
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	conn, err := NewTLSDialer(clientConfig)(ctx, "tcp", address)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_, err = conn.Read(make([]byte, 1))
+	return err
+}
+
+func (s *TLSSuite) leafCert(serial int64, commonName string) *x509.Certificate {
+	return &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+}
+
+// writeCert writes the certificate and its key as <name>.pem and <name>.key,
+// the certificate is self signed if no CA was written yet
+func (s *TLSSuite) writeCert(name string, template *x509.Certificate) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	s.NoError(err)
+	parent, parentKey := s.caCert, s.caKey
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	s.NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	s.NoError(err)
+	keyBytes, err := x509.MarshalECPrivateKey(key)
+	s.NoError(err)
+	s.NoError(ioutil.WriteFile(s.path(name+".pem"), pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	s.NoError(ioutil.WriteFile(s.path(name+".key"), pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600))
+	return cert, key
+}
+
+func (s *TLSSuite) path(name string) string {
+	return filepath.Join(s.dir, name)
+}
+
+func (h peerIdentityHandler) Handle(ctx context.Context, args *raw.Args) (*raw.Res, error) {
+	identity, _ := PeerIdentityFromContext(ctx)
+	return &raw.Res{Arg3: []byte(identity)}, nil
+}
+
+func (h peerIdentityHandler) OnError(ctx context.Context, err error) {}
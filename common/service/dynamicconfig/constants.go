@@ -57,13 +57,14 @@ var keys = map[Key]string{
 	EnableArchival:                  "system.enableArchival",
 
 	// size limit
-	BlobSizeLimitError:     "limit.blobSize.error",
-	BlobSizeLimitWarn:      "limit.blobSize.warn",
-	HistorySizeLimitError:  "limit.historySize.error",
-	HistorySizeLimitWarn:   "limit.historySize.warn",
-	HistoryCountLimitError: "limit.historyCount.error",
-	HistoryCountLimitWarn:  "limit.historyCount.warn",
-	MaxIDLengthLimit:       "limit.maxIDLength",
+	BlobSizeLimitError:               "limit.blobSize.error",
+	BlobSizeLimitWarn:                "limit.blobSize.warn",
+	PayloadOffloadBlobSizeLimitError: "limit.blobSize.payloadOffloadError",
+	HistorySizeLimitError:            "limit.historySize.error",
+	HistorySizeLimitWarn:             "limit.historySize.warn",
+	HistoryCountLimitError:           "limit.historyCount.error",
+	HistoryCountLimitWarn:            "limit.historyCount.warn",
+	MaxIDLengthLimit:                 "limit.maxIDLength",
 
 	// frontend settings
 	FrontendPersistenceMaxQPS:      "frontend.persistenceMaxQPS",
@@ -88,6 +89,7 @@ var keys = map[Key]string{
 	MaxTasklistIdleTime:                     "matching.maxTasklistIdleTime",
 	MatchingOutstandingTaskAppendsThreshold: "matching.outstandingTaskAppendsThreshold",
 	MatchingMaxTaskBatchSize:                "matching.maxTaskBatchSize",
+	MatchingPriorityStarvationInterval:      "matching.priorityStarvationInterval",
 
 	// history settings
 	HistoryRPS:                                            "history.rps",
@@ -154,6 +156,8 @@ var keys = map[Key]string{
 	AdminOperationToken:                                   "history.adminOperationToken",
 	EnableEventsV2:                                        "history.enableEventsV2",
 	NumSystemWorkflows:                                    "history.numSystemWorkflows",
+	EnableActivityEagerExecution:                          "history.enableActivityEagerExecution",
+	MaxActivityEagerExecutionPerDecision:                  "history.maxActivityEagerExecutionPerDecision",
 
 	WorkerPersistenceMaxQPS:                  "worker.persistenceMaxQPS",
 	WorkerReplicatorConcurrency:              "worker.replicatorConcurrency",
@@ -168,6 +172,22 @@ var keys = map[Key]string{
 	EnableArchivalCompression:                "worker.EnableArchivalCompression",
 	WorkerHistoryPageSize:                    "worker.WorkerHistoryPageSize",
 	WorkerTargetArchivalBlobSize:             "worker.WorkerTargetArchivalBlobSize",
+	HistoryScavengerEnabled:                  "worker.historyScavengerEnabled",
+	HistoryScavengerPersistenceMaxQPS:        "worker.historyScavengerPersistenceMaxQPS",
+	HistoryScavengerPageSize:                 "worker.historyScavengerPageSize",
+	HistoryScavengerGracePeriod:              "worker.historyScavengerGracePeriod",
+	TaskListScavengerEnabled:                 "worker.taskListScavengerEnabled",
+	TaskListScavengerPersistenceMaxQPS:       "worker.taskListScavengerPersistenceMaxQPS",
+	TaskListScavengerPageSize:                "worker.taskListScavengerPageSize",
+	TaskListScavengerIdleTime:                "worker.taskListScavengerIdleTime",
+	ExecutionsScannerEnabled:                 "worker.executionsScannerEnabled",
+	ExecutionsScannerPersistenceMaxQPS:       "worker.executionsScannerPersistenceMaxQPS",
+	ExecutionsScannerPageSize:                "worker.executionsScannerPageSize",
+	ExecutionsScannerFixEnabled:              "worker.executionsScannerFixEnabled",
+	ExecutionsScannerFixMode:                 "worker.executionsScannerFixMode",
+	DomainDeleterEnabled:                     "worker.domainDeleterEnabled",
+	DomainDeleterPersistenceMaxQPS:           "worker.domainDeleterPersistenceMaxQPS",
+	DomainDeleterPageSize:                    "worker.domainDeleterPageSize",
 }
 
 const (
@@ -206,6 +226,8 @@ const (
 	BlobSizeLimitError
 	// BlobSizeLimitWarn is the per event blob size limit for warning
 	BlobSizeLimitWarn
+	// PayloadOffloadBlobSizeLimitError is the per event blob size limit when large payloads are offloaded to the blobstore
+	PayloadOffloadBlobSizeLimitError
 	// HistorySizeLimitError is the per workflow execution history size limit
 	HistorySizeLimitError
 	// HistorySizeLimitWarn is the per workflow execution history size limit for warning
@@ -262,6 +284,9 @@ const (
 	MatchingOutstandingTaskAppendsThreshold
 	// MatchingMaxTaskBatchSize is max batch size for task writer
 	MatchingMaxTaskBatchSize
+	// MatchingPriorityStarvationInterval is the number of buffered task dispatches after which the
+	// oldest buffered task is dispatched regardless of its priority, 0 disables it
+	MatchingPriorityStarvationInterval
 
 	// key for history
 
@@ -383,7 +408,7 @@ const (
 	ShardUpdateMinInterval
 	// ShardSyncMinInterval is the minimal time interval which the shard info should be sync to remote
 	ShardSyncMinInterval
-	// DefaultEventEncoding is the encoding type for history events
+	// DefaultEventEncoding is the encoding type for history events, one of json, thriftrw, thriftrw-snappy or thriftrw-gzip
 	DefaultEventEncoding
 	// NumSystemWorkflows is key for number of system workflows running in total
 	NumSystemWorkflows
@@ -396,6 +421,11 @@ const (
 	// EnableEventsV2 is whether to use eventsV2
 	EnableEventsV2
 
+	// EnableActivityEagerExecution is whether activities can be started eagerly when the decision scheduling them is completed
+	EnableActivityEagerExecution
+	// MaxActivityEagerExecutionPerDecision is max number of activities started eagerly by a single decision
+	MaxActivityEagerExecutionPerDecision
+
 	// key for worker
 
 	// WorkerPersistenceMaxQPS is the max qps worker host can query DB
@@ -424,6 +454,38 @@ const (
 	WorkerHistoryPageSize
 	// WorkerTargetArchivalBlobSize indicates the target blob size in bytes for archival, actual blob size may vary
 	WorkerTargetArchivalBlobSize
+	// HistoryScavengerEnabled indicates whether the history scavenger is started by the worker service
+	HistoryScavengerEnabled
+	// HistoryScavengerPersistenceMaxQPS is the max qps the history scavenger can query DB
+	HistoryScavengerPersistenceMaxQPS
+	// HistoryScavengerPageSize is the number of history branches read from DB per page by the history scavenger
+	HistoryScavengerPageSize
+	// HistoryScavengerGracePeriod is how old a history branch must be before the history scavenger may delete it
+	HistoryScavengerGracePeriod
+	// TaskListScavengerEnabled indicates whether the task list scavenger is started by the worker service
+	TaskListScavengerEnabled
+	// TaskListScavengerPersistenceMaxQPS is the max qps the task list scavenger can query DB
+	TaskListScavengerPersistenceMaxQPS
+	// TaskListScavengerPageSize is the number of task lists or tasks read / deleted per DB call by the task list scavenger
+	TaskListScavengerPageSize
+	// TaskListScavengerIdleTime is how long a task list must have had no activity before the task list scavenger may delete it
+	TaskListScavengerIdleTime
+	// ExecutionsScannerEnabled indicates whether the executions scanner is started by the worker service
+	ExecutionsScannerEnabled
+	// ExecutionsScannerPersistenceMaxQPS is the max qps the executions scanner can query DB
+	ExecutionsScannerPersistenceMaxQPS
+	// ExecutionsScannerPageSize is the number of executions read per DB call by the executions scanner
+	ExecutionsScannerPageSize
+	// ExecutionsScannerFixEnabled indicates whether the executions scanner fixes the corrupted executions it finds
+	ExecutionsScannerFixEnabled
+	// ExecutionsScannerFixMode is how the executions scanner fixes corrupted executions, either delete or reset
+	ExecutionsScannerFixMode
+	// DomainDeleterEnabled indicates whether the domain deletion workflow is allowed to delete domains
+	DomainDeleterEnabled
+	// DomainDeleterPersistenceMaxQPS is the max qps the domain deletion workflow can query DB
+	DomainDeleterPersistenceMaxQPS
+	// DomainDeleterPageSize is the number of records read / deleted per DB call by the domain deletion workflow
+	DomainDeleterPageSize
 
 	// lastKeyForTest must be the last one in this const group for testing purpose
 	lastKeyForTest
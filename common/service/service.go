@@ -21,6 +21,7 @@
 package service
 
 import (
+	"crypto/tls"
 	"math/rand"
 	"os"
 	"sync/atomic"
@@ -60,6 +61,7 @@ type (
 		Logger              bark.Logger
 		MetricScope         tally.Scope
 		RingpopFactory      RingpopFactory
+		MembershipFactory   MembershipFactory
 		RPCFactory          common.RPCFactory
 		PProfInitializer    common.PProfInitializer
 		PersistenceConfig   config.Persistence
@@ -73,6 +75,8 @@ type (
 		DispatcherProvider  client.DispatcherProvider
 		BlobstoreClient     blobstore.Client
 		DCRedirectionPolicy config.DCRedirectionPolicy
+		HTTPGatewayAddress  string
+		HTTPGatewayTLS      *tls.Config
 	}
 
 	// RingpopFactory provides a bootstrapped ringpop
@@ -81,6 +85,13 @@ type (
 		CreateRingpop(d *yarpc.Dispatcher) (*ringpop.Ringpop, error)
 	}
 
+	// MembershipFactory provides a membership monitor which is not based on ringpop,
+	// ringpop is used when it is not set
+	MembershipFactory interface {
+		// CreateMembershipMonitor vends a membership monitor of the given services
+		CreateMembershipMonitor(serviceName string, services []string, d *yarpc.Dispatcher) (membership.Monitor, error)
+	}
+
 	// Service contains the objects specific to this service
 	serviceImpl struct {
 		status                 int32
@@ -90,6 +101,7 @@ type (
 		dispatcher             *yarpc.Dispatcher
 		rp                     *ringpop.Ringpop
 		rpFactory              RingpopFactory
+		membershipFactory      MembershipFactory
 		membershipMonitor      membership.Monitor
 		rpcFactory             common.RPCFactory
 		pprofInitializer       common.PProfInitializer
@@ -115,6 +127,7 @@ func New(params *BootstrapParams) Service {
 		logger:                params.Logger,
 		rpcFactory:            params.RPCFactory,
 		rpFactory:             params.RingpopFactory,
+		membershipFactory:     params.MembershipFactory,
 		pprofInitializer:      params.PProfInitializer,
 		metricsScope:          params.MetricScope,
 		numberOfHistoryShards: params.PersistenceConfig.NumHistoryShards,
@@ -168,22 +181,14 @@ func (h *serviceImpl) Start() {
 		h.logger.WithFields(bark.Fields{logging.TagErr: err}).Fatal("Failed to start yarpc dispatcher")
 	}
 
-	// use actual listen port (in case service is bound to :0 or 0.0.0.0:0)
-	h.rp, err = h.rpFactory.CreateRingpop(h.dispatcher)
-	if err != nil {
-		h.logger.WithFields(bark.Fields{logging.TagErr: err}).Fatal("Ringpop creation failed")
-	}
-
-	labels, err := h.rp.Labels()
-	if err != nil {
-		h.logger.WithFields(bark.Fields{logging.TagErr: err}).Fatal("Ringpop get node labels failed")
-	}
-	err = labels.Set(membership.RoleKey, h.sName)
-	if err != nil {
-		h.logger.WithFields(bark.Fields{logging.TagErr: err}).Fatal("Ringpop setting role label failed")
+	if h.membershipFactory != nil {
+		h.membershipMonitor, err = h.membershipFactory.CreateMembershipMonitor(h.sName, cadenceServices, h.dispatcher)
+		if err != nil {
+			h.logger.WithFields(bark.Fields{logging.TagErr: err}).Fatal("Membership monitor creation failed")
+		}
+	} else {
+		h.membershipMonitor = h.createRingpopMonitor()
 	}
-
-	h.membershipMonitor = membership.NewRingpopMonitor(cadenceServices, h.rp, h.logger)
 	err = h.membershipMonitor.Start()
 	if err != nil {
 		h.logger.WithFields(bark.Fields{logging.TagErr: err}).Fatal("starting membership monitor failed")
@@ -211,6 +216,27 @@ func (h *serviceImpl) Start() {
 	rand.Seed(time.Now().UTC().UnixNano())
 }
 
+// createRingpopMonitor bootstraps ringpop and returns the membership monitor based on it
+func (h *serviceImpl) createRingpopMonitor() membership.Monitor {
+	var err error
+	// use actual listen port (in case service is bound to :0 or 0.0.0.0:0)
+	h.rp, err = h.rpFactory.CreateRingpop(h.dispatcher)
+	if err != nil {
+		h.logger.WithFields(bark.Fields{logging.TagErr: err}).Fatal("Ringpop creation failed")
+	}
+
+	labels, err := h.rp.Labels()
+	if err != nil {
+		h.logger.WithFields(bark.Fields{logging.TagErr: err}).Fatal("Ringpop get node labels failed")
+	}
+	err = labels.Set(membership.RoleKey, h.sName)
+	if err != nil {
+		h.logger.WithFields(bark.Fields{logging.TagErr: err}).Fatal("Ringpop setting role label failed")
+	}
+
+	return membership.NewRingpopMonitor(cadenceServices, h.rp, h.logger)
+}
+
 // Stop closes the associated transport
 func (h *serviceImpl) Stop() {
 	if !atomic.CompareAndSwapInt32(&h.status, common.DaemonStatusStarted, common.DaemonStatusStopped) {
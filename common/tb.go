@@ -21,6 +21,7 @@
 package common
 
 import (
+	"context"
 	"sync"
 	"time"
 )
@@ -93,6 +94,8 @@ type (
 const (
 	millisPerSecond = 1000
 	backoffInterval = int64(10 * time.Millisecond)
+	// tokenWaitTimeout is how long a single attempt to get a token may block in WaitForToken
+	tokenWaitTimeout = time.Second
 )
 
 // NewTokenBucket creates and returns a
@@ -142,6 +145,21 @@ func (f *tokenBucketFactoryImpl) CreateTokenBucket(rps int, timeSource TimeSourc
 	return NewTokenBucket(rps, timeSource)
 }
 
+// NewRealTimeTokenBucket creates a token bucket of rps tokens per second that uses the real time source
+func NewRealTimeTokenBucket(rps int) TokenBucket {
+	return NewTokenBucket(rps, NewRealTimeSource())
+}
+
+// WaitForToken blocks until the token bucket hands out a token or the context is done
+func WaitForToken(ctx context.Context, tb TokenBucket) error {
+	for !tb.Consume(1, tokenWaitTimeout) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
 func (tb *tokenBucketImpl) TryConsume(count int) (bool, time.Duration) {
 	now := tb.timeSource.Now().UnixNano()
 	tb.Lock()
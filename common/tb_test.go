@@ -21,6 +21,7 @@
 package common
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -55,6 +56,19 @@ func (ts *mockTimeSource) advance(d time.Duration) {
 	ts.currTime = ts.currTime.Add(d)
 }
 
+func (s *TokenBucketSuite) TestWaitForToken() {
+	ts := &mockTimeSource{currTime: time.Now()}
+	tb := NewTokenBucket(10, ts)
+	s.NoError(WaitForToken(context.Background(), tb))
+
+	// the time source never advances, so no token is handed out once the bucket is drained
+	for ok := true; ok; ok, _ = tb.TryConsume(1) {
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	s.Equal(context.DeadlineExceeded, WaitForToken(ctx, tb))
+}
+
 func (s *TokenBucketSuite) TestRpsEnforced() {
 	ts := &mockTimeSource{currTime: time.Now()}
 	tb := NewTokenBucket(99, ts)
@@ -22,6 +22,7 @@ package common
 
 import (
 	"encoding/json"
+	"fmt"
 	"sync"
 	"time"
 
@@ -325,6 +326,15 @@ func GenerateRandomString(n int) string {
 	return string(b)
 }
 
+// GetDecisionScheduleAttempt returns the attempt identifying the started decision task in its task token, falling back
+// to the attempt of the decision for history hosts which do not count decision heartbeats separately
+func GetDecisionScheduleAttempt(historyResponse *h.RecordDecisionTaskStartedResponse) int64 {
+	if historyResponse.ScheduleAttempt != nil {
+		return historyResponse.GetScheduleAttempt()
+	}
+	return historyResponse.GetAttempt()
+}
+
 // CreateMatchingPollForDecisionTaskResponse create response for matching's PollForDecisionTask
 func CreateMatchingPollForDecisionTaskResponse(historyResponse *h.RecordDecisionTaskStartedResponse, workflowExecution *workflow.WorkflowExecution, token []byte) *m.PollForDecisionTaskResponse {
 	matchingResp := &m.PollForDecisionTaskResponse{
@@ -339,6 +349,7 @@ func CreateMatchingPollForDecisionTaskResponse(historyResponse *h.RecordDecision
 		WorkflowExecutionTaskList: historyResponse.WorkflowExecutionTaskList,
 		EventStoreVersion:         historyResponse.EventStoreVersion,
 		BranchToken:               historyResponse.BranchToken,
+		Queries:                   historyResponse.Queries,
 	}
 	if historyResponse.GetPreviousStartedEventId() != EmptyEventID {
 		matchingResp.PreviousStartedEventId = historyResponse.PreviousStartedEventId
@@ -384,6 +395,14 @@ func ValidateRetryPolicy(policy *workflow.RetryPolicy) error {
 	return nil
 }
 
+// ValidatePriority validates the dispatch priority of a workflow or an activity
+func ValidatePriority(priority int32) error {
+	if priority < 0 || priority > MaxTaskPriority {
+		return &workflow.BadRequestError{Message: fmt.Sprintf("Priority must be between 0 and %v.", MaxTaskPriority)}
+	}
+	return nil
+}
+
 // ValidateCronSchedule validates a cron schedule spec
 func ValidateCronSchedule(cronSchedule string) error {
 	if cronSchedule == "" {
@@ -401,8 +420,12 @@ func CreateHistoryStartWorkflowRequest(domainID string, startRequest *workflow.S
 		DomainUUID:   StringPtr(domainID),
 		StartRequest: startRequest,
 	}
+	delayStartSeconds := startRequest.GetDelayStartSeconds()
+	if delayStartSeconds > 0 {
+		histRequest.FirstDecisionTaskBackoffSeconds = Int32Ptr(delayStartSeconds)
+	}
 	if startRequest.RetryPolicy != nil && startRequest.RetryPolicy.GetExpirationIntervalInSeconds() > 0 {
-		expirationInSeconds := startRequest.RetryPolicy.GetExpirationIntervalInSeconds()
+		expirationInSeconds := startRequest.RetryPolicy.GetExpirationIntervalInSeconds() + delayStartSeconds
 		deadline := time.Now().Add(time.Second * time.Duration(expirationInSeconds))
 		histRequest.ExpirationTimestamp = Int64Ptr(deadline.Round(time.Millisecond).UnixNano())
 	}
@@ -58,6 +58,82 @@ service AdminService {
       3: shared.EntityNotExistsError entityNotExistError,
       4: shared.ServiceBusyError serviceBusyError,
     )
+
+  /**
+  * CloseShard closes the shard on the history host owning it and drops its in memory state. The shard stays owned
+  * by the same host, which reloads it from persistence on next request or shard acquisition.
+  **/
+  void CloseShard(1: shared.CloseShardRequest request)
+    throws (
+      1: shared.BadRequestError       badRequestError,
+      2: shared.InternalServiceError  internalServiceError,
+      3: shared.AccessDeniedError     accessDeniedError,
+    )
+
+  /**
+  * DescribeShard returns information about the persisted state of a shard
+  **/
+  shared.DescribeShardResponse DescribeShard(1: shared.DescribeShardRequest request)
+    throws (
+      1: shared.BadRequestError       badRequestError,
+      2: shared.InternalServiceError  internalServiceError,
+      3: shared.EntityNotExistsError  entityNotExistError,
+      4: shared.AccessDeniedError     accessDeniedError,
+    )
+
+  /**
+  * DrainHistoryHost removes the history host from membership ring so that all its shards are moved to other hosts.
+  * It is meant to be called before shutting the host down, the host does not re-join the ring until it is restarted.
+  * Draining is not supported when the membership is not managed by the hosts themselves (static or dns membership).
+  **/
+  void DrainHistoryHost(1: shared.DrainHistoryHostRequest request)
+    throws (
+      1: shared.BadRequestError       badRequestError,
+      2: shared.InternalServiceError  internalServiceError,
+      3: shared.AccessDeniedError     accessDeniedError,
+    )
+
+  /**
+  * ListQueueTasks returns the pending transfer, timer or replication tasks of a shard
+  **/
+  shared.ListQueueTasksResponse ListQueueTasks(1: shared.ListQueueTasksRequest request)
+    throws (
+      1: shared.BadRequestError       badRequestError,
+      2: shared.InternalServiceError  internalServiceError,
+      3: shared.AccessDeniedError     accessDeniedError,
+    )
+
+  /**
+  * RemoveQueueTask removes a transfer, timer or replication task from the queue of a shard
+  **/
+  void RemoveQueueTask(1: shared.RemoveQueueTaskRequest request)
+    throws (
+      1: shared.BadRequestError       badRequestError,
+      2: shared.InternalServiceError  internalServiceError,
+      3: shared.AccessDeniedError     accessDeniedError,
+    )
+
+  /**
+  * RegenerateWorkflowTasks re-generates the transfer and timer tasks of a workflow from its mutable state
+  **/
+  void RegenerateWorkflowTasks(1: RegenerateWorkflowTasksRequest request)
+    throws (
+      1: shared.BadRequestError       badRequestError,
+      2: shared.InternalServiceError  internalServiceError,
+      3: shared.EntityNotExistsError  entityNotExistError,
+      4: shared.AccessDeniedError     accessDeniedError,
+    )
+
+  /**
+  * PromoteTaskListBuildID makes a worker build the default for new workflows on a decision tasklist. Decision tasks
+  * of a workflow are only dispatched to pollers whose build is compatible with the build the workflow is pinned to.
+  **/
+  void PromoteTaskListBuildID(1: shared.PromoteTaskListBuildIDRequest request)
+    throws (
+      1: shared.BadRequestError       badRequestError,
+      2: shared.InternalServiceError  internalServiceError,
+      3: shared.AccessDeniedError     accessDeniedError,
+    )
 }
 
 struct DescribeWorkflowExecutionRequest {
@@ -65,6 +141,11 @@ struct DescribeWorkflowExecutionRequest {
   20: optional shared.WorkflowExecution     execution
 }
 
+struct RegenerateWorkflowTasksRequest {
+  10: optional string                       domain
+  20: optional shared.WorkflowExecution     execution
+}
+
 struct DescribeWorkflowExecutionResponse{
   10: optional string shardId
   20: optional string historyAddr
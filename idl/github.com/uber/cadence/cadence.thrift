@@ -192,6 +192,23 @@ service WorkflowService {
       6: shared.DomainNotActiveError domainNotActiveError,
     )
 
+  /**
+  * PollForActivityCancellation is called by application worker to learn about the cancellation of the activities it
+  * is processing from a specific taskList, without waiting for the 'cancelRequested' flag of the next heartbeat.  It
+  * returns the 'taskToken' of an activity started by the same worker identity once a 'RequestCancelActivityTask'
+  * decision is made for it, or an empty response when the long poll expires.  Notifications are best effort, so
+  * workers are still expected to heartbeat.
+  **/
+  shared.PollForActivityCancellationResponse PollForActivityCancellation(1: shared.PollForActivityCancellationRequest pollRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.ServiceBusyError serviceBusyError,
+      4: shared.LimitExceededError limitExceededError,
+      5: shared.EntityNotExistsError entityNotExistError,
+      6: shared.DomainNotActiveError domainNotActiveError,
+    )
+
   /**
   * RecordActivityTaskHeartbeat is called by application worker while it is processing an ActivityTask.  If worker fails
   * to heartbeat within 'heartbeatTimeoutSeconds' interval for the ActivityTask, then it will be marked as timedout and
@@ -405,6 +422,35 @@ service WorkflowService {
       6: shared.LimitExceededError limitExceededError,
     )
 
+  /**
+  * PauseWorkflowExecution pauses an existing workflow execution by recording WorkflowExecutionPaused event
+  * in the history. While paused no decision or activity task is dispatched for the execution, signals are
+  * still accepted and timers firing in the meantime are delivered once the execution is unpaused.
+  **/
+  void PauseWorkflowExecution(1: shared.PauseWorkflowExecutionRequest pauseRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+    )
+
+  /**
+  * UnpauseWorkflowExecution resumes a paused workflow execution by recording WorkflowExecutionUnpaused event
+  * in the history and dispatching the decision and activity tasks held back while it was paused.
+  **/
+  void UnpauseWorkflowExecution(1: shared.UnpauseWorkflowExecutionRequest unpauseRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.ServiceBusyError serviceBusyError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+    )
+
   /**
   * ListOpenWorkflowExecutions is a visibility API to list the open executions in a specific domain.
   **/
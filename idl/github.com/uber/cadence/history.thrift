@@ -56,6 +56,11 @@ struct DescribeMutableStateRequest{
   20: optional shared.WorkflowExecution execution
 }
 
+struct RegenerateWorkflowTasksRequest {
+  10: optional string domainUUID
+  20: optional shared.WorkflowExecution execution
+}
+
 struct DescribeMutableStateResponse{
   30: optional string mutableStateInCache
   40: optional string mutableStateInDatabase
@@ -83,6 +88,9 @@ struct GetMutableStateResponse {
   120: optional i32 eventStoreVersion
   130: optional binary branchToken
   140: optional map<string, shared.ReplicationInfo> replicationInfo
+  150: optional shared.WorkflowExecutionCloseStatus workflowCloseStatus
+  160: optional bool hasPendingDecision
+  170: optional string buildID
 }
 
 struct ResetStickyTaskListRequest {
@@ -102,6 +110,7 @@ struct RespondDecisionTaskCompletedRequest {
 
 struct RespondDecisionTaskCompletedResponse {
   10: optional RecordDecisionTaskStartedResponse startedResponse
+  20: optional list<shared.PollForActivityTaskResponse> activityTasks
 }
 
 struct RespondDecisionTaskFailedRequest {
@@ -169,6 +178,10 @@ struct RecordDecisionTaskStartedResponse {
   90: optional shared.TaskList WorkflowExecutionTaskList
   100: optional i32 eventStoreVersion
   110: optional binary branchToken
+  // attempt of the decision task including its heartbeats, identifies the decision task in task tokens
+  120: optional i64 (js.type = "Long") scheduleAttempt
+  // strongly consistent queries to answer with the completion of the decision task, keyed by query ID
+  130: optional map<string, shared.WorkflowQuery> queries
 }
 
 struct SignalWorkflowExecutionRequest {
@@ -194,6 +207,26 @@ struct TerminateWorkflowExecutionRequest {
   20: optional shared.TerminateWorkflowExecutionRequest terminateRequest
 }
 
+struct QueryWorkflowRequest {
+  10: optional string domainUUID
+  20: optional shared.QueryWorkflowRequest request
+}
+
+struct QueryWorkflowResponse {
+  // not set when the workflow has no pending decision task, the query can then be dispatched to a worker directly
+  10: optional shared.QueryWorkflowResponse response
+}
+
+struct PauseWorkflowExecutionRequest {
+  10: optional string domainUUID
+  20: optional shared.PauseWorkflowExecutionRequest pauseRequest
+}
+
+struct UnpauseWorkflowExecutionRequest {
+  10: optional string domainUUID
+  20: optional shared.UnpauseWorkflowExecutionRequest unpauseRequest
+}
+
 struct ResetWorkflowExecutionRequest {
   10: optional string domainUUID
   20: optional shared.ResetWorkflowExecutionRequest resetRequest
@@ -314,6 +347,23 @@ service HistoryService {
       6: shared.ServiceBusyError serviceBusyError,
     )
 
+  /**
+  * QueryWorkflow delivers a strongly consistent query with the next decision task of the workflow, so that it is
+  * answered after all the events recorded before it were processed. It returns an empty response when the workflow
+  * has no pending decision task, the query can then be dispatched to a worker directly.
+  **/
+  QueryWorkflowResponse QueryWorkflow(1: QueryWorkflowRequest queryRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.QueryFailedError queryFailedError,
+      6: shared.DomainNotActiveError domainNotActiveError,
+      7: shared.LimitExceededError limitExceededError,
+      8: shared.ServiceBusyError serviceBusyError,
+    )
+
   /**
   * Reset the sticky tasklist related information in mutable state of a given workflow.
   * Things cleared are:
@@ -537,6 +587,36 @@ service HistoryService {
       7: shared.ServiceBusyError serviceBusyError,
     )
 
+  /**
+  * PauseWorkflowExecution pauses an existing workflow execution by recording WorkflowExecutionPaused event
+  * in the history, decision and activity tasks are held back until the execution is unpaused.
+  **/
+  void PauseWorkflowExecution(1: PauseWorkflowExecutionRequest pauseRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+      7: shared.ServiceBusyError serviceBusyError,
+    )
+
+  /**
+  * UnpauseWorkflowExecution resumes a paused workflow execution by recording WorkflowExecutionUnpaused event
+  * in the history and dispatching the tasks held back while it was paused.
+  **/
+  void UnpauseWorkflowExecution(1: UnpauseWorkflowExecutionRequest unpauseRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+      6: shared.LimitExceededError limitExceededError,
+      7: shared.ServiceBusyError serviceBusyError,
+    )
+
   /**
   * ResetWorkflowExecution reset an existing workflow execution by a firstEventID of a existing event batch
   * in the history and immediately terminating the current execution instance.
@@ -685,4 +765,74 @@ service HistoryService {
       2: shared.InternalServiceError internalServiceError,
       3: shared.AccessDeniedError accessDeniedError,
     )
+
+  /**
+  * CloseShard closes the shard on the history host owning it and drops its in memory state. The shard stays owned
+  * by the same host, which reloads it from persistence on next request or shard acquisition.
+  **/
+  void CloseShard(1: shared.CloseShardRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.AccessDeniedError accessDeniedError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+    )
+
+  /**
+  * DescribeShard returns information about the persisted state of a shard
+  **/
+  shared.DescribeShardResponse DescribeShard(1: shared.DescribeShardRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.AccessDeniedError accessDeniedError,
+      5: ShardOwnershipLostError shardOwnershipLostError,
+    )
+
+  /**
+  * DrainHistoryHost removes the history host from membership ring so that all its shards are moved to other hosts.
+  * It is meant to be called before shutting the host down, the host does not re-join the ring until it is restarted.
+  * Draining is not supported when the membership is not managed by the hosts themselves (static or dns membership).
+  **/
+  void DrainHistoryHost(1: shared.DrainHistoryHostRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.AccessDeniedError accessDeniedError,
+    )
+
+  /**
+  * ListQueueTasks returns the pending transfer, timer or replication tasks of a shard
+  **/
+  shared.ListQueueTasksResponse ListQueueTasks(1: shared.ListQueueTasksRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.AccessDeniedError accessDeniedError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+    )
+
+  /**
+  * RemoveQueueTask removes a transfer, timer or replication task from the queue of a shard
+  **/
+  void RemoveQueueTask(1: shared.RemoveQueueTaskRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.AccessDeniedError accessDeniedError,
+      4: ShardOwnershipLostError shardOwnershipLostError,
+    )
+
+  /**
+  * RegenerateWorkflowTasks re-generates the transfer and timer tasks of a workflow from its mutable state
+  **/
+  void RegenerateWorkflowTasks(1: RegenerateWorkflowTasksRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.EntityNotExistsError entityNotExistError,
+      4: shared.AccessDeniedError accessDeniedError,
+      5: ShardOwnershipLostError shardOwnershipLostError,
+    )
 }
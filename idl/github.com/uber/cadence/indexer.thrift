@@ -51,4 +51,13 @@ struct Message {
   40: optional string runID
   50: optional i64 (js.type = "Long") version
   60: optional IndexAttributes indexAttributes
-}
\ No newline at end of file
+}
+
+// DLQMessage wraps a message that failed to be indexed with the error reported by ElasticSearch
+struct DLQMessage {
+  10: optional Message message
+  20: optional i32 status
+  30: optional string errorType
+  40: optional string errorReason
+  50: optional i64 (js.type = "Long") timestamp
+}
@@ -43,6 +43,7 @@ struct PollForDecisionTaskResponse {
   100: optional shared.TaskList WorkflowExecutionTaskList
   110: optional i32 eventStoreVersion
   120: optional binary branchToken
+  130: optional map<string, shared.WorkflowQuery> queries
 }
 
 struct PollForActivityTaskRequest {
@@ -57,6 +58,8 @@ struct AddDecisionTaskRequest {
   30: optional shared.TaskList taskList
   40: optional i64 (js.type = "Long") scheduleId
   50: optional i32 scheduleToStartTimeoutSeconds
+  60: optional i32 priority
+  70: optional string buildID
 }
 
 struct AddActivityTaskRequest {
@@ -66,12 +69,29 @@ struct AddActivityTaskRequest {
   40: optional shared.TaskList taskList
   50: optional i64 (js.type = "Long") scheduleId
   60: optional i32 scheduleToStartTimeoutSeconds
+  70: optional i32 priority
+}
+
+struct AddActivityCancellationRequest {
+  10: optional string domainUUID
+  20: optional shared.WorkflowExecution execution
+  30: optional shared.TaskList taskList
+  40: optional string identity
+  50: optional binary taskToken
+  60: optional string activityId
+}
+
+struct PollForActivityCancellationRequest {
+  10: optional string domainUUID
+  15: optional string pollerID
+  20: optional shared.PollForActivityCancellationRequest pollRequest
 }
 
 struct QueryWorkflowRequest {
   10: optional string domainUUID
   20: optional shared.TaskList taskList
   30: optional shared.QueryWorkflowRequest queryRequest
+  40: optional string buildID
 }
 
 struct RespondQueryTaskCompletedRequest {
@@ -93,6 +113,11 @@ struct DescribeTaskListRequest {
   20: optional shared.DescribeTaskListRequest descRequest
 }
 
+struct PromoteTaskListBuildIDRequest {
+  10: optional string domainUUID
+  20: optional shared.PromoteTaskListBuildIDRequest promoteRequest
+}
+
 /**
 * MatchingService API is exposed to provide support for polling from long running applications.
 * Such applications are expected to have a worker which regularly polls for DecisionTask and ActivityTask.  For each
@@ -151,6 +176,31 @@ service MatchingService {
       5: shared.DomainNotActiveError domainNotActiveError,
     )
 
+  /**
+  * AddActivityCancellation is called by the history service when the cancellation of a started activity is
+  * requested, so that it can be dispatched to the worker which started the activity by the MatchingEngine.
+  **/
+  void AddActivityCancellation(1: AddActivityCancellationRequest addRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.ServiceBusyError serviceBusyError,
+      4: shared.LimitExceededError limitExceededError,
+      5: shared.DomainNotActiveError domainNotActiveError,
+    )
+
+  /**
+  * PollForActivityCancellation is called by frontend to learn about the cancellation of activities started by a
+  * worker from a specific taskList.
+  **/
+  shared.PollForActivityCancellationResponse PollForActivityCancellation(1: PollForActivityCancellationRequest pollRequest)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.LimitExceededError limitExceededError,
+      4: shared.ServiceBusyError serviceBusyError,
+    )
+
   /**
   * QueryWorkflow is called by frontend to query a workflow.
   **/
@@ -203,4 +253,14 @@ service MatchingService {
         3: shared.EntityNotExistsError entityNotExistError,
         4: shared.ServiceBusyError serviceBusyError,
       )
+
+  /**
+  * PromoteTaskListBuildID makes a worker build the default for new workflows on a decision tasklist.
+  **/
+  void PromoteTaskListBuildID(1: PromoteTaskListBuildIDRequest request)
+    throws (
+      1: shared.BadRequestError badRequestError,
+      2: shared.InternalServiceError internalServiceError,
+      3: shared.ServiceBusyError serviceBusyError,
+    )
 }
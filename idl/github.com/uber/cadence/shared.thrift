@@ -169,6 +169,8 @@ enum EventType {
   SignalExternalWorkflowExecutionInitiated,
   SignalExternalWorkflowExecutionFailed,
   ExternalWorkflowExecutionSignaled,
+  WorkflowExecutionPaused,
+  WorkflowExecutionUnpaused,
 }
 
 enum DecisionTaskFailedCause {
@@ -226,6 +228,20 @@ enum QueryTaskCompletedType {
   FAILED,
 }
 
+enum QueryRejectCondition {
+  // NOT_OPEN indicates that query should be rejected if workflow is not open
+  NOT_OPEN,
+  // NOT_COMPLETED_CLEANLY indicates that query should be rejected if workflow did not complete cleanly
+  NOT_COMPLETED_CLEANLY,
+}
+
+enum QueryConsistencyLevel {
+  // EVENTUAL indicates that query should be eventually consistent
+  EVENTUAL,
+  // STRONG indicates that any events that came before query should be reflected in workflow state before running query
+  STRONG,
+}
+
 enum PendingActivityState {
   SCHEDULED,
   STARTED,
@@ -295,6 +311,7 @@ struct WorkflowExecutionInfo {
   40: optional i64 (js.type = "Long") closeTime
   50: optional WorkflowExecutionCloseStatus closeStatus
   60: optional i64 (js.type = "Long") historyLength
+  70: optional bool paused
 }
 
 struct WorkflowExecutionConfiguration {
@@ -320,6 +337,8 @@ struct ScheduleActivityTaskDecisionAttributes {
   55: optional i32 startToCloseTimeoutSeconds
   60: optional i32 heartbeatTimeoutSeconds
   70: optional RetryPolicy retryPolicy
+  80: optional i32 priority
+  90: optional bool requestEagerExecution
 }
 
 struct RequestCancelActivityTaskDecisionAttributes {
@@ -438,6 +457,7 @@ struct WorkflowExecutionStartedEventAttributes {
   90: optional i64 (js.type = "Long") expirationTimestamp
   100: optional string cronSchedule
   110: optional i32 firstDecisionTaskBackoffSeconds
+  120: optional i32 priority
 }
 
 struct WorkflowExecutionCompletedEventAttributes {
@@ -527,6 +547,7 @@ struct ActivityTaskScheduledEventAttributes {
   60: optional i32 heartbeatTimeoutSeconds
   90: optional i64 (js.type = "Long") decisionTaskCompletedEventId
   110: optional RetryPolicy retryPolicy
+  120: optional i32 priority
 }
 
 struct ActivityTaskStartedEventAttributes {
@@ -633,6 +654,18 @@ struct WorkflowExecutionTerminatedEventAttributes {
   30: optional string identity
 }
 
+struct WorkflowExecutionPausedEventAttributes {
+  10: optional string reason
+  20: optional binary details
+  30: optional string identity
+}
+
+struct WorkflowExecutionUnpausedEventAttributes {
+  10: optional string reason
+  20: optional binary details
+  30: optional string identity
+}
+
 struct RequestCancelExternalWorkflowExecutionInitiatedEventAttributes {
   10: optional i64 (js.type = "Long") decisionTaskCompletedEventId
   20: optional string domain
@@ -806,6 +839,8 @@ struct HistoryEvent {
   420: optional SignalExternalWorkflowExecutionInitiatedEventAttributes signalExternalWorkflowExecutionInitiatedEventAttributes
   430: optional SignalExternalWorkflowExecutionFailedEventAttributes signalExternalWorkflowExecutionFailedEventAttributes
   440: optional ExternalWorkflowExecutionSignaledEventAttributes externalWorkflowExecutionSignaledEventAttributes
+  450: optional WorkflowExecutionPausedEventAttributes workflowExecutionPausedEventAttributes
+  460: optional WorkflowExecutionUnpausedEventAttributes workflowExecutionUnpausedEventAttributes
 }
 
 struct History {
@@ -874,9 +909,16 @@ struct RegisterDomainRequest {
   110: optional string archivalBucketName
 }
 
+// ListDomainsRequest filters are applied to each page of domains read from the store, so a page of the
+// response can hold fewer than pageSize domains, or none, and still have a nextPageToken
 struct ListDomainsRequest {
   10: optional i32 pageSize
   20: optional binary nextPageToken
+  30: optional DomainStatus status
+  40: optional string activeClusterName
+  50: optional bool isGlobalDomain
+  // only domains whose data contains all of these key value pairs are returned
+  60: optional map<string,string> data
 }
 
 struct ListDomainsResponse {
@@ -931,6 +973,8 @@ struct StartWorkflowExecutionRequest {
   110: optional ChildPolicy childPolicy
   120: optional RetryPolicy retryPolicy
   130: optional string cronSchedule
+  140: optional i32 delayStartSeconds
+  150: optional i32 priority
 }
 
 struct StartWorkflowExecutionResponse {
@@ -941,6 +985,7 @@ struct PollForDecisionTaskRequest {
   10: optional string domain
   20: optional TaskList taskList
   30: optional string identity
+  40: optional string binaryChecksum
 }
 
 struct PollForDecisionTaskResponse {
@@ -955,6 +1000,8 @@ struct PollForDecisionTaskResponse {
   70: optional binary nextPageToken
   80: optional WorkflowQuery query
   90: optional TaskList WorkflowExecutionTaskList
+  // queries to answer with the completion of this decision task, keyed by query ID
+  100: optional map<string, WorkflowQuery> queries
 }
 
 struct StickyExecutionAttributes {
@@ -971,10 +1018,13 @@ struct RespondDecisionTaskCompletedRequest {
   60: optional bool returnNewDecisionTask
   70: optional bool forceCreateNewDecisionTask
   80: optional string binaryChecksum
+  // answers to the queries delivered with the decision task, keyed by query ID
+  90: optional map<string, WorkflowQueryResult> queryResults
 }
 
 struct RespondDecisionTaskCompletedResponse {
   10: optional PollForDecisionTaskResponse decisionTask
+  20: optional list<PollForActivityTaskResponse> activityTasks
 }
 
 struct RespondDecisionTaskFailedRequest {
@@ -991,6 +1041,18 @@ struct PollForActivityTaskRequest {
   40: optional TaskListMetadata taskListMetadata
 }
 
+struct PollForActivityCancellationRequest {
+  10: optional string domain
+  20: optional TaskList taskList
+  30: optional string identity
+}
+
+struct PollForActivityCancellationResponse {
+  10: optional binary taskToken
+  20: optional WorkflowExecution workflowExecution
+  30: optional string activityId
+}
+
 struct PollForActivityTaskResponse {
   10:  optional binary taskToken
   20:  optional WorkflowExecution workflowExecution
@@ -1122,6 +1184,7 @@ struct SignalWithStartWorkflowExecutionRequest {
   130: optional binary control
   140: optional RetryPolicy retryPolicy
   150: optional string cronSchedule
+  160: optional i32 delayStartSeconds
 }
 
 struct TerminateWorkflowExecutionRequest {
@@ -1132,6 +1195,22 @@ struct TerminateWorkflowExecutionRequest {
   50: optional string identity
 }
 
+struct PauseWorkflowExecutionRequest {
+  10: optional string domain
+  20: optional WorkflowExecution workflowExecution
+  30: optional string reason
+  40: optional binary details
+  50: optional string identity
+}
+
+struct UnpauseWorkflowExecutionRequest {
+  10: optional string domain
+  20: optional WorkflowExecution workflowExecution
+  30: optional string reason
+  40: optional binary details
+  50: optional string identity
+}
+
 struct ResetWorkflowExecutionRequest {
   10: optional string domain
   20: optional WorkflowExecution workflowExecution
@@ -1177,10 +1256,18 @@ struct QueryWorkflowRequest {
   10: optional string domain
   20: optional WorkflowExecution execution
   30: optional WorkflowQuery query
+  // queryRejectCondition rejects the query when the workflow state satisfies the condition
+  40: optional QueryRejectCondition queryRejectCondition
+  50: optional QueryConsistencyLevel queryConsistencyLevel
+}
+
+struct QueryRejected {
+  10: optional WorkflowExecutionCloseStatus closeStatus
 }
 
 struct QueryWorkflowResponse {
   10: optional binary queryResult
+  20: optional QueryRejected queryRejected
 }
 
 struct WorkflowQuery {
@@ -1188,6 +1275,12 @@ struct WorkflowQuery {
   20: optional binary queryArgs
 }
 
+struct WorkflowQueryResult {
+  10: optional QueryTaskCompletedType resultType
+  20: optional binary answer
+  30: optional string errorMessage
+}
+
 struct ResetStickyTaskListRequest {
   10: optional string domain
   20: optional WorkflowExecution execution
@@ -1234,6 +1327,20 @@ struct DescribeTaskListRequest {
 
 struct DescribeTaskListResponse {
   10: optional list<PollerInfo> pollers
+  20: optional list<CompatibleBuildSet> buildSets
+}
+
+// CompatibleBuildSet is a set of worker build IDs which can process the decision tasks of each other's workflows
+struct CompatibleBuildSet {
+  10: optional list<string> buildIDs
+}
+
+struct PromoteTaskListBuildIDRequest {
+  10: optional string domain
+  20: optional TaskList taskList
+  30: optional string buildID
+  // when set the build joins the compatible set of this build, otherwise it starts a new set
+  40: optional string compatibleBuildID
 }
 
 //At least one of the parameters needs to be provided
@@ -1251,6 +1358,51 @@ struct DescribeHistoryHostResponse{
   50: optional string               address
 }
 
+struct CloseShardRequest {
+  10: optional i32 shardID
+}
+
+struct DescribeShardRequest {
+  10: optional i32 shardID
+}
+
+struct DescribeShardResponse {
+  10: optional i32    shardID
+  20: optional string hostAddress
+  30: optional string shardInfoInDatabase
+}
+
+struct DrainHistoryHostRequest {
+  10: optional string hostAddress //ip:port
+}
+
+enum QueueTaskType {
+  TRANSFER,
+  TIMER,
+  REPLICATION,
+}
+
+struct ListQueueTasksRequest {
+  10: optional i32           shardID
+  20: optional QueueTaskType taskType
+  30: optional string        workflowID
+  40: optional string        runID
+  50: optional i32           pageSize
+  60: optional binary        nextPageToken
+}
+
+struct ListQueueTasksResponse {
+  10: optional list<string> tasks
+  20: optional binary       nextPageToken
+}
+
+struct RemoveQueueTaskRequest {
+  10: optional i32                     shardID
+  20: optional QueueTaskType           taskType
+  30: optional i64 (js.type = "Long")  taskID
+  40: optional i64 (js.type = "Long")  visibilityTimestamp // only needed for timer tasks
+}
+
 struct DomainCacheInfo{
   10: optional i64 numOfItemsInCacheByID
   20: optional i64 numOfItemsInCacheByName
@@ -1271,6 +1423,7 @@ struct PollerInfo {
   // Unix Nano
   10: optional i64 (js.type = "Long")  lastAccessTime
   20: optional string identity
+  30: optional string binaryChecksum
 }
 
 struct RetryPolicy {
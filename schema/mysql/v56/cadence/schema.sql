@@ -121,6 +121,10 @@ CREATE TABLE executions(
   expiration_seconds INT NOT NULL,
   expiration_time DATETIME(6) NOT NULL, -- retry expiration time
   non_retryable_errors BLOB,
+  paused BOOLEAN NOT NULL DEFAULT FALSE, -- set while the workflow is paused
+  priority INT NOT NULL DEFAULT 0, -- dispatch priority of decision tasks, higher is dispatched first
+  build_id VARCHAR(255) NOT NULL DEFAULT '', -- worker build the workflow is pinned to
+  decision_heartbeat_count BIGINT NOT NULL DEFAULT 0, -- number of decision attempts which were heartbeats rather than failures
 	PRIMARY KEY (shard_id, domain_id, workflow_id, run_id)
 );
 
@@ -162,9 +166,12 @@ CREATE TABLE tasks (
   task_type TINYINT NOT NULL, -- {Activity, Decision}
   task_id BIGINT NOT NULL,
   expiry_ts DATETIME(6) NOT NULL,
+  priority INT NOT NULL DEFAULT 0,
   PRIMARY KEY (shard_id, domain_id, task_list_name, task_type, task_id)
 );
 
+CREATE INDEX tasks_by_priority ON tasks(domain_id, task_list_name, task_type, priority, task_id);
+
 CREATE TABLE task_lists (
 	domain_id BINARY(16) NOT NULL,
 	range_id BIGINT NOT NULL,
@@ -173,6 +180,8 @@ CREATE TABLE task_lists (
 	ack_level BIGINT NOT NULL DEFAULT 0,
 	kind TINYINT NOT NULL, -- {Normal, Sticky}
 	expiry_ts DATETIME(6) NOT NULL,
+	last_updated DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+	build_sets BLOB, -- compatible worker build sets, the last set holds the default build
 	PRIMARY KEY (domain_id, name, task_type)
 );
 
@@ -259,6 +268,7 @@ max_interval                INT NOT NULL,
 expiration_time             DATETIME(6) NOT NULL,
 max_attempts                INT NOT NULL,
 non_retriable_errors        BLOB, -- this was a list<text>. The use pattern is to replace, no modifications.
+priority                    INT NOT NULL DEFAULT 0,
 	PRIMARY KEY (shard_id, domain_id, workflow_id, run_id, schedule_id)
 );
 
@@ -0,0 +1 @@
+ALTER TABLE task_lists ADD last_updated DATETIME(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6);
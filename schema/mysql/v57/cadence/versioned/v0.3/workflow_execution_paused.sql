@@ -0,0 +1 @@
+ALTER TABLE executions ADD paused BOOLEAN NOT NULL DEFAULT FALSE;
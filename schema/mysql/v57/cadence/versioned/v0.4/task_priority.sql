@@ -0,0 +1,3 @@
+ALTER TABLE executions ADD priority INT NOT NULL DEFAULT 0;
+ALTER TABLE activity_info_maps ADD priority INT NOT NULL DEFAULT 0;
+ALTER TABLE tasks ADD priority INT NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+ALTER TABLE executions ADD build_id VARCHAR(255) NOT NULL DEFAULT '';
+ALTER TABLE task_lists ADD build_sets BLOB;
@@ -0,0 +1 @@
+ALTER TABLE executions ADD decision_heartbeat_count BIGINT NOT NULL DEFAULT 0;
@@ -0,0 +1 @@
+CREATE INDEX tasks_by_priority ON tasks(domain_id, task_list_name, task_type, priority, task_id);
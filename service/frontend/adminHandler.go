@@ -22,6 +22,7 @@ package frontend
 
 import (
 	"context"
+	"fmt"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -34,8 +35,10 @@ import (
 	"github.com/uber/cadence/.gen/go/admin/adminserviceserver"
 	h "github.com/uber/cadence/.gen/go/history"
 	hist "github.com/uber/cadence/.gen/go/history"
+	m "github.com/uber/cadence/.gen/go/matching"
 	gen "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/client/matching"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/logging"
@@ -54,6 +57,7 @@ type (
 		numberOfHistoryShards int
 		service.Service
 		history       history.Client
+		matching      matching.Client
 		domainCache   cache.DomainCache
 		metricsClient metrics.Client
 		historyMgr    persistence.HistoryManager
@@ -90,6 +94,7 @@ func (adh *AdminHandler) Start() error {
 	adh.Service.Start()
 
 	adh.history = adh.GetClientBean().GetHistoryClient()
+	adh.matching = adh.GetClientBean().GetMatchingClient()
 	adh.metricsClient = adh.Service.GetMetricsClient()
 	adh.startWG.Done()
 	return nil
@@ -159,6 +164,181 @@ func (adh *AdminHandler) DescribeHistoryHost(ctx context.Context, request *gen.D
 	return resp, err
 }
 
+// CloseShard closes the shard on the history host owning it, the same host reloads it from persistence
+func (adh *AdminHandler) CloseShard(ctx context.Context, request *gen.CloseShardRequest) error {
+	scope := metrics.AdminCloseShardScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil || request.ShardID == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if err := adh.validateShardID(request.GetShardID()); err != nil {
+		return adh.error(err, scope)
+	}
+
+	if err := adh.history.CloseShard(ctx, request); err != nil {
+		return adh.error(err, scope)
+	}
+	return nil
+}
+
+// DescribeShard returns information about the persisted state of a shard
+func (adh *AdminHandler) DescribeShard(ctx context.Context, request *gen.DescribeShardRequest) (*gen.DescribeShardResponse, error) {
+	scope := metrics.AdminDescribeShardScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil || request.ShardID == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if err := adh.validateShardID(request.GetShardID()); err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err := adh.history.DescribeShard(ctx, request)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return resp, nil
+}
+
+// DrainHistoryHost removes the history host from membership ring so that all its shards are moved to other hosts,
+// before shutting the host down. The host does not re-join the ring until it is restarted.
+func (adh *AdminHandler) DrainHistoryHost(ctx context.Context, request *gen.DrainHistoryHostRequest) error {
+	scope := metrics.AdminDrainHistoryHostScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil || request.GetHostAddress() == "" {
+		return adh.error(errRequestNotSet, scope)
+	}
+
+	if err := adh.history.DrainHistoryHost(ctx, request); err != nil {
+		return adh.error(err, scope)
+	}
+	return nil
+}
+
+// ListQueueTasks returns the pending transfer, timer or replication tasks of a shard
+func (adh *AdminHandler) ListQueueTasks(ctx context.Context, request *gen.ListQueueTasksRequest) (*gen.ListQueueTasksResponse, error) {
+	scope := metrics.AdminListQueueTasksScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil || request.TaskType == nil {
+		return nil, adh.error(errRequestNotSet, scope)
+	}
+	if request.ShardID == nil {
+		if request.GetWorkflowID() == "" {
+			return nil, adh.error(&gen.BadRequestError{Message: "Either ShardID or WorkflowID must be set."}, scope)
+		}
+		shardID := common.WorkflowIDToHistoryShard(request.GetWorkflowID(), adh.numberOfHistoryShards)
+		request.ShardID = common.Int32Ptr(int32(shardID))
+	}
+	if err := adh.validateShardID(request.GetShardID()); err != nil {
+		return nil, adh.error(err, scope)
+	}
+
+	resp, err := adh.history.ListQueueTasks(ctx, request)
+	if err != nil {
+		return nil, adh.error(err, scope)
+	}
+	return resp, nil
+}
+
+// RemoveQueueTask removes a transfer, timer or replication task from the queue of a shard
+func (adh *AdminHandler) RemoveQueueTask(ctx context.Context, request *gen.RemoveQueueTaskRequest) error {
+	scope := metrics.AdminRemoveQueueTaskScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil || request.ShardID == nil || request.TaskType == nil || request.TaskID == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if err := adh.validateShardID(request.GetShardID()); err != nil {
+		return adh.error(err, scope)
+	}
+	if request.GetTaskType() == gen.QueueTaskTypeTimer && request.VisibilityTimestamp == nil {
+		return adh.error(&gen.BadRequestError{Message: "VisibilityTimestamp is required to remove timer task."}, scope)
+	}
+
+	if err := adh.history.RemoveQueueTask(ctx, request); err != nil {
+		return adh.error(err, scope)
+	}
+	return nil
+}
+
+// RegenerateWorkflowTasks re-generates the transfer and timer tasks of a workflow from its mutable state
+func (adh *AdminHandler) RegenerateWorkflowTasks(ctx context.Context, request *admin.RegenerateWorkflowTasksRequest) error {
+	scope := metrics.AdminRegenerateWorkflowTasksScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return adh.error(errDomainNotSet, scope)
+	}
+	if err := validateExecution(request.Execution); err != nil {
+		return adh.error(err, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return adh.error(err, scope)
+	}
+
+	if err := adh.history.RegenerateWorkflowTasks(ctx, &hist.RegenerateWorkflowTasksRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  request.Execution,
+	}); err != nil {
+		return adh.error(err, scope)
+	}
+	return nil
+}
+
+// PromoteTaskListBuildID makes a worker build the default one for new workflows on a decision task list
+func (adh *AdminHandler) PromoteTaskListBuildID(ctx context.Context, request *gen.PromoteTaskListBuildIDRequest) error {
+	scope := metrics.AdminPromoteTaskListBuildIDScope
+	sw := adh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if request == nil {
+		return adh.error(errRequestNotSet, scope)
+	}
+	if request.GetDomain() == "" {
+		return adh.error(errDomainNotSet, scope)
+	}
+	if request.TaskList == nil || request.TaskList.GetName() == "" {
+		return adh.error(errTaskListNotSet, scope)
+	}
+	if request.GetBuildID() == "" {
+		return adh.error(&gen.BadRequestError{Message: "BuildID is not set on request."}, scope)
+	}
+
+	domainID, err := adh.domainCache.GetDomainID(request.GetDomain())
+	if err != nil {
+		return adh.error(err, scope)
+	}
+
+	if err := adh.matching.PromoteTaskListBuildID(ctx, &m.PromoteTaskListBuildIDRequest{
+		DomainUUID:     common.StringPtr(domainID),
+		PromoteRequest: request,
+	}); err != nil {
+		return adh.error(err, scope)
+	}
+	return nil
+}
+
+func (adh *AdminHandler) validateShardID(shardID int32) error {
+	if shardID < 0 || int(shardID) >= adh.numberOfHistoryShards {
+		return &gen.BadRequestError{Message: fmt.Sprintf("Invalid ShardID, must be in range [0, %v).", adh.numberOfHistoryShards)}
+	}
+	return nil
+}
+
 // GetWorkflowExecutionRawHistory - retrieves the history of workflow execution
 func (adh *AdminHandler) GetWorkflowExecutionRawHistory(
 	ctx context.Context, request *admin.GetWorkflowExecutionRawHistoryRequest) (*admin.GetWorkflowExecutionRawHistoryResponse, error) {
@@ -199,6 +199,24 @@ func (handler *DCRedirectionHandlerImpl) ListOpenWorkflowExecutions(
 	return handler.sevice.GetClientBean().GetRemoteFrontendClient(targetDC).ListOpenWorkflowExecutions(ctx, request)
 }
 
+// PollForActivityCancellation API call
+func (handler *DCRedirectionHandlerImpl) PollForActivityCancellation(
+	ctx context.Context,
+	request *shared.PollForActivityCancellationRequest,
+) (*shared.PollForActivityCancellationResponse, error) {
+
+	targetDC, err := handler.redirectionPolicy.GetTargetDatacenterByName(request.GetDomain())
+	if err != nil {
+		return nil, err
+	}
+
+	if targetDC == handler.currentClusteName {
+		return handler.frontendHandler.PollForActivityCancellation(ctx, request)
+	}
+
+	return handler.sevice.GetClientBean().GetRemoteFrontendClient(targetDC).PollForActivityCancellation(ctx, request)
+}
+
 // PollForActivityTask API call
 func (handler *DCRedirectionHandlerImpl) PollForActivityTask(
 	ctx context.Context,
@@ -611,3 +629,39 @@ func (handler *DCRedirectionHandlerImpl) TerminateWorkflowExecution(
 
 	return handler.sevice.GetClientBean().GetRemoteFrontendClient(targetDC).TerminateWorkflowExecution(ctx, request)
 }
+
+// PauseWorkflowExecution API call
+func (handler *DCRedirectionHandlerImpl) PauseWorkflowExecution(
+	ctx context.Context,
+	request *shared.PauseWorkflowExecutionRequest,
+) error {
+
+	targetDC, err := handler.redirectionPolicy.GetTargetDatacenterByName(request.GetDomain())
+	if err != nil {
+		return err
+	}
+
+	if targetDC == handler.currentClusteName {
+		return handler.frontendHandler.PauseWorkflowExecution(ctx, request)
+	}
+
+	return handler.sevice.GetClientBean().GetRemoteFrontendClient(targetDC).PauseWorkflowExecution(ctx, request)
+}
+
+// UnpauseWorkflowExecution API call
+func (handler *DCRedirectionHandlerImpl) UnpauseWorkflowExecution(
+	ctx context.Context,
+	request *shared.UnpauseWorkflowExecutionRequest,
+) error {
+
+	targetDC, err := handler.redirectionPolicy.GetTargetDatacenterByName(request.GetDomain())
+	if err != nil {
+		return err
+	}
+
+	if targetDC == handler.currentClusteName {
+		return handler.frontendHandler.UnpauseWorkflowExecution(ctx, request)
+	}
+
+	return handler.sevice.GetClientBean().GetRemoteFrontendClient(targetDC).UnpauseWorkflowExecution(ctx, request)
+}
@@ -0,0 +1,451 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/cadence/workflowserviceserver"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/service/config"
+)
+
+const (
+	// httpGatewayPathPrefix is the path prefix of the endpoints, the path of an endpoint
+	// is the prefix followed by the name of the WorkflowService method
+	httpGatewayPathPrefix = "/api/v1/"
+	// httpGatewayTimeoutHeader is the header carrying the timeout of the request in milliseconds
+	httpGatewayTimeoutHeader = "Context-TTL-Ms"
+
+	httpGatewayDefaultTimeout         = 10 * time.Second
+	httpGatewayDefaultLongPollTimeout = 70 * time.Second
+	httpGatewayMaxTimeout             = 5 * time.Minute
+	httpGatewayMaxRequestBodySize     = 8 * 1024 * 1024
+)
+
+type (
+	// HTTPGateway exposes the methods of WorkflowService as JSON POST endpoints,
+	// the request and response bodies are the JSON encoded shared types
+	HTTPGateway struct {
+		address   string
+		tlsConfig *tls.Config
+		routes    map[string]httpGatewayRoute
+		server    *http.Server
+		logger    bark.Logger
+	}
+
+	httpGatewayRoute struct {
+		newRequest func() interface{}
+		invoke     func(ctx context.Context, request interface{}) (interface{}, error)
+		longPoll   bool
+	}
+
+	httpGatewayError struct {
+		Type  string      `json:"type"`
+		Error interface{} `json:"error"`
+	}
+)
+
+// NewHTTPGateway creates a new HTTPGateway listening on address and serving the requests with handler,
+// the gateway serves HTTPS when tlsConfig is set
+func NewHTTPGateway(
+	address string,
+	tlsConfig *tls.Config,
+	handler workflowserviceserver.Interface,
+	logger bark.Logger,
+) *HTTPGateway {
+	g := &HTTPGateway{
+		address:   address,
+		tlsConfig: tlsConfig,
+		routes:    newHTTPGatewayRoutes(handler),
+		logger:    logger,
+	}
+	g.server = &http.Server{Handler: g}
+	return g
+}
+
+// Start starts serving the requests
+func (g *HTTPGateway) Start() {
+	listener, err := net.Listen("tcp", g.address)
+	if err != nil {
+		g.logger.WithField(logging.TagErr, err).Fatal("Failed to listen on HTTP gateway port")
+	}
+	if g.tlsConfig != nil {
+		listener = tls.NewListener(listener, g.tlsConfig)
+	}
+	g.logger.Infof("Created HTTP gateway listening at '%v', TLS enabled: %v", g.address, g.tlsConfig != nil)
+	go func() {
+		if err := g.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			g.logger.WithField(logging.TagErr, err).Error("HTTP gateway stopped serving")
+		}
+	}()
+}
+
+// Stop stops serving the requests
+func (g *HTTPGateway) Stop() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := g.server.Shutdown(ctx); err != nil {
+		g.logger.WithField(logging.TagErr, err).Warn("Failed to shutdown HTTP gateway")
+	}
+}
+
+// ServeHTTP serves a single request
+func (g *HTTPGateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.URL.Path, httpGatewayPathPrefix) {
+		http.NotFound(w, r)
+		return
+	}
+	route, ok := g.routes[strings.TrimPrefix(r.URL.Path, httpGatewayPathPrefix)]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeHTTPGatewayError(w, http.StatusMethodNotAllowed, &gen.BadRequestError{Message: "only POST is supported"})
+		return
+	}
+
+	timeout, err := httpGatewayTimeout(r, route.longPoll)
+	if err != nil {
+		writeHTTPGatewayError(w, http.StatusBadRequest, &gen.BadRequestError{Message: err.Error()})
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), timeout)
+	defer cancel()
+	if r.TLS != nil {
+		if identity, ok := config.PeerIdentity(*r.TLS); ok {
+			ctx = config.NewPeerIdentityContext(ctx, identity)
+		}
+	}
+
+	request := route.newRequest()
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, httpGatewayMaxRequestBodySize))
+	if err != nil {
+		writeHTTPGatewayError(w, http.StatusRequestEntityTooLarge, &gen.BadRequestError{Message: err.Error()})
+		return
+	}
+	if len(body) > 0 {
+		if err := json.Unmarshal(body, request); err != nil {
+			writeHTTPGatewayError(w, http.StatusBadRequest, &gen.BadRequestError{Message: "invalid request body: " + err.Error()})
+			return
+		}
+	}
+
+	response, err := route.invoke(ctx, request)
+	if err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			writeHTTPGatewayError(w, http.StatusGatewayTimeout, &gen.InternalServiceError{Message: "request timed out"})
+			return
+		}
+		writeHTTPGatewayError(w, httpGatewayStatusCode(err), err)
+		return
+	}
+	if response == nil {
+		response = struct{}{}
+	}
+	writeHTTPGatewayResponse(w, http.StatusOK, response)
+}
+
+// httpGatewayTimeout returns the timeout requested by the client, or the default one
+func httpGatewayTimeout(r *http.Request, longPoll bool) (time.Duration, error) {
+	value := r.Header.Get(httpGatewayTimeoutHeader)
+	if len(value) == 0 {
+		if longPoll {
+			return httpGatewayDefaultLongPollTimeout, nil
+		}
+		return httpGatewayDefaultTimeout, nil
+	}
+	ms, err := strconv.Atoi(value)
+	if err != nil || ms <= 0 {
+		return 0, &gen.BadRequestError{Message: "invalid " + httpGatewayTimeoutHeader + " header: " + value}
+	}
+	timeout := time.Duration(ms) * time.Millisecond
+	if timeout > httpGatewayMaxTimeout {
+		timeout = httpGatewayMaxTimeout
+	}
+	return timeout, nil
+}
+
+// httpGatewayStatusCode maps the errors of WorkflowService to HTTP status codes
+func httpGatewayStatusCode(err error) int {
+	switch err.(type) {
+	case *gen.BadRequestError, *gen.QueryFailedError:
+		return http.StatusBadRequest
+	case *gen.AccessDeniedError:
+		return http.StatusForbidden
+	case *gen.EntityNotExistsError:
+		return http.StatusNotFound
+	case *gen.DomainAlreadyExistsError, *gen.WorkflowExecutionAlreadyStartedError, *gen.CancellationAlreadyRequestedError:
+		return http.StatusConflict
+	case *gen.DomainNotActiveError:
+		return http.StatusMisdirectedRequest
+	case *gen.ServiceBusyError, *gen.LimitExceededError:
+		return http.StatusTooManyRequests
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+func writeHTTPGatewayError(w http.ResponseWriter, statusCode int, err error) {
+	response := httpGatewayError{Error: err}
+	switch err.(type) {
+	case *gen.BadRequestError, *gen.QueryFailedError, *gen.AccessDeniedError, *gen.EntityNotExistsError,
+		*gen.DomainAlreadyExistsError, *gen.WorkflowExecutionAlreadyStartedError, *gen.CancellationAlreadyRequestedError,
+		*gen.DomainNotActiveError, *gen.ServiceBusyError, *gen.LimitExceededError, *gen.InternalServiceError:
+		response.Type = strings.TrimPrefix(fmt.Sprintf("%T", err), "*shared.")
+	default:
+		response.Type = "InternalServiceError"
+		response.Error = &gen.InternalServiceError{Message: err.Error()}
+	}
+	writeHTTPGatewayResponse(w, statusCode, response)
+}
+
+func writeHTTPGatewayResponse(w http.ResponseWriter, statusCode int, response interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(response)
+}
+
+func newHTTPGatewayRoutes(handler workflowserviceserver.Interface) map[string]httpGatewayRoute {
+	return map[string]httpGatewayRoute{
+		"DeprecateDomain": {
+			newRequest: func() interface{} { return &gen.DeprecateDomainRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.DeprecateDomain(ctx, request.(*gen.DeprecateDomainRequest))
+			},
+		},
+		"DescribeDomain": {
+			newRequest: func() interface{} { return &gen.DescribeDomainRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.DescribeDomain(ctx, request.(*gen.DescribeDomainRequest))
+			},
+		},
+		"DescribeTaskList": {
+			newRequest: func() interface{} { return &gen.DescribeTaskListRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.DescribeTaskList(ctx, request.(*gen.DescribeTaskListRequest))
+			},
+		},
+		"DescribeWorkflowExecution": {
+			newRequest: func() interface{} { return &gen.DescribeWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.DescribeWorkflowExecution(ctx, request.(*gen.DescribeWorkflowExecutionRequest))
+			},
+		},
+		"GetWorkflowExecutionHistory": {
+			newRequest: func() interface{} { return &gen.GetWorkflowExecutionHistoryRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.GetWorkflowExecutionHistory(ctx, request.(*gen.GetWorkflowExecutionHistoryRequest))
+			},
+			longPoll: true,
+		},
+		"ListClosedWorkflowExecutions": {
+			newRequest: func() interface{} { return &gen.ListClosedWorkflowExecutionsRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.ListClosedWorkflowExecutions(ctx, request.(*gen.ListClosedWorkflowExecutionsRequest))
+			},
+		},
+		"ListDomains": {
+			newRequest: func() interface{} { return &gen.ListDomainsRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.ListDomains(ctx, request.(*gen.ListDomainsRequest))
+			},
+		},
+		"ListOpenWorkflowExecutions": {
+			newRequest: func() interface{} { return &gen.ListOpenWorkflowExecutionsRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.ListOpenWorkflowExecutions(ctx, request.(*gen.ListOpenWorkflowExecutionsRequest))
+			},
+		},
+		"PauseWorkflowExecution": {
+			newRequest: func() interface{} { return &gen.PauseWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.PauseWorkflowExecution(ctx, request.(*gen.PauseWorkflowExecutionRequest))
+			},
+		},
+		"PollForActivityCancellation": {
+			newRequest: func() interface{} { return &gen.PollForActivityCancellationRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.PollForActivityCancellation(ctx, request.(*gen.PollForActivityCancellationRequest))
+			},
+			longPoll: true,
+		},
+		"PollForActivityTask": {
+			newRequest: func() interface{} { return &gen.PollForActivityTaskRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.PollForActivityTask(ctx, request.(*gen.PollForActivityTaskRequest))
+			},
+			longPoll: true,
+		},
+		"PollForDecisionTask": {
+			newRequest: func() interface{} { return &gen.PollForDecisionTaskRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.PollForDecisionTask(ctx, request.(*gen.PollForDecisionTaskRequest))
+			},
+			longPoll: true,
+		},
+		"QueryWorkflow": {
+			newRequest: func() interface{} { return &gen.QueryWorkflowRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.QueryWorkflow(ctx, request.(*gen.QueryWorkflowRequest))
+			},
+		},
+		"RecordActivityTaskHeartbeat": {
+			newRequest: func() interface{} { return &gen.RecordActivityTaskHeartbeatRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.RecordActivityTaskHeartbeat(ctx, request.(*gen.RecordActivityTaskHeartbeatRequest))
+			},
+		},
+		"RecordActivityTaskHeartbeatByID": {
+			newRequest: func() interface{} { return &gen.RecordActivityTaskHeartbeatByIDRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.RecordActivityTaskHeartbeatByID(ctx, request.(*gen.RecordActivityTaskHeartbeatByIDRequest))
+			},
+		},
+		"RegisterDomain": {
+			newRequest: func() interface{} { return &gen.RegisterDomainRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.RegisterDomain(ctx, request.(*gen.RegisterDomainRequest))
+			},
+		},
+		"RequestCancelWorkflowExecution": {
+			newRequest: func() interface{} { return &gen.RequestCancelWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.RequestCancelWorkflowExecution(ctx, request.(*gen.RequestCancelWorkflowExecutionRequest))
+			},
+		},
+		"ResetStickyTaskList": {
+			newRequest: func() interface{} { return &gen.ResetStickyTaskListRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.ResetStickyTaskList(ctx, request.(*gen.ResetStickyTaskListRequest))
+			},
+		},
+		"ResetWorkflowExecution": {
+			newRequest: func() interface{} { return &gen.ResetWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.ResetWorkflowExecution(ctx, request.(*gen.ResetWorkflowExecutionRequest))
+			},
+		},
+		"RespondActivityTaskCanceled": {
+			newRequest: func() interface{} { return &gen.RespondActivityTaskCanceledRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.RespondActivityTaskCanceled(ctx, request.(*gen.RespondActivityTaskCanceledRequest))
+			},
+		},
+		"RespondActivityTaskCanceledByID": {
+			newRequest: func() interface{} { return &gen.RespondActivityTaskCanceledByIDRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.RespondActivityTaskCanceledByID(ctx, request.(*gen.RespondActivityTaskCanceledByIDRequest))
+			},
+		},
+		"RespondActivityTaskCompleted": {
+			newRequest: func() interface{} { return &gen.RespondActivityTaskCompletedRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.RespondActivityTaskCompleted(ctx, request.(*gen.RespondActivityTaskCompletedRequest))
+			},
+		},
+		"RespondActivityTaskCompletedByID": {
+			newRequest: func() interface{} { return &gen.RespondActivityTaskCompletedByIDRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.RespondActivityTaskCompletedByID(ctx, request.(*gen.RespondActivityTaskCompletedByIDRequest))
+			},
+		},
+		"RespondActivityTaskFailed": {
+			newRequest: func() interface{} { return &gen.RespondActivityTaskFailedRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.RespondActivityTaskFailed(ctx, request.(*gen.RespondActivityTaskFailedRequest))
+			},
+		},
+		"RespondActivityTaskFailedByID": {
+			newRequest: func() interface{} { return &gen.RespondActivityTaskFailedByIDRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.RespondActivityTaskFailedByID(ctx, request.(*gen.RespondActivityTaskFailedByIDRequest))
+			},
+		},
+		"RespondDecisionTaskCompleted": {
+			newRequest: func() interface{} { return &gen.RespondDecisionTaskCompletedRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.RespondDecisionTaskCompleted(ctx, request.(*gen.RespondDecisionTaskCompletedRequest))
+			},
+		},
+		"RespondDecisionTaskFailed": {
+			newRequest: func() interface{} { return &gen.RespondDecisionTaskFailedRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.RespondDecisionTaskFailed(ctx, request.(*gen.RespondDecisionTaskFailedRequest))
+			},
+		},
+		"RespondQueryTaskCompleted": {
+			newRequest: func() interface{} { return &gen.RespondQueryTaskCompletedRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.RespondQueryTaskCompleted(ctx, request.(*gen.RespondQueryTaskCompletedRequest))
+			},
+		},
+		"SignalWithStartWorkflowExecution": {
+			newRequest: func() interface{} { return &gen.SignalWithStartWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.SignalWithStartWorkflowExecution(ctx, request.(*gen.SignalWithStartWorkflowExecutionRequest))
+			},
+		},
+		"SignalWorkflowExecution": {
+			newRequest: func() interface{} { return &gen.SignalWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.SignalWorkflowExecution(ctx, request.(*gen.SignalWorkflowExecutionRequest))
+			},
+		},
+		"StartWorkflowExecution": {
+			newRequest: func() interface{} { return &gen.StartWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.StartWorkflowExecution(ctx, request.(*gen.StartWorkflowExecutionRequest))
+			},
+		},
+		"TerminateWorkflowExecution": {
+			newRequest: func() interface{} { return &gen.TerminateWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.TerminateWorkflowExecution(ctx, request.(*gen.TerminateWorkflowExecutionRequest))
+			},
+		},
+		"UnpauseWorkflowExecution": {
+			newRequest: func() interface{} { return &gen.UnpauseWorkflowExecutionRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return nil, handler.UnpauseWorkflowExecution(ctx, request.(*gen.UnpauseWorkflowExecutionRequest))
+			},
+		},
+		"UpdateDomain": {
+			newRequest: func() interface{} { return &gen.UpdateDomainRequest{} },
+			invoke: func(ctx context.Context, request interface{}) (interface{}, error) {
+				return handler.UpdateDomain(ctx, request.(*gen.UpdateDomainRequest))
+			},
+		},
+	}
+}
@@ -0,0 +1,156 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package frontend
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/cadence/workflowserviceserver"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+type (
+	httpGatewaySuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		handler *fakeWorkflowHandler
+		gateway *HTTPGateway
+	}
+
+	// fakeWorkflowHandler implements the methods used by the tests, calling the other methods panics
+	fakeWorkflowHandler struct {
+		workflowserviceserver.Interface
+		startRequest *gen.StartWorkflowExecutionRequest
+		startErr     error
+		pollDeadline time.Time
+	}
+)
+
+func TestHTTPGatewaySuite(t *testing.T) {
+	suite.Run(t, new(httpGatewaySuite))
+}
+
+func (s *httpGatewaySuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.handler = &fakeWorkflowHandler{}
+	s.gateway = NewHTTPGateway("127.0.0.1:0", nil, s.handler, bark.NewLoggerFromLogrus(log.New()))
+}
+
+func (s *httpGatewaySuite) TestStartWorkflowExecution() {
+	body := `{"domain":"test-domain","workflowId":"test-workflow-id","input":"aW5wdXQ="}`
+	recorder := s.serve(http.MethodPost, "/api/v1/StartWorkflowExecution", body, nil)
+
+	s.Equal(http.StatusOK, recorder.Code)
+	s.Equal("test-domain", s.handler.startRequest.GetDomain())
+	s.Equal("test-workflow-id", s.handler.startRequest.GetWorkflowId())
+	s.Equal([]byte("input"), s.handler.startRequest.Input)
+
+	var response gen.StartWorkflowExecutionResponse
+	s.NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+	s.Equal("test-run-id", response.GetRunId())
+}
+
+func (s *httpGatewaySuite) TestErrors() {
+	testCases := []struct {
+		err          error
+		expectedCode int
+		expectedType string
+	}{
+		{err: &gen.BadRequestError{Message: "bad"}, expectedCode: http.StatusBadRequest, expectedType: "BadRequestError"},
+		{err: &gen.EntityNotExistsError{Message: "missing"}, expectedCode: http.StatusNotFound, expectedType: "EntityNotExistsError"},
+		{err: &gen.WorkflowExecutionAlreadyStartedError{Message: common.StringPtr("started")}, expectedCode: http.StatusConflict, expectedType: "WorkflowExecutionAlreadyStartedError"},
+		{err: &gen.ServiceBusyError{Message: "busy"}, expectedCode: http.StatusTooManyRequests, expectedType: "ServiceBusyError"},
+		{err: context.Canceled, expectedCode: http.StatusInternalServerError, expectedType: "InternalServiceError"},
+	}
+
+	for _, tc := range testCases {
+		s.handler.startErr = tc.err
+		recorder := s.serve(http.MethodPost, "/api/v1/StartWorkflowExecution", "{}", nil)
+		s.Equal(tc.expectedCode, recorder.Code)
+
+		var response map[string]interface{}
+		s.NoError(json.Unmarshal(recorder.Body.Bytes(), &response))
+		s.Equal(tc.expectedType, response["type"])
+	}
+}
+
+func (s *httpGatewaySuite) TestInvalidRequests() {
+	s.Equal(http.StatusNotFound, s.serve(http.MethodPost, "/api/v1/UnknownMethod", "{}", nil).Code)
+	s.Equal(http.StatusNotFound, s.serve(http.MethodPost, "/StartWorkflowExecution", "{}", nil).Code)
+	s.Equal(http.StatusMethodNotAllowed, s.serve(http.MethodGet, "/api/v1/StartWorkflowExecution", "", nil).Code)
+	s.Equal(http.StatusBadRequest, s.serve(http.MethodPost, "/api/v1/StartWorkflowExecution", "{", nil).Code)
+	s.Equal(http.StatusBadRequest, s.serve(http.MethodPost, "/api/v1/StartWorkflowExecution", "{}",
+		map[string]string{httpGatewayTimeoutHeader: "abc"}).Code)
+}
+
+func (s *httpGatewaySuite) TestLongPollTimeout() {
+	before := time.Now()
+	recorder := s.serve(http.MethodPost, "/api/v1/PollForDecisionTask", "{}", nil)
+	s.Equal(http.StatusOK, recorder.Code)
+	s.True(s.handler.pollDeadline.After(before.Add(httpGatewayDefaultLongPollTimeout - time.Second)))
+
+	before = time.Now()
+	recorder = s.serve(http.MethodPost, "/api/v1/PollForDecisionTask", "{}", map[string]string{httpGatewayTimeoutHeader: "1000"})
+	s.Equal(http.StatusOK, recorder.Code)
+	s.True(s.handler.pollDeadline.Before(before.Add(2 * time.Second)))
+}
+
+func (s *httpGatewaySuite) serve(method string, path string, body string, headers map[string]string) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, strings.NewReader(body))
+	for k, v := range headers {
+		request.Header.Set(k, v)
+	}
+	recorder := httptest.NewRecorder()
+	s.gateway.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func (h *fakeWorkflowHandler) StartWorkflowExecution(
+	ctx context.Context,
+	request *gen.StartWorkflowExecutionRequest,
+) (*gen.StartWorkflowExecutionResponse, error) {
+	h.startRequest = request
+	if h.startErr != nil {
+		return nil, h.startErr
+	}
+	return &gen.StartWorkflowExecutionResponse{RunId: common.StringPtr("test-run-id")}, nil
+}
+
+func (h *fakeWorkflowHandler) PollForDecisionTask(
+	ctx context.Context,
+	request *gen.PollForDecisionTaskRequest,
+) (*gen.PollForDecisionTaskResponse, error) {
+	h.pollDeadline, _ = ctx.Deadline()
+	return &gen.PollForDecisionTaskResponse{}, nil
+}
@@ -97,9 +97,16 @@ type Service struct {
 // NewService builds a new cadence-frontend service
 func NewService(params *service.BootstrapParams) common.Daemon {
 	params.UpdateLoggerWithServiceName(common.FrontendServiceName)
+	dc := dynamicconfig.NewCollection(params.DynamicConfig, params.Logger)
+	config := NewConfig(dc, params.ESConfig.Enable)
+	if params.PersistenceConfig.PayloadOffload.Enabled {
+		// large payloads are written to the blobstore instead of the database
+		config.BlobSizeLimitError = dc.GetIntPropertyFilteredByDomain(
+			dynamicconfig.PayloadOffloadBlobSizeLimitError, common.PayloadOffloadBlobSizeLimitError)
+	}
 	return &Service{
 		params: params,
-		config: NewConfig(dynamicconfig.NewCollection(params.DynamicConfig, params.Logger), params.ESConfig.Enable),
+		config: config,
 		stopC:  make(chan struct{}),
 	}
 }
@@ -122,7 +129,7 @@ func (s *Service) Start() {
 		EnableSampling:                  s.config.EnableVisibilitySampling,
 		EnableReadFromClosedExecutionV2: s.config.EnableReadFromClosedExecutionV2,
 	}
-	pFactory := persistencefactory.New(&pConfig, params.ClusterMetadata.GetCurrentClusterName(), base.GetMetricsClient(), log)
+	pFactory := persistencefactory.New(&pConfig, params.BlobstoreClient, params.ClusterMetadata.GetCurrentClusterName(), base.GetMetricsClient(), log)
 
 	metadata, err := pFactory.NewMetadataManager(persistencefactory.MetadataV1V2)
 	if err != nil {
@@ -172,11 +179,12 @@ func (s *Service) Start() {
 
 	wfHandler := NewWorkflowHandler(base, s.config, metadata, history, historyV2, visibility, kafkaProducer, params.BlobstoreClient)
 	wfHandler.Start()
+	var handler workflowserviceserver.Interface
 	switch params.DCRedirectionPolicy.Policy {
 	case DCRedirectionPolicyDefault:
-		base.GetDispatcher().Register(workflowserviceserver.New(wfHandler))
+		handler = wfHandler
 	case DCRedirectionPolicyNoop:
-		base.GetDispatcher().Register(workflowserviceserver.New(wfHandler))
+		handler = wfHandler
 	case DCRedirectionPolicyForwarding:
 		dcRedirectionPolicy := RedirectionPolicyGenerator(
 			base.GetClusterMetadata(),
@@ -187,10 +195,17 @@ func (s *Service) Start() {
 		dcRediectionHandle := NewDCRedirectionHandler(
 			currentClusteName, dcRedirectionPolicy, base, wfHandler,
 		)
-		base.GetDispatcher().Register(workflowserviceserver.New(dcRediectionHandle))
+		handler = dcRediectionHandle
 	default:
 		panic(fmt.Sprintf("Unknown DC redirection policy %v", params.DCRedirectionPolicy.Policy))
 	}
+	base.GetDispatcher().Register(workflowserviceserver.New(handler))
+
+	var httpGateway *HTTPGateway
+	if len(params.HTTPGatewayAddress) > 0 {
+		httpGateway = NewHTTPGateway(params.HTTPGatewayAddress, params.HTTPGatewayTLS, handler, log)
+		httpGateway.Start()
+	}
 
 	adminHandler := NewAdminHandler(base, pConfig.NumHistoryShards, metadata, history, historyV2)
 	adminHandler.Start()
@@ -199,6 +214,9 @@ func (s *Service) Start() {
 
 	<-s.stopC
 
+	if httpGateway != nil {
+		httpGateway.Stop()
+	}
 	base.Stop()
 }
 
@@ -50,6 +50,7 @@ import (
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/config"
 	"go.uber.org/yarpc/yarpcerrors"
 )
 
@@ -100,17 +101,20 @@ var (
 	errWorkflowIDNotSet                           = &gen.BadRequestError{Message: "WorkflowId is not set on request."}
 	errRunIDNotSet                                = &gen.BadRequestError{Message: "RunId is not set on request."}
 	errActivityIDNotSet                           = &gen.BadRequestError{Message: "ActivityID is not set on request."}
+	errIdentityNotSet                             = &gen.BadRequestError{Message: "Identity is not set on request."}
 	errInvalidRunID                               = &gen.BadRequestError{Message: "Invalid RunId."}
 	errInvalidNextPageToken                       = &gen.BadRequestError{Message: "Invalid NextPageToken."}
 	errNextPageTokenRunIDMismatch                 = &gen.BadRequestError{Message: "RunID in the request does not match the NextPageToken."}
 	errQueryNotSet                                = &gen.BadRequestError{Message: "WorkflowQuery is not set on request."}
 	errQueryTypeNotSet                            = &gen.BadRequestError{Message: "QueryType is not set on request."}
+	errQueryPendingDecisionTimeout                = &gen.QueryFailedError{Message: "timeout: the pending decision task was not completed, strongly consistent query cannot be answered"}
 	errRequestNotSet                              = &gen.BadRequestError{Message: "Request is nil."}
 	errNoPermission                               = &gen.BadRequestError{Message: "No permission to do this operation."}
 	errRequestIDNotSet                            = &gen.BadRequestError{Message: "RequestId is not set on request."}
 	errWorkflowTypeNotSet                         = &gen.BadRequestError{Message: "WorkflowType is not set on request."}
 	errInvalidExecutionStartToCloseTimeoutSeconds = &gen.BadRequestError{Message: "A valid ExecutionStartToCloseTimeoutSeconds is not set on request."}
 	errInvalidTaskStartToCloseTimeoutSeconds      = &gen.BadRequestError{Message: "A valid TaskStartToCloseTimeoutSeconds is not set on request."}
+	errInvalidDelayStartSeconds                   = &gen.BadRequestError{Message: "DelayStartSeconds cannot be negative."}
 
 	// err for string too long
 	errDomainTooLong       = &gen.BadRequestError{Message: "Domain length exceeds limit."}
@@ -370,6 +374,9 @@ func (wh *WorkflowHandler) ListDomains(ctx context.Context,
 			FailoverVersion: common.Int64Ptr(d.FailoverVersion),
 		}
 		desc.DomainInfo, desc.Configuration, desc.ReplicationConfiguration = wh.createDomainResponse(d.Info, d.Config, d.ReplicationConfig)
+		if !matchListDomainsFilters(listRequest, desc) {
+			continue
+		}
 		domains = append(domains, desc)
 	}
 
@@ -381,6 +388,26 @@ func (wh *WorkflowHandler) ListDomains(ctx context.Context,
 	return response, nil
 }
 
+// matchListDomainsFilters returns whether the domain passes all the filters set on the request
+func matchListDomainsFilters(listRequest *gen.ListDomainsRequest, domain *gen.DescribeDomainResponse) bool {
+	if listRequest.Status != nil && listRequest.GetStatus() != domain.DomainInfo.GetStatus() {
+		return false
+	}
+	if listRequest.ActiveClusterName != nil &&
+		listRequest.GetActiveClusterName() != domain.ReplicationConfiguration.GetActiveClusterName() {
+		return false
+	}
+	if listRequest.IsGlobalDomain != nil && listRequest.GetIsGlobalDomain() != domain.GetIsGlobalDomain() {
+		return false
+	}
+	for key, value := range listRequest.Data {
+		if v, ok := domain.DomainInfo.Data[key]; !ok || v != value {
+			return false
+		}
+	}
+	return true
+}
+
 // DescribeDomain returns the information and configuration for a registered domain.
 func (wh *WorkflowHandler) DescribeDomain(ctx context.Context,
 	describeRequest *gen.DescribeDomainRequest) (*gen.DescribeDomainResponse, error) {
@@ -744,6 +771,69 @@ func (wh *WorkflowHandler) DeprecateDomain(ctx context.Context, deprecateRequest
 	return nil
 }
 
+// PollForActivityCancellation - Poll for the cancellation of an activity started by the worker.
+func (wh *WorkflowHandler) PollForActivityCancellation(
+	ctx context.Context,
+	pollRequest *gen.PollForActivityCancellationRequest) (*gen.PollForActivityCancellationResponse, error) {
+
+	scope := metrics.FrontendPollForActivityCancellationScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if pollRequest == nil {
+		return nil, wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
+		return nil, wh.error(createServiceBusyError(), scope)
+	}
+
+	wh.Service.GetLogger().Debug("Received PollForActivityCancellation")
+	if pollRequest.Domain == nil || pollRequest.GetDomain() == "" {
+		return nil, wh.error(errDomainNotSet, scope)
+	}
+
+	if len(pollRequest.GetDomain()) > wh.config.MaxIDLengthLimit() {
+		return nil, wh.error(errDomainTooLong, scope)
+	}
+
+	if err := wh.validateTaskList(pollRequest.TaskList, scope); err != nil {
+		return nil, err
+	}
+	// cancellations are delivered to the worker which started the activity
+	if pollRequest.GetIdentity() == "" {
+		return nil, wh.error(errIdentityNotSet, scope)
+	}
+	if len(pollRequest.GetIdentity()) > wh.config.MaxIDLengthLimit() {
+		return nil, wh.error(errIdentityTooLong, scope)
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(pollRequest.GetDomain())
+	if err != nil {
+		return nil, wh.error(err, scope)
+	}
+
+	pollerID := uuid.New()
+	var resp *gen.PollForActivityCancellationResponse
+	op := func() error {
+		var err error
+		resp, err = wh.matching.PollForActivityCancellation(ctx, &m.PollForActivityCancellationRequest{
+			DomainUUID:  common.StringPtr(domainID),
+			PollerID:    common.StringPtr(pollerID),
+			PollRequest: pollRequest,
+		})
+		return err
+	}
+
+	err = backoff.Retry(op, frontendServiceRetryPolicy, common.IsServiceTransientError)
+	if err != nil {
+		wh.Service.GetLogger().Errorf(
+			"PollForActivityCancellation failed. TaskList: %v, Error: %v", pollRequest.TaskList.GetName(), err)
+		return nil, wh.error(err, scope)
+	}
+	return resp, nil
+}
+
 // PollForActivityTask - Poll for an activity task.
 func (wh *WorkflowHandler) PollForActivityTask(
 	ctx context.Context,
@@ -1574,7 +1664,7 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(
 			WorkflowID:      taskToken.WorkflowID,
 			RunID:           taskToken.RunID,
 			ScheduleID:      histResp.StartedResponse.GetScheduledEventId(),
-			ScheduleAttempt: histResp.StartedResponse.GetAttempt(),
+			ScheduleAttempt: common.GetDecisionScheduleAttempt(histResp.StartedResponse),
 		}
 		token, _ := wh.tokenSerializer.Serialize(taskToken)
 		workflowExecution := &gen.WorkflowExecution{
@@ -1590,6 +1680,9 @@ func (wh *WorkflowHandler) RespondDecisionTaskCompleted(
 		}
 		completedResp.DecisionTask = newDecisionTask
 	}
+	if histResp != nil {
+		completedResp.ActivityTasks = histResp.ActivityTasks
+	}
 
 	return completedResp, nil
 }
@@ -1703,6 +1796,7 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 	if startRequest == nil {
 		return nil, wh.error(errRequestNotSet, scope)
 	}
+	setIdentityFromPeer(ctx, &startRequest.Identity)
 
 	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
@@ -1756,6 +1850,14 @@ func (wh *WorkflowHandler) StartWorkflowExecution(
 		return nil, wh.error(errInvalidTaskStartToCloseTimeoutSeconds, scope)
 	}
 
+	if startRequest.GetDelayStartSeconds() < 0 {
+		return nil, wh.error(errInvalidDelayStartSeconds, scope)
+	}
+
+	if err := common.ValidatePriority(startRequest.GetPriority()); err != nil {
+		return nil, wh.error(err, scope)
+	}
+
 	if startRequest.GetRequestId() == "" {
 		return nil, wh.error(errRequestIDNotSet, scope)
 	}
@@ -1996,6 +2098,7 @@ func (wh *WorkflowHandler) SignalWorkflowExecution(ctx context.Context,
 	if signalRequest == nil {
 		return wh.error(errRequestNotSet, scope)
 	}
+	setIdentityFromPeer(ctx, &signalRequest.Identity)
 
 	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
 		return wh.error(createServiceBusyError(), scope)
@@ -2064,6 +2167,7 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 	if signalWithStartRequest == nil {
 		return nil, wh.error(errRequestNotSet, scope)
 	}
+	setIdentityFromPeer(ctx, &signalWithStartRequest.Identity)
 
 	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
 		return nil, wh.error(createServiceBusyError(), scope)
@@ -2119,6 +2223,10 @@ func (wh *WorkflowHandler) SignalWithStartWorkflowExecution(ctx context.Context,
 			Message: "A valid TaskStartToCloseTimeoutSeconds is not set on request."}, scope)
 	}
 
+	if signalWithStartRequest.GetDelayStartSeconds() < 0 {
+		return nil, wh.error(errInvalidDelayStartSeconds, scope)
+	}
+
 	if err := common.ValidateRetryPolicy(signalWithStartRequest.RetryPolicy); err != nil {
 		return nil, wh.error(err, scope)
 	}
@@ -2199,6 +2307,7 @@ func (wh *WorkflowHandler) TerminateWorkflowExecution(ctx context.Context,
 	if terminateRequest == nil {
 		return wh.error(errRequestNotSet, scope)
 	}
+	setIdentityFromPeer(ctx, &terminateRequest.Identity)
 
 	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
 		return wh.error(createServiceBusyError(), scope)
@@ -2228,6 +2337,88 @@ func (wh *WorkflowHandler) TerminateWorkflowExecution(ctx context.Context,
 	return nil
 }
 
+// PauseWorkflowExecution pauses an existing workflow execution by recording WorkflowExecutionPaused event
+// in the history. Decision and activity tasks are held back until the execution is unpaused.
+func (wh *WorkflowHandler) PauseWorkflowExecution(ctx context.Context,
+	pauseRequest *gen.PauseWorkflowExecutionRequest) error {
+
+	scope := metrics.FrontendPauseWorkflowExecutionScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if pauseRequest == nil {
+		return wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
+		return wh.error(createServiceBusyError(), scope)
+	}
+
+	if pauseRequest.GetDomain() == "" {
+		return wh.error(errDomainNotSet, scope)
+	}
+
+	if err := wh.validateExecutionAndEmitMetrics(pauseRequest.WorkflowExecution, scope); err != nil {
+		return err
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(pauseRequest.GetDomain())
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	err = wh.history.PauseWorkflowExecution(ctx, &h.PauseWorkflowExecutionRequest{
+		DomainUUID:   common.StringPtr(domainID),
+		PauseRequest: pauseRequest,
+	})
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	return nil
+}
+
+// UnpauseWorkflowExecution resumes a paused workflow execution by recording WorkflowExecutionUnpaused event
+// in the history and dispatching the tasks held back while it was paused.
+func (wh *WorkflowHandler) UnpauseWorkflowExecution(ctx context.Context,
+	unpauseRequest *gen.UnpauseWorkflowExecutionRequest) error {
+
+	scope := metrics.FrontendUnpauseWorkflowExecutionScope
+	sw := wh.startRequestProfile(scope)
+	defer sw.Stop()
+
+	if unpauseRequest == nil {
+		return wh.error(errRequestNotSet, scope)
+	}
+
+	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
+		return wh.error(createServiceBusyError(), scope)
+	}
+
+	if unpauseRequest.GetDomain() == "" {
+		return wh.error(errDomainNotSet, scope)
+	}
+
+	if err := wh.validateExecutionAndEmitMetrics(unpauseRequest.WorkflowExecution, scope); err != nil {
+		return err
+	}
+
+	domainID, err := wh.domainCache.GetDomainID(unpauseRequest.GetDomain())
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	err = wh.history.UnpauseWorkflowExecution(ctx, &h.UnpauseWorkflowExecutionRequest{
+		DomainUUID:     common.StringPtr(domainID),
+		UnpauseRequest: unpauseRequest,
+	})
+	if err != nil {
+		return wh.error(err, scope)
+	}
+
+	return nil
+}
+
 // ResetWorkflowExecution reset an existing workflow execution to the nextFirstEventID
 // in the history and immediately terminating the current execution instance.
 func (wh *WorkflowHandler) ResetWorkflowExecution(ctx context.Context,
@@ -2282,6 +2473,7 @@ func (wh *WorkflowHandler) RequestCancelWorkflowExecution(
 	if cancelRequest == nil {
 		return wh.error(errRequestNotSet, scope)
 	}
+	setIdentityFromPeer(ctx, &cancelRequest.Identity)
 
 	if ok, _ := wh.rateLimiter.TryConsume(1); !ok {
 		return wh.error(createServiceBusyError(), scope)
@@ -2595,13 +2787,54 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 	if err != nil {
 		return nil, wh.error(err, scope)
 	}
+	queryRequest.Execution.RunId = response.Execution.RunId
+
 	clientFeature := client.NewFeatureImpl(
 		response.GetClientLibraryVersion(),
 		response.GetClientFeatureVersion(),
 		response.GetClientImpl(),
 	)
 
-	queryRequest.Execution.RunId = response.Execution.RunId
+	if queryRequest.GetQueryConsistencyLevel() == gen.QueryConsistencyLevelStrong && response.GetIsWorkflowRunning() {
+		if clientFeature.SupportConsistentQuery() {
+			// history delivers the query with the next decision task, after all the events recorded before the query
+			historyResp, err := wh.history.QueryWorkflow(ctx, &h.QueryWorkflowRequest{
+				DomainUUID: common.StringPtr(domainID),
+				Request:    queryRequest,
+			})
+			if err != nil {
+				if ctx.Err() != nil {
+					err = errQueryPendingDecisionTimeout
+				}
+				return nil, wh.error(err, scope)
+			}
+			if historyResp.Response != nil {
+				return historyResp.Response, nil
+			}
+			// the workflow has no pending decision task, so its workers can answer the query right away
+		} else {
+			// the workers cannot answer queries delivered with a decision task, so the query is dispatched
+			// to a poller once the workflow has no pending decision task
+			response, err = wh.waitForPendingDecision(ctx, domainID, queryRequest.Execution, response)
+			if err != nil {
+				return nil, wh.error(err, scope)
+			}
+		}
+	}
+
+	if queryRequest.QueryRejectCondition != nil && !response.GetIsWorkflowRunning() {
+		rejectCondition := queryRequest.GetQueryRejectCondition()
+		closeStatus := response.GetWorkflowCloseStatus()
+		notOpenReject := rejectCondition == gen.QueryRejectConditionNotOpen
+		notCompletedCleanlyReject := rejectCondition == gen.QueryRejectConditionNotCompletedCleanly &&
+			closeStatus != gen.WorkflowExecutionCloseStatusCompleted
+		if notOpenReject || notCompletedCleanlyReject {
+			return &gen.QueryWorkflowResponse{
+				QueryRejected: &gen.QueryRejected{CloseStatus: &closeStatus},
+			}, nil
+		}
+	}
+
 	if len(response.StickyTaskList.GetName()) != 0 && clientFeature.SupportStickyQuery() {
 		matchingRequest.TaskList = response.StickyTaskList
 		stickyDecisionTimeout := response.GetStickyTaskListScheduleToStartTimeout()
@@ -2636,6 +2869,8 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 	}
 
 	matchingRequest.TaskList = response.TaskList
+	// the normal tasklist may be versioned, in which case only workers of the pinned build can answer
+	matchingRequest.BuildID = response.BuildID
 	matchingResp, err := wh.matching.QueryWorkflow(ctx, matchingRequest)
 	if err != nil {
 		logging.LogQueryTaskFailedEvent(wh.GetLogger(),
@@ -2649,6 +2884,34 @@ func (wh *WorkflowHandler) QueryWorkflow(ctx context.Context,
 	return matchingResp, nil
 }
 
+// waitForPendingDecision waits until the workflow has no pending decision task nor buffered events,
+// so that the worker answering a strongly consistent query has processed all the events of the workflow
+func (wh *WorkflowHandler) waitForPendingDecision(ctx context.Context, domainID string,
+	execution *gen.WorkflowExecution, response *h.GetMutableStateResponse) (*h.GetMutableStateResponse, error) {
+
+	for response.GetIsWorkflowRunning() && response.GetHasPendingDecision() {
+		// long poll until the next event is recorded, the long poll response only carries the event IDs
+		_, err := wh.history.GetMutableState(ctx, &h.GetMutableStateRequest{
+			DomainUUID:          common.StringPtr(domainID),
+			Execution:           execution,
+			ExpectedNextEventId: common.Int64Ptr(response.GetNextEventId()),
+		})
+		if err == nil {
+			response, err = wh.history.GetMutableState(ctx, &h.GetMutableStateRequest{
+				DomainUUID: common.StringPtr(domainID),
+				Execution:  execution,
+			})
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, errQueryPendingDecisionTimeout
+			}
+			return nil, err
+		}
+	}
+	return response, nil
+}
+
 // DescribeWorkflowExecution returns information about the specified workflow execution.
 func (wh *WorkflowHandler) DescribeWorkflowExecution(ctx context.Context, request *gen.DescribeWorkflowExecutionRequest) (*gen.DescribeWorkflowExecutionResponse, error) {
 
@@ -2890,6 +3153,17 @@ func (wh *WorkflowHandler) validateExecutionAndEmitMetrics(w *gen.WorkflowExecut
 	return nil
 }
 
+// setIdentityFromPeer sets the identity of a request which has none to the identity of the
+// tls client certificate of the caller, so that the events record which client issued it
+func setIdentityFromPeer(ctx context.Context, identity **string) {
+	if len(common.StringDefault(*identity)) > 0 {
+		return
+	}
+	if peerIdentity, ok := config.PeerIdentityFromContext(ctx); ok {
+		*identity = common.StringPtr(peerIdentity)
+	}
+}
+
 func validateExecution(w *gen.WorkflowExecution) error {
 	if w == nil {
 		return errExecutionNotSet
@@ -3041,6 +3315,7 @@ func (wh *WorkflowHandler) createPollForDecisionTaskResponse(ctx context.Context
 		History:                   history,
 		NextPageToken:             continuation,
 		WorkflowExecutionTaskList: matchingResp.WorkflowExecutionTaskList,
+		Queries:                   matchingResp.Queries,
 	}
 
 	return resp, nil
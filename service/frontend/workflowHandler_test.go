@@ -34,6 +34,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
 	"github.com/uber-go/tally"
+	h "github.com/uber/cadence/.gen/go/history"
 	"github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/common"
@@ -44,6 +45,7 @@ import (
 	"github.com/uber/cadence/common/mocks"
 	"github.com/uber/cadence/common/persistence"
 	cs "github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/config"
 	dc "github.com/uber/cadence/common/service/dynamicconfig"
 )
 
@@ -482,6 +484,74 @@ func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_InvalidTaskStar
 	assert.Equal(s.T(), errInvalidTaskStartToCloseTimeoutSeconds, err)
 }
 
+func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_InvalidDelayStartSeconds() {
+	config := s.newConfig()
+	config.RPS = dc.GetIntPropertyFn(10)
+	wh := s.getWorkflowHandler(config)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	startWorkflowExecutionRequest := &shared.StartWorkflowExecutionRequest{
+		Domain:     common.StringPtr("test-domain"),
+		WorkflowId: common.StringPtr("workflow-id"),
+		WorkflowType: &shared.WorkflowType{
+			Name: common.StringPtr("workflow-type"),
+		},
+		TaskList: &shared.TaskList{
+			Name: common.StringPtr("task-list"),
+		},
+		ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+		TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+		DelayStartSeconds:                   common.Int32Ptr(-1),
+		RequestId:                           common.StringPtr(uuid.New()),
+	}
+	_, err := wh.StartWorkflowExecution(context.Background(), startWorkflowExecutionRequest)
+	assert.Error(s.T(), err)
+	assert.Equal(s.T(), errInvalidDelayStartSeconds, err)
+}
+
+func (s *workflowHandlerSuite) TestStartWorkflowExecution_Failed_InvalidPriority() {
+	for _, priority := range []int32{-1, common.MaxTaskPriority + 1} {
+		config := s.newConfig()
+		config.RPS = dc.GetIntPropertyFn(10)
+		wh := s.getWorkflowHandler(config)
+		wh.metricsClient = wh.Service.GetMetricsClient()
+		wh.startWG.Done()
+
+		startWorkflowExecutionRequest := &shared.StartWorkflowExecutionRequest{
+			Domain:     common.StringPtr("test-domain"),
+			WorkflowId: common.StringPtr("workflow-id"),
+			WorkflowType: &shared.WorkflowType{
+				Name: common.StringPtr("workflow-type"),
+			},
+			TaskList: &shared.TaskList{
+				Name: common.StringPtr("task-list"),
+			},
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			RequestId:                           common.StringPtr(uuid.New()),
+			Priority:                            common.Int32Ptr(priority),
+		}
+		_, err := wh.StartWorkflowExecution(context.Background(), startWorkflowExecutionRequest)
+		assert.Error(s.T(), err)
+		assert.IsType(s.T(), &shared.BadRequestError{}, err)
+	}
+}
+
+func (s *workflowHandlerSuite) TestSetIdentityFromPeer() {
+	ctx := config.NewPeerIdentityContext(context.Background(), "peer-identity")
+
+	var identity *string
+	setIdentityFromPeer(context.Background(), &identity)
+	s.Nil(identity)
+	setIdentityFromPeer(ctx, &identity)
+	s.Equal("peer-identity", common.StringDefault(identity))
+
+	identity = common.StringPtr("request-identity")
+	setIdentityFromPeer(ctx, &identity)
+	s.Equal("request-identity", common.StringDefault(identity))
+}
+
 func (s *workflowHandlerSuite) getWorkflowHandlerWithParams(mService cs.Service, config *Config,
 	mMetadataManager persistence.MetadataManager, blobStore blobstore.Client) *WorkflowHandler {
 	return NewWorkflowHandler(mService, config, mMetadataManager, s.mockHistoryMgr, s.mockHistoryV2Mgr,
@@ -713,6 +783,76 @@ func (s *workflowHandlerSuite) TestDescribeDomain_Success_BlobstoreReturnsError(
 	mBlobstore.AssertCalled(s.T(), "BucketMetadata", mock.Anything, mock.Anything)
 }
 
+func (s *workflowHandlerSuite) TestListDomains_Filters() {
+	config := s.newConfig()
+	local := persistenceGetDomainResponse("", shared.ArchivalStatusNeverEnabled)
+	local.Info.Name = "local"
+	local.Info.Data = map[string]string{"team": "a", "tier": "1"}
+	global := persistenceGetDomainResponse("", shared.ArchivalStatusNeverEnabled)
+	global.Info.Name = "global"
+	global.Info.Data = map[string]string{"team": "b"}
+	global.IsGlobalDomain = true
+	global.ReplicationConfig.ActiveClusterName = "standby"
+	deprecated := persistenceGetDomainResponse("", shared.ArchivalStatusNeverEnabled)
+	deprecated.Info.Name = "deprecated"
+	deprecated.Info.Data = map[string]string{"team": "a"}
+	deprecated.Info.Status = persistence.DomainStatusDeprecated
+
+	mMetadataManager := &mocks.MetadataManager{}
+	mMetadataManager.On("ListDomains", &persistence.ListDomainsRequest{PageSize: 10}).Return(&persistence.ListDomainsResponse{
+		Domains:       []*persistence.GetDomainResponse{local, global, deprecated},
+		NextPageToken: []byte("token"),
+	}, nil)
+	wh := s.getWorkflowHandlerWithParams(s.mockService, config, mMetadataManager, s.mockBlobstoreClient)
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.startWG.Done()
+
+	testCases := []struct {
+		request  *shared.ListDomainsRequest
+		expected []string
+	}{
+		{
+			request:  &shared.ListDomainsRequest{},
+			expected: []string{"local", "global", "deprecated"},
+		},
+		{
+			request:  &shared.ListDomainsRequest{Status: shared.DomainStatusDeprecated.Ptr()},
+			expected: []string{"deprecated"},
+		},
+		{
+			request:  &shared.ListDomainsRequest{ActiveClusterName: common.StringPtr("standby")},
+			expected: []string{"global"},
+		},
+		{
+			request:  &shared.ListDomainsRequest{IsGlobalDomain: common.BoolPtr(false)},
+			expected: []string{"local", "deprecated"},
+		},
+		{
+			request:  &shared.ListDomainsRequest{Data: map[string]string{"team": "a"}},
+			expected: []string{"local", "deprecated"},
+		},
+		{
+			request: &shared.ListDomainsRequest{
+				Status: shared.DomainStatusRegistered.Ptr(),
+				Data:   map[string]string{"team": "a", "tier": "2"},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range testCases {
+		tc.request.PageSize = common.Int32Ptr(10)
+		result, err := wh.ListDomains(context.Background(), tc.request)
+		s.NoError(err)
+		var names []string
+		for _, domain := range result.Domains {
+			names = append(names, domain.DomainInfo.GetName())
+		}
+		s.Equal(tc.expected, names)
+		s.Equal([]byte("token"), result.NextPageToken)
+	}
+}
+
 func (s *workflowHandlerSuite) TestUpdateDomain_Failed_RequestInvalid() {
 	config := s.newConfig()
 	clusterMetadata := &mocks.ClusterMetadata{}
@@ -1041,3 +1181,204 @@ func registerDomainRequest(archivalStatus *shared.ArchivalStatus, bucketName *st
 		ArchivalBucketName: bucketName,
 	}
 }
+
+func (s *workflowHandlerSuite) TestQueryWorkflow_RejectCondition() {
+	wh, mockHistory, mockMatching := s.getWorkflowHandlerForQuery()
+
+	closeStatus := shared.WorkflowExecutionCloseStatusFailed
+	mockHistory.On("GetMutableState", mock.Anything, mock.Anything).Return(&h.GetMutableStateResponse{
+		Execution:           &shared.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr(uuid.New())},
+		TaskList:            &shared.TaskList{Name: common.StringPtr("task-list")},
+		StickyTaskList:      &shared.TaskList{},
+		IsWorkflowRunning:   common.BoolPtr(false),
+		WorkflowCloseStatus: &closeStatus,
+	}, nil)
+
+	for _, condition := range []shared.QueryRejectCondition{
+		shared.QueryRejectConditionNotOpen,
+		shared.QueryRejectConditionNotCompletedCleanly,
+	} {
+		request := s.newQueryWorkflowRequest()
+		request.QueryRejectCondition = condition.Ptr()
+		resp, err := wh.QueryWorkflow(context.Background(), request)
+		s.NoError(err)
+		s.Nil(resp.QueryResult)
+		s.Equal(closeStatus, resp.QueryRejected.GetCloseStatus())
+	}
+
+	// queries without reject condition are answered by the workers
+	mockMatching.On("QueryWorkflow", mock.Anything, mock.Anything).Return(&shared.QueryWorkflowResponse{
+		QueryResult: []byte("result"),
+	}, nil).Once()
+	resp, err := wh.QueryWorkflow(context.Background(), s.newQueryWorkflowRequest())
+	s.NoError(err)
+	s.Equal([]byte("result"), resp.QueryResult)
+	s.Nil(resp.QueryRejected)
+	mockMatching.AssertExpectations(s.T())
+}
+
+func (s *workflowHandlerSuite) TestQueryWorkflow_RejectCondition_CompletedCleanly() {
+	wh, mockHistory, mockMatching := s.getWorkflowHandlerForQuery()
+
+	closeStatus := shared.WorkflowExecutionCloseStatusCompleted
+	mockHistory.On("GetMutableState", mock.Anything, mock.Anything).Return(&h.GetMutableStateResponse{
+		Execution:           &shared.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr(uuid.New())},
+		TaskList:            &shared.TaskList{Name: common.StringPtr("task-list")},
+		StickyTaskList:      &shared.TaskList{},
+		IsWorkflowRunning:   common.BoolPtr(false),
+		WorkflowCloseStatus: &closeStatus,
+	}, nil)
+	mockMatching.On("QueryWorkflow", mock.Anything, mock.Anything).Return(&shared.QueryWorkflowResponse{
+		QueryResult: []byte("result"),
+	}, nil).Once()
+
+	request := s.newQueryWorkflowRequest()
+	request.QueryRejectCondition = shared.QueryRejectConditionNotCompletedCleanly.Ptr()
+	resp, err := wh.QueryWorkflow(context.Background(), request)
+	s.NoError(err)
+	s.Equal([]byte("result"), resp.QueryResult)
+	s.Nil(resp.QueryRejected)
+	mockMatching.AssertExpectations(s.T())
+}
+
+func (s *workflowHandlerSuite) TestQueryWorkflow_StrongConsistency() {
+	wh, mockHistory, mockMatching := s.getWorkflowHandlerForQuery()
+
+	execution := &shared.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr(uuid.New())}
+	mockHistory.On("GetMutableState", mock.Anything, mock.Anything).Return(&h.GetMutableStateResponse{
+		Execution:            execution,
+		TaskList:             &shared.TaskList{Name: common.StringPtr("task-list")},
+		StickyTaskList:       &shared.TaskList{},
+		IsWorkflowRunning:    common.BoolPtr(true),
+		ClientFeatureVersion: common.StringPtr("1.1.0"),
+	}, nil).Once()
+	mockHistory.On("QueryWorkflow", mock.Anything, mock.MatchedBy(func(request *h.QueryWorkflowRequest) bool {
+		return request.Request.Execution.GetRunId() == execution.GetRunId()
+	})).Return(&h.QueryWorkflowResponse{
+		Response: &shared.QueryWorkflowResponse{QueryResult: []byte("result")},
+	}, nil).Once()
+
+	request := s.newQueryWorkflowRequest()
+	request.QueryConsistencyLevel = shared.QueryConsistencyLevelStrong.Ptr()
+	resp, err := wh.QueryWorkflow(context.Background(), request)
+	s.NoError(err)
+	s.Equal([]byte("result"), resp.QueryResult)
+	mockHistory.AssertExpectations(s.T())
+	// the query was answered with the decision task, not dispatched to a poller
+	mockMatching.AssertNotCalled(s.T(), "QueryWorkflow", mock.Anything, mock.Anything)
+}
+
+func (s *workflowHandlerSuite) TestQueryWorkflow_StrongConsistency_NoPendingDecision() {
+	wh, mockHistory, mockMatching := s.getWorkflowHandlerForQuery()
+
+	execution := &shared.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr(uuid.New())}
+	mockHistory.On("GetMutableState", mock.Anything, mock.Anything).Return(&h.GetMutableStateResponse{
+		Execution:            execution,
+		TaskList:             &shared.TaskList{Name: common.StringPtr("task-list")},
+		StickyTaskList:       &shared.TaskList{},
+		IsWorkflowRunning:    common.BoolPtr(true),
+		ClientFeatureVersion: common.StringPtr("1.1.0"),
+	}, nil).Once()
+	mockHistory.On("QueryWorkflow", mock.Anything, mock.Anything).Return(&h.QueryWorkflowResponse{}, nil).Once()
+	mockMatching.On("QueryWorkflow", mock.Anything, mock.Anything).Return(&shared.QueryWorkflowResponse{
+		QueryResult: []byte("result"),
+	}, nil).Once()
+
+	request := s.newQueryWorkflowRequest()
+	request.QueryConsistencyLevel = shared.QueryConsistencyLevelStrong.Ptr()
+	resp, err := wh.QueryWorkflow(context.Background(), request)
+	s.NoError(err)
+	s.Equal([]byte("result"), resp.QueryResult)
+	mockHistory.AssertExpectations(s.T())
+	mockMatching.AssertExpectations(s.T())
+}
+
+func (s *workflowHandlerSuite) TestQueryWorkflow_StrongConsistency_Timeout() {
+	wh, mockHistory, _ := s.getWorkflowHandlerForQuery()
+
+	mockHistory.On("GetMutableState", mock.Anything, mock.Anything).Return(&h.GetMutableStateResponse{
+		Execution:            &shared.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr(uuid.New())},
+		TaskList:             &shared.TaskList{Name: common.StringPtr("task-list")},
+		StickyTaskList:       &shared.TaskList{},
+		IsWorkflowRunning:    common.BoolPtr(true),
+		ClientFeatureVersion: common.StringPtr("1.1.0"),
+	}, nil).Once()
+	ctx, cancel := context.WithCancel(context.Background())
+	mockHistory.On("QueryWorkflow", mock.Anything, mock.Anything).Return(nil, context.Canceled).Run(func(args mock.Arguments) {
+		cancel()
+	}).Once()
+
+	request := s.newQueryWorkflowRequest()
+	request.QueryConsistencyLevel = shared.QueryConsistencyLevelStrong.Ptr()
+	_, err := wh.QueryWorkflow(ctx, request)
+	s.Equal(errQueryPendingDecisionTimeout, err)
+}
+
+func (s *workflowHandlerSuite) TestQueryWorkflow_StrongConsistency_ClientNotSupported() {
+	wh, mockHistory, mockMatching := s.getWorkflowHandlerForQuery()
+
+	execution := &shared.WorkflowExecution{WorkflowId: common.StringPtr("wid"), RunId: common.StringPtr(uuid.New())}
+	// the workers of the workflow cannot answer queries delivered with a decision task
+	mockHistory.On("GetMutableState", mock.Anything, mock.MatchedBy(func(request *h.GetMutableStateRequest) bool {
+		return request.ExpectedNextEventId == nil
+	})).Return(&h.GetMutableStateResponse{
+		Execution:            execution,
+		TaskList:             &shared.TaskList{Name: common.StringPtr("task-list")},
+		StickyTaskList:       &shared.TaskList{},
+		IsWorkflowRunning:    common.BoolPtr(true),
+		HasPendingDecision:   common.BoolPtr(true),
+		NextEventId:          common.Int64Ptr(5),
+		ClientFeatureVersion: common.StringPtr("1.0.0"),
+	}, nil).Once()
+	mockHistory.On("GetMutableState", mock.Anything, mock.MatchedBy(func(request *h.GetMutableStateRequest) bool {
+		return request.GetExpectedNextEventId() == 5
+	})).Return(&h.GetMutableStateResponse{
+		NextEventId: common.Int64Ptr(7),
+	}, nil).Once()
+	mockHistory.On("GetMutableState", mock.Anything, mock.MatchedBy(func(request *h.GetMutableStateRequest) bool {
+		return request.ExpectedNextEventId == nil
+	})).Return(&h.GetMutableStateResponse{
+		Execution:            execution,
+		TaskList:             &shared.TaskList{Name: common.StringPtr("task-list")},
+		StickyTaskList:       &shared.TaskList{},
+		IsWorkflowRunning:    common.BoolPtr(true),
+		HasPendingDecision:   common.BoolPtr(false),
+		NextEventId:          common.Int64Ptr(7),
+		ClientFeatureVersion: common.StringPtr("1.0.0"),
+	}, nil).Once()
+	mockMatching.On("QueryWorkflow", mock.Anything, mock.Anything).Return(&shared.QueryWorkflowResponse{
+		QueryResult: []byte("result"),
+	}, nil).Once()
+
+	request := s.newQueryWorkflowRequest()
+	request.QueryConsistencyLevel = shared.QueryConsistencyLevelStrong.Ptr()
+	resp, err := wh.QueryWorkflow(context.Background(), request)
+	s.NoError(err)
+	s.Equal([]byte("result"), resp.QueryResult)
+	mockHistory.AssertExpectations(s.T())
+	mockHistory.AssertNotCalled(s.T(), "QueryWorkflow", mock.Anything, mock.Anything)
+	mockMatching.AssertExpectations(s.T())
+}
+
+func (s *workflowHandlerSuite) getWorkflowHandlerForQuery() (*WorkflowHandler, *mocks.HistoryClient, *mocks.MatchingClient) {
+	wh := s.getWorkflowHandler(s.newConfig())
+	mockDomainCache := &cache.DomainCacheMock{}
+	mockDomainCache.On("GetDomainID", mock.Anything).Return(uuid.New(), nil)
+	mockHistory := &mocks.HistoryClient{}
+	mockMatching := &mocks.MatchingClient{}
+	wh.metricsClient = wh.Service.GetMetricsClient()
+	wh.domainCache = mockDomainCache
+	wh.history = mockHistory
+	wh.matching = mockMatching
+	wh.matchingRawClient = mockMatching
+	wh.startWG.Done()
+	return wh, mockHistory, mockMatching
+}
+
+func (s *workflowHandlerSuite) newQueryWorkflowRequest() *shared.QueryWorkflowRequest {
+	return &shared.QueryWorkflowRequest{
+		Domain:    common.StringPtr("test-domain"),
+		Execution: &shared.WorkflowExecution{WorkflowId: common.StringPtr("wid")},
+		Query:     &shared.WorkflowQuery{QueryType: common.StringPtr("query-type")},
+	}
+}
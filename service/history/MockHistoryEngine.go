@@ -112,6 +112,29 @@ func (_m *MockHistoryEngine) GetMutableState(ctx context.Context, request *gohis
 	return r0, r1
 }
 
+// QueryWorkflow is mock implementation for QueryWorkflow of HistoryEngine
+func (_m *MockHistoryEngine) QueryWorkflow(ctx context.Context, request *gohistory.QueryWorkflowRequest) (*gohistory.QueryWorkflowResponse, error) {
+	ret := _m.Called(ctx, request)
+
+	var r0 *gohistory.QueryWorkflowResponse
+	if rf, ok := ret.Get(0).(func(*gohistory.QueryWorkflowRequest) *gohistory.QueryWorkflowResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*gohistory.QueryWorkflowResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*gohistory.QueryWorkflowRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // ResetStickyTaskList is mock implementation for ResetStickyTaskList of HistoryEngine
 func (_m *MockHistoryEngine) ResetStickyTaskList(ctx context.Context, request *gohistory.ResetStickyTaskListRequest) (*gohistory.ResetStickyTaskListResponse, error) {
 	ret := _m.Called(request)
@@ -386,6 +409,34 @@ func (_m *MockHistoryEngine) TerminateWorkflowExecution(ctx context.Context, req
 	return r0
 }
 
+// PauseWorkflowExecution is mock implementation for PauseWorkflowExecution of HistoryEngine
+func (_m *MockHistoryEngine) PauseWorkflowExecution(ctx context.Context, request *gohistory.PauseWorkflowExecutionRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.PauseWorkflowExecutionRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UnpauseWorkflowExecution is mock implementation for UnpauseWorkflowExecution of HistoryEngine
+func (_m *MockHistoryEngine) UnpauseWorkflowExecution(ctx context.Context, request *gohistory.UnpauseWorkflowExecutionRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.UnpauseWorkflowExecutionRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // ResetWorkflowExecution is mock implementation for TerminateWorkflowExecution of HistoryEngine
 func (_m *MockHistoryEngine) ResetWorkflowExecution(ctx context.Context, request *gohistory.ResetWorkflowExecutionRequest) (*shared.ResetWorkflowExecutionResponse, error) {
 	ret := _m.Called(request)
@@ -493,4 +544,55 @@ func (_m *MockHistoryEngine) SyncActivity(ctx context.Context, request *gohistor
 	return r0
 }
 
+// ListQueueTasks is mock implementation for ListQueueTasks of HistoryEngine
+func (_m *MockHistoryEngine) ListQueueTasks(ctx context.Context, request *shared.ListQueueTasksRequest) (*shared.ListQueueTasksResponse, error) {
+	ret := _m.Called(request)
+
+	var r0 *shared.ListQueueTasksResponse
+	if rf, ok := ret.Get(0).(func(*shared.ListQueueTasksRequest) *shared.ListQueueTasksResponse); ok {
+		r0 = rf(request)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.ListQueueTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(*shared.ListQueueTasksRequest) error); ok {
+		r1 = rf(request)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveQueueTask is mock implementation for RemoveQueueTask of HistoryEngine
+func (_m *MockHistoryEngine) RemoveQueueTask(ctx context.Context, request *shared.RemoveQueueTaskRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*shared.RemoveQueueTaskRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// RegenerateWorkflowTasks is mock implementation for RegenerateWorkflowTasks of HistoryEngine
+func (_m *MockHistoryEngine) RegenerateWorkflowTasks(ctx context.Context, request *gohistory.RegenerateWorkflowTasksRequest) error {
+	ret := _m.Called(request)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*gohistory.RegenerateWorkflowTasksRequest) error); ok {
+		r0 = rf(request)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 var _ Engine = (*MockHistoryEngine)(nil)
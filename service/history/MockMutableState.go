@@ -350,6 +350,29 @@ func (_m *mockMutableState) AddDecisionTaskCompletedEvent(_a0 int64, _a1 int64,
 	return r0
 }
 
+// AddDecisionTaskHeartbeatEvent provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockMutableState) AddDecisionTaskHeartbeatEvent(_a0 int64, _a1 int64, _a2 *shared.RespondDecisionTaskCompletedRequest) (*shared.HistoryEvent, bool) {
+	ret := _m.Called(_a0, _a1, _a2)
+
+	var r0 *shared.HistoryEvent
+	if rf, ok := ret.Get(0).(func(int64, int64, *shared.RespondDecisionTaskCompletedRequest) *shared.HistoryEvent); ok {
+		r0 = rf(_a0, _a1, _a2)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.HistoryEvent)
+		}
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(int64, int64, *shared.RespondDecisionTaskCompletedRequest) bool); ok {
+		r1 = rf(_a0, _a1, _a2)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // AddDecisionTaskFailedEvent provides a mock function with given fields
 func (_m *mockMutableState) AddDecisionTaskFailedEvent(_a0 int64, _a1 int64, _a2 shared.DecisionTaskFailedCause, _a3 []uint8, _a4, _a5, _a6, _a7 string, _a8 int64) *shared.HistoryEvent {
 	ret := _m.Called(_a0, _a1, _a2, _a3, _a4, _a5, _a6, _a7, _a8)
@@ -779,6 +802,22 @@ func (_m *mockMutableState) AddWorkflowExecutionCanceledEvent(_a0 int64, _a1 *sh
 	return r0
 }
 
+// AddWorkflowExecutionPausedEvent provides a mock function with given fields: _a0
+func (_m *mockMutableState) AddWorkflowExecutionPausedEvent(_a0 *shared.PauseWorkflowExecutionRequest) *shared.HistoryEvent {
+	ret := _m.Called(_a0)
+
+	var r0 *shared.HistoryEvent
+	if rf, ok := ret.Get(0).(func(*shared.PauseWorkflowExecutionRequest) *shared.HistoryEvent); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.HistoryEvent)
+		}
+	}
+
+	return r0
+}
+
 // AddWorkflowExecutionSignaled provides a mock function with given fields: _a0
 func (_m *mockMutableState) AddWorkflowExecutionSignaled(_a0 string, _a1 []byte, _a2 string) *shared.HistoryEvent {
 	ret := _m.Called(_a0, _a1, _a2)
@@ -827,6 +866,22 @@ func (_m *mockMutableState) AddWorkflowExecutionTerminatedEvent(_a0 *shared.Term
 	return r0
 }
 
+// AddWorkflowExecutionUnpausedEvent provides a mock function with given fields: _a0
+func (_m *mockMutableState) AddWorkflowExecutionUnpausedEvent(_a0 *shared.UnpauseWorkflowExecutionRequest) *shared.HistoryEvent {
+	ret := _m.Called(_a0)
+
+	var r0 *shared.HistoryEvent
+	if rf, ok := ret.Get(0).(func(*shared.UnpauseWorkflowExecutionRequest) *shared.HistoryEvent); ok {
+		r0 = rf(_a0)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*shared.HistoryEvent)
+		}
+	}
+
+	return r0
+}
+
 // AfterAddDecisionTaskCompletedEvent provides a mock function with given fields: _a0
 func (_m *mockMutableState) AfterAddDecisionTaskCompletedEvent(_a0 int64) {
 	_m.Called(_a0)
@@ -1250,6 +1305,20 @@ func (_m *mockMutableState) GetCurrentVersion() int64 {
 	return r0
 }
 
+// GetDecisionFailureAttempt provides a mock function with given fields:
+func (_m *mockMutableState) GetDecisionFailureAttempt() int64 {
+	ret := _m.Called()
+
+	var r0 int64
+	if rf, ok := ret.Get(0).(func() int64); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	return r0
+}
+
 // GetExecutionInfo provides a mock function with given fields:
 func (_m *mockMutableState) GetExecutionInfo() *persistence.WorkflowExecutionInfo {
 	ret := _m.Called()
@@ -1713,6 +1782,20 @@ func (_m *mockMutableState) HasPendingDecisionTask() bool {
 	return r0
 }
 
+// HasProcessedOrPendingDecisionTask provides a mock function with given fields:
+func (_m *mockMutableState) HasProcessedOrPendingDecisionTask() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // IncrementHistorySize provides a mock function with given fields: appendSize
 func (_m *mockMutableState) IncrementHistorySize(appendSize int) {
 	_m.Called(appendSize)
@@ -1739,6 +1822,20 @@ func (_m *mockMutableState) IsCancelRequested() (bool, string) {
 	return r0, r1
 }
 
+// IsPaused provides a mock function with given fields:
+func (_m *mockMutableState) IsPaused() bool {
+	ret := _m.Called()
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	return r0
+}
+
 // IsSignalRequested provides a mock function with given fields: requestID
 func (_m *mockMutableState) IsSignalRequested(requestID string) bool {
 	ret := _m.Called(requestID)
@@ -1921,9 +2018,9 @@ func (_m *mockMutableState) ReplicateChildWorkflowExecutionTimedOutEvent(_a0 *sh
 	_m.Called(_a0)
 }
 
-// ReplicateDecisionTaskCompletedEvent provides a mock function with given fields: _a0, _a1
-func (_m *mockMutableState) ReplicateDecisionTaskCompletedEvent(_a0 int64, _a1 int64) {
-	_m.Called(_a0, _a1)
+// ReplicateDecisionTaskCompletedEvent provides a mock function with given fields: _a0, _a1, _a2
+func (_m *mockMutableState) ReplicateDecisionTaskCompletedEvent(_a0 int64, _a1 int64, _a2 string) {
+	_m.Called(_a0, _a1, _a2)
 }
 
 // ReplicateDecisionTaskFailedEvent provides a mock function with given fields: _a0, _a1
@@ -2116,6 +2213,11 @@ func (_m *mockMutableState) ReplicateWorkflowExecutionFailedEvent(_a0 int64, _a1
 	_m.Called(_a0, _a1)
 }
 
+// ReplicateWorkflowExecutionPausedEvent provides a mock function with given fields: _a0
+func (_m *mockMutableState) ReplicateWorkflowExecutionPausedEvent(_a0 *shared.HistoryEvent) {
+	_m.Called(_a0)
+}
+
 // ReplicateWorkflowExecutionSignaled provides a mock function with given fields: _a0
 func (_m *mockMutableState) ReplicateWorkflowExecutionSignaled(_a0 *shared.HistoryEvent) {
 	_m.Called(_a0)
@@ -2136,6 +2238,11 @@ func (_m *mockMutableState) ReplicateWorkflowExecutionTimedoutEvent(_a0 int64, _
 	_m.Called(_a0, _a1)
 }
 
+// ReplicateWorkflowExecutionUnpausedEvent provides a mock function with given fields: _a0
+func (_m *mockMutableState) ReplicateWorkflowExecutionUnpausedEvent(_a0 *shared.HistoryEvent) {
+	_m.Called(_a0)
+}
+
 // ResetSnapshot provides a mock function with given fields:
 func (_m *mockMutableState) ResetSnapshot(_a0 string) *persistence.ResetMutableStateRequest {
 	ret := _m.Called(_a0)
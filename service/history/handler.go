@@ -22,6 +22,7 @@ package history
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"sync"
 
@@ -88,6 +89,8 @@ var (
 	errSourceClusterNotSet     = &gen.BadRequestError{Message: "Source Cluster not set on request."}
 	errShardIDNotSet           = &gen.BadRequestError{Message: "Shard ID not set on request."}
 	errTimestampNotSet         = &gen.BadRequestError{Message: "Timestamp not set on request."}
+	errTaskTypeNotSet          = &gen.BadRequestError{Message: "Task type not set on request."}
+	errTaskIDNotSet            = &gen.BadRequestError{Message: "Task ID not set on request."}
 	errHistoryHostThrottle     = &gen.ServiceBusyError{Message: "History host rps exceeded"}
 )
 
@@ -642,6 +645,176 @@ func (h *Handler) DescribeHistoryHost(ctx context.Context,
 	return resp, nil
 }
 
+// CloseShard closes the shard owned by this host, the shard stays owned by this host which reloads it
+// from persistence on next request
+func (h *Handler) CloseShard(ctx context.Context, request *gen.CloseShardRequest) error {
+	h.startWG.Wait()
+
+	scope := metrics.HistoryCloseShardScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if request.ShardID == nil {
+		return h.error(errShardIDNotSet, scope, "", "")
+	}
+
+	if err := h.controller.closeShard(int(request.GetShardID())); err != nil {
+		return h.error(err, scope, "", "")
+	}
+	return nil
+}
+
+// DescribeShard returns information about the persisted state of a shard
+func (h *Handler) DescribeShard(ctx context.Context, request *gen.DescribeShardRequest) (*gen.DescribeShardResponse, error) {
+	h.startWG.Wait()
+
+	scope := metrics.HistoryDescribeShardScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if request.ShardID == nil {
+		return nil, h.error(errShardIDNotSet, scope, "", "")
+	}
+	shardID := int(request.GetShardID())
+
+	info, err := h.hServiceResolver.Lookup(string(shardID))
+	if err != nil {
+		return nil, h.error(err, scope, "", "")
+	}
+
+	resp, err := h.shardManager.GetShard(&persistence.GetShardRequest{ShardID: shardID})
+	if err != nil {
+		return nil, h.error(err, scope, "", "")
+	}
+	jsonBytes, err := json.Marshal(resp.ShardInfo)
+	if err != nil {
+		return nil, h.error(err, scope, "", "")
+	}
+
+	return &gen.DescribeShardResponse{
+		ShardID:             request.ShardID,
+		HostAddress:         common.StringPtr(info.GetAddress()),
+		ShardInfoInDatabase: common.StringPtr(string(jsonBytes)),
+	}, nil
+}
+
+// DrainHistoryHost removes this host from membership ring, shards owned by this host
+// are released once the ring change is propagated and acquired by other hosts.
+// The host does not re-join the ring until it is restarted, so it is only meant to be
+// called before shutting the host down.
+func (h *Handler) DrainHistoryHost(ctx context.Context, request *gen.DrainHistoryHostRequest) error {
+	h.startWG.Wait()
+
+	scope := metrics.HistoryDrainHistoryHostScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if address := h.GetHostInfo().GetAddress(); request.GetHostAddress() != address {
+		return h.error(&gen.BadRequestError{
+			Message: fmt.Sprintf("Request host address %v doesn't match host %v.", request.GetHostAddress(), address),
+		}, scope, "", "")
+	}
+
+	h.GetLogger().Info("Draining history host, evicting self from membership ring.")
+	if err := h.GetMembershipMonitor().EvictSelf(); err != nil {
+		return h.error(err, scope, "", "")
+	}
+	return nil
+}
+
+// ListQueueTasks returns the pending transfer, timer or replication tasks of a shard owned by this host
+func (h *Handler) ListQueueTasks(ctx context.Context, request *gen.ListQueueTasksRequest) (*gen.ListQueueTasksResponse, error) {
+	h.startWG.Wait()
+
+	scope := metrics.HistoryListQueueTasksScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if request.ShardID == nil {
+		return nil, h.error(errShardIDNotSet, scope, "", "")
+	}
+	if request.TaskType == nil {
+		return nil, h.error(errTaskTypeNotSet, scope, "", "")
+	}
+
+	engine, err := h.controller.getEngineForShard(int(request.GetShardID()))
+	if err != nil {
+		return nil, h.error(err, scope, "", "")
+	}
+
+	resp, err := engine.ListQueueTasks(ctx, request)
+	if err != nil {
+		return nil, h.error(err, scope, "", "")
+	}
+	return resp, nil
+}
+
+// RemoveQueueTask removes a transfer, timer or replication task from the queue of a shard owned by this host
+func (h *Handler) RemoveQueueTask(ctx context.Context, request *gen.RemoveQueueTaskRequest) error {
+	h.startWG.Wait()
+
+	scope := metrics.HistoryRemoveQueueTaskScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	if request.ShardID == nil {
+		return h.error(errShardIDNotSet, scope, "", "")
+	}
+	if request.TaskType == nil {
+		return h.error(errTaskTypeNotSet, scope, "", "")
+	}
+	if request.TaskID == nil {
+		return h.error(errTaskIDNotSet, scope, "", "")
+	}
+	if request.GetTaskType() == gen.QueueTaskTypeTimer && request.VisibilityTimestamp == nil {
+		return h.error(errTimestampNotSet, scope, "", "")
+	}
+
+	engine, err := h.controller.getEngineForShard(int(request.GetShardID()))
+	if err != nil {
+		return h.error(err, scope, "", "")
+	}
+
+	if err := engine.RemoveQueueTask(ctx, request); err != nil {
+		return h.error(err, scope, "", "")
+	}
+	return nil
+}
+
+// RegenerateWorkflowTasks re-generates the transfer and timer tasks of a workflow from its mutable state
+func (h *Handler) RegenerateWorkflowTasks(ctx context.Context, request *hist.RegenerateWorkflowTasksRequest) error {
+	h.startWG.Wait()
+
+	scope := metrics.HistoryRegenerateWorkflowTasksScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := request.GetDomainUUID()
+	if domainID == "" {
+		return h.error(errDomainNotSet, scope, domainID, "")
+	}
+	if request.Execution == nil {
+		return h.error(errWorkflowExecutionNotSet, scope, domainID, "")
+	}
+
+	workflowID := request.Execution.GetWorkflowId()
+	engine, err := h.controller.GetEngine(workflowID)
+	if err != nil {
+		return h.error(err, scope, domainID, workflowID)
+	}
+
+	if err := engine.RegenerateWorkflowTasks(ctx, request); err != nil {
+		return h.error(err, scope, domainID, workflowID)
+	}
+	return nil
+}
+
 // DescribeMutableState - returns the internal analysis of workflow execution state
 func (h *Handler) DescribeMutableState(ctx context.Context,
 	request *hist.DescribeMutableStateRequest) (*hist.DescribeMutableStateResponse, error) {
@@ -704,6 +877,38 @@ func (h *Handler) GetMutableState(ctx context.Context,
 	return resp, nil
 }
 
+// QueryWorkflow delivers a strongly consistent query with the next decision task of the workflow
+func (h *Handler) QueryWorkflow(ctx context.Context,
+	request *hist.QueryWorkflowRequest) (*hist.QueryWorkflowResponse, error) {
+	h.startWG.Wait()
+
+	scope := metrics.HistoryQueryWorkflowScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := request.GetDomainUUID()
+	if domainID == "" {
+		return nil, h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return nil, h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowID := request.Request.Execution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return nil, h.error(err1, scope, domainID, workflowID)
+	}
+
+	resp, err2 := engine.QueryWorkflow(ctx, request)
+	if err2 != nil {
+		return nil, h.error(err2, scope, domainID, workflowID)
+	}
+	return resp, nil
+}
+
 // DescribeWorkflowExecution returns information about the specified workflow execution.
 func (h *Handler) DescribeWorkflowExecution(ctx context.Context, request *hist.DescribeWorkflowExecutionRequest) (*gen.DescribeWorkflowExecutionResponse, error) {
 	h.startWG.Wait()
@@ -919,6 +1124,76 @@ func (h *Handler) TerminateWorkflowExecution(ctx context.Context,
 	return nil
 }
 
+// PauseWorkflowExecution pauses an existing workflow execution by recording WorkflowExecutionPaused event
+// in the history, decision and activity tasks are held back until the execution is unpaused.
+func (h *Handler) PauseWorkflowExecution(ctx context.Context,
+	wrappedRequest *hist.PauseWorkflowExecutionRequest) error {
+	h.startWG.Wait()
+
+	scope := metrics.HistoryPauseWorkflowExecutionScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := wrappedRequest.GetDomainUUID()
+	if domainID == "" {
+		return h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowExecution := wrappedRequest.PauseRequest.WorkflowExecution
+	workflowID := workflowExecution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return h.error(err1, scope, domainID, workflowID)
+	}
+
+	err2 := engine.PauseWorkflowExecution(ctx, wrappedRequest)
+	if err2 != nil {
+		return h.error(err2, scope, domainID, workflowID)
+	}
+
+	return nil
+}
+
+// UnpauseWorkflowExecution resumes a paused workflow execution by recording WorkflowExecutionUnpaused event
+// in the history and dispatching the tasks held back while paused.
+func (h *Handler) UnpauseWorkflowExecution(ctx context.Context,
+	wrappedRequest *hist.UnpauseWorkflowExecutionRequest) error {
+	h.startWG.Wait()
+
+	scope := metrics.HistoryUnpauseWorkflowExecutionScope
+	h.metricsClient.IncCounter(scope, metrics.CadenceRequests)
+	sw := h.metricsClient.StartTimer(scope, metrics.CadenceLatency)
+	defer sw.Stop()
+
+	domainID := wrappedRequest.GetDomainUUID()
+	if domainID == "" {
+		return h.error(errDomainNotSet, scope, domainID, "")
+	}
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return h.error(errHistoryHostThrottle, scope, domainID, "")
+	}
+
+	workflowExecution := wrappedRequest.UnpauseRequest.WorkflowExecution
+	workflowID := workflowExecution.GetWorkflowId()
+	engine, err1 := h.controller.GetEngine(workflowID)
+	if err1 != nil {
+		return h.error(err1, scope, domainID, workflowID)
+	}
+
+	err2 := engine.UnpauseWorkflowExecution(ctx, wrappedRequest)
+	if err2 != nil {
+		return h.error(err2, scope, domainID, workflowID)
+	}
+
+	return nil
+}
+
 // ResetWorkflowExecution reset an existing workflow execution
 // in the history and immediately terminating the execution instance.
 func (h *Handler) ResetWorkflowExecution(ctx context.Context,
@@ -1264,6 +1539,8 @@ func (h *Handler) updateErrorMetric(scope int, domainID, workflowID string, err
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrRetryTaskCounter)
 	case *gen.ServiceBusyError:
 		h.metricsClient.IncCounter(scope, metrics.CadenceErrServiceBusyCounter)
+	case *gen.QueryFailedError:
+		h.metricsClient.IncCounter(scope, metrics.CadenceErrQueryFailedCounter)
 	case *yarpcerrors.Status:
 		if err.Code() == yarpcerrors.CodeDeadlineExceeded {
 			h.metricsClient.IncCounter(scope, metrics.CadenceErrContextTimeoutCounter)
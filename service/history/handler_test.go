@@ -0,0 +1,221 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber-go/tally"
+
+	hist "github.com/uber/cadence/.gen/go/history"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/cache"
+	"github.com/uber/cadence/common/membership"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/service"
+)
+
+type (
+	handlerSuite struct {
+		suite.Suite
+		// override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test,
+		// not merely log an error
+		*require.Assertions
+		handler             *Handler
+		hostInfo            *membership.HostInfo
+		mockShardManager    *mocks.ShardManager
+		mockMetadataMgr     *mocks.MetadataManager
+		mockServiceResolver *mocks.ServiceResolver
+		mockClusterMetadata *mocks.ClusterMetadata
+		mockClientBean      *client.MockClientBean
+		monitor             *handlerTestMonitor
+	}
+
+	// handlerTestService is the test service with a membership monitor
+	handlerTestService struct {
+		service.Service
+		monitor membership.Monitor
+	}
+
+	// handlerTestMonitor records the evictions of the host from the membership ring
+	handlerTestMonitor struct {
+		membership.Monitor
+		evictSelfErr   error
+		evictSelfCount int
+	}
+)
+
+func TestHandlerSuite(t *testing.T) {
+	s := new(handlerSuite)
+	suite.Run(t, s)
+}
+
+func (s *handlerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	logger := bark.NewLoggerFromLogrus(log.New())
+	config := NewDynamicConfigForTest()
+	metricsClient := metrics.NewClient(tally.NoopScope, metrics.History)
+	s.mockShardManager = &mocks.ShardManager{}
+	s.mockMetadataMgr = &mocks.MetadataManager{}
+	s.mockServiceResolver = &mocks.ServiceResolver{}
+	s.mockClusterMetadata = &mocks.ClusterMetadata{}
+	s.mockClientBean = &client.MockClientBean{}
+	s.monitor = &handlerTestMonitor{}
+	svc := &handlerTestService{
+		Service: service.NewTestService(s.mockClusterMetadata, nil, metricsClient, s.mockClientBean, logger),
+		monitor: s.monitor,
+	}
+	s.hostInfo = svc.GetHostInfo()
+
+	s.handler = NewHandler(svc, config, s.mockShardManager, s.mockMetadataMgr, nil, nil, nil, nil)
+	s.handler.metricsClient = metricsClient
+	s.handler.hServiceResolver = s.mockServiceResolver
+	domainCache := cache.NewDomainCache(s.mockMetadataMgr, s.mockClusterMetadata, metricsClient, logger)
+	s.handler.controller = newShardController(svc, s.hostInfo, s.mockServiceResolver, s.mockShardManager,
+		nil, nil, domainCache, nil, s.handler, config, logger, metricsClient)
+	s.handler.startWG.Done()
+}
+
+func (s *handlerSuite) TearDownTest() {
+	s.mockShardManager.AssertExpectations(s.T())
+	s.mockServiceResolver.AssertExpectations(s.T())
+	s.mockClientBean.AssertExpectations(s.T())
+}
+
+func (s *handlerSuite) TestCloseShard() {
+	shardID := 7
+	s.mockServiceResolver.On("Lookup", string(rune(shardID))).Return(s.hostInfo, nil).Once()
+
+	err := s.handler.CloseShard(context.Background(), &workflow.CloseShardRequest{ShardID: common.Int32Ptr(int32(shardID))})
+	s.NoError(err)
+}
+
+func (s *handlerSuite) TestCloseShard_ShardIDNotSet() {
+	err := s.handler.CloseShard(context.Background(), &workflow.CloseShardRequest{})
+	s.Equal(errShardIDNotSet, err)
+}
+
+func (s *handlerSuite) TestCloseShard_NotOwned() {
+	shardID := 7
+	owner := membership.NewHostInfo("owner-host", nil)
+	s.mockServiceResolver.On("Lookup", string(rune(shardID))).Return(owner, nil).Once()
+
+	err := s.handler.CloseShard(context.Background(), &workflow.CloseShardRequest{ShardID: common.Int32Ptr(int32(shardID))})
+	ownershipLostErr, ok := err.(*hist.ShardOwnershipLostError)
+	s.True(ok)
+	s.Equal(owner.GetAddress(), ownershipLostErr.GetOwner())
+}
+
+func (s *handlerSuite) TestCloseShard_RingFailure() {
+	shardID := 7
+	ringErr := errors.New("ring failure")
+	s.mockServiceResolver.On("Lookup", string(rune(shardID))).Return(nil, ringErr).Once()
+
+	err := s.handler.CloseShard(context.Background(), &workflow.CloseShardRequest{ShardID: common.Int32Ptr(int32(shardID))})
+	s.Equal(ringErr, err)
+}
+
+func (s *handlerSuite) TestDescribeShard() {
+	shardID := 7
+	owner := membership.NewHostInfo("owner-host", nil)
+	shardInfo := &persistence.ShardInfo{ShardID: shardID, Owner: "owner-host", RangeID: 12}
+	s.mockServiceResolver.On("Lookup", string(rune(shardID))).Return(owner, nil).Once()
+	s.mockShardManager.On("GetShard", &persistence.GetShardRequest{ShardID: shardID}).Return(
+		&persistence.GetShardResponse{ShardInfo: shardInfo}, nil).Once()
+
+	resp, err := s.handler.DescribeShard(context.Background(), &workflow.DescribeShardRequest{
+		ShardID: common.Int32Ptr(int32(shardID)),
+	})
+	s.NoError(err)
+	s.Equal(int32(shardID), resp.GetShardID())
+	s.Equal(owner.GetAddress(), resp.GetHostAddress())
+	var describedInfo persistence.ShardInfo
+	s.NoError(json.Unmarshal([]byte(resp.GetShardInfoInDatabase()), &describedInfo))
+	s.Equal(shardInfo.Owner, describedInfo.Owner)
+	s.Equal(shardInfo.RangeID, describedInfo.RangeID)
+}
+
+func (s *handlerSuite) TestDescribeShard_ShardIDNotSet() {
+	_, err := s.handler.DescribeShard(context.Background(), &workflow.DescribeShardRequest{})
+	s.Equal(errShardIDNotSet, err)
+}
+
+func (s *handlerSuite) TestDescribeShard_OwnershipLost() {
+	shardID := 7
+	owner := membership.NewHostInfo("owner-host", nil)
+	// the first lookup resolves the owner, the second one maps the persistence error
+	s.mockServiceResolver.On("Lookup", string(rune(shardID))).Return(owner, nil).Twice()
+	s.mockShardManager.On("GetShard", &persistence.GetShardRequest{ShardID: shardID}).Return(
+		nil, &persistence.ShardOwnershipLostError{ShardID: shardID, Msg: "range id changed"}).Once()
+
+	_, err := s.handler.DescribeShard(context.Background(), &workflow.DescribeShardRequest{
+		ShardID: common.Int32Ptr(int32(shardID)),
+	})
+	ownershipLostErr, ok := err.(*hist.ShardOwnershipLostError)
+	s.True(ok)
+	s.Equal(owner.GetAddress(), ownershipLostErr.GetOwner())
+}
+
+func (s *handlerSuite) TestDrainHistoryHost() {
+	err := s.handler.DrainHistoryHost(context.Background(), &workflow.DrainHistoryHostRequest{
+		HostAddress: common.StringPtr(s.hostInfo.GetAddress()),
+	})
+	s.NoError(err)
+	s.Equal(1, s.monitor.evictSelfCount)
+}
+
+func (s *handlerSuite) TestDrainHistoryHost_OtherHost() {
+	err := s.handler.DrainHistoryHost(context.Background(), &workflow.DrainHistoryHostRequest{
+		HostAddress: common.StringPtr("other-host"),
+	})
+	s.IsType(&workflow.BadRequestError{}, err)
+	s.Equal(0, s.monitor.evictSelfCount)
+}
+
+func (s *handlerSuite) TestDrainHistoryHost_EvictSelfNotSupported() {
+	s.monitor.evictSelfErr = membership.ErrEvictSelfNotSupported
+
+	err := s.handler.DrainHistoryHost(context.Background(), &workflow.DrainHistoryHostRequest{
+		HostAddress: common.StringPtr(s.hostInfo.GetAddress()),
+	})
+	s.Equal(membership.ErrEvictSelfNotSupported, err)
+	s.Equal(1, s.monitor.evictSelfCount)
+}
+
+func (s *handlerTestService) GetMembershipMonitor() membership.Monitor {
+	return s.monitor
+}
+
+func (m *handlerTestMonitor) EvictSelf() error {
+	m.evictSelfCount++
+	return m.evictSelfErr
+}
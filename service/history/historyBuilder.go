@@ -186,6 +186,20 @@ func (b *historyBuilder) AddWorkflowExecutionTerminatedEvent(
 	return b.addEventToHistory(event)
 }
 
+func (b *historyBuilder) AddWorkflowExecutionPausedEvent(
+	request *workflow.PauseWorkflowExecutionRequest) *workflow.HistoryEvent {
+	event := b.newWorkflowExecutionPausedEvent(request)
+
+	return b.addEventToHistory(event)
+}
+
+func (b *historyBuilder) AddWorkflowExecutionUnpausedEvent(
+	request *workflow.UnpauseWorkflowExecutionRequest) *workflow.HistoryEvent {
+	event := b.newWorkflowExecutionUnpausedEvent(request)
+
+	return b.addEventToHistory(event)
+}
+
 func (b *historyBuilder) AddContinuedAsNewEvent(decisionCompletedEventID int64, newRunID string,
 	attributes *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent {
 	event := b.newWorkflowExecutionContinuedAsNewEvent(decisionCompletedEventID, newRunID, attributes)
@@ -466,6 +480,7 @@ func (b *historyBuilder) newWorkflowExecutionStartedEvent(
 	attributes.ContinuedFailureDetails = startRequest.ContinuedFailureDetails
 	attributes.Initiator = startRequest.ContinueAsNewInitiator
 	attributes.FirstDecisionTaskBackoffSeconds = startRequest.FirstDecisionTaskBackoffSeconds
+	attributes.Priority = request.Priority
 
 	parentInfo := startRequest.ParentExecutionInfo
 	if parentInfo != nil {
@@ -551,6 +566,11 @@ func (b *historyBuilder) newActivityTaskScheduledEvent(decisionTaskCompletedEven
 	attributes.HeartbeatTimeoutSeconds = common.Int32Ptr(common.Int32Default(scheduleAttributes.HeartbeatTimeoutSeconds))
 	attributes.DecisionTaskCompletedEventId = common.Int64Ptr(decisionTaskCompletedEventID)
 	attributes.RetryPolicy = scheduleAttributes.RetryPolicy
+	attributes.Priority = scheduleAttributes.Priority
+	if attributes.Priority == nil {
+		// activities without explicit priority are dispatched with the priority of the workflow
+		attributes.Priority = common.Int32Ptr(b.msBuilder.GetExecutionInfo().Priority)
+	}
 	historyEvent.ActivityTaskScheduledEventAttributes = attributes
 
 	return historyEvent
@@ -665,6 +685,30 @@ func (b *historyBuilder) newWorkflowExecutionTerminatedEvent(
 	return historyEvent
 }
 
+func (b *historyBuilder) newWorkflowExecutionPausedEvent(
+	request *workflow.PauseWorkflowExecutionRequest) *workflow.HistoryEvent {
+	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionPaused)
+	attributes := &workflow.WorkflowExecutionPausedEventAttributes{}
+	attributes.Reason = common.StringPtr(common.StringDefault(request.Reason))
+	attributes.Details = request.Details
+	attributes.Identity = common.StringPtr(common.StringDefault(request.Identity))
+	historyEvent.WorkflowExecutionPausedEventAttributes = attributes
+
+	return historyEvent
+}
+
+func (b *historyBuilder) newWorkflowExecutionUnpausedEvent(
+	request *workflow.UnpauseWorkflowExecutionRequest) *workflow.HistoryEvent {
+	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeWorkflowExecutionUnpaused)
+	attributes := &workflow.WorkflowExecutionUnpausedEventAttributes{}
+	attributes.Reason = common.StringPtr(common.StringDefault(request.Reason))
+	attributes.Details = request.Details
+	attributes.Identity = common.StringPtr(common.StringDefault(request.Identity))
+	historyEvent.WorkflowExecutionUnpausedEventAttributes = attributes
+
+	return historyEvent
+}
+
 func (b *historyBuilder) newMarkerRecordedEventAttributes(decisionTaskCompletedEventID int64,
 	request *workflow.RecordMarkerDecisionAttributes) *workflow.HistoryEvent {
 	historyEvent := b.msBuilder.CreateNewHistoryEvent(workflow.EventTypeMarkerRecorded)
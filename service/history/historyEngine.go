@@ -21,6 +21,7 @@
 package history
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
@@ -53,6 +54,7 @@ const (
 	activityCancelationMsgActivityIDUnknown  = "ACTIVITY_ID_UNKNOWN"
 	activityCancelationMsgActivityNotStarted = "ACTIVITY_ID_NOT_STARTED"
 	timerCancelationMsgTimerIDUnknown        = "TIMER_ID_UNKNOWN"
+	defaultQueueTasksPageSize                = 100
 )
 
 type (
@@ -75,6 +77,7 @@ type (
 		config               *Config
 		archivalClient       sysworkflow.ArchivalClient
 		resetor              workflowResetor
+		queryRegistry        queryRegistry
 	}
 
 	// shardContextWrapper wraps ShardContext to notify transferQueueProcessor on new tasks.
@@ -119,6 +122,12 @@ var (
 	ErrBufferedEventsLimitExceeded = &workflow.LimitExceededError{Message: "Exceeded workflow execution limit for buffered events"}
 	// ErrSignalsLimitExceeded is the error indicating limit reached for maximum number of signal events
 	ErrSignalsLimitExceeded = &workflow.LimitExceededError{Message: "Exceeded workflow execution limit for signal events"}
+	// ErrWorkflowAlreadyPaused is the error indicating the target workflow is already paused
+	ErrWorkflowAlreadyPaused = &workflow.BadRequestError{Message: "Workflow execution is already paused."}
+	// ErrWorkflowNotPaused is the error indicating the target workflow is not paused
+	ErrWorkflowNotPaused = &workflow.BadRequestError{Message: "Workflow execution is not paused."}
+	// ErrWorkflowPaused is the error indicating a task of a paused workflow is dropped, it is dispatched again on unpause
+	ErrWorkflowPaused = &workflow.EntityNotExistsError{Message: "Workflow execution is paused."}
 	// FailedWorkflowCloseState is a set of failed workflow close states, used for start workflow policy
 	// for start workflow execution API
 	FailedWorkflowCloseState = map[int]bool{
@@ -168,6 +177,7 @@ func NewEngineWithShardContext(
 		historyEventNotifier: historyEventNotifier,
 		config:               config,
 		archivalClient:       sysworkflow.NewArchivalClient(publicClient, shard.GetConfig().NumSysWorkflows),
+		queryRegistry:        newQueryRegistry(),
 	}
 
 	txProcessor := newTransferQueueProcessor(shard, historyEngImpl, visibilityMgr, visibilityProducer, matching, historyClient, logger)
@@ -304,7 +314,8 @@ func (e *historyEngineImpl) createMutableState(clusterMetadata cluster.Metadata,
 	return msBuilder
 }
 
-func (e *historyEngineImpl) generateFirstDecisionTask(domainID string, msBuilder mutableState, parentInfo *h.ParentExecutionInfo, taskListName string) ([]persistence.Task, *decisionInfo, error) {
+func (e *historyEngineImpl) generateFirstDecisionTask(domainID string, msBuilder mutableState, parentInfo *h.ParentExecutionInfo,
+	taskListName string, backoffSeconds int32) ([]persistence.Task, *decisionInfo, error) {
 	di := &decisionInfo{
 		TaskList:        taskListName,
 		Version:         common.EmptyVersion,
@@ -313,8 +324,9 @@ func (e *historyEngineImpl) generateFirstDecisionTask(domainID string, msBuilder
 		DecisionTimeout: int32(0),
 	}
 	var transferTasks []persistence.Task
-	if parentInfo == nil {
-		// DecisionTask is only created when it is not a Child Workflow Execution
+	if parentInfo == nil && backoffSeconds == 0 {
+		// DecisionTask is only created when it is not a Child Workflow Execution,
+		// a delayed start leaves it to the workflow backoff timer
 		di = msBuilder.AddDecisionTaskScheduledEvent()
 		if di == nil {
 			return nil, nil, &workflow.InternalServiceError{Message: "Failed to add decision scheduled event."}
@@ -327,6 +339,23 @@ func (e *historyEngineImpl) generateFirstDecisionTask(domainID string, msBuilder
 	return transferTasks, di, nil
 }
 
+func (e *historyEngineImpl) generateFirstTimerTasks(executionTimeoutSeconds int32, backoffSeconds int32) []persistence.Task {
+	now := e.shard.GetTimeSource().Now()
+	backoff := time.Duration(backoffSeconds) * time.Second
+	// the execution timeout only starts counting once the first decision is scheduled
+	duration := time.Duration(executionTimeoutSeconds) * time.Second
+	timerTasks := []persistence.Task{&persistence.WorkflowTimeoutTask{
+		VisibilityTimestamp: now.Add(backoff + duration),
+	}}
+	if backoffSeconds > 0 {
+		timerTasks = append(timerTasks, &persistence.WorkflowBackoffTimerTask{
+			VisibilityTimestamp: now.Add(backoff),
+			TimeoutType:         persistence.WorkflowBackoffTimeoutTypeDelayStart,
+		})
+	}
+	return timerTasks
+}
+
 func (e *historyEngineImpl) appendFirstBatchHistoryEvents(msBuilder mutableState, domainID string, execution workflow.WorkflowExecution) (historySize int, err error) {
 	events := msBuilder.GetHistoryBuilder().GetHistory().Events
 	startedEvent := events[0]
@@ -440,6 +469,7 @@ func (e *historyEngineImpl) createWorkflow(startRequest *h.StartWorkflowExecutio
 		BranchToken:                 msBuilder.GetCurrentBranch(),
 		CreateWorkflowMode:          createMode,
 		CronSchedule:                request.GetCronSchedule(),
+		Priority:                    currExeInfo.Priority,
 	}
 
 	if createRequest.HasRetryPolicy {
@@ -500,16 +530,14 @@ func (e *historyEngineImpl) StartWorkflowExecution(ctx context.Context, startReq
 	}
 
 	taskList := request.TaskList.GetName()
-	// Generate first decision task event if not child WF
-	transferTasks, firstDecisionTask, retError := e.generateFirstDecisionTask(domainID, msBuilder, startRequest.ParentExecutionInfo, taskList)
+	backoffSeconds := startRequest.GetFirstDecisionTaskBackoffSeconds()
+	// Generate first decision task event if not child WF and not delayed
+	transferTasks, firstDecisionTask, retError := e.generateFirstDecisionTask(domainID, msBuilder, startRequest.ParentExecutionInfo, taskList, backoffSeconds)
 	if retError != nil {
 		return
 	}
-	// Generate first timer task : WF timeout task
-	duration := time.Duration(*request.ExecutionStartToCloseTimeoutSeconds) * time.Second
-	timerTasks := []persistence.Task{&persistence.WorkflowTimeoutTask{
-		VisibilityTimestamp: e.shard.GetTimeSource().Now().Add(duration),
-	}}
+	// Generate first timer tasks : WF timeout task, and the backoff timer task if delayed
+	timerTasks := e.generateFirstTimerTasks(request.GetExecutionStartToCloseTimeoutSeconds(), backoffSeconds)
 	// generate first replication task
 	replicationTasks := generateFirstReplicationTask(msBuilder, clusterMetadata, domainEntry)
 	// set versions and timestamp for timer and transfer tasks
@@ -681,6 +709,12 @@ func (e *historyEngineImpl) getMutableState(ctx context.Context,
 		StickyTaskListScheduleToStartTimeout: common.Int32Ptr(executionInfo.StickyScheduleToStartTimeout),
 		EventStoreVersion:                    common.Int32Ptr(msBuilder.GetEventStoreVersion()),
 		BranchToken:                          msBuilder.GetCurrentBranch(),
+		HasPendingDecision:                   common.BoolPtr(msBuilder.HasPendingDecisionTask() || msBuilder.HasBufferedEvents()),
+		BuildID:                              common.StringPtr(executionInfo.BuildID),
+	}
+	if !msBuilder.IsWorkflowExecutionRunning() {
+		closeStatus := getWorkflowExecutionCloseStatus(executionInfo.CloseStatus)
+		retResp.WorkflowCloseStatus = &closeStatus
 	}
 
 	replicationState := msBuilder.GetReplicationState()
@@ -738,6 +772,346 @@ func (e *historyEngineImpl) toMutableStateJSON(msb mutableState) (*string, error
 	return common.StringPtr(string(jsonBytes)), nil
 }
 
+// ListQueueTasks returns one page of the pending transfer, timer or replication tasks of the shard,
+// optionally filtered by workflow ID and run ID. Filtering is done per page, so a page could contain
+// less tasks than requested while more tasks are still available.
+func (e *historyEngineImpl) ListQueueTasks(ctx context.Context,
+	request *workflow.ListQueueTasksRequest) (*workflow.ListQueueTasksResponse, error) {
+
+	pageSize := int(request.GetPageSize())
+	if pageSize <= 0 {
+		pageSize = defaultQueueTasksPageSize
+	}
+
+	var tasks []interface{}
+	var nextPageToken []byte
+	switch request.GetTaskType() {
+	case workflow.QueueTaskTypeTransfer:
+		resp, err := e.executionManager.GetTransferTasks(&persistence.GetTransferTasksRequest{
+			ReadLevel:     e.shard.GetTransferAckLevel(),
+			MaxReadLevel:  e.shard.GetTransferMaxReadLevel(),
+			BatchSize:     pageSize,
+			NextPageToken: request.NextPageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range resp.Tasks {
+			if matchQueueTask(request, task.WorkflowID, task.RunID) {
+				tasks = append(tasks, task)
+			}
+		}
+		nextPageToken = resp.NextPageToken
+	case workflow.QueueTaskTypeTimer:
+		resp, err := e.executionManager.GetTimerIndexTasks(&persistence.GetTimerIndexTasksRequest{
+			MinTimestamp:  e.shard.GetTimerAckLevel(),
+			MaxTimestamp:  maximumTime,
+			BatchSize:     pageSize,
+			NextPageToken: request.NextPageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range resp.Timers {
+			if matchQueueTask(request, task.WorkflowID, task.RunID) {
+				tasks = append(tasks, task)
+			}
+		}
+		nextPageToken = resp.NextPageToken
+	case workflow.QueueTaskTypeReplication:
+		resp, err := e.executionManager.GetReplicationTasks(&persistence.GetReplicationTasksRequest{
+			ReadLevel:     e.shard.GetReplicatorAckLevel(),
+			MaxReadLevel:  e.shard.GetTransferMaxReadLevel(),
+			BatchSize:     pageSize,
+			NextPageToken: request.NextPageToken,
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, task := range resp.Tasks {
+			if matchQueueTask(request, task.WorkflowID, task.RunID) {
+				tasks = append(tasks, task)
+			}
+		}
+		nextPageToken = resp.NextPageToken
+	default:
+		return nil, &workflow.BadRequestError{Message: fmt.Sprintf("Unknown task type: %v", request.GetTaskType())}
+	}
+
+	response := &workflow.ListQueueTasksResponse{
+		Tasks:         make([]string, 0, len(tasks)),
+		NextPageToken: nextPageToken,
+	}
+	for _, task := range tasks {
+		jsonBytes, err := json.Marshal(task)
+		if err != nil {
+			return nil, err
+		}
+		response.Tasks = append(response.Tasks, string(jsonBytes))
+	}
+	return response, nil
+}
+
+func matchQueueTask(request *workflow.ListQueueTasksRequest, workflowID, runID string) bool {
+	if request.GetWorkflowID() != "" && request.GetWorkflowID() != workflowID {
+		return false
+	}
+	if request.GetRunID() != "" && request.GetRunID() != runID {
+		return false
+	}
+	return true
+}
+
+// RemoveQueueTask deletes a transfer, timer or replication task from the shard.
+// Tasks already loaded by the queue processors are not affected until the shard is reloaded.
+func (e *historyEngineImpl) RemoveQueueTask(ctx context.Context, request *workflow.RemoveQueueTaskRequest) error {
+	logger := e.logger.WithFields(bark.Fields{
+		logging.TagTaskID:   request.GetTaskID(),
+		logging.TagTaskType: request.GetTaskType().String(),
+	})
+
+	var err error
+	switch request.GetTaskType() {
+	case workflow.QueueTaskTypeTransfer:
+		err = e.executionManager.CompleteTransferTask(&persistence.CompleteTransferTaskRequest{
+			TaskID: request.GetTaskID(),
+		})
+	case workflow.QueueTaskTypeTimer:
+		err = e.executionManager.CompleteTimerTask(&persistence.CompleteTimerTaskRequest{
+			VisibilityTimestamp: time.Unix(0, request.GetVisibilityTimestamp()),
+			TaskID:              request.GetTaskID(),
+		})
+	case workflow.QueueTaskTypeReplication:
+		err = e.executionManager.CompleteReplicationTask(&persistence.CompleteReplicationTaskRequest{
+			TaskID: request.GetTaskID(),
+		})
+	default:
+		return &workflow.BadRequestError{Message: fmt.Sprintf("Unknown task type: %v", request.GetTaskType())}
+	}
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Queue task removed by admin request.")
+	return nil
+}
+
+// RegenerateWorkflowTasks re-creates the transfer and timer tasks of a workflow from its mutable state.
+// For a running workflow this covers the pending decision, activities and child workflows which are
+// not started yet, pending request cancels and signals to external workflows, as well as workflow,
+// decision (including the sticky schedule to start timeout), activity and user timers. For a closed
+// workflow the close execution and history cleanup tasks are re-created.
+func (e *historyEngineImpl) RegenerateWorkflowTasks(ctx context.Context, request *h.RegenerateWorkflowTasksRequest) error {
+	domainID, err := validateDomainUUID(request.DomainUUID)
+	if err != nil {
+		return err
+	}
+
+	execution := workflow.WorkflowExecution{
+		WorkflowId: request.Execution.WorkflowId,
+		RunId:      request.Execution.RunId,
+	}
+
+	return e.updateWorkflowExecutionWithAction(ctx, domainID, execution,
+		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+			if !msBuilder.IsWorkflowExecutionRunning() {
+				return &updateWorkflowAction{deleteWorkflow: true}, nil
+			}
+
+			transferTasks, err := e.regenerateTransferTasks(domainID, msBuilder)
+			if err != nil {
+				return nil, err
+			}
+			timerTasks, err := e.regenerateTimerTasks(msBuilder, tBuilder)
+			if err != nil {
+				return nil, err
+			}
+			return &updateWorkflowAction{
+				transferTasks: transferTasks,
+				timerTasks:    timerTasks,
+			}, nil
+		})
+}
+
+func (e *historyEngineImpl) regenerateTransferTasks(domainID string, msBuilder mutableState) ([]persistence.Task, error) {
+	var transferTasks []persistence.Task
+	executionInfo := msBuilder.GetExecutionInfo()
+
+	if msBuilder.HasPendingDecisionTask() && !msBuilder.HasInFlightDecisionTask() {
+		taskList := executionInfo.TaskList
+		if msBuilder.IsStickyTaskListEnabled() {
+			taskList = executionInfo.StickyTaskList
+		}
+		transferTasks = append(transferTasks, &persistence.DecisionTask{
+			DomainID:   domainID,
+			TaskList:   taskList,
+			ScheduleID: executionInfo.DecisionScheduleID,
+		})
+	}
+
+	for _, ai := range msBuilder.GetPendingActivityInfos() {
+		if ai.StartedID != common.EmptyEventID {
+			continue
+		}
+		targetDomainID := ai.DomainID
+		if targetDomainID == "" {
+			targetDomainID = domainID
+		}
+		transferTasks = append(transferTasks, &persistence.ActivityTask{
+			DomainID:   targetDomainID,
+			TaskList:   ai.TaskList,
+			ScheduleID: ai.ScheduleID,
+		})
+	}
+
+	for _, ci := range msBuilder.GetPendingChildExecutionInfos() {
+		if ci.StartedID != common.EmptyEventID {
+			continue
+		}
+		initiatedEvent, ok := msBuilder.GetChildExecutionInitiatedEvent(ci.InitiatedID)
+		if !ok {
+			return nil, &workflow.InternalServiceError{Message: "Unable to get child execution initiated event."}
+		}
+		targetDomainID, err := e.getTargetDomainID(domainID, ci.DomainName)
+		if err != nil {
+			return nil, err
+		}
+		attributes := initiatedEvent.StartChildWorkflowExecutionInitiatedEventAttributes
+		transferTasks = append(transferTasks, &persistence.StartChildExecutionTask{
+			TargetDomainID:   targetDomainID,
+			TargetWorkflowID: attributes.GetWorkflowId(),
+			InitiatedID:      ci.InitiatedID,
+		})
+	}
+
+	requestCancels := msBuilder.GetAllRequestCancels()
+	signalsToSend := msBuilder.GetAllSignalsToSend()
+	if len(requestCancels) == 0 && len(signalsToSend) == 0 {
+		return transferTasks, nil
+	}
+
+	// request cancel and signal infos do not keep the target execution, so it is read back from the initiated events
+	initiatedEvents, err := e.getInitiatedEvents(msBuilder, len(requestCancels)+len(signalsToSend))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rci := range requestCancels {
+		initiatedEvent, ok := initiatedEvents[rci.InitiatedID]
+		if !ok || initiatedEvent.RequestCancelExternalWorkflowExecutionInitiatedEventAttributes == nil {
+			return nil, &workflow.InternalServiceError{Message: "Unable to get request cancel initiated event."}
+		}
+		attributes := initiatedEvent.RequestCancelExternalWorkflowExecutionInitiatedEventAttributes
+		targetDomainID, err := e.getTargetDomainID(domainID, attributes.GetDomain())
+		if err != nil {
+			return nil, err
+		}
+		transferTasks = append(transferTasks, &persistence.CancelExecutionTask{
+			TargetDomainID:          targetDomainID,
+			TargetWorkflowID:        attributes.WorkflowExecution.GetWorkflowId(),
+			TargetRunID:             attributes.WorkflowExecution.GetRunId(),
+			TargetChildWorkflowOnly: attributes.GetChildWorkflowOnly(),
+			InitiatedID:             rci.InitiatedID,
+		})
+	}
+
+	for _, si := range signalsToSend {
+		initiatedEvent, ok := initiatedEvents[si.InitiatedID]
+		if !ok || initiatedEvent.SignalExternalWorkflowExecutionInitiatedEventAttributes == nil {
+			return nil, &workflow.InternalServiceError{Message: "Unable to get signal initiated event."}
+		}
+		attributes := initiatedEvent.SignalExternalWorkflowExecutionInitiatedEventAttributes
+		targetDomainID, err := e.getTargetDomainID(domainID, attributes.GetDomain())
+		if err != nil {
+			return nil, err
+		}
+		transferTasks = append(transferTasks, &persistence.SignalExecutionTask{
+			TargetDomainID:          targetDomainID,
+			TargetWorkflowID:        attributes.WorkflowExecution.GetWorkflowId(),
+			TargetRunID:             attributes.WorkflowExecution.GetRunId(),
+			TargetChildWorkflowOnly: attributes.GetChildWorkflowOnly(),
+			InitiatedID:             si.InitiatedID,
+		})
+	}
+
+	return transferTasks, nil
+}
+
+func (e *historyEngineImpl) getTargetDomainID(domainID string, targetDomainName string) (string, error) {
+	if targetDomainName == "" {
+		return domainID, nil
+	}
+	domainEntry, err := e.shard.GetDomainCache().GetDomain(targetDomainName)
+	if err != nil {
+		return "", err
+	}
+	return domainEntry.GetInfo().ID, nil
+}
+
+// getInitiatedEvents pages through the history of the workflow and collects the request cancel and signal
+// initiated events, stopping once the given number of events is found
+func (e *historyEngineImpl) getInitiatedEvents(msBuilder mutableState, count int) (map[int64]*workflow.HistoryEvent, error) {
+	executionInfo := msBuilder.GetExecutionInfo()
+	initiatedEvents := make(map[int64]*workflow.HistoryEvent)
+	var token []byte
+	for {
+		events, _, nextToken, _, err := PaginateHistory(e.historyMgr, e.historyV2Mgr, e.metricsClient, e.logger,
+			false, executionInfo.DomainID, executionInfo.WorkflowID, executionInfo.RunID, common.FirstEventID,
+			msBuilder.GetNextEventID(), token, executionInfo.EventStoreVersion, executionInfo.GetCurrentBranch(),
+			defaultHistoryPageSize)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range events {
+			switch event.GetEventType() {
+			case workflow.EventTypeRequestCancelExternalWorkflowExecutionInitiated,
+				workflow.EventTypeSignalExternalWorkflowExecutionInitiated:
+				initiatedEvents[event.GetEventId()] = event
+			}
+		}
+		if len(nextToken) == 0 || len(initiatedEvents) >= count {
+			return initiatedEvents, nil
+		}
+		token = nextToken
+	}
+}
+
+func (e *historyEngineImpl) regenerateTimerTasks(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+	executionInfo := msBuilder.GetExecutionInfo()
+	// the first decision backoff of the workflow is only kept on its started event
+	startedEvent, err := getWorkflowStartedEvent(e.historyMgr, e.historyV2Mgr, executionInfo.EventStoreVersion,
+		executionInfo.GetCurrentBranch(), e.logger, executionInfo.DomainID, executionInfo.WorkflowID, executionInfo.RunID)
+	if err != nil {
+		return nil, err
+	}
+	timerTasks := []persistence.Task{newWorkflowTimeoutTask(startedEvent, executionInfo.WorkflowTimeout)}
+	if executionInfo.LastProcessedEvent == common.EmptyEventID && !msBuilder.HasPendingDecisionTask() {
+		// the first decision is not scheduled yet, the workflow is still in its backoff
+		if tt := newWorkflowBackoffTimerTask(startedEvent); tt != nil {
+			timerTasks = append(timerTasks, tt)
+		}
+	}
+
+	if di, ok := msBuilder.GetInFlightDecisionTask(); ok {
+		timerTasks = append(timerTasks, tBuilder.AddStartToCloseDecisionTimoutTask(di.ScheduleID, di.Attempt, di.DecisionTimeout))
+	} else if msBuilder.HasPendingDecisionTask() && msBuilder.IsStickyTaskListEnabled() {
+		timerTasks = append(timerTasks, tBuilder.AddScheduleToStartDecisionTimoutTask(executionInfo.DecisionScheduleID,
+			executionInfo.DecisionAttempt, executionInfo.StickyScheduleToStartTimeout))
+	}
+
+	tBuilder.loadUserTimers(msBuilder)
+	if tt := tBuilder.firstTimerTaskWithoutChecking(); tt != nil {
+		timerTasks = append(timerTasks, tt)
+	}
+
+	tBuilder.loadActivityTimers(msBuilder)
+	if tt := tBuilder.firstActivityTimerTaskWithoutChecking(); tt != nil {
+		timerTasks = append(timerTasks, tt)
+	}
+
+	return timerTasks, nil
+}
+
 // ResetStickyTaskList reset the volatile information in mutable state of a given workflow.
 // Volatile information are the information related to client, such as:
 // 1. StickyTaskList
@@ -767,6 +1141,52 @@ func (e *historyEngineImpl) ResetStickyTaskList(ctx context.Context, resetReques
 	return &h.ResetStickyTaskListResponse{}, nil
 }
 
+// QueryWorkflow delivers a strongly consistent query with the next decision task of the workflow and waits for the
+// worker to answer it along with the completion of the decision task. It returns an empty response when the workflow
+// has no pending decision task, as its workers have then processed all of its events and can be queried directly.
+func (e *historyEngineImpl) QueryWorkflow(ctx context.Context,
+	request *h.QueryWorkflowRequest) (*h.QueryWorkflowResponse, error) {
+	domainEntry, err := e.getActiveDomainEntry(request.DomainUUID)
+	if err != nil {
+		return nil, err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	context, release, err0 := e.historyCache.getOrCreateWorkflowExecutionWithTimeout(ctx, domainID,
+		*request.Request.Execution)
+	if err0 != nil {
+		return nil, err0
+	}
+	msBuilder, err1 := context.loadWorkflowExecution()
+	if err1 != nil {
+		release(err1)
+		return nil, err1
+	}
+	if !msBuilder.IsWorkflowExecutionRunning() || !msBuilder.HasPendingDecisionTask() {
+		release(nil)
+		return &h.QueryWorkflowResponse{}, nil
+	}
+
+	executionInfo := msBuilder.GetExecutionInfo()
+	id := definition.NewWorkflowIdentifier(domainID, executionInfo.WorkflowID, executionInfo.RunID)
+	queryID, resultChan := e.queryRegistry.bufferQuery(id, request.Request.Query)
+	// the decision task the query is delivered with can only start once the workflow is released
+	release(nil)
+
+	select {
+	case result := <-resultChan:
+		if result.err != nil {
+			return nil, result.err
+		}
+		return &h.QueryWorkflowResponse{
+			Response: &workflow.QueryWorkflowResponse{QueryResult: result.answer},
+		}, nil
+	case <-ctx.Done():
+		e.queryRegistry.removeQuery(id, queryID)
+		return nil, errQueryDecisionTimeout
+	}
+}
+
 // DescribeWorkflowExecution returns information about the specified workflow execution.
 func (e *historyEngineImpl) DescribeWorkflowExecution(ctx context.Context,
 	request *h.DescribeWorkflowExecutionRequest) (retResp *workflow.DescribeWorkflowExecutionResponse, retError error) {
@@ -804,6 +1224,7 @@ func (e *historyEngineImpl) DescribeWorkflowExecution(ctx context.Context,
 			Type:          &workflow.WorkflowType{Name: common.StringPtr(executionInfo.WorkflowTypeName)},
 			StartTime:     common.Int64Ptr(executionInfo.StartTimestamp.UnixNano()),
 			HistoryLength: common.Int64Ptr(msBuilder.GetNextEventID() - common.FirstEventID),
+			Paused:        common.BoolPtr(executionInfo.Paused),
 		},
 	}
 	if executionInfo.State == persistence.WorkflowStateCompleted {
@@ -911,6 +1332,12 @@ Update_History_Loop:
 			return nil, &h.EventAlreadyStartedError{Message: "Decision task already started."}
 		}
 
+		if msBuilder.IsPaused() {
+			// Decision task was dispatched before the pause, drop it.
+			// UnpauseWorkflowExecution schedules it again.
+			return nil, ErrWorkflowPaused
+		}
+
 		_, di = msBuilder.AddDecisionTaskStartedEvent(scheduleID, requestID, request.PollRequest)
 		if di == nil {
 			// Unable to add DecisionTaskStarted event to history
@@ -1015,6 +1442,12 @@ func (e *historyEngineImpl) RecordActivityTaskStarted(ctx context.Context,
 				return nil, &h.EventAlreadyStartedError{Message: "Activity task already started."}
 			}
 
+			if msBuilder.IsPaused() {
+				// Activity task was dispatched before the pause, drop it.
+				// UnpauseWorkflowExecution schedules it again.
+				return nil, ErrWorkflowPaused
+			}
+
 			msBuilder.AddActivityTaskStartedEvent(ai, scheduleID, requestID, request.PollRequest.GetIdentity())
 
 			response.StartedTimestamp = common.Int64Ptr(ai.StartedTime.UnixNano())
@@ -1153,16 +1586,29 @@ Update_History_Loop:
 		}
 
 		startedID := di.StartedID
-		completedEvent := msBuilder.AddDecisionTaskCompletedEvent(scheduleID, startedID, request)
-		if completedEvent == nil {
-			return nil, &workflow.InternalServiceError{Message: "Unable to add DecisionTaskCompleted event to history."}
+		var completedEvent *workflow.HistoryEvent
+		if e.isDecisionHeartbeat(domainEntry, msBuilder, request) {
+			e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.DecisionHeartbeatCounter)
+			var ok bool
+			if completedEvent, ok = msBuilder.AddDecisionTaskHeartbeatEvent(scheduleID, startedID, request); !ok {
+				return nil, &workflow.InternalServiceError{Message: "Unable to add DecisionTaskCompleted event to history."}
+			}
+		} else {
+			completedEvent = msBuilder.AddDecisionTaskCompletedEvent(scheduleID, startedID, request)
+			if completedEvent == nil {
+				return nil, &workflow.InternalServiceError{Message: "Unable to add DecisionTaskCompleted event to history."}
+			}
 		}
 
 		failDecision := false
 		var failCause workflow.DecisionTaskFailedCause
 		var failMessage string
 		var err error
-		completedID := *completedEvent.EventId
+		// completing a transient decision heartbeat does not write any event
+		completedID := common.EmptyEventID
+		if completedEvent != nil {
+			completedID = completedEvent.GetEventId()
+		}
 		hasUnhandledEvents := msBuilder.HasBufferedEvents()
 		isComplete := false
 		transferTasks := []persistence.Task{}
@@ -1170,6 +1616,8 @@ Update_History_Loop:
 		var continueAsNewBuilder mutableState
 		var continueAsNewTimerTasks []persistence.Task
 		hasDecisionScheduleActivityTask := false
+		// scheduled events of activities started eagerly and returned to the worker along with the response
+		var eagerActivities []*workflow.HistoryEvent
 
 		if request.StickyAttributes == nil || request.StickyAttributes.WorkerTaskList == nil {
 			e.metricsClient.IncCounter(metrics.HistoryRespondDecisionTaskCompletedScope, metrics.CompleteDecisionWithStickyDisabledCounter)
@@ -1221,11 +1669,15 @@ Update_History_Loop:
 				}
 
 				scheduleEvent, _ := msBuilder.AddActivityTaskScheduledEvent(completedID, attributes)
-				transferTasks = append(transferTasks, &persistence.ActivityTask{
-					DomainID:   targetDomainID,
-					TaskList:   *attributes.TaskList.Name,
-					ScheduleID: *scheduleEvent.EventId,
-				})
+				if e.isActivityEagerExecutionAllowed(domainEntry, targetDomainID, msBuilder, attributes, len(eagerActivities)) {
+					eagerActivities = append(eagerActivities, scheduleEvent)
+				} else {
+					transferTasks = append(transferTasks, &persistence.ActivityTask{
+						DomainID:   targetDomainID,
+						TaskList:   *attributes.TaskList.Name,
+						ScheduleID: *scheduleEvent.EventId,
+					})
+				}
 				hasDecisionScheduleActivityTask = true
 
 			case workflow.DecisionTypeCompleteWorkflowExecution:
@@ -1437,6 +1889,17 @@ Update_History_Loop:
 					// We haven't started the activity yet, we can cancel the activity right away.
 					msBuilder.AddActivityTaskCanceledEvent(ai.ScheduleID, ai.StartedID, *actCancelReqEvent.EventId,
 						[]byte(activityCancelationMsgActivityNotStarted), common.StringDefault(request.Identity))
+				} else {
+					// The activity is running on a worker, notify the worker instead of waiting for its next heartbeat.
+					targetDomainID := ai.DomainID
+					if targetDomainID == "" {
+						targetDomainID = domainID
+					}
+					transferTasks = append(transferTasks, &persistence.ActivityCancellationTask{
+						DomainID:   targetDomainID,
+						TaskList:   ai.TaskList,
+						ScheduleID: ai.ScheduleID,
+					})
 				}
 
 			case workflow.DecisionTypeCancelTimer:
@@ -1670,6 +2133,23 @@ Update_History_Loop:
 			isComplete = false
 			hasUnhandledEvents = true
 			continueAsNewBuilder = nil
+			eagerActivities = nil
+		}
+
+		if isComplete {
+			// the workflow is closed by this decision, so leave the activities to the transfer queue
+			for _, scheduleEvent := range eagerActivities {
+				transferTasks = append(transferTasks, &persistence.ActivityTask{
+					DomainID:   domainID,
+					TaskList:   scheduleEvent.ActivityTaskScheduledEventAttributes.TaskList.GetName(),
+					ScheduleID: scheduleEvent.GetEventId(),
+				})
+			}
+			eagerActivities = nil
+		}
+		for _, scheduleEvent := range eagerActivities {
+			ai, _ := msBuilder.GetActivityInfo(scheduleEvent.GetEventId())
+			msBuilder.AddActivityTaskStartedEvent(ai, ai.ScheduleID, uuid.New(), request.GetIdentity())
 		}
 
 		if tt := tBuilder.GetUserTimerTaskIfNeeded(msBuilder); tt != nil {
@@ -1681,9 +2161,12 @@ Update_History_Loop:
 			}
 		}
 
-		// Schedule another decision task if new events came in during this decision or if request forced to
+		// Schedule another decision task if new events came in during this decision or if request forced to, or to
+		// deliver the queries which came in during this decision
+		workflowIdentifier := definition.NewWorkflowIdentifier(domainID, executionInfo.WorkflowID, executionInfo.RunID)
 		createNewDecisionTask := !isComplete && (hasUnhandledEvents ||
-			request.GetForceCreateNewDecisionTask())
+			request.GetForceCreateNewDecisionTask() ||
+			e.queryRegistry.hasUndeliveredQueries(workflowIdentifier))
 
 		var newDecisionTaskScheduledID int64
 		if createNewDecisionTask {
@@ -1762,6 +2245,11 @@ Update_History_Loop:
 		// Inform timer about the new ones.
 		e.timerProcessor.NotifyNewTimers(e.currentClusterName, e.shard.GetCurrentTime(e.currentClusterName), timerTasks)
 
+		e.queryRegistry.completeQueries(workflowIdentifier, request.QueryResults)
+		if isComplete {
+			e.queryRegistry.failQueries(workflowIdentifier, errQueryWorkflowClosed)
+		}
+
 		response = &h.RespondDecisionTaskCompletedResponse{}
 		if request.GetReturnNewDecisionTask() && createNewDecisionTask {
 			di, _ := msBuilder.GetPendingDecision(newDecisionTaskScheduledID)
@@ -1769,6 +2257,14 @@ Update_History_Loop:
 			// sticky is always enabled when worker request for new decision task from RespondDecisionTaskCompleted
 			response.StartedResponse.StickyExecutionEnabled = common.BoolPtr(true)
 		}
+		if len(eagerActivities) > 0 {
+			e.metricsClient.AddCounter(metrics.HistoryRespondDecisionTaskCompletedScope,
+				metrics.EagerActivityTaskCounter, int64(len(eagerActivities)))
+		}
+		for _, scheduleEvent := range eagerActivities {
+			response.ActivityTasks = append(response.ActivityTasks,
+				e.createEagerActivityTaskResponse(domainEntry, msBuilder, scheduleEvent))
+		}
 
 		return response, nil
 	}
@@ -2083,8 +2579,8 @@ func (e *historyEngineImpl) RequestCancelWorkflowExecution(ctx context.Context,
 		RunId:      request.WorkflowExecution.RunId,
 	}
 
-	return e.updateWorkflowExecution(ctx, domainID, execution, false, true,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+	return e.updateWorkflowExecutionWithAction(ctx, domainID, execution,
+		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
@@ -2105,7 +2601,7 @@ func (e *historyEngineImpl) RequestCancelWorkflowExecution(ctx context.Context,
 				if cancelRequest.RequestId != nil {
 					requestID := *cancelRequest.RequestId
 					if requestID != "" && cancelRequestID == requestID {
-						return nil, nil
+						return &updateWorkflowAction{createDecision: true}, nil
 					}
 				}
 				// if we consider workflow cancellation idempotent, then this error is redundant
@@ -2117,7 +2613,17 @@ func (e *historyEngineImpl) RequestCancelWorkflowExecution(ctx context.Context,
 				return nil, &workflow.InternalServiceError{Message: "Unable to cancel workflow execution."}
 			}
 
-			return nil, nil
+			if !msBuilder.HasProcessedOrPendingDecisionTask() {
+				// workflow is yet to be started, there is no decision to handle the cancellation,
+				// so the workflow is canceled right away
+				if msBuilder.AddWorkflowExecutionCanceledEvent(common.EmptyEventID,
+					&workflow.CancelWorkflowExecutionDecisionAttributes{}) == nil {
+					return nil, &workflow.InternalServiceError{Message: "Unable to cancel workflow execution."}
+				}
+				return &updateWorkflowAction{deleteWorkflow: true}, nil
+			}
+
+			return &updateWorkflowAction{createDecision: true}, nil
 		})
 }
 
@@ -2137,8 +2643,8 @@ func (e *historyEngineImpl) SignalWorkflowExecution(ctx context.Context, signalR
 		RunId:      request.WorkflowExecution.RunId,
 	}
 
-	return e.updateWorkflowExecution(ctx, domainID, execution, false, true,
-		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+	return e.updateWorkflowExecutionWithAction(ctx, domainID, execution,
+		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
 			if !msBuilder.IsWorkflowExecutionRunning() {
 				return nil, ErrWorkflowCompleted
 			}
@@ -2164,10 +2670,16 @@ func (e *historyEngineImpl) SignalWorkflowExecution(ctx context.Context, signalR
 				}
 			}
 
+			// the first decision of a workflow which is yet to be started is scheduled by its start,
+			// signals are delivered with it
+			postActions := &updateWorkflowAction{
+				createDecision: msBuilder.HasProcessedOrPendingDecisionTask(),
+			}
+
 			// deduplicate by request id for signal decision
 			if requestID := request.GetRequestId(); requestID != "" {
 				if msBuilder.IsSignalRequested(requestID) {
-					return nil, nil
+					return postActions, nil
 				}
 				msBuilder.AddSignalRequested(requestID)
 			}
@@ -2176,7 +2688,7 @@ func (e *historyEngineImpl) SignalWorkflowExecution(ctx context.Context, signalR
 				return nil, &workflow.InternalServiceError{Message: "Unable to signal workflow execution."}
 			}
 
-			return nil, nil
+			return postActions, nil
 		})
 }
 
@@ -2235,8 +2747,8 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context
 
 			var transferTasks []persistence.Task
 			var timerTasks []persistence.Task
-			// Create a transfer task to schedule a decision task
-			if !msBuilder.HasPendingDecisionTask() {
+			// Create a transfer task to schedule a decision task, unless the workflow is yet to be started
+			if msBuilder.HasProcessedOrPendingDecisionTask() && !msBuilder.HasPendingDecisionTask() {
 				di := msBuilder.AddDecisionTaskScheduledEvent()
 				if di == nil {
 					return nil, &workflow.InternalServiceError{Message: "Failed to add decision scheduled event."}
@@ -2336,19 +2848,14 @@ func (e *historyEngineImpl) SignalWithStartWorkflowExecution(ctx context.Context
 	if msBuilder.AddWorkflowExecutionSignaled(sRequest.GetSignalName(), sRequest.GetSignalInput(), sRequest.GetIdentity()) == nil {
 		return nil, &workflow.InternalServiceError{Message: "Failed to add workflow execution signaled event."}
 	}
-	// first decision task
-	firstDecisionTask := msBuilder.AddDecisionTaskScheduledEvent()
-	if firstDecisionTask == nil {
-		return nil, &workflow.InternalServiceError{Message: "Failed to add decision scheduled event."}
+	// first decision task, unless delayed
+	backoffSeconds := startRequest.GetFirstDecisionTaskBackoffSeconds()
+	transferTasks, firstDecisionTask, retError := e.generateFirstDecisionTask(domainID, msBuilder, nil, taskList, backoffSeconds)
+	if retError != nil {
+		return
 	}
-	transferTasks := []persistence.Task{&persistence.DecisionTask{
-		DomainID: domainID, TaskList: taskList, ScheduleID: firstDecisionTask.ScheduleID,
-	}}
-	// first timer task
-	duration := time.Duration(*request.ExecutionStartToCloseTimeoutSeconds) * time.Second
-	timerTasks := []persistence.Task{&persistence.WorkflowTimeoutTask{
-		VisibilityTimestamp: e.shard.GetTimeSource().Now().Add(duration),
-	}}
+	// first timer tasks
+	timerTasks := e.generateFirstTimerTasks(request.GetExecutionStartToCloseTimeoutSeconds(), backoffSeconds)
 	// first replication task
 	replicationTasks := generateFirstReplicationTask(msBuilder, clusterMetadata, domainEntry)
 	// set versions and timestamp for timer and transfer tasks
@@ -2453,6 +2960,125 @@ func (e *historyEngineImpl) TerminateWorkflowExecution(ctx context.Context, term
 		})
 }
 
+// PauseWorkflowExecution pauses a running workflow, decision and activity tasks are held back until it is unpaused
+func (e *historyEngineImpl) PauseWorkflowExecution(ctx context.Context, pauseRequest *h.PauseWorkflowExecutionRequest) error {
+
+	domainEntry, err := e.getActiveDomainEntry(pauseRequest.DomainUUID)
+	if err != nil {
+		return err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	request := pauseRequest.PauseRequest
+	execution := workflow.WorkflowExecution{
+		WorkflowId: request.WorkflowExecution.WorkflowId,
+		RunId:      request.WorkflowExecution.RunId,
+	}
+
+	return e.updateWorkflowExecution(ctx, domainID, execution, false, false,
+		func(msBuilder mutableState, tBuilder *timerBuilder) ([]persistence.Task, error) {
+			if !msBuilder.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+
+			if msBuilder.IsPaused() {
+				return nil, ErrWorkflowAlreadyPaused
+			}
+
+			if msBuilder.AddWorkflowExecutionPausedEvent(request) == nil {
+				return nil, &workflow.InternalServiceError{Message: "Unable to pause workflow execution."}
+			}
+
+			return nil, nil
+		})
+}
+
+// UnpauseWorkflowExecution resumes a paused workflow, dispatching the tasks and firing the timers held back while paused
+func (e *historyEngineImpl) UnpauseWorkflowExecution(ctx context.Context, unpauseRequest *h.UnpauseWorkflowExecutionRequest) error {
+
+	domainEntry, err := e.getActiveDomainEntry(unpauseRequest.DomainUUID)
+	if err != nil {
+		return err
+	}
+	domainID := domainEntry.GetInfo().ID
+
+	request := unpauseRequest.UnpauseRequest
+	execution := workflow.WorkflowExecution{
+		WorkflowId: request.WorkflowExecution.WorkflowId,
+		RunId:      request.WorkflowExecution.RunId,
+	}
+
+	return e.updateWorkflowExecutionWithAction(ctx, domainID, execution,
+		func(msBuilder mutableState, tBuilder *timerBuilder) (*updateWorkflowAction, error) {
+			if !msBuilder.IsWorkflowExecutionRunning() {
+				return nil, ErrWorkflowCompleted
+			}
+
+			if !msBuilder.IsPaused() {
+				return nil, ErrWorkflowNotPaused
+			}
+
+			if msBuilder.AddWorkflowExecutionUnpausedEvent(request) == nil {
+				return nil, &workflow.InternalServiceError{Message: "Unable to unpause workflow execution."}
+			}
+
+			postActions := &updateWorkflowAction{
+				transferTasks: e.getUnpausedTransferTasks(domainID, msBuilder),
+			}
+			// user timers which expired while paused were skipped by the timer queue, fire them now
+			tBuilder.loadUserTimers(msBuilder)
+			if tt := tBuilder.firstTimerTaskWithoutChecking(); tt != nil {
+				postActions.timerTasks = append(postActions.timerTasks, tt)
+			}
+			// activities held back by the pause had no timeouts, the unpause restarted their schedule clock
+			if tt := tBuilder.GetActivityTimerTaskIfNeeded(msBuilder); tt != nil {
+				postActions.timerTasks = append(postActions.timerTasks, tt)
+			}
+			// the sticky decision timeout was skipped while paused, the decision is dispatched again now
+			if msBuilder.HasPendingDecisionTask() && !msBuilder.HasInFlightDecisionTask() &&
+				msBuilder.IsStickyTaskListEnabled() {
+				executionInfo := msBuilder.GetExecutionInfo()
+				di, _ := msBuilder.GetPendingDecision(executionInfo.DecisionScheduleID)
+				postActions.timerTasks = append(postActions.timerTasks, tBuilder.AddScheduleToStartDecisionTimoutTask(
+					di.ScheduleID, di.Attempt, executionInfo.StickyScheduleToStartTimeout))
+			}
+			return postActions, nil
+		})
+}
+
+// getUnpausedTransferTasks generates transfer tasks for the decision and activities held back while paused.
+// Activities waiting on a retry backoff are left to their retry timer.
+func (e *historyEngineImpl) getUnpausedTransferTasks(domainID string, msBuilder mutableState) []persistence.Task {
+	var transferTasks []persistence.Task
+
+	if msBuilder.HasPendingDecisionTask() && !msBuilder.HasInFlightDecisionTask() {
+		executionInfo := msBuilder.GetExecutionInfo()
+		transferTasks = append(transferTasks, &persistence.DecisionTask{
+			DomainID:   domainID,
+			TaskList:   executionInfo.TaskList,
+			ScheduleID: executionInfo.DecisionScheduleID,
+		})
+	}
+
+	now := e.shard.GetTimeSource().Now()
+	for _, ai := range msBuilder.GetPendingActivityInfos() {
+		if ai.StartedID != common.EmptyEventID || ai.ScheduledTime.After(now) {
+			continue
+		}
+		targetDomainID := ai.DomainID
+		if targetDomainID == "" {
+			targetDomainID = domainID
+		}
+		transferTasks = append(transferTasks, &persistence.ActivityTask{
+			DomainID:   targetDomainID,
+			TaskList:   ai.TaskList,
+			ScheduleID: ai.ScheduleID,
+		})
+	}
+
+	return transferTasks
+}
+
 // ScheduleDecisionTask schedules a decision if no outstanding decision found
 func (e *historyEngineImpl) ScheduleDecisionTask(ctx context.Context, scheduleRequest *h.ScheduleDecisionTaskRequest) error {
 
@@ -2703,6 +3329,74 @@ func getWorkflowHistoryCleanupTasksFromShard(
 	return &persistence.CloseExecutionTask{}, deleteTask, nil
 }
 
+// isDecisionHeartbeat returns whether the completion of a decision only asks for a new decision to keep working on the
+// workflow, without any decision, new event or new execution context to process. Such decisions are completed as
+// heartbeats which do not write events until the worker makes progress. Decisions of domains replicated to other
+// clusters are always written to history, as standby clusters could not recreate the transient decision on failover.
+func (e *historyEngineImpl) isDecisionHeartbeat(domainEntry *cache.DomainCacheEntry, msBuilder mutableState,
+	request *workflow.RespondDecisionTaskCompletedRequest) bool {
+	return request.GetForceCreateNewDecisionTask() &&
+		request.GetReturnNewDecisionTask() &&
+		len(request.Decisions) == 0 &&
+		(len(request.ExecutionContext) == 0 ||
+			bytes.Equal(request.ExecutionContext, msBuilder.GetExecutionInfo().ExecutionContext)) &&
+		!msBuilder.HasBufferedEvents() &&
+		!domainEntry.CanReplicateEvent()
+}
+
+// isActivityEagerExecutionAllowed returns whether the activity scheduled by a decision can be started right away
+// and returned to the worker completing the decision, instead of being dispatched through matching.
+// Eager execution is only allowed when the worker requested it, it is enabled for the domain, the per decision
+// limit is not reached, the activity runs in the workflow's own domain and on the workflow's task list, which
+// the worker polls (directly, or through its sticky task list), and the workflow is not paused.
+// Otherwise the activity falls back to a regular transfer task.
+func (e *historyEngineImpl) isActivityEagerExecutionAllowed(domainEntry *cache.DomainCacheEntry, targetDomainID string,
+	msBuilder mutableState, attributes *workflow.ScheduleActivityTaskDecisionAttributes, eagerCount int) bool {
+	domainName := domainEntry.GetInfo().Name
+	return attributes.GetRequestEagerExecution() &&
+		e.config.EnableActivityEagerExecution(domainName) &&
+		eagerCount < e.config.MaxActivityEagerExecutionPerDecision(domainName) &&
+		targetDomainID == domainEntry.GetInfo().ID &&
+		attributes.TaskList.GetName() == msBuilder.GetExecutionInfo().TaskList &&
+		!msBuilder.IsPaused()
+}
+
+func (e *historyEngineImpl) createEagerActivityTaskResponse(domainEntry *cache.DomainCacheEntry, msBuilder mutableState,
+	scheduledEvent *workflow.HistoryEvent) *workflow.PollForActivityTaskResponse {
+	attributes := scheduledEvent.ActivityTaskScheduledEventAttributes
+	executionInfo := msBuilder.GetExecutionInfo()
+	ai, _ := msBuilder.GetActivityInfo(scheduledEvent.GetEventId())
+
+	response := &workflow.PollForActivityTaskResponse{}
+	response.ActivityId = attributes.ActivityId
+	response.ActivityType = attributes.ActivityType
+	response.Input = attributes.Input
+	response.WorkflowExecution = &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(executionInfo.WorkflowID),
+		RunId:      common.StringPtr(executionInfo.RunID),
+	}
+	response.ScheduledTimestampOfThisAttempt = common.Int64Ptr(ai.ScheduledTime.UnixNano())
+	response.ScheduledTimestamp = common.Int64Ptr(scheduledEvent.GetTimestamp())
+	response.ScheduleToCloseTimeoutSeconds = common.Int32Ptr(attributes.GetScheduleToCloseTimeoutSeconds())
+	response.StartedTimestamp = common.Int64Ptr(ai.StartedTime.UnixNano())
+	response.StartToCloseTimeoutSeconds = common.Int32Ptr(attributes.GetStartToCloseTimeoutSeconds())
+	response.HeartbeatTimeoutSeconds = common.Int32Ptr(attributes.GetHeartbeatTimeoutSeconds())
+
+	token := &common.TaskToken{
+		DomainID:        executionInfo.DomainID,
+		WorkflowID:      executionInfo.WorkflowID,
+		RunID:           executionInfo.RunID,
+		ScheduleID:      ai.ScheduleID,
+		ScheduleAttempt: int64(ai.Attempt),
+	}
+
+	response.TaskToken, _ = e.tokenSerializer.Serialize(token)
+	response.Attempt = common.Int32Ptr(ai.Attempt)
+	response.WorkflowType = msBuilder.GetWorkflowType()
+	response.WorkflowDomain = common.StringPtr(domainEntry.GetInfo().Name)
+	return response
+}
+
 func (e *historyEngineImpl) createRecordDecisionTaskStartedResponse(domainID string, msBuilder mutableState,
 	di *decisionInfo, identity string) *h.RecordDecisionTaskStartedResponse {
 	response := &h.RecordDecisionTaskStartedResponse{}
@@ -2718,7 +3412,8 @@ func (e *historyEngineImpl) createRecordDecisionTaskStartedResponse(domainID str
 	response.StartedEventId = common.Int64Ptr(di.StartedID)
 	response.StickyExecutionEnabled = common.BoolPtr(msBuilder.IsStickyTaskListEnabled())
 	response.NextEventId = common.Int64Ptr(msBuilder.GetNextEventID())
-	response.Attempt = common.Int64Ptr(di.Attempt)
+	response.Attempt = common.Int64Ptr(msBuilder.GetDecisionFailureAttempt())
+	response.ScheduleAttempt = common.Int64Ptr(di.Attempt)
 	response.WorkflowExecutionTaskList = common.TaskListPtr(workflow.TaskList{
 		Name: &executionInfo.TaskList,
 		Kind: common.TaskListKindPtr(workflow.TaskListKindNormal),
@@ -2734,6 +3429,8 @@ func (e *historyEngineImpl) createRecordDecisionTaskStartedResponse(domainID str
 	}
 	response.EventStoreVersion = common.Int32Ptr(msBuilder.GetEventStoreVersion())
 	response.BranchToken = msBuilder.GetCurrentBranch()
+	response.Queries = e.queryRegistry.deliverQueries(
+		definition.NewWorkflowIdentifier(domainID, executionInfo.WorkflowID, executionInfo.RunID))
 
 	return response
 }
@@ -2830,6 +3527,10 @@ func validateActivityScheduleAttributes(attributes *workflow.ScheduleActivityTas
 		return err
 	}
 
+	if err := common.ValidatePriority(attributes.GetPriority()); err != nil {
+		return err
+	}
+
 	if len(attributes.GetActivityId()) > maxIDLengthLimit {
 		return &workflow.BadRequestError{Message: "ActivityID exceeds length limit."}
 	}
@@ -3151,6 +3852,9 @@ func validateStartWorkflowExecutionRequest(request *workflow.StartWorkflowExecut
 	if len(request.WorkflowType.GetName()) > maxIDLengthLimit {
 		return &workflow.BadRequestError{Message: "WorkflowType exceeds length limit."}
 	}
+	if err := common.ValidatePriority(request.GetPriority()); err != nil {
+		return err
+	}
 
 	return common.ValidateRetryPolicy(request.RetryPolicy)
 }
@@ -3210,6 +3914,7 @@ func getStartRequest(domainID string,
 		WorkflowIdReusePolicy:               request.WorkflowIdReusePolicy,
 		RetryPolicy:                         request.RetryPolicy,
 		CronSchedule:                        request.CronSchedule,
+		DelayStartSeconds:                   request.DelayStartSeconds,
 	}
 
 	startRequest := common.CreateHistoryStartWorkflowRequest(domainID, req)
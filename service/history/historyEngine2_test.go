@@ -27,6 +27,7 @@ import (
 	"github.com/uber/cadence/service/worker/sysworkflow"
 	"os"
 	"testing"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/mock"
@@ -120,7 +121,7 @@ func (s *engine2Suite) SetupTest() {
 	s.mockClusterMetadata.On("GetAllClusterFailoverVersions").Return(cluster.TestSingleDCAllClusterFailoverVersions)
 	s.mockClusterMetadata.On("IsGlobalDomainEnabled").Return(false)
 	s.mockDomainCache = &cache.DomainCacheMock{}
-	s.mockDomainCache.On("GetDomainByID", mock.Anything).Return(cache.NewDomainCacheEntryForTest(&p.DomainInfo{ID: validDomainID}, nil), nil)
+	s.mockDomainCache.On("GetDomainByID", mock.Anything).Return(cache.NewDomainCacheEntryForTest(&p.DomainInfo{ID: validDomainID}, &p.DomainConfig{Retention: 1}), nil)
 	s.mockEventsCache = &MockEventsCache{}
 	s.mockEventsCache.On("putEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything,
 		mock.Anything).Return()
@@ -153,6 +154,7 @@ func (s *engine2Suite) SetupTest() {
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
 		config:             s.config,
 		archivalClient:     s.mockArchivalClient,
+		queryRegistry:      newQueryRegistry(),
 	}
 	h.txProcessor = newTransferQueueProcessor(mockShard, h, s.mockVisibilityMgr, s.mockProducer, s.mockMatchingClient, s.mockHistoryClient, s.logger)
 	h.timerProcessor = newTimerQueueProcessor(mockShard, h, s.mockMatchingClient, s.mockProducer, s.logger)
@@ -235,6 +237,7 @@ func (s *engine2Suite) TestRecordDecisionTaskStartedSuccessStickyEnabled() {
 	expectedResponse.StickyExecutionEnabled = common.BoolPtr(true)
 	expectedResponse.NextEventId = common.Int64Ptr(msBuilder.GetNextEventID() + 1)
 	expectedResponse.Attempt = common.Int64Ptr(di.Attempt)
+	expectedResponse.ScheduleAttempt = common.Int64Ptr(di.Attempt)
 	expectedResponse.WorkflowExecutionTaskList = common.TaskListPtr(workflow.TaskList{
 		Name: &executionInfo.TaskList,
 		Kind: common.TaskListKindPtr(workflow.TaskListKindNormal),
@@ -936,6 +939,584 @@ func (s *engine2Suite) printHistory(builder mutableState) string {
 	return builder.GetHistoryBuilder().GetHistory().String()
 }
 
+func (s *engine2Suite) TestRequestCancelWorkflowExecutionNotStarted() {
+	domainID := validDomainID
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	// workflow with a delayed start, the first decision is not scheduled yet
+	msBuilder := newMutableStateBuilder(s.mockClusterMetadata.GetCurrentClusterName(), s.config, s.mockEventsCache,
+		s.logger)
+	addWorkflowExecutionStartedEvent(msBuilder, workflowExecution, "wType", tl, []byte("input"), 100, 200, identity)
+	ms1 := createMutableState(msBuilder)
+	gwmsResponse1 := &p.GetWorkflowExecutionResponse{State: ms1}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil, nil).Once()
+	s.mockClusterMetadata.On("IsArchivalEnabled").Return(false)
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&p.GetDomainResponse{
+			Info:   &p.DomainInfo{ID: domainID},
+			Config: &p.DomainConfig{Retention: 1},
+			ReplicationConfig: &p.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*p.ClusterReplicationConfig{
+					&p.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: p.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	err := s.historyEngine.RequestCancelWorkflowExecution(context.Background(), &h.RequestCancelWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CancelRequest: &workflow.RequestCancelWorkflowExecutionRequest{
+			WorkflowExecution: &workflow.WorkflowExecution{
+				WorkflowId: workflowExecution.WorkflowId,
+				RunId:      workflowExecution.RunId,
+			},
+			Identity: common.StringPtr("identity"),
+		},
+	})
+	s.Nil(err)
+
+	executionBuilder := s.getBuilder(domainID, workflowExecution)
+	s.Equal(int64(4), executionBuilder.GetNextEventID())
+	s.False(executionBuilder.IsWorkflowExecutionRunning())
+	s.Equal(p.WorkflowCloseStatusCanceled, executionBuilder.GetExecutionInfo().CloseStatus)
+	s.False(executionBuilder.HasPendingDecisionTask())
+}
+
+func (s *engine2Suite) TestPauseWorkflowExecution() {
+	domainID := validDomainID
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, false)
+	ms1 := createMutableState(msBuilder)
+	gwmsResponse1 := &p.GetWorkflowExecutionResponse{State: ms1}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&p.GetDomainResponse{
+			Info:   &p.DomainInfo{ID: domainID},
+			Config: &p.DomainConfig{Retention: 1},
+			ReplicationConfig: &p.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*p.ClusterReplicationConfig{
+					&p.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: p.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	err := s.historyEngine.PauseWorkflowExecution(context.Background(), &h.PauseWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PauseRequest: &workflow.PauseWorkflowExecutionRequest{
+			WorkflowExecution: &workflow.WorkflowExecution{
+				WorkflowId: workflowExecution.WorkflowId,
+				RunId:      workflowExecution.RunId,
+			},
+			Reason:   common.StringPtr("maintenance"),
+			Identity: common.StringPtr("identity"),
+		},
+	})
+	s.Nil(err)
+
+	executionBuilder := s.getBuilder(domainID, workflowExecution)
+	s.Equal(int64(4), executionBuilder.GetNextEventID())
+	s.True(executionBuilder.IsPaused())
+	s.True(executionBuilder.IsWorkflowExecutionRunning())
+}
+
+func (s *engine2Suite) TestPauseWorkflowExecution_AlreadyPaused() {
+	domainID := validDomainID
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, false)
+	msBuilder.GetExecutionInfo().Paused = true
+	ms1 := createMutableState(msBuilder)
+	gwmsResponse1 := &p.GetWorkflowExecutionResponse{State: ms1}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&p.GetDomainResponse{
+			Info:   &p.DomainInfo{ID: domainID},
+			Config: &p.DomainConfig{Retention: 1},
+			ReplicationConfig: &p.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*p.ClusterReplicationConfig{
+					&p.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: p.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	err := s.historyEngine.PauseWorkflowExecution(context.Background(), &h.PauseWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PauseRequest: &workflow.PauseWorkflowExecutionRequest{
+			WorkflowExecution: &workflow.WorkflowExecution{
+				WorkflowId: workflowExecution.WorkflowId,
+				RunId:      workflowExecution.RunId,
+			},
+			Identity: common.StringPtr("identity"),
+		},
+	})
+	s.Equal(ErrWorkflowAlreadyPaused, err)
+}
+
+func (s *engine2Suite) TestUnpauseWorkflowExecution() {
+	domainID := validDomainID
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	// the decision scheduled before the pause was held back by the transfer queue
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, false)
+	msBuilder.GetExecutionInfo().Paused = true
+	ms1 := createMutableState(msBuilder)
+	gwmsResponse1 := &p.GetWorkflowExecutionResponse{State: ms1}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *p.UpdateWorkflowExecutionRequest) bool {
+		if len(request.TransferTasks) != 1 {
+			return false
+		}
+		task, ok := request.TransferTasks[0].(*p.DecisionTask)
+		return ok && task.ScheduleID == int64(2) && task.TaskList == tl
+	})).Return(nil, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&p.GetDomainResponse{
+			Info:   &p.DomainInfo{ID: domainID},
+			Config: &p.DomainConfig{Retention: 1},
+			ReplicationConfig: &p.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*p.ClusterReplicationConfig{
+					&p.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: p.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	err := s.historyEngine.UnpauseWorkflowExecution(context.Background(), &h.UnpauseWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		UnpauseRequest: &workflow.UnpauseWorkflowExecutionRequest{
+			WorkflowExecution: &workflow.WorkflowExecution{
+				WorkflowId: workflowExecution.WorkflowId,
+				RunId:      workflowExecution.RunId,
+			},
+			Identity: common.StringPtr("identity"),
+		},
+	})
+	s.Nil(err)
+
+	executionBuilder := s.getBuilder(domainID, workflowExecution)
+	s.Equal(int64(4), executionBuilder.GetNextEventID())
+	s.False(executionBuilder.IsPaused())
+}
+
+func (s *engine2Suite) TestUnpauseWorkflowExecution_ActivityTimeouts() {
+	domainID := validDomainID
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	// the activity scheduled before the pause was held back without timing out
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, true)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, int64(2), int64(3), nil, identity)
+	scheduledEvent, ai := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), "activity1",
+		"activity_type1", tl, []byte("input"), 100, 10, 5)
+	pausedTime := time.Now().Add(-time.Hour)
+	ai.ScheduledTime = pausedTime
+	ai.ExpirationTime = pausedTime.Add(100 * time.Second)
+	ai.TimerTaskStatus = TimerTaskStatusCreatedScheduleToStart
+	msBuilder.GetExecutionInfo().Paused = true
+	ms1 := createMutableState(msBuilder)
+	gwmsResponse1 := &p.GetWorkflowExecutionResponse{State: ms1}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *p.UpdateWorkflowExecutionRequest) bool {
+		if len(request.TransferTasks) != 1 || len(request.TimerTasks) != 1 {
+			return false
+		}
+		activityTask, ok := request.TransferTasks[0].(*p.ActivityTask)
+		if !ok || activityTask.ScheduleID != scheduledEvent.GetEventId() {
+			return false
+		}
+		timerTask, ok := request.TimerTasks[0].(*p.ActivityTimeoutTask)
+		return ok && timerTask.TimeoutType == int(workflow.TimeoutTypeScheduleToStart) &&
+			timerTask.VisibilityTimestamp.After(time.Now())
+	})).Return(nil, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&p.GetDomainResponse{
+			Info:   &p.DomainInfo{ID: domainID},
+			Config: &p.DomainConfig{Retention: 1},
+			ReplicationConfig: &p.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*p.ClusterReplicationConfig{
+					&p.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: p.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	err := s.historyEngine.UnpauseWorkflowExecution(context.Background(), &h.UnpauseWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		UnpauseRequest: &workflow.UnpauseWorkflowExecutionRequest{
+			WorkflowExecution: &workflow.WorkflowExecution{
+				WorkflowId: workflowExecution.WorkflowId,
+				RunId:      workflowExecution.RunId,
+			},
+			Identity: common.StringPtr("identity"),
+		},
+	})
+	s.Nil(err)
+
+	executionBuilder := s.getBuilder(domainID, workflowExecution)
+	s.False(executionBuilder.IsPaused())
+	ai, ok := executionBuilder.GetActivityInfo(scheduledEvent.GetEventId())
+	s.True(ok)
+	s.True(ai.ScheduledTime.After(pausedTime.Add(time.Minute)))
+	s.True(ai.ScheduledTime.Add(100 * time.Second).Equal(ai.ExpirationTime))
+}
+
+func (s *engine2Suite) TestUnpauseWorkflowExecution_NotPaused() {
+	domainID := validDomainID
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, false)
+	ms1 := createMutableState(msBuilder)
+	gwmsResponse1 := &p.GetWorkflowExecutionResponse{State: ms1}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&p.GetDomainResponse{
+			Info:   &p.DomainInfo{ID: domainID},
+			Config: &p.DomainConfig{Retention: 1},
+			ReplicationConfig: &p.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*p.ClusterReplicationConfig{
+					&p.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: p.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	err := s.historyEngine.UnpauseWorkflowExecution(context.Background(), &h.UnpauseWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		UnpauseRequest: &workflow.UnpauseWorkflowExecutionRequest{
+			WorkflowExecution: &workflow.WorkflowExecution{
+				WorkflowId: workflowExecution.WorkflowId,
+				RunId:      workflowExecution.RunId,
+			},
+			Identity: common.StringPtr("identity"),
+		},
+	})
+	s.Equal(ErrWorkflowNotPaused, err)
+}
+
+func (s *engine2Suite) TestRecordDecisionTaskStarted_QueuedBeforePause() {
+	domainID := validDomainID
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	// the decision was dispatched to matching before the pause
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, false)
+	ms1 := createMutableState(msBuilder)
+	gwmsResponse1 := &p.GetWorkflowExecutionResponse{State: ms1}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&p.GetDomainResponse{
+			Info:   &p.DomainInfo{ID: domainID},
+			Config: &p.DomainConfig{Retention: 1},
+			ReplicationConfig: &p.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*p.ClusterReplicationConfig{
+					&p.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: p.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	err := s.historyEngine.PauseWorkflowExecution(context.Background(), &h.PauseWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PauseRequest: &workflow.PauseWorkflowExecutionRequest{
+			WorkflowExecution: &workflow.WorkflowExecution{
+				WorkflowId: workflowExecution.WorkflowId,
+				RunId:      workflowExecution.RunId,
+			},
+			Identity: common.StringPtr("identity"),
+		},
+	})
+	s.Nil(err)
+	ms2 := createMutableState(s.getBuilder(domainID, workflowExecution))
+
+	_, err = s.historyEngine.RecordDecisionTaskStarted(context.Background(), &h.RecordDecisionTaskStartedRequest{
+		DomainUUID:        common.StringPtr(domainID),
+		WorkflowExecution: &workflowExecution,
+		ScheduleId:        common.Int64Ptr(2),
+		TaskId:            common.Int64Ptr(100),
+		RequestId:         common.StringPtr("reqId"),
+		PollRequest: &workflow.PollForDecisionTaskRequest{
+			TaskList: &workflow.TaskList{
+				Name: common.StringPtr(tl),
+			},
+			Identity: common.StringPtr(identity),
+		},
+	})
+	s.Equal(ErrWorkflowPaused, err)
+
+	// the dropped decision is dispatched again on unpause
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&p.GetWorkflowExecutionResponse{State: ms2}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *p.UpdateWorkflowExecutionRequest) bool {
+		if len(request.TransferTasks) != 1 {
+			return false
+		}
+		task, ok := request.TransferTasks[0].(*p.DecisionTask)
+		return ok && task.ScheduleID == int64(2) && task.TaskList == tl
+	})).Return(nil, nil).Once()
+
+	err = s.historyEngine.UnpauseWorkflowExecution(context.Background(), &h.UnpauseWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		UnpauseRequest: &workflow.UnpauseWorkflowExecutionRequest{
+			WorkflowExecution: &workflow.WorkflowExecution{
+				WorkflowId: workflowExecution.WorkflowId,
+				RunId:      workflowExecution.RunId,
+			},
+			Identity: common.StringPtr("identity"),
+		},
+	})
+	s.Nil(err)
+	s.False(s.getBuilder(domainID, workflowExecution).IsPaused())
+}
+
+func (s *engine2Suite) TestRecordActivityTaskStarted_QueuedBeforePause() {
+	domainID := validDomainID
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	identity := "testIdentity"
+	tl := "testTaskList"
+
+	// the activity was dispatched to matching before the pause
+	msBuilder := s.createExecutionStartedState(workflowExecution, tl, identity, true)
+	decisionCompletedEvent := addDecisionTaskCompletedEvent(msBuilder, int64(2), int64(3), nil, identity)
+	scheduledEvent, _ := addActivityTaskScheduledEvent(msBuilder, decisionCompletedEvent.GetEventId(), "activity1",
+		"activity_type1", tl, []byte("input"), 100, 10, 5)
+	ms1 := createMutableState(msBuilder)
+	gwmsResponse1 := &p.GetWorkflowExecutionResponse{State: ms1}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse1, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&p.GetDomainResponse{
+			Info:   &p.DomainInfo{ID: domainID},
+			Config: &p.DomainConfig{Retention: 1},
+			ReplicationConfig: &p.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*p.ClusterReplicationConfig{
+					&p.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: p.DomainTableVersionV1,
+		},
+		nil,
+	)
+	s.mockEventsCache.On("getEvent", domainID, workflowExecution.GetWorkflowId(), workflowExecution.GetRunId(),
+		decisionCompletedEvent.GetEventId(), scheduledEvent.GetEventId(), mock.Anything, mock.Anything).Return(scheduledEvent, nil)
+
+	err := s.historyEngine.PauseWorkflowExecution(context.Background(), &h.PauseWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PauseRequest: &workflow.PauseWorkflowExecutionRequest{
+			WorkflowExecution: &workflow.WorkflowExecution{
+				WorkflowId: workflowExecution.WorkflowId,
+				RunId:      workflowExecution.RunId,
+			},
+			Identity: common.StringPtr("identity"),
+		},
+	})
+	s.Nil(err)
+	ms2 := createMutableState(s.getBuilder(domainID, workflowExecution))
+
+	_, err = s.historyEngine.RecordActivityTaskStarted(context.Background(), &h.RecordActivityTaskStartedRequest{
+		DomainUUID:        common.StringPtr(domainID),
+		WorkflowExecution: &workflowExecution,
+		ScheduleId:        common.Int64Ptr(scheduledEvent.GetEventId()),
+		TaskId:            common.Int64Ptr(100),
+		RequestId:         common.StringPtr("reqId"),
+		PollRequest: &workflow.PollForActivityTaskRequest{
+			TaskList: &workflow.TaskList{
+				Name: common.StringPtr(tl),
+			},
+			Identity: common.StringPtr(identity),
+		},
+	})
+	s.Equal(ErrWorkflowPaused, err)
+
+	// the dropped activity is dispatched again on unpause
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&p.GetWorkflowExecutionResponse{State: ms2}, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *p.UpdateWorkflowExecutionRequest) bool {
+		if len(request.TransferTasks) != 1 {
+			return false
+		}
+		task, ok := request.TransferTasks[0].(*p.ActivityTask)
+		return ok && task.ScheduleID == scheduledEvent.GetEventId() && task.TaskList == tl
+	})).Return(nil, nil).Once()
+
+	err = s.historyEngine.UnpauseWorkflowExecution(context.Background(), &h.UnpauseWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		UnpauseRequest: &workflow.UnpauseWorkflowExecutionRequest{
+			WorkflowExecution: &workflow.WorkflowExecution{
+				WorkflowId: workflowExecution.WorkflowId,
+				RunId:      workflowExecution.RunId,
+			},
+			Identity: common.StringPtr("identity"),
+		},
+	})
+	s.Nil(err)
+	ai, ok := s.getBuilder(domainID, workflowExecution).GetActivityInfo(scheduledEvent.GetEventId())
+	s.True(ok)
+	s.Equal(common.EmptyEventID, ai.StartedID)
+}
+
+func (s *engine2Suite) TestRegenerateWorkflowTasks_FirstDecisionBackoff() {
+	domainID := validDomainID
+	workflowExecution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	backoffSeconds := int32(30)
+	executionTimeout := int32(100)
+
+	// the workflow is started with a backoff, the first decision is not scheduled yet
+	msBuilder := newMutableStateBuilder(s.mockClusterMetadata.GetCurrentClusterName(), s.config, s.mockEventsCache,
+		s.logger)
+	startedEvent := msBuilder.AddWorkflowExecutionStartedEvent(workflowExecution, &h.StartWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		StartRequest: &workflow.StartWorkflowExecutionRequest{
+			WorkflowId:                          workflowExecution.WorkflowId,
+			WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr("wType")},
+			TaskList:                            &workflow.TaskList{Name: common.StringPtr(tl)},
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(executionTimeout),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(200),
+			Identity:                            common.StringPtr("identity"),
+		},
+		FirstDecisionTaskBackoffSeconds: common.Int32Ptr(backoffSeconds),
+	})
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &p.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("GetWorkflowExecutionHistory", mock.Anything).Return(&p.GetWorkflowExecutionHistoryResponse{
+		History:          &workflow.History{Events: []*workflow.HistoryEvent{startedEvent}},
+		LastFirstEventID: startedEvent.GetEventId(),
+	}, nil).Once()
+	var timerTasks []p.Task
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(nil, nil).Run(func(args mock.Arguments) {
+		timerTasks = args.Get(0).(*p.UpdateWorkflowExecutionRequest).TimerTasks
+	}).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&p.GetDomainResponse{
+			Info:   &p.DomainInfo{ID: domainID},
+			Config: &p.DomainConfig{Retention: 1},
+			ReplicationConfig: &p.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*p.ClusterReplicationConfig{
+					&p.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: p.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	err := s.historyEngine.RegenerateWorkflowTasks(context.Background(), &h.RegenerateWorkflowTasksRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  &workflowExecution,
+	})
+	s.Nil(err)
+
+	startTime := time.Unix(0, startedEvent.GetTimestamp())
+	backoff := time.Duration(backoffSeconds) * time.Second
+	var timeoutTask *p.WorkflowTimeoutTask
+	var backoffTask *p.WorkflowBackoffTimerTask
+	for _, task := range timerTasks {
+		switch t := task.(type) {
+		case *p.WorkflowTimeoutTask:
+			timeoutTask = t
+		case *p.WorkflowBackoffTimerTask:
+			backoffTask = t
+		}
+	}
+	s.NotNil(backoffTask)
+	s.Equal(p.WorkflowBackoffTimeoutTypeDelayStart, backoffTask.TimeoutType)
+	s.Equal(startTime.Add(backoff), backoffTask.VisibilityTimestamp)
+	s.NotNil(timeoutTask)
+	s.Equal(startTime.Add(backoff+time.Duration(executionTimeout)*time.Second), timeoutTask.VisibilityTimestamp)
+}
+
 func (s *engine2Suite) TestRespondDecisionTaskCompletedRecordMarkerDecision() {
 	domainID := validDomainID
 	we := workflow.WorkflowExecution{
@@ -1044,6 +1625,73 @@ func (s *engine2Suite) TestStartWorkflowExecution_BrandNew() {
 	s.NotNil(resp.RunId)
 }
 
+func (s *engine2Suite) TestStartWorkflowExecution_DelayStart() {
+	domainID := validDomainID
+	workflowID := "workflowID"
+	workflowType := "workflowType"
+	taskList := "testTaskList"
+	identity := "testIdentity"
+	delayStartSeconds := int32(60)
+
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On(
+		"CreateWorkflowExecution",
+		mock.MatchedBy(func(request *p.CreateWorkflowExecutionRequest) bool {
+			if request.DecisionScheduleID != common.EmptyEventID {
+				return false
+			}
+			for _, task := range request.TransferTasks {
+				if task.GetType() == p.TransferTaskTypeDecisionTask {
+					return false
+				}
+			}
+			var timeoutTask *p.WorkflowTimeoutTask
+			var backoffTask *p.WorkflowBackoffTimerTask
+			for _, task := range request.TimerTasks {
+				switch t := task.(type) {
+				case *p.WorkflowTimeoutTask:
+					timeoutTask = t
+				case *p.WorkflowBackoffTimerTask:
+					backoffTask = t
+				}
+			}
+			return timeoutTask != nil && backoffTask != nil &&
+				backoffTask.TimeoutType == p.WorkflowBackoffTimeoutTypeDelayStart &&
+				timeoutTask.VisibilityTimestamp.Sub(backoffTask.VisibilityTimestamp) == time.Second
+		}),
+	).Return(&p.CreateWorkflowExecutionResponse{}, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&p.GetDomainResponse{
+			Info:   &p.DomainInfo{ID: domainID},
+			Config: &p.DomainConfig{Retention: 1},
+			ReplicationConfig: &p.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*p.ClusterReplicationConfig{
+					&p.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: p.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	resp, err := s.historyEngine.StartWorkflowExecution(context.Background(), &h.StartWorkflowExecutionRequest{
+		DomainUUID: common.StringPtr(domainID),
+		StartRequest: &workflow.StartWorkflowExecutionRequest{
+			Domain:                              common.StringPtr(domainID),
+			WorkflowId:                          common.StringPtr(workflowID),
+			WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr(workflowType)},
+			TaskList:                            &workflow.TaskList{Name: common.StringPtr(taskList)},
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(1),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(2),
+			Identity:                            common.StringPtr(identity),
+		},
+		FirstDecisionTaskBackoffSeconds: common.Int32Ptr(delayStartSeconds),
+	})
+	s.Nil(err)
+	s.NotNil(resp.RunId)
+}
+
 func (s *engine2Suite) TestStartWorkflowExecution_StillRunning_Dedup() {
 	domainID := validDomainID
 	workflowID := "workflowID"
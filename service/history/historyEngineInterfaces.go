@@ -50,6 +50,7 @@ type (
 		GetMutableState(ctx context.Context, request *h.GetMutableStateRequest) (*h.GetMutableStateResponse, error)
 		DescribeMutableState(ctx context.Context, request *h.DescribeMutableStateRequest) (*h.DescribeMutableStateResponse, error)
 		ResetStickyTaskList(ctx context.Context, resetRequest *h.ResetStickyTaskListRequest) (*h.ResetStickyTaskListResponse, error)
+		QueryWorkflow(ctx context.Context, request *h.QueryWorkflowRequest) (*h.QueryWorkflowResponse, error)
 		DescribeWorkflowExecution(ctx context.Context,
 			request *h.DescribeWorkflowExecutionRequest) (*workflow.DescribeWorkflowExecutionResponse, error)
 		RecordDecisionTaskStarted(ctx context.Context, request *h.RecordDecisionTaskStartedRequest) (*h.RecordDecisionTaskStartedResponse, error)
@@ -66,6 +67,8 @@ type (
 			*workflow.StartWorkflowExecutionResponse, error)
 		RemoveSignalMutableState(ctx context.Context, request *h.RemoveSignalMutableStateRequest) error
 		TerminateWorkflowExecution(ctx context.Context, request *h.TerminateWorkflowExecutionRequest) error
+		PauseWorkflowExecution(ctx context.Context, request *h.PauseWorkflowExecutionRequest) error
+		UnpauseWorkflowExecution(ctx context.Context, request *h.UnpauseWorkflowExecutionRequest) error
 		ResetWorkflowExecution(ctx context.Context, request *h.ResetWorkflowExecutionRequest) (*workflow.ResetWorkflowExecutionResponse, error)
 		ScheduleDecisionTask(ctx context.Context, request *h.ScheduleDecisionTaskRequest) error
 		RecordChildExecutionCompleted(ctx context.Context, request *h.RecordChildExecutionCompletedRequest) error
@@ -73,6 +76,9 @@ type (
 		ReplicateRawEvents(ctx context.Context, request *h.ReplicateRawEventsRequest) error
 		SyncShardStatus(ctx context.Context, request *h.SyncShardStatusRequest) error
 		SyncActivity(ctx context.Context, request *h.SyncActivityRequest) error
+		ListQueueTasks(ctx context.Context, request *workflow.ListQueueTasksRequest) (*workflow.ListQueueTasksResponse, error)
+		RemoveQueueTask(ctx context.Context, request *workflow.RemoveQueueTaskRequest) error
+		RegenerateWorkflowTasks(ctx context.Context, request *h.RegenerateWorkflowTasksRequest) error
 	}
 
 	// EngineFactory is used to create an instance of sharded history engine
@@ -35,6 +35,7 @@ import (
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/definition"
 	"github.com/uber/cadence/common/metrics"
 	"github.com/uber/cadence/common/mocks"
 	"github.com/uber/cadence/common/persistence"
@@ -49,6 +50,7 @@ import (
 	"github.com/uber/cadence/common/messaging"
 	p "github.com/uber/cadence/common/persistence"
 	"github.com/uber/cadence/common/service"
+	"github.com/uber/cadence/common/service/dynamicconfig"
 )
 
 type (
@@ -172,6 +174,7 @@ func (s *engineSuite) SetupTest() {
 		historyEventNotifier: historyEventNotifier,
 		config:               NewDynamicConfigForTest(),
 		archivalClient:       s.mockArchivalClient,
+		queryRegistry:        newQueryRegistry(),
 	}
 	h.txProcessor = newTransferQueueProcessor(shardContextWrapper, h, s.mockVisibilityMgr, s.mockProducer, s.mockMatchingClient, s.mockHistoryClient, s.logger)
 	h.timerProcessor = newTimerQueueProcessor(shardContextWrapper, h, s.mockMatchingClient, s.mockProducer, s.logger)
@@ -1108,6 +1111,38 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedBadDecisionAttributes() {
 	s.IsType(&workflow.BadRequestError{}, err)
 }
 
+func (s *engineSuite) TestValidatePriority() {
+	startRequest := func(priority int32) *workflow.StartWorkflowExecutionRequest {
+		return &workflow.StartWorkflowExecutionRequest{
+			Domain:                              common.StringPtr("domain"),
+			WorkflowId:                          common.StringPtr("wId"),
+			WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr("wType")},
+			TaskList:                            &workflow.TaskList{Name: common.StringPtr("testTaskList")},
+			ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(100),
+			TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(10),
+			Priority:                            common.Int32Ptr(priority),
+		}
+	}
+	scheduleAttributes := func(priority int32) *workflow.ScheduleActivityTaskDecisionAttributes {
+		return &workflow.ScheduleActivityTaskDecisionAttributes{
+			ActivityId:                    common.StringPtr("activity1"),
+			ActivityType:                  &workflow.ActivityType{Name: common.StringPtr("activity_type1")},
+			TaskList:                      &workflow.TaskList{Name: common.StringPtr("testTaskList")},
+			ScheduleToCloseTimeoutSeconds: common.Int32Ptr(100),
+			Priority:                      common.Int32Ptr(priority),
+		}
+	}
+
+	for _, priority := range []int32{0, 5, common.MaxTaskPriority} {
+		s.NoError(validateStartWorkflowExecutionRequest(startRequest(priority), 1000))
+		s.NoError(validateActivityScheduleAttributes(scheduleAttributes(priority), 100, 1000))
+	}
+	for _, priority := range []int32{-1, common.MaxTaskPriority + 1} {
+		s.IsType(&workflow.BadRequestError{}, validateStartWorkflowExecutionRequest(startRequest(priority), 1000))
+		s.IsType(&workflow.BadRequestError{}, validateActivityScheduleAttributes(scheduleAttributes(priority), 100, 1000))
+	}
+}
+
 // This test unit tests the activity schedule timeout validation logic of HistoryEngine's RespondDecisionTaskComplete function.
 // An scheduled activity decision has 3 timeouts: ScheduleToClose, ScheduleToStart and StartToClose.
 // This test verifies that when either ScheduleToClose or ScheduleToStart and StartToClose are specified,
@@ -1335,6 +1370,357 @@ func (s *engineSuite) TestRespondDecisionTaskCompletedSingleActivityScheduledDec
 	s.Equal(int32(5), *activity1Attributes.HeartbeatTimeoutSeconds)
 }
 
+func (s *engineSuite) TestRespondDecisionTaskCompletedActivityEagerExecution() {
+	enableEager := s.mockHistoryEngine.config.EnableActivityEagerExecution
+	s.mockHistoryEngine.config.EnableActivityEagerExecution = dynamicconfig.GetBoolPropertyFnFilteredByDomain(true)
+	defer func() { s.mockHistoryEngine.config.EnableActivityEagerExecution = enableEager }()
+
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: "wId",
+		RunID:      we.GetRunId(),
+		ScheduleID: 2,
+	})
+	identity := "testIdentity"
+	input := []byte("input")
+
+	msBuilder := newMutableStateBuilder(s.mockClusterMetadata.GetCurrentClusterName(), s.config, s.eventsCache,
+		bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+
+	scheduleActivity := func(activityID string, activityTaskList string, eager bool) *workflow.Decision {
+		return &workflow.Decision{
+			DecisionType: common.DecisionTypePtr(workflow.DecisionTypeScheduleActivityTask),
+			ScheduleActivityTaskDecisionAttributes: &workflow.ScheduleActivityTaskDecisionAttributes{
+				ActivityId:                    common.StringPtr(activityID),
+				ActivityType:                  &workflow.ActivityType{Name: common.StringPtr("activity_type1")},
+				TaskList:                      &workflow.TaskList{Name: common.StringPtr(activityTaskList)},
+				Input:                         input,
+				ScheduleToCloseTimeoutSeconds: common.Int32Ptr(100),
+				ScheduleToStartTimeoutSeconds: common.Int32Ptr(10),
+				StartToCloseTimeoutSeconds:    common.Int32Ptr(50),
+				HeartbeatTimeoutSeconds:       common.Int32Ptr(5),
+				RequestEagerExecution:         common.BoolPtr(eager),
+			},
+		}
+	}
+	decisions := []*workflow.Decision{
+		scheduleActivity("activity1", tl, true),
+		scheduleActivity("activity2", tl, false),
+		// the worker does not poll the task list of the activity, it is dispatched through matching
+		scheduleActivity("activity3", "otherTaskList", true),
+	}
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *p.UpdateWorkflowExecutionRequest) bool {
+		var activityTasks []*p.ActivityTask
+		for _, task := range request.TransferTasks {
+			if activityTask, ok := task.(*p.ActivityTask); ok {
+				activityTasks = append(activityTasks, activityTask)
+			}
+		}
+		return len(activityTasks) == 2 &&
+			activityTasks[0].ScheduleID == 6 &&
+			activityTasks[1].ScheduleID == 7 && activityTasks[1].TaskList == "otherTaskList"
+	})).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Once()
+
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID, Name: "testDomain"},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+	resp, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Decisions: decisions,
+			Identity:  &identity,
+		},
+	})
+	s.Nil(err, s.printHistory(msBuilder))
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(int64(9), executionBuilder.GetExecutionInfo().NextEventID)
+
+	ai1, ok := executionBuilder.GetActivityInfo(5)
+	s.True(ok)
+	s.Equal(int64(8), ai1.StartedID)
+	ai2, ok := executionBuilder.GetActivityInfo(6)
+	s.True(ok)
+	s.Equal(common.EmptyEventID, ai2.StartedID)
+	ai3, ok := executionBuilder.GetActivityInfo(7)
+	s.True(ok)
+	s.Equal(common.EmptyEventID, ai3.StartedID)
+
+	s.Equal(1, len(resp.ActivityTasks))
+	activityTask := resp.ActivityTasks[0]
+	s.Equal("activity1", activityTask.GetActivityId())
+	s.Equal(input, activityTask.Input)
+	s.Equal(we.GetWorkflowId(), activityTask.WorkflowExecution.GetWorkflowId())
+	s.Equal(we.GetRunId(), activityTask.WorkflowExecution.GetRunId())
+	s.Equal(int32(50), activityTask.GetStartToCloseTimeoutSeconds())
+	s.Equal("testDomain", activityTask.GetWorkflowDomain())
+	token, err := s.mockHistoryEngine.tokenSerializer.Deserialize(activityTask.TaskToken)
+	s.NoError(err)
+	s.Equal(int64(5), token.ScheduleID)
+}
+
+func (s *engineSuite) TestRespondDecisionTaskCompletedDecisionHeartbeat() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilder(s.mockClusterMetadata.GetCurrentClusterName(), s.config, s.eventsCache,
+		bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	// only the first heartbeat writes the completed event of the decision
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Twice()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	heartbeat := func(scheduleID, attempt int64) *history.RespondDecisionTaskCompletedResponse {
+		taskToken, _ := json.Marshal(&common.TaskToken{
+			WorkflowID:      "wId",
+			RunID:           we.GetRunId(),
+			ScheduleID:      scheduleID,
+			ScheduleAttempt: attempt,
+		})
+		resp, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &history.RespondDecisionTaskCompletedRequest{
+			DomainUUID: common.StringPtr(domainID),
+			CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+				TaskToken:                  taskToken,
+				Identity:                   &identity,
+				ReturnNewDecisionTask:      common.BoolPtr(true),
+				ForceCreateNewDecisionTask: common.BoolPtr(true),
+			},
+		})
+		s.Nil(err, s.printHistory(msBuilder))
+		return resp
+	}
+
+	resp := heartbeat(2, 0)
+	executionBuilder := s.getBuilder(domainID, we)
+	s.Equal(int64(5), executionBuilder.GetExecutionInfo().NextEventID)
+	s.Equal(int64(3), executionBuilder.GetExecutionInfo().LastProcessedEvent)
+	di, ok := executionBuilder.GetPendingDecision(5)
+	s.True(ok)
+	s.Equal(int64(1), di.Attempt)
+	s.Equal(int64(5), resp.StartedResponse.GetScheduledEventId())
+	// heartbeats are no failures of the decision
+	s.Equal(int64(0), resp.StartedResponse.GetAttempt())
+	s.Equal(int64(1), resp.StartedResponse.GetScheduleAttempt())
+	s.NotNil(resp.StartedResponse.DecisionInfo)
+	s.Equal(int64(0), resp.StartedResponse.DecisionInfo.ScheduledEvent.DecisionTaskScheduledEventAttributes.GetAttempt())
+
+	resp = heartbeat(5, 1)
+	executionBuilder = s.getBuilder(domainID, we)
+	s.Equal(int64(5), executionBuilder.GetExecutionInfo().NextEventID)
+	di, ok = executionBuilder.GetPendingDecision(5)
+	s.True(ok)
+	s.Equal(int64(2), di.Attempt)
+	s.Equal(int64(0), resp.StartedResponse.GetAttempt())
+	s.Equal(int64(2), resp.StartedResponse.GetScheduleAttempt())
+	s.NotNil(resp.StartedResponse.DecisionInfo)
+}
+
+func (s *engineSuite) TestQueryWorkflowDeliveredWithDecisionTask() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilder(s.mockClusterMetadata.GetCurrentClusterName(), s.config, s.eventsCache,
+		bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil).Twice()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Twice()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	type queryResponse struct {
+		resp *history.QueryWorkflowResponse
+		err  error
+	}
+	queryChan := make(chan queryResponse, 1)
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		resp, err := s.mockHistoryEngine.QueryWorkflow(ctx, &history.QueryWorkflowRequest{
+			DomainUUID: common.StringPtr(domainID),
+			Request: &workflow.QueryWorkflowRequest{
+				Execution: &we,
+				Query:     &workflow.WorkflowQuery{QueryType: common.StringPtr("query-type")},
+			},
+		})
+		queryChan <- queryResponse{resp: resp, err: err}
+	}()
+	id := definition.NewWorkflowIdentifier(domainID, we.GetWorkflowId(), we.GetRunId())
+	for i := 0; i < 500 && !s.mockHistoryEngine.queryRegistry.hasUndeliveredQueries(id); i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	s.True(s.mockHistoryEngine.queryRegistry.hasUndeliveredQueries(id))
+
+	// the decision task in flight started before the query, so the query is delivered with a new one
+	taskToken, _ := json.Marshal(&common.TaskToken{
+		WorkflowID: "wId",
+		RunID:      we.GetRunId(),
+		ScheduleID: 2,
+	})
+	resp, err := s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken:             taskToken,
+			Identity:              &identity,
+			ReturnNewDecisionTask: common.BoolPtr(true),
+		},
+	})
+	s.Nil(err)
+	s.NotNil(resp.StartedResponse)
+	s.Equal(1, len(resp.StartedResponse.Queries))
+	var queryID string
+	for queryID = range resp.StartedResponse.Queries {
+	}
+	s.Equal("query-type", resp.StartedResponse.Queries[queryID].GetQueryType())
+	s.Empty(queryChan)
+
+	taskToken, _ = json.Marshal(&common.TaskToken{
+		WorkflowID: "wId",
+		RunID:      we.GetRunId(),
+		ScheduleID: resp.StartedResponse.GetScheduledEventId(),
+	})
+	_, err = s.mockHistoryEngine.RespondDecisionTaskCompleted(context.Background(), &history.RespondDecisionTaskCompletedRequest{
+		DomainUUID: common.StringPtr(domainID),
+		CompleteRequest: &workflow.RespondDecisionTaskCompletedRequest{
+			TaskToken: taskToken,
+			Identity:  &identity,
+			QueryResults: map[string]*workflow.WorkflowQueryResult{
+				queryID: {
+					ResultType: workflow.QueryTaskCompletedTypeCompleted.Ptr(),
+					Answer:     []byte("answer"),
+				},
+			},
+		},
+	})
+	s.Nil(err)
+
+	queryResp := <-queryChan
+	s.Nil(queryResp.err)
+	s.Equal([]byte("answer"), queryResp.resp.Response.QueryResult)
+}
+
+func (s *engineSuite) TestQueryWorkflowNoPendingDecision() {
+	domainID := validDomainID
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	identity := "testIdentity"
+
+	msBuilder := newMutableStateBuilder(s.mockClusterMetadata.GetCurrentClusterName(), s.config, s.eventsCache,
+		bark.NewLoggerFromLogrus(log.New()))
+	addWorkflowExecutionStartedEvent(msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, tl, identity)
+	addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, di.ScheduleID+1, nil, identity)
+
+	ms := createMutableState(msBuilder)
+	gwmsResponse := &persistence.GetWorkflowExecutionResponse{State: ms}
+
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
+	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
+		&persistence.GetDomainResponse{
+			Info:   &persistence.DomainInfo{ID: domainID},
+			Config: &persistence.DomainConfig{Retention: 1},
+			ReplicationConfig: &persistence.DomainReplicationConfig{
+				ActiveClusterName: cluster.TestCurrentClusterName,
+				Clusters: []*persistence.ClusterReplicationConfig{
+					&persistence.ClusterReplicationConfig{ClusterName: cluster.TestCurrentClusterName},
+				},
+			},
+			TableVersion: persistence.DomainTableVersionV1,
+		},
+		nil,
+	)
+
+	resp, err := s.mockHistoryEngine.QueryWorkflow(context.Background(), &history.QueryWorkflowRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Request: &workflow.QueryWorkflowRequest{
+			Execution: &we,
+			Query:     &workflow.WorkflowQuery{QueryType: common.StringPtr("query-type")},
+		},
+	})
+	s.Nil(err)
+	s.Nil(resp.Response)
+	s.False(s.mockHistoryEngine.queryRegistry.hasUndeliveredQueries(
+		definition.NewWorkflowIdentifier(domainID, we.GetWorkflowId(), we.GetRunId())))
+}
+
 func (s *engineSuite) TestRespondDecisionTaskCompletedCompleteWorkflowSuccess() {
 	domainID := validDomainID
 	we := workflow.WorkflowExecution{
@@ -3784,7 +4170,17 @@ func (s *engineSuite) TestRequestCancel_RespondDecisionTaskCompleted_NoHeartBeat
 
 	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(gwmsResponse, nil).Once()
 	s.mockHistoryMgr.On("AppendHistoryEvents", mock.Anything).Return(&p.AppendHistoryEventsResponse{Size: 0}, nil).Once()
-	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.Anything).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Once()
+	s.mockExecutionMgr.On("UpdateWorkflowExecution", mock.MatchedBy(func(request *p.UpdateWorkflowExecutionRequest) bool {
+		for _, task := range request.TransferTasks {
+			if cancellationTask, ok := task.(*p.ActivityCancellationTask); ok {
+				// the activity info carries no domain ID, the activity runs in the domain of the workflow
+				return cancellationTask.DomainID == domainID &&
+					cancellationTask.ScheduleID == *activityScheduledEvent.EventId &&
+					cancellationTask.TaskList == tl
+			}
+		}
+		return false
+	})).Return(&p.UpdateWorkflowExecutionResponse{MutableStateUpdateSessionStats: &p.MutableStateUpdateSessionStats{}}, nil).Once()
 
 	s.mockMetadataMgr.On("GetDomain", mock.Anything).Return(
 		&persistence.GetDomainResponse{
@@ -4486,6 +4882,46 @@ func (s *engineSuite) TestValidateSignalExternalWorkflowExecutionAttributes() {
 	s.Nil(err)
 }
 
+func (s *engineSuite) TestListQueueTasks_FilterByWorkflow() {
+	s.mockExecutionMgr.On("GetTransferTasks", mock.Anything).Return(&persistence.GetTransferTasksResponse{
+		Tasks: []*persistence.TransferTaskInfo{
+			{WorkflowID: "wid1", RunID: "rid1", TaskID: 1},
+			{WorkflowID: "wid2", RunID: "rid2", TaskID: 2},
+			{WorkflowID: "wid1", RunID: "rid3", TaskID: 3},
+		},
+		NextPageToken: []byte("token"),
+	}, nil).Once()
+
+	resp, err := s.mockHistoryEngine.ListQueueTasks(context.Background(), &workflow.ListQueueTasksRequest{
+		ShardID:    common.Int32Ptr(0),
+		TaskType:   workflow.QueueTaskTypeTransfer.Ptr(),
+		WorkflowID: common.StringPtr("wid1"),
+	})
+	s.Nil(err)
+	s.Equal(2, len(resp.Tasks))
+	s.Equal([]byte("token"), resp.NextPageToken)
+
+	task := &persistence.TransferTaskInfo{}
+	s.Nil(json.Unmarshal([]byte(resp.Tasks[1]), task))
+	s.Equal(int64(3), task.TaskID)
+}
+
+func (s *engineSuite) TestRemoveQueueTask_Timer() {
+	visibilityTimestamp := time.Now()
+	s.mockExecutionMgr.On("CompleteTimerTask", &persistence.CompleteTimerTaskRequest{
+		VisibilityTimestamp: time.Unix(0, visibilityTimestamp.UnixNano()),
+		TaskID:              5,
+	}).Return(nil).Once()
+
+	err := s.mockHistoryEngine.RemoveQueueTask(context.Background(), &workflow.RemoveQueueTaskRequest{
+		ShardID:             common.Int32Ptr(0),
+		TaskType:            workflow.QueueTaskTypeTimer.Ptr(),
+		TaskID:              common.Int64Ptr(5),
+		VisibilityTimestamp: common.Int64Ptr(visibilityTimestamp.UnixNano()),
+	})
+	s.Nil(err)
+}
+
 func (s *engineSuite) getBuilder(domainID string, we workflow.WorkflowExecution) mutableState {
 	context, release, err := s.mockHistoryEngine.historyCache.getOrCreateWorkflowExecution(domainID, we)
 	if err != nil {
@@ -4790,6 +5226,9 @@ func copyWorkflowExecutionInfo(sourceInfo *persistence.WorkflowExecutionInfo) *p
 		DecisionTimeout:              sourceInfo.DecisionTimeout,
 		EventStoreVersion:            sourceInfo.EventStoreVersion,
 		BranchToken:                  sourceInfo.BranchToken,
+		Paused:                       sourceInfo.Paused,
+		Priority:                     sourceInfo.Priority,
+		BuildID:                      sourceInfo.BuildID,
 	}
 }
 
@@ -4823,6 +5262,7 @@ func copyActivityInfo(sourceInfo *persistence.ActivityInfo) *persistence.Activit
 		MaximumInterval:          sourceInfo.MaximumInterval,
 		ExpirationTime:           sourceInfo.ExpirationTime,
 		MaximumAttempts:          sourceInfo.MaximumAttempts,
+		Priority:                 sourceInfo.Priority,
 	}
 }
 
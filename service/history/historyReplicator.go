@@ -889,6 +889,7 @@ func (r *historyReplicator) replicateWorkflowStarted(ctx context.Context, contex
 			ReplicationState:            replicationState,
 			EventStoreVersion:           msBuilder.GetEventStoreVersion(),
 			BranchToken:                 msBuilder.GetCurrentBranch(),
+			Priority:                    executionInfo.Priority,
 		}
 		createRequest.CreateWorkflowMode = persistence.CreateWorkflowModeBrandNew
 		if !isBrandNew {
@@ -140,6 +140,7 @@ func (s *historyReplicatorSuite) SetupTest() {
 		metricsClient:      s.mockShard.GetMetricsClient(),
 		txProcessor:        s.mockTxProcessor,
 		timerProcessor:     s.mockTimerProcessor,
+		queryRegistry:      newQueryRegistry(),
 	}
 	s.historyReplicator = newHistoryReplicator(s.mockShard, h, historyCache, s.mockShard.domainCache, s.mockHistoryMgr, s.mockHistoryV2Mgr, s.logger)
 	s.mockWorkflowResetor = &mockWorkflowResetor{}
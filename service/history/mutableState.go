@@ -60,6 +60,7 @@ type (
 		AddCompletedWorkflowEvent(int64, *workflow.CompleteWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent
 		AddContinueAsNewEvent(int64, *cache.DomainCacheEntry, string, *workflow.ContinueAsNewWorkflowExecutionDecisionAttributes, int32) (*workflow.HistoryEvent, mutableState, error)
 		AddDecisionTaskCompletedEvent(int64, int64, *workflow.RespondDecisionTaskCompletedRequest) *workflow.HistoryEvent
+		AddDecisionTaskHeartbeatEvent(int64, int64, *workflow.RespondDecisionTaskCompletedRequest) (*workflow.HistoryEvent, bool)
 		AddDecisionTaskFailedEvent(scheduleEventID int64, startedEventID int64, cause workflow.DecisionTaskFailedCause, details []byte, identity, reason, baseRunID, newRunID string, forkEventVersion int64) *workflow.HistoryEvent
 		AddDecisionTaskScheduleToStartTimeoutEvent(int64) *workflow.HistoryEvent
 		AddDecisionTaskScheduledEvent() *decisionInfo
@@ -83,9 +84,11 @@ type (
 		AddTimerStartedEvent(int64, *workflow.StartTimerDecisionAttributes) (*workflow.HistoryEvent, *persistence.TimerInfo)
 		AddWorkflowExecutionCancelRequestedEvent(string, *h.RequestCancelWorkflowExecutionRequest) *workflow.HistoryEvent
 		AddWorkflowExecutionCanceledEvent(int64, *workflow.CancelWorkflowExecutionDecisionAttributes) *workflow.HistoryEvent
+		AddWorkflowExecutionPausedEvent(*workflow.PauseWorkflowExecutionRequest) *workflow.HistoryEvent
 		AddWorkflowExecutionSignaled(signalName string, input []byte, identity string) *workflow.HistoryEvent
 		AddWorkflowExecutionStartedEvent(workflow.WorkflowExecution, *h.StartWorkflowExecutionRequest) *workflow.HistoryEvent
 		AddWorkflowExecutionTerminatedEvent(*workflow.TerminateWorkflowExecutionRequest) *workflow.HistoryEvent
+		AddWorkflowExecutionUnpausedEvent(*workflow.UnpauseWorkflowExecutionRequest) *workflow.HistoryEvent
 		AfterAddDecisionTaskCompletedEvent(int64)
 		BeforeAddDecisionTaskCompletedEvent()
 		BufferReplicationTask(*h.ReplicateEventsRequest) error
@@ -117,6 +120,7 @@ type (
 		GetContinueAsNew() *persistence.CreateWorkflowExecutionRequest
 		GetCurrentBranch() []byte
 		GetCurrentVersion() int64
+		GetDecisionFailureAttempt() int64
 		GetExecutionInfo() *persistence.WorkflowExecutionInfo
 		GetEventStoreVersion() int32
 		GetHistoryBuilder() *historyBuilder
@@ -147,8 +151,10 @@ type (
 		HasInFlightDecisionTask() bool
 		HasParentExecution() bool
 		HasPendingDecisionTask() bool
+		HasProcessedOrPendingDecisionTask() bool
 		IncrementHistorySize(int)
 		IsCancelRequested() (bool, string)
+		IsPaused() bool
 		IsSignalRequested(requestID string) bool
 		IsStickyTaskListEnabled() bool
 		IsWorkflowExecutionRunning() bool
@@ -167,7 +173,7 @@ type (
 		ReplicateChildWorkflowExecutionStartedEvent(*workflow.HistoryEvent) error
 		ReplicateChildWorkflowExecutionTerminatedEvent(*workflow.HistoryEvent)
 		ReplicateChildWorkflowExecutionTimedOutEvent(*workflow.HistoryEvent)
-		ReplicateDecisionTaskCompletedEvent(int64, int64)
+		ReplicateDecisionTaskCompletedEvent(int64, int64, string)
 		ReplicateDecisionTaskFailedEvent()
 		ReplicateDecisionTaskScheduledEvent(int64, int64, string, int32, int64) *decisionInfo
 		ReplicateDecisionTaskStartedEvent(*decisionInfo, int64, int64, int64, string, int64) *decisionInfo
@@ -189,10 +195,12 @@ type (
 		ReplicateWorkflowExecutionCompletedEvent(int64, *workflow.HistoryEvent)
 		ReplicateWorkflowExecutionContinuedAsNewEvent(string, string, *workflow.HistoryEvent, *workflow.HistoryEvent, *decisionInfo, mutableState, int32) error
 		ReplicateWorkflowExecutionFailedEvent(int64, *workflow.HistoryEvent)
+		ReplicateWorkflowExecutionPausedEvent(*workflow.HistoryEvent)
 		ReplicateWorkflowExecutionSignaled(*workflow.HistoryEvent)
 		ReplicateWorkflowExecutionStartedEvent(string, *string, workflow.WorkflowExecution, string, *workflow.WorkflowExecutionStartedEventAttributes)
 		ReplicateWorkflowExecutionTerminatedEvent(int64, *workflow.HistoryEvent)
 		ReplicateWorkflowExecutionTimedoutEvent(int64, *workflow.HistoryEvent)
+		ReplicateWorkflowExecutionUnpausedEvent(*workflow.HistoryEvent)
 		ResetSnapshot(string) *persistence.ResetMutableStateRequest
 		SetHistoryBuilder(hBuilder *historyBuilder)
 		SetHistoryTree(treeID string) error
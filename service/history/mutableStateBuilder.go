@@ -1049,6 +1049,13 @@ func (e *mutableStateBuilder) HasPendingDecisionTask() bool {
 	return e.executionInfo.DecisionScheduleID != common.EmptyEventID
 }
 
+// HasProcessedOrPendingDecisionTask returns false while the first decision of the workflow
+// is still held back, either by a workflow backoff (retry, cron, delayed start) or by a
+// child workflow waiting for its parent to schedule it.
+func (e *mutableStateBuilder) HasProcessedOrPendingDecisionTask() bool {
+	return e.HasPendingDecisionTask() || e.GetPreviousStartedEventID() != common.EmptyEventID
+}
+
 func (e *mutableStateBuilder) HasInFlightDecisionTask() bool {
 	return e.executionInfo.DecisionStartedID > 0
 }
@@ -1094,6 +1101,9 @@ func (e *mutableStateBuilder) UpdateDecision(di *decisionInfo) {
 	e.executionInfo.DecisionTimeout = di.DecisionTimeout
 	e.executionInfo.DecisionAttempt = di.Attempt
 	e.executionInfo.DecisionTimestamp = di.Timestamp
+	if di.Attempt == 0 {
+		e.executionInfo.DecisionHeartbeatCount = 0
+	}
 
 	e.logger.Debugf("Decision Updated: {Schedule: %v, Started: %v, ID: %v, Timeout: %v, Attempt: %v, Timestamp: %v}",
 		di.ScheduleID, di.StartedID, di.RequestID, di.DecisionTimeout, di.Attempt, di.Timestamp)
@@ -1113,6 +1123,12 @@ func (e *mutableStateBuilder) DeleteDecision() {
 	e.UpdateDecision(emptyDecisionInfo)
 }
 
+// GetDecisionFailureAttempt returns how many times the pending decision has failed or timed out in a row. Unlike the
+// decision attempt, which identifies the decision task in timers and task tokens, it does not count heartbeats.
+func (e *mutableStateBuilder) GetDecisionFailureAttempt() int64 {
+	return e.executionInfo.DecisionAttempt - e.executionInfo.DecisionHeartbeatCount
+}
+
 func (e *mutableStateBuilder) FailDecision(incrementAttempt bool) {
 	// Clear stickiness whenever decision fails
 	e.ClearStickyness()
@@ -1166,6 +1182,10 @@ func (e *mutableStateBuilder) IsCancelRequested() (bool, string) {
 	return false, ""
 }
 
+func (e *mutableStateBuilder) IsPaused() bool {
+	return e.executionInfo.Paused
+}
+
 func (e *mutableStateBuilder) IsSignalRequested(requestID string) bool {
 	if _, ok := e.pendingSignalRequestedIDs[requestID]; ok {
 		return true
@@ -1223,6 +1243,7 @@ func (e *mutableStateBuilder) addWorkflowExecutionStartedEventForContinueAsNew(d
 		Input:                               attributes.Input,
 		RetryPolicy:                         attributes.RetryPolicy,
 		CronSchedule:                        attributes.CronSchedule,
+		Priority:                            common.Int32Ptr(previousExecutionInfo.Priority),
 	}
 
 	req := &h.StartWorkflowExecutionRequest{
@@ -1332,6 +1353,7 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionStartedEvent(domainID st
 	if event.CronSchedule != nil {
 		e.executionInfo.CronSchedule = event.GetCronSchedule()
 	}
+	e.executionInfo.Priority = event.GetPriority()
 }
 
 func (e *mutableStateBuilder) AddDecisionTaskScheduledEvent() *decisionInfo {
@@ -1485,7 +1507,7 @@ func (e *mutableStateBuilder) CreateTransientDecisionEvents(di *decisionInfo, id
 	*workflow.HistoryEvent) {
 	tasklist := e.executionInfo.TaskList
 	scheduledEvent := newDecisionTaskScheduledEventWithInfo(di.ScheduleID, di.Timestamp, tasklist, di.DecisionTimeout,
-		di.Attempt)
+		e.GetDecisionFailureAttempt())
 	startedEvent := newDecisionTaskStartedEventWithInfo(di.StartedID, di.Timestamp, di.ScheduleID, di.RequestID,
 		identity)
 
@@ -1512,11 +1534,12 @@ func (e *mutableStateBuilder) AddDecisionTaskCompletedEvent(scheduleEventID, sta
 		return nil
 	}
 
+	failureAttempt := e.GetDecisionFailureAttempt()
 	e.BeforeAddDecisionTaskCompletedEvent()
 	if di.Attempt > 0 {
 		// Create corresponding DecisionTaskSchedule and DecisionTaskStarted events for decisions we have been retrying
 		scheduledEvent := e.hBuilder.AddTransientDecisionTaskScheduledEvent(e.executionInfo.TaskList, di.DecisionTimeout,
-			di.Attempt, di.Timestamp)
+			failureAttempt, di.Timestamp)
 		startedEvent := e.hBuilder.AddTransientDecisionTaskStartedEvent(scheduledEvent.GetEventId(), di.RequestID,
 			request.GetIdentity(), di.Timestamp)
 		startedEventID = startedEvent.GetEventId()
@@ -1525,12 +1548,54 @@ func (e *mutableStateBuilder) AddDecisionTaskCompletedEvent(scheduleEventID, sta
 	event := e.hBuilder.AddDecisionTaskCompletedEvent(scheduleEventID, startedEventID, request)
 
 	e.AfterAddDecisionTaskCompletedEvent(startedEventID)
+	e.pinBuildID(request.GetBinaryChecksum())
 	return event
 }
 
-func (e *mutableStateBuilder) ReplicateDecisionTaskCompletedEvent(scheduleEventID, startedEventID int64) {
+// AddDecisionTaskHeartbeatEvent completes a decision which made no progress, so that the next decision is scheduled
+// as a transient one in its place. Like for decisions which keep failing, the events of transient decisions are only
+// written to history once one of them completes with progress, so a worker heartbeating a decision to keep working on
+// the workflow does not grow its history. Returns the completed event, which is nil if the decision was transient.
+func (e *mutableStateBuilder) AddDecisionTaskHeartbeatEvent(scheduleEventID, startedEventID int64,
+	request *workflow.RespondDecisionTaskCompletedRequest) (*workflow.HistoryEvent, bool) {
+	hasPendingDecision := e.HasPendingDecisionTask()
+	di, ok := e.GetPendingDecision(scheduleEventID)
+	if !hasPendingDecision || !ok || di.StartedID != startedEventID {
+		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionDecisionTaskCompleted, e.GetNextEventID(), fmt.Sprintf(
+			"{HasPending: %v, ScheduleID: %v, StartedID: %v, Exist: %v}", hasPendingDecision, scheduleEventID,
+			startedEventID, ok))
+		return nil, false
+	}
+
+	heartbeatCount := e.executionInfo.DecisionHeartbeatCount
+	var event *workflow.HistoryEvent
+	if di.Attempt == 0 {
+		event = e.AddDecisionTaskCompletedEvent(scheduleEventID, startedEventID, request)
+	} else {
+		// nothing of a transient decision is in history yet, so there is nothing to complete either
+		e.DeleteDecision()
+	}
+
+	// the next decision is scheduled with the same ID as a transient one, its attempt tells their timeouts apart,
+	// while the heartbeat count keeps the heartbeat from being taken for a failure of the decision
+	e.executionInfo.DecisionAttempt = di.Attempt + 1
+	e.executionInfo.DecisionHeartbeatCount = heartbeatCount + 1
+	return event, true
+}
+
+func (e *mutableStateBuilder) ReplicateDecisionTaskCompletedEvent(scheduleEventID, startedEventID int64,
+	binaryChecksum string) {
 	e.BeforeAddDecisionTaskCompletedEvent()
 	e.AfterAddDecisionTaskCompletedEvent(startedEventID)
+	e.pinBuildID(binaryChecksum)
+}
+
+// pinBuildID pins the workflow to the build of the worker which completed its first decision, so that
+// matching keeps dispatching its decision tasks to workers of a compatible build
+func (e *mutableStateBuilder) pinBuildID(binaryChecksum string) {
+	if e.executionInfo.BuildID == "" {
+		e.executionInfo.BuildID = binaryChecksum
+	}
 }
 
 func (e *mutableStateBuilder) AddDecisionTaskTimedOutEvent(scheduleEventID int64,
@@ -1546,11 +1611,16 @@ func (e *mutableStateBuilder) AddDecisionTaskTimedOutEvent(scheduleEventID int64
 
 	var event *workflow.HistoryEvent
 	// Avoid creating new history events when decisions are continuously timing out
-	if dt.Attempt == 0 {
+	firstFailure := e.GetDecisionFailureAttempt() == 0
+	if firstFailure {
+		scheduleEventID, startedEventID = e.addHeartbeatedDecisionEvents(dt, "")
 		event = e.hBuilder.AddDecisionTaskTimedOutEvent(scheduleEventID, startedEventID, workflow.TimeoutTypeStartToClose)
 	}
 
 	e.ReplicateDecisionTaskTimedOutEvent(workflow.TimeoutTypeStartToClose)
+	if firstFailure && dt.Attempt > 0 {
+		e.resetDecisionHeartbeats()
+	}
 	return event
 }
 
@@ -1606,19 +1676,50 @@ func (e *mutableStateBuilder) AddDecisionTaskFailedEvent(scheduleEventID int64,
 
 	var event *workflow.HistoryEvent
 	// Only emit DecisionTaskFailedEvent for the very first time
-	if dt.Attempt == 0 || cause == workflow.DecisionTaskFailedCauseResetWorkflow {
+	firstFailure := e.GetDecisionFailureAttempt() == 0
+	if firstFailure || cause == workflow.DecisionTaskFailedCauseResetWorkflow {
+		if firstFailure {
+			scheduleEventID, startedEventID = e.addHeartbeatedDecisionEvents(dt, identity)
+			attr.ScheduledEventId = common.Int64Ptr(scheduleEventID)
+			attr.StartedEventId = common.Int64Ptr(startedEventID)
+		}
 		event = e.hBuilder.AddDecisionTaskFailedEvent(attr)
 	}
 
 	e.ReplicateDecisionTaskFailedEvent()
+	if firstFailure && dt.Attempt > 0 {
+		e.resetDecisionHeartbeats()
+	}
 
 	// always clear decision attempt for reset
 	if cause == workflow.DecisionTaskFailedCauseResetWorkflow {
 		e.executionInfo.DecisionAttempt = 0
+		e.executionInfo.DecisionHeartbeatCount = 0
 	}
 	return event
 }
 
+// addHeartbeatedDecisionEvents writes the scheduled and started events of a transient decision which followed a
+// heartbeat, so that its failure can be recorded in history. Returns the IDs of the scheduled and started events.
+func (e *mutableStateBuilder) addHeartbeatedDecisionEvents(di *decisionInfo, identity string) (int64, int64) {
+	if di.Attempt == 0 {
+		return di.ScheduleID, di.StartedID
+	}
+
+	scheduledEvent := e.hBuilder.AddTransientDecisionTaskScheduledEvent(e.executionInfo.TaskList, di.DecisionTimeout,
+		0, di.Timestamp)
+	startedEvent := e.hBuilder.AddTransientDecisionTaskStartedEvent(scheduledEvent.GetEventId(), di.RequestID,
+		identity, di.Timestamp)
+	return scheduledEvent.GetEventId(), startedEvent.GetEventId()
+}
+
+// resetDecisionHeartbeats starts counting the attempts of the next decision afresh once the failure of a decision
+// which followed a heartbeat got recorded in history, just like after the failure of a regular decision
+func (e *mutableStateBuilder) resetDecisionHeartbeats() {
+	e.executionInfo.DecisionAttempt = 1
+	e.executionInfo.DecisionHeartbeatCount = 0
+}
+
 func (e *mutableStateBuilder) ReplicateDecisionTaskFailedEvent() {
 	e.FailDecision(true)
 }
@@ -1670,6 +1771,7 @@ func (e *mutableStateBuilder) ReplicateActivityTaskScheduledEvent(firstEventID i
 		TimerTaskStatus:          TimerTaskStatusNone,
 		TaskList:                 attributes.TaskList.GetName(),
 		HasRetryPolicy:           attributes.RetryPolicy != nil,
+		Priority:                 attributes.GetPriority(),
 	}
 	ai.ExpirationTime = ai.ScheduledTime.Add(time.Duration(scheduleToCloseTimeout) * time.Second)
 	if ai.HasRetryPolicy {
@@ -1732,6 +1834,7 @@ func (e *mutableStateBuilder) ReplicateActivityTaskStartedEvent(event *workflow.
 	ai.StartedID = event.GetEventId()
 	ai.RequestID = attributes.GetRequestId()
 	ai.StartedTime = time.Unix(0, event.GetTimestamp())
+	ai.StartedIdentity = attributes.GetIdentity()
 	e.updateActivityInfos[ai] = struct{}{}
 }
 
@@ -2259,6 +2362,57 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionTerminatedEvent(firstEve
 	e.executionInfo.CompletionEventBatchID = firstEventID // Used when completion event needs to be loaded from database
 }
 
+func (e *mutableStateBuilder) AddWorkflowExecutionPausedEvent(
+	request *workflow.PauseWorkflowExecutionRequest) *workflow.HistoryEvent {
+	if e.executionInfo.State == persistence.WorkflowStateCompleted || e.executionInfo.Paused {
+		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionWorkflowPaused, e.GetNextEventID(), fmt.Sprintf(
+			"{State: %v, Paused: %v}", e.executionInfo.State, e.executionInfo.Paused))
+		return nil
+	}
+
+	event := e.hBuilder.AddWorkflowExecutionPausedEvent(request)
+	e.ReplicateWorkflowExecutionPausedEvent(event)
+	return event
+}
+
+func (e *mutableStateBuilder) ReplicateWorkflowExecutionPausedEvent(event *workflow.HistoryEvent) {
+	e.executionInfo.Paused = true
+}
+
+func (e *mutableStateBuilder) AddWorkflowExecutionUnpausedEvent(
+	request *workflow.UnpauseWorkflowExecutionRequest) *workflow.HistoryEvent {
+	if e.executionInfo.State == persistence.WorkflowStateCompleted || !e.executionInfo.Paused {
+		logging.LogInvalidHistoryActionEvent(e.logger, logging.TagValueActionWorkflowUnpaused, e.GetNextEventID(), fmt.Sprintf(
+			"{State: %v, Paused: %v}", e.executionInfo.State, e.executionInfo.Paused))
+		return nil
+	}
+
+	event := e.hBuilder.AddWorkflowExecutionUnpausedEvent(request)
+	e.ReplicateWorkflowExecutionUnpausedEvent(event)
+	return event
+}
+
+func (e *mutableStateBuilder) ReplicateWorkflowExecutionUnpausedEvent(event *workflow.HistoryEvent) {
+	e.executionInfo.Paused = false
+
+	// activities held back by the pause are scheduled again on unpause, so restart their schedule clock and let the
+	// timer builder create their ScheduleToStart and ScheduleToClose timers again
+	unpauseTime := time.Unix(0, event.GetTimestamp())
+	for _, ai := range e.pendingActivityInfoIDs {
+		if ai.StartedID != common.EmptyEventID {
+			continue
+		}
+		if !ai.ScheduledTime.After(unpauseTime) {
+			if !ai.ExpirationTime.IsZero() {
+				ai.ExpirationTime = ai.ExpirationTime.Add(unpauseTime.Sub(ai.ScheduledTime))
+			}
+			ai.ScheduledTime = unpauseTime
+		}
+		ai.TimerTaskStatus = ai.TimerTaskStatus &^ (TimerTaskStatusCreatedScheduleToStart | TimerTaskStatusCreatedScheduleToClose)
+		e.updateActivityInfos[ai] = struct{}{}
+	}
+}
+
 func (e *mutableStateBuilder) AddWorkflowExecutionSignaled(
 	signalName string, input []byte, identity string) *workflow.HistoryEvent {
 	if e.executionInfo.State == persistence.WorkflowStateCompleted {
@@ -2390,6 +2544,7 @@ func (e *mutableStateBuilder) ReplicateWorkflowExecutionContinuedAsNewEvent(sour
 		ReplicationState:     newStateBuilder.GetReplicationState(),
 		HasRetryPolicy:       startedAttributes.RetryPolicy != nil,
 		CronSchedule:         startedAttributes.GetCronSchedule(),
+		Priority:             startedAttributes.GetPriority(),
 		EventStoreVersion:    newStateBuilder.GetEventStoreVersion(),
 		BranchToken:          newStateBuilder.GetCurrentBranch(),
 	}
@@ -29,6 +29,7 @@ import (
 	"github.com/uber/cadence/common/persistence"
 
 	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
 	workflow "github.com/uber/cadence/.gen/go/shared"
@@ -242,3 +243,64 @@ func (s *mutableStateSuite) TestReorderEvents() {
 	s.Equal(int64(5), s.msBuilder.hBuilder.history[1].ActivityTaskCompletedEventAttributes.GetScheduledEventId())
 
 }
+
+func (s *mutableStateSuite) TestDecisionFailedAfterHeartbeat() {
+	s.startHeartbeatedDecision()
+
+	event := s.msBuilder.AddDecisionTaskFailedEvent(5, 6, workflow.DecisionTaskFailedCauseUnhandledDecision, nil,
+		"identity", "", "", "", 0)
+	s.NotNil(event)
+	s.Equal(int64(5), event.DecisionTaskFailedEventAttributes.GetScheduledEventId())
+	s.Equal(int64(6), event.DecisionTaskFailedEventAttributes.GetStartedEventId())
+	s.assertHeartbeatedDecisionEvents()
+	s.Equal(int64(1), s.msBuilder.GetDecisionFailureAttempt())
+}
+
+func (s *mutableStateSuite) TestDecisionTimedOutAfterHeartbeat() {
+	s.startHeartbeatedDecision()
+
+	event := s.msBuilder.AddDecisionTaskTimedOutEvent(5, 6)
+	s.NotNil(event)
+	s.Equal(int64(5), event.DecisionTaskTimedOutEventAttributes.GetScheduledEventId())
+	s.Equal(int64(6), event.DecisionTaskTimedOutEventAttributes.GetStartedEventId())
+	s.assertHeartbeatedDecisionEvents()
+	s.Equal(int64(1), s.msBuilder.GetDecisionFailureAttempt())
+}
+
+// startHeartbeatedDecision starts the transient decision 5 which follows the heartbeat of decision 2
+func (s *mutableStateSuite) startHeartbeatedDecision() {
+	we := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("wId"),
+		RunId:      common.StringPtr(validRunID),
+	}
+	tl := "testTaskList"
+	identity := "identity"
+	s.mockEventsCache.On("putEvent", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	addWorkflowExecutionStartedEvent(s.msBuilder, we, "wType", tl, []byte("input"), 100, 200, identity)
+	di := addDecisionTaskScheduledEvent(s.msBuilder)
+	addDecisionTaskStartedEvent(s.msBuilder, di.ScheduleID, tl, identity)
+	event, ok := s.msBuilder.AddDecisionTaskHeartbeatEvent(di.ScheduleID, di.ScheduleID+1,
+		&workflow.RespondDecisionTaskCompletedRequest{Identity: common.StringPtr(identity)})
+	s.True(ok)
+	s.NotNil(event)
+
+	di = addDecisionTaskScheduledEvent(s.msBuilder)
+	s.Equal(int64(5), di.ScheduleID)
+	s.Equal(int64(1), di.Attempt)
+	addDecisionTaskStartedEvent(s.msBuilder, di.ScheduleID, tl, identity)
+	s.Equal(int64(0), s.msBuilder.GetDecisionFailureAttempt())
+	s.Empty(s.msBuilder.hBuilder.transientHistory)
+}
+
+func (s *mutableStateSuite) assertHeartbeatedDecisionEvents() {
+	transientHistory := s.msBuilder.hBuilder.transientHistory
+	s.Equal(2, len(transientHistory))
+	s.Equal(workflow.EventTypeDecisionTaskScheduled, transientHistory[0].GetEventType())
+	s.Equal(int64(5), transientHistory[0].GetEventId())
+	s.Equal(int64(0), transientHistory[0].DecisionTaskScheduledEventAttributes.GetAttempt())
+	s.Equal(workflow.EventTypeDecisionTaskStarted, transientHistory[1].GetEventType())
+	s.Equal(int64(6), transientHistory[1].GetEventId())
+	s.Equal(int64(1), s.msBuilder.GetExecutionInfo().DecisionAttempt)
+	s.Equal(int64(0), s.msBuilder.GetExecutionInfo().DecisionHeartbeatCount)
+}
@@ -0,0 +1,179 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"sync"
+
+	"github.com/pborman/uuid"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common/definition"
+)
+
+var (
+	errQueryDecisionTimeout = &workflow.QueryFailedError{Message: "timeout: the pending decision task was not completed, strongly consistent query cannot be answered"}
+	errQueryNotAnswered     = &workflow.QueryFailedError{Message: "query was not answered by the decision task it was delivered with."}
+	errQueryWorkflowClosed  = &workflow.QueryFailedError{Message: "workflow closed before the query was delivered with a decision task."}
+)
+
+type (
+	// queryRegistry holds the strongly consistent queries of workflows until they are answered by the worker
+	// completing the decision task they were delivered with
+	queryRegistry interface {
+		bufferQuery(id definition.WorkflowIdentifier, query *workflow.WorkflowQuery) (string, <-chan *queryResult)
+		removeQuery(id definition.WorkflowIdentifier, queryID string)
+		deliverQueries(id definition.WorkflowIdentifier) map[string]*workflow.WorkflowQuery
+		hasUndeliveredQueries(id definition.WorkflowIdentifier) bool
+		completeQueries(id definition.WorkflowIdentifier, results map[string]*workflow.WorkflowQueryResult)
+		failQueries(id definition.WorkflowIdentifier, err error)
+	}
+
+	queryResult struct {
+		answer []byte
+		err    error
+	}
+
+	bufferedQuery struct {
+		query     *workflow.WorkflowQuery
+		delivered bool
+		// buffered so that answering the query never blocks on a caller which gave up waiting
+		resultChan chan *queryResult
+	}
+
+	queryRegistryImpl struct {
+		sync.Mutex
+		queries map[definition.WorkflowIdentifier]map[string]*bufferedQuery
+	}
+)
+
+var _ queryRegistry = (*queryRegistryImpl)(nil)
+
+func newQueryRegistry() *queryRegistryImpl {
+	return &queryRegistryImpl{
+		queries: make(map[definition.WorkflowIdentifier]map[string]*bufferedQuery),
+	}
+}
+
+// bufferQuery holds the query until the next decision task of the workflow is started, returns the ID of the query
+// and the channel its result is sent to
+func (r *queryRegistryImpl) bufferQuery(id definition.WorkflowIdentifier,
+	query *workflow.WorkflowQuery) (string, <-chan *queryResult) {
+	r.Lock()
+	defer r.Unlock()
+
+	queries, ok := r.queries[id]
+	if !ok {
+		queries = make(map[string]*bufferedQuery)
+		r.queries[id] = queries
+	}
+	queryID := uuid.New()
+	resultChan := make(chan *queryResult, 1)
+	queries[queryID] = &bufferedQuery{
+		query:      query,
+		resultChan: resultChan,
+	}
+	return queryID, resultChan
+}
+
+// removeQuery drops a query whose caller gave up waiting for its result
+func (r *queryRegistryImpl) removeQuery(id definition.WorkflowIdentifier, queryID string) {
+	r.Lock()
+	defer r.Unlock()
+
+	if queries, ok := r.queries[id]; ok {
+		delete(queries, queryID)
+		if len(queries) == 0 {
+			delete(r.queries, id)
+		}
+	}
+}
+
+// deliverQueries returns all the queries which are not answered yet, to be delivered with the decision task which
+// is started. Queries delivered with a decision task which failed or timed out are delivered again.
+func (r *queryRegistryImpl) deliverQueries(id definition.WorkflowIdentifier) map[string]*workflow.WorkflowQuery {
+	r.Lock()
+	defer r.Unlock()
+
+	queries, ok := r.queries[id]
+	if !ok {
+		return nil
+	}
+	result := make(map[string]*workflow.WorkflowQuery, len(queries))
+	for queryID, query := range queries {
+		query.delivered = true
+		result[queryID] = query.query
+	}
+	return result
+}
+
+// hasUndeliveredQueries returns whether queries arrived after the current decision task was started, which need
+// another decision task to be delivered with
+func (r *queryRegistryImpl) hasUndeliveredQueries(id definition.WorkflowIdentifier) bool {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, query := range r.queries[id] {
+		if !query.delivered {
+			return true
+		}
+	}
+	return false
+}
+
+// completeQueries answers the queries delivered with a completed decision task, queries it did not answer are
+// failed as the worker is not going to answer them
+func (r *queryRegistryImpl) completeQueries(id definition.WorkflowIdentifier,
+	results map[string]*workflow.WorkflowQueryResult) {
+	r.Lock()
+	defer r.Unlock()
+
+	queries := r.queries[id]
+	for queryID, query := range queries {
+		if !query.delivered {
+			continue
+		}
+
+		result, ok := results[queryID]
+		switch {
+		case !ok:
+			query.resultChan <- &queryResult{err: errQueryNotAnswered}
+		case result.GetResultType() == workflow.QueryTaskCompletedTypeFailed:
+			query.resultChan <- &queryResult{err: &workflow.QueryFailedError{Message: result.GetErrorMessage()}}
+		default:
+			query.resultChan <- &queryResult{answer: result.Answer}
+		}
+		delete(queries, queryID)
+	}
+	if len(queries) == 0 {
+		delete(r.queries, id)
+	}
+}
+
+// failQueries fails all the queries of a workflow which is not going to start another decision task
+func (r *queryRegistryImpl) failQueries(id definition.WorkflowIdentifier, err error) {
+	r.Lock()
+	defer r.Unlock()
+
+	for _, query := range r.queries[id] {
+		query.resultChan <- &queryResult{err: err}
+	}
+	delete(r.queries, id)
+}
@@ -0,0 +1,117 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package history
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/definition"
+)
+
+type (
+	queryRegistrySuite struct {
+		suite.Suite
+		registry *queryRegistryImpl
+		id       definition.WorkflowIdentifier
+	}
+)
+
+func TestQueryRegistrySuite(t *testing.T) {
+	s := new(queryRegistrySuite)
+	suite.Run(t, s)
+}
+
+func (s *queryRegistrySuite) SetupTest() {
+	s.registry = newQueryRegistry()
+	s.id = definition.NewWorkflowIdentifier(validDomainID, "wId", validRunID)
+}
+
+func (s *queryRegistrySuite) TestCompleteQueries() {
+	answeredID, answeredChan := s.registry.bufferQuery(s.id, s.newQuery())
+	failedID, failedChan := s.registry.bufferQuery(s.id, s.newQuery())
+	unansweredID, unansweredChan := s.registry.bufferQuery(s.id, s.newQuery())
+	s.True(s.registry.hasUndeliveredQueries(s.id))
+
+	queries := s.registry.deliverQueries(s.id)
+	s.Equal(3, len(queries))
+	s.Contains(queries, answeredID)
+	s.Contains(queries, failedID)
+	s.Contains(queries, unansweredID)
+	s.False(s.registry.hasUndeliveredQueries(s.id))
+
+	// queries arriving after the decision task started are left for the next one
+	laterID, laterChan := s.registry.bufferQuery(s.id, s.newQuery())
+	s.registry.completeQueries(s.id, map[string]*workflow.WorkflowQueryResult{
+		answeredID: {
+			ResultType: workflow.QueryTaskCompletedTypeCompleted.Ptr(),
+			Answer:     []byte("answer"),
+		},
+		failedID: {
+			ResultType:   workflow.QueryTaskCompletedTypeFailed.Ptr(),
+			ErrorMessage: common.StringPtr("query failed"),
+		},
+	})
+
+	result := <-answeredChan
+	s.Nil(result.err)
+	s.Equal([]byte("answer"), result.answer)
+	result = <-failedChan
+	s.Equal(&workflow.QueryFailedError{Message: "query failed"}, result.err)
+	result = <-unansweredChan
+	s.Equal(errQueryNotAnswered, result.err)
+	s.Empty(laterChan)
+
+	s.True(s.registry.hasUndeliveredQueries(s.id))
+	queries = s.registry.deliverQueries(s.id)
+	s.Equal(1, len(queries))
+	s.Contains(queries, laterID)
+}
+
+func (s *queryRegistrySuite) TestRedeliverQueries() {
+	queryID, _ := s.registry.bufferQuery(s.id, s.newQuery())
+	s.Contains(s.registry.deliverQueries(s.id), queryID)
+
+	// the decision task failed, so the query goes with the next one
+	s.Contains(s.registry.deliverQueries(s.id), queryID)
+}
+
+func (s *queryRegistrySuite) TestRemoveQuery() {
+	queryID, _ := s.registry.bufferQuery(s.id, s.newQuery())
+	s.registry.removeQuery(s.id, queryID)
+	s.False(s.registry.hasUndeliveredQueries(s.id))
+	s.Nil(s.registry.deliverQueries(s.id))
+	s.Empty(s.registry.queries)
+}
+
+func (s *queryRegistrySuite) TestFailQueries() {
+	_, resultChan := s.registry.bufferQuery(s.id, s.newQuery())
+	s.registry.failQueries(s.id, errQueryWorkflowClosed)
+	result := <-resultChan
+	s.Equal(errQueryWorkflowClosed, result.err)
+	s.Empty(s.registry.queries)
+}
+
+func (s *queryRegistrySuite) newQuery() *workflow.WorkflowQuery {
+	return &workflow.WorkflowQuery{QueryType: common.StringPtr("query-type")}
+}
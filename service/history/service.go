@@ -129,6 +129,10 @@ type Config struct {
 	// whether or not using eventsV2
 	EnableEventsV2 dynamicconfig.BoolPropertyFnWithDomainFilter
 
+	// whether or not activities can be started eagerly from RespondDecisionTaskCompleted, and how many at most
+	EnableActivityEagerExecution         dynamicconfig.BoolPropertyFnWithDomainFilter
+	MaxActivityEagerExecutionPerDecision dynamicconfig.IntPropertyFnWithDomainFilter
+
 	NumSysWorkflows dynamicconfig.IntPropertyFn
 
 	BlobSizeLimitError     dynamicconfig.IntPropertyFnWithDomainFilter
@@ -209,6 +213,9 @@ func NewConfig(dc *dynamicconfig.Collection, numberOfShards int, enableVisibilit
 		EventEncodingType:          dc.GetStringPropertyFnWithDomainFilter(dynamicconfig.DefaultEventEncoding, string(common.EncodingTypeJSON)),
 		EnableEventsV2:             dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableEventsV2, false),
 
+		EnableActivityEagerExecution:         dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableActivityEagerExecution, false),
+		MaxActivityEagerExecutionPerDecision: dc.GetIntPropertyFilteredByDomain(dynamicconfig.MaxActivityEagerExecutionPerDecision, 3),
+
 		NumSysWorkflows: dc.GetIntProperty(dynamicconfig.NumSystemWorkflows, 1000),
 
 		BlobSizeLimitError:     dc.GetIntPropertyFilteredByDomain(dynamicconfig.BlobSizeLimitError, 2*1024*1024),
@@ -237,14 +244,17 @@ type Service struct {
 func NewService(params *service.BootstrapParams) common.Daemon {
 	params.UpdateLoggerWithServiceName(common.HistoryServiceName)
 	fmt.Println(params.ESConfig)
+	dc := dynamicconfig.NewCollection(params.DynamicConfig, params.Logger)
+	config := NewConfig(dc, params.PersistenceConfig.NumHistoryShards, params.ESConfig.Enable)
+	if params.PersistenceConfig.PayloadOffload.Enabled {
+		// large payloads are written to the blobstore instead of the database
+		config.BlobSizeLimitError = dc.GetIntPropertyFilteredByDomain(
+			dynamicconfig.PayloadOffloadBlobSizeLimitError, common.PayloadOffloadBlobSizeLimitError)
+	}
 	return &Service{
 		params: params,
 		stopC:  make(chan struct{}),
-		config: NewConfig(
-			dynamicconfig.NewCollection(params.DynamicConfig, params.Logger),
-			params.PersistenceConfig.NumHistoryShards,
-			params.ESConfig.Enable,
-		),
+		config: config,
 	}
 }
 
@@ -269,7 +279,7 @@ func (s *Service) Start() {
 		EnableSampling:                  s.config.EnableVisibilitySampling,
 		EnableReadFromClosedExecutionV2: s.config.EnableReadFromClosedExecutionV2,
 	}
-	pFactory := persistencefactory.New(&pConfig, params.ClusterMetadata.GetCurrentClusterName(), s.metricsClient, log)
+	pFactory := persistencefactory.New(&pConfig, params.BlobstoreClient, params.ClusterMetadata.GetCurrentClusterName(), s.metricsClient, log)
 
 	shardMgr, err := pFactory.NewShardManager()
 	if err != nil {
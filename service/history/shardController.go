@@ -193,6 +193,20 @@ func (c *shardController) GetEngine(workflowID string) (Engine, error) {
 	return c.getEngineForShard(shardID)
 }
 
+// closeShard stops the engine of the shard owned by this host, the shard will be
+// reloaded from persistence on next request or shard acquisition
+func (c *shardController) closeShard(shardID int) error {
+	info, err := c.hServiceResolver.Lookup(string(shardID))
+	if err != nil {
+		return err
+	}
+	if info.Identity() != c.host.Identity() {
+		return createShardOwnershipLostError(c.host.Identity(), info.GetAddress())
+	}
+	c.removeEngineForShard(shardID)
+	return nil
+}
+
 func (c *shardController) getEngineForShard(shardID int) (Engine, error) {
 	sw := c.metricsClient.StartTimer(metrics.HistoryShardControllerScope, metrics.GetEngineForShardLatency)
 	defer sw.Stop()
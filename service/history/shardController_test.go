@@ -35,6 +35,7 @@ import (
 	"time"
 
 	"github.com/uber-go/tally"
+	hist "github.com/uber/cadence/.gen/go/history"
 	"github.com/uber/cadence/client"
 	"github.com/uber/cadence/common/cache"
 	"github.com/uber/cadence/common/cluster"
@@ -443,6 +444,35 @@ func (s *shardControllerSuite) TestHistoryEngineClosed() {
 	}
 }
 
+func (s *shardControllerSuite) TestCloseShard() {
+	shardID := 1
+	mockEngine := &MockHistoryEngine{}
+	s.setupMocksForAcquireShard(shardID, mockEngine, 5, 6)
+	// when shard is initialized, it will use the 2 mock function below to initialize the "current" time of each cluster
+	s.mockClusterMetadata.On("GetCurrentClusterName").Return(cluster.TestCurrentClusterName)
+	s.mockClusterMetadata.On("GetAllClusterFailoverVersions").Return(cluster.TestSingleDCAllClusterFailoverVersions)
+
+	engine, err := s.controller.getEngineForShard(shardID)
+	s.Nil(err)
+	s.Equal(mockEngine, engine)
+	s.Equal(1, s.controller.numShards())
+
+	mockEngine.On("Stop").Return().Once()
+	s.Nil(s.controller.closeShard(shardID))
+	s.Equal(0, s.controller.numShards())
+	mockEngine.AssertExpectations(s.T())
+}
+
+func (s *shardControllerSuite) TestCloseShard_NotOwned() {
+	shardID := 1
+	differentHostInfo := membership.NewHostInfo("another-host", nil)
+	s.mockServiceResolver.On("Lookup", string(shardID)).Return(differentHostInfo, nil).Once()
+
+	err := s.controller.closeShard(shardID)
+	s.IsType(&hist.ShardOwnershipLostError{}, err)
+	s.Equal(differentHostInfo.GetAddress(), err.(*hist.ShardOwnershipLostError).GetOwner())
+}
+
 func (s *shardControllerSuite) TestRingUpdated() {
 	numShards := 4
 	s.config.NumberOfShards = numShards
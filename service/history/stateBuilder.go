@@ -123,7 +123,10 @@ func (b *stateBuilderImpl) applyEvents(domainID, requestID string, execution sha
 			}
 			b.msBuilder.ReplicateWorkflowExecutionStartedEvent(domainID, parentDomainID, execution, requestID, attributes)
 
-			b.timerTasks = append(b.timerTasks, b.scheduleWorkflowTimerTask(event, b.msBuilder))
+			b.timerTasks = append(b.timerTasks, newWorkflowTimeoutTask(event, b.msBuilder.GetExecutionInfo().WorkflowTimeout))
+			if timerTask := newWorkflowBackoffTimerTask(event); timerTask != nil {
+				b.timerTasks = append(b.timerTasks, timerTask)
+			}
 			if eventStoreVersion == persistence.EventStoreVersionV2 {
 				err := b.msBuilder.SetHistoryTree(execution.GetRunId())
 				if err != nil {
@@ -155,7 +158,7 @@ func (b *stateBuilderImpl) applyEvents(domainID, requestID string, execution sha
 		case shared.EventTypeDecisionTaskCompleted:
 			attributes := event.DecisionTaskCompletedEventAttributes
 			b.msBuilder.ReplicateDecisionTaskCompletedEvent(attributes.GetScheduledEventId(),
-				attributes.GetStartedEventId())
+				attributes.GetStartedEventId(), attributes.GetBinaryChecksum())
 
 		case shared.EventTypeDecisionTaskTimedOut:
 			attributes := event.DecisionTaskTimedOutEventAttributes
@@ -345,6 +348,15 @@ func (b *stateBuilderImpl) applyEvents(domainID, requestID string, execution sha
 		case shared.EventTypeWorkflowExecutionSignaled:
 			b.msBuilder.ReplicateWorkflowExecutionSignaled(event)
 
+		case shared.EventTypeWorkflowExecutionPaused:
+			b.msBuilder.ReplicateWorkflowExecutionPausedEvent(event)
+
+		case shared.EventTypeWorkflowExecutionUnpaused:
+			b.msBuilder.ReplicateWorkflowExecutionUnpausedEvent(event)
+			if timerTask := b.scheduleActivityTimerTask(event, b.msBuilder); timerTask != nil {
+				b.timerTasks = append(b.timerTasks, timerTask)
+			}
+
 		case shared.EventTypeWorkflowExecutionCancelRequested:
 			b.msBuilder.ReplicateWorkflowExecutionCancelRequestedEvent(event)
 
@@ -524,13 +536,38 @@ func (b *stateBuilderImpl) scheduleActivityTimerTask(event *shared.HistoryEvent,
 	return b.getTimerBuilder(event).GetActivityTimerTaskIfNeeded(msBuilder)
 }
 
-func (b *stateBuilderImpl) scheduleWorkflowTimerTask(event *shared.HistoryEvent,
-	msBuilder mutableState) persistence.Task {
+// newWorkflowTimeoutTask creates the workflow timeout timer of a workflow started by the given event
+func newWorkflowTimeoutTask(event *shared.HistoryEvent, workflowTimeout int32) persistence.Task {
 	now := time.Unix(0, event.GetTimestamp())
-	timeout := now.Add(time.Duration(msBuilder.GetExecutionInfo().WorkflowTimeout) * time.Second)
+	// the execution timeout only starts counting once the first decision is scheduled
+	backoff := time.Duration(event.WorkflowExecutionStartedEventAttributes.GetFirstDecisionTaskBackoffSeconds()) * time.Second
+	timeout := now.Add(backoff + time.Duration(workflowTimeout)*time.Second)
 	return &persistence.WorkflowTimeoutTask{VisibilityTimestamp: timeout}
 }
 
+// newWorkflowBackoffTimerTask creates the timer scheduling the first decision of a workflow started with a
+// backoff (retry, cron or delayed start), nil if the workflow was started without a backoff
+func newWorkflowBackoffTimerTask(event *shared.HistoryEvent) persistence.Task {
+	attributes := event.WorkflowExecutionStartedEventAttributes
+	backoffSeconds := attributes.GetFirstDecisionTaskBackoffSeconds()
+	if backoffSeconds <= 0 {
+		return nil
+	}
+
+	timeoutType := persistence.WorkflowBackoffTimeoutTypeDelayStart
+	switch attributes.GetInitiator() {
+	case shared.ContinueAsNewInitiatorRetryPolicy:
+		timeoutType = persistence.WorkflowBackoffTimeoutTypeRetry
+	case shared.ContinueAsNewInitiatorCronSchedule:
+		timeoutType = persistence.WorkflowBackoffTimeoutTypeCron
+	}
+	now := time.Unix(0, event.GetTimestamp())
+	return &persistence.WorkflowBackoffTimerTask{
+		VisibilityTimestamp: now.Add(time.Duration(backoffSeconds) * time.Second),
+		TimeoutType:         timeoutType,
+	}
+}
+
 func (b *stateBuilderImpl) scheduleDeleteHistoryTimerTask(event *shared.HistoryEvent, domainID, workflowID string) (persistence.Task, error) {
 	var retentionInDays int32
 	domainEntry, err := b.shard.GetDomainCache().GetDomainByID(domainID)
@@ -194,6 +194,54 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeWorkflowExecutionStarted()
 	s.Empty(s.stateBuilder.newRunTransferTasks)
 }
 
+func (s *stateBuilderSuite) TestApplyEvents_EventTypeWorkflowExecutionStarted_DelayStart() {
+	version := int64(1)
+	requestID := uuid.New()
+	domainID := validDomainID
+	execution := shared.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(validRunID),
+	}
+
+	executionInfo := &persistence.WorkflowExecutionInfo{
+		WorkflowTimeout: 100,
+	}
+	backoffSeconds := int32(30)
+
+	now := time.Now()
+	evenType := shared.EventTypeWorkflowExecutionStarted
+	event := &shared.HistoryEvent{
+		Version:   common.Int64Ptr(version),
+		EventId:   common.Int64Ptr(1),
+		Timestamp: common.Int64Ptr(now.UnixNano()),
+		EventType: &evenType,
+		WorkflowExecutionStartedEventAttributes: &shared.WorkflowExecutionStartedEventAttributes{
+			FirstDecisionTaskBackoffSeconds: common.Int32Ptr(backoffSeconds),
+		},
+	}
+
+	s.mockMutableState.On("ReplicateWorkflowExecutionStartedEvent",
+		domainID, (*string)(nil), execution, requestID, event.WorkflowExecutionStartedEventAttributes).Once()
+	s.mockUpdateVersion(event)
+	s.mockMutableState.On("GetExecutionInfo").Return(executionInfo)
+
+	s.mockMutableState.On("ClearStickyness").Once()
+	s.stateBuilder.applyEvents(domainID, requestID, execution, s.toHistory(event), nil, 0, 0)
+	s.Equal(2, len(s.stateBuilder.timerTasks))
+	timeoutTask, ok := s.stateBuilder.timerTasks[0].(*persistence.WorkflowTimeoutTask)
+	s.True(ok)
+	backoff := time.Duration(backoffSeconds) * time.Second
+	s.True(timeoutTask.VisibilityTimestamp.Equal(now.Add(backoff + time.Duration(executionInfo.WorkflowTimeout)*time.Second)))
+	backoffTask, ok := s.stateBuilder.timerTasks[1].(*persistence.WorkflowBackoffTimerTask)
+	s.True(ok)
+	s.True(backoffTask.VisibilityTimestamp.Equal(now.Add(backoff)))
+	s.Equal(persistence.WorkflowBackoffTimeoutTypeDelayStart, backoffTask.TimeoutType)
+
+	s.Empty(s.stateBuilder.transferTasks)
+	s.Empty(s.stateBuilder.newRunTimerTasks)
+	s.Empty(s.stateBuilder.newRunTransferTasks)
+}
+
 func (s *stateBuilderSuite) TestApplyEvents_EventTypeWorkflowExecutionTimedOut() {
 	version := int64(1)
 	requestID := uuid.New()
@@ -1677,9 +1725,10 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeDecisionTaskCompleted() {
 		DecisionTaskCompletedEventAttributes: &shared.DecisionTaskCompletedEventAttributes{
 			ScheduledEventId: common.Int64Ptr(scheduleID),
 			StartedEventId:   common.Int64Ptr(startedID),
+			BinaryChecksum:   common.StringPtr("some random binary checksum"),
 		},
 	}
-	s.mockMutableState.On("ReplicateDecisionTaskCompletedEvent", scheduleID, startedID).Once()
+	s.mockMutableState.On("ReplicateDecisionTaskCompletedEvent", scheduleID, startedID, "some random binary checksum").Once()
 	s.mockUpdateVersion(event)
 
 	s.mockMutableState.On("ClearStickyness").Once()
@@ -2076,6 +2125,7 @@ func (s *stateBuilderSuite) TestApplyEvents_EventTypeActivityTaskScheduled() {
 	}
 	s.mockMutableState.On("GetExecutionInfo").Return(executionInfo)
 	s.mockMutableState.On("GetPendingActivityInfos").Return(map[int64]*persistence.ActivityInfo{event.GetEventId(): ai})
+	s.mockMutableState.On("IsPaused").Return(false)
 	s.mockMutableState.On("UpdateActivity", ai).Return(nil).Once()
 	s.mockMutableState.On("ReplicateActivityTaskScheduledEvent", event.GetEventId(), event).Return(ai).Once()
 	s.mockUpdateVersion(event)
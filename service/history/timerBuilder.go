@@ -278,6 +278,10 @@ func (tb *timerBuilder) loadActivityTimers(msBuilder mutableState) {
 	tb.pendingActivityTimers = msBuilder.GetPendingActivityInfos()
 	tb.activityTimers = make(timers, 0, len(tb.pendingActivityTimers))
 	for _, v := range tb.pendingActivityTimers {
+		if v.StartedID == common.EmptyEventID && msBuilder.IsPaused() {
+			// activities held back by a pause do not time out, their timers are created again on unpause
+			continue
+		}
 		if v.ScheduleID != common.EmptyEventID {
 			scheduleToCloseExpiry := v.ScheduledTime.Add(time.Duration(v.ScheduleToCloseTimeout) * time.Second)
 			if !v.ExpirationTime.IsZero() && v.ExpirationTime.Before(scheduleToCloseExpiry) {
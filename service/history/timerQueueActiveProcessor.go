@@ -280,6 +280,10 @@ Update_History_Loop:
 		} else if msBuilder == nil || !msBuilder.IsWorkflowExecutionRunning() {
 			return nil
 		}
+		if msBuilder.IsPaused() {
+			// expired timers are fired when the workflow is unpaused
+			return nil
+		}
 		tBuilder := t.historyService.getTimerBuilder(context.getExecution())
 
 		var timerTasks []persistence.Task
@@ -535,6 +539,10 @@ Update_History_Loop:
 			t.metricsClient.IncCounter(metrics.TimerActiveTaskDecisionTimeoutScope, metrics.ScheduleToStartTimeoutCounter)
 			// decision schedule to start timeout only apply to sticky decision
 			// check if scheduled decision still pending and not started yet
+			if msBuilder.IsPaused() {
+				// the decision is held back by the pause, its timeout is created again on unpause
+				return nil
+			}
 			if di.Attempt == task.ScheduleAttempt && di.StartedID == common.EmptyEventID && msBuilder.IsStickyTaskListEnabled() {
 				timeoutEvent := msBuilder.AddDecisionTaskScheduleToStartTimeoutEvent(scheduleID)
 				if timeoutEvent == nil {
@@ -573,9 +581,12 @@ func (t *timerQueueActiveProcessorImpl) processWorkflowBackoffTimer(task *persis
 	}
 	defer func() { release(retError) }()
 
-	if task.TimeoutType == persistence.WorkflowBackoffTimeoutTypeRetry {
+	switch task.TimeoutType {
+	case persistence.WorkflowBackoffTimeoutTypeRetry:
 		t.metricsClient.IncCounter(metrics.TimerActiveTaskWorkflowBackoffTimerScope, metrics.WorkflowRetryBackoffTimerCount)
-	} else {
+	case persistence.WorkflowBackoffTimeoutTypeDelayStart:
+		t.metricsClient.IncCounter(metrics.TimerActiveTaskWorkflowBackoffTimerScope, metrics.WorkflowDelayStartBackoffTimerCount)
+	default:
 		t.metricsClient.IncCounter(metrics.TimerActiveTaskWorkflowBackoffTimerScope, metrics.WorkflowCronBackoffTimerCount)
 	}
 
@@ -622,6 +633,11 @@ func (t *timerQueueActiveProcessorImpl) processActivityRetryTimer(task *persiste
 			return nil
 		}
 
+		if msBuilder.IsPaused() {
+			// activity is dispatched again when the workflow is unpaused
+			return nil
+		}
+
 		// generate activity task
 		scheduledID := task.EventID
 		ai, running := msBuilder.GetActivityInfo(scheduledID)
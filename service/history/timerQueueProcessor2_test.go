@@ -152,6 +152,7 @@ func (s *timerQueueProcessor2Suite) SetupTest() {
 		logger:             s.logger,
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
 		metricsClient:      s.mockShard.GetMetricsClient(),
+		queryRegistry:      newQueryRegistry(),
 	}
 	h.txProcessor = newTransferQueueProcessor(s.mockShard, h, s.mockVisibilityMgr, s.mockProducer, s.mockMatchingClient, &mocks.HistoryClient{}, s.logger)
 	h.timerProcessor = newTimerQueueProcessor(s.mockShard, h, s.mockMatchingClient, s.mockProducer, s.logger)
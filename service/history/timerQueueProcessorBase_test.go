@@ -118,6 +118,7 @@ func (s *timerQueueProcessorBaseSuite) SetupTest() {
 			shard:         s.mockShard,
 			logger:        s.logger,
 			metricsClient: metricsClient,
+			queryRegistry: newQueryRegistry(),
 		},
 		s.mockQueueAckMgr,
 		NewLocalTimerGate(),
@@ -100,6 +100,7 @@ func (s *timerQueueProcessorSuite) SetupTest() {
 		logger:             s.logger,
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
 		metricsClient:      metrics.NewClient(tally.NoopScope, metrics.History),
+		queryRegistry:      newQueryRegistry(),
 	}
 	s.engineImpl.txProcessor = newTransferQueueProcessor(
 		s.ShardContext, s.engineImpl, s.mockVisibilityMgr, &mocks.KafkaProducer{}, &mocks.MatchingClient{}, &mocks.HistoryClient{}, s.logger,
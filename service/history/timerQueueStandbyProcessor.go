@@ -354,6 +354,11 @@ func (t *timerQueueStandbyProcessorImpl) processDecisionTimeout(timerTask *persi
 			return nil
 		}
 
+		if msBuilder.IsPaused() && timerTask.TimeoutType == int(workflow.TimeoutTypeScheduleToStart) {
+			// active cluster skips the timeout of a decision held back by a pause
+			return nil
+		}
+
 		ok, err := verifyTaskVersion(t.shard, t.logger, timerTask.DomainID, di.Version, timerTask.Version, timerTask)
 		if err != nil {
 			return err
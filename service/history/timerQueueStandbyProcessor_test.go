@@ -149,6 +149,7 @@ func (s *timerQueueStandbyProcessorSuite) SetupTest() {
 		logger:             s.logger,
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
 		metricsClient:      s.mockShard.GetMetricsClient(),
+		queryRegistry:      newQueryRegistry(),
 	}
 	s.mockHistoryEngine = h
 	s.clusterName = cluster.TestAlternativeClusterName
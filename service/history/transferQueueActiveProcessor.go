@@ -26,6 +26,7 @@ import (
 	"github.com/pborman/uuid"
 	"github.com/uber-common/bark"
 	h "github.com/uber/cadence/.gen/go/history"
+	m "github.com/uber/cadence/.gen/go/matching"
 	workflow "github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/client/history"
 	"github.com/uber/cadence/client/matching"
@@ -213,6 +214,12 @@ func (t *transferQueueActiveProcessorImpl) process(qTask queueTaskInfo, shouldPr
 		}
 		return metrics.TransferActiveTaskActivityScope, err
 
+	case persistence.TransferTaskTypeActivityCancellation:
+		if shouldProcessTask {
+			err = t.processActivityCancellation(task)
+		}
+		return metrics.TransferActiveTaskActivityCancellationScope, err
+
 	case persistence.TransferTaskTypeDecisionTask:
 		if shouldProcessTask {
 			err = t.processDecisionTask(task)
@@ -281,11 +288,79 @@ func (t *transferQueueActiveProcessorImpl) processActivityTask(task *persistence
 		return nil
 	}
 
+	if msBuilder.IsPaused() {
+		// activity is dispatched again when the workflow is unpaused
+		return nil
+	}
+
 	timeout := common.MinInt32(ai.ScheduleToStartTimeout, common.MaxTaskTimeout)
+	priority := ai.Priority
 	// release the context lock since we no longer need mutable state builder and
 	// the rest of logic is making RPC call, which takes time.
 	release(nil)
-	return t.pushActivity(task, timeout)
+	return t.pushActivity(task, timeout, priority)
+}
+
+func (t *transferQueueActiveProcessorImpl) processActivityCancellation(task *persistence.TransferTaskInfo) (retError error) {
+
+	var err error
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr(task.WorkflowID),
+		RunId:      common.StringPtr(task.RunID)}
+
+	context, release, err := t.cache.getOrCreateWorkflowExecution(task.DomainID, execution)
+	if err != nil {
+		return err
+	}
+	defer func() { release(retError) }()
+
+	var msBuilder mutableState
+	msBuilder, err = loadMutableStateForTransferTask(context, task, t.metricsClient, t.logger)
+	if err != nil {
+		return err
+	} else if msBuilder == nil || !msBuilder.IsWorkflowExecutionRunning() {
+		return nil
+	}
+
+	ai, found := msBuilder.GetActivityInfo(task.ScheduleID)
+	if !found {
+		logging.LogDuplicateTransferTaskEvent(t.logger, persistence.TransferTaskTypeActivityCancellation, task.TaskID, task.ScheduleID)
+		return nil
+	}
+	ok, err := verifyTaskVersion(t.shard, t.logger, task.DomainID, ai.Version, task.Version, task)
+	if err != nil {
+		return err
+	} else if !ok {
+		return nil
+	}
+
+	if ai.StartedID == common.EmptyEventID || !ai.CancelRequested {
+		// the activity was retried or its cancellation already handled, nothing to notify
+		return nil
+	}
+
+	token, err := t.historyService.tokenSerializer.Serialize(&common.TaskToken{
+		DomainID:        task.DomainID,
+		WorkflowID:      task.WorkflowID,
+		RunID:           task.RunID,
+		ScheduleID:      ai.ScheduleID,
+		ScheduleAttempt: int64(ai.Attempt),
+	})
+	if err != nil {
+		return err
+	}
+	request := &m.AddActivityCancellationRequest{
+		DomainUUID: common.StringPtr(task.TargetDomainID),
+		Execution:  &execution,
+		TaskList:   &workflow.TaskList{Name: common.StringPtr(task.TaskList)},
+		Identity:   common.StringPtr(ai.StartedIdentity),
+		TaskToken:  token,
+		ActivityId: common.StringPtr(ai.ActivityID),
+	}
+	// release the context lock since we no longer need mutable state builder and
+	// the rest of logic is making RPC call, which takes time.
+	release(nil)
+	return t.matchingClient.AddActivityCancellation(nil, request)
 }
 
 func (t *transferQueueActiveProcessorImpl) processDecisionTask(task *persistence.TransferTaskInfo) (retError error) {
@@ -330,6 +405,9 @@ func (t *transferQueueActiveProcessorImpl) processDecisionTask(task *persistence
 	decisionTimeout := common.MinInt32(workflowTimeout, common.MaxTaskTimeout)
 	wfTypeName := executionInfo.WorkflowTypeName
 	startTimestamp := executionInfo.StartTimestamp
+	paused := executionInfo.Paused
+	priority := executionInfo.Priority
+	buildID := executionInfo.BuildID
 
 	// NOTE: previously this section check whether mutable state has enabled
 	// sticky decision, if so convert the decision to a sticky decision.
@@ -353,7 +431,12 @@ func (t *transferQueueActiveProcessorImpl) processDecisionTask(task *persistence
 		}
 	}
 
-	return t.pushDecision(task, tasklist, decisionTimeout)
+	if paused {
+		// decision is dispatched again when the workflow is unpaused
+		return nil
+	}
+
+	return t.pushDecision(task, tasklist, decisionTimeout, priority, buildID)
 }
 
 func (t *transferQueueActiveProcessorImpl) processCloseExecution(task *persistence.TransferTaskInfo) (retError error) {
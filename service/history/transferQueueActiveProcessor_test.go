@@ -156,6 +156,7 @@ func (s *transferQueueActiveProcessorSuite) SetupTest() {
 		metricsClient:      s.mockShard.GetMetricsClient(),
 		txProcessor:        s.mockTransferQueueProcessor,
 		timerProcessor:     s.mockTimerQueueProcessor,
+		queryRegistry:      newQueryRegistry(),
 	}
 	s.mockHistoryEngine = h
 	s.transferQueueActiveProcessor = newTransferQueueActiveProcessor(s.mockShard, h, s.mockVisibilityMgr, s.mockProducer, s.mockMatchingClient, s.mockHistoryClient, newTaskAllocator(s.mockShard), s.logger)
@@ -293,6 +294,87 @@ func (s *transferQueueActiveProcessorSuite) TestProcessActivityTask_Duplication(
 	s.Nil(err)
 }
 
+func (s *transferQueueActiveProcessorSuite) TestProcessActivityCancellation_Success() {
+	domainID := "some random domain ID"
+	targetDomainID := "some random target domain ID"
+	execution := workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("some random workflow ID"),
+		RunId:      common.StringPtr(uuid.New()),
+	}
+	workflowType := "some random workflow type"
+	taskListName := "some random task list"
+	identity := "some random identity"
+
+	msBuilder := newMutableStateBuilderWithReplicationState(s.mockClusterMetadata.GetCurrentClusterName(),
+		s.mockShard.GetConfig(), s.mockShard.GetEventsCache(), s.logger, s.version)
+	msBuilder.AddWorkflowExecutionStartedEvent(
+		execution,
+		&history.StartWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(domainID),
+			StartRequest: &workflow.StartWorkflowExecutionRequest{
+				WorkflowType:                        &workflow.WorkflowType{Name: common.StringPtr(workflowType)},
+				TaskList:                            &workflow.TaskList{Name: common.StringPtr(taskListName)},
+				ExecutionStartToCloseTimeoutSeconds: common.Int32Ptr(2),
+				TaskStartToCloseTimeoutSeconds:      common.Int32Ptr(1),
+			},
+		},
+	)
+
+	di := addDecisionTaskScheduledEvent(msBuilder)
+	event := addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, di.StartedID, nil, "some random identity")
+
+	taskID := int64(59)
+	activityID := "activity-1"
+	activityType := "some random activity type"
+	event, ai := addActivityTaskScheduledEvent(msBuilder, event.GetEventId(), activityID, activityType, taskListName, []byte{}, 1, 1, 1)
+	scheduleID := event.GetEventId()
+	addActivityTaskStartedEvent(msBuilder, scheduleID, taskListName, identity)
+
+	di = addDecisionTaskScheduledEvent(msBuilder)
+	event = addDecisionTaskStartedEvent(msBuilder, di.ScheduleID, taskListName, uuid.New())
+	di.StartedID = event.GetEventId()
+	event = addDecisionTaskCompletedEvent(msBuilder, di.ScheduleID, di.StartedID, nil, "some random identity")
+	event, _, _ = msBuilder.AddActivityTaskCancelRequestedEvent(event.GetEventId(), activityID, "some random identity")
+	msBuilder.UpdateReplicationStateLastEventID(s.mockClusterMetadata.GetCurrentClusterName(), s.version, event.GetEventId())
+
+	transferTask := &persistence.TransferTaskInfo{
+		Version:        s.version,
+		DomainID:       domainID,
+		TargetDomainID: targetDomainID,
+		WorkflowID:     execution.GetWorkflowId(),
+		RunID:          execution.GetRunId(),
+		TaskID:         taskID,
+		TaskList:       taskListName,
+		TaskType:       persistence.TransferTaskTypeActivityCancellation,
+		ScheduleID:     scheduleID,
+	}
+
+	token, err := common.NewJSONTaskTokenSerializer().Serialize(&common.TaskToken{
+		DomainID:        domainID,
+		WorkflowID:      execution.GetWorkflowId(),
+		RunID:           execution.GetRunId(),
+		ScheduleID:      scheduleID,
+		ScheduleAttempt: int64(ai.Attempt),
+	})
+	s.NoError(err)
+
+	persistenceMutableState := createMutableState(msBuilder)
+	s.mockExecutionMgr.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{State: persistenceMutableState}, nil)
+	s.mockMatchingClient.On("AddActivityCancellation", nil, &matching.AddActivityCancellationRequest{
+		DomainUUID: common.StringPtr(targetDomainID),
+		Execution:  &execution,
+		TaskList:   &workflow.TaskList{Name: common.StringPtr(taskListName)},
+		Identity:   common.StringPtr(identity),
+		TaskToken:  token,
+		ActivityId: common.StringPtr(activityID),
+	}).Once().Return(nil)
+
+	_, err = s.transferQueueActiveProcessor.process(transferTask, true)
+	s.Nil(err)
+}
+
 func (s *transferQueueActiveProcessorSuite) TestProcessDecisionTask_FirstDecision() {
 	domainID := "some random domain ID"
 	execution := workflow.WorkflowExecution{
@@ -1462,6 +1544,7 @@ func (s *transferQueueActiveProcessorSuite) createAddActivityTaskRequest(task *p
 		TaskList:                      taskList,
 		ScheduleId:                    &task.ScheduleID,
 		ScheduleToStartTimeoutSeconds: common.Int32Ptr(ai.ScheduleToStartTimeout),
+		Priority:                      common.Int32Ptr(ai.Priority),
 	}
 }
 
@@ -1485,6 +1568,8 @@ func (s *transferQueueActiveProcessorSuite) createAddDecisionTaskRequest(task *p
 		TaskList:                      taskList,
 		ScheduleId:                    common.Int64Ptr(task.ScheduleID),
 		ScheduleToStartTimeoutSeconds: common.Int32Ptr(timeout),
+		Priority:                      common.Int32Ptr(executionInfo.Priority),
+		BuildID:                       common.StringPtr(executionInfo.BuildID),
 	}
 }
 
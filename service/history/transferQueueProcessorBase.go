@@ -100,7 +100,7 @@ func (t *transferQueueProcessorBase) queueShutdown() error {
 	return t.transferQueueShutdown()
 }
 
-func (t *transferQueueProcessorBase) pushActivity(task *persistence.TransferTaskInfo, activityScheduleToStartTimeout int32) error {
+func (t *transferQueueProcessorBase) pushActivity(task *persistence.TransferTaskInfo, activityScheduleToStartTimeout int32, priority int32) error {
 	if task.TaskType != persistence.TransferTaskTypeActivityTask {
 		t.logger.WithField(logging.TagTaskType, task.GetTaskType()).Fatal("Cannot process non activity task")
 	}
@@ -115,12 +115,13 @@ func (t *transferQueueProcessorBase) pushActivity(task *persistence.TransferTask
 		TaskList:                      &workflow.TaskList{Name: &task.TaskList},
 		ScheduleId:                    &task.ScheduleID,
 		ScheduleToStartTimeoutSeconds: common.Int32Ptr(activityScheduleToStartTimeout),
+		Priority:                      common.Int32Ptr(priority),
 	})
 
 	return err
 }
 
-func (t *transferQueueProcessorBase) pushDecision(task *persistence.TransferTaskInfo, tasklist *workflow.TaskList, decisionScheduleToStartTimeout int32) error {
+func (t *transferQueueProcessorBase) pushDecision(task *persistence.TransferTaskInfo, tasklist *workflow.TaskList, decisionScheduleToStartTimeout int32, priority int32, buildID string) error {
 	if task.TaskType != persistence.TransferTaskTypeDecisionTask {
 		t.logger.WithField(logging.TagTaskType, task.GetTaskType()).Fatal("Cannot process non decision task")
 	}
@@ -134,6 +135,8 @@ func (t *transferQueueProcessorBase) pushDecision(task *persistence.TransferTask
 		TaskList:                      tasklist,
 		ScheduleId:                    common.Int64Ptr(task.ScheduleID),
 		ScheduleToStartTimeoutSeconds: common.Int32Ptr(decisionScheduleToStartTimeout),
+		Priority:                      common.Int32Ptr(priority),
+		BuildID:                       common.StringPtr(buildID),
 	})
 
 	return err
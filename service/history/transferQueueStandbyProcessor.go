@@ -136,6 +136,11 @@ func (t *transferQueueStandbyProcessorImpl) process(qTask queueTaskInfo, shouldP
 		}
 		return metrics.TransferStandbyTaskActivityScope, err
 
+	case persistence.TransferTaskTypeActivityCancellation:
+		// workers are only notified by the active cluster, the cancellation is also
+		// delivered through the heartbeat response
+		return metrics.TransferStandbyTaskActivityCancellationScope, nil
+
 	case persistence.TransferTaskTypeDecisionTask:
 		if shouldProcessTask {
 			err = t.processDecisionTask(task)
@@ -173,6 +178,7 @@ func (t *transferQueueStandbyProcessorImpl) process(qTask queueTaskInfo, shouldP
 func (t *transferQueueStandbyProcessorImpl) processActivityTask(transferTask *persistence.TransferTaskInfo) error {
 
 	var activityScheduleToStartTimeout *int32
+	var priority int32
 	processTaskIfClosed := false
 	return t.processTransfer(processTaskIfClosed, transferTask, func(msBuilder mutableState) error {
 		activityInfo, isPending := msBuilder.GetActivityInfo(transferTask.ScheduleID)
@@ -195,6 +201,7 @@ func (t *transferQueueStandbyProcessorImpl) processActivityTask(transferTask *pe
 			}
 
 			activityScheduleToStartTimeout = common.Int32Ptr(common.MinInt32(activityInfo.ScheduleToStartTimeout, common.MaxTaskTimeout))
+			priority = activityInfo.Priority
 			return nil
 		}
 
@@ -205,7 +212,7 @@ func (t *transferQueueStandbyProcessorImpl) processActivityTask(transferTask *pe
 		}
 
 		timeout := common.MinInt32(*activityScheduleToStartTimeout, common.MaxTaskTimeout)
-		err := t.pushActivity(transferTask, timeout)
+		err := t.pushActivity(transferTask, timeout, priority)
 		return err
 	})
 }
@@ -213,6 +220,8 @@ func (t *transferQueueStandbyProcessorImpl) processActivityTask(transferTask *pe
 func (t *transferQueueStandbyProcessorImpl) processDecisionTask(transferTask *persistence.TransferTaskInfo) error {
 	var decisionScheduleToStartTimeout *int32
 	var tasklist *workflow.TaskList
+	var priority int32
+	var buildID string
 	processTaskIfClosed := false
 
 	execution := workflow.WorkflowExecution{
@@ -261,6 +270,8 @@ func (t *transferQueueStandbyProcessorImpl) processDecisionTask(transferTask *pe
 
 			decisionScheduleToStartTimeout = common.Int32Ptr(decisionTimeout)
 			tasklist = &workflow.TaskList{Name: &transferTask.TaskList}
+			priority = executionInfo.Priority
+			buildID = executionInfo.BuildID
 			return nil
 		}
 
@@ -271,7 +282,7 @@ func (t *transferQueueStandbyProcessorImpl) processDecisionTask(transferTask *pe
 		}
 
 		timeout := common.MinInt32(*decisionScheduleToStartTimeout, common.MaxTaskTimeout)
-		err := t.pushDecision(transferTask, tasklist, timeout)
+		err := t.pushDecision(transferTask, tasklist, timeout, priority, buildID)
 		return err
 	})
 }
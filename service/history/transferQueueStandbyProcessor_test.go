@@ -151,6 +151,7 @@ func (s *transferQueueStandbyProcessorSuite) SetupTest() {
 		logger:             s.logger,
 		tokenSerializer:    common.NewJSONTaskTokenSerializer(),
 		metricsClient:      s.mockShard.GetMetricsClient(),
+		queryRegistry:      newQueryRegistry(),
 	}
 	s.mockHistoryEngine = h
 	s.clusterName = cluster.TestAlternativeClusterName
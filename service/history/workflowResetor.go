@@ -404,7 +404,7 @@ func (w *workflowResetorImpl) terminateIfCurrIsRunning(currMutableState mutableS
 }
 
 func historyGarbageCleanupInfo(domainID, workflowID, runID string) string {
-	return fmt.Sprintf("%v:%v:%v", domainID, workflowID, runID)
+	return persistence.BuildHistoryGarbageCleanupInfo(domainID, workflowID, runID)
 }
 
 func (w *workflowResetorImpl) setEventIDsWithHistory(msBuilder mutableState) int64 {
@@ -36,17 +36,34 @@ type ackManager struct {
 }
 
 // Registers task as in-flight and moves read level to it. Tasks can be added in increasing order of taskID only.
-func (m *ackManager) addTask(taskID int64) {
+// Returns false if the task was already registered by addReadAheadTask.
+func (m *ackManager) addTask(taskID int64) bool {
 	if m.readLevel >= taskID {
 		m.logger.Fatalf("Next task ID is less than current read level.  TaskID: %v, ReadLevel: %v", taskID,
 			m.readLevel)
 	}
 	m.readLevel = taskID
 	if _, ok := m.outstandingTasks[taskID]; ok {
-		m.logger.Fatalf("Already present in outstanding tasks: taskID=%v", taskID)
+		// task was read ahead of the read level by its priority
+		return false
 	}
 	m.outstandingTasks[taskID] = false // true is for acked
 	m.backlogCounter.Inc()
+	return true
+}
+
+// Registers task read ahead of the read level as in-flight without moving the read level.
+// Returns false if the task is already registered.
+func (m *ackManager) addReadAheadTask(taskID int64) bool {
+	if m.readLevel >= taskID {
+		return false
+	}
+	if _, ok := m.outstandingTasks[taskID]; ok {
+		return false
+	}
+	m.outstandingTasks[taskID] = false // true is for acked
+	m.backlogCounter.Inc()
+	return true
 }
 
 func newAckManager(logger bark.Logger) ackManager {
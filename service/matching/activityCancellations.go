@@ -0,0 +1,172 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+// Cancellations of started activities are pushed by history to the matching host owning the activity task list,
+// and handed out to the worker which started the activity the next time it polls for cancellations. They are only
+// kept in memory: the heartbeat response still reports the cancellation, so a cancellation lost on a host restart
+// only delays the worker until its next heartbeat. Cancellations are only buffered for workers which polled for
+// cancellations recently, as other workers rely on heartbeats anyway.
+const (
+	activityCancellationPollerTTL    = 5 * time.Minute
+	maxBufferedActivityCancellations = 1000
+)
+
+var (
+	// emptyPollForActivityCancellationResponse is the response when there are no cancellations to hand out
+	emptyPollForActivityCancellationResponse = &workflow.PollForActivityCancellationResponse{}
+)
+
+type (
+	activityCancellationPollerKey struct {
+		domainID     string
+		taskListName string
+		identity     string
+	}
+
+	activityCancellationPoller struct {
+		cancellations []*workflow.PollForActivityCancellationResponse
+		// notifyC is signaled when cancellations are added
+		notifyC      chan struct{}
+		polls        int
+		lastPollTime time.Time
+	}
+
+	activityCancellations struct {
+		sync.Mutex
+		pollers   map[activityCancellationPollerKey]*activityCancellationPoller
+		lastPurge time.Time
+	}
+)
+
+func newActivityCancellations() *activityCancellations {
+	return &activityCancellations{
+		pollers:   make(map[activityCancellationPollerKey]*activityCancellationPoller),
+		lastPurge: time.Now(),
+	}
+}
+
+func (p *activityCancellationPoller) isExpired(now time.Time) bool {
+	return p.polls == 0 && now.Sub(p.lastPollTime) > activityCancellationPollerTTL
+}
+
+func (p *activityCancellationPoller) notify() {
+	select {
+	case p.notifyC <- struct{}{}:
+	default:
+	}
+}
+
+// add buffers the cancellation for the poller, returns false if the poller did not poll for cancellations recently
+func (c *activityCancellations) add(key activityCancellationPollerKey,
+	cancellation *workflow.PollForActivityCancellationResponse) bool {
+	c.Lock()
+	defer c.Unlock()
+
+	poller, ok := c.pollers[key]
+	if !ok || poller.isExpired(time.Now()) {
+		return false
+	}
+	if len(poller.cancellations) >= maxBufferedActivityCancellations {
+		poller.cancellations = poller.cancellations[1:]
+	}
+	poller.cancellations = append(poller.cancellations, cancellation)
+	poller.notify()
+	return true
+}
+
+// poll returns the next cancellation buffered for the poller, waiting up to the given timeout for one to be added
+func (c *activityCancellations) poll(ctx context.Context, key activityCancellationPollerKey,
+	timeout time.Duration) *workflow.PollForActivityCancellationResponse {
+	poller := c.startPoll(key)
+	defer c.endPoll(poller)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		if cancellation := c.next(poller); cancellation != nil {
+			return cancellation
+		}
+		select {
+		case <-poller.notifyC:
+		case <-timer.C:
+			return emptyPollForActivityCancellationResponse
+		case <-ctx.Done():
+			return emptyPollForActivityCancellationResponse
+		}
+	}
+}
+
+func (c *activityCancellations) startPoll(key activityCancellationPollerKey) *activityCancellationPoller {
+	c.Lock()
+	defer c.Unlock()
+
+	now := time.Now()
+	if now.Sub(c.lastPurge) > activityCancellationPollerTTL {
+		for k, p := range c.pollers {
+			if p.isExpired(now) {
+				delete(c.pollers, k)
+			}
+		}
+		c.lastPurge = now
+	}
+
+	poller, ok := c.pollers[key]
+	if !ok || poller.isExpired(now) {
+		poller = &activityCancellationPoller{notifyC: make(chan struct{}, 1)}
+		c.pollers[key] = poller
+	}
+	poller.polls++
+	poller.lastPollTime = now
+	return poller
+}
+
+func (c *activityCancellations) endPoll(poller *activityCancellationPoller) {
+	c.Lock()
+	defer c.Unlock()
+
+	poller.polls--
+	poller.lastPollTime = time.Now()
+}
+
+func (c *activityCancellations) next(poller *activityCancellationPoller) *workflow.PollForActivityCancellationResponse {
+	c.Lock()
+	defer c.Unlock()
+
+	if len(poller.cancellations) == 0 {
+		return nil
+	}
+	cancellation := poller.cancellations[0]
+	poller.cancellations = poller.cancellations[1:]
+	if len(poller.cancellations) > 0 {
+		// wake up other polls of the same worker
+		poller.notify()
+	}
+	return cancellation
+}
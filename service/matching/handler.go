@@ -111,6 +111,20 @@ func (h *Handler) startRequestProfile(api string, scope int) tally.Stopwatch {
 	return sw
 }
 
+// AddActivityCancellation - adds the cancellation of a started activity.
+func (h *Handler) AddActivityCancellation(ctx context.Context, addRequest *m.AddActivityCancellationRequest) error {
+	scope := metrics.MatchingAddActivityCancellationScope
+	sw := h.startRequestProfile("AddActivityCancellation", scope)
+	defer sw.Stop()
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return h.handleErr(errMatchingHostThrottle, scope)
+	}
+
+	err := h.engine.AddActivityCancellation(addRequest)
+	return h.handleErr(err, scope)
+}
+
 // AddActivityTask - adds an activity task.
 func (h *Handler) AddActivityTask(ctx context.Context, addRequest *m.AddActivityTaskRequest) error {
 	startT := time.Now()
@@ -148,6 +162,22 @@ func (h *Handler) AddDecisionTask(ctx context.Context, addRequest *m.AddDecision
 	return h.handleErr(err, scope)
 }
 
+// PollForActivityCancellation - long poll for the cancellation of an activity started by the worker.
+func (h *Handler) PollForActivityCancellation(ctx context.Context,
+	pollRequest *m.PollForActivityCancellationRequest) (*gen.PollForActivityCancellationResponse, error) {
+
+	scope := metrics.MatchingPollForActivityCancellationScope
+	sw := h.startRequestProfile("PollForActivityCancellation", scope)
+	defer sw.Stop()
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return nil, h.handleErr(errMatchingHostThrottle, scope)
+	}
+
+	response, err := h.engine.PollForActivityCancellation(ctx, pollRequest)
+	return response, h.handleErr(err, scope)
+}
+
 // PollForActivityTask - long poll for an activity task.
 func (h *Handler) PollForActivityTask(ctx context.Context,
 	pollRequest *m.PollForActivityTaskRequest) (*gen.PollForActivityTaskResponse, error) {
@@ -237,6 +267,20 @@ func (h *Handler) DescribeTaskList(ctx context.Context, request *m.DescribeTaskL
 	return response, h.handleErr(err, scope)
 }
 
+// PromoteTaskListBuildID makes a worker build the default one for new workflows on a decision tasklist
+func (h *Handler) PromoteTaskListBuildID(ctx context.Context, request *m.PromoteTaskListBuildIDRequest) error {
+	scope := metrics.MatchingPromoteTaskListBuildIDScope
+	sw := h.startRequestProfile("PromoteTaskListBuildID", scope)
+	defer sw.Stop()
+
+	if ok, _ := h.rateLimiter.TryConsume(1); !ok {
+		return h.handleErr(errMatchingHostThrottle, scope)
+	}
+
+	err := h.engine.PromoteTaskListBuildID(ctx, request)
+	return h.handleErr(err, scope)
+}
+
 func (h *Handler) handleErr(err error, scope int) error {
 
 	if err == nil {
@@ -24,6 +24,7 @@ import (
 	"context"
 	"errors"
 	"math"
+	"strings"
 	"sync"
 
 	h "github.com/uber/cadence/.gen/go/history"
@@ -59,6 +60,8 @@ type matchingEngineImpl struct {
 	// unblock QueryWorkflow() call.
 	queryTaskMap map[string]chan *queryResult
 	domainCache  cache.DomainCache
+	// cancellations of started activities waiting for the workers running them to poll
+	activityCancellations *activityCancellations
 }
 
 type taskListID struct {
@@ -69,6 +72,7 @@ type taskListID struct {
 
 type pollerIDCtxKey string
 type identityCtxKey string
+type binaryChecksumCtxKey string
 
 var (
 	// EmptyPollForDecisionTaskResponse is the response when there are no decision tasks to hand out
@@ -82,8 +86,9 @@ var (
 	ErrNoTasks    = errors.New("No tasks")
 	errPumpClosed = errors.New("Task list pump closed its channel")
 
-	pollerIDKey pollerIDCtxKey = "pollerID"
-	identityKey identityCtxKey = "identity"
+	pollerIDKey       pollerIDCtxKey       = "pollerID"
+	identityKey       identityCtxKey       = "identity"
+	binaryChecksumKey binaryChecksumCtxKey = "binaryChecksum"
 )
 
 const (
@@ -127,6 +132,8 @@ func NewEngine(taskManager persistence.TaskManager,
 		config:        config,
 		queryTaskMap:  make(map[string]chan *queryResult),
 		domainCache:   domainCache,
+
+		activityCancellations: newActivityCancellations(),
 	}
 }
 
@@ -228,12 +235,19 @@ func (e *matchingEngineImpl) AddDecisionTask(addRequest *m.AddDecisionTaskReques
 	if err != nil {
 		return false, err
 	}
+	tlMgr, err = e.getBuildSetTaskListManager(tlMgr, taskList, taskListKind, func(buildSets [][]string) string {
+		return taskBuildSetID(buildSets, addRequest.GetBuildID())
+	})
+	if err != nil {
+		return false, err
+	}
 	taskInfo := &persistence.TaskInfo{
 		DomainID:               domainID,
 		RunID:                  addRequest.Execution.GetRunId(),
 		WorkflowID:             addRequest.Execution.GetWorkflowId(),
 		ScheduleID:             addRequest.GetScheduleId(),
 		ScheduleToStartTimeout: addRequest.GetScheduleToStartTimeoutSeconds(),
+		Priority:               addRequest.GetPriority(),
 	}
 	return tlMgr.AddTask(addRequest.Execution, taskInfo)
 }
@@ -257,6 +271,7 @@ func (e *matchingEngineImpl) AddActivityTask(addRequest *m.AddActivityTaskReques
 		WorkflowID:             addRequest.Execution.GetWorkflowId(),
 		ScheduleID:             addRequest.GetScheduleId(),
 		ScheduleToStartTimeout: addRequest.GetScheduleToStartTimeoutSeconds(),
+		Priority:               addRequest.GetPriority(),
 	}
 	return tlMgr.AddTask(addRequest.Execution, taskInfo)
 }
@@ -281,9 +296,10 @@ pollLoop:
 		// long-poll when frontend calls CancelOutstandingPoll API
 		pollerCtx := context.WithValue(ctx, pollerIDKey, pollerID)
 		pollerCtx = context.WithValue(pollerCtx, identityKey, request.GetIdentity())
+		pollerCtx = context.WithValue(pollerCtx, binaryChecksumKey, request.GetBinaryChecksum())
 		taskList := newTaskListID(domainID, taskListName, persistence.TaskListTypeDecision)
 		taskListKind := common.TaskListKindPtr(request.TaskList.GetKind())
-		tCtx, err := e.getTask(pollerCtx, taskList, nil, taskListKind)
+		tCtx, err := e.getDecisionTask(pollerCtx, taskList, taskListKind, request.GetBinaryChecksum())
 		if err != nil {
 			// TODO: Is empty poll the best reply for errPumpClosed?
 			if err == ErrNoTasks || err == errPumpClosed {
@@ -363,6 +379,48 @@ pollLoop:
 	}
 }
 
+// AddActivityCancellation buffers the cancellation of a started activity for the worker running it
+func (e *matchingEngineImpl) AddActivityCancellation(request *m.AddActivityCancellationRequest) error {
+	domainID := request.GetDomainUUID()
+	taskListName := request.TaskList.GetName()
+	key := activityCancellationPollerKey{
+		domainID:     domainID,
+		taskListName: taskListName,
+		identity:     request.GetIdentity(),
+	}
+	added := e.activityCancellations.add(key, &workflow.PollForActivityCancellationResponse{
+		TaskToken:         request.TaskToken,
+		WorkflowExecution: request.Execution,
+		ActivityId:        request.ActivityId,
+	})
+	if !added {
+		e.logger.Debugf("Dropped activity cancellation for taskList=%v, worker %v is not polling for cancellations",
+			taskListName, request.GetIdentity())
+	}
+	return nil
+}
+
+// PollForActivityCancellation waits for the cancellation of an activity started by the polling worker
+func (e *matchingEngineImpl) PollForActivityCancellation(ctx context.Context, req *m.PollForActivityCancellationRequest) (
+	*workflow.PollForActivityCancellationResponse, error) {
+	domainID := req.GetDomainUUID()
+	request := req.PollRequest
+	taskListName := request.TaskList.GetName()
+	e.logger.Debugf("Received PollForActivityCancellation for taskList=%v", taskListName)
+
+	domainEntry, err := e.domainCache.GetDomainByID(domainID)
+	if err != nil {
+		return nil, err
+	}
+	timeout := e.config.LongPollExpirationInterval(domainEntry.GetInfo().Name, taskListName, persistence.TaskListTypeActivity)
+	key := activityCancellationPollerKey{
+		domainID:     domainID,
+		taskListName: taskListName,
+		identity:     request.GetIdentity(),
+	}
+	return e.activityCancellations.poll(ctx, key, timeout), nil
+}
+
 // pollForActivityTaskOperation takes one task from the task manager, update workflow execution history, mark task as
 // completed and return it to user. If a task from task manager is already started, return an empty response, without
 // error. Timeouts handled by the timer queue.
@@ -440,6 +498,12 @@ query_loop:
 		if err != nil {
 			return nil, err
 		}
+		tlMgr, err = e.getBuildSetTaskListManager(tlMgr, taskList, taskListKind, func(buildSets [][]string) string {
+			return taskBuildSetID(buildSets, queryRequest.GetBuildID())
+		})
+		if err != nil {
+			return nil, err
+		}
 		queryTask := &queryTaskInfo{
 			queryRequest: queryRequest,
 			taskID:       uuid.New(),
@@ -505,7 +569,7 @@ query_loop:
 			return nil, &workflow.QueryFailedError{Message: "timeout: workflow worker is not responding"}
 		}
 	}
-	return nil, &workflow.QueryFailedError{Message: "query failed with max retry" + lastErr.Error()}
+	return nil, &workflow.QueryFailedError{Message: "query failed with max retry: " + lastErr.Error()}
 }
 
 type queryResult struct {
@@ -570,14 +634,37 @@ func (e *matchingEngineImpl) DescribeTaskList(ctx context.Context, request *m.De
 		return nil, err
 	}
 
+	pollerInfos := tlMgr.GetAllPollerInfo()
+	for _, mgr := range e.getBuildSetTaskListManagers(taskList) {
+		pollerInfos = append(pollerInfos, mgr.GetAllPollerInfo()...)
+	}
 	pollers := []*workflow.PollerInfo{}
-	for _, poller := range tlMgr.GetAllPollerInfo() {
+	for _, poller := range pollerInfos {
 		pollers = append(pollers, &workflow.PollerInfo{
 			Identity:       common.StringPtr(poller.identity),
 			LastAccessTime: common.Int64Ptr(poller.lastAccessTime.UnixNano()),
+			BinaryChecksum: common.StringPtr(poller.binaryChecksum),
 		})
 	}
-	return &workflow.DescribeTaskListResponse{Pollers: pollers}, nil
+	buildSets := []*workflow.CompatibleBuildSet{}
+	for _, set := range tlMgr.GetBuildSets() {
+		buildSets = append(buildSets, &workflow.CompatibleBuildSet{BuildIDs: set})
+	}
+	return &workflow.DescribeTaskListResponse{Pollers: pollers, BuildSets: buildSets}, nil
+}
+
+// PromoteTaskListBuildID makes the build the default one for new workflows on a decision task list
+func (e *matchingEngineImpl) PromoteTaskListBuildID(ctx context.Context, request *m.PromoteTaskListBuildIDRequest) error {
+	domainID := request.GetDomainUUID()
+	promoteRequest := request.PromoteRequest
+	taskListName := promoteRequest.TaskList.GetName()
+
+	taskList := newTaskListID(domainID, taskListName, persistence.TaskListTypeDecision)
+	tlMgr, err := e.getTaskListManager(taskList, common.TaskListKindPtr(workflow.TaskListKindNormal))
+	if err != nil {
+		return err
+	}
+	return tlMgr.PromoteBuildID(promoteRequest.GetBuildID(), promoteRequest.GetCompatibleBuildID())
 }
 
 // Loads a task from persistence and wraps it in a task context
@@ -591,6 +678,61 @@ func (e *matchingEngineImpl) getTask(
 	return tlMgr.GetTaskContext(ctx, maxDispatchPerSecond)
 }
 
+// Loads a decision task for a poller of the given build and wraps it in a task context
+func (e *matchingEngineImpl) getDecisionTask(
+	ctx context.Context, taskList *taskListID, taskListKind *workflow.TaskListKind, binaryChecksum string,
+) (*taskContext, error) {
+	tlMgr, err := e.getTaskListManager(taskList, taskListKind)
+	if err != nil {
+		return nil, err
+	}
+	unversionedMgr := tlMgr
+	tlMgr, err = e.getBuildSetTaskListManager(tlMgr, taskList, taskListKind, func(buildSets [][]string) string {
+		setID := pollerBuildSetID(buildSets, binaryChecksum)
+		if setID == defaultBuildSetID(buildSets) && unversionedMgr.GetBacklogCountHint() > 0 {
+			// tasks added before the task list got versioned are drained by the pollers of the default set
+			return ""
+		}
+		return setID
+	})
+	if err != nil {
+		return nil, err
+	}
+	return tlMgr.GetTaskContext(ctx, nil)
+}
+
+// getBuildSetTaskListManager returns the manager of the physical task list of the build set selected from
+// the build sets of a decision task list, or the given manager if the task list is not versioned
+func (e *matchingEngineImpl) getBuildSetTaskListManager(
+	tlMgr taskListManager, taskList *taskListID, taskListKind *workflow.TaskListKind,
+	selectBuildSet func(buildSets [][]string) string,
+) (taskListManager, error) {
+	if taskListKind != nil && *taskListKind == workflow.TaskListKindSticky {
+		return tlMgr, nil
+	}
+	setID := selectBuildSet(tlMgr.GetBuildSets())
+	if setID == "" {
+		return tlMgr, nil
+	}
+	buildSetTaskList := newTaskListID(taskList.domainID, versionedTaskListName(setID, taskList.taskListName), taskList.taskType)
+	return e.getTaskListManager(buildSetTaskList, taskListKind)
+}
+
+// getBuildSetTaskListManagers returns the loaded managers of the physical task lists of all build sets
+func (e *matchingEngineImpl) getBuildSetTaskListManagers(taskList *taskListID) []taskListManager {
+	e.taskListsLock.RLock()
+	defer e.taskListsLock.RUnlock()
+	var result []taskListManager
+	for id, tlMgr := range e.taskLists {
+		if id.domainID == taskList.domainID && id.taskType == taskList.taskType &&
+			strings.HasPrefix(id.taskListName, versionedTaskListPrefix) &&
+			strings.HasSuffix(id.taskListName, "/"+taskList.taskListName) {
+			result = append(result, tlMgr)
+		}
+	}
+	return result
+}
+
 func (e *matchingEngineImpl) unloadTaskList(id *taskListID) {
 	e.taskListsLock.Lock()
 	tlMgr, ok := e.taskLists[*id]
@@ -624,7 +766,7 @@ func (e *matchingEngineImpl) createPollForDecisionTaskResponse(context *taskCont
 			WorkflowID:      task.WorkflowID,
 			RunID:           task.RunID,
 			ScheduleID:      historyResponse.GetScheduledEventId(),
-			ScheduleAttempt: historyResponse.GetAttempt(),
+			ScheduleAttempt: common.GetDecisionScheduleAttempt(historyResponse),
 		}
 		token, _ = e.tokenSerializer.Serialize(taskoken)
 	}
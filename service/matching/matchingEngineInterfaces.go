@@ -33,11 +33,14 @@ type (
 		Stop()
 		AddDecisionTask(addRequest *m.AddDecisionTaskRequest) (syncMatch bool, err error)
 		AddActivityTask(addRequest *m.AddActivityTaskRequest) (syncMatch bool, err error)
+		AddActivityCancellation(addRequest *m.AddActivityCancellationRequest) error
 		PollForDecisionTask(ctx context.Context, request *m.PollForDecisionTaskRequest) (*m.PollForDecisionTaskResponse, error)
 		PollForActivityTask(ctx context.Context, request *m.PollForActivityTaskRequest) (*workflow.PollForActivityTaskResponse, error)
+		PollForActivityCancellation(ctx context.Context, request *m.PollForActivityCancellationRequest) (*workflow.PollForActivityCancellationResponse, error)
 		QueryWorkflow(ctx context.Context, request *m.QueryWorkflowRequest) (*workflow.QueryWorkflowResponse, error)
 		RespondQueryTaskCompleted(ctx context.Context, request *m.RespondQueryTaskCompletedRequest) error
 		CancelOutstandingPoll(ctx context.Context, request *m.CancelOutstandingPollRequest) error
 		DescribeTaskList(ctx context.Context, request *m.DescribeTaskListRequest) (*workflow.DescribeTaskListResponse, error)
+		PromoteTaskListBuildID(ctx context.Context, request *m.PromoteTaskListBuildIDRequest) error
 	}
 )
@@ -133,6 +133,8 @@ func newMatchingEngine(
 		tokenSerializer: common.NewJSONTaskTokenSerializer(),
 		config:          config,
 		domainCache:     domainCache,
+
+		activityCancellations: newActivityCancellations(),
 	}
 }
 
@@ -397,6 +399,137 @@ func (s *matchingEngineSuite) AddTasksTest(taskType int) {
 
 }
 
+func (s *matchingEngineSuite) TestAddDecisionTasks_BuildSets() {
+	domainID := "domainId"
+	tl := "makeToast"
+	taskList := &workflow.TaskList{Name: common.StringPtr(tl)}
+	execution := workflow.WorkflowExecution{RunId: common.StringPtr("run1"), WorkflowId: common.StringPtr("workflow1")}
+
+	addDecisionTask := func(buildID string) {
+		_, err := s.matchingEngine.AddDecisionTask(&matching.AddDecisionTaskRequest{
+			DomainUUID:                    common.StringPtr(domainID),
+			Execution:                     &execution,
+			ScheduleId:                    common.Int64Ptr(1),
+			TaskList:                      taskList,
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
+			BuildID:                       common.StringPtr(buildID),
+		})
+		s.NoError(err)
+	}
+	promote := func(buildID string, compatibleBuildID string) error {
+		return s.matchingEngine.PromoteTaskListBuildID(context.Background(), &matching.PromoteTaskListBuildIDRequest{
+			DomainUUID: common.StringPtr(domainID),
+			PromoteRequest: &workflow.PromoteTaskListBuildIDRequest{
+				TaskList:          taskList,
+				BuildID:           common.StringPtr(buildID),
+				CompatibleBuildID: common.StringPtr(compatibleBuildID),
+			},
+		})
+	}
+	taskCount := func(setID string) int {
+		name := tl
+		if setID != "" {
+			name = versionedTaskListName(setID, tl)
+		}
+		return s.taskManager.getTaskCount(newTaskListID(domainID, name, persistence.TaskListTypeDecision))
+	}
+
+	// task list is not versioned yet
+	addDecisionTask("")
+	s.Equal(1, taskCount(""))
+
+	s.NoError(promote("b1", ""))
+	addDecisionTask("")
+	s.Equal(1, taskCount("b1"))
+
+	s.NoError(promote("b2", ""))
+	s.NoError(promote("b1.1", "b1"))
+	s.IsType(&workflow.BadRequestError{}, promote("b3", "unknown"))
+	addDecisionTask("b2")
+	addDecisionTask("")
+	s.Equal(1, taskCount("b2"))
+	s.Equal(2, taskCount("b1"))
+	s.Equal(1, taskCount(""))
+
+	// build sets are persisted with the task list
+	s.EqualValues([][]string{{"b2"}, {"b1", "b1.1"}},
+		s.taskManager.getTaskListManager(newTaskListID(domainID, tl, persistence.TaskListTypeDecision)).buildSets)
+
+	desc, err := s.matchingEngine.DescribeTaskList(context.Background(), &matching.DescribeTaskListRequest{
+		DomainUUID: common.StringPtr(domainID),
+		DescRequest: &workflow.DescribeTaskListRequest{
+			TaskList:     taskList,
+			TaskListType: workflow.TaskListTypeDecision.Ptr(),
+		},
+	})
+	s.NoError(err)
+	s.Equal([]*workflow.CompatibleBuildSet{
+		{BuildIDs: []string{"b2"}},
+		{BuildIDs: []string{"b1", "b1.1"}},
+	}, desc.BuildSets)
+}
+
+func (s *matchingEngineSuite) TestPollForDecisionTasks_BuildSetsWithoutBinaryChecksum() {
+	s.matchingEngine.config.LongPollExpirationInterval = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(10 * time.Millisecond)
+
+	domainID := "domainId"
+	tl := "makeToast"
+	identity := "selfDrivingToaster"
+	taskList := &workflow.TaskList{Name: common.StringPtr(tl)}
+	workflowType := workflow.WorkflowType{Name: common.StringPtr("workflow")}
+
+	s.historyClient.On("RecordDecisionTaskStarted", mock.Anything,
+		mock.AnythingOfType("*history.RecordDecisionTaskStartedRequest")).Return(
+		func(ctx context.Context, taskRequest *gohistory.RecordDecisionTaskStartedRequest) *gohistory.RecordDecisionTaskStartedResponse {
+			return &gohistory.RecordDecisionTaskStartedResponse{
+				WorkflowType:           &workflowType,
+				PreviousStartedEventId: common.Int64Ptr(0),
+				ScheduledEventId:       common.Int64Ptr(taskRequest.GetScheduleId()),
+				Attempt:                common.Int64Ptr(0),
+			}
+		}, nil)
+
+	addDecisionTask := func(workflowID string) {
+		_, err := s.matchingEngine.AddDecisionTask(&matching.AddDecisionTaskRequest{
+			DomainUUID:                    common.StringPtr(domainID),
+			Execution:                     &workflow.WorkflowExecution{RunId: common.StringPtr("run1"), WorkflowId: common.StringPtr(workflowID)},
+			ScheduleId:                    common.Int64Ptr(1),
+			TaskList:                      taskList,
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
+		})
+		s.NoError(err)
+	}
+
+	// the first task is added before the task list is versioned, the second one goes to the default set
+	addDecisionTask("workflow1")
+	s.NoError(s.matchingEngine.PromoteTaskListBuildID(context.Background(), &matching.PromoteTaskListBuildIDRequest{
+		DomainUUID: common.StringPtr(domainID),
+		PromoteRequest: &workflow.PromoteTaskListBuildIDRequest{
+			TaskList: taskList,
+			BuildID:  common.StringPtr("b1"),
+		},
+	}))
+	addDecisionTask("workflow2")
+	s.Equal(1, s.taskManager.getTaskCount(newTaskListID(domainID, versionedTaskListName("b1", tl), persistence.TaskListTypeDecision)))
+
+	// a poller which does not report its build receives the tasks of both task lists
+	polled := make(map[string]struct{})
+	for i := 0; i < 100 && len(polled) < 2; i++ {
+		resp, err := s.matchingEngine.PollForDecisionTask(s.callContext, &matching.PollForDecisionTaskRequest{
+			DomainUUID: common.StringPtr(domainID),
+			PollRequest: &workflow.PollForDecisionTaskRequest{
+				TaskList: taskList,
+				Identity: common.StringPtr(identity),
+			},
+		})
+		s.NoError(err)
+		if resp.WorkflowExecution != nil {
+			polled[resp.WorkflowExecution.GetWorkflowId()] = struct{}{}
+		}
+	}
+	s.Equal(map[string]struct{}{"workflow1": {}, "workflow2": {}}, polled)
+}
+
 func (s *matchingEngineSuite) TestTaskWriterShutdown() {
 	s.matchingEngine.config.RangeSize = 300 // override to low number for the test
 
@@ -555,6 +688,160 @@ func (s *matchingEngineSuite) TestAddThenConsumeActivities() {
 	s.True(expectedRange <= s.taskManager.getTaskListManager(tlID).rangeID)
 }
 
+func (s *matchingEngineSuite) TestAddThenConsumeActivities_Priority() {
+	s.matchingEngine.config.LongPollExpirationInterval = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(10 * time.Millisecond)
+
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeActivity}
+	taskList := &workflow.TaskList{}
+	taskList.Name = &tl
+
+	// no poller is waiting, so all tasks end up in the backlog
+	priorities := []int32{0, 5, 1}
+	for i, priority := range priorities {
+		scheduleID := int64(i * 3)
+		addRequest := matching.AddActivityTaskRequest{
+			SourceDomainUUID:              common.StringPtr(domainID),
+			DomainUUID:                    common.StringPtr(domainID),
+			Execution:                     &workflowExecution,
+			ScheduleId:                    &scheduleID,
+			TaskList:                      taskList,
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
+			Priority:                      common.Int32Ptr(priority),
+		}
+
+		_, err := s.matchingEngine.AddActivityTask(&addRequest)
+		s.NoError(err)
+	}
+	s.EqualValues(len(priorities), s.taskManager.getTaskCount(tlID))
+	time.Sleep(100 * time.Millisecond) // let the task list load the backlog
+
+	activityID := "activityId1"
+	activityType := &workflow.ActivityType{Name: common.StringPtr("activity1")}
+	s.historyClient.On("RecordActivityTaskStarted", mock.Anything,
+		mock.AnythingOfType("*history.RecordActivityTaskStartedRequest")).Return(
+		func(ctx context.Context, taskRequest *gohistory.RecordActivityTaskStartedRequest) *gohistory.RecordActivityTaskStartedResponse {
+			return &gohistory.RecordActivityTaskStartedResponse{
+				ScheduledEvent: newActivityTaskScheduledEvent(*taskRequest.ScheduleId, 0,
+					&workflow.ScheduleActivityTaskDecisionAttributes{
+						ActivityId:                    &activityID,
+						TaskList:                      &workflow.TaskList{Name: taskList.Name},
+						ActivityType:                  activityType,
+						ScheduleToCloseTimeoutSeconds: common.Int32Ptr(100),
+						ScheduleToStartTimeoutSeconds: common.Int32Ptr(50),
+						StartToCloseTimeoutSeconds:    common.Int32Ptr(50),
+						HeartbeatTimeoutSeconds:       common.Int32Ptr(10),
+					}),
+				StartedTimestamp: common.Int64Ptr(time.Now().UnixNano()),
+			}
+		}, nil)
+
+	var scheduleIDs []int64
+	for len(scheduleIDs) < len(priorities) {
+		result, err := s.matchingEngine.PollForActivityTask(s.callContext, &matching.PollForActivityTaskRequest{
+			DomainUUID: common.StringPtr(domainID),
+			PollRequest: &workflow.PollForActivityTaskRequest{
+				TaskList: taskList,
+				Identity: common.StringPtr("nobody")},
+		})
+		s.NoError(err)
+		if len(result.TaskToken) == 0 {
+			continue
+		}
+		token, err := s.matchingEngine.tokenSerializer.Deserialize(result.TaskToken)
+		s.NoError(err)
+		scheduleIDs = append(scheduleIDs, token.ScheduleID)
+	}
+	// dispatched in priority order instead of the order the tasks were added
+	s.Equal([]int64{3, 6, 0}, scheduleIDs)
+	s.EqualValues(0, s.taskManager.getTaskCount(tlID))
+}
+
+func (s *matchingEngineSuite) TestAddThenConsumeActivities_PriorityBehindBacklog() {
+	s.matchingEngine.config.LongPollExpirationInterval = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(10 * time.Millisecond)
+	s.matchingEngine.config.GetTasksBatchSize = dynamicconfig.GetIntPropertyFilteredByTaskListInfo(3)
+
+	runID := "run1"
+	workflowID := "workflow1"
+	workflowExecution := workflow.WorkflowExecution{RunId: &runID, WorkflowId: &workflowID}
+
+	domainID := "domainId"
+	tl := "makeToast"
+	tlID := &taskListID{domainID: domainID, taskListName: tl, taskType: persistence.TaskListTypeActivity}
+	taskList := &workflow.TaskList{}
+	taskList.Name = &tl
+
+	// a bulk backlog of more than a batch, followed by an urgent task
+	const backlogCount = 8
+	const urgentScheduleID = int64(backlogCount * 3)
+	for i := int64(0); i <= backlogCount; i++ {
+		scheduleID := i * 3
+		priority := int32(0)
+		if scheduleID == urgentScheduleID {
+			priority = 5
+		}
+		addRequest := matching.AddActivityTaskRequest{
+			SourceDomainUUID:              common.StringPtr(domainID),
+			DomainUUID:                    common.StringPtr(domainID),
+			Execution:                     &workflowExecution,
+			ScheduleId:                    &scheduleID,
+			TaskList:                      taskList,
+			ScheduleToStartTimeoutSeconds: common.Int32Ptr(1),
+			Priority:                      common.Int32Ptr(priority),
+		}
+
+		_, err := s.matchingEngine.AddActivityTask(&addRequest)
+		s.NoError(err)
+	}
+	s.EqualValues(backlogCount+1, s.taskManager.getTaskCount(tlID))
+	time.Sleep(100 * time.Millisecond) // let the task list load the backlog
+
+	activityID := "activityId1"
+	activityType := &workflow.ActivityType{Name: common.StringPtr("activity1")}
+	s.historyClient.On("RecordActivityTaskStarted", mock.Anything,
+		mock.AnythingOfType("*history.RecordActivityTaskStartedRequest")).Return(
+		func(ctx context.Context, taskRequest *gohistory.RecordActivityTaskStartedRequest) *gohistory.RecordActivityTaskStartedResponse {
+			return &gohistory.RecordActivityTaskStartedResponse{
+				ScheduledEvent: newActivityTaskScheduledEvent(*taskRequest.ScheduleId, 0,
+					&workflow.ScheduleActivityTaskDecisionAttributes{
+						ActivityId:                    &activityID,
+						TaskList:                      &workflow.TaskList{Name: taskList.Name},
+						ActivityType:                  activityType,
+						ScheduleToCloseTimeoutSeconds: common.Int32Ptr(100),
+						ScheduleToStartTimeoutSeconds: common.Int32Ptr(50),
+						StartToCloseTimeoutSeconds:    common.Int32Ptr(50),
+						HeartbeatTimeoutSeconds:       common.Int32Ptr(10),
+					}),
+				StartedTimestamp: common.Int64Ptr(time.Now().UnixNano()),
+			}
+		}, nil)
+
+	var scheduleIDs []int64
+	for len(scheduleIDs) < backlogCount+1 {
+		result, err := s.matchingEngine.PollForActivityTask(s.callContext, &matching.PollForActivityTaskRequest{
+			DomainUUID: common.StringPtr(domainID),
+			PollRequest: &workflow.PollForActivityTaskRequest{
+				TaskList: taskList,
+				Identity: common.StringPtr("nobody")},
+		})
+		s.NoError(err)
+		if len(result.TaskToken) == 0 {
+			continue
+		}
+		token, err := s.matchingEngine.tokenSerializer.Deserialize(result.TaskToken)
+		s.NoError(err)
+		scheduleIDs = append(scheduleIDs, token.ScheduleID)
+	}
+	// the urgent task was read ahead of the backlog instead of waiting for it to be loaded
+	s.Equal(urgentScheduleID, scheduleIDs[0])
+	s.EqualValues(0, s.taskManager.getTaskCount(tlID))
+}
+
 func (s *matchingEngineSuite) TestSyncMatchActivities() {
 	// Set a short long poll expiration so we don't have to wait too long for 0 throttling cases
 	s.matchingEngine.config.LongPollExpirationInterval = dynamicconfig.GetDurationPropertyFnFilteredByTaskListInfo(50 * time.Millisecond)
@@ -1002,6 +1289,64 @@ func (s *matchingEngineSuite) TestPollWithExpiredContext() {
 	s.Equal(emptyPollForActivityTaskResponse, resp)
 }
 
+func (s *matchingEngineSuite) TestActivityCancellation() {
+	identity := "worker"
+	domainID := "domainId"
+	tl := "makeToast"
+	taskList := &workflow.TaskList{Name: &tl}
+	execution := &workflow.WorkflowExecution{
+		WorkflowId: common.StringPtr("workflowID"),
+		RunId:      common.StringPtr("runID"),
+	}
+	addRequest := &matching.AddActivityCancellationRequest{
+		DomainUUID: common.StringPtr(domainID),
+		Execution:  execution,
+		TaskList:   taskList,
+		Identity:   common.StringPtr(identity),
+		TaskToken:  []byte("token"),
+		ActivityId: common.StringPtr("activityID"),
+	}
+	poll := func(identity string) *workflow.PollForActivityCancellationResponse {
+		resp, err := s.matchingEngine.PollForActivityCancellation(s.callContext, &matching.PollForActivityCancellationRequest{
+			DomainUUID: common.StringPtr(domainID),
+			PollRequest: &workflow.PollForActivityCancellationRequest{
+				TaskList: taskList,
+				Identity: common.StringPtr(identity),
+			},
+		})
+		s.NoError(err)
+		return resp
+	}
+
+	// the worker did not poll for cancellations yet, so the cancellation is not buffered
+	s.NoError(s.matchingEngine.AddActivityCancellation(addRequest))
+	s.Equal(emptyPollForActivityCancellationResponse, poll(identity))
+
+	s.NoError(s.matchingEngine.AddActivityCancellation(addRequest))
+	s.Equal(emptyPollForActivityCancellationResponse, poll("otherWorker"))
+	resp := poll(identity)
+	s.Equal([]byte("token"), resp.TaskToken)
+	s.Equal(execution, resp.WorkflowExecution)
+	s.Equal("activityID", resp.GetActivityId())
+	s.Equal(emptyPollForActivityCancellationResponse, poll(identity))
+
+	// a waiting poll returns as soon as a cancellation is added
+	respC := make(chan *workflow.PollForActivityCancellationResponse, 1)
+	go func() {
+		resp, _ := s.matchingEngine.PollForActivityCancellation(s.callContext, &matching.PollForActivityCancellationRequest{
+			DomainUUID: common.StringPtr(domainID),
+			PollRequest: &workflow.PollForActivityCancellationRequest{
+				TaskList: taskList,
+				Identity: common.StringPtr(identity),
+			},
+		})
+		respC <- resp
+	}()
+	time.Sleep(10 * time.Millisecond)
+	s.NoError(s.matchingEngine.AddActivityCancellation(addRequest))
+	s.Equal([]byte("token"), (<-respC).TaskToken)
+}
+
 func (s *matchingEngineSuite) TestMultipleEnginesActivitiesRangeStealing() {
 	runID := "run1"
 	workflowID := "workflow1"
@@ -1561,6 +1906,7 @@ type testTaskListManager struct {
 	rangeID         int64
 	ackLevel        int64
 	createTaskCount int
+	buildSets       [][]string
 	tasks           *treemap.Map
 }
 
@@ -1591,12 +1937,13 @@ func (m *testTaskManager) LeaseTaskList(request *persistence.LeaseTaskListReques
 
 	return &persistence.LeaseTaskListResponse{
 		TaskListInfo: &persistence.TaskListInfo{
-			AckLevel: tlm.ackLevel,
-			DomainID: request.DomainID,
-			Name:     request.TaskList,
-			TaskType: request.TaskType,
-			RangeID:  tlm.rangeID,
-			Kind:     request.TaskListKind,
+			AckLevel:  tlm.ackLevel,
+			DomainID:  request.DomainID,
+			Name:      request.TaskList,
+			TaskType:  request.TaskType,
+			RangeID:   tlm.rangeID,
+			Kind:      request.TaskListKind,
+			BuildSets: tlm.buildSets,
 		},
 	}, nil
 }
@@ -1616,6 +1963,7 @@ func (m *testTaskManager) UpdateTaskList(request *persistence.UpdateTaskListRequ
 		}
 	}
 	tlm.ackLevel = tli.AckLevel
+	tlm.buildSets = tli.BuildSets
 	return &persistence.UpdateTaskListResponse{}, nil
 }
 
@@ -1636,6 +1984,35 @@ func (m *testTaskManager) CompleteTask(request *persistence.CompleteTaskRequest)
 	return nil
 }
 
+// CompleteTasksLessThan provides a mock function with given fields: request
+func (m *testTaskManager) CompleteTasksLessThan(request *persistence.CompleteTasksLessThanRequest) (int, error) {
+	tlm := m.getTaskListManager(newTaskListID(request.DomainID, request.TaskListName, request.TaskType))
+	tlm.Lock()
+	defer tlm.Unlock()
+	keys := tlm.tasks.Keys()
+	for _, key := range keys {
+		id := key.(int64)
+		if id <= request.TaskID {
+			tlm.tasks.Remove(id)
+		}
+	}
+	return persistence.UnknownNumRowsAffected, nil
+}
+
+// ListTaskList provides a mock function with given fields: request
+func (m *testTaskManager) ListTaskList(request *persistence.ListTaskListRequest) (*persistence.ListTaskListResponse, error) {
+	return nil, fmt.Errorf("unsupported operation")
+}
+
+// DeleteTaskList provides a mock function with given fields: request
+func (m *testTaskManager) DeleteTaskList(request *persistence.DeleteTaskListRequest) error {
+	m.Lock()
+	defer m.Unlock()
+	key := newTaskListID(request.DomainID, request.TaskListName, request.TaskListType)
+	delete(m.taskLists, *key)
+	return nil
+}
+
 // CreateTask provides a mock function with given fields: request
 func (m *testTaskManager) CreateTasks(request *persistence.CreateTasksRequest) (*persistence.CreateTasksResponse, error) {
 	domainID := request.TaskListInfo.DomainID
@@ -1678,6 +2055,7 @@ func (m *testTaskManager) CreateTasks(request *persistence.CreateTasksRequest) (
 			ScheduleID: scheduleID,
 			TaskID:     task.TaskID,
 			WorkflowID: *task.Execution.WorkflowId,
+			Priority:   task.Data.Priority,
 		})
 		tlm.createTaskCount++
 	}
@@ -1709,7 +2087,14 @@ func (m *testTaskManager) GetTasks(request *persistence.GetTasksRequest) (*persi
 		if taskID > request.MaxReadLevel {
 			break
 		}
-		tasks = append(tasks, it.Value().(*persistence.TaskInfo))
+		task := it.Value().(*persistence.TaskInfo)
+		if request.MinPriority != nil && task.Priority < *request.MinPriority {
+			continue
+		}
+		tasks = append(tasks, task)
+		if len(tasks) == request.BatchSize {
+			break
+		}
 	}
 	return &persistence.GetTasksResponse{
 		Tasks: tasks,
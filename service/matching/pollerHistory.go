@@ -34,12 +34,14 @@ const (
 
 type (
 	pollerIdentity struct {
-		identity string
+		identity       string
+		binaryChecksum string
 		// TODO add IP, T1396795
 	}
 
 	pollerInfo struct {
-		identity string
+		identity       string
+		binaryChecksum string
 		// TODO add IP, T1396795
 		lastAccessTime time.Time
 	}
@@ -77,7 +79,8 @@ func (pollers *pollerHistory) getAllPollerInfo() []*pollerInfo {
 		key := entry.Key().(pollerIdentity)
 		lastAccessTime := entry.CreateTime()
 		result = append(result, &pollerInfo{
-			identity: key.identity,
+			identity:       key.identity,
+			binaryChecksum: key.binaryChecksum,
 			// TODO add IP, T1396795
 			lastAccessTime: lastAccessTime,
 		})
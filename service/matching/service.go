@@ -45,6 +45,8 @@ type Config struct {
 	// Time to hold a poll request before returning an empty response if there are no tasks
 	LongPollExpirationInterval dynamicconfig.DurationPropertyFnWithTaskListInfoFilters
 	MinTaskThrottlingBurstSize dynamicconfig.IntPropertyFnWithTaskListInfoFilters
+	// Every Nth dispatch of a buffered task picks the oldest task instead of the highest priority one
+	PriorityStarvationInterval dynamicconfig.IntPropertyFnWithTaskListInfoFilters
 
 	// taskWriter configuration
 	OutstandingTaskAppendsThreshold dynamicconfig.IntPropertyFnWithTaskListInfoFilters
@@ -64,6 +66,7 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 		MaxTasklistIdleTime:             dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MaxTasklistIdleTime, 5*time.Minute),
 		LongPollExpirationInterval:      dc.GetDurationPropertyFilteredByTaskListInfo(dynamicconfig.MatchingLongPollExpirationInterval, time.Minute),
 		MinTaskThrottlingBurstSize:      dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMinTaskThrottlingBurstSize, 1),
+		PriorityStarvationInterval:      dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingPriorityStarvationInterval, 10),
 		OutstandingTaskAppendsThreshold: dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingOutstandingTaskAppendsThreshold, 250),
 		MaxTaskBatchSize:                dc.GetIntPropertyFilteredByTaskListInfo(dynamicconfig.MatchingMaxTaskBatchSize, 100),
 	}
@@ -98,7 +101,7 @@ func (s *Service) Start() {
 
 	pConfig := params.PersistenceConfig
 	pConfig.SetMaxQPS(pConfig.DefaultStore, s.config.PersistenceMaxQPS())
-	pFactory := persistencefactory.New(&pConfig, params.ClusterMetadata.GetCurrentClusterName(), base.GetMetricsClient(), log)
+	pFactory := persistencefactory.New(&pConfig, params.BlobstoreClient, params.ClusterMetadata.GetCurrentClusterName(), base.GetMetricsClient(), log)
 
 	taskPersistence, err := pFactory.NewTaskManager()
 	if err != nil {
@@ -24,6 +24,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"runtime"
 	"sync"
 	"sync/atomic"
@@ -45,6 +46,9 @@ import (
 
 const (
 	done time.Duration = -1
+
+	// noBufferedTaskPriority indicates that no task loaded from persistence is waiting for dispatch
+	noBufferedTaskPriority int64 = math.MinInt64
 )
 
 // NOTE: Is this good enough for stress tests?
@@ -63,6 +67,11 @@ type taskListManager interface {
 	SyncMatchQueryTask(ctx context.Context, queryTask *queryTaskInfo) error
 	CancelPoller(pollerID string)
 	GetAllPollerInfo() []*pollerInfo
+	// GetBacklogCountHint returns the number of tasks loaded from persistence which are not completed yet
+	GetBacklogCountHint() int64
+	// GetBuildSets returns the compatible build sets of a versioned task list, see taskListVersioning.go
+	GetBuildSets() [][]string
+	PromoteBuildID(buildID string, compatibleBuildID string) error
 	String() string
 }
 
@@ -76,6 +85,7 @@ type taskListConfig struct {
 	IdleTasklistCheckInterval  func() time.Duration
 	MaxTasklistIdleTime        func() time.Duration
 	MinTaskThrottlingBurstSize func() int
+	PriorityStarvationInterval func() int
 	// taskWriter configuration
 	OutstandingTaskAppendsThreshold func() int
 	MaxTaskBatchSize                func() int
@@ -107,6 +117,9 @@ func newTaskListConfig(id *taskListID, config *Config, domainCache cache.DomainC
 		MinTaskThrottlingBurstSize: func() int {
 			return config.MinTaskThrottlingBurstSize(domain, taskListName, taskType)
 		},
+		PriorityStarvationInterval: func() int {
+			return config.PriorityStarvationInterval(domain, taskListName, taskType)
+		},
 		EnableSyncMatch: func() bool {
 			return config.EnableSyncMatch(domain, taskListName, taskType)
 		},
@@ -218,6 +231,7 @@ func newTaskListManagerWithRateLimiter(
 		domainCache:             domainCache,
 		engine:                  e,
 		taskBuffer:              make(chan *persistence.TaskInfo, taskBufferSize),
+		priorityTaskBuffer:      make(chan *persistence.TaskInfo, config.GetTasksBatchSize()),
 		notifyCh:                make(chan struct{}, 1),
 		shutdownCh:              make(chan struct{}),
 		deliverBufferShutdownCh: make(chan struct{}),
@@ -237,6 +251,8 @@ func newTaskListManagerWithRateLimiter(
 		outstandingPollsMap: make(map[string]context.CancelFunc),
 		rateLimiter:         rl,
 		taskListKind:        taskListKind,
+
+		bufferedTaskPriority: noBufferedTaskPriority,
 	}
 	tlMgr.taskWriter = newTaskWriter(tlMgr)
 	tlMgr.startWG.Add(1)
@@ -276,6 +292,9 @@ type taskListManagerImpl struct {
 	persistenceLock sync.Mutex
 	taskWriter      *taskWriter
 	taskBuffer      chan *persistence.TaskInfo // tasks loaded from persistence
+	// priorityTaskBuffer holds tasks read ahead of the backlog because they have a higher priority than
+	// the backlog, they are moved into the priority queue regardless of the backlog loaded already
+	priorityTaskBuffer chan *persistence.TaskInfo
 	// tasksForPoll is used to deliver tasks to pollers.
 	// It must to be unbuffered. addTask publishes to it asynchronously and expects publish to succeed
 	// only if there is waiting poll that consumes from it. Tasks in taskBuffer will blocking-add to
@@ -305,6 +324,8 @@ type taskListManagerImpl struct {
 	rangeID                 int64      // Current range of the task list. Starts from 1.
 	taskSequenceNumber      int64      // Sequence number of the next task. Starts from 1.
 	nextRangeSequenceNumber int64      // Current range boundary
+	priorityReadLevel       int64      // Maximum TaskID read ahead of the backlog, see readPriorityTasksAhead
+	priorityReadMinPriority int32      // Minimum priority of tasks read up to priorityReadLevel
 
 	// outstandingPollsMap is needed to keep track of all outstanding pollers for a
 	// particular tasklist.  PollerID generated by frontend is used as the key and
@@ -317,6 +338,11 @@ type taskListManagerImpl struct {
 	rateLimiter *rateLimiter
 
 	taskListKind *s.TaskListKind // sticky taskList has different process in persistence
+	buildSets    [][]string      // compatible worker build sets, loaded with the range
+
+	// bufferedTaskPriority is the highest priority of tasks loaded from persistence which are waiting
+	// for dispatch, sync match is skipped for tasks with a lower priority so that the backlog goes first
+	bufferedTaskPriority int64
 }
 
 // getTaskResult contains task info and optional channel to notify createTask caller
@@ -460,12 +486,13 @@ func (c *taskListManagerImpl) persistAckLevel() error {
 	c.Lock()
 	updateTaskListRequest := &persistence.UpdateTaskListRequest{
 		TaskListInfo: &persistence.TaskListInfo{
-			DomainID: c.taskListID.domainID,
-			Name:     c.taskListID.taskListName,
-			TaskType: c.taskListID.taskType,
-			AckLevel: c.taskAckManager.getAckLevel(),
-			RangeID:  c.rangeID,
-			Kind:     c.getTaskListKind(),
+			DomainID:  c.taskListID.domainID,
+			Name:      c.taskListID.taskListName,
+			TaskType:  c.taskListID.taskType,
+			AckLevel:  c.taskAckManager.getAckLevel(),
+			RangeID:   c.rangeID,
+			Kind:      c.getTaskListKind(),
+			BuildSets: c.buildSets,
 		},
 	}
 	c.Unlock()
@@ -546,8 +573,10 @@ func (c *taskListManagerImpl) getTask(ctx context.Context) (*getTaskResult, erro
 
 	identity, ok := ctx.Value(identityKey).(string)
 	if ok && identity != "" {
+		binaryChecksum, _ := ctx.Value(binaryChecksumKey).(string)
 		c.pollerHistory.updatePollerInfo(pollerIdentity{
-			identity: identity,
+			identity:       identity,
+			binaryChecksum: binaryChecksum,
 		})
 	}
 
@@ -600,6 +629,7 @@ func (c *taskListManagerImpl) CancelPoller(pollerID string) {
 // Returns a batch of tasks from persistence starting form current read level.
 // Also return a number that can be used to update readLevel
 // Also return a bool to indicate whether read is finished
+// Tasks are read in task ID order regardless of their priority, see readPriorityTasksAhead
 func (c *taskListManagerImpl) getTaskBatch() ([]*persistence.TaskInfo, int64, bool, error) {
 	var tasks []*persistence.TaskInfo
 	readLevel := c.taskAckManager.getReadLevel()
@@ -611,7 +641,7 @@ func (c *taskListManagerImpl) getTaskBatch() ([]*persistence.TaskInfo, int64, bo
 		if upper > maxReadLevel {
 			upper = maxReadLevel
 		}
-		tasks, err := c.getTaskBatchWithRange(readLevel, upper)
+		tasks, err := c.getTaskBatchWithRange(readLevel, upper, nil)
 		if err != nil {
 			return nil, readLevel, true, err
 		}
@@ -624,7 +654,9 @@ func (c *taskListManagerImpl) getTaskBatch() ([]*persistence.TaskInfo, int64, bo
 	return tasks, readLevel, readLevel == maxReadLevel, nil // caller will update readLevel when no task grabbed
 }
 
-func (c *taskListManagerImpl) getTaskBatchWithRange(readLevel int64, maxReadLevel int64) ([]*persistence.TaskInfo, error) {
+func (c *taskListManagerImpl) getTaskBatchWithRange(
+	readLevel int64, maxReadLevel int64, minPriority *int32,
+) ([]*persistence.TaskInfo, error) {
 	response, err := c.executeWithRetry(func(rangeID int64) (interface{}, error) {
 		c.Lock()
 		request := &persistence.GetTasksRequest{
@@ -635,6 +667,7 @@ func (c *taskListManagerImpl) getTaskBatchWithRange(readLevel int64, maxReadLeve
 			RangeID:      rangeID,
 			ReadLevel:    readLevel,    // exclusive
 			MaxReadLevel: maxReadLevel, // inclusive
+			MinPriority:  minPriority,
 		}
 		c.Unlock()
 		return c.engine.taskManager.GetTasks(request)
@@ -645,6 +678,52 @@ func (c *taskListManagerImpl) getTaskBatchWithRange(readLevel int64, maxReadLeve
 	return response.(*persistence.GetTasksResponse).Tasks, err
 }
 
+// readPriorityTasksAhead reads the tasks beyond the current read level which have a higher priority than
+// the backlog being loaded, so that they do not wait until the backlog in front of them is loaded.
+// The tasks are registered with the ack manager, the backlog read skips them once it reaches them.
+// Priorities are never negative so only tasks of a priority above 0 are read ahead, the tasks persisted
+// before the priority was added to the tasks, which have no priority, are only read with the backlog.
+func (c *taskListManagerImpl) readPriorityTasksAhead(backlogPriority int32) ([]*persistence.TaskInfo, error) {
+	if backlogPriority == math.MaxInt32 {
+		return nil, nil
+	}
+	minPriority := backlogPriority + 1
+
+	c.Lock()
+	readLevel := c.taskAckManager.getReadLevel()
+	if c.priorityReadLevel < readLevel || minPriority < c.priorityReadMinPriority {
+		// tasks of the lower priorities were not read ahead so far, start over from the read level
+		c.priorityReadLevel = readLevel
+	}
+	c.priorityReadMinPriority = minPriority
+	priorityReadLevel := c.priorityReadLevel
+	c.Unlock()
+
+	maxReadLevel := c.taskWriter.GetMaxReadLevel()
+	if priorityReadLevel >= maxReadLevel {
+		return nil, nil
+	}
+	tasks, err := c.getTaskBatchWithRange(priorityReadLevel, maxReadLevel, &minPriority)
+	if err != nil {
+		return nil, err
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	if len(tasks) < c.config.GetTasksBatchSize() {
+		c.priorityReadLevel = maxReadLevel
+	} else {
+		c.priorityReadLevel = tasks[len(tasks)-1].TaskID
+	}
+	var result []*persistence.TaskInfo
+	for _, t := range tasks {
+		if c.taskAckManager.addReadAheadTask(t.TaskID) {
+			result = append(result, t)
+		}
+	}
+	return result, nil
+}
+
 func (c *taskListManagerImpl) updateRangeIfNeeded() error {
 	c.Lock()
 	defer c.Unlock()
@@ -680,6 +759,7 @@ func (c *taskListManagerImpl) updateRangeIfNeededLocked(e *matchingEngineImpl) e
 	tli := resp.TaskListInfo
 	c.rangeID = tli.RangeID // Starts from 1
 	c.taskAckManager.setAckLevel(tli.AckLevel)
+	c.buildSets = tli.BuildSets
 	c.taskSequenceNumber = (tli.RangeID-1)*c.config.RangeSize + 1
 	c.nextRangeSequenceNumber = (tli.RangeID)*c.config.RangeSize + 1
 	c.logger.Debugf("updateRangeLocked rangeID=%v, c.taskSequenceNumber=%v, c.nextRangeSequenceNumber=%v",
@@ -717,6 +797,43 @@ func (c *taskListManagerImpl) GetAllPollerInfo() []*pollerInfo {
 	return c.pollerHistory.getAllPollerInfo()
 }
 
+// GetBacklogCountHint returns the number of tasks loaded from persistence which are not completed yet
+func (c *taskListManagerImpl) GetBacklogCountHint() int64 {
+	return c.taskAckManager.getBacklogCountHint()
+}
+
+// GetBuildSets returns the compatible worker build sets of this tasklist
+func (c *taskListManagerImpl) GetBuildSets() [][]string {
+	c.Lock()
+	defer c.Unlock()
+	return c.buildSets
+}
+
+// PromoteBuildID makes the build the default one for new workflows on this tasklist
+func (c *taskListManagerImpl) PromoteBuildID(buildID string, compatibleBuildID string) error {
+	c.startWG.Wait()
+	c.Lock()
+	buildSets, err := promoteBuildID(c.buildSets, buildID, compatibleBuildID)
+	if err != nil {
+		c.Unlock()
+		return err
+	}
+	previousBuildSets := c.buildSets
+	c.buildSets = buildSets
+	c.Unlock()
+
+	err = c.persistAckLevel()
+	if err != nil {
+		c.Lock()
+		c.buildSets = previousBuildSets
+		c.Unlock()
+		logging.LogPersistantStoreErrorEvent(c.logger, logging.TagValueStoreOperationUpdateTaskList, err,
+			"Persist build sets failed")
+		return err
+	}
+	return nil
+}
+
 // Tries to match task to a poller that is already waiting on getTask.
 // When this method returns non nil response without error it is guaranteed that the task is started
 // and sent to a poller. So it not necessary to persist it.
@@ -725,6 +842,10 @@ func (c *taskListManagerImpl) trySyncMatch(task *persistence.TaskInfo) (*persist
 	if !c.config.EnableSyncMatch() {
 		return nil, nil
 	}
+	if int64(task.Priority) < atomic.LoadInt64(&c.bufferedTaskPriority) {
+		// tasks with a higher priority are waiting in the backlog, they are dispatched first
+		return nil, nil
+	}
 	// Request from the point of view of Add(Activity|Decision)Task operation.
 	// But it is getTask result from the point of view of a poll operation.
 	request := &getTaskResult{task: task, C: make(chan *syncMatchResponse, 1), syncMatch: true}
@@ -747,6 +868,9 @@ func (c *taskListManagerImpl) trySyncMatch(task *persistence.TaskInfo) (*persist
 }
 
 func (c *taskListManagerImpl) deliverBufferTasksForPoll() {
+	// tasks are taken from taskBuffer into the priority queue, which decides the dispatch order
+	queue := newTaskPriorityQueue(c.config.PriorityStarvationInterval)
+	defer atomic.StoreInt64(&c.bufferedTaskPriority, noBufferedTaskPriority)
 deliverBufferTasksLoop:
 	for {
 		err := c.rateLimiter.Wait(c.cancelCtx)
@@ -764,22 +888,103 @@ deliverBufferTasksLoop:
 			runtime.Gosched()
 			continue
 		}
-		select {
-		case task, ok := <-c.taskBuffer:
-			if !ok { // Task list getTasks pump is shutdown
-				break deliverBufferTasksLoop
+	deliverTaskLoop:
+		for {
+			// move all tasks already loaded into the queue, so the next dispatch sees the highest priority
+		fillQueueLoop:
+			for {
+				taskBuffer, priorityTaskBuffer := c.getTaskBuffersToFill(queue)
+				select {
+				case task := <-priorityTaskBuffer:
+					queue.add(task)
+				case task, ok := <-taskBuffer:
+					if !ok { // Task list getTasks pump is shutdown
+						break deliverBufferTasksLoop
+					}
+					queue.add(task)
+				default:
+					break fillQueueLoop
+				}
 			}
+			c.updateBufferedTaskPriority(queue)
+
+			var tasksForPoll chan *getTaskResult
+			var result *getTaskResult
+			if task := queue.peek(); task != nil {
+				tasksForPoll = c.tasksForPoll
+				result = &getTaskResult{task: task}
+			}
+			taskBuffer, priorityTaskBuffer := c.getTaskBuffersToFill(queue)
+
 			select {
-			case c.tasksForPoll <- &getTaskResult{task: task}:
+			case tasksForPoll <- result:
+				queue.remove(result.task)
+				c.updateBufferedTaskPriority(queue)
+				break deliverTaskLoop
+			case task := <-priorityTaskBuffer:
+				queue.add(task)
+			case task, ok := <-taskBuffer:
+				if !ok { // Task list getTasks pump is shutdown
+					break deliverBufferTasksLoop
+				}
+				queue.add(task)
 			case <-c.deliverBufferShutdownCh:
 				break deliverBufferTasksLoop
 			}
-		case <-c.deliverBufferShutdownCh:
-			break deliverBufferTasksLoop
 		}
 	}
 }
 
+// getTaskBuffersToFill returns the buffers to move tasks from into the priority queue, nil for the ones
+// not to take tasks from as the queue holds enough of them already. Tasks read ahead of the backlog are
+// taken until the queue holds twice the tasks of a backlog batch.
+func (c *taskListManagerImpl) getTaskBuffersToFill(
+	queue *taskPriorityQueue,
+) (taskBuffer chan *persistence.TaskInfo, priorityTaskBuffer chan *persistence.TaskInfo) {
+	batchSize := c.config.GetTasksBatchSize()
+	if queue.len() < batchSize {
+		taskBuffer = c.taskBuffer
+	}
+	if queue.len() < 2*batchSize {
+		priorityTaskBuffer = c.priorityTaskBuffer
+	}
+	return
+}
+
+func (c *taskListManagerImpl) updateBufferedTaskPriority(queue *taskPriorityQueue) {
+	priority := noBufferedTaskPriority
+	if p, ok := queue.maxPriority(); ok {
+		priority = int64(p)
+	}
+	atomic.StoreInt64(&c.bufferedTaskPriority, priority)
+}
+
+// pushPriorityTasksAhead reads the tasks with a higher priority than the backlog ahead of it and
+// hands them to the priority queue. Returns false if the task list is shut down.
+func (c *taskListManagerImpl) pushPriorityTasksAhead(backlogPriority int32) bool {
+	tasks, err := c.readPriorityTasksAhead(backlogPriority)
+	if err != nil {
+		// the tasks are still loaded with the backlog
+		logging.LogPersistantStoreErrorEvent(c.logger, logging.TagValueStoreOperationGetTasks, err,
+			"Read priority tasks ahead failed")
+		return true
+	}
+	return c.pushPriorityTasks(tasks)
+}
+
+// pushPriorityTasks hands the tasks to the priority queue without waiting for the backlog loaded
+// already. Returns false if the task list is shut down.
+func (c *taskListManagerImpl) pushPriorityTasks(tasks []*persistence.TaskInfo) bool {
+	for _, t := range tasks {
+		select {
+		case c.priorityTaskBuffer <- t:
+		case <-c.shutdownCh:
+			return false
+		}
+	}
+	return true
+}
+
 func (c *taskListManagerImpl) getTasksPump() {
 	defer close(c.taskBuffer)
 	c.startWG.Wait()
@@ -795,6 +1000,13 @@ getTasksPumpLoop:
 			break getTasksPumpLoop
 		case <-c.notifyCh:
 			{
+				select {
+				case <-c.shutdownCh:
+					// the task buffer no longer blocks the pump, as the tasks are taken into the priority
+					// queue, so shutdown can race with pending notifications
+					break getTasksPumpLoop
+				default:
+				}
 				lastTimeWriteTask = time.Now()
 
 				tasks, readLevel, isReadBatchDone, err := c.getTaskBatch()
@@ -803,18 +1015,47 @@ getTasksPumpLoop:
 					// TODO: Should we ever stop retrying on db errors?
 					continue getTasksPumpLoop
 				}
+				var newTasks []*persistence.TaskInfo
 				c.Lock()
 				if len(tasks) == 0 {
 					c.taskAckManager.setReadLevel(readLevel)
 				} else {
 					for _, t := range tasks {
-						c.taskAckManager.addTask(t.TaskID)
+						// tasks read ahead by their priority are loaded already
+						if c.taskAckManager.addTask(t.TaskID) {
+							newTasks = append(newTasks, t)
+						}
 					}
 				}
 				c.Unlock()
-				for _, t := range tasks {
+
+				// the backlog is made of the lowest priority tasks of the batch, the others go ahead of it
+				backlogPriority := int32(math.MaxInt32)
+				for _, t := range newTasks {
+					if t.Priority < backlogPriority {
+						backlogPriority = t.Priority
+					}
+				}
+				var backlogTasks, priorityTasks []*persistence.TaskInfo
+				for _, t := range newTasks {
+					if t.Priority > backlogPriority {
+						priorityTasks = append(priorityTasks, t)
+					} else {
+						backlogTasks = append(backlogTasks, t)
+					}
+				}
+				if !c.pushPriorityTasks(priorityTasks) || !c.pushPriorityTasksAhead(backlogPriority) {
+					break getTasksPumpLoop
+				}
+				for i := 0; i < len(backlogTasks); {
 					select {
-					case c.taskBuffer <- t:
+					case c.taskBuffer <- backlogTasks[i]:
+						i++
+					case <-c.notifyCh:
+						// new tasks were written while the backlog is loaded, the urgent ones must not wait for it
+						if !c.pushPriorityTasksAhead(backlogPriority) {
+							break getTasksPumpLoop
+						}
 					case <-c.shutdownCh:
 						break getTasksPumpLoop
 					}
@@ -78,6 +78,176 @@ func TestDeliverBufferTasks_NoPollers(t *testing.T) {
 	wg.Wait()
 }
 
+func TestDeliverBufferTasks_Priority(t *testing.T) {
+	tlm := createTestTaskListManager()
+	tlm.taskBuffer <- &persistence.TaskInfo{TaskID: 1, Priority: 0}
+	tlm.taskBuffer <- &persistence.TaskInfo{TaskID: 2, Priority: 5}
+	tlm.taskBuffer <- &persistence.TaskInfo{TaskID: 3, Priority: 1}
+	tlm.taskBuffer <- &persistence.TaskInfo{TaskID: 4, Priority: 5}
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		tlm.deliverBufferTasksForPoll()
+		wg.Done()
+	}()
+	time.Sleep(100 * time.Millisecond) // let go routine move the buffered tasks into the priority queue
+
+	var taskIDs []int64
+	for i := 0; i < 4; i++ {
+		result := <-tlm.tasksForPoll
+		taskIDs = append(taskIDs, result.task.TaskID)
+	}
+	require.Equal(t, []int64{2, 4, 3, 1}, taskIDs)
+	close(tlm.deliverBufferShutdownCh)
+	wg.Wait()
+	require.Equal(t, noBufferedTaskPriority, atomic.LoadInt64(&tlm.bufferedTaskPriority))
+}
+
+func TestDeliverBufferTasks_PriorityTasksAhead(t *testing.T) {
+	cfg := defaultTestConfig()
+	cfg.GetTasksBatchSize = dynamicconfig.GetIntPropertyFilteredByTaskListInfo(3)
+	tlm := createTestTaskListManagerWithConfig(cfg)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		tlm.deliverBufferTasksForPoll()
+		wg.Done()
+	}()
+	// the queue and the task buffer are full with the backlog
+	for i := int64(1); i <= 5; i++ {
+		tlm.taskBuffer <- &persistence.TaskInfo{TaskID: i, Priority: 0}
+	}
+	// task read ahead of the backlog by its priority
+	tlm.priorityTaskBuffer <- &persistence.TaskInfo{TaskID: 9, Priority: 5}
+	time.Sleep(100 * time.Millisecond) // let go routine fill the priority queue
+
+	var taskIDs []int64
+	for i := 0; i < 6; i++ {
+		result := <-tlm.tasksForPoll
+		taskIDs = append(taskIDs, result.task.TaskID)
+	}
+	require.Equal(t, []int64{9, 1, 2, 3, 4, 5}, taskIDs)
+	close(tlm.deliverBufferShutdownCh)
+	wg.Wait()
+}
+
+func TestTaskPriorityQueue(t *testing.T) {
+	queue := newTaskPriorityQueue(func() int { return 0 })
+	require.Nil(t, queue.peek())
+	_, ok := queue.maxPriority()
+	require.False(t, ok)
+
+	queue.add(&persistence.TaskInfo{TaskID: 1, Priority: 1})
+	queue.add(&persistence.TaskInfo{TaskID: 2, Priority: 3})
+	queue.add(&persistence.TaskInfo{TaskID: 3, Priority: 2})
+	queue.add(&persistence.TaskInfo{TaskID: 4, Priority: 3})
+	require.Equal(t, 4, queue.len())
+	priority, ok := queue.maxPriority()
+	require.True(t, ok)
+	require.Equal(t, int32(3), priority)
+
+	var taskIDs []int64
+	for task := queue.peek(); task != nil; task = queue.peek() {
+		taskIDs = append(taskIDs, task.TaskID)
+		queue.remove(task)
+	}
+	require.Equal(t, []int64{2, 4, 3, 1}, taskIDs)
+	require.Equal(t, 0, queue.len())
+}
+
+func TestTaskPriorityQueue_Starvation(t *testing.T) {
+	queue := newTaskPriorityQueue(func() int { return 3 })
+	queue.add(&persistence.TaskInfo{TaskID: 1, Priority: 0})
+	for i := int64(2); i <= 6; i++ {
+		queue.add(&persistence.TaskInfo{TaskID: i, Priority: 10})
+	}
+
+	var taskIDs []int64
+	for task := queue.peek(); task != nil; task = queue.peek() {
+		taskIDs = append(taskIDs, task.TaskID)
+		queue.remove(task)
+	}
+	// every third dispatch picks the oldest task, so the low priority task does not wait for the whole backlog
+	require.Equal(t, []int64{2, 3, 1, 4, 5, 6}, taskIDs)
+}
+
+func TestTrySyncMatch_HigherPriorityBacklog(t *testing.T) {
+	tlm := createTestTaskListManager()
+	atomic.StoreInt64(&tlm.bufferedTaskPriority, 5)
+
+	polled := make(chan *getTaskResult, 1)
+	go func() {
+		select {
+		case result := <-tlm.tasksForPoll:
+			result.C <- &syncMatchResponse{response: &persistence.CreateTasksResponse{}}
+			polled <- result
+		case <-time.After(time.Second):
+			polled <- nil
+		}
+	}()
+	time.Sleep(100 * time.Millisecond) // let go routine run first and block on tasksForPoll
+
+	// lower priority than the backlog, the task has to be persisted
+	resp, err := tlm.trySyncMatch(&persistence.TaskInfo{TaskID: 1, Priority: 1})
+	require.NoError(t, err)
+	require.Nil(t, resp)
+
+	// same priority as the backlog, the task is handed to the waiting poller
+	resp, err = tlm.trySyncMatch(&persistence.TaskInfo{TaskID: 2, Priority: 5})
+	require.NoError(t, err)
+	require.NotNil(t, resp)
+	result := <-polled
+	require.NotNil(t, result)
+	require.Equal(t, int64(2), result.task.TaskID)
+}
+
+func TestPromoteBuildID(t *testing.T) {
+	buildSets, err := promoteBuildID(nil, "b1", "")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"b1"}}, buildSets)
+
+	buildSets, err = promoteBuildID(buildSets, "b2", "")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"b1"}, {"b2"}}, buildSets)
+
+	// compatible build joins the set and makes it the default one again
+	buildSets, err = promoteBuildID(buildSets, "b1.1", "b1")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"b2"}, {"b1", "b1.1"}}, buildSets)
+
+	// known build only moves its set to the end
+	buildSets, err = promoteBuildID(buildSets, "b2", "")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"b1", "b1.1"}, {"b2"}}, buildSets)
+	buildSets, err = promoteBuildID(buildSets, "b1", "b1.1")
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"b2"}, {"b1", "b1.1"}}, buildSets)
+
+	_, err = promoteBuildID(buildSets, "b3", "unknown")
+	require.IsType(t, &workflow.BadRequestError{}, err)
+	_, err = promoteBuildID(buildSets, "b2", "b1")
+	require.IsType(t, &workflow.BadRequestError{}, err)
+	_, err = promoteBuildID(buildSets, "", "")
+	require.IsType(t, &workflow.BadRequestError{}, err)
+}
+
+func TestBuildSetID(t *testing.T) {
+	buildSets := [][]string{{"b1", "b1.1"}, {"b2"}}
+
+	require.Equal(t, "", taskBuildSetID(nil, "b1"))
+	require.Equal(t, "b2", taskBuildSetID(buildSets, ""))
+	require.Equal(t, "b1", taskBuildSetID(buildSets, "b1.1"))
+	require.Equal(t, "b3", taskBuildSetID(buildSets, "b3"))
+
+	require.Equal(t, "", pollerBuildSetID(nil, "b1"))
+	require.Equal(t, "b2", pollerBuildSetID(buildSets, ""))
+	require.Equal(t, "b1", pollerBuildSetID(buildSets, "b1.1"))
+	require.Equal(t, "b3", pollerBuildSetID(buildSets, "b3"))
+
+	require.Equal(t, "", defaultBuildSetID(nil))
+	require.Equal(t, "b2", defaultBuildSetID(buildSets))
+}
+
 func TestNewRateLimiter(t *testing.T) {
 	maxDispatch := float64(0.01)
 	rl := newRateLimiter(&maxDispatch, time.Second, _minBurst)
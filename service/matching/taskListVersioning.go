@@ -0,0 +1,142 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"fmt"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+)
+
+// Decision task lists can be versioned by worker build IDs (the binary checksum reported by workers).
+// Builds known to be compatible are grouped into sets, which are stored on the task list in the order
+// they were promoted. The last set is the default one and receives decision tasks of new workflows.
+// A set is identified by its first build, and the builds of a set are never reordered so the ID of a
+// set does not change once it is created.
+//
+// Decision tasks of each set are dispatched through a separate physical task list, which is only
+// polled by workers reporting a build of that set. Workers which do not report a build poll the list of
+// the default set, like the decision tasks of workflows which are not pinned yet. The unversioned task
+// list still holds the tasks added before the task list got versioned, the pollers of the default set
+// drain it first as long as it has a backlog.
+const versionedTaskListPrefix = "/__cadence_build/"
+
+// versionedTaskListName returns the name of the physical task list of the given build set
+func versionedTaskListName(setID string, taskListName string) string {
+	return versionedTaskListPrefix + setID + "/" + taskListName
+}
+
+// findBuildSet returns the index of the set containing the build, or -1 if the build is unknown
+func findBuildSet(buildSets [][]string, buildID string) int {
+	for i, set := range buildSets {
+		for _, id := range set {
+			if id == buildID {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// buildSetID returns the ID of the set containing the build. An unknown build forms a set of its own,
+// so that workflows pinned to a build which is not promoted yet are only dispatched to that build.
+func buildSetID(buildSets [][]string, buildID string) string {
+	if i := findBuildSet(buildSets, buildID); i >= 0 {
+		return buildSets[i][0]
+	}
+	return buildID
+}
+
+// taskBuildSetID returns the ID of the build set a decision task of a workflow pinned to the given build
+// is dispatched to. Workflows which are not pinned yet go to the default set. Returns an empty string if
+// the task list is not versioned.
+func taskBuildSetID(buildSets [][]string, buildID string) string {
+	if len(buildSets) == 0 {
+		return ""
+	}
+	if buildID == "" {
+		return defaultBuildSetID(buildSets)
+	}
+	return buildSetID(buildSets, buildID)
+}
+
+// pollerBuildSetID returns the ID of the build set a poller reporting the given binary checksum
+// receives decision tasks from. Pollers which do not report their build receive the decision tasks
+// of the default set. Returns an empty string if the task list is not versioned.
+func pollerBuildSetID(buildSets [][]string, binaryChecksum string) string {
+	if len(buildSets) == 0 {
+		return ""
+	}
+	if binaryChecksum == "" {
+		return defaultBuildSetID(buildSets)
+	}
+	return buildSetID(buildSets, binaryChecksum)
+}
+
+// defaultBuildSetID returns the ID of the default build set, or an empty string if the task list
+// is not versioned
+func defaultBuildSetID(buildSets [][]string) string {
+	if len(buildSets) == 0 {
+		return ""
+	}
+	return buildSets[len(buildSets)-1][0]
+}
+
+// promoteBuildID returns a copy of the build sets with the given build made the default one for new
+// workflows. Without a compatible build the build starts a new set, unless it is known already in which
+// case its set is promoted. Otherwise the build joins the set of the compatible build, and that set
+// is promoted.
+func promoteBuildID(buildSets [][]string, buildID string, compatibleBuildID string) ([][]string, error) {
+	if buildID == "" {
+		return nil, &workflow.BadRequestError{Message: "BuildID is not set on request."}
+	}
+
+	index := findBuildSet(buildSets, buildID)
+	set := []string{buildID}
+	if compatibleBuildID != "" {
+		compatibleIndex := findBuildSet(buildSets, compatibleBuildID)
+		if compatibleIndex < 0 {
+			return nil, &workflow.BadRequestError{
+				Message: fmt.Sprintf("Compatible build %v is not known on the task list.", compatibleBuildID),
+			}
+		}
+		if index >= 0 && index != compatibleIndex {
+			return nil, &workflow.BadRequestError{
+				Message: fmt.Sprintf("Build %v is already in a set which is not compatible with build %v.", buildID, compatibleBuildID),
+			}
+		}
+		set = append([]string{}, buildSets[compatibleIndex]...)
+		if index < 0 {
+			set = append(set, buildID)
+		}
+		index = compatibleIndex
+	} else if index >= 0 {
+		set = buildSets[index]
+	}
+
+	result := make([][]string, 0, len(buildSets)+1)
+	for i, s := range buildSets {
+		if i != index {
+			result = append(result, s)
+		}
+	}
+	return append(result, set), nil
+}
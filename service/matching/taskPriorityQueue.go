@@ -0,0 +1,114 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package matching
+
+import (
+	"sort"
+
+	"github.com/uber/cadence/common/persistence"
+)
+
+// taskPriorityQueue orders tasks loaded from persistence for dispatch to pollers.
+// Tasks with a higher priority are dispatched first and tasks with the same priority
+// are dispatched in the order they were added. To avoid starving low priorities, every
+// starvationInterval-th dispatch picks the oldest task regardless of its priority.
+//
+// The backlog is loaded in task ID order, up to GetTasksBatchSize tasks at a time. Tasks with
+// a higher priority than the backlog are read ahead of it and added regardless of that limit.
+type taskPriorityQueue struct {
+	levels             map[int32][]*persistence.TaskInfo
+	priorities         []int32 // distinct priorities of queued tasks, highest first
+	size               int
+	dispatchCount      int
+	starvationInterval func() int
+}
+
+func newTaskPriorityQueue(starvationInterval func() int) *taskPriorityQueue {
+	return &taskPriorityQueue{
+		levels:             make(map[int32][]*persistence.TaskInfo),
+		starvationInterval: starvationInterval,
+	}
+}
+
+func (q *taskPriorityQueue) len() int {
+	return q.size
+}
+
+// add appends the task to the tail of its priority level
+func (q *taskPriorityQueue) add(task *persistence.TaskInfo) {
+	level, ok := q.levels[task.Priority]
+	if !ok {
+		i := sort.Search(len(q.priorities), func(i int) bool { return q.priorities[i] < task.Priority })
+		q.priorities = append(q.priorities, 0)
+		copy(q.priorities[i+1:], q.priorities[i:])
+		q.priorities[i] = task.Priority
+	}
+	q.levels[task.Priority] = append(level, task)
+	q.size++
+}
+
+// peek returns the task to dispatch next without removing it, nil if the queue is empty
+func (q *taskPriorityQueue) peek() *persistence.TaskInfo {
+	if q.size == 0 {
+		return nil
+	}
+	if interval := q.starvationInterval(); interval > 0 && (q.dispatchCount+1)%interval == 0 {
+		var oldest *persistence.TaskInfo
+		for _, priority := range q.priorities {
+			if head := q.levels[priority][0]; oldest == nil || head.TaskID < oldest.TaskID {
+				oldest = head
+			}
+		}
+		return oldest
+	}
+	return q.levels[q.priorities[0]][0]
+}
+
+// remove removes a task previously returned by peek and counts it as dispatched
+func (q *taskPriorityQueue) remove(task *persistence.TaskInfo) {
+	level := q.levels[task.Priority]
+	if len(level) == 0 || level[0] != task {
+		return
+	}
+	level[0] = nil
+	level = level[1:]
+	if len(level) == 0 {
+		delete(q.levels, task.Priority)
+		for i, priority := range q.priorities {
+			if priority == task.Priority {
+				q.priorities = append(q.priorities[:i], q.priorities[i+1:]...)
+				break
+			}
+		}
+	} else {
+		q.levels[task.Priority] = level
+	}
+	q.size--
+	q.dispatchCount++
+}
+
+// maxPriority returns the highest priority of queued tasks, false if the queue is empty
+func (q *taskPriorityQueue) maxPriority() (int32, bool) {
+	if q.size == 0 {
+		return 0, false
+	}
+	return q.priorities[0], true
+}
@@ -0,0 +1,386 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package domaindeleter
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/service/worker/sysworkflow"
+)
+
+// blobstoreOperationTimeout is the timeout of a single call to the blobstore
+const blobstoreOperationTimeout = 10 * time.Second
+
+type (
+	// Report is the summary of the data deleted for a domain
+	Report struct {
+		TaskListsDeleted         int64
+		TasksDeleted             int64
+		ExecutionsDeleted        int64
+		VisibilityRecordsDeleted int64
+		// ArchivedHistoryBlobsDeleted is the number of history blobs deleted from the archival bucket
+		ArchivedHistoryBlobsDeleted int64
+	}
+
+	// taskListsProgress is recorded with every heartbeat of the
+	// task lists activity, so that a retried activity resumes from
+	// the last page it processed
+	taskListsProgress struct {
+		NextPageToken []byte
+		Report        Report
+	}
+
+	// executionsProgress is recorded with every heartbeat of the
+	// executions activity, so that a retried activity resumes from
+	// the last page it processed
+	executionsProgress struct {
+		ShardID        int
+		NextPageToken  []byte
+		OpenExecutions int64
+		Report         Report
+	}
+
+	// dataDeleter deletes the task lists, executions, histories and visibility
+	// records of a single domain, one page at a time
+	dataDeleter struct {
+		domainID            string
+		domainName          string
+		taskManager         persistence.TaskManager
+		historyManager      persistence.HistoryManager
+		historyV2Manager    persistence.HistoryV2Manager
+		visibilityManager   persistence.VisibilityManager
+		blobstoreClient     blobstore.Client
+		getExecutionManager func(shardID int) (persistence.ExecutionManager, error)
+		numHistoryShards    int
+		rateLimiter         common.TokenBucket
+		pageSize            int
+		metricsClient       metrics.Client
+		logger              bark.Logger
+	}
+)
+
+func newDataDeleter(dc *deleterContext, domainID string, domainName string) *dataDeleter {
+	return &dataDeleter{
+		domainID:            domainID,
+		domainName:          domainName,
+		taskManager:         dc.taskManager,
+		historyManager:      dc.historyManager,
+		historyV2Manager:    dc.historyV2Manager,
+		visibilityManager:   dc.visibilityManager,
+		blobstoreClient:     dc.blobstoreClient,
+		getExecutionManager: dc.executionManagers.get,
+		numHistoryShards:    dc.numHistoryShards,
+		rateLimiter:         common.NewRealTimeTokenBucket(dc.cfg.PersistenceMaxQPS()),
+		pageSize:            dc.cfg.PageSize(),
+		metricsClient:       dc.metricsClient,
+		logger: dc.logger.WithFields(bark.Fields{
+			logging.TagDomainID: domainID,
+		}),
+	}
+}
+
+// deleteTaskLists pages through all the task lists and deletes the ones of the domain along with their tasks
+func (d *dataDeleter) deleteTaskLists(
+	ctx context.Context,
+	progress taskListsProgress,
+	heartbeat func(progress taskListsProgress),
+) (Report, error) {
+	for {
+		if err := d.waitForToken(ctx); err != nil {
+			return progress.Report, err
+		}
+		resp, err := d.taskManager.ListTaskList(&persistence.ListTaskListRequest{
+			PageSize:  d.pageSize,
+			PageToken: progress.NextPageToken,
+		})
+		if err != nil {
+			return progress.Report, d.handleFailure(err, "failed to list task lists")
+		}
+
+		for _, info := range resp.Items {
+			if info.DomainID != d.domainID {
+				continue
+			}
+			if err := d.deleteTaskList(ctx, info, &progress.Report); err != nil {
+				return progress.Report, d.handleFailure(err, "failed to delete task list")
+			}
+		}
+
+		progress.NextPageToken = resp.NextPageToken
+		heartbeat(progress)
+		if len(progress.NextPageToken) == 0 {
+			return progress.Report, nil
+		}
+	}
+}
+
+// deleteTaskList deletes all the tasks of the task list and then the task list itself
+func (d *dataDeleter) deleteTaskList(ctx context.Context, info persistence.TaskListInfo, report *Report) error {
+	for {
+		if err := d.waitForToken(ctx); err != nil {
+			return err
+		}
+		n, err := d.taskManager.CompleteTasksLessThan(&persistence.CompleteTasksLessThanRequest{
+			DomainID:     info.DomainID,
+			TaskListName: info.Name,
+			TaskType:     info.TaskType,
+			TaskID:       math.MaxInt64,
+			Limit:        d.pageSize,
+		})
+		if err != nil {
+			return err
+		}
+		if n == persistence.UnknownNumRowsAffected {
+			break
+		}
+		report.TasksDeleted += int64(n)
+		if n < d.pageSize {
+			break
+		}
+	}
+
+	if err := d.waitForToken(ctx); err != nil {
+		return err
+	}
+	// a ConditionFailedError means the task list was leased again since it was listed,
+	// it is returned so that the page is processed again once the poller is gone
+	err := d.taskManager.DeleteTaskList(&persistence.DeleteTaskListRequest{
+		DomainID:     info.DomainID,
+		TaskListName: info.Name,
+		TaskListType: info.TaskType,
+		RangeID:      info.RangeID,
+	})
+	if err != nil {
+		return err
+	}
+	report.TaskListsDeleted++
+	d.metricsClient.IncCounter(metrics.DomainDeleterScope, metrics.DomainDeleterTaskListsDeleted)
+	return nil
+}
+
+// deleteExecutions pages through the executions of all the shards and deletes the closed executions
+// of the domain along with their history. Open executions are kept and counted in the progress.
+func (d *dataDeleter) deleteExecutions(
+	ctx context.Context,
+	progress executionsProgress,
+	heartbeat func(progress executionsProgress),
+) (executionsProgress, error) {
+	for ; progress.ShardID < d.numHistoryShards; progress.ShardID++ {
+		executionManager, err := d.getExecutionManager(progress.ShardID)
+		if err != nil {
+			return progress, d.handleFailure(err, "failed to create execution manager")
+		}
+
+		for {
+			if err := d.waitForToken(ctx); err != nil {
+				return progress, err
+			}
+			resp, err := executionManager.ListConcreteExecutions(&persistence.ListConcreteExecutionsRequest{
+				PageSize:  d.pageSize,
+				PageToken: progress.NextPageToken,
+			})
+			if err != nil {
+				return progress, d.handleFailure(err, "failed to list executions")
+			}
+
+			for _, info := range resp.ExecutionInfos {
+				if info.DomainID != d.domainID {
+					continue
+				}
+				if info.State != persistence.WorkflowStateCompleted {
+					progress.OpenExecutions++
+					continue
+				}
+				if err := d.deleteExecution(ctx, executionManager, info); err != nil {
+					return progress, d.handleFailure(err, "failed to delete execution")
+				}
+				progress.Report.ExecutionsDeleted++
+				d.metricsClient.IncCounter(metrics.DomainDeleterScope, metrics.DomainDeleterExecutionsDeleted)
+			}
+
+			progress.NextPageToken = resp.PageToken
+			heartbeat(progress)
+			if len(progress.NextPageToken) == 0 {
+				break
+			}
+		}
+	}
+	return progress, nil
+}
+
+// deleteExecution deletes the mutable state, the current execution record and the history
+// of the execution, the history is deleted last so that a failure leaves at most an orphaned
+// history behind, which is cleaned up by the history scavenger
+func (d *dataDeleter) deleteExecution(
+	ctx context.Context,
+	executionManager persistence.ExecutionManager,
+	info *persistence.WorkflowExecutionInfo,
+) error {
+	if err := d.waitForToken(ctx); err != nil {
+		return err
+	}
+	err := executionManager.DeleteWorkflowExecution(&persistence.DeleteWorkflowExecutionRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := d.waitForToken(ctx); err != nil {
+		return err
+	}
+	err = executionManager.DeleteCurrentWorkflowExecution(&persistence.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := d.waitForToken(ctx); err != nil {
+		return err
+	}
+	if info.EventStoreVersion == persistence.EventStoreVersionV2 {
+		if d.historyV2Manager == nil {
+			return nil
+		}
+		return d.historyV2Manager.DeleteHistoryBranch(&persistence.DeleteHistoryBranchRequest{
+			BranchToken: info.BranchToken,
+		})
+	}
+	return d.historyManager.DeleteWorkflowExecutionHistory(&persistence.DeleteWorkflowExecutionHistoryRequest{
+		DomainID: info.DomainID,
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(info.WorkflowID),
+			RunId:      common.StringPtr(info.RunID),
+		},
+	})
+}
+
+// deleteVisibility deletes the visibility records of the domain
+func (d *dataDeleter) deleteVisibility(
+	ctx context.Context,
+	report Report,
+	heartbeat func(report Report),
+) (Report, error) {
+	for {
+		if err := d.waitForToken(ctx); err != nil {
+			return report, err
+		}
+		n, err := d.visibilityManager.DeleteDomainVisibility(&persistence.DeleteDomainVisibilityRequest{
+			DomainUUID: d.domainID,
+			Domain:     d.domainName,
+			Limit:      d.pageSize,
+		})
+		if err != nil {
+			return report, d.handleFailure(err, "failed to delete visibility records")
+		}
+		if n == persistence.UnknownNumRowsAffected {
+			return report, nil
+		}
+		report.VisibilityRecordsDeleted += int64(n)
+		d.metricsClient.AddCounter(metrics.DomainDeleterScope, metrics.DomainDeleterVisibilityRecordsDeleted, int64(n))
+		heartbeat(report)
+		if n < d.pageSize {
+			return report, nil
+		}
+	}
+}
+
+// deleteArchivedHistories deletes the history blobs of the domain from its archival bucket. The keys
+// listed by the prefix of the domain may belong to other domains, the domain of each blob is checked
+// against its tags before the blob is deleted.
+func (d *dataDeleter) deleteArchivedHistories(
+	ctx context.Context,
+	bucket string,
+	report Report,
+	heartbeat func(report Report),
+) (Report, error) {
+	if len(bucket) == 0 {
+		return report, nil
+	}
+
+	listCtx, cancel := context.WithTimeout(ctx, blobstoreOperationTimeout)
+	keys, err := d.blobstoreClient.ListByPrefix(listCtx, bucket, sysworkflow.HistoryBlobKeyPrefix(d.domainID))
+	cancel()
+	if err == blobstore.ErrBucketNotExists {
+		return report, nil
+	}
+	if err != nil {
+		return report, d.handleFailure(err, "failed to list archived histories")
+	}
+
+	for _, key := range keys {
+		if err := d.waitForToken(ctx); err != nil {
+			return report, err
+		}
+		downloadCtx, cancel := context.WithTimeout(ctx, blobstoreOperationTimeout)
+		historyBlob, err := d.blobstoreClient.Download(downloadCtx, bucket, key)
+		cancel()
+		if err == blobstore.ErrBlobNotExists {
+			continue
+		}
+		if err != nil {
+			return report, d.handleFailure(err, "failed to download archived history")
+		}
+		if sysworkflow.HistoryBlobDomainID(historyBlob.Tags) != d.domainID {
+			continue
+		}
+
+		if err := d.waitForToken(ctx); err != nil {
+			return report, err
+		}
+		deleteCtx, cancel := context.WithTimeout(ctx, blobstoreOperationTimeout)
+		deleted, err := d.blobstoreClient.Delete(deleteCtx, bucket, key)
+		cancel()
+		if err != nil {
+			return report, d.handleFailure(err, "failed to delete archived history")
+		}
+		if deleted {
+			report.ArchivedHistoryBlobsDeleted++
+			d.metricsClient.IncCounter(metrics.DomainDeleterScope, metrics.DomainDeleterArchivedHistoryBlobsDeleted)
+		}
+		heartbeat(report)
+	}
+	return report, nil
+}
+
+func (d *dataDeleter) handleFailure(err error, msg string) error {
+	d.metricsClient.IncCounter(metrics.DomainDeleterScope, metrics.DomainDeleterFailures)
+	d.logger.WithField(logging.TagErr, err).Error(msg)
+	return err
+}
+
+func (d *dataDeleter) waitForToken(ctx context.Context) error {
+	return common.WaitForToken(ctx, d.rateLimiter)
+}
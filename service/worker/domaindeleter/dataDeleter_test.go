@@ -0,0 +1,311 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package domaindeleter
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/blobstore/blob"
+	metricsMocks "github.com/uber/cadence/common/metrics/mocks"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/service/worker/sysworkflow"
+)
+
+const (
+	testDomainID   = "test-domain-id"
+	testDomainName = "test-domain"
+	testPageSize   = 10
+	testBucket     = "test-bucket"
+)
+
+type dataDeleterSuite struct {
+	*require.Assertions
+	suite.Suite
+	taskManager       *mocks.TaskManager
+	executionManagers []*mocks.ExecutionManager
+	historyManager    *mocks.HistoryManager
+	historyV2Manager  *mocks.HistoryV2Manager
+	visibilityManager *mocks.VisibilityManager
+	blobstoreClient   *mocks.BlobstoreClient
+	metricsClient     *metricsMocks.Client
+	deleter           *dataDeleter
+}
+
+func TestDataDeleterSuite(t *testing.T) {
+	suite.Run(t, new(dataDeleterSuite))
+}
+
+func (s *dataDeleterSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.taskManager = &mocks.TaskManager{}
+	s.executionManagers = []*mocks.ExecutionManager{{}, {}}
+	s.historyManager = &mocks.HistoryManager{}
+	s.historyV2Manager = &mocks.HistoryV2Manager{}
+	s.visibilityManager = &mocks.VisibilityManager{}
+	s.blobstoreClient = &mocks.BlobstoreClient{}
+	s.metricsClient = &metricsMocks.Client{}
+	s.metricsClient.On("IncCounter", mock.Anything, mock.Anything)
+	s.metricsClient.On("AddCounter", mock.Anything, mock.Anything, mock.Anything)
+	s.deleter = &dataDeleter{
+		domainID:          testDomainID,
+		domainName:        testDomainName,
+		taskManager:       s.taskManager,
+		historyManager:    s.historyManager,
+		historyV2Manager:  s.historyV2Manager,
+		visibilityManager: s.visibilityManager,
+		blobstoreClient:   s.blobstoreClient,
+		getExecutionManager: func(shardID int) (persistence.ExecutionManager, error) {
+			return s.executionManagers[shardID], nil
+		},
+		numHistoryShards: len(s.executionManagers),
+		rateLimiter:      common.NewTokenBucket(10000, common.NewRealTimeSource()),
+		pageSize:         testPageSize,
+		metricsClient:    s.metricsClient,
+		logger:           bark.NewNopLogger(),
+	}
+}
+
+func (s *dataDeleterSuite) TearDownTest() {
+	s.taskManager.AssertExpectations(s.T())
+	for _, executionManager := range s.executionManagers {
+		executionManager.AssertExpectations(s.T())
+	}
+	s.historyManager.AssertExpectations(s.T())
+	s.historyV2Manager.AssertExpectations(s.T())
+	s.visibilityManager.AssertExpectations(s.T())
+	s.blobstoreClient.AssertExpectations(s.T())
+}
+
+func (s *dataDeleterSuite) TestDeleteTaskLists() {
+	tl := persistence.TaskListInfo{DomainID: testDomainID, Name: "tl1", TaskType: persistence.TaskListTypeDecision, RangeID: 5}
+	other := persistence.TaskListInfo{DomainID: "other-domain-id", Name: "tl2", TaskType: persistence.TaskListTypeDecision}
+	s.taskManager.On("ListTaskList", &persistence.ListTaskListRequest{PageSize: testPageSize}).
+		Return(&persistence.ListTaskListResponse{Items: []persistence.TaskListInfo{tl, other}, NextPageToken: []byte("token")}, nil).Once()
+	s.taskManager.On("ListTaskList", &persistence.ListTaskListRequest{PageSize: testPageSize, PageToken: []byte("token")}).
+		Return(&persistence.ListTaskListResponse{}, nil).Once()
+	s.mockCompleteTasks(tl, testPageSize)
+	s.mockCompleteTasks(tl, 3)
+	s.taskManager.On("DeleteTaskList", &persistence.DeleteTaskListRequest{
+		DomainID:     tl.DomainID,
+		TaskListName: tl.Name,
+		TaskListType: tl.TaskType,
+		RangeID:      tl.RangeID,
+	}).Return(nil).Once()
+
+	var heartbeats []taskListsProgress
+	report, err := s.deleter.deleteTaskLists(context.Background(), taskListsProgress{}, func(progress taskListsProgress) {
+		heartbeats = append(heartbeats, progress)
+	})
+	s.NoError(err)
+	s.Equal(Report{TaskListsDeleted: 1, TasksDeleted: 13}, report)
+	s.Equal(2, len(heartbeats))
+	s.Equal([]byte("token"), heartbeats[0].NextPageToken)
+}
+
+func (s *dataDeleterSuite) TestDeleteTaskListsResumesFromProgress() {
+	s.taskManager.On("ListTaskList", &persistence.ListTaskListRequest{PageSize: testPageSize, PageToken: []byte("token")}).
+		Return(&persistence.ListTaskListResponse{}, nil).Once()
+
+	progress := taskListsProgress{NextPageToken: []byte("token"), Report: Report{TaskListsDeleted: 2}}
+	report, err := s.deleter.deleteTaskLists(context.Background(), progress, func(taskListsProgress) {})
+	s.NoError(err)
+	s.Equal(Report{TaskListsDeleted: 2}, report)
+}
+
+func (s *dataDeleterSuite) TestDeleteTaskListsLeaseLost() {
+	tl := persistence.TaskListInfo{DomainID: testDomainID, Name: "tl1", TaskType: persistence.TaskListTypeActivity, RangeID: 5}
+	s.taskManager.On("ListTaskList", &persistence.ListTaskListRequest{PageSize: testPageSize}).
+		Return(&persistence.ListTaskListResponse{Items: []persistence.TaskListInfo{tl}}, nil).Once()
+	s.mockCompleteTasks(tl, persistence.UnknownNumRowsAffected)
+	s.taskManager.On("DeleteTaskList", mock.Anything).
+		Return(&persistence.ConditionFailedError{Msg: "range id mismatch"}).Once()
+
+	_, err := s.deleter.deleteTaskLists(context.Background(), taskListsProgress{}, func(taskListsProgress) {})
+	s.Error(err)
+	s.IsType(&persistence.ConditionFailedError{}, err)
+}
+
+func (s *dataDeleterSuite) TestDeleteExecutions() {
+	closedV1 := s.newExecution("wf1", persistence.WorkflowStateCompleted, 0)
+	closedV2 := s.newExecution("wf2", persistence.WorkflowStateCompleted, persistence.EventStoreVersionV2)
+	running := s.newExecution("wf3", persistence.WorkflowStateRunning, 0)
+	other := s.newExecution("wf4", persistence.WorkflowStateCompleted, 0)
+	other.DomainID = "other-domain-id"
+
+	s.mockListExecutions(0, nil, []byte("token"), closedV1, other)
+	s.mockListExecutions(0, []byte("token"), nil, running)
+	s.mockListExecutions(1, nil, nil, closedV2)
+	s.mockDeleteExecution(0, closedV1)
+	s.mockDeleteExecution(1, closedV2)
+	s.historyManager.On("DeleteWorkflowExecutionHistory", &persistence.DeleteWorkflowExecutionHistoryRequest{
+		DomainID: testDomainID,
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(closedV1.WorkflowID),
+			RunId:      common.StringPtr(closedV1.RunID),
+		},
+	}).Return(nil).Once()
+	s.historyV2Manager.On("DeleteHistoryBranch", &persistence.DeleteHistoryBranchRequest{
+		BranchToken: closedV2.BranchToken,
+	}).Return(nil).Once()
+
+	var heartbeats []executionsProgress
+	progress, err := s.deleter.deleteExecutions(context.Background(), executionsProgress{}, func(progress executionsProgress) {
+		heartbeats = append(heartbeats, progress)
+	})
+	s.NoError(err)
+	s.Equal(int64(1), progress.OpenExecutions)
+	s.Equal(Report{ExecutionsDeleted: 2}, progress.Report)
+	s.Equal(3, len(heartbeats))
+}
+
+func (s *dataDeleterSuite) TestDeleteExecutionsFailure() {
+	closed := s.newExecution("wf1", persistence.WorkflowStateCompleted, 0)
+	s.mockListExecutions(1, []byte("token"), nil, closed)
+	s.executionManagers[1].On("DeleteWorkflowExecution", mock.Anything).Return(errors.New("some random error")).Once()
+
+	progress := executionsProgress{ShardID: 1, NextPageToken: []byte("token")}
+	progress, err := s.deleter.deleteExecutions(context.Background(), progress, func(executionsProgress) {})
+	s.Error(err)
+	s.Equal(1, progress.ShardID)
+	s.Equal([]byte("token"), progress.NextPageToken)
+	s.Equal(Report{}, progress.Report)
+}
+
+func (s *dataDeleterSuite) TestDeleteVisibility() {
+	s.mockDeleteVisibility(testPageSize)
+	s.mockDeleteVisibility(4)
+
+	report, err := s.deleter.deleteVisibility(context.Background(), Report{}, func(Report) {})
+	s.NoError(err)
+	s.Equal(Report{VisibilityRecordsDeleted: testPageSize + 4}, report)
+}
+
+func (s *dataDeleterSuite) TestDeleteVisibilityUnknownRowsAffected() {
+	s.mockDeleteVisibility(persistence.UnknownNumRowsAffected)
+
+	report, err := s.deleter.deleteVisibility(context.Background(), Report{}, func(Report) {})
+	s.NoError(err)
+	s.Equal(Report{}, report)
+}
+
+func (s *dataDeleterSuite) TestDeleteArchivedHistories() {
+	key, err := sysworkflow.NewHistoryBlobKey(testDomainID, "workflow-id", "run-id", "1")
+	s.NoError(err)
+	otherKey, err := sysworkflow.NewHistoryBlobKey("other-domain-id", "workflow-id", "run-id", "1")
+	s.NoError(err)
+	s.blobstoreClient.On("ListByPrefix", mock.Anything, testBucket, sysworkflow.HistoryBlobKeyPrefix(testDomainID)).
+		Return([]blob.Key{key, otherKey}, nil).Once()
+	s.blobstoreClient.On("Download", mock.Anything, testBucket, key).
+		Return(s.newHistoryBlob(testDomainID), nil).Once()
+	s.blobstoreClient.On("Download", mock.Anything, testBucket, otherKey).
+		Return(s.newHistoryBlob("other-domain-id"), nil).Once()
+	s.blobstoreClient.On("Delete", mock.Anything, testBucket, key).Return(true, nil).Once()
+
+	report, err := s.deleter.deleteArchivedHistories(context.Background(), testBucket, Report{}, func(Report) {})
+	s.NoError(err)
+	s.Equal(Report{ArchivedHistoryBlobsDeleted: 1}, report)
+}
+
+func (s *dataDeleterSuite) TestDeleteArchivedHistoriesNoBucket() {
+	report, err := s.deleter.deleteArchivedHistories(context.Background(), "", Report{}, func(Report) {})
+	s.NoError(err)
+	s.Equal(Report{}, report)
+
+	s.blobstoreClient.On("ListByPrefix", mock.Anything, testBucket, sysworkflow.HistoryBlobKeyPrefix(testDomainID)).
+		Return(nil, blobstore.ErrBucketNotExists).Once()
+	report, err = s.deleter.deleteArchivedHistories(context.Background(), testBucket, Report{}, func(Report) {})
+	s.NoError(err)
+	s.Equal(Report{}, report)
+}
+
+func (s *dataDeleterSuite) mockCompleteTasks(tl persistence.TaskListInfo, resp int) {
+	s.taskManager.On("CompleteTasksLessThan", &persistence.CompleteTasksLessThanRequest{
+		DomainID:     tl.DomainID,
+		TaskListName: tl.Name,
+		TaskType:     tl.TaskType,
+		TaskID:       math.MaxInt64,
+		Limit:        testPageSize,
+	}).Return(resp, nil).Once()
+}
+
+func (s *dataDeleterSuite) mockListExecutions(
+	shardID int,
+	pageToken []byte,
+	nextPageToken []byte,
+	executions ...*persistence.WorkflowExecutionInfo,
+) {
+	s.executionManagers[shardID].On("ListConcreteExecutions", &persistence.ListConcreteExecutionsRequest{
+		PageSize:  testPageSize,
+		PageToken: pageToken,
+	}).Return(&persistence.ListConcreteExecutionsResponse{
+		ExecutionInfos: executions,
+		PageToken:      nextPageToken,
+	}, nil).Once()
+}
+
+func (s *dataDeleterSuite) mockDeleteExecution(shardID int, info *persistence.WorkflowExecutionInfo) {
+	s.executionManagers[shardID].On("DeleteWorkflowExecution", &persistence.DeleteWorkflowExecutionRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+	}).Return(nil).Once()
+	s.executionManagers[shardID].On("DeleteCurrentWorkflowExecution", &persistence.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+	}).Return(nil).Once()
+}
+
+func (s *dataDeleterSuite) mockDeleteVisibility(resp int) {
+	s.visibilityManager.On("DeleteDomainVisibility", &persistence.DeleteDomainVisibilityRequest{
+		DomainUUID: testDomainID,
+		Domain:     testDomainName,
+		Limit:      testPageSize,
+	}).Return(resp, nil).Once()
+}
+
+func (s *dataDeleterSuite) newHistoryBlob(domainID string) *blob.Blob {
+	tags, err := sysworkflow.ConvertHeaderToTags(&sysworkflow.HistoryBlobHeader{DomainID: common.StringPtr(domainID)})
+	s.NoError(err)
+	return blob.NewBlob([]byte("history"), tags)
+}
+
+func (s *dataDeleterSuite) newExecution(workflowID string, state int, eventStoreVersion int32) *persistence.WorkflowExecutionInfo {
+	return &persistence.WorkflowExecutionInfo{
+		DomainID:          testDomainID,
+		WorkflowID:        workflowID,
+		RunID:             workflowID + "-run-id",
+		State:             state,
+		EventStoreVersion: eventStoreVersion,
+		BranchToken:       []byte(workflowID + "-branch-token"),
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package domaindeleter
+
+import (
+	"context"
+	"sync"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/client"
+	"github.com/uber/cadence/client/public"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/blobstore"
+	"github.com/uber/cadence/common/cluster"
+	"github.com/uber/cadence/common/domaindeletion"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	persistencefactory "github.com/uber/cadence/common/persistence/persistence-factory"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"go.uber.org/cadence/worker"
+)
+
+type (
+	// Config defines the configuration for the domain deleter
+	Config struct {
+		// Enabled indicates whether domains can be deleted
+		Enabled dynamicconfig.BoolPropertyFn
+		// PersistenceMaxQPS is the max qps a single deletion activity can query DB
+		PersistenceMaxQPS dynamicconfig.IntPropertyFn
+		// PageSize is the number of records read / deleted per DB call
+		PageSize dynamicconfig.IntPropertyFn
+		// EnableReadVisibilityFromES is whether the open workflows of a domain are listed from ElasticSearch
+		EnableReadVisibilityFromES dynamicconfig.BoolPropertyFnWithDomainFilter
+	}
+
+	// BootstrapParams contains the set of params needed to bootstrap the domain deleter
+	BootstrapParams struct {
+		// Config contains the configuration for the domain deleter
+		Config Config
+		// PublicClient is the cadence client used to run the domain deletion workflows
+		PublicClient public.Client
+		// ClientBean provides the frontend clients of the remote clusters
+		ClientBean client.Bean
+		// ClusterMetadata is the metadata of the cluster
+		ClusterMetadata cluster.Metadata
+		// PersistenceFactory creates the persistence managers used by the domain deleter
+		PersistenceFactory persistencefactory.Factory
+		// VisibilityManager deletes the visibility records, from all the visibility stores in use
+		VisibilityManager persistence.VisibilityManager
+		// BlobstoreClient deletes the archived histories
+		BlobstoreClient blobstore.Client
+		// NumHistoryShards is the number of history shards of the cluster
+		NumHistoryShards int
+		// MetricsClient is an instance of metrics object for emitting stats
+		MetricsClient metrics.Client
+		// Logger is the logger
+		Logger bark.Logger
+	}
+
+	// deleterContext is the context object that gets
+	// passed around within the domain deletion activities
+	deleterContext struct {
+		cfg               Config
+		clientBean        client.Bean
+		clusterMetadata   cluster.Metadata
+		metadataManager   persistence.MetadataManager
+		visibilityManager persistence.VisibilityManager
+		blobstoreClient   blobstore.Client
+		taskManager       persistence.TaskManager
+		historyManager    persistence.HistoryManager
+		historyV2Manager  persistence.HistoryV2Manager
+		executionManagers *executionManagers
+		numHistoryShards  int
+		metricsClient     metrics.Client
+		logger            bark.Logger
+	}
+
+	// executionManagers lazily creates and caches the execution manager of each history shard
+	executionManagers struct {
+		sync.Mutex
+		factory  persistencefactory.Factory
+		managers map[int]persistence.ExecutionManager
+	}
+
+	// Deleter is the background sub-system that hosts the workflow which
+	// deletes all the data of a deprecated domain, the workflow is started
+	// on demand by an operator
+	Deleter struct {
+		context      deleterContext
+		publicClient public.Client
+		worker       worker.Worker
+	}
+
+	contextKey int
+)
+
+const (
+	deleterContextKey contextKey = iota
+)
+
+// New returns a new instance of the domain deleter
+func New(params *BootstrapParams) *Deleter {
+	logger := params.Logger.WithFields(bark.Fields{
+		logging.TagWorkflowComponent: logging.TagValueDomainDeleterComponent,
+	})
+	return &Deleter{
+		context: deleterContext{
+			cfg:               params.Config,
+			clientBean:        params.ClientBean,
+			clusterMetadata:   params.ClusterMetadata,
+			visibilityManager: params.VisibilityManager,
+			blobstoreClient:   params.BlobstoreClient,
+			executionManagers: &executionManagers{
+				factory:  params.PersistenceFactory,
+				managers: make(map[int]persistence.ExecutionManager),
+			},
+			numHistoryShards: params.NumHistoryShards,
+			metricsClient:    params.MetricsClient,
+			logger:           logger,
+		},
+		publicClient: params.PublicClient,
+	}
+}
+
+// Start creates the persistence managers and starts polling for domain deletion tasks
+func (d *Deleter) Start() error {
+	factory := d.context.executionManagers.factory
+
+	metadataManager, err := factory.NewMetadataManager(persistencefactory.MetadataV1V2)
+	if err != nil {
+		return err
+	}
+	d.context.metadataManager = metadataManager
+
+	taskManager, err := factory.NewTaskManager()
+	if err != nil {
+		return err
+	}
+	d.context.taskManager = taskManager
+
+	historyManager, err := factory.NewHistoryManager()
+	if err != nil {
+		return err
+	}
+	d.context.historyManager = historyManager
+
+	historyV2Manager, err := factory.NewHistoryV2Manager()
+	if err != nil {
+		// not every persistence plugin supports events v2, there is no v2 history to delete then
+		d.context.logger.WithField(logging.TagErr, err).Warn("history V2 is not available, only history V1 will be deleted")
+	} else {
+		d.context.historyV2Manager = historyV2Manager
+	}
+
+	workerOpts := worker.Options{
+		BackgroundActivityContext: context.WithValue(context.Background(), deleterContextKey, &d.context),
+	}
+	d.worker = worker.New(d.publicClient, common.SystemDomainName, domaindeletion.TaskListName, workerOpts)
+	return d.worker.Start()
+}
+
+// Stop stops the domain deleter
+func (d *Deleter) Stop() {
+	if d.worker != nil {
+		d.worker.Stop()
+	}
+	if d.context.metadataManager != nil {
+		d.context.metadataManager.Close()
+	}
+	if d.context.taskManager != nil {
+		d.context.taskManager.Close()
+	}
+	if d.context.historyManager != nil {
+		d.context.historyManager.Close()
+	}
+	if d.context.historyV2Manager != nil {
+		d.context.historyV2Manager.Close()
+	}
+	if d.context.visibilityManager != nil {
+		d.context.visibilityManager.Close()
+	}
+	d.context.executionManagers.close()
+}
+
+// get returns the execution manager of the given shard
+func (m *executionManagers) get(shardID int) (persistence.ExecutionManager, error) {
+	m.Lock()
+	defer m.Unlock()
+	if mgr, ok := m.managers[shardID]; ok {
+		return mgr, nil
+	}
+	mgr, err := m.factory.NewExecutionManager(shardID)
+	if err != nil {
+		return nil, err
+	}
+	m.managers[shardID] = mgr
+	return mgr, nil
+}
+
+func (m *executionManagers) close() {
+	m.Lock()
+	defer m.Unlock()
+	for shardID, mgr := range m.managers {
+		mgr.Close()
+		delete(m.managers, shardID)
+	}
+}
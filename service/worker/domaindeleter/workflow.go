@@ -0,0 +1,406 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package domaindeleter
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/domaindeletion"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/workflow"
+)
+
+type (
+	// domainInfo is the result of the validation of the domain
+	domainInfo struct {
+		ID             string
+		Name           string
+		IsGlobal       bool
+		RemoteClusters []string
+		ArchivalBucket string
+	}
+)
+
+const (
+	validateActivityName         = "cadence-sys-domain-deleter-validate-activity"
+	deleteRemoteActivityName     = "cadence-sys-domain-deleter-delete-remote-activity"
+	deleteTaskListsActivityName  = "cadence-sys-domain-deleter-delete-tasklists-activity"
+	deleteExecutionsActivityName = "cadence-sys-domain-deleter-delete-executions-activity"
+	deleteVisibilityActivityName = "cadence-sys-domain-deleter-delete-visibility-activity"
+	deleteArchivalActivityName   = "cadence-sys-domain-deleter-delete-archival-activity"
+	deleteDomainActivityName     = "cadence-sys-domain-deleter-delete-domain-activity"
+
+	// maxDeletionDuration is the max time deleting the data of the domain from a single store may take
+	maxDeletionDuration = 7 * 24 * time.Hour
+
+	errDeletionDisabledStr    = "cadence-sys-domain-deleter-deletion-disabled"
+	errDomainNotExistsStr     = "cadence-sys-domain-deleter-domain-not-exists"
+	errDomainNotDeprecatedStr = "cadence-sys-domain-deleter-domain-not-deprecated"
+	errDomainNotDrainedStr    = "cadence-sys-domain-deleter-domain-not-drained"
+	errNotMasterClusterStr    = "cadence-sys-domain-deleter-not-master-cluster"
+)
+
+var (
+	errDeletionDisabled    = cadence.NewCustomError(errDeletionDisabledStr)
+	errDomainNotExists     = cadence.NewCustomError(errDomainNotExistsStr)
+	errDomainNotDeprecated = cadence.NewCustomError(errDomainNotDeprecatedStr)
+	errDomainNotDrained    = cadence.NewCustomError(errDomainNotDrainedStr)
+	errNotMasterCluster    = cadence.NewCustomError(errNotMasterClusterStr)
+
+	nonRetriableErrorReasons = []string{
+		errDeletionDisabledStr,
+		errDomainNotExistsStr,
+		errDomainNotDeprecatedStr,
+		errDomainNotDrainedStr,
+		errNotMasterClusterStr,
+	}
+
+	shortActivityOptions = workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          10 * time.Second,
+			BackoffCoefficient:       1.7,
+			MaximumInterval:          10 * time.Minute,
+			ExpirationInterval:       time.Hour,
+			NonRetriableErrorReasons: nonRetriableErrorReasons,
+		},
+	}
+
+	deletionActivityOptions = workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    maxDeletionDuration,
+		HeartbeatTimeout:       5 * time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:          10 * time.Second,
+			BackoffCoefficient:       1.7,
+			MaximumInterval:          10 * time.Minute,
+			ExpirationInterval:       maxDeletionDuration,
+			NonRetriableErrorReasons: nonRetriableErrorReasons,
+		},
+	}
+)
+
+func init() {
+	workflow.RegisterWithOptions(DomainDeletionWorkflow, workflow.RegisterOptions{Name: domaindeletion.WorkflowTypeName})
+	activity.RegisterWithOptions(ValidateActivity, activity.RegisterOptions{Name: validateActivityName})
+	activity.RegisterWithOptions(DeleteRemoteActivity, activity.RegisterOptions{Name: deleteRemoteActivityName})
+	activity.RegisterWithOptions(DeleteTaskListsActivity, activity.RegisterOptions{Name: deleteTaskListsActivityName})
+	activity.RegisterWithOptions(DeleteExecutionsActivity, activity.RegisterOptions{Name: deleteExecutionsActivityName})
+	activity.RegisterWithOptions(DeleteVisibilityActivity, activity.RegisterOptions{Name: deleteVisibilityActivityName})
+	activity.RegisterWithOptions(DeleteArchivalActivity, activity.RegisterOptions{Name: deleteArchivalActivityName})
+	activity.RegisterWithOptions(DeleteDomainActivity, activity.RegisterOptions{Name: deleteDomainActivityName})
+}
+
+// DomainDeletionWorkflow deletes all the data of a deprecated domain which has no open workflow left.
+// For a global domain, the copies of the domain in the remote clusters are deleted first, so that
+// the domain in the master cluster is only gone once the deletion completed everywhere.
+func DomainDeletionWorkflow(ctx workflow.Context, params domaindeletion.Params) (Report, error) {
+	logger := workflow.GetLogger(ctx)
+
+	var report Report
+	var info domainInfo
+	shortCtx := workflow.WithActivityOptions(ctx, shortActivityOptions)
+	if err := workflow.ExecuteActivity(shortCtx, validateActivityName, params).Get(ctx, &info); err != nil {
+		return report, err
+	}
+
+	deletionCtx := workflow.WithActivityOptions(ctx, deletionActivityOptions)
+	for _, cluster := range info.RemoteClusters {
+		if err := workflow.ExecuteActivity(deletionCtx, deleteRemoteActivityName, cluster, info.Name).Get(ctx, nil); err != nil {
+			return report, err
+		}
+		logger.Info("domain deleted from remote cluster " + cluster)
+	}
+
+	for _, activityName := range []string{
+		deleteTaskListsActivityName,
+		deleteExecutionsActivityName,
+		deleteVisibilityActivityName,
+		deleteArchivalActivityName,
+	} {
+		var result Report
+		if err := workflow.ExecuteActivity(deletionCtx, activityName, info).Get(ctx, &result); err != nil {
+			return report, err
+		}
+		report.TaskListsDeleted += result.TaskListsDeleted
+		report.TasksDeleted += result.TasksDeleted
+		report.ExecutionsDeleted += result.ExecutionsDeleted
+		report.VisibilityRecordsDeleted += result.VisibilityRecordsDeleted
+		report.ArchivedHistoryBlobsDeleted += result.ArchivedHistoryBlobsDeleted
+	}
+
+	if err := workflow.ExecuteActivity(shortCtx, deleteDomainActivityName, info).Get(ctx, nil); err != nil {
+		return report, err
+	}
+	logger.Info("domain " + info.Name + " deleted")
+	return report, nil
+}
+
+// ValidateActivity checks that the domain can be deleted. The deletion of a global domain must be started in the
+// master cluster, the copy of the domain in a remote cluster is deprecated when the master cluster starts its deletion.
+// Deletion must be enabled by the dynamic config of every cluster the domain is deleted from.
+func ValidateActivity(ctx context.Context, params domaindeletion.Params) (domainInfo, error) {
+	dc := ctx.Value(deleterContextKey).(*deleterContext)
+	if !dc.cfg.Enabled() {
+		return domainInfo{}, errDeletionDisabled
+	}
+
+	// the notification version must be read before the domain, see the DeprecateDomain API of the frontend
+	metadata, err := dc.metadataManager.GetMetadata()
+	if err != nil {
+		return domainInfo{}, err
+	}
+	resp, err := dc.metadataManager.GetDomain(&persistence.GetDomainRequest{Name: params.DomainName})
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); ok {
+			return domainInfo{}, errDomainNotExists
+		}
+		return domainInfo{}, err
+	}
+
+	info := domainInfo{
+		ID:             resp.Info.ID,
+		Name:           resp.Info.Name,
+		IsGlobal:       resp.IsGlobalDomain,
+		ArchivalBucket: resp.Config.ArchivalBucket,
+	}
+	if info.IsGlobal && !params.Remote {
+		if !dc.clusterMetadata.IsMasterCluster() {
+			return domainInfo{}, errNotMasterCluster
+		}
+		currentCluster := dc.clusterMetadata.GetCurrentClusterName()
+		for _, cluster := range resp.ReplicationConfig.Clusters {
+			if cluster.ClusterName != currentCluster {
+				info.RemoteClusters = append(info.RemoteClusters, cluster.ClusterName)
+			}
+		}
+	}
+
+	if resp.Info.Status != persistence.DomainStatusDeprecated {
+		if !params.Remote {
+			return domainInfo{}, errDomainNotDeprecated
+		}
+		if err := deprecateDomain(dc.metadataManager, resp, metadata.NotificationVersion); err != nil {
+			return domainInfo{}, err
+		}
+	}
+
+	openExecutions, err := dc.visibilityManager.ListOpenWorkflowExecutions(&persistence.ListWorkflowExecutionsRequest{
+		DomainUUID:        info.ID,
+		Domain:            info.Name,
+		EarliestStartTime: 0,
+		LatestStartTime:   time.Now().UnixNano(),
+		PageSize:          1,
+	})
+	if err != nil {
+		return domainInfo{}, err
+	}
+	if len(openExecutions.Executions) > 0 {
+		return domainInfo{}, errDomainNotDrained
+	}
+	return info, nil
+}
+
+// DeleteRemoteActivity starts the deletion of the domain in the remote cluster and waits for it to complete
+func DeleteRemoteActivity(ctx context.Context, cluster string, domainName string) error {
+	dc := ctx.Value(deleterContextKey).(*deleterContext)
+	logger := dc.logger.WithFields(bark.Fields{
+		logging.TagDomainID: domainName,
+		"cluster":           cluster,
+	})
+	frontendClient := dc.clientBean.GetRemoteFrontendClient(cluster)
+
+	identity := domaindeletion.WorkflowIDPrefix + dc.clusterMetadata.GetCurrentClusterName()
+	request, err := domaindeletion.NewStartWorkflowRequest(domaindeletion.Params{DomainName: domainName, Remote: true}, identity)
+	if err != nil {
+		return err
+	}
+	if _, err := frontendClient.StartWorkflowExecution(ctx, request); err != nil {
+		if _, ok := err.(*shared.WorkflowExecutionAlreadyStartedError); !ok {
+			logger.WithField(logging.TagErr, err).Error("failed to start remote domain deletion")
+			return err
+		}
+	}
+
+	var nextPageToken []byte
+	for {
+		activity.RecordHeartbeat(ctx)
+		resp, err := frontendClient.GetWorkflowExecutionHistory(ctx, &shared.GetWorkflowExecutionHistoryRequest{
+			Domain:                 common.StringPtr(common.SystemDomainName),
+			Execution:              &shared.WorkflowExecution{WorkflowId: request.WorkflowId},
+			WaitForNewEvent:        common.BoolPtr(true),
+			HistoryEventFilterType: shared.HistoryEventFilterTypeCloseEvent.Ptr(),
+			NextPageToken:          nextPageToken,
+		})
+		if err != nil {
+			return err
+		}
+		if resp.History != nil && len(resp.History.Events) > 0 {
+			return remoteDeletionResult(resp.History.Events[len(resp.History.Events)-1])
+		}
+		nextPageToken = resp.NextPageToken
+	}
+}
+
+// remoteDeletionResult turns the close event of a remote deletion workflow into the result of the
+// activity, a domain which does not exist (anymore) in the remote cluster counts as deleted
+func remoteDeletionResult(event *shared.HistoryEvent) error {
+	switch event.GetEventType() {
+	case shared.EventTypeWorkflowExecutionCompleted:
+		return nil
+	case shared.EventTypeWorkflowExecutionFailed:
+		attributes := event.WorkflowExecutionFailedEventAttributes
+		if attributes.GetReason() == errDomainNotExistsStr {
+			return nil
+		}
+		return fmt.Errorf("remote domain deletion failed: %v", attributes.GetReason())
+	default:
+		return fmt.Errorf("remote domain deletion closed with event %v", event.GetEventType())
+	}
+}
+
+// DeleteTaskListsActivity deletes the task lists of the domain
+func DeleteTaskListsActivity(ctx context.Context, info domainInfo) (Report, error) {
+	dc := ctx.Value(deleterContextKey).(*deleterContext)
+
+	var progress taskListsProgress
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &progress); err != nil {
+			dc.logger.WithField(logging.TagErr, err).Error("failed to read task lists deletion progress, starting over")
+			progress = taskListsProgress{}
+		}
+	}
+
+	return newDataDeleter(dc, info.ID, info.Name).deleteTaskLists(ctx, progress, func(progress taskListsProgress) {
+		activity.RecordHeartbeat(ctx, progress)
+	})
+}
+
+// DeleteExecutionsActivity deletes the closed executions of the domain and their history,
+// it fails if an open execution is found
+func DeleteExecutionsActivity(ctx context.Context, info domainInfo) (Report, error) {
+	dc := ctx.Value(deleterContextKey).(*deleterContext)
+
+	var progress executionsProgress
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &progress); err != nil {
+			dc.logger.WithField(logging.TagErr, err).Error("failed to read executions deletion progress, starting over")
+			progress = executionsProgress{}
+		}
+	}
+
+	progress, err := newDataDeleter(dc, info.ID, info.Name).deleteExecutions(ctx, progress, func(progress executionsProgress) {
+		activity.RecordHeartbeat(ctx, progress)
+	})
+	if err != nil {
+		return progress.Report, err
+	}
+	if progress.OpenExecutions > 0 {
+		dc.logger.WithFields(bark.Fields{
+			logging.TagDomainID: info.ID,
+			"open-executions":   progress.OpenExecutions,
+		}).Error("domain has open executions, it cannot be deleted")
+		return progress.Report, errDomainNotDrained
+	}
+	return progress.Report, nil
+}
+
+// DeleteVisibilityActivity deletes the visibility records of the domain
+func DeleteVisibilityActivity(ctx context.Context, info domainInfo) (Report, error) {
+	dc := ctx.Value(deleterContextKey).(*deleterContext)
+
+	var report Report
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &report); err != nil {
+			report = Report{}
+		}
+	}
+
+	return newDataDeleter(dc, info.ID, info.Name).deleteVisibility(ctx, report, func(report Report) {
+		activity.RecordHeartbeat(ctx, report)
+	})
+}
+
+// DeleteArchivalActivity deletes the histories of the domain archived to the blobstore
+func DeleteArchivalActivity(ctx context.Context, info domainInfo) (Report, error) {
+	dc := ctx.Value(deleterContextKey).(*deleterContext)
+
+	var report Report
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &report); err != nil {
+			report = Report{}
+		}
+	}
+
+	return newDataDeleter(dc, info.ID, info.Name).deleteArchivedHistories(ctx, info.ArchivalBucket, report, func(report Report) {
+		activity.RecordHeartbeat(ctx, report)
+	})
+}
+
+// DeleteDomainActivity deletes the domain from the metadata store
+func DeleteDomainActivity(ctx context.Context, info domainInfo) error {
+	dc := ctx.Value(deleterContextKey).(*deleterContext)
+
+	if err := dc.metadataManager.DeleteDomain(&persistence.DeleteDomainRequest{ID: info.ID}); err != nil {
+		return err
+	}
+	dc.metricsClient.IncCounter(metrics.DomainDeleterScope, metrics.DomainDeleterDomainsDeleted)
+	dc.logger.WithField(logging.TagDomainID, info.ID).Infof("domain %v deleted", info.Name)
+	return nil
+}
+
+// deprecateDomain marks the copy of a global domain in a remote cluster as deprecated,
+// deprecation is not replicated from the master cluster
+func deprecateDomain(
+	metadataManager persistence.MetadataManager,
+	resp *persistence.GetDomainResponse,
+	notificationVersion int64,
+) error {
+	resp.Info.Status = persistence.DomainStatusDeprecated
+	request := &persistence.UpdateDomainRequest{
+		Info:              resp.Info,
+		Config:            resp.Config,
+		ReplicationConfig: resp.ReplicationConfig,
+		ConfigVersion:     resp.ConfigVersion + 1,
+		FailoverVersion:   resp.FailoverVersion,
+		TableVersion:      resp.TableVersion,
+	}
+	switch resp.TableVersion {
+	case persistence.DomainTableVersionV1:
+		request.NotificationVersion = resp.NotificationVersion
+	case persistence.DomainTableVersionV2:
+		request.FailoverNotificationVersion = resp.FailoverNotificationVersion
+		request.NotificationVersion = notificationVersion
+	default:
+		return fmt.Errorf("domain table version is not set")
+	}
+	return metadataManager.UpdateDomain(request)
+}
@@ -25,7 +25,9 @@ import (
 	"encoding/json"
 	"github.com/olivere/elastic"
 	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/indexer"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/codec"
 	"github.com/uber/cadence/common/collection"
 	es "github.com/uber/cadence/common/elasticsearch"
 	"github.com/uber/cadence/common/logging"
@@ -59,6 +61,8 @@ type (
 	esProcessorImpl struct {
 		processor     ElasticBulkProcessor
 		mapToKafkaMsg collection.ConcurrentTxMap // used to map ES request to kafka message
+		dlqProducer   messaging.Producer         // optional, receives messages rejected by ES
+		msgEncoder    codec.BinaryEncoder
 		config        *Config
 		logger        bark.Logger
 		metricsClient metrics.Client
@@ -75,10 +79,13 @@ const (
 )
 
 // NewESProcessorAndStart create new ESProcessor and start
-func NewESProcessorAndStart(config *Config, client es.Client, processorName string,
+// dlqProducer is optional, when it is nil messages rejected by ES are nacked
+func NewESProcessorAndStart(config *Config, client es.Client, processorName string, dlqProducer messaging.Producer,
 	logger bark.Logger, metricsClient metrics.Client) (ESProcessor, error) {
 	p := &esProcessorImpl{
-		config: config,
+		dlqProducer: dlqProducer,
+		msgEncoder:  codec.NewThriftRWEncoder(),
+		config:      config,
 		logger: logger.WithFields(bark.Fields{
 			logging.TagWorkflowComponent: logging.TagValueIndexerESProcessorComponent,
 		}),
@@ -107,6 +114,9 @@ func NewESProcessorAndStart(config *Config, client es.Client, processorName stri
 func (p *esProcessorImpl) Stop() {
 	p.processor.Stop()
 	p.mapToKafkaMsg = nil
+	if p.dlqProducer != nil {
+		p.dlqProducer.Close()
+	}
 }
 
 // Add an ES request, and an map item for kafka message
@@ -150,7 +160,7 @@ func (p *esProcessorImpl) bulkAfterAction(id int64, requests []elastic.BulkableR
 			case isResponseSuccess(resp.Status):
 				p.ackKafkaMsg(key)
 			case !isResponseRetriable(resp.Status):
-				p.nackKafkaMsg(key)
+				p.sendToDLQ(key, resp)
 			default:
 				// do nothing, bulk processor will retry
 			}
@@ -158,6 +168,57 @@ func (p *esProcessorImpl) bulkAfterAction(id int64, requests []elastic.BulkableR
 	}
 }
 
+// sendToDLQ writes the kafka message with the ES error to DLQ and acks it,
+// message is nacked if there is no DLQ producer or writing to DLQ failed
+func (p *esProcessorImpl) sendToDLQ(key string, resp *elastic.BulkResponseItem) {
+	if p.dlqProducer == nil {
+		p.nackKafkaMsg(key)
+		return
+	}
+	msg, ok := p.mapToKafkaMsg.Get(key)
+	if !ok {
+		return // duplicate kafka message
+	}
+	kafkaMsg, ok := msg.(messaging.Message)
+	if !ok { // must be bug in code and bad deployment
+		p.logger.WithFields(bark.Fields{
+			logging.TagESKey: key,
+		}).Fatal("Message is not kafka message.")
+	}
+
+	indexMsg := &indexer.Message{}
+	if err := p.msgEncoder.Decode(kafkaMsg.Value(), indexMsg); err != nil {
+		p.logger.WithFields(bark.Fields{
+			logging.TagErr:   err,
+			logging.TagESKey: key,
+		}).Error("Failed to deserialize index message for DLQ.")
+		p.metricsClient.IncCounter(metrics.ESProcessorScope, metrics.ESProcessorCorruptedData)
+		p.nackKafkaMsg(key)
+		return
+	}
+
+	dlqMsg := &indexer.DLQMessage{
+		Message:   indexMsg,
+		Status:    common.Int32Ptr(int32(resp.Status)),
+		Timestamp: common.Int64Ptr(time.Now().UnixNano()),
+	}
+	if resp.Error != nil {
+		dlqMsg.ErrorType = common.StringPtr(resp.Error.Type)
+		dlqMsg.ErrorReason = common.StringPtr(resp.Error.Reason)
+	}
+	if err := p.dlqProducer.Publish(dlqMsg); err != nil {
+		p.logger.WithFields(bark.Fields{
+			logging.TagErr:   err,
+			logging.TagESKey: key,
+		}).Error("Failed to publish message to DLQ.")
+		p.metricsClient.IncCounter(metrics.ESProcessorScope, metrics.ESProcessorDLQFailures)
+		p.nackKafkaMsg(key)
+		return
+	}
+	p.metricsClient.IncCounter(metrics.ESProcessorScope, metrics.ESProcessorDLQWrites)
+	p.ackKafkaMsg(key)
+}
+
 func (p *esProcessorImpl) ackKafkaMsg(key string) {
 	p.ackKafkaMsgHelper(key, false)
 }
@@ -27,6 +27,9 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/indexer"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/codec"
 	"github.com/uber/cadence/common/collection"
 	es "github.com/uber/cadence/common/elasticsearch"
 	esMocks "github.com/uber/cadence/common/elasticsearch/mocks"
@@ -47,6 +50,7 @@ type esProcessorSuite struct {
 	mockBulkProcessor *mocks.ElasticBulkProcessor
 	mockMetricClient  *mmocks.Client
 	mockESClient      *esMocks.Client
+	mockDLQProducer   *msgMocks.Producer
 }
 
 var (
@@ -76,7 +80,9 @@ func (s *esProcessorSuite) SetupTest() {
 	}
 	s.mockMetricClient = &mmocks.Client{}
 	s.mockBulkProcessor = &mocks.ElasticBulkProcessor{}
+	s.mockDLQProducer = &msgMocks.Producer{}
 	p := &esProcessorImpl{
+		msgEncoder:    codec.NewThriftRWEncoder(),
 		config:        config,
 		logger:        bark.NewNopLogger(),
 		metricsClient: s.mockMetricClient,
@@ -93,6 +99,7 @@ func (s *esProcessorSuite) TearDownTest() {
 	s.mockBulkProcessor.AssertExpectations(s.T())
 	s.mockMetricClient.AssertExpectations(s.T())
 	s.mockESClient.AssertExpectations(s.T())
+	s.mockDLQProducer.AssertExpectations(s.T())
 }
 
 func (s *esProcessorSuite) TestNewESProcessorAndStart() {
@@ -114,7 +121,7 @@ func (s *esProcessorSuite) TestNewESProcessorAndStart() {
 		s.NotNil(input.AfterFunc)
 		return true
 	})).Return(&elastic.BulkProcessor{}, nil).Once()
-	p, err := NewESProcessorAndStart(config, s.mockESClient, processorName, bark.NewNopLogger(), &mmocks.Client{})
+	p, err := NewESProcessorAndStart(config, s.mockESClient, processorName, nil, bark.NewNopLogger(), &mmocks.Client{})
 	s.NoError(err)
 
 	processor, ok := p.(*esProcessorImpl)
@@ -245,6 +252,64 @@ func (s *esProcessorSuite) TestBulkAfterAction_Error() {
 	s.esProcessor.bulkAfterAction(0, requests, response, errors.New("some error"))
 }
 
+func (s *esProcessorSuite) TestSendToDLQ() {
+	key := "test-key-dlq"
+	s.esProcessor.dlqProducer = s.mockDLQProducer
+	indexMsg := &indexer.Message{
+		MessageType: indexer.MessageTypeIndex.Ptr(),
+		DomainID:    common.StringPtr("domainID"),
+		WorkflowID:  common.StringPtr("workflowID"),
+		RunID:       common.StringPtr("runID"),
+		Version:     common.Int64Ptr(3),
+	}
+	payload, err := s.esProcessor.msgEncoder.Encode(indexMsg)
+	s.NoError(err)
+
+	request := elastic.NewBulkIndexRequest()
+	mockKafkaMsg := &msgMocks.Message{}
+	s.mockBulkProcessor.On("Add", request).Return().Once()
+	s.esProcessor.Add(request, key, mockKafkaMsg)
+
+	resp := &elastic.BulkResponseItem{
+		Status: 400,
+		Error:  &elastic.ErrorDetails{Type: "mapper_parsing_exception", Reason: "failed to parse"},
+	}
+	mockKafkaMsg.On("Value").Return(payload).Once()
+	s.mockDLQProducer.On("Publish", mock.MatchedBy(func(input *indexer.DLQMessage) bool {
+		s.Equal(indexMsg, input.GetMessage())
+		s.Equal(int32(400), input.GetStatus())
+		s.Equal("mapper_parsing_exception", input.GetErrorType())
+		s.Equal("failed to parse", input.GetErrorReason())
+		s.True(input.GetTimestamp() > 0)
+		return true
+	})).Return(nil).Once()
+	s.mockMetricClient.On("IncCounter", metrics.ESProcessorScope, metrics.ESProcessorDLQWrites).Once()
+	mockKafkaMsg.On("Ack").Return(nil).Once()
+	s.esProcessor.sendToDLQ(key, resp)
+	mockKafkaMsg.AssertExpectations(s.T())
+	s.Equal(0, s.esProcessor.mapToKafkaMsg.Size())
+}
+
+func (s *esProcessorSuite) TestSendToDLQ_PublishFailed() {
+	key := "test-key-dlq-failed"
+	s.esProcessor.dlqProducer = s.mockDLQProducer
+	payload, err := s.esProcessor.msgEncoder.Encode(&indexer.Message{WorkflowID: common.StringPtr("workflowID")})
+	s.NoError(err)
+
+	request := elastic.NewBulkIndexRequest()
+	mockKafkaMsg := &msgMocks.Message{}
+	s.mockBulkProcessor.On("Add", request).Return().Once()
+	s.esProcessor.Add(request, key, mockKafkaMsg)
+
+	mockKafkaMsg.On("Value").Return(payload).Once()
+	s.mockDLQProducer.On("Publish", mock.Anything).Return(errors.New("some error")).Once()
+	s.mockMetricClient.On("IncCounter", metrics.ESProcessorScope, metrics.ESProcessorDLQFailures).Once()
+	mockKafkaMsg.On("Nack").Return(nil).Once()
+	s.esProcessor.sendToDLQ(key, &elastic.BulkResponseItem{Status: 400})
+	mockKafkaMsg.AssertExpectations(s.T())
+	s.Equal(0, s.esProcessor.mapToKafkaMsg.Size())
+}
+
 func (s *esProcessorSuite) TestAckKafkaMsg() {
 	key := "test-key"
 	// no msg in map, nothing called
@@ -78,7 +78,7 @@ func NewIndexer(config *Config, client messaging.Client, esClient es.Client, esC
 func (x Indexer) Start() error {
 	visibilityApp := common.VisibilityAppName
 	visConsumerName := getConsumerName(x.visibilityIndexName)
-	x.visibilityProcessor = newIndexProcessor(visibilityApp, visConsumerName, x.kafkaClient, x.esClient,
+	x.visibilityProcessor = newIndexProcessor(visibilityApp, common.VisibilityDLQAppName, visConsumerName, x.kafkaClient, x.esClient,
 		visibilityProcessorName, x.visibilityIndexName, x.config, x.logger, x.metricsClient)
 	return x.visibilityProcessor.Start()
 }
@@ -39,6 +39,7 @@ import (
 
 type indexProcessor struct {
 	appName         string
+	dlqAppName      string
 	consumerName    string
 	kafkaClient     messaging.Client
 	consumer        messaging.Consumer
@@ -67,10 +68,11 @@ var (
 	errUnknownMessageType = &shared.BadRequestError{Message: "unknown message type"}
 )
 
-func newIndexProcessor(appName, consumerName string, kafkaClient messaging.Client, esClient es.Client,
+func newIndexProcessor(appName, dlqAppName, consumerName string, kafkaClient messaging.Client, esClient es.Client,
 	esProcessorName, esIndexName string, config *Config, logger bark.Logger, metricsClient metrics.Client) *indexProcessor {
 	return &indexProcessor{
 		appName:         appName,
+		dlqAppName:      dlqAppName,
 		consumerName:    consumerName,
 		kafkaClient:     kafkaClient,
 		esClient:        esClient,
@@ -103,7 +105,16 @@ func (p *indexProcessor) Start() error {
 		return err
 	}
 
-	esProcessor, err := NewESProcessorAndStart(p.config, p.esClient, p.esProcessorName, p.logger, p.metricsClient)
+	// DLQ is optional, messages rejected by ES are nacked when it is not configured
+	dlqProducer, err := p.kafkaClient.NewProducer(p.dlqAppName)
+	if err != nil {
+		p.logger.WithFields(bark.Fields{
+			logging.TagErr: err,
+		}).Warn("Failed to create DLQ producer, messages rejected by ES will be nacked.")
+		dlqProducer = nil
+	}
+
+	esProcessor, err := NewESProcessorAndStart(p.config, p.esClient, p.esProcessorName, dlqProducer, p.logger, p.metricsClient)
 	if err != nil {
 		logging.LogIndexProcessorStartFailedEvent(p.logger, err)
 		return err
@@ -0,0 +1,482 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type (
+	// CorruptionType is the kind of inconsistency found in an execution
+	CorruptionType string
+
+	// FixMode selects how the corrupted executions are fixed
+	FixMode string
+
+	// ResetFn resets the execution to the given DecisionTaskCompleted event, a new run is created
+	// from the history up to that event and the corrupted run is terminated
+	ResetFn func(ctx context.Context, info *persistence.WorkflowExecutionInfo, decisionFinishEventID int64) error
+
+	// CorruptedExecution is an execution that failed one of the consistency checks
+	CorruptedExecution struct {
+		ShardID    int
+		DomainID   string
+		WorkflowID string
+		RunID      string
+		Corruption CorruptionType
+		Details    string
+		// Fixed is true if the execution was deleted or reset by the scanner
+		Fixed bool
+		// FixError is the error returned while deleting or resetting the execution
+		FixError string
+	}
+
+	// FailedExecution is an execution that could not be checked
+	FailedExecution struct {
+		ShardID    int
+		DomainID   string
+		WorkflowID string
+		RunID      string
+		Error      string
+	}
+
+	// Report is the summary of a scan
+	Report struct {
+		Scanned   int64
+		Corrupted int64
+		Fixed     int64
+		Failures  int64
+	}
+
+	// PageResult is the outcome of scanning one page of executions
+	PageResult struct {
+		Corrupted     []*CorruptedExecution
+		Failed        []*FailedExecution
+		NextPageToken []byte
+	}
+
+	// ShardScanner checks the executions of a single history shard for corrupted
+	// mutable state, and optionally deletes or resets the corrupted ones
+	ShardScanner struct {
+		shardID          int
+		executionManager persistence.ExecutionManager
+		historyManager   persistence.HistoryManager
+		historyV2Manager persistence.HistoryV2Manager
+		rateLimiter      common.TokenBucket
+		pageSize         int
+		fix              bool
+		fixMode          FixMode
+		reset            ResetFn
+	}
+)
+
+const (
+	// CorruptionTypeHistoryMissing means the history events the mutable state refers to do not exist
+	CorruptionTypeHistoryMissing CorruptionType = "history_missing"
+	// CorruptionTypeInvalidNextEventID means the last history event does not match the NextEventID of the mutable state
+	CorruptionTypeInvalidNextEventID CorruptionType = "invalid_next_event_id"
+	// CorruptionTypeOpenExecutionNotCurrent means an open execution is not pointed to by the current execution record
+	CorruptionTypeOpenExecutionNotCurrent CorruptionType = "open_execution_not_current"
+	// CorruptionTypeCurrentStateMismatch means the current execution record disagrees with the execution on its state
+	CorruptionTypeCurrentStateMismatch CorruptionType = "current_state_mismatch"
+)
+
+const (
+	// FixModeDelete deletes the corrupted executions
+	FixModeDelete FixMode = "delete"
+	// FixModeReset resets the executions whose mutable state disagrees with their history to the last
+	// completed decision found in the history. Corrupted current execution records are fixed as in
+	// FixModeDelete, a reset would terminate the run the record points to.
+	FixModeReset FixMode = "reset"
+)
+
+var errNoResetPoint = errors.New("no DecisionTaskCompleted event found in history to reset the execution to")
+
+// NewShardScanner returns a new scanner for the executions of the given shard. historyV2Manager may be nil
+// if the persistence plugin does not support events v2, executions using it are then reported as failures.
+// reset is only used if fixMode is FixModeReset.
+func NewShardScanner(
+	shardID int,
+	executionManager persistence.ExecutionManager,
+	historyManager persistence.HistoryManager,
+	historyV2Manager persistence.HistoryV2Manager,
+	rateLimiter common.TokenBucket,
+	pageSize int,
+	fix bool,
+	fixMode FixMode,
+	reset ResetFn,
+) *ShardScanner {
+	return &ShardScanner{
+		shardID:          shardID,
+		executionManager: executionManager,
+		historyManager:   historyManager,
+		historyV2Manager: historyV2Manager,
+		rateLimiter:      rateLimiter,
+		pageSize:         pageSize,
+		fix:              fix,
+		fixMode:          fixMode,
+		reset:            reset,
+	}
+}
+
+// ScanPage checks one page of executions starting at the given page token and updates the report.
+// Only a failure to list the executions or a cancelled context is returned as error, executions
+// that cannot be checked are returned as part of the result.
+func (s *ShardScanner) ScanPage(ctx context.Context, pageToken []byte, report *Report) (*PageResult, error) {
+	if err := s.waitForToken(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := s.executionManager.ListConcreteExecutions(&persistence.ListConcreteExecutionsRequest{
+		PageSize:  s.pageSize,
+		PageToken: pageToken,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &PageResult{NextPageToken: resp.PageToken}
+	for _, info := range resp.ExecutionInfos {
+		report.Scanned++
+		corrupted, err := s.checkExecution(ctx, info)
+		if err != nil {
+			if ctxErr := ctx.Err(); ctxErr != nil {
+				return nil, ctxErr
+			}
+			report.Failures++
+			result.Failed = append(result.Failed, &FailedExecution{
+				ShardID:    s.shardID,
+				DomainID:   info.DomainID,
+				WorkflowID: info.WorkflowID,
+				RunID:      info.RunID,
+				Error:      err.Error(),
+			})
+			continue
+		}
+		if corrupted == nil {
+			continue
+		}
+
+		report.Corrupted++
+		if s.fix {
+			if err := s.fixExecution(ctx, corrupted.info, corrupted.Corruption); err != nil {
+				corrupted.FixError = err.Error()
+			} else {
+				corrupted.Fixed = true
+				report.Fixed++
+			}
+		}
+		result.Corrupted = append(result.Corrupted, corrupted.CorruptedExecution)
+	}
+	return result, nil
+}
+
+type corruptedExecution struct {
+	*CorruptedExecution
+	info *persistence.WorkflowExecutionInfo
+}
+
+// checkExecution checks the given execution, a corruption is only reported if it is
+// still there after the execution is read again, the execution may have been updated
+// after it was listed
+func (s *ShardScanner) checkExecution(ctx context.Context, info *persistence.WorkflowExecutionInfo) (*corruptedExecution, error) {
+	corrupted, err := s.check(ctx, info)
+	if err != nil || corrupted == nil {
+		return nil, err
+	}
+
+	if err := s.waitForToken(ctx); err != nil {
+		return nil, err
+	}
+	resp, err := s.executionManager.GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
+		DomainID: info.DomainID,
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(info.WorkflowID),
+			RunId:      common.StringPtr(info.RunID),
+		},
+	})
+	if err != nil {
+		if _, ok := err.(*workflow.EntityNotExistsError); ok {
+			// deleted since it was listed
+			return nil, nil
+		}
+		return nil, err
+	}
+	return s.check(ctx, resp.State.ExecutionInfo)
+}
+
+func (s *ShardScanner) check(ctx context.Context, info *persistence.WorkflowExecutionInfo) (*corruptedExecution, error) {
+	corruption, details, err := s.checkHistory(ctx, info)
+	if err != nil {
+		return nil, err
+	}
+	if len(corruption) == 0 {
+		corruption, details, err = s.checkCurrentExecution(ctx, info)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(corruption) == 0 {
+		return nil, nil
+	}
+	return &corruptedExecution{
+		CorruptedExecution: &CorruptedExecution{
+			ShardID:    s.shardID,
+			DomainID:   info.DomainID,
+			WorkflowID: info.WorkflowID,
+			RunID:      info.RunID,
+			Corruption: corruption,
+			Details:    details,
+		},
+		info: info,
+	}, nil
+}
+
+// checkHistory reads the last batch of history events of the execution and
+// verifies that it exists and ends right before the NextEventID
+func (s *ShardScanner) checkHistory(ctx context.Context, info *persistence.WorkflowExecutionInfo) (CorruptionType, string, error) {
+	firstEventID := info.LastFirstEventID
+	if firstEventID < common.FirstEventID {
+		firstEventID = common.FirstEventID
+	}
+	if info.NextEventID <= firstEventID {
+		return CorruptionTypeInvalidNextEventID,
+			fmt.Sprintf("NextEventID %v is not greater than LastFirstEventID %v", info.NextEventID, info.LastFirstEventID), nil
+	}
+
+	lastEventID := common.EmptyEventID
+	var pageToken []byte
+	for {
+		if err := s.waitForToken(ctx); err != nil {
+			return "", "", err
+		}
+		events, nextPageToken, err := s.readHistory(info, firstEventID, pageToken)
+		if err != nil {
+			if _, ok := err.(*workflow.EntityNotExistsError); ok {
+				return CorruptionTypeHistoryMissing, err.Error(), nil
+			}
+			return "", "", err
+		}
+		if len(events) > 0 {
+			lastEventID = events[len(events)-1].GetEventId()
+		}
+		if len(nextPageToken) == 0 {
+			break
+		}
+		pageToken = nextPageToken
+	}
+
+	if lastEventID == common.EmptyEventID {
+		return CorruptionTypeHistoryMissing,
+			fmt.Sprintf("no history events found between %v and %v", firstEventID, info.NextEventID), nil
+	}
+	if lastEventID != info.NextEventID-1 {
+		return CorruptionTypeInvalidNextEventID,
+			fmt.Sprintf("last history event is %v but NextEventID is %v", lastEventID, info.NextEventID), nil
+	}
+	return "", "", nil
+}
+
+func (s *ShardScanner) readHistory(
+	info *persistence.WorkflowExecutionInfo,
+	firstEventID int64,
+	pageToken []byte,
+) ([]*workflow.HistoryEvent, []byte, error) {
+	if info.EventStoreVersion == persistence.EventStoreVersionV2 {
+		if s.historyV2Manager == nil {
+			return nil, nil, fmt.Errorf("execution uses events v2 which is not supported by the persistence store")
+		}
+		resp, err := s.historyV2Manager.ReadHistoryBranch(&persistence.ReadHistoryBranchRequest{
+			BranchToken:   info.BranchToken,
+			MinEventID:    firstEventID,
+			MaxEventID:    info.NextEventID,
+			PageSize:      s.pageSize,
+			NextPageToken: pageToken,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return resp.HistoryEvents, resp.NextPageToken, nil
+	}
+
+	resp, err := s.historyManager.GetWorkflowExecutionHistory(&persistence.GetWorkflowExecutionHistoryRequest{
+		DomainID: info.DomainID,
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(info.WorkflowID),
+			RunId:      common.StringPtr(info.RunID),
+		},
+		FirstEventID:  firstEventID,
+		NextEventID:   info.NextEventID,
+		PageSize:      s.pageSize,
+		NextPageToken: pageToken,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return resp.History.Events, resp.NextPageToken, nil
+}
+
+// checkCurrentExecution verifies that an open execution is the current run of its workflow,
+// and that the current execution record agrees with the execution it points to
+func (s *ShardScanner) checkCurrentExecution(ctx context.Context, info *persistence.WorkflowExecutionInfo) (CorruptionType, string, error) {
+	if err := s.waitForToken(ctx); err != nil {
+		return "", "", err
+	}
+	current, err := s.executionManager.GetCurrentExecution(&persistence.GetCurrentExecutionRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+	})
+	isOpen := info.State != persistence.WorkflowStateCompleted
+	if err != nil {
+		if _, ok := err.(*workflow.EntityNotExistsError); ok {
+			// the current execution record of a closed workflow may have expired already
+			if isOpen {
+				return CorruptionTypeOpenExecutionNotCurrent, "current execution record does not exist", nil
+			}
+			return "", "", nil
+		}
+		return "", "", err
+	}
+
+	if current.RunID != info.RunID {
+		if isOpen {
+			return CorruptionTypeOpenExecutionNotCurrent,
+				fmt.Sprintf("current execution record points to run %v", current.RunID), nil
+		}
+		return "", "", nil
+	}
+	if current.State != info.State {
+		return CorruptionTypeCurrentStateMismatch,
+			fmt.Sprintf("current execution record has state %v but execution has state %v", current.State, info.State), nil
+	}
+	return "", "", nil
+}
+
+// fixExecution deletes or resets the corrupted execution. If only the current execution record of a closed
+// execution is wrong, the record is deleted so that the workflow id can be used again and the execution
+// is kept. The history is deleted last, so that a failure leaves at most an orphaned history behind.
+func (s *ShardScanner) fixExecution(ctx context.Context, info *persistence.WorkflowExecutionInfo, corruption CorruptionType) error {
+	if s.fixMode == FixModeReset &&
+		(corruption == CorruptionTypeHistoryMissing || corruption == CorruptionTypeInvalidNextEventID) {
+		return s.resetExecution(ctx, info)
+	}
+
+	if corruption == CorruptionTypeCurrentStateMismatch && info.State == persistence.WorkflowStateCompleted {
+		if err := s.waitForToken(ctx); err != nil {
+			return err
+		}
+		return s.executionManager.DeleteCurrentWorkflowExecution(&persistence.DeleteCurrentWorkflowExecutionRequest{
+			DomainID:   info.DomainID,
+			WorkflowID: info.WorkflowID,
+			RunID:      info.RunID,
+		})
+	}
+
+	if err := s.waitForToken(ctx); err != nil {
+		return err
+	}
+	err := s.executionManager.DeleteWorkflowExecution(&persistence.DeleteWorkflowExecutionRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := s.waitForToken(ctx); err != nil {
+		return err
+	}
+	err = s.executionManager.DeleteCurrentWorkflowExecution(&persistence.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+	})
+	if err != nil {
+		return err
+	}
+
+	if corruption == CorruptionTypeHistoryMissing {
+		return nil
+	}
+	if err := s.waitForToken(ctx); err != nil {
+		return err
+	}
+	if info.EventStoreVersion == persistence.EventStoreVersionV2 {
+		if s.historyV2Manager == nil {
+			return nil
+		}
+		return s.historyV2Manager.DeleteHistoryBranch(&persistence.DeleteHistoryBranchRequest{
+			BranchToken: info.BranchToken,
+		})
+	}
+	return s.historyManager.DeleteWorkflowExecutionHistory(&persistence.DeleteWorkflowExecutionHistoryRequest{
+		DomainID: info.DomainID,
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(info.WorkflowID),
+			RunId:      common.StringPtr(info.RunID),
+		},
+	})
+}
+
+// resetExecution resets the execution to the last DecisionTaskCompleted event which can still be read
+// from its history
+func (s *ShardScanner) resetExecution(ctx context.Context, info *persistence.WorkflowExecutionInfo) error {
+	resetEventID := common.EmptyEventID
+	var pageToken []byte
+	for {
+		if err := s.waitForToken(ctx); err != nil {
+			return err
+		}
+		events, nextPageToken, err := s.readHistory(info, common.FirstEventID, pageToken)
+		if err != nil {
+			if _, ok := err.(*workflow.EntityNotExistsError); ok {
+				// the rest of the history is missing
+				break
+			}
+			return err
+		}
+		for _, event := range events {
+			if event.GetEventType() == workflow.EventTypeDecisionTaskCompleted {
+				resetEventID = event.GetEventId()
+			}
+		}
+		if len(nextPageToken) == 0 {
+			break
+		}
+		pageToken = nextPageToken
+	}
+
+	if resetEventID == common.EmptyEventID {
+		return errNoResetPoint
+	}
+	return s.reset(ctx, info, resetEventID)
+}
+
+// waitForToken blocks until the rate limiter hands out a token or the context is done
+func (s *ShardScanner) waitForToken(ctx context.Context) error {
+	return common.WaitForToken(ctx, s.rateLimiter)
+}
@@ -0,0 +1,356 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package executions
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	workflow "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type shardScannerSuite struct {
+	*require.Assertions
+	suite.Suite
+	executionManager *mocks.ExecutionManager
+	historyManager   *mocks.HistoryManager
+	historyV2Manager *mocks.HistoryV2Manager
+}
+
+func TestShardScannerSuite(t *testing.T) {
+	suite.Run(t, new(shardScannerSuite))
+}
+
+func (s *shardScannerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.executionManager = &mocks.ExecutionManager{}
+	s.historyManager = &mocks.HistoryManager{}
+	s.historyV2Manager = &mocks.HistoryV2Manager{}
+}
+
+func (s *shardScannerSuite) TearDownTest() {
+	s.executionManager.AssertExpectations(s.T())
+	s.historyManager.AssertExpectations(s.T())
+	s.historyV2Manager.AssertExpectations(s.T())
+}
+
+func (s *shardScannerSuite) TestHealthyExecutions() {
+	open := s.newExecution("wf1", persistence.WorkflowStateRunning, 5, 8)
+	closed := s.newExecution("wf2", persistence.WorkflowStateCompleted, 1, 4)
+	s.mockList(nil, []byte("next"), open, closed)
+	s.mockHistory(open, 5, 7)
+	s.mockHistory(closed, 1, 3)
+	s.mockCurrent(open, open.RunID, persistence.WorkflowStateRunning)
+	s.executionManager.On("GetCurrentExecution", s.currentRequest(closed)).
+		Return(nil, &workflow.EntityNotExistsError{}).Once()
+
+	var report Report
+	result, err := s.newScanner(false).ScanPage(context.Background(), nil, &report)
+	s.NoError(err)
+	s.Empty(result.Corrupted)
+	s.Empty(result.Failed)
+	s.Equal([]byte("next"), result.NextPageToken)
+	s.Equal(Report{Scanned: 2}, report)
+}
+
+func (s *shardScannerSuite) TestHistoryMissing() {
+	info := s.newExecution("wf1", persistence.WorkflowStateRunning, 5, 8)
+	s.mockList(nil, nil, info)
+	s.historyV2Manager.On("ReadHistoryBranch", mock.Anything).
+		Return(nil, &workflow.EntityNotExistsError{}).Twice()
+	s.mockGet(info)
+
+	var report Report
+	result, err := s.newScanner(false).ScanPage(context.Background(), nil, &report)
+	s.NoError(err)
+	s.Len(result.Corrupted, 1)
+	s.Equal(CorruptionTypeHistoryMissing, result.Corrupted[0].Corruption)
+	s.Equal(info.RunID, result.Corrupted[0].RunID)
+	s.False(result.Corrupted[0].Fixed)
+	s.Equal(Report{Scanned: 1, Corrupted: 1}, report)
+}
+
+func (s *shardScannerSuite) TestInvalidNextEventIDFixed() {
+	info := s.newExecution("wf1", persistence.WorkflowStateRunning, 5, 10)
+	s.mockList(nil, nil, info)
+	s.historyV2Manager.On("ReadHistoryBranch", mock.Anything).
+		Return(&persistence.ReadHistoryBranchResponse{HistoryEvents: s.newEvents(5, 7)}, nil).Twice()
+	s.mockGet(info)
+	s.mockDeleteExecution(info)
+	s.historyV2Manager.On("DeleteHistoryBranch", &persistence.DeleteHistoryBranchRequest{
+		BranchToken: info.BranchToken,
+	}).Return(nil).Once()
+
+	var report Report
+	result, err := s.newScanner(true).ScanPage(context.Background(), nil, &report)
+	s.NoError(err)
+	s.Len(result.Corrupted, 1)
+	s.Equal(CorruptionTypeInvalidNextEventID, result.Corrupted[0].Corruption)
+	s.True(result.Corrupted[0].Fixed)
+	s.Equal(Report{Scanned: 1, Corrupted: 1, Fixed: 1}, report)
+}
+
+func (s *shardScannerSuite) TestInvalidNextEventIDReset() {
+	info := s.newExecution("wf1", persistence.WorkflowStateRunning, 5, 10)
+	s.mockList(nil, nil, info)
+	s.historyV2Manager.On("ReadHistoryBranch", mock.Anything).
+		Return(&persistence.ReadHistoryBranchResponse{HistoryEvents: s.newEvents(5, 7)}, nil).Twice()
+	s.mockGet(info)
+	events := s.newEvents(1, 7)
+	events[3].EventType = common.EventTypePtr(workflow.EventTypeDecisionTaskCompleted)
+	s.historyV2Manager.On("ReadHistoryBranch", &persistence.ReadHistoryBranchRequest{
+		BranchToken: info.BranchToken,
+		MinEventID:  common.FirstEventID,
+		MaxEventID:  info.NextEventID,
+		PageSize:    10,
+	}).Return(&persistence.ReadHistoryBranchResponse{HistoryEvents: events}, nil).Once()
+
+	var resetEventID int64
+	reset := func(ctx context.Context, resetInfo *persistence.WorkflowExecutionInfo, decisionFinishEventID int64) error {
+		s.Equal(info.RunID, resetInfo.RunID)
+		resetEventID = decisionFinishEventID
+		return nil
+	}
+	var report Report
+	result, err := s.newResetScanner(reset).ScanPage(context.Background(), nil, &report)
+	s.NoError(err)
+	s.Len(result.Corrupted, 1)
+	s.Equal(CorruptionTypeInvalidNextEventID, result.Corrupted[0].Corruption)
+	s.True(result.Corrupted[0].Fixed)
+	s.Equal(int64(4), resetEventID)
+	s.Equal(Report{Scanned: 1, Corrupted: 1, Fixed: 1}, report)
+}
+
+func (s *shardScannerSuite) TestHistoryMissingNoResetPoint() {
+	info := s.newExecution("wf1", persistence.WorkflowStateRunning, 5, 8)
+	s.mockList(nil, nil, info)
+	s.historyV2Manager.On("ReadHistoryBranch", mock.Anything).
+		Return(nil, &workflow.EntityNotExistsError{}).Times(3)
+	s.mockGet(info)
+
+	reset := func(ctx context.Context, info *persistence.WorkflowExecutionInfo, decisionFinishEventID int64) error {
+		s.Fail("execution without history must not be reset")
+		return nil
+	}
+	var report Report
+	result, err := s.newResetScanner(reset).ScanPage(context.Background(), nil, &report)
+	s.NoError(err)
+	s.Len(result.Corrupted, 1)
+	s.False(result.Corrupted[0].Fixed)
+	s.Equal(errNoResetPoint.Error(), result.Corrupted[0].FixError)
+	s.Equal(Report{Scanned: 1, Corrupted: 1}, report)
+}
+
+func (s *shardScannerSuite) TestOpenExecutionNotCurrent() {
+	info := s.newExecution("wf1", persistence.WorkflowStateRunning, 5, 8)
+	info.EventStoreVersion = 0
+	s.mockList(nil, nil, info)
+	s.historyManager.On("GetWorkflowExecutionHistory", mock.Anything).Return(&persistence.GetWorkflowExecutionHistoryResponse{
+		History: &workflow.History{Events: s.newEvents(5, 7)},
+	}, nil).Twice()
+	s.mockCurrent(info, "other-run-id", persistence.WorkflowStateRunning).Twice()
+	s.mockGet(info)
+	s.mockDeleteExecution(info)
+	s.historyManager.On("DeleteWorkflowExecutionHistory", mock.Anything).Return(nil).Once()
+
+	var report Report
+	result, err := s.newScanner(true).ScanPage(context.Background(), nil, &report)
+	s.NoError(err)
+	s.Len(result.Corrupted, 1)
+	s.Equal(CorruptionTypeOpenExecutionNotCurrent, result.Corrupted[0].Corruption)
+	s.True(result.Corrupted[0].Fixed)
+	s.Equal(Report{Scanned: 1, Corrupted: 1, Fixed: 1}, report)
+}
+
+func (s *shardScannerSuite) TestCurrentStateMismatchOfClosedExecutionFixed() {
+	info := s.newExecution("wf1", persistence.WorkflowStateCompleted, 5, 8)
+	s.mockList(nil, nil, info)
+	s.mockHistory(info, 5, 7).Twice()
+	s.mockCurrent(info, info.RunID, persistence.WorkflowStateRunning).Twice()
+	s.mockGet(info)
+	s.executionManager.On("DeleteCurrentWorkflowExecution", s.deleteCurrentRequest(info)).Return(nil).Once()
+
+	var report Report
+	result, err := s.newScanner(true).ScanPage(context.Background(), nil, &report)
+	s.NoError(err)
+	s.Len(result.Corrupted, 1)
+	s.Equal(CorruptionTypeCurrentStateMismatch, result.Corrupted[0].Corruption)
+	s.True(result.Corrupted[0].Fixed)
+	s.Equal(Report{Scanned: 1, Corrupted: 1, Fixed: 1}, report)
+}
+
+func (s *shardScannerSuite) TestCorruptionGoneAfterReread() {
+	info := s.newExecution("wf1", persistence.WorkflowStateRunning, 5, 8)
+	s.mockList(nil, nil, info)
+	s.mockHistory(info, 5, 7)
+	s.mockCurrent(info, "", 0)
+	s.executionManager.On("GetWorkflowExecution", mock.Anything).
+		Return(nil, &workflow.EntityNotExistsError{}).Once()
+
+	var report Report
+	result, err := s.newScanner(true).ScanPage(context.Background(), nil, &report)
+	s.NoError(err)
+	s.Empty(result.Corrupted)
+	s.Equal(Report{Scanned: 1}, report)
+}
+
+func (s *shardScannerSuite) TestCheckFailure() {
+	info := s.newExecution("wf1", persistence.WorkflowStateRunning, 5, 8)
+	s.mockList(nil, nil, info)
+	s.historyV2Manager.On("ReadHistoryBranch", mock.Anything).
+		Return(nil, errors.New("some random error")).Once()
+
+	var report Report
+	result, err := s.newScanner(true).ScanPage(context.Background(), nil, &report)
+	s.NoError(err)
+	s.Empty(result.Corrupted)
+	s.Len(result.Failed, 1)
+	s.Equal("some random error", result.Failed[0].Error)
+	s.Equal(Report{Scanned: 1, Failures: 1}, report)
+}
+
+func (s *shardScannerSuite) TestListFailure() {
+	s.executionManager.On("ListConcreteExecutions", mock.Anything).
+		Return(nil, errors.New("some random error")).Once()
+
+	var report Report
+	_, err := s.newScanner(false).ScanPage(context.Background(), nil, &report)
+	s.Error(err)
+	s.Equal(Report{}, report)
+}
+
+func (s *shardScannerSuite) newScanner(fix bool) *ShardScanner {
+	return NewShardScanner(
+		1,
+		s.executionManager,
+		s.historyManager,
+		s.historyV2Manager,
+		common.NewTokenBucket(10000, common.NewRealTimeSource()),
+		10,
+		fix,
+		FixModeDelete,
+		nil,
+	)
+}
+
+func (s *shardScannerSuite) newResetScanner(reset ResetFn) *ShardScanner {
+	return NewShardScanner(
+		1,
+		s.executionManager,
+		s.historyManager,
+		s.historyV2Manager,
+		common.NewTokenBucket(10000, common.NewRealTimeSource()),
+		10,
+		true,
+		FixModeReset,
+		reset,
+	)
+}
+
+func (s *shardScannerSuite) newExecution(workflowID string, state int, lastFirstEventID int64, nextEventID int64) *persistence.WorkflowExecutionInfo {
+	return &persistence.WorkflowExecutionInfo{
+		DomainID:          "3a8b6c6e-7a43-4e0b-a1e5-f8f2e44fd9e1",
+		WorkflowID:        workflowID,
+		RunID:             workflowID + "-run-id",
+		State:             state,
+		LastFirstEventID:  lastFirstEventID,
+		NextEventID:       nextEventID,
+		EventStoreVersion: persistence.EventStoreVersionV2,
+		BranchToken:       []byte(workflowID + "-branch-token"),
+	}
+}
+
+func (s *shardScannerSuite) newEvents(firstEventID int64, lastEventID int64) []*workflow.HistoryEvent {
+	var events []*workflow.HistoryEvent
+	for id := firstEventID; id <= lastEventID; id++ {
+		events = append(events, &workflow.HistoryEvent{EventId: common.Int64Ptr(id)})
+	}
+	return events
+}
+
+func (s *shardScannerSuite) mockList(pageToken []byte, nextPageToken []byte, infos ...*persistence.WorkflowExecutionInfo) {
+	s.executionManager.On("ListConcreteExecutions", &persistence.ListConcreteExecutionsRequest{
+		PageSize:  10,
+		PageToken: pageToken,
+	}).Return(&persistence.ListConcreteExecutionsResponse{
+		ExecutionInfos: infos,
+		PageToken:      nextPageToken,
+	}, nil).Once()
+}
+
+func (s *shardScannerSuite) mockHistory(info *persistence.WorkflowExecutionInfo, firstEventID int64, lastEventID int64) *mock.Call {
+	return s.historyV2Manager.On("ReadHistoryBranch", &persistence.ReadHistoryBranchRequest{
+		BranchToken: info.BranchToken,
+		MinEventID:  firstEventID,
+		MaxEventID:  info.NextEventID,
+		PageSize:    10,
+	}).Return(&persistence.ReadHistoryBranchResponse{
+		HistoryEvents: s.newEvents(firstEventID, lastEventID),
+	}, nil).Once()
+}
+
+func (s *shardScannerSuite) currentRequest(info *persistence.WorkflowExecutionInfo) *persistence.GetCurrentExecutionRequest {
+	return &persistence.GetCurrentExecutionRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+	}
+}
+
+func (s *shardScannerSuite) mockCurrent(info *persistence.WorkflowExecutionInfo, runID string, state int) *mock.Call {
+	return s.executionManager.On("GetCurrentExecution", s.currentRequest(info)).
+		Return(&persistence.GetCurrentExecutionResponse{RunID: runID, State: state}, nil).Once()
+}
+
+func (s *shardScannerSuite) mockGet(info *persistence.WorkflowExecutionInfo) {
+	s.executionManager.On("GetWorkflowExecution", &persistence.GetWorkflowExecutionRequest{
+		DomainID: info.DomainID,
+		Execution: workflow.WorkflowExecution{
+			WorkflowId: common.StringPtr(info.WorkflowID),
+			RunId:      common.StringPtr(info.RunID),
+		},
+	}).Return(&persistence.GetWorkflowExecutionResponse{
+		State: &persistence.WorkflowMutableState{ExecutionInfo: info},
+	}, nil).Once()
+}
+
+func (s *shardScannerSuite) deleteCurrentRequest(info *persistence.WorkflowExecutionInfo) *persistence.DeleteCurrentWorkflowExecutionRequest {
+	return &persistence.DeleteCurrentWorkflowExecutionRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+	}
+}
+
+func (s *shardScannerSuite) mockDeleteExecution(info *persistence.WorkflowExecutionInfo) {
+	s.executionManager.On("DeleteWorkflowExecution", &persistence.DeleteWorkflowExecutionRequest{
+		DomainID:   info.DomainID,
+		WorkflowID: info.WorkflowID,
+		RunID:      info.RunID,
+	}).Return(nil).Once()
+	s.executionManager.On("DeleteCurrentWorkflowExecution", s.deleteCurrentRequest(info)).Return(nil).Once()
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scanner
+
+import (
+	"context"
+	"time"
+
+	"github.com/pborman/uuid"
+	"github.com/uber-common/bark"
+	h "github.com/uber/cadence/.gen/go/history"
+	gen "github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/service/worker/scanner/executions"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+)
+
+type (
+	// executionsScannerProgress is recorded with every activity heartbeat
+	// so that a retried activity resumes from the last page it processed
+	executionsScannerProgress struct {
+		ShardID       int
+		NextPageToken []byte
+		Report        executions.Report
+	}
+)
+
+const (
+	executionsScannerWFID         = "cadence-sys-executions-scanner"
+	executionsScannerWFTypeName   = "cadence-sys-executions-scanner-workflow"
+	executionsScannerActivityName = "cadence-sys-executions-scanner-activity"
+	// executionsScannerPeriod is the time between two runs of the executions scanner
+	executionsScannerPeriod = 24 * time.Hour
+	// executionsScannerMaxDuration is the max time a single run over all the shards may take
+	executionsScannerMaxDuration = 7 * 24 * time.Hour
+	// executionsScannerResetReason is the reason recorded for the executions reset by the scanner
+	executionsScannerResetReason = "reset by executions scanner: corrupted mutable state"
+)
+
+var (
+	executionsScannerWFStartOptions = client.StartWorkflowOptions{
+		ID:                              executionsScannerWFID,
+		TaskList:                        scannerTaskListName,
+		ExecutionStartToCloseTimeout:    scannerWorkflowStartToCloseTimeout,
+		DecisionTaskStartToCloseTimeout: scannerDecisionTaskTimeout,
+		WorkflowIDReusePolicy:           client.WorkflowIDReusePolicyAllowDuplicate,
+	}
+
+	executionsScannerActivityOptions = workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    executionsScannerMaxDuration,
+		HeartbeatTimeout:       5 * time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 1.7,
+			MaximumInterval:    10 * time.Minute,
+			ExpirationInterval: executionsScannerMaxDuration,
+		},
+	}
+)
+
+func init() {
+	workflow.RegisterWithOptions(ExecutionsScannerWorkflow, workflow.RegisterOptions{Name: executionsScannerWFTypeName})
+	activity.RegisterWithOptions(ExecutionsScannerActivity, activity.RegisterOptions{Name: executionsScannerActivityName})
+}
+
+// ExecutionsScannerWorkflow is the workflow that runs the executions scanner once a day
+func ExecutionsScannerWorkflow(ctx workflow.Context) error {
+	logger := workflow.GetLogger(ctx)
+
+	var report executions.Report
+	ctx = workflow.WithActivityOptions(ctx, executionsScannerActivityOptions)
+	err := workflow.ExecuteActivity(ctx, executionsScannerActivityName).Get(ctx, &report)
+	if err != nil {
+		logger.Error("executions scanner activity failed, err=" + err.Error())
+	} else {
+		logger.Info("executions scanner activity completed")
+	}
+
+	if err := workflow.Sleep(ctx, executionsScannerPeriod); err != nil {
+		return err
+	}
+	return workflow.NewContinueAsNewError(ctx, executionsScannerWFTypeName)
+}
+
+// ExecutionsScannerActivity checks the executions of all the shards for corrupted mutable state
+func ExecutionsScannerActivity(ctx context.Context) (executions.Report, error) {
+	sc := ctx.Value(scannerContextKey).(*scannerContext)
+
+	var progress executionsScannerProgress
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &progress); err != nil {
+			sc.logger.WithField(logging.TagErr, err).Error("failed to read executions scanner progress, starting over")
+			progress = executionsScannerProgress{}
+		}
+	}
+
+	logger := sc.logger.WithFields(bark.Fields{
+		logging.TagWorkflowComponent: logging.TagValueExecutionsScannerComponent,
+	})
+	rateLimiter := common.NewRealTimeTokenBucket(sc.cfg.ExecutionsScannerPersistenceMaxQPS())
+	pageSize := sc.cfg.ExecutionsScannerPageSize()
+	fix := sc.cfg.ExecutionsScannerFixEnabled()
+	fixMode := executions.FixMode(sc.cfg.ExecutionsScannerFixMode())
+	if fix && fixMode != executions.FixModeDelete && fixMode != executions.FixModeReset {
+		logger.WithField("fix-mode", fixMode).Error("unknown executions scanner fix mode, corrupted executions are not fixed")
+		fix = false
+	}
+
+	for ; progress.ShardID < sc.numHistoryShards; progress.ShardID++ {
+		executionManager, err := sc.executionManagers.get(progress.ShardID)
+		if err != nil {
+			sc.metricsClient.IncCounter(metrics.ExecutionsScannerScope, metrics.ExecutionsScannerFailures)
+			logger.WithFields(bark.Fields{
+				logging.TagErr:            err,
+				logging.TagHistoryShardID: progress.ShardID,
+			}).Error("failed to create execution manager")
+			return progress.Report, err
+		}
+		shardScanner := executions.NewShardScanner(
+			progress.ShardID,
+			executionManager,
+			sc.historyManager,
+			sc.historyV2Manager,
+			rateLimiter,
+			pageSize,
+			fix,
+			fixMode,
+			newExecutionResetFn(sc.historyClient),
+		)
+
+		for {
+			scanned := progress.Report.Scanned
+			result, err := shardScanner.ScanPage(ctx, progress.NextPageToken, &progress.Report)
+			if err != nil {
+				sc.metricsClient.IncCounter(metrics.ExecutionsScannerScope, metrics.ExecutionsScannerFailures)
+				logger.WithFields(bark.Fields{
+					logging.TagErr:            err,
+					logging.TagHistoryShardID: progress.ShardID,
+				}).Error("failed to scan executions")
+				return progress.Report, err
+			}
+			sc.metricsClient.AddCounter(metrics.ExecutionsScannerScope, metrics.ExecutionsScannerExecutionsScanned, progress.Report.Scanned-scanned)
+			emitPageResult(result, sc.metricsClient, logger)
+
+			progress.NextPageToken = result.NextPageToken
+			activity.RecordHeartbeat(ctx, progress)
+			if len(progress.NextPageToken) == 0 {
+				break
+			}
+		}
+	}
+
+	logger.WithFields(bark.Fields{
+		"scanned":   progress.Report.Scanned,
+		"corrupted": progress.Report.Corrupted,
+		"fixed":     progress.Report.Fixed,
+		"failures":  progress.Report.Failures,
+	}).Info("executions scanner finished")
+	return progress.Report, nil
+}
+
+// newExecutionResetFn returns the function used by the executions scanner to reset corrupted executions
+// through the history service
+func newExecutionResetFn(historyClient history.Client) executions.ResetFn {
+	return func(ctx context.Context, info *persistence.WorkflowExecutionInfo, decisionFinishEventID int64) error {
+		_, err := historyClient.ResetWorkflowExecution(ctx, &h.ResetWorkflowExecutionRequest{
+			DomainUUID: common.StringPtr(info.DomainID),
+			ResetRequest: &gen.ResetWorkflowExecutionRequest{
+				WorkflowExecution: &gen.WorkflowExecution{
+					WorkflowId: common.StringPtr(info.WorkflowID),
+					RunId:      common.StringPtr(info.RunID),
+				},
+				Reason:                common.StringPtr(executionsScannerResetReason),
+				DecisionFinishEventId: common.Int64Ptr(decisionFinishEventID),
+				RequestId:             common.StringPtr(uuid.New()),
+			},
+		})
+		return err
+	}
+}
+
+// emitPageResult logs every corrupted execution and every execution that could not be checked,
+// the logs are the report of the scanner
+func emitPageResult(result *executions.PageResult, metricsClient metrics.Client, logger bark.Logger) {
+	for _, c := range result.Corrupted {
+		metricsClient.IncCounter(metrics.ExecutionsScannerScope, metrics.ExecutionsScannerExecutionsCorrupted)
+		if c.Fixed {
+			metricsClient.IncCounter(metrics.ExecutionsScannerScope, metrics.ExecutionsScannerExecutionsFixed)
+		}
+		fields := bark.Fields{
+			logging.TagHistoryShardID:      c.ShardID,
+			logging.TagDomainID:            c.DomainID,
+			logging.TagWorkflowExecutionID: c.WorkflowID,
+			logging.TagWorkflowRunID:       c.RunID,
+			"corruption":                   c.Corruption,
+			"details":                      c.Details,
+			"fixed":                        c.Fixed,
+		}
+		if len(c.FixError) > 0 {
+			fields["fix-error"] = c.FixError
+		}
+		logger.WithFields(fields).Warn("corrupted execution")
+	}
+	for _, f := range result.Failed {
+		metricsClient.IncCounter(metrics.ExecutionsScannerScope, metrics.ExecutionsScannerFailures)
+		logger.WithFields(bark.Fields{
+			logging.TagHistoryShardID:      f.ShardID,
+			logging.TagDomainID:            f.DomainID,
+			logging.TagWorkflowExecutionID: f.WorkflowID,
+			logging.TagWorkflowRunID:       f.RunID,
+			logging.TagErr:                 f.Error,
+		}).Error("failed to check execution")
+	}
+}
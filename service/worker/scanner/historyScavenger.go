@@ -0,0 +1,319 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scanner
+
+import (
+	"context"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/codec"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+)
+
+type (
+	// HistoryScavengerReport is the summary of one run of the history scavenger
+	HistoryScavengerReport struct {
+		Scanned  int64
+		Skipped  int64
+		Deleted  int64
+		Failures int64
+	}
+
+	// historyScavengerProgress is recorded with every activity heartbeat
+	// so that a retried activity resumes from the last page it processed
+	historyScavengerProgress struct {
+		NextPageToken []byte
+		Report        HistoryScavengerReport
+	}
+
+	// historyScavenger pages through all history branches and deletes the ones
+	// whose workflow execution no longer exists or no longer refers to them
+	historyScavenger struct {
+		historyV2Manager    persistence.HistoryV2Manager
+		getExecutionManager func(shardID int) (persistence.ExecutionManager, error)
+		numHistoryShards    int
+		rateLimiter         common.TokenBucket
+		pageSize            int
+		gracePeriod         time.Duration
+		heartbeat           func(progress historyScavengerProgress)
+		thriftEncoder       codec.BinaryEncoder
+		metricsClient       metrics.Client
+		logger              bark.Logger
+	}
+)
+
+const (
+	historyScavengerWFID         = "cadence-sys-history-scavenger"
+	historyScavengerWFTypeName   = "cadence-sys-history-scavenger-workflow"
+	historyScavengerActivityName = "cadence-sys-history-scavenger-activity"
+	// historyScavengerPeriod is the time between two runs of the history scavenger
+	historyScavengerPeriod = 24 * time.Hour
+)
+
+var (
+	historyScavengerWFStartOptions = client.StartWorkflowOptions{
+		ID:                              historyScavengerWFID,
+		TaskList:                        scannerTaskListName,
+		ExecutionStartToCloseTimeout:    scannerWorkflowStartToCloseTimeout,
+		DecisionTaskStartToCloseTimeout: scannerDecisionTaskTimeout,
+		WorkflowIDReusePolicy:           client.WorkflowIDReusePolicyAllowDuplicate,
+	}
+
+	historyScavengerActivityOptions = workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    historyScavengerPeriod,
+		HeartbeatTimeout:       5 * time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 1.7,
+			MaximumInterval:    10 * time.Minute,
+			ExpirationInterval: historyScavengerPeriod,
+		},
+	}
+)
+
+func init() {
+	workflow.RegisterWithOptions(HistoryScavengerWorkflow, workflow.RegisterOptions{Name: historyScavengerWFTypeName})
+	activity.RegisterWithOptions(HistoryScavengerActivity, activity.RegisterOptions{Name: historyScavengerActivityName})
+}
+
+// HistoryScavengerWorkflow is the workflow that runs the history scavenger once a day
+func HistoryScavengerWorkflow(ctx workflow.Context) error {
+	logger := workflow.GetLogger(ctx)
+
+	var report HistoryScavengerReport
+	ctx = workflow.WithActivityOptions(ctx, historyScavengerActivityOptions)
+	err := workflow.ExecuteActivity(ctx, historyScavengerActivityName).Get(ctx, &report)
+	if err != nil {
+		logger.Error("history scavenger activity failed, err=" + err.Error())
+	} else {
+		logger.Info("history scavenger activity completed")
+	}
+
+	if err := workflow.Sleep(ctx, historyScavengerPeriod); err != nil {
+		return err
+	}
+	return workflow.NewContinueAsNewError(ctx, historyScavengerWFTypeName)
+}
+
+// HistoryScavengerActivity scans all history branches and deletes the orphaned ones
+func HistoryScavengerActivity(ctx context.Context) (HistoryScavengerReport, error) {
+	sc := ctx.Value(scannerContextKey).(*scannerContext)
+
+	var progress historyScavengerProgress
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &progress); err != nil {
+			sc.logger.WithField(logging.TagErr, err).Error("failed to read history scavenger progress, starting over")
+			progress = historyScavengerProgress{}
+		}
+	}
+
+	scavenger := newHistoryScavenger(
+		sc.historyV2Manager,
+		sc.executionManagers.get,
+		sc.numHistoryShards,
+		common.NewRealTimeTokenBucket(sc.cfg.HistoryScavengerPersistenceMaxQPS()),
+		sc.cfg.HistoryScavengerPageSize(),
+		sc.cfg.HistoryScavengerGracePeriod(),
+		func(progress historyScavengerProgress) {
+			activity.RecordHeartbeat(ctx, progress)
+		},
+		sc.metricsClient,
+		sc.logger,
+	)
+	return scavenger.run(ctx, progress)
+}
+
+func newHistoryScavenger(
+	historyV2Manager persistence.HistoryV2Manager,
+	getExecutionManager func(shardID int) (persistence.ExecutionManager, error),
+	numHistoryShards int,
+	rateLimiter common.TokenBucket,
+	pageSize int,
+	gracePeriod time.Duration,
+	heartbeat func(progress historyScavengerProgress),
+	metricsClient metrics.Client,
+	logger bark.Logger,
+) *historyScavenger {
+	return &historyScavenger{
+		historyV2Manager:    historyV2Manager,
+		getExecutionManager: getExecutionManager,
+		numHistoryShards:    numHistoryShards,
+		rateLimiter:         rateLimiter,
+		pageSize:            pageSize,
+		gracePeriod:         gracePeriod,
+		heartbeat:           heartbeat,
+		thriftEncoder:       codec.NewThriftRWEncoder(),
+		metricsClient:       metricsClient,
+		logger: logger.WithFields(bark.Fields{
+			logging.TagWorkflowComponent: logging.TagValueHistoryScavengerComponent,
+		}),
+	}
+}
+
+// run scans all the history branches starting from the given progress
+func (s *historyScavenger) run(ctx context.Context, progress historyScavengerProgress) (HistoryScavengerReport, error) {
+	for {
+		if err := s.waitForToken(ctx); err != nil {
+			return progress.Report, err
+		}
+		resp, err := s.historyV2Manager.GetAllHistoryTreeBranches(&persistence.GetAllHistoryTreeBranchesRequest{
+			PageSize:      s.pageSize,
+			NextPageToken: progress.NextPageToken,
+		})
+		if err != nil {
+			s.metricsClient.IncCounter(metrics.HistoryScavengerScope, metrics.HistoryScavengerFailures)
+			s.logger.WithField(logging.TagErr, err).Error("failed to read history branches")
+			return progress.Report, err
+		}
+
+		for _, branch := range resp.Branches {
+			if err := s.handleBranch(ctx, branch, &progress.Report); err != nil {
+				return progress.Report, err
+			}
+		}
+
+		progress.NextPageToken = resp.NextPageToken
+		s.heartbeat(progress)
+		if len(progress.NextPageToken) == 0 {
+			break
+		}
+	}
+
+	s.logger.WithFields(bark.Fields{
+		"scanned":  progress.Report.Scanned,
+		"skipped":  progress.Report.Skipped,
+		"deleted":  progress.Report.Deleted,
+		"failures": progress.Report.Failures,
+	}).Info("history scavenger finished")
+	return progress.Report, nil
+}
+
+// handleBranch deletes the given branch if it is orphaned, only a cancelled context is returned as error,
+// failures on a single branch are counted and the branch will be looked at again by the next run
+func (s *historyScavenger) handleBranch(ctx context.Context, branch persistence.HistoryBranchDetail, report *HistoryScavengerReport) error {
+	report.Scanned++
+	s.metricsClient.IncCounter(metrics.HistoryScavengerScope, metrics.HistoryScavengerBranchesScanned)
+
+	if time.Now().Sub(branch.ForkTime) < s.gracePeriod {
+		report.Skipped++
+		s.metricsClient.IncCounter(metrics.HistoryScavengerScope, metrics.HistoryScavengerBranchesSkipped)
+		return nil
+	}
+
+	logger := s.logger.WithFields(bark.Fields{
+		logging.TagTreeID:   branch.TreeID,
+		logging.TagBranchID: branch.BranchID,
+	})
+
+	domainID, workflowID, runID, err := persistence.SplitHistoryGarbageCleanupInfo(branch.Info)
+	if err != nil {
+		// without the workflow identity there is no way to tell if the branch is still used
+		report.Skipped++
+		s.metricsClient.IncCounter(metrics.HistoryScavengerScope, metrics.HistoryScavengerBranchesSkipped)
+		logger.WithField(logging.TagErr, err).Warn("skipping history branch with unknown workflow")
+		return nil
+	}
+	logger = logger.WithFields(bark.Fields{
+		logging.TagDomainID:            domainID,
+		logging.TagWorkflowExecutionID: workflowID,
+		logging.TagWorkflowRunID:       runID,
+	})
+
+	if err := s.waitForToken(ctx); err != nil {
+		return err
+	}
+	orphaned, err := s.isOrphaned(branch, domainID, workflowID, runID)
+	if err != nil {
+		report.Failures++
+		s.metricsClient.IncCounter(metrics.HistoryScavengerScope, metrics.HistoryScavengerFailures)
+		logger.WithField(logging.TagErr, err).Error("failed to check workflow execution of history branch")
+		return nil
+	}
+	if !orphaned {
+		return nil
+	}
+
+	if err := s.waitForToken(ctx); err != nil {
+		return err
+	}
+	err = s.historyV2Manager.DeleteHistoryBranch(&persistence.DeleteHistoryBranchRequest{
+		BranchToken: branch.BranchToken,
+	})
+	if err != nil {
+		report.Failures++
+		s.metricsClient.IncCounter(metrics.HistoryScavengerScope, metrics.HistoryScavengerFailures)
+		logger.WithField(logging.TagErr, err).Error("failed to delete orphaned history branch")
+		return nil
+	}
+
+	report.Deleted++
+	s.metricsClient.IncCounter(metrics.HistoryScavengerScope, metrics.HistoryScavengerBranchesDeleted)
+	logger.Info("deleted orphaned history branch")
+	return nil
+}
+
+// isOrphaned returns true if the workflow execution of the branch does not exist
+// or its mutable state refers to a different history branch
+func (s *historyScavenger) isOrphaned(branch persistence.HistoryBranchDetail, domainID, workflowID, runID string) (bool, error) {
+	shardID := common.WorkflowIDToHistoryShard(workflowID, s.numHistoryShards)
+	executionManager, err := s.getExecutionManager(shardID)
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := executionManager.GetWorkflowExecution(&persistence.GetWorkflowExecutionRequest{
+		DomainID: domainID,
+		Execution: shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(workflowID),
+			RunId:      common.StringPtr(runID),
+		},
+	})
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); ok {
+			return true, nil
+		}
+		return false, err
+	}
+
+	branchToken := resp.State.ExecutionInfo.GetCurrentBranch()
+	if len(branchToken) == 0 {
+		return true, nil
+	}
+	var current shared.HistoryBranch
+	if err := s.thriftEncoder.Decode(branchToken, &current); err != nil {
+		return false, err
+	}
+	return current.GetBranchID() != branch.BranchID, nil
+}
+
+func (s *historyScavenger) waitForToken(ctx context.Context) error {
+	return common.WaitForToken(ctx, s.rateLimiter)
+}
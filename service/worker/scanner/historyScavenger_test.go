@@ -0,0 +1,216 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scanner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/codec"
+	metricsMocks "github.com/uber/cadence/common/metrics/mocks"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+)
+
+const (
+	testDomainID = "deadbeef-0000-4000-8000-000000000001"
+	testTreeID   = "deadbeef-0000-4000-8000-000000000002"
+)
+
+type historyScavengerSuite struct {
+	*require.Assertions
+	suite.Suite
+	historyV2Manager *mocks.HistoryV2Manager
+	executionManager *mocks.ExecutionManager
+	metricsClient    *metricsMocks.Client
+	heartbeats       []historyScavengerProgress
+	scavenger        *historyScavenger
+}
+
+func TestHistoryScavengerSuite(t *testing.T) {
+	suite.Run(t, new(historyScavengerSuite))
+}
+
+func (s *historyScavengerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.historyV2Manager = &mocks.HistoryV2Manager{}
+	s.executionManager = &mocks.ExecutionManager{}
+	s.metricsClient = &metricsMocks.Client{}
+	s.metricsClient.On("IncCounter", mock.Anything, mock.Anything)
+	s.heartbeats = nil
+	s.scavenger = newHistoryScavenger(
+		s.historyV2Manager,
+		func(shardID int) (persistence.ExecutionManager, error) { return s.executionManager, nil },
+		4,
+		common.NewTokenBucket(10000, common.NewRealTimeSource()),
+		10,
+		time.Hour,
+		func(progress historyScavengerProgress) { s.heartbeats = append(s.heartbeats, progress) },
+		s.metricsClient,
+		bark.NewNopLogger(),
+	)
+}
+
+func (s *historyScavengerSuite) TearDownTest() {
+	s.historyV2Manager.AssertExpectations(s.T())
+	s.executionManager.AssertExpectations(s.T())
+}
+
+func (s *historyScavengerSuite) TestSkipBranchWithinGracePeriod() {
+	branch := s.newBranch("wid", "run1", "branch1", time.Now())
+	s.mockPages([]persistence.HistoryBranchDetail{branch})
+
+	report, err := s.scavenger.run(context.Background(), historyScavengerProgress{})
+	s.NoError(err)
+	s.Equal(HistoryScavengerReport{Scanned: 1, Skipped: 1}, report)
+}
+
+func (s *historyScavengerSuite) TestSkipBranchWithUnknownWorkflow() {
+	branch := s.newBranch("wid", "run1", "branch1", time.Now().Add(-2*time.Hour))
+	branch.Info = "unknown"
+	s.mockPages([]persistence.HistoryBranchDetail{branch})
+
+	report, err := s.scavenger.run(context.Background(), historyScavengerProgress{})
+	s.NoError(err)
+	s.Equal(HistoryScavengerReport{Scanned: 1, Skipped: 1}, report)
+}
+
+func (s *historyScavengerSuite) TestDeleteBranchOfMissingExecution() {
+	branch := s.newBranch("wid", "run1", "branch1", time.Now().Add(-2*time.Hour))
+	s.mockPages([]persistence.HistoryBranchDetail{branch})
+	s.executionManager.On("GetWorkflowExecution", mock.Anything).Return(nil, &shared.EntityNotExistsError{}).Once()
+	s.historyV2Manager.On("DeleteHistoryBranch", &persistence.DeleteHistoryBranchRequest{
+		BranchToken: branch.BranchToken,
+	}).Return(nil).Once()
+
+	report, err := s.scavenger.run(context.Background(), historyScavengerProgress{})
+	s.NoError(err)
+	s.Equal(HistoryScavengerReport{Scanned: 1, Deleted: 1}, report)
+}
+
+func (s *historyScavengerSuite) TestDeleteBranchNotReferencedByExecution() {
+	branch := s.newBranch("wid", "run1", "branch1", time.Now().Add(-2*time.Hour))
+	current := s.newBranch("wid", "run1", "branch2", time.Now().Add(-2*time.Hour))
+	s.mockPages([]persistence.HistoryBranchDetail{branch})
+	s.mockExecution(current.BranchToken)
+	s.historyV2Manager.On("DeleteHistoryBranch", &persistence.DeleteHistoryBranchRequest{
+		BranchToken: branch.BranchToken,
+	}).Return(nil).Once()
+
+	report, err := s.scavenger.run(context.Background(), historyScavengerProgress{})
+	s.NoError(err)
+	s.Equal(HistoryScavengerReport{Scanned: 1, Deleted: 1}, report)
+}
+
+func (s *historyScavengerSuite) TestKeepBranchReferencedByExecution() {
+	branch := s.newBranch("wid", "run1", "branch1", time.Now().Add(-2*time.Hour))
+	s.mockPages([]persistence.HistoryBranchDetail{branch})
+	s.mockExecution(branch.BranchToken)
+
+	report, err := s.scavenger.run(context.Background(), historyScavengerProgress{})
+	s.NoError(err)
+	s.Equal(HistoryScavengerReport{Scanned: 1}, report)
+}
+
+func (s *historyScavengerSuite) TestExecutionReadFailure() {
+	branch := s.newBranch("wid", "run1", "branch1", time.Now().Add(-2*time.Hour))
+	s.mockPages([]persistence.HistoryBranchDetail{branch})
+	s.executionManager.On("GetWorkflowExecution", mock.Anything).Return(nil, errors.New("db error")).Once()
+
+	report, err := s.scavenger.run(context.Background(), historyScavengerProgress{})
+	s.NoError(err)
+	s.Equal(HistoryScavengerReport{Scanned: 1, Failures: 1}, report)
+}
+
+func (s *historyScavengerSuite) TestPagingAndHeartbeat() {
+	old := time.Now().Add(-2 * time.Hour)
+	page1 := []persistence.HistoryBranchDetail{s.newBranch("wid1", "run1", "branch1", old)}
+	page2 := []persistence.HistoryBranchDetail{s.newBranch("wid2", "run2", "branch2", time.Now())}
+	s.historyV2Manager.On("GetAllHistoryTreeBranches", &persistence.GetAllHistoryTreeBranchesRequest{
+		PageSize: 10,
+	}).Return(&persistence.GetAllHistoryTreeBranchesResponse{
+		Branches:      page1,
+		NextPageToken: []byte("page2"),
+	}, nil).Once()
+	s.historyV2Manager.On("GetAllHistoryTreeBranches", &persistence.GetAllHistoryTreeBranchesRequest{
+		PageSize:      10,
+		NextPageToken: []byte("page2"),
+	}).Return(&persistence.GetAllHistoryTreeBranchesResponse{
+		Branches: page2,
+	}, nil).Once()
+	s.mockExecution(page1[0].BranchToken)
+
+	report, err := s.scavenger.run(context.Background(), historyScavengerProgress{})
+	s.NoError(err)
+	s.Equal(HistoryScavengerReport{Scanned: 2, Skipped: 1}, report)
+	s.Equal(2, len(s.heartbeats))
+	s.Equal([]byte("page2"), s.heartbeats[0].NextPageToken)
+	s.Equal(HistoryScavengerReport{Scanned: 1}, s.heartbeats[0].Report)
+	s.Empty(s.heartbeats[1].NextPageToken)
+}
+
+func (s *historyScavengerSuite) TestReadBranchesFailure() {
+	s.historyV2Manager.On("GetAllHistoryTreeBranches", mock.Anything).Return(nil, errors.New("db error")).Once()
+
+	_, err := s.scavenger.run(context.Background(), historyScavengerProgress{})
+	s.Error(err)
+}
+
+func (s *historyScavengerSuite) newBranch(workflowID, runID, branchID string, forkTime time.Time) persistence.HistoryBranchDetail {
+	token, err := codec.NewThriftRWEncoder().Encode(&shared.HistoryBranch{
+		TreeID:   common.StringPtr(testTreeID),
+		BranchID: common.StringPtr(branchID),
+	})
+	s.NoError(err)
+	return persistence.HistoryBranchDetail{
+		TreeID:      testTreeID,
+		BranchID:    branchID,
+		BranchToken: token,
+		ForkTime:    forkTime,
+		Info:        persistence.BuildHistoryGarbageCleanupInfo(testDomainID, workflowID, runID),
+	}
+}
+
+func (s *historyScavengerSuite) mockPages(branches []persistence.HistoryBranchDetail) {
+	s.historyV2Manager.On("GetAllHistoryTreeBranches", &persistence.GetAllHistoryTreeBranchesRequest{
+		PageSize: 10,
+	}).Return(&persistence.GetAllHistoryTreeBranchesResponse{
+		Branches: branches,
+	}, nil).Once()
+}
+
+func (s *historyScavengerSuite) mockExecution(branchToken []byte) {
+	s.executionManager.On("GetWorkflowExecution", mock.Anything).Return(&persistence.GetWorkflowExecutionResponse{
+		State: &persistence.WorkflowMutableState{
+			ExecutionInfo: &persistence.WorkflowExecutionInfo{
+				BranchToken: branchToken,
+			},
+		},
+	}, nil).Once()
+}
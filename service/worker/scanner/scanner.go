@@ -0,0 +1,300 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scanner
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/client/history"
+	"github.com/uber/cadence/client/public"
+	"github.com/uber/cadence/common/backoff"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	persistencefactory "github.com/uber/cadence/common/persistence/persistence-factory"
+	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/service/worker/sysworkflow"
+	"go.uber.org/cadence/.gen/go/shared"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/worker"
+)
+
+type (
+	// Config defines the configuration for the scanner
+	Config struct {
+		// HistoryScavengerEnabled indicates if the history scavenger should be started
+		HistoryScavengerEnabled dynamicconfig.BoolPropertyFn
+		// HistoryScavengerPersistenceMaxQPS is the max qps the history scavenger can query DB
+		HistoryScavengerPersistenceMaxQPS dynamicconfig.IntPropertyFn
+		// HistoryScavengerPageSize is the number of history branches read per page
+		HistoryScavengerPageSize dynamicconfig.IntPropertyFn
+		// HistoryScavengerGracePeriod is the min age of a history branch before it can be deleted
+		HistoryScavengerGracePeriod dynamicconfig.DurationPropertyFn
+		// TaskListScavengerEnabled indicates if the task list scavenger should be started
+		TaskListScavengerEnabled dynamicconfig.BoolPropertyFn
+		// TaskListScavengerPersistenceMaxQPS is the max qps the task list scavenger can query DB
+		TaskListScavengerPersistenceMaxQPS dynamicconfig.IntPropertyFn
+		// TaskListScavengerPageSize is the number of task lists / tasks read per page
+		TaskListScavengerPageSize dynamicconfig.IntPropertyFn
+		// TaskListScavengerIdleTime is how long a task list must have had no activity before it can be deleted
+		TaskListScavengerIdleTime dynamicconfig.DurationPropertyFn
+		// ExecutionsScannerEnabled indicates if the executions scanner should be started
+		ExecutionsScannerEnabled dynamicconfig.BoolPropertyFn
+		// ExecutionsScannerPersistenceMaxQPS is the max qps the executions scanner can query DB
+		ExecutionsScannerPersistenceMaxQPS dynamicconfig.IntPropertyFn
+		// ExecutionsScannerPageSize is the number of executions read per page
+		ExecutionsScannerPageSize dynamicconfig.IntPropertyFn
+		// ExecutionsScannerFixEnabled indicates if corrupted executions should be fixed
+		ExecutionsScannerFixEnabled dynamicconfig.BoolPropertyFn
+		// ExecutionsScannerFixMode is how corrupted executions are fixed, either delete or reset
+		ExecutionsScannerFixMode dynamicconfig.StringPropertyFn
+	}
+
+	// BootstrapParams contains the set of params needed to bootstrap the scanner
+	BootstrapParams struct {
+		// Config contains the configuration for scanner
+		Config Config
+		// PublicClient is the cadence client used to run the scanner workflows
+		PublicClient public.Client
+		// HistoryClient is used by the executions scanner to reset corrupted executions
+		HistoryClient history.Client
+		// PersistenceFactory creates the persistence managers used by the scanner
+		PersistenceFactory persistencefactory.Factory
+		// NumHistoryShards is the number of history shards of the cluster
+		NumHistoryShards int
+		// MetricsClient is an instance of metrics object for emitting stats
+		MetricsClient metrics.Client
+		// Logger is the logger
+		Logger bark.Logger
+	}
+
+	// scannerContext is the context object that gets
+	// passed around within the scanner workflows / activities
+	scannerContext struct {
+		cfg               Config
+		historyManager    persistence.HistoryManager
+		historyV2Manager  persistence.HistoryV2Manager
+		taskManager       persistence.TaskManager
+		executionManagers *executionManagers
+		historyClient     history.Client
+		numHistoryShards  int
+		metricsClient     metrics.Client
+		logger            bark.Logger
+	}
+
+	// executionManagers lazily creates and caches the execution manager of each history shard
+	executionManagers struct {
+		sync.Mutex
+		factory  persistencefactory.Factory
+		managers map[int]persistence.ExecutionManager
+	}
+
+	// Scanner is the background sub-system that hosts the system workflows
+	// which scan the persistence layer and clean up data that is no longer used
+	Scanner struct {
+		context      scannerContext
+		publicClient public.Client
+		worker       worker.Worker
+		stopC        chan struct{}
+	}
+
+	contextKey int
+)
+
+const (
+	scannerContextKey contextKey = iota
+)
+
+const (
+	scannerTaskListName                = "cadence-sys-scanner-tl"
+	scannerWorkflowStartToCloseTimeout = 30 * 24 * time.Hour
+	scannerDecisionTaskTimeout         = time.Minute
+)
+
+// scannerWFStartOptions are the start options of each scanner workflow, keyed by workflow type
+var scannerWFStartOptions = map[string]client.StartWorkflowOptions{
+	historyScavengerWFTypeName:  historyScavengerWFStartOptions,
+	tlScavengerWFTypeName:       tlScavengerWFStartOptions,
+	executionsScannerWFTypeName: executionsScannerWFStartOptions,
+}
+
+// New returns a new instance of scanner daemon
+func New(params *BootstrapParams) *Scanner {
+	logger := params.Logger.WithFields(bark.Fields{
+		logging.TagWorkflowComponent: logging.TagValueScannerComponent,
+	})
+	return &Scanner{
+		context: scannerContext{
+			cfg: params.Config,
+			executionManagers: &executionManagers{
+				factory:  params.PersistenceFactory,
+				managers: make(map[int]persistence.ExecutionManager),
+			},
+			historyClient:    params.HistoryClient,
+			numHistoryShards: params.NumHistoryShards,
+			metricsClient:    params.MetricsClient,
+			logger:           logger,
+		},
+		publicClient: params.PublicClient,
+		stopC:        make(chan struct{}),
+	}
+}
+
+// Start starts the scanner
+func (s *Scanner) Start() error {
+	var workflows []string
+	factory := s.context.executionManagers.factory
+
+	if s.context.cfg.HistoryScavengerEnabled() {
+		historyV2Manager, err := factory.NewHistoryV2Manager()
+		if err != nil {
+			// not every persistence plugin supports events v2, there is nothing to scavenge then
+			s.context.logger.WithField(logging.TagErr, err).Warn("history V2 is not available, history scavenger not started")
+		} else {
+			s.context.historyV2Manager = historyV2Manager
+			workflows = append(workflows, historyScavengerWFTypeName)
+		}
+	}
+
+	if s.context.cfg.TaskListScavengerEnabled() {
+		taskManager, err := factory.NewTaskManager()
+		if err != nil {
+			return err
+		}
+		s.context.taskManager = taskManager
+		workflows = append(workflows, tlScavengerWFTypeName)
+	}
+
+	if s.context.cfg.ExecutionsScannerEnabled() {
+		historyManager, err := factory.NewHistoryManager()
+		if err != nil {
+			return err
+		}
+		s.context.historyManager = historyManager
+		if s.context.historyV2Manager == nil {
+			// executions on events v2 are reported as failures if the store does not support it
+			if historyV2Manager, err := factory.NewHistoryV2Manager(); err == nil {
+				s.context.historyV2Manager = historyV2Manager
+			}
+		}
+		workflows = append(workflows, executionsScannerWFTypeName)
+	}
+
+	if len(workflows) == 0 {
+		s.context.logger.Info("no scavenger is enabled, scanner not started")
+		return nil
+	}
+
+	workerOpts := worker.Options{
+		BackgroundActivityContext: context.WithValue(context.Background(), scannerContextKey, &s.context),
+	}
+	s.worker = worker.New(s.publicClient, sysworkflow.SystemDomainName, scannerTaskListName, workerOpts)
+	if err := s.worker.Start(); err != nil {
+		return err
+	}
+
+	for _, workflowType := range workflows {
+		go s.startWorkflowWithRetry(scannerWFStartOptions[workflowType], workflowType)
+	}
+	return nil
+}
+
+// Stop stops the scanner
+func (s *Scanner) Stop() {
+	close(s.stopC)
+	if s.worker != nil {
+		s.worker.Stop()
+	}
+	if s.context.historyManager != nil {
+		s.context.historyManager.Close()
+	}
+	if s.context.historyV2Manager != nil {
+		s.context.historyV2Manager.Close()
+	}
+	if s.context.taskManager != nil {
+		s.context.taskManager.Close()
+	}
+	s.context.executionManagers.close()
+}
+
+// startWorkflowWithRetry keeps trying to start the given workflow until it
+// succeeds, the frontend may not be ready to accept requests when the worker starts
+func (s *Scanner) startWorkflowWithRetry(options client.StartWorkflowOptions, workflowType string) {
+	policy := backoff.NewExponentialRetryPolicy(time.Second)
+	policy.SetMaximumInterval(time.Minute)
+	policy.SetExpirationInterval(backoff.NoInterval)
+	err := backoff.Retry(func() error {
+		select {
+		case <-s.stopC:
+			return nil
+		default:
+		}
+		return s.startWorkflow(options, workflowType)
+	}, policy, func(err error) bool {
+		return true
+	})
+	if err != nil {
+		s.context.logger.WithField(logging.TagErr, err).Errorf("unable to start scanner workflow %v", workflowType)
+	}
+}
+
+func (s *Scanner) startWorkflow(options client.StartWorkflowOptions, workflowType string) error {
+	sdkClient := client.NewClient(s.publicClient, sysworkflow.SystemDomainName, &client.Options{})
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	_, err := sdkClient.StartWorkflow(ctx, options, workflowType)
+	if err != nil {
+		if _, ok := err.(*shared.WorkflowExecutionAlreadyStartedError); ok {
+			return nil
+		}
+		s.context.logger.WithField(logging.TagErr, err).Warnf("error starting scanner workflow %v", workflowType)
+		return err
+	}
+	s.context.logger.Infof("scanner workflow %v started", workflowType)
+	return nil
+}
+
+// get returns the execution manager of the given shard
+func (m *executionManagers) get(shardID int) (persistence.ExecutionManager, error) {
+	m.Lock()
+	defer m.Unlock()
+	if mgr, ok := m.managers[shardID]; ok {
+		return mgr, nil
+	}
+	mgr, err := m.factory.NewExecutionManager(shardID)
+	if err != nil {
+		return nil, err
+	}
+	m.managers[shardID] = mgr
+	return mgr, nil
+}
+
+func (m *executionManagers) close() {
+	m.Lock()
+	defer m.Unlock()
+	for shardID, mgr := range m.managers {
+		mgr.Close()
+		delete(m.managers, shardID)
+	}
+}
@@ -0,0 +1,402 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scanner
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/logging"
+	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"go.uber.org/cadence"
+	"go.uber.org/cadence/activity"
+	"go.uber.org/cadence/client"
+	"go.uber.org/cadence/workflow"
+)
+
+type (
+	// TaskListScavengerReport is the summary of one run of the task list scavenger
+	TaskListScavengerReport struct {
+		Scanned      int64
+		Deleted      int64
+		TasksDeleted int64
+		Failures     int64
+	}
+
+	// tlScavengerProgress is recorded with every activity heartbeat
+	// so that a retried activity resumes from the last page it processed
+	tlScavengerProgress struct {
+		NextPageToken []byte
+		Report        TaskListScavengerReport
+	}
+
+	// tlScavenger pages through all task lists, deletes the tasks that are
+	// acked or expired and deletes the task lists that have been idle for too long
+	tlScavenger struct {
+		taskManager   persistence.TaskManager
+		rateLimiter   common.TokenBucket
+		pageSize      int
+		idleTime      time.Duration
+		heartbeat     func(progress tlScavengerProgress)
+		metricsClient metrics.Client
+		logger        bark.Logger
+	}
+)
+
+const (
+	tlScavengerWFID         = "cadence-sys-tl-scavenger"
+	tlScavengerWFTypeName   = "cadence-sys-tl-scavenger-workflow"
+	tlScavengerActivityName = "cadence-sys-tl-scavenger-activity"
+	// tlScavengerPeriod is the time between two runs of the task list scavenger
+	tlScavengerPeriod = 12 * time.Hour
+)
+
+var (
+	tlScavengerWFStartOptions = client.StartWorkflowOptions{
+		ID:                              tlScavengerWFID,
+		TaskList:                        scannerTaskListName,
+		ExecutionStartToCloseTimeout:    scannerWorkflowStartToCloseTimeout,
+		DecisionTaskStartToCloseTimeout: scannerDecisionTaskTimeout,
+		WorkflowIDReusePolicy:           client.WorkflowIDReusePolicyAllowDuplicate,
+	}
+
+	tlScavengerActivityOptions = workflow.ActivityOptions{
+		ScheduleToStartTimeout: 5 * time.Minute,
+		StartToCloseTimeout:    tlScavengerPeriod,
+		HeartbeatTimeout:       5 * time.Minute,
+		RetryPolicy: &cadence.RetryPolicy{
+			InitialInterval:    10 * time.Second,
+			BackoffCoefficient: 1.7,
+			MaximumInterval:    10 * time.Minute,
+			ExpirationInterval: tlScavengerPeriod,
+		},
+	}
+)
+
+func init() {
+	workflow.RegisterWithOptions(TaskListScavengerWorkflow, workflow.RegisterOptions{Name: tlScavengerWFTypeName})
+	activity.RegisterWithOptions(TaskListScavengerActivity, activity.RegisterOptions{Name: tlScavengerActivityName})
+}
+
+// TaskListScavengerWorkflow is the workflow that runs the task list scavenger twice a day
+func TaskListScavengerWorkflow(ctx workflow.Context) error {
+	logger := workflow.GetLogger(ctx)
+
+	var report TaskListScavengerReport
+	ctx = workflow.WithActivityOptions(ctx, tlScavengerActivityOptions)
+	err := workflow.ExecuteActivity(ctx, tlScavengerActivityName).Get(ctx, &report)
+	if err != nil {
+		logger.Error("task list scavenger activity failed, err=" + err.Error())
+	} else {
+		logger.Info("task list scavenger activity completed")
+	}
+
+	if err := workflow.Sleep(ctx, tlScavengerPeriod); err != nil {
+		return err
+	}
+	return workflow.NewContinueAsNewError(ctx, tlScavengerWFTypeName)
+}
+
+// TaskListScavengerActivity scans all task lists and deletes stale tasks and idle task lists
+func TaskListScavengerActivity(ctx context.Context) (TaskListScavengerReport, error) {
+	sc := ctx.Value(scannerContextKey).(*scannerContext)
+
+	var progress tlScavengerProgress
+	if activity.HasHeartbeatDetails(ctx) {
+		if err := activity.GetHeartbeatDetails(ctx, &progress); err != nil {
+			sc.logger.WithField(logging.TagErr, err).Error("failed to read task list scavenger progress, starting over")
+			progress = tlScavengerProgress{}
+		}
+	}
+
+	scavenger := newTaskListScavenger(
+		sc.taskManager,
+		common.NewRealTimeTokenBucket(sc.cfg.TaskListScavengerPersistenceMaxQPS()),
+		sc.cfg.TaskListScavengerPageSize(),
+		sc.cfg.TaskListScavengerIdleTime(),
+		func(progress tlScavengerProgress) {
+			activity.RecordHeartbeat(ctx, progress)
+		},
+		sc.metricsClient,
+		sc.logger,
+	)
+	return scavenger.run(ctx, progress)
+}
+
+func newTaskListScavenger(
+	taskManager persistence.TaskManager,
+	rateLimiter common.TokenBucket,
+	pageSize int,
+	idleTime time.Duration,
+	heartbeat func(progress tlScavengerProgress),
+	metricsClient metrics.Client,
+	logger bark.Logger,
+) *tlScavenger {
+	return &tlScavenger{
+		taskManager:   taskManager,
+		rateLimiter:   rateLimiter,
+		pageSize:      pageSize,
+		idleTime:      idleTime,
+		heartbeat:     heartbeat,
+		metricsClient: metricsClient,
+		logger: logger.WithFields(bark.Fields{
+			logging.TagWorkflowComponent: logging.TagValueTaskListScavengerComponent,
+		}),
+	}
+}
+
+// run scans all the task lists starting from the given progress
+func (s *tlScavenger) run(ctx context.Context, progress tlScavengerProgress) (TaskListScavengerReport, error) {
+	for {
+		if err := s.waitForToken(ctx); err != nil {
+			return progress.Report, err
+		}
+		resp, err := s.taskManager.ListTaskList(&persistence.ListTaskListRequest{
+			PageSize:  s.pageSize,
+			PageToken: progress.NextPageToken,
+		})
+		if err != nil {
+			s.metricsClient.IncCounter(metrics.TaskListScavengerScope, metrics.TaskListScavengerFailures)
+			s.logger.WithField(logging.TagErr, err).Error("failed to list task lists")
+			return progress.Report, err
+		}
+
+		for _, info := range resp.Items {
+			if err := s.handleTaskList(ctx, info, &progress.Report); err != nil {
+				return progress.Report, err
+			}
+		}
+
+		progress.NextPageToken = resp.NextPageToken
+		s.heartbeat(progress)
+		if len(progress.NextPageToken) == 0 {
+			break
+		}
+	}
+
+	s.logger.WithFields(bark.Fields{
+		"scanned":       progress.Report.Scanned,
+		"deleted":       progress.Report.Deleted,
+		"tasks-deleted": progress.Report.TasksDeleted,
+		"failures":      progress.Report.Failures,
+	}).Info("task list scavenger finished")
+	return progress.Report, nil
+}
+
+// handleTaskList deletes the acked and expired tasks of the given task list and then
+// deletes the task list itself if it is empty and idle, only a cancelled context is
+// returned as error, failures on a single task list are counted and retried by the next run
+func (s *tlScavenger) handleTaskList(ctx context.Context, info persistence.TaskListInfo, report *TaskListScavengerReport) error {
+	report.Scanned++
+	s.metricsClient.IncCounter(metrics.TaskListScavengerScope, metrics.TaskListScavengerTaskListsScanned)
+
+	logger := s.logger.WithFields(bark.Fields{
+		logging.TagDomainID:     info.DomainID,
+		logging.TagTaskListName: info.Name,
+		logging.TagTaskListType: info.TaskType,
+	})
+
+	if err := s.deleteTasks(ctx, info, info.AckLevel, report); err != nil {
+		return s.handleFailure(ctx, err, report, logger, "failed to delete acked tasks")
+	}
+
+	empty, err := s.deleteExpiredTasks(ctx, info, report)
+	if err != nil {
+		return s.handleFailure(ctx, err, report, logger, "failed to delete expired tasks")
+	}
+	// task lists written before last_updated was introduced read back a zero time, their idle
+	// time is unknown until the task list is leased again, so they are never deleted
+	if !empty || info.LastUpdated.IsZero() || time.Now().Sub(info.LastUpdated) < s.idleTime {
+		return nil
+	}
+	// the build sets of a versioned task list are only kept on the task list itself,
+	// deleting it would drop the compatible builds of its workflows
+	if len(info.BuildSets) > 0 {
+		return nil
+	}
+
+	// steal the lease, so that a matching host still holding the old range can no longer append
+	// tasks, then check again for tasks appended since the task list was scanned
+	if err := s.waitForToken(ctx); err != nil {
+		return err
+	}
+	resp, err := s.taskManager.LeaseTaskList(&persistence.LeaseTaskListRequest{
+		DomainID:     info.DomainID,
+		TaskList:     info.Name,
+		TaskType:     info.TaskType,
+		TaskListKind: info.Kind,
+	})
+	if err != nil {
+		if _, ok := err.(*persistence.ConditionFailedError); ok {
+			// the task list was leased again since it was listed, it is no longer idle
+			return nil
+		}
+		return s.handleFailure(ctx, err, report, logger, "failed to lease idle task list")
+	}
+	leased := resp.TaskListInfo
+	if leased.RangeID != info.RangeID+1 {
+		// the task list was leased again since it was listed, it is no longer idle
+		return nil
+	}
+	empty, err = s.isEmpty(ctx, leased)
+	if err != nil {
+		return s.handleFailure(ctx, err, report, logger, "failed to check idle task list")
+	}
+	if !empty {
+		// the owner picks the tasks up once it leases the task list again
+		return nil
+	}
+	if len(leased.BuildSets) > 0 {
+		// build sets were added to the task list since it was listed
+		return nil
+	}
+
+	if err := s.waitForToken(ctx); err != nil {
+		return err
+	}
+	err = s.taskManager.DeleteTaskList(&persistence.DeleteTaskListRequest{
+		DomainID:     info.DomainID,
+		TaskListName: info.Name,
+		TaskListType: info.TaskType,
+		RangeID:      leased.RangeID,
+	})
+	if err != nil {
+		if _, ok := err.(*persistence.ConditionFailedError); ok {
+			// the task list was leased again since the lease was stolen, it is no longer idle
+			return nil
+		}
+		return s.handleFailure(ctx, err, report, logger, "failed to delete idle task list")
+	}
+
+	report.Deleted++
+	s.metricsClient.IncCounter(metrics.TaskListScavengerScope, metrics.TaskListScavengerTaskListsDeleted)
+	logger.Info("deleted idle task list")
+	return nil
+}
+
+// deleteExpiredTasks deletes the tasks above the ack level whose schedule to start timeout has
+// passed. Only the leading expired tasks are deleted, so that a single range delete removes them.
+// Returns true if no task is left in the task list.
+func (s *tlScavenger) deleteExpiredTasks(ctx context.Context, info persistence.TaskListInfo, report *TaskListScavengerReport) (bool, error) {
+	readLevel := info.AckLevel
+	for {
+		if err := s.waitForToken(ctx); err != nil {
+			return false, err
+		}
+		resp, err := s.taskManager.GetTasks(&persistence.GetTasksRequest{
+			DomainID:     info.DomainID,
+			TaskList:     info.Name,
+			TaskType:     info.TaskType,
+			ReadLevel:    readLevel,
+			MaxReadLevel: math.MaxInt64,
+			BatchSize:    s.pageSize,
+		})
+		if err != nil {
+			return false, err
+		}
+		if len(resp.Tasks) == 0 {
+			return true, nil
+		}
+
+		now := time.Now()
+		expiredLevel := readLevel
+		for _, task := range resp.Tasks {
+			if task.Expiry.IsZero() || task.Expiry.After(now) {
+				break
+			}
+			expiredLevel = task.TaskID
+		}
+		if expiredLevel > readLevel {
+			if err := s.deleteTasks(ctx, info, expiredLevel, report); err != nil {
+				return false, err
+			}
+		}
+		if expiredLevel != resp.Tasks[len(resp.Tasks)-1].TaskID {
+			// there is at least one live task left
+			return false, nil
+		}
+		readLevel = expiredLevel
+	}
+}
+
+// isEmpty returns true if the task list has no task above its ack level
+func (s *tlScavenger) isEmpty(ctx context.Context, info *persistence.TaskListInfo) (bool, error) {
+	if err := s.waitForToken(ctx); err != nil {
+		return false, err
+	}
+	resp, err := s.taskManager.GetTasks(&persistence.GetTasksRequest{
+		DomainID:     info.DomainID,
+		TaskList:     info.Name,
+		TaskType:     info.TaskType,
+		ReadLevel:    info.AckLevel,
+		MaxReadLevel: math.MaxInt64,
+		BatchSize:    1,
+	})
+	if err != nil {
+		return false, err
+	}
+	return len(resp.Tasks) == 0, nil
+}
+
+// deleteTasks deletes all the tasks of the task list with an id less than or equal to the given one
+func (s *tlScavenger) deleteTasks(ctx context.Context, info persistence.TaskListInfo, taskID int64, report *TaskListScavengerReport) error {
+	for {
+		if err := s.waitForToken(ctx); err != nil {
+			return err
+		}
+		n, err := s.taskManager.CompleteTasksLessThan(&persistence.CompleteTasksLessThanRequest{
+			DomainID:     info.DomainID,
+			TaskListName: info.Name,
+			TaskType:     info.TaskType,
+			TaskID:       taskID,
+			Limit:        s.pageSize,
+		})
+		if err != nil {
+			return err
+		}
+		if n == persistence.UnknownNumRowsAffected {
+			return nil
+		}
+		report.TasksDeleted += int64(n)
+		s.metricsClient.AddCounter(metrics.TaskListScavengerScope, metrics.TaskListScavengerTasksDeleted, int64(n))
+		if n < s.pageSize {
+			return nil
+		}
+	}
+}
+
+func (s *tlScavenger) handleFailure(ctx context.Context, err error, report *TaskListScavengerReport, logger bark.Logger, msg string) error {
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	report.Failures++
+	s.metricsClient.IncCounter(metrics.TaskListScavengerScope, metrics.TaskListScavengerFailures)
+	logger.WithField(logging.TagErr, err).Error(msg)
+	return nil
+}
+
+func (s *tlScavenger) waitForToken(ctx context.Context) error {
+	return common.WaitForToken(ctx, s.rateLimiter)
+}
@@ -0,0 +1,327 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package scanner
+
+import (
+	"context"
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/common"
+	metricsMocks "github.com/uber/cadence/common/metrics/mocks"
+	"github.com/uber/cadence/common/mocks"
+	"github.com/uber/cadence/common/persistence"
+)
+
+type tlScavengerSuite struct {
+	*require.Assertions
+	suite.Suite
+	taskManager   *mocks.TaskManager
+	metricsClient *metricsMocks.Client
+	heartbeats    []tlScavengerProgress
+	scavenger     *tlScavenger
+}
+
+func TestTaskListScavengerSuite(t *testing.T) {
+	suite.Run(t, new(tlScavengerSuite))
+}
+
+func (s *tlScavengerSuite) SetupTest() {
+	s.Assertions = require.New(s.T())
+	s.taskManager = &mocks.TaskManager{}
+	s.metricsClient = &metricsMocks.Client{}
+	s.metricsClient.On("IncCounter", mock.Anything, mock.Anything)
+	s.metricsClient.On("AddCounter", mock.Anything, mock.Anything, mock.Anything)
+	s.heartbeats = nil
+	s.scavenger = newTaskListScavenger(
+		s.taskManager,
+		common.NewTokenBucket(10000, common.NewRealTimeSource()),
+		10,
+		time.Hour,
+		func(progress tlScavengerProgress) { s.heartbeats = append(s.heartbeats, progress) },
+		s.metricsClient,
+		bark.NewNopLogger(),
+	)
+}
+
+func (s *tlScavengerSuite) TearDownTest() {
+	s.taskManager.AssertExpectations(s.T())
+}
+
+func (s *tlScavengerSuite) TestDeleteAckedTasksAndKeepActiveTaskList() {
+	tl := s.newTaskList("tl1", 100, time.Now().Add(-2*time.Hour))
+	s.mockPages([]persistence.TaskListInfo{tl})
+	s.mockCompleteTasks(tl, 100, 10)
+	s.mockCompleteTasks(tl, 100, 3)
+	s.mockGetTasks(tl, 100, &persistence.TaskInfo{TaskID: 101})
+
+	report, err := s.scavenger.run(context.Background(), tlScavengerProgress{})
+	s.NoError(err)
+	s.Equal(TaskListScavengerReport{Scanned: 1, TasksDeleted: 13}, report)
+}
+
+func (s *tlScavengerSuite) TestDeleteIdleTaskList() {
+	tl := s.newTaskList("tl1", 100, time.Now().Add(-2*time.Hour))
+	s.mockPages([]persistence.TaskListInfo{tl})
+	s.mockCompleteTasks(tl, 100, persistence.UnknownNumRowsAffected)
+	s.mockGetTasks(tl, 100)
+	leased := s.mockLease(tl, tl.RangeID+1)
+	s.mockIsEmpty(leased)
+	s.taskManager.On("DeleteTaskList", &persistence.DeleteTaskListRequest{
+		DomainID:     tl.DomainID,
+		TaskListName: tl.Name,
+		TaskListType: tl.TaskType,
+		RangeID:      tl.RangeID + 1,
+	}).Return(nil).Once()
+
+	report, err := s.scavenger.run(context.Background(), tlScavengerProgress{})
+	s.NoError(err)
+	s.Equal(TaskListScavengerReport{Scanned: 1, Deleted: 1}, report)
+}
+
+func (s *tlScavengerSuite) TestKeepRecentlyUpdatedTaskList() {
+	tl := s.newTaskList("tl1", 100, time.Now())
+	s.mockPages([]persistence.TaskListInfo{tl})
+	s.mockCompleteTasks(tl, 100, 0)
+	s.mockGetTasks(tl, 100)
+
+	report, err := s.scavenger.run(context.Background(), tlScavengerProgress{})
+	s.NoError(err)
+	s.Equal(TaskListScavengerReport{Scanned: 1}, report)
+}
+
+func (s *tlScavengerSuite) TestKeepTaskListWithUnknownLastUpdated() {
+	tl := s.newTaskList("tl1", 100, time.Time{})
+	s.mockPages([]persistence.TaskListInfo{tl})
+	s.mockCompleteTasks(tl, 100, 0)
+	s.mockGetTasks(tl, 100)
+
+	report, err := s.scavenger.run(context.Background(), tlScavengerProgress{})
+	s.NoError(err)
+	s.Equal(TaskListScavengerReport{Scanned: 1}, report)
+}
+
+func (s *tlScavengerSuite) TestKeepIdleTaskListWithBuildSets() {
+	tl := s.newTaskList("tl1", 100, time.Now().Add(-2*time.Hour))
+	tl.BuildSets = [][]string{{"build1"}, {"build2"}}
+	s.mockPages([]persistence.TaskListInfo{tl})
+	s.mockCompleteTasks(tl, 100, 0)
+	s.mockGetTasks(tl, 100)
+
+	report, err := s.scavenger.run(context.Background(), tlScavengerProgress{})
+	s.NoError(err)
+	s.Equal(TaskListScavengerReport{Scanned: 1}, report)
+}
+
+func (s *tlScavengerSuite) TestDeleteExpiredTasks() {
+	expired := time.Now().Add(-time.Minute)
+	tl := s.newTaskList("tl1", 100, time.Now())
+	s.mockPages([]persistence.TaskListInfo{tl})
+	s.mockCompleteTasks(tl, 100, 0)
+	s.mockGetTasks(tl, 100,
+		&persistence.TaskInfo{TaskID: 101, Expiry: expired},
+		&persistence.TaskInfo{TaskID: 102, Expiry: expired},
+		&persistence.TaskInfo{TaskID: 103, Expiry: time.Now().Add(time.Hour)},
+		&persistence.TaskInfo{TaskID: 104, Expiry: expired},
+	)
+	s.mockCompleteTasks(tl, 102, 2)
+
+	report, err := s.scavenger.run(context.Background(), tlScavengerProgress{})
+	s.NoError(err)
+	s.Equal(TaskListScavengerReport{Scanned: 1, TasksDeleted: 2}, report)
+}
+
+func (s *tlScavengerSuite) TestDeleteTaskListAfterAllTasksExpired() {
+	expired := time.Now().Add(-time.Minute)
+	tl := s.newTaskList("tl1", 100, time.Now().Add(-2*time.Hour))
+	s.mockPages([]persistence.TaskListInfo{tl})
+	s.mockCompleteTasks(tl, 100, 0)
+	s.mockGetTasks(tl, 100, &persistence.TaskInfo{TaskID: 101, Expiry: expired})
+	s.mockCompleteTasks(tl, 101, 1)
+	s.mockGetTasks(tl, 101)
+	s.mockIsEmpty(s.mockLease(tl, tl.RangeID+1))
+	s.taskManager.On("DeleteTaskList", mock.Anything).Return(nil).Once()
+
+	report, err := s.scavenger.run(context.Background(), tlScavengerProgress{})
+	s.NoError(err)
+	s.Equal(TaskListScavengerReport{Scanned: 1, Deleted: 1, TasksDeleted: 1}, report)
+}
+
+func (s *tlScavengerSuite) TestTaskListLeasedBeforeDelete() {
+	tl := s.newTaskList("tl1", 100, time.Now().Add(-2*time.Hour))
+	s.mockPages([]persistence.TaskListInfo{tl})
+	s.mockCompleteTasks(tl, 100, 0)
+	s.mockGetTasks(tl, 100)
+	s.mockIsEmpty(s.mockLease(tl, tl.RangeID+1))
+	s.taskManager.On("DeleteTaskList", mock.Anything).Return(&persistence.ConditionFailedError{}).Once()
+
+	report, err := s.scavenger.run(context.Background(), tlScavengerProgress{})
+	s.NoError(err)
+	s.Equal(TaskListScavengerReport{Scanned: 1}, report)
+}
+
+func (s *tlScavengerSuite) TestTaskListLeasedBeforeSteal() {
+	tl := s.newTaskList("tl1", 100, time.Now().Add(-2*time.Hour))
+	s.mockPages([]persistence.TaskListInfo{tl})
+	s.mockCompleteTasks(tl, 100, 0)
+	s.mockGetTasks(tl, 100)
+	s.mockLease(tl, tl.RangeID+2)
+
+	report, err := s.scavenger.run(context.Background(), tlScavengerProgress{})
+	s.NoError(err)
+	s.Equal(TaskListScavengerReport{Scanned: 1}, report)
+}
+
+func (s *tlScavengerSuite) TestTaskAddedBeforeSteal() {
+	tl := s.newTaskList("tl1", 100, time.Now().Add(-2*time.Hour))
+	s.mockPages([]persistence.TaskListInfo{tl})
+	s.mockCompleteTasks(tl, 100, 0)
+	s.mockGetTasks(tl, 100)
+	leased := s.mockLease(tl, tl.RangeID+1)
+	s.taskManager.On("GetTasks", &persistence.GetTasksRequest{
+		DomainID:     leased.DomainID,
+		TaskList:     leased.Name,
+		TaskType:     leased.TaskType,
+		ReadLevel:    leased.AckLevel,
+		MaxReadLevel: math.MaxInt64,
+		BatchSize:    1,
+	}).Return(&persistence.GetTasksResponse{Tasks: []*persistence.TaskInfo{{TaskID: 101}}}, nil).Once()
+
+	report, err := s.scavenger.run(context.Background(), tlScavengerProgress{})
+	s.NoError(err)
+	s.Equal(TaskListScavengerReport{Scanned: 1}, report)
+}
+
+func (s *tlScavengerSuite) TestDeleteTasksFailure() {
+	tl := s.newTaskList("tl1", 100, time.Now().Add(-2*time.Hour))
+	s.mockPages([]persistence.TaskListInfo{tl})
+	s.taskManager.On("CompleteTasksLessThan", mock.Anything).Return(0, errors.New("db error")).Once()
+
+	report, err := s.scavenger.run(context.Background(), tlScavengerProgress{})
+	s.NoError(err)
+	s.Equal(TaskListScavengerReport{Scanned: 1, Failures: 1}, report)
+}
+
+func (s *tlScavengerSuite) TestPagingAndHeartbeat() {
+	tl1 := s.newTaskList("tl1", 100, time.Now())
+	tl2 := s.newTaskList("tl2", 200, time.Now())
+	s.taskManager.On("ListTaskList", &persistence.ListTaskListRequest{
+		PageSize: 10,
+	}).Return(&persistence.ListTaskListResponse{
+		Items:         []persistence.TaskListInfo{tl1},
+		NextPageToken: []byte("page2"),
+	}, nil).Once()
+	s.taskManager.On("ListTaskList", &persistence.ListTaskListRequest{
+		PageSize:  10,
+		PageToken: []byte("page2"),
+	}).Return(&persistence.ListTaskListResponse{
+		Items: []persistence.TaskListInfo{tl2},
+	}, nil).Once()
+	s.mockCompleteTasks(tl1, 100, 0)
+	s.mockGetTasks(tl1, 100)
+	s.mockCompleteTasks(tl2, 200, 0)
+	s.mockGetTasks(tl2, 200)
+
+	report, err := s.scavenger.run(context.Background(), tlScavengerProgress{})
+	s.NoError(err)
+	s.Equal(TaskListScavengerReport{Scanned: 2}, report)
+	s.Equal(2, len(s.heartbeats))
+	s.Equal([]byte("page2"), s.heartbeats[0].NextPageToken)
+	s.Equal(TaskListScavengerReport{Scanned: 1}, s.heartbeats[0].Report)
+	s.Empty(s.heartbeats[1].NextPageToken)
+}
+
+func (s *tlScavengerSuite) TestListTaskListFailure() {
+	s.taskManager.On("ListTaskList", mock.Anything).Return(nil, errors.New("db error")).Once()
+
+	_, err := s.scavenger.run(context.Background(), tlScavengerProgress{})
+	s.Error(err)
+}
+
+func (s *tlScavengerSuite) newTaskList(name string, ackLevel int64, lastUpdated time.Time) persistence.TaskListInfo {
+	return persistence.TaskListInfo{
+		DomainID:    testDomainID,
+		Name:        name,
+		TaskType:    persistence.TaskListTypeActivity,
+		RangeID:     5,
+		AckLevel:    ackLevel,
+		Kind:        persistence.TaskListKindNormal,
+		LastUpdated: lastUpdated,
+	}
+}
+
+func (s *tlScavengerSuite) mockPages(items []persistence.TaskListInfo) {
+	s.taskManager.On("ListTaskList", &persistence.ListTaskListRequest{
+		PageSize: 10,
+	}).Return(&persistence.ListTaskListResponse{
+		Items: items,
+	}, nil).Once()
+}
+
+func (s *tlScavengerSuite) mockCompleteTasks(tl persistence.TaskListInfo, taskID int64, deleted int) {
+	s.taskManager.On("CompleteTasksLessThan", &persistence.CompleteTasksLessThanRequest{
+		DomainID:     tl.DomainID,
+		TaskListName: tl.Name,
+		TaskType:     tl.TaskType,
+		TaskID:       taskID,
+		Limit:        10,
+	}).Return(deleted, nil).Once()
+}
+
+func (s *tlScavengerSuite) mockGetTasks(tl persistence.TaskListInfo, readLevel int64, tasks ...*persistence.TaskInfo) {
+	s.taskManager.On("GetTasks", &persistence.GetTasksRequest{
+		DomainID:     tl.DomainID,
+		TaskList:     tl.Name,
+		TaskType:     tl.TaskType,
+		ReadLevel:    readLevel,
+		MaxReadLevel: math.MaxInt64,
+		BatchSize:    10,
+	}).Return(&persistence.GetTasksResponse{Tasks: tasks}, nil).Once()
+}
+
+func (s *tlScavengerSuite) mockLease(tl persistence.TaskListInfo, rangeID int64) *persistence.TaskListInfo {
+	leased := tl
+	leased.RangeID = rangeID
+	s.taskManager.On("LeaseTaskList", &persistence.LeaseTaskListRequest{
+		DomainID:     tl.DomainID,
+		TaskList:     tl.Name,
+		TaskType:     tl.TaskType,
+		TaskListKind: tl.Kind,
+	}).Return(&persistence.LeaseTaskListResponse{TaskListInfo: &leased}, nil).Once()
+	return &leased
+}
+
+func (s *tlScavengerSuite) mockIsEmpty(tl *persistence.TaskListInfo) {
+	s.taskManager.On("GetTasks", &persistence.GetTasksRequest{
+		DomainID:     tl.DomainID,
+		TaskList:     tl.Name,
+		TaskType:     tl.TaskType,
+		ReadLevel:    tl.AckLevel,
+		MaxReadLevel: math.MaxInt64,
+		BatchSize:    1,
+	}).Return(&persistence.GetTasksResponse{}, nil).Once()
+}
@@ -32,11 +32,16 @@ import (
 	"github.com/uber-common/bark"
 	"github.com/uber/cadence/common"
 	"github.com/uber/cadence/common/metrics"
+	"github.com/uber/cadence/common/persistence"
+	"github.com/uber/cadence/common/persistence/elasticsearch"
 	persistencefactory "github.com/uber/cadence/common/persistence/persistence-factory"
 	"github.com/uber/cadence/common/service"
 	"github.com/uber/cadence/common/service/dynamicconfig"
+	"github.com/uber/cadence/service/worker/domaindeleter"
 	"github.com/uber/cadence/service/worker/indexer"
 	"github.com/uber/cadence/service/worker/replicator"
+	"github.com/uber/cadence/service/worker/scanner"
+	"github.com/uber/cadence/service/worker/scanner/executions"
 	"github.com/uber/cadence/service/worker/sysworkflow"
 	"go.uber.org/cadence/.gen/go/shared"
 )
@@ -51,6 +56,8 @@ type (
 	// 1. Replicator: Handles applying replication tasks generated by remote clusters.
 	// 2. Indexer: Handles uploading of visibility records to elastic search.
 	// 3. Sysworker: Handles running cadence client worker, thereby enabling cadence to host arbitrary system workflows
+	// 4. Scanner: Handles running the system workflows which clean up unused data in persistence
+	// 5. DomainDeleter: Handles running the system workflow which deletes all the data of a deprecated domain
 	Service struct {
 		stopC         chan struct{}
 		isStopped     int32
@@ -62,9 +69,11 @@ type (
 
 	// Config contains all the service config for worker
 	Config struct {
-		ReplicationCfg *replicator.Config
-		SysWorkflowCfg *sysworkflow.Config
-		IndexerCfg     *indexer.Config
+		ReplicationCfg   *replicator.Config
+		SysWorkflowCfg   *sysworkflow.Config
+		IndexerCfg       *indexer.Config
+		ScannerCfg       *scanner.Config
+		DomainDeleterCfg *domaindeleter.Config
 	}
 )
 
@@ -100,6 +109,27 @@ func NewConfig(dc *dynamicconfig.Collection) *Config {
 			ESProcessorBulkSize:      dc.GetIntProperty(dynamicconfig.WorkerESProcessorBulkSize, 2<<24), // 16MB
 			ESProcessorFlushInterval: dc.GetDurationProperty(dynamicconfig.WorkerESProcessorFlushInterval, 10*time.Second),
 		},
+		ScannerCfg: &scanner.Config{
+			HistoryScavengerEnabled:            dc.GetBoolProperty(dynamicconfig.HistoryScavengerEnabled, true),
+			HistoryScavengerPersistenceMaxQPS:  dc.GetIntProperty(dynamicconfig.HistoryScavengerPersistenceMaxQPS, 100),
+			HistoryScavengerPageSize:           dc.GetIntProperty(dynamicconfig.HistoryScavengerPageSize, 100),
+			HistoryScavengerGracePeriod:        dc.GetDurationProperty(dynamicconfig.HistoryScavengerGracePeriod, 7*24*time.Hour),
+			TaskListScavengerEnabled:           dc.GetBoolProperty(dynamicconfig.TaskListScavengerEnabled, true),
+			TaskListScavengerPersistenceMaxQPS: dc.GetIntProperty(dynamicconfig.TaskListScavengerPersistenceMaxQPS, 100),
+			TaskListScavengerPageSize:          dc.GetIntProperty(dynamicconfig.TaskListScavengerPageSize, 100),
+			TaskListScavengerIdleTime:          dc.GetDurationProperty(dynamicconfig.TaskListScavengerIdleTime, 3*24*time.Hour),
+			ExecutionsScannerEnabled:           dc.GetBoolProperty(dynamicconfig.ExecutionsScannerEnabled, false),
+			ExecutionsScannerPersistenceMaxQPS: dc.GetIntProperty(dynamicconfig.ExecutionsScannerPersistenceMaxQPS, 100),
+			ExecutionsScannerPageSize:          dc.GetIntProperty(dynamicconfig.ExecutionsScannerPageSize, 100),
+			ExecutionsScannerFixEnabled:        dc.GetBoolProperty(dynamicconfig.ExecutionsScannerFixEnabled, false),
+			ExecutionsScannerFixMode:           dc.GetStringProperty(dynamicconfig.ExecutionsScannerFixMode, string(executions.FixModeDelete)),
+		},
+		DomainDeleterCfg: &domaindeleter.Config{
+			Enabled:                    dc.GetBoolProperty(dynamicconfig.DomainDeleterEnabled, false),
+			PersistenceMaxQPS:          dc.GetIntProperty(dynamicconfig.DomainDeleterPersistenceMaxQPS, 100),
+			PageSize:                   dc.GetIntProperty(dynamicconfig.DomainDeleterPageSize, 100),
+			EnableReadVisibilityFromES: dc.GetBoolPropertyFnWithDomainFilter(dynamicconfig.EnableReadVisibilityFromES, false),
+		},
 	}
 }
 
@@ -113,7 +143,7 @@ func (s *Service) Start() {
 
 	pConfig := s.params.PersistenceConfig
 	pConfig.SetMaxQPS(pConfig.DefaultStore, s.config.ReplicationCfg.PersistenceMaxQPS())
-	pFactory := persistencefactory.New(&pConfig, s.params.ClusterMetadata.GetCurrentClusterName(), s.metricsClient, s.logger)
+	pFactory := persistencefactory.New(&pConfig, s.params.BlobstoreClient, s.params.ClusterMetadata.GetCurrentClusterName(), s.metricsClient, s.logger)
 
 	if base.GetClusterMetadata().IsGlobalDomainEnabled() {
 		s.startReplicator(base, pFactory)
@@ -124,6 +154,8 @@ func (s *Service) Start() {
 	if s.params.ESConfig.Enable {
 		s.startIndexer(base)
 	}
+	s.startScanner(base, pFactory)
+	s.startDomainDeleter(base, pFactory)
 
 	s.logger.Infof("%v started", common.WorkerServiceName)
 	<-s.stopC
@@ -222,6 +254,69 @@ func (s *Service) startSysWorker(base service.Service, pFactory persistencefacto
 	}
 }
 
+func (s *Service) startScanner(base service.Service, pFactory persistencefactory.Factory) {
+	publicClient := public.NewRetryableClient(
+		base.GetClientBean().GetPublicClient(),
+		common.CreatePublicClientRetryPolicy(),
+		common.IsWhitelistServiceTransientError,
+	)
+	params := &scanner.BootstrapParams{
+		Config:             *s.config.ScannerCfg,
+		PublicClient:       publicClient,
+		HistoryClient:      base.GetClientBean().GetHistoryClient(),
+		PersistenceFactory: pFactory,
+		NumHistoryShards:   s.params.PersistenceConfig.NumHistoryShards,
+		MetricsClient:      s.metricsClient,
+		Logger:             s.logger,
+	}
+	scanner := scanner.New(params)
+	if err := scanner.Start(); err != nil {
+		scanner.Stop()
+		s.logger.Fatalf("failed to start scanner: %v", err)
+	}
+}
+
+func (s *Service) startDomainDeleter(base service.Service, pFactory persistencefactory.Factory) {
+	publicClient := public.NewRetryableClient(
+		base.GetClientBean().GetPublicClient(),
+		common.CreatePublicClientRetryPolicy(),
+		common.IsWhitelistServiceTransientError,
+	)
+	visibilityFromDB, err := pFactory.NewVisibilityManager()
+	if err != nil {
+		s.logger.Fatalf("failed to start domain deleter, could not create VisibilityManager: %v", err)
+	}
+	var visibilityFromES persistence.VisibilityManager
+	if s.params.ESConfig.Enable {
+		visibilityIndexName := s.params.ESConfig.Indices[common.VisibilityAppName]
+		visibilityFromES = elasticsearch.NewElasticSearchVisibilityManager(s.params.ESClient, visibilityIndexName, s.logger)
+		visibilityFromES = elasticsearch.NewVisibilityMetricsClient(visibilityFromES, s.metricsClient, s.logger)
+	}
+	visibility := persistence.NewVisibilityManagerWrapper(visibilityFromDB, visibilityFromES, s.config.DomainDeleterCfg.EnableReadVisibilityFromES)
+	blobstoreClient := blobstore.NewRetryableClient(
+		blobstore.NewMetricClient(s.params.BlobstoreClient, s.metricsClient),
+		common.CreateBlobstoreClientRetryPolicy(),
+		common.IsBlobstoreTransientError)
+
+	params := &domaindeleter.BootstrapParams{
+		Config:             *s.config.DomainDeleterCfg,
+		PublicClient:       publicClient,
+		ClientBean:         base.GetClientBean(),
+		ClusterMetadata:    base.GetClusterMetadata(),
+		PersistenceFactory: pFactory,
+		VisibilityManager:  visibility,
+		BlobstoreClient:    blobstoreClient,
+		NumHistoryShards:   s.params.PersistenceConfig.NumHistoryShards,
+		MetricsClient:      s.metricsClient,
+		Logger:             s.logger,
+	}
+	deleter := domaindeleter.New(params)
+	if err := deleter.Start(); err != nil {
+		deleter.Stop()
+		s.logger.Fatalf("failed to start domain deleter: %v", err)
+	}
+}
+
 func (s *Service) waitForFrontendStart(publicClient public.Client) {
 	request := &shared.DescribeDomainRequest{
 		Name: common.StringPtr(sysworkflow.SystemDomainName),
@@ -22,6 +22,8 @@ package sysworkflow
 
 import (
 	"time"
+
+	"github.com/uber/cadence/common"
 )
 
 type contextKey int
@@ -32,7 +34,7 @@ const (
 
 const (
 	// SystemDomainName is domain name for all system workflows
-	SystemDomainName = "cadence-system"
+	SystemDomainName = common.SystemDomainName
 
 	workflowIDPrefix                    = "cadsys-wf"
 	decisionTaskList                    = "cadsys-decision-tl"
@@ -44,6 +46,7 @@ const (
 	archivalUploadActivityFnName        = "ArchivalUploadActivity"
 	archivalDeleteHistoryActivityFnName = "ArchivalDeleteHistoryActivity"
 	historyBlobKeyExt                   = "history"
+	historyBlobDomainIDTag              = "domain_id"
 	blobstoreOperationsDefaultTimeout   = 5 * time.Second
 	heartbeatTimeout                    = 10 * time.Second
 	numWorkers                          = 50
@@ -61,13 +61,25 @@ func NewHistoryBlobKey(domainID, workflowID, runID, pageToken string) (blob.Key,
 	if len(domainID) == 0 || len(workflowID) == 0 || len(runID) == 0 || len(pageToken) == 0 {
 		return nil, errors.New("all inputs required to be non-empty")
 	}
-	domainIDHash := fmt.Sprintf("%v", farm.Fingerprint64([]byte(domainID)))
+	domainIDHash := HistoryBlobKeyPrefix(domainID)
 	workflowIDHash := fmt.Sprintf("%v", farm.Fingerprint64([]byte(workflowID)))
 	runIDHash := fmt.Sprintf("%v", farm.Fingerprint64([]byte(runID)))
 	combinedHash := strings.Join([]string{domainIDHash, workflowIDHash, runIDHash}, "")
 	return blob.NewKey(historyBlobKeyExt, combinedHash, pageToken)
 }
 
+// HistoryBlobKeyPrefix returns the prefix of the keys of the history blobs of the domain. The hashes
+// making up a key are not delimited, so the prefix can match the blobs of other domains too, the
+// domain of a blob is given by its tags, see HistoryBlobDomainID.
+func HistoryBlobKeyPrefix(domainID string) string {
+	return fmt.Sprintf("%v", farm.Fingerprint64([]byte(domainID)))
+}
+
+// HistoryBlobDomainID returns the id of the domain of a history blob from the tags of the blob
+func HistoryBlobDomainID(tags map[string]string) string {
+	return tags[historyBlobDomainIDTag]
+}
+
 // ConvertHeaderToTags converts header into metadata tags for blob
 func ConvertHeaderToTags(header *HistoryBlobHeader) (map[string]string, error) {
 	var tempMap map[string]interface{}
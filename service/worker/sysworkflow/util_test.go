@@ -24,6 +24,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber/cadence/common"
+	"strings"
 	"testing"
 )
 
@@ -125,3 +126,14 @@ func (s *UtilSuite) TestConvertHeaderToTags() {
 		s.Equal(tc.expectTags, tags)
 	}
 }
+
+func (s *UtilSuite) TestHistoryBlobOfDomain() {
+	key, err := NewHistoryBlobKey("testDomainID", "testWorkflowID", "testRunID", "testPageToken")
+	s.NoError(err)
+	s.True(strings.HasPrefix(key.String(), HistoryBlobKeyPrefix("testDomainID")))
+	s.False(strings.HasPrefix(key.String(), HistoryBlobKeyPrefix("otherDomainID")))
+
+	tags, err := ConvertHeaderToTags(&HistoryBlobHeader{DomainID: common.StringPtr("testDomainID")})
+	s.NoError(err)
+	s.Equal("testDomainID", HistoryBlobDomainID(tags))
+}
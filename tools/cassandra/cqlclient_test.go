@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
+	"github.com/uber/cadence/tools/common/schema"
 	"io/ioutil"
 	"math/rand"
 	"os"
@@ -87,7 +88,7 @@ func (s *CQLClientTestSuite) TestParseCQLFile() {
 	defer os.Remove(cqlFile.Name())
 
 	cqlFile.WriteString(createTestCQLFileContent())
-	stmts, err := ParseCQLFile(cqlFile.Name())
+	stmts, err := schema.ParseFile(cqlFile.Name())
 	s.Nil(err)
 	s.Equal(2, len(stmts), "wrong number of cql statements")
 }
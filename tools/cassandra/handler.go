@@ -24,6 +24,8 @@ import (
 	"fmt"
 	"github.com/urfave/cli"
 	"log"
+
+	"github.com/uber/cadence/tools/common/schema"
 )
 
 // setupSchema executes the setupSchemaTask
@@ -112,7 +114,7 @@ func validateSetupSchemaConfig(config *SetupSchemaConfig) error {
 			flag(cliOptVersion) + " but not both must be specified")
 	}
 	if !config.DisableVersioning {
-		ver, err := parseValidateVersion(config.InitialVersion)
+		ver, err := schema.ParseValidateVersion(config.InitialVersion)
 		if err != nil {
 			return newConfigError("invalid " + flag(cliOptVersion) + " argument:" + err.Error())
 		}
@@ -157,7 +159,7 @@ func validateUpdateSchemaConfig(config *UpdateSchemaConfig) error {
 		return newConfigError("missing " + flag(cliOptSchemaDir) + " argument ")
 	}
 	if len(config.TargetVersion) > 0 {
-		ver, err := parseValidateVersion(config.TargetVersion)
+		ver, err := schema.ParseValidateVersion(config.TargetVersion)
 		if err != nil {
 			return newConfigError("invalid " + flag(cliOptTargetVersion) + " argument:" + err.Error())
 		}
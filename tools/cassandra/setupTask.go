@@ -22,6 +22,8 @@ package cassandra
 
 import (
 	"log"
+
+	"github.com/uber/cadence/tools/common/schema"
 )
 
 // SetupSchemaTask represents a task
@@ -67,7 +69,7 @@ func (task *SetupSchemaTask) run() error {
 	}
 
 	if len(config.SchemaFilePath) > 0 {
-		stmts, err := ParseCQLFile(config.SchemaFilePath)
+		stmts, err := schema.ParseFile(config.SchemaFilePath)
 		if err != nil {
 			return err
 		}
@@ -33,6 +33,7 @@ import (
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
 	"github.com/uber-common/bark"
+	"github.com/uber/cadence/tools/common/schema"
 )
 
 type (
@@ -130,7 +131,7 @@ func (s *UpdateSchemaTestSuite) TestDryrun() {
 	s.Nil(err)
 	// update the version to the latest
 	s.log.Infof("Ver: %v", ver)
-	s.Equal(0, cmpVersion(ver, "0.13"))
+	s.Equal(0, schema.CmpVersion(ver, "0.19"))
 
 	dropAllTablesTypes(client)
 }
@@ -172,104 +173,3 @@ func (s *UpdateSchemaTestSuite) makeSchemaVersionDirs(rootDir string) {
 	err = ioutil.WriteFile(dir+"/domain.cql", []byte(domain), os.FileMode(0600))
 	s.Nil(err)
 }
-
-func (s *UpdateSchemaTestSuite) TestReadManifest() {
-
-	tmpDir, err := ioutil.TempDir("", "update_schema_test")
-	s.Nil(err)
-	defer os.RemoveAll(tmpDir)
-
-	input := `{
-		"CurrVersion": "0.4",
-		"MinCompatibleVersion": "0.1",
-		"Description": "base version of schema",
-		"SchemaUpdateCqlFiles": ["base1.cql", "base2.cql", "base3.cql"]
-	}`
-	files := []string{"base1.cql", "base2.cql", "base3.cql"}
-	s.runReadManifestTest(tmpDir, input, "0.4", "0.1", "base version of schema", files, false)
-
-	errInputs := []string{
-		`{
-			"MinCompatibleVersion": "0.1",
-			"Description": "base",
-			"SchemaUpdateCqlFiles": ["base1.cql"]
-		 }`,
-		`{
-			"CurrVersion": "0.4",
-			"Description": "base version of schema",
-			"SchemaUpdateCqlFiles": ["base1.cql", "base2.cql", "base3.cql"]
-		 }`,
-		`{
-			"CurrVersion": "0.4",
-			"MinCompatibleVersion": "0.1",
-			"Description": "base version of schema",
-		 }`,
-		`{
-			"CurrVersion": "",
-			"MinCompatibleVersion": "0.1",
-			"Description": "base version of schema",
-			"SchemaUpdateCqlFiles": ["base1.cql", "base2.cql", "base3.cql"]
-		 }`,
-		`{
-			"CurrVersion": "0.4",
-			"MinCompatibleVersion": "",
-			"Description": "base version of schema",
-			"SchemaUpdateCqlFiles": ["base1.cql", "base2.cql", "base3.cql"]
-		 }`,
-		`{
-			"CurrVersion": "",
-			"MinCompatibleVersion": "0.1",
-			"Description": "base version of schema",
-			"SchemaUpdateCqlFiles": []
-		 }`,
-	}
-
-	for _, in := range errInputs {
-		s.runReadManifestTest(tmpDir, in, "", "", "", nil, true)
-	}
-}
-
-func (s *UpdateSchemaTestSuite) TestReadSchemaDir() {
-
-	tmpDir, err := ioutil.TempDir("", "update_schema_test")
-	s.Nil(err)
-	defer os.RemoveAll(tmpDir)
-
-	subDirs := []string{"v0.5", "v1.5", "v2.5", "v3.5", "v10.2", "abc", "2.0", "3.0"}
-	for _, d := range subDirs {
-		os.Mkdir(tmpDir+"/"+d, os.FileMode(0444))
-	}
-
-	_, err = readSchemaDir(tmpDir, "11.0", "11.2")
-	s.NotNil(err)
-	_, err = readSchemaDir(tmpDir, "0.5", "10.3")
-	s.NotNil(err)
-
-	ans, err := readSchemaDir(tmpDir, "0.4", "10.2")
-	s.Nil(err)
-	s.Equal([]string{"v0.5", "v1.5", "v2.5", "v3.5", "v10.2"}, ans)
-
-	ans, err = readSchemaDir(tmpDir, "0.5", "3.5")
-	s.Nil(err)
-	s.Equal([]string{"v1.5", "v2.5", "v3.5"}, ans)
-}
-
-func (s *UpdateSchemaTestSuite) runReadManifestTest(dir, input, currVer, minVer, desc string,
-	files []string, isErr bool) {
-
-	file := dir + "/manifest.json"
-	err := ioutil.WriteFile(file, []byte(input), os.FileMode(0644))
-	s.Nil(err)
-
-	m, err := readManifest(dir)
-	if isErr {
-		s.NotNil(err)
-		return
-	}
-	s.Nil(err)
-	s.Equal(currVer, m.CurrVersion)
-	s.Equal(minVer, m.MinCompatibleVersion)
-	s.Equal(desc, m.Description)
-	s.True(len(m.md5) > 0)
-	s.Equal(files, m.SchemaUpdateCqlFiles)
-}
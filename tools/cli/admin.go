@@ -20,7 +20,10 @@
 
 package cli
 
-import "github.com/urfave/cli"
+import (
+	"github.com/uber/cadence/service/worker/scanner/executions"
+	"github.com/urfave/cli"
+)
 
 func newAdminWorkflowCommands() []cli.Command {
 	return []cli.Command{
@@ -197,6 +200,224 @@ func newAdminHistoryHostCommands() []cli.Command {
 				AdminGetShardID(c)
 			},
 		},
+		{
+			Name:    "drain",
+			Aliases: []string{"dr"},
+			Usage:   "Move all shards of history host to other hosts before shutting it down, the host stays out of membership ring until it is restarted",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagHistoryAddressWithAlias,
+					Usage: "History Host address(IP:PORT)",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDrainHistoryHost(c)
+			},
+		},
+	}
+}
+
+func newAdminShardCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:    "describe",
+			Aliases: []string{"desc"},
+			Usage:   "Describe persisted state of shard",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardIDWithAlias,
+					Usage: "ShardID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminDescribeShard(c)
+			},
+		},
+		{
+			Name:    "close",
+			Aliases: []string{"cl"},
+			Usage:   "Close shard on history host owning it, the same host reloads the shard from persistence",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardIDWithAlias,
+					Usage: "ShardID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminCloseShard(c)
+			},
+		},
+		{
+			Name:  "scan",
+			Usage: "Scan executions of shards for corrupted mutable state and write a report of the corrupted ones",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardIDWithAlias,
+					Usage: "ShardID, scan only this shard",
+				},
+				cli.IntFlag{
+					Name:  FlagNumberOfShards,
+					Usage: "NumberOfShards for the cadence cluster(see config for numHistoryShards), scan all shards if shardID is not given",
+				},
+				cli.StringFlag{
+					Name:  FlagOutputFilenameWithAlias,
+					Usage: "Output file the corrupted executions are written to as one JSON object per line",
+					Value: "corrupted_executions.json",
+				},
+				cli.BoolFlag{
+					Name:  FlagFix,
+					Usage: "Fix the corrupted executions",
+				},
+				cli.StringFlag{
+					Name:  FlagFixMode,
+					Value: string(executions.FixModeDelete),
+					Usage: "How corrupted executions are fixed: delete, or reset to their last completed decision through the frontend",
+				},
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: 100,
+					Usage: "Number of executions read from database per request",
+				},
+				cli.IntFlag{
+					Name:  FlagRPS,
+					Usage: "Max number of database requests per second",
+					Value: 100,
+				},
+
+				// for cassandra connection
+				cli.StringFlag{
+					Name:  FlagAddress,
+					Usage: "cassandra host address",
+				},
+				cli.IntFlag{
+					Name:  FlagPort,
+					Usage: "cassandra port for the host (default is 9042)",
+				},
+				cli.StringFlag{
+					Name:  FlagUsername,
+					Usage: "cassandra username",
+				},
+				cli.StringFlag{
+					Name:  FlagPassword,
+					Usage: "cassandra password",
+				},
+				cli.StringFlag{
+					Name:  FlagKeyspace,
+					Usage: "cassandra keyspace",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminScanShards(c)
+			},
+		},
+	}
+}
+
+func newAdminQueueCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:    "list",
+			Aliases: []string{"l"},
+			Usage:   "List pending tasks of a shard or a workflow",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardIDWithAlias,
+					Usage: "ShardID, optional if workflowID is given",
+				},
+				cli.StringFlag{
+					Name:  FlagQueueTaskTypeWithAlias,
+					Usage: "Task type: transfer, timer or replication",
+				},
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID, only list tasks of this workflow",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID, only list tasks of this run",
+				},
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: 100,
+					Usage: "Number of tasks read from database per request",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminListQueueTasks(c)
+			},
+		},
+		{
+			Name:    "remove",
+			Aliases: []string{"rm"},
+			Usage:   "Remove a task from queue of a shard",
+			Flags: []cli.Flag{
+				cli.IntFlag{
+					Name:  FlagShardIDWithAlias,
+					Usage: "ShardID",
+				},
+				cli.StringFlag{
+					Name:  FlagQueueTaskTypeWithAlias,
+					Usage: "Task type: transfer, timer or replication",
+				},
+				cli.Int64Flag{
+					Name:  FlagTaskIDWithAlias,
+					Usage: "TaskID",
+				},
+				cli.StringFlag{
+					Name: FlagVisibilityTimestampAlias,
+					Usage: "Visibility timestamp of timer task, required for timer task. " +
+						"Supported formats are '2006-01-02T15:04:05Z' or raw UnixNano",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminRemoveQueueTask(c)
+			},
+		},
+		{
+			Name:    "regenerate",
+			Aliases: []string{"regen"},
+			Usage:   "Re-generate transfer and timer tasks of a workflow from its mutable state",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagWorkflowIDWithAlias,
+					Usage: "WorkflowID",
+				},
+				cli.StringFlag{
+					Name:  FlagRunIDWithAlias,
+					Usage: "RunID",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminRegenerateWorkflowTasks(c)
+			},
+		},
+	}
+}
+
+func newAdminTaskListCommands() []cli.Command {
+	return []cli.Command{
+		{
+			Name:    "promote",
+			Aliases: []string{"pro"},
+			Usage:   "Promote a worker build as default for new workflows on a decision tasklist",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagTaskListWithAlias,
+					Usage: "TaskList name",
+				},
+				cli.StringFlag{
+					Name:  FlagBuildIDWithAlias,
+					Usage: "Build ID, the binary checksum reported by workers of the build",
+				},
+				cli.StringFlag{
+					Name:  FlagCompatibleBuildIDWithAlias,
+					Usage: "Optional build ID which the promoted build is compatible with",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminPromoteTaskListBuildID(c)
+			},
+		},
 	}
 }
 
@@ -353,6 +574,14 @@ func newAdminDomainCommands() []cli.Command {
 				AdminDescribeDomain(c)
 			},
 		},
+		{
+			Name:    "delete",
+			Aliases: []string{"del"},
+			Usage:   "Delete all the data of a deprecated domain which has no open workflow, the domain itself included, if enabled by the worker.domainDeleterEnabled dynamic config",
+			Action: func(c *cli.Context) {
+				AdminDeleteDomain(c)
+			},
+		},
 	}
 }
 
@@ -613,5 +842,81 @@ func newAdminElasticSearchCommands() []cli.Command {
 				AdminIndex(c)
 			},
 		},
+		{
+			Name:    "reindex",
+			Aliases: []string{"rind"},
+			Usage:   "Rebuild docs of a domain on ElasticSearch from visibility store",
+			Flags: []cli.Flag{
+				cli.StringFlag{
+					Name:  FlagURL,
+					Usage: "URL of ElasticSearch cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagMuttleyDestinationWithAlias,
+					Usage: "Optional muttely destination to ElasticSearch cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagIndex,
+					Usage: "ElasticSearch target index",
+				},
+				cli.StringFlag{
+					Name:  FlagDomainID,
+					Usage: "Domain ID(uuid)",
+				},
+				cli.StringFlag{
+					Name:  FlagEarliestTimeWithAlias,
+					Usage: "Optional earliest start time of workflows to reindex, supported formats are '2006-01-02T15:04:05Z07:00' and raw UnixNano",
+				},
+				cli.StringFlag{
+					Name:  FlagLatestTimeWithAlias,
+					Usage: "Optional latest start time of workflows to reindex, supported formats are '2006-01-02T15:04:05Z07:00' and raw UnixNano",
+				},
+				cli.IntFlag{
+					Name:  FlagBatchSizeWithAlias,
+					Usage: "Optional page size of visibility records to read and bulk index",
+					Value: 1000,
+				},
+				cli.IntFlag{
+					Name:  FlagRPS,
+					Usage: "Optional max number of docs indexed per second",
+					Value: 100,
+				},
+				cli.StringFlag{
+					Name:  FlagProgressFileWithAlias,
+					Usage: "Optional file to save progress to, reindex resumes from it when the file exists",
+					Value: "reindex_progress.json",
+				},
+
+				// for visibility store connection
+				cli.StringFlag{
+					Name:  FlagDBEngine,
+					Usage: "Type of the visibility store, cassandra or mysql",
+					Value: "cassandra",
+				},
+				cli.StringFlag{
+					Name:  FlagAddress,
+					Usage: "cassandra or mysql host address",
+				},
+				cli.IntFlag{
+					Name:  FlagPort,
+					Usage: "cassandra or mysql port for the host (default is 9042 for cassandra)",
+				},
+				cli.StringFlag{
+					Name:  FlagUsername,
+					Usage: "cassandra or mysql username",
+				},
+				cli.StringFlag{
+					Name:  FlagPassword,
+					Usage: "cassandra or mysql password",
+				},
+				cli.StringFlag{
+					Name:  FlagKeyspace,
+					Usage: "cassandra keyspace or mysql database name",
+				},
+			},
+			Action: func(c *cli.Context) {
+				AdminReindex(c)
+			},
+		},
 	}
 }
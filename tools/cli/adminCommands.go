@@ -146,7 +146,7 @@ func AdminDescribeWorkflow(c *cli.Context) {
 		ErrorAndExit("Describe workflow execution failed", err)
 	}
 
-	prettyPrintJSONObject(resp)
+	printObject(c, resp)
 
 	if resp != nil {
 		msStr := resp.GetMutableStateInDatabase()
@@ -162,7 +162,7 @@ func AdminDescribeWorkflow(c *cli.Context) {
 			if err != nil {
 				ErrorAndExit("thriftrwEncoder.Decode err", err)
 			}
-			prettyPrintJSONObject(branchInfo)
+			printObject(c, branchInfo)
 		}
 	}
 }
@@ -336,5 +336,5 @@ func AdminDescribeHistoryHost(c *cli.Context) {
 	if !printFully {
 		resp.ShardIDs = nil
 	}
-	prettyPrintJSONObject(resp)
+	printObject(c, resp)
 }
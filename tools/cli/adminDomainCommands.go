@@ -27,6 +27,7 @@ import (
 
 	"github.com/uber/cadence/.gen/go/shared"
 	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/domaindeletion"
 	"github.com/urfave/cli"
 )
 
@@ -273,6 +274,39 @@ func AdminDescribeDomain(c *cli.Context) {
 	fmt.Printf(formatStr, descValues...)
 }
 
+// AdminDeleteDomain starts the workflow which deletes all the data of a deprecated domain
+func AdminDeleteDomain(c *cli.Context) {
+	frontendClient := cFactory.ServerFrontendClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
+
+	ctx, cancel := newContext()
+	defer cancel()
+	resp, err := frontendClient.DescribeDomain(ctx, &shared.DescribeDomainRequest{
+		Name: common.StringPtr(domain),
+	})
+	if err != nil {
+		if _, ok := err.(*shared.EntityNotExistsError); !ok {
+			ErrorAndExit("Operation DescribeDomain failed.", err)
+		}
+		ErrorAndExit(fmt.Sprintf("Domain %s does not exist.", domain), err)
+	}
+	if resp.DomainInfo.GetStatus() != shared.DomainStatusDeprecated {
+		ErrorAndExit(fmt.Sprintf("Domain %s is not deprecated, deprecate it before deleting it.", domain), nil)
+	}
+
+	request, err := domaindeletion.NewStartWorkflowRequest(domaindeletion.Params{DomainName: domain}, getCliIdentity())
+	if err != nil {
+		ErrorAndExit("Failed to create domain deletion request.", err)
+	}
+	startResp, err := frontendClient.StartWorkflowExecution(ctx, request)
+	if err != nil {
+		ErrorAndExit("Failed to start domain deletion.", err)
+	}
+	fmt.Printf("Deletion of domain %s started, WorkflowID: %s, RunID: %s\n", domain, request.GetWorkflowId(), startResp.GetRunId())
+	fmt.Printf("Progress can be followed with: cadence --domain %s workflow observe --workflow_id %s\n",
+		common.SystemDomainName, request.GetWorkflowId())
+}
+
 func serverClustersToString(clusters []*shared.ClusterReplicationConfiguration) string {
 	var res string
 	for i, cluster := range clusters {
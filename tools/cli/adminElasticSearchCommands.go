@@ -25,14 +25,20 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"github.com/olekukonko/tablewriter"
 	"github.com/olivere/elastic"
+	"github.com/uber-common/bark"
 	"github.com/uber/cadence/.gen/go/indexer"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
 	es "github.com/uber/cadence/common/elasticsearch"
+	"github.com/uber/cadence/common/persistence"
+	cassp "github.com/uber/cadence/common/persistence/cassandra"
+	"github.com/uber/cadence/common/persistence/sql"
+	"github.com/uber/cadence/common/service/config"
 	"github.com/urfave/cli"
+	"io/ioutil"
 	"net/http"
 	"os"
-	"strconv"
 	"strings"
 	"time"
 )
@@ -42,8 +48,38 @@ const (
 	esDocType        = "_doc"
 
 	versionTypeExternal = "external"
+	// ES requires a positive external version, task IDs of indexer messages are always above it
+	reindexFirstVersion = 1
 )
 
+var catIndicesColumns = []outputColumn{
+	{Name: "health", Header: "health"},
+	{Name: "status", Header: "status"},
+	{Name: "index", Header: "index"},
+	{Name: "pri", Header: "pri"},
+	{Name: "rep", Header: "rep"},
+	{Name: "docs.count", Header: "docs.count"},
+	{Name: "docs.deleted", Header: "docs.deleted"},
+	{Name: "store.size", Header: "store.size"},
+	{Name: "pri.store.size", Header: "pri.store.size"},
+}
+
+const (
+	reindexPhaseOpen   = "open"
+	reindexPhaseClosed = "closed"
+	reindexPhaseDone   = "done"
+)
+
+// reindexProgress is persisted after each page so an interrupted reindex can be resumed
+type reindexProgress struct {
+	DomainID      string `json:"domainID"`
+	EarliestTime  int64  `json:"earliestTime"`
+	LatestTime    int64  `json:"latestTime"`
+	Phase         string `json:"phase"`
+	NextPageToken []byte `json:"nextPageToken"`
+	Count         int64  `json:"count"`
+}
+
 const (
 	headerSource      = "rpc-caller"
 	headerDestination = "rpc-service"
@@ -102,23 +138,11 @@ func AdminCatIndices(c *cli.Context) {
 		ErrorAndExit("Unable to cat indices", err)
 	}
 
-	table := tablewriter.NewWriter(os.Stdout)
-	header := []string{"health", "status", "index", "pri", "rep", "docs.count", "docs.deleted", "store.size", "pri.store.size"}
-	table.SetHeader(header)
+	writer := newRecordWriter(c, catIndicesColumns, false)
 	for _, row := range resp {
-		data := make([]string, len(header))
-		data[0] = row.Health
-		data[1] = row.Status
-		data[2] = row.Index
-		data[3] = strconv.Itoa(row.Pri)
-		data[4] = strconv.Itoa(row.Rep)
-		data[5] = strconv.Itoa(row.DocsCount)
-		data[6] = strconv.Itoa(row.DocsDeleted)
-		data[7] = row.StoreSize
-		data[8] = row.PriStoreSize
-		table.Append(data)
+		writer.Append(row.Health, row.Status, row.Index, row.Pri, row.Rep, row.DocsCount, row.DocsDeleted, row.StoreSize, row.PriStoreSize)
 	}
-	table.Render()
+	writer.Close()
 }
 
 // AdminIndex used to bulk insert message from kafka parse
@@ -177,6 +201,235 @@ func AdminIndex(c *cli.Context) {
 	}
 }
 
+// AdminReindex rebuilds ES docs of a domain from the visibility store
+func AdminReindex(c *cli.Context) {
+	esClient := getESClient(c)
+	indexName := getRequiredOption(c, FlagIndex)
+	domainID := getRequiredOption(c, FlagDomainID)
+	earliestTime := parseTime(c.String(FlagEarliestTime), 0)
+	latestTime := parseTime(c.String(FlagLatestTime), time.Now().UnixNano())
+	pageSize := c.Int(FlagBatchSize)
+	progressFile := c.String(FlagProgressFile)
+	rps := c.Int(FlagRPS)
+	if pageSize <= 0 || rps <= 0 {
+		ErrorAndExit("batch_size and rps must be positive", nil)
+	}
+
+	progress := loadReindexProgress(progressFile, domainID, earliestTime, latestTime)
+	if progress.Phase == reindexPhaseDone {
+		fmt.Printf("Reindex of domain %v is already done with %v docs, remove %v to start over\n", domainID, progress.Count, progressFile)
+		return
+	}
+
+	visibilityMgr := getVisibilityManager(c)
+	defer visibilityMgr.Close()
+	throttler := common.NewTokenBucket(rps, common.NewRealTimeSource())
+
+	for progress.Phase != reindexPhaseDone {
+		request := &persistence.ListWorkflowExecutionsRequest{
+			DomainUUID:        domainID,
+			EarliestStartTime: progress.EarliestTime,
+			LatestStartTime:   progress.LatestTime,
+			PageSize:          pageSize,
+			NextPageToken:     progress.NextPageToken,
+		}
+		var resp *persistence.ListWorkflowExecutionsResponse
+		var err error
+		if progress.Phase == reindexPhaseOpen {
+			resp, err = visibilityMgr.ListOpenWorkflowExecutions(request)
+		} else {
+			resp, err = visibilityMgr.ListClosedWorkflowExecutions(request)
+		}
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Failed to list %v workflow executions", progress.Phase), err)
+		}
+
+		if len(resp.Executions) != 0 {
+			existingDocs := getExistingDocs(esClient, indexName, resp.Executions)
+			bulkRequest := esClient.Bulk()
+			for i, execution := range resp.Executions {
+				throttler.Consume(1, time.Minute)
+				if req := generateReindexRequest(indexName, domainID, execution, existingDocs[i]); req != nil {
+					bulkRequest.Add(req)
+				}
+			}
+			if bulkRequest.NumberOfActions() != 0 {
+				bulkResp, err := bulkRequest.Do(context.Background())
+				if err != nil {
+					ErrorAndExit("Bulk failed", err)
+				}
+				failedCount := 0
+				for _, item := range bulkResp.Failed() {
+					// version conflict means the indexer wrote a newer doc since it was read
+					if item.Status == http.StatusConflict {
+						continue
+					}
+					fmt.Printf("Failed to index doc %v: %v\n", item.Id, item.Error)
+					failedCount++
+				}
+				if failedCount != 0 {
+					ErrorAndExit(fmt.Sprintf("Bulk request has %d failed docs", failedCount), nil)
+				}
+			}
+			progress.Count += int64(len(resp.Executions))
+		}
+
+		progress.NextPageToken = resp.NextPageToken
+		if len(progress.NextPageToken) == 0 {
+			if progress.Phase == reindexPhaseOpen {
+				progress.Phase = reindexPhaseClosed
+			} else {
+				progress.Phase = reindexPhaseDone
+			}
+		}
+		saveReindexProgress(progressFile, progress)
+		fmt.Printf("%v docs reindexed\n", progress.Count)
+	}
+	fmt.Printf("Reindex of domain %v is done\n", domainID)
+}
+
+func getVisibilityManager(c *cli.Context) persistence.VisibilityManager {
+	switch engine := c.String(FlagDBEngine); engine {
+	case "cassandra":
+		session := connectToCassandra(c)
+		return cassp.NewVisibilityPersistenceFromSession(session, bark.NewNopLogger())
+	case "mysql":
+		if !c.IsSet(FlagPort) {
+			ErrorAndExit("port is required", nil)
+		}
+		cfg := config.SQL{
+			User:            c.String(FlagUsername),
+			Password:        c.String(FlagPassword),
+			DriverName:      engine,
+			DatabaseName:    getRequiredOption(c, FlagKeyspace),
+			ConnectAddr:     fmt.Sprintf("%v:%v", getRequiredOption(c, FlagAddress), c.Int(FlagPort)),
+			ConnectProtocol: "tcp",
+		}
+		visibilityMgr, err := sql.NewSQLVisibilityStore(cfg, bark.NewNopLogger())
+		if err != nil {
+			ErrorAndExit("connect to MySQL failed", err)
+		}
+		return visibilityMgr
+	default:
+		ErrorAndExit(fmt.Sprintf("Unknown db engine %v", engine), nil)
+	}
+	return nil
+}
+
+// getExistingDocs returns the current ES docs of the executions, in the same order
+func getExistingDocs(esClient *elastic.Client, indexName string, executions []*shared.WorkflowExecutionInfo) []*elastic.GetResult {
+	mget := esClient.MultiGet()
+	for _, execution := range executions {
+		mget.Add(elastic.NewMultiGetItem().
+			Index(indexName).
+			Type(esDocType).
+			Id(getESDocID(execution)).
+			FetchSource(elastic.NewFetchSourceContext(true).Include(es.CloseStatus)))
+	}
+	resp, err := mget.Do(context.Background())
+	if err != nil {
+		ErrorAndExit("Failed to get existing docs", err)
+	}
+	if len(resp.Docs) != len(executions) {
+		ErrorAndExit(fmt.Sprintf("Expect %d existing docs, got %d", len(executions), len(resp.Docs)), nil)
+	}
+	return resp.Docs
+}
+
+// generateReindexRequest returns the request to write the doc of a visibility record, or nil if the existing doc
+// is at least as new as the record
+func generateReindexRequest(indexName, domainID string, execution *shared.WorkflowExecutionInfo, existing *elastic.GetResult) elastic.BulkableRequest {
+	version, ok := getReindexVersion(execution, existing)
+	if !ok {
+		return nil
+	}
+	doc := map[string]interface{}{
+		es.DomainID:     domainID,
+		es.WorkflowID:   execution.Execution.GetWorkflowId(),
+		es.RunID:        execution.Execution.GetRunId(),
+		es.WorkflowType: execution.Type.GetName(),
+		es.StartTime:    execution.GetStartTime(),
+	}
+	if execution.CloseStatus != nil {
+		doc[es.CloseTime] = execution.GetCloseTime()
+		doc[es.CloseStatus] = int64(execution.GetCloseStatus())
+		doc[es.HistoryLength] = execution.GetHistoryLength()
+	}
+	return elastic.NewBulkIndexRequest().
+		Index(indexName).
+		Type(esDocType).
+		Id(getESDocID(execution)).
+		VersionType(versionTypeExternal).
+		Version(version).
+		Doc(doc)
+}
+
+// getReindexVersion derives the external version of a reindexed doc. The indexer versions docs by history task ID,
+// which the visibility store doesn't keep, so the version is derived from the doc the record replaces: a missing doc
+// is created with the first version and the open doc of a closed record is bumped by one. Both stay below the task
+// IDs of later indexer messages, so the indexer always wins over a reindex.
+func getReindexVersion(execution *shared.WorkflowExecutionInfo, existing *elastic.GetResult) (int64, bool) {
+	if existing == nil || !existing.Found {
+		return reindexFirstVersion, true
+	}
+	if execution.CloseStatus == nil || existing.Version == nil || isClosedDoc(existing) {
+		return 0, false
+	}
+	return *existing.Version + 1, true
+}
+
+func isClosedDoc(doc *elastic.GetResult) bool {
+	if doc.Source == nil {
+		return false
+	}
+	var source map[string]interface{}
+	if err := json.Unmarshal(*doc.Source, &source); err != nil {
+		ErrorAndExit(fmt.Sprintf("Unable to parse doc %v", doc.Id), err)
+	}
+	_, ok := source[es.CloseStatus]
+	return ok
+}
+
+func getESDocID(execution *shared.WorkflowExecutionInfo) string {
+	return execution.Execution.GetWorkflowId() + esDocIDDelimiter + execution.Execution.GetRunId()
+}
+
+// loadReindexProgress returns the saved progress if progress file exists, time range of the saved progress
+// takes precedence so that page tokens stay valid
+func loadReindexProgress(fileName, domainID string, earliestTime, latestTime int64) *reindexProgress {
+	progress := &reindexProgress{
+		DomainID:     domainID,
+		EarliestTime: earliestTime,
+		LatestTime:   latestTime,
+		Phase:        reindexPhaseOpen,
+	}
+	data, err := ioutil.ReadFile(fileName)
+	if os.IsNotExist(err) {
+		return progress
+	}
+	if err != nil {
+		ErrorAndExit("Unable to read progress file", err)
+	}
+	if err := json.Unmarshal(data, progress); err != nil {
+		ErrorAndExit("Unable to parse progress file", err)
+	}
+	if progress.DomainID != domainID {
+		ErrorAndExit(fmt.Sprintf("Progress file %v belongs to domain %v", fileName, progress.DomainID), nil)
+	}
+	fmt.Printf("Resume reindex from %v phase with %v docs reindexed\n", progress.Phase, progress.Count)
+	return progress
+}
+
+func saveReindexProgress(fileName string, progress *reindexProgress) {
+	data, err := json.Marshal(progress)
+	if err != nil {
+		ErrorAndExit("Unable to serialize progress", err)
+	}
+	if err := ioutil.WriteFile(fileName, data, 0644); err != nil {
+		ErrorAndExit("Unable to write progress file", err)
+	}
+}
+
 func parseIndexerMessage(fileName string) (messages []*indexer.Message, err error) {
 	file, err := os.Open(fileName)
 	if err != nil {
@@ -0,0 +1,83 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/olivere/elastic"
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+)
+
+func TestGetReindexVersion(t *testing.T) {
+	openExecution := &shared.WorkflowExecutionInfo{}
+	closedExecution := &shared.WorkflowExecutionInfo{
+		CloseStatus: shared.WorkflowExecutionCloseStatusCompleted.Ptr(),
+	}
+	openSource := json.RawMessage(`{}`)
+	closedSource := json.RawMessage(`{"CloseStatus": 0}`)
+	openDoc := &elastic.GetResult{Found: true, Version: common.Int64Ptr(10), Source: &openSource}
+	closedDoc := &elastic.GetResult{Found: true, Version: common.Int64Ptr(20), Source: &closedSource}
+	missingDoc := &elastic.GetResult{Found: false}
+
+	tests := []struct {
+		execution       *shared.WorkflowExecutionInfo
+		existing        *elastic.GetResult
+		expectedVersion int64
+		expectedOK      bool
+	}{
+		{openExecution, missingDoc, 1, true},
+		{closedExecution, missingDoc, 1, true},
+		{openExecution, openDoc, 0, false},
+		{openExecution, closedDoc, 0, false},
+		{closedExecution, openDoc, 11, true},
+		{closedExecution, closedDoc, 0, false},
+	}
+	for _, tt := range tests {
+		version, ok := getReindexVersion(tt.execution, tt.existing)
+		assert.Equal(t, tt.expectedOK, ok)
+		assert.Equal(t, tt.expectedVersion, version)
+	}
+}
+
+func TestGenerateReindexRequest(t *testing.T) {
+	execution := &shared.WorkflowExecutionInfo{
+		Execution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr("wid"),
+			RunId:      common.StringPtr("rid"),
+		},
+		Type:      &shared.WorkflowType{Name: common.StringPtr("type")},
+		StartTime: common.Int64Ptr(1),
+	}
+	existing := &elastic.GetResult{Found: true, Version: common.Int64Ptr(10)}
+	assert.Nil(t, generateReindexRequest("index", "domain", execution, existing))
+
+	req := generateReindexRequest("index", "domain", execution, &elastic.GetResult{})
+	lines, err := req.Source()
+	assert.NoError(t, err)
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], `"_id":"wid~rid"`)
+	assert.Contains(t, lines[0], `"version_type":"external"`)
+	assert.Contains(t, lines[0], `"version":1`)
+}
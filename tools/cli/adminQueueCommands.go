@@ -0,0 +1,134 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/uber/cadence/.gen/go/admin"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/urfave/cli"
+)
+
+// AdminListQueueTasks lists the pending transfer, timer or replication tasks of a shard or workflow
+func AdminListQueueTasks(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+	taskType := getRequiredQueueTaskType(c)
+	wid := c.String(FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	if !c.IsSet(FlagShardID) && wid == "" {
+		ErrorAndExit(fmt.Sprintf("Option %s or %s is required", FlagShardID, FlagWorkflowID), nil)
+	}
+
+	request := &shared.ListQueueTasksRequest{
+		TaskType:   taskType.Ptr(),
+		WorkflowID: getPtrOrNilIfEmpty(wid),
+		RunID:      getPtrOrNilIfEmpty(rid),
+		PageSize:   common.Int32Ptr(int32(c.Int(FlagPageSize))),
+	}
+	if c.IsSet(FlagShardID) {
+		request.ShardID = common.Int32Ptr(int32(c.Int(FlagShardID)))
+	}
+
+	count := 0
+	for {
+		ctx, cancel := newContext()
+		resp, err := adminClient.ListQueueTasks(ctx, request)
+		cancel()
+		if err != nil {
+			ErrorAndExit("List queue tasks failed", err)
+		}
+		for _, task := range resp.Tasks {
+			fmt.Println(task)
+		}
+		count += len(resp.Tasks)
+		if len(resp.NextPageToken) == 0 {
+			break
+		}
+		request.NextPageToken = resp.NextPageToken
+	}
+	fmt.Printf("Found %v %v tasks\n", count, strings.ToLower(taskType.String()))
+}
+
+// AdminRemoveQueueTask removes a transfer, timer or replication task from the queue of a shard
+func AdminRemoveQueueTask(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+	sid := getRequiredShardID(c)
+	taskType := getRequiredQueueTaskType(c)
+	if !c.IsSet(FlagTaskID) {
+		ErrorAndExit(fmt.Sprintf("Option %s is required", FlagTaskID), nil)
+	}
+	taskID := c.Int64(FlagTaskID)
+
+	request := &shared.RemoveQueueTaskRequest{
+		ShardID:  common.Int32Ptr(sid),
+		TaskType: taskType.Ptr(),
+		TaskID:   common.Int64Ptr(taskID),
+	}
+	if taskType == shared.QueueTaskTypeTimer {
+		timestamp := parseTime(getRequiredOption(c, FlagVisibilityTimestamp), 0)
+		request.VisibilityTimestamp = common.Int64Ptr(timestamp)
+	}
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	if err := adminClient.RemoveQueueTask(ctx, request); err != nil {
+		ErrorAndExit("Remove queue task failed", err)
+	}
+	fmt.Printf("Task %v is removed from %v queue of shard %v, close the shard to drop it from memory of history host\n",
+		taskID, strings.ToLower(taskType.String()), sid)
+}
+
+// AdminRegenerateWorkflowTasks re-generates the transfer and timer tasks of a workflow from its mutable state
+func AdminRegenerateWorkflowTasks(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	err := adminClient.RegenerateWorkflowTasks(ctx, &admin.RegenerateWorkflowTasksRequest{
+		Domain: common.StringPtr(domain),
+		Execution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(wid),
+			RunId:      getPtrOrNilIfEmpty(rid),
+		},
+	})
+	if err != nil {
+		ErrorAndExit("Regenerate workflow tasks failed", err)
+	}
+	fmt.Println("Workflow tasks are re-generated")
+}
+
+func getRequiredQueueTaskType(c *cli.Context) shared.QueueTaskType {
+	var taskType shared.QueueTaskType
+	str := getRequiredOption(c, FlagQueueTaskType)
+	if err := taskType.UnmarshalText([]byte(strings.ToUpper(str))); err != nil {
+		ErrorAndExit(fmt.Sprintf("Invalid %s, valid values are transfer, timer and replication", FlagQueueTaskType), err)
+	}
+	return taskType
+}
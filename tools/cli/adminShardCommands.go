@@ -0,0 +1,221 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/gocql/gocql"
+	"github.com/pborman/uuid"
+	"github.com/uber-common/bark"
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/uber/cadence/common/persistence"
+	cassp "github.com/uber/cadence/common/persistence/cassandra"
+	"github.com/uber/cadence/service/worker/scanner/executions"
+	"github.com/urfave/cli"
+)
+
+// describeShardResponse is printed by describe shard in json and csv output
+type describeShardResponse struct {
+	ShardID     int32                 `json:"shardID"`
+	HostAddress string                `json:"hostAddress"`
+	ShardInfo   persistence.ShardInfo `json:"shardInfo"`
+}
+
+// AdminDescribeShard describes the persisted state of a shard
+func AdminDescribeShard(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+	sid := getRequiredShardID(c)
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	resp, err := adminClient.DescribeShard(ctx, &shared.DescribeShardRequest{
+		ShardID: common.Int32Ptr(sid),
+	})
+	if err != nil {
+		ErrorAndExit("Describe shard failed", err)
+	}
+
+	shardInfo := persistence.ShardInfo{}
+	if err := json.Unmarshal([]byte(resp.GetShardInfoInDatabase()), &shardInfo); err != nil {
+		ErrorAndExit("json.Unmarshal err", err)
+	}
+	if !isTableOutput(c) {
+		printObject(c, describeShardResponse{
+			ShardID:     resp.GetShardID(),
+			HostAddress: resp.GetHostAddress(),
+			ShardInfo:   shardInfo,
+		})
+		return
+	}
+	fmt.Printf("ShardID: %v, owned by history host: %v\n", resp.GetShardID(), resp.GetHostAddress())
+	printObject(c, shardInfo)
+}
+
+// AdminCloseShard closes a shard on the history host owning it
+func AdminCloseShard(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+	sid := getRequiredShardID(c)
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	err := adminClient.CloseShard(ctx, &shared.CloseShardRequest{
+		ShardID: common.Int32Ptr(sid),
+	})
+	if err != nil {
+		ErrorAndExit("Close shard failed", err)
+	}
+	fmt.Printf("Shard %v is closed, the history host owning it reloads the shard from persistence on next request\n", sid)
+}
+
+// AdminDrainHistoryHost moves all shards of a history host to other hosts before it is shut down
+func AdminDrainHistoryHost(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+	addr := getRequiredOption(c, FlagHistoryAddress)
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	err := adminClient.DrainHistoryHost(ctx, &shared.DrainHistoryHostRequest{
+		HostAddress: common.StringPtr(addr),
+	})
+	if err != nil {
+		ErrorAndExit("Drain history host failed", err)
+	}
+	fmt.Printf("History host %v is removed from membership ring until it is restarted, its shards will be moved to other hosts\n", addr)
+}
+
+// AdminScanShards scans the executions of shards for corrupted mutable state, writes the corrupted
+// executions to the output file and deletes or resets them if requested
+func AdminScanShards(c *cli.Context) {
+	minShardID, maxShardID := 0, 0
+	if c.IsSet(FlagShardID) {
+		minShardID = c.Int(FlagShardID)
+		maxShardID = minShardID + 1
+	} else if c.IsSet(FlagNumberOfShards) {
+		maxShardID = c.Int(FlagNumberOfShards)
+	} else {
+		ErrorAndExit(fmt.Sprintf("Option %s or %s is required", FlagShardID, FlagNumberOfShards), nil)
+	}
+	outputFile := c.String(FlagOutputFilename)
+	fix := c.Bool(FlagFix)
+	fixMode := executions.FixMode(c.String(FlagFixMode))
+	if fixMode != executions.FixModeDelete && fixMode != executions.FixModeReset {
+		ErrorAndExit(fmt.Sprintf("Option %s must be %v or %v", FlagFixMode, executions.FixModeDelete, executions.FixModeReset), nil)
+	}
+	pageSize := c.Int(FlagPageSize)
+	rateLimiter := common.NewTokenBucket(c.Int(FlagRPS), common.NewRealTimeSource())
+
+	session := connectToCassandra(c)
+	defer session.Close()
+	var reset executions.ResetFn
+	if fix && fixMode == executions.FixModeReset {
+		reset = newExecutionResetFn(c, session)
+	}
+	logger := bark.NewNopLogger()
+	historyManager := persistence.NewHistoryManagerImpl(cassp.NewHistoryPersistenceFromSession(session, logger), logger)
+	historyV2Manager := persistence.NewHistoryV2ManagerImpl(cassp.NewHistoryV2PersistenceFromSession(session, logger), logger)
+
+	f, err := os.Create(outputFile)
+	if err != nil {
+		ErrorAndExit("Failed to create output file", err)
+	}
+	defer f.Close()
+	encoder := json.NewEncoder(f)
+
+	var report executions.Report
+	for shardID := minShardID; shardID < maxShardID; shardID++ {
+		executionManager := persistence.NewExecutionManagerImpl(
+			cassp.NewWorkflowExecutionPersistenceFromSession(session, shardID, logger), logger)
+		shardScanner := executions.NewShardScanner(
+			shardID, executionManager, historyManager, historyV2Manager, rateLimiter, pageSize, fix, fixMode, reset)
+
+		var pageToken []byte
+		for {
+			result, err := shardScanner.ScanPage(context.Background(), pageToken, &report)
+			if err != nil {
+				ErrorAndExit(fmt.Sprintf("Failed to scan shard %v", shardID), err)
+			}
+			for _, corrupted := range result.Corrupted {
+				if err := encoder.Encode(corrupted); err != nil {
+					ErrorAndExit("Failed to write output file", err)
+				}
+			}
+			for _, failed := range result.Failed {
+				fmt.Printf("Failed to check execution, shardID: %v, domainID: %v, workflowID: %v, runID: %v, err: %v\n",
+					failed.ShardID, failed.DomainID, failed.WorkflowID, failed.RunID, failed.Error)
+			}
+			if len(result.NextPageToken) == 0 {
+				break
+			}
+			pageToken = result.NextPageToken
+		}
+		fmt.Printf("Shard %v scanned, %v executions scanned so far, %v corrupted\n", shardID, report.Scanned, report.Corrupted)
+	}
+
+	fmt.Printf("Scanned: %v, corrupted: %v, fixed: %v, failures: %v, report written to %v\n",
+		report.Scanned, report.Corrupted, report.Fixed, report.Failures, outputFile)
+}
+
+// newExecutionResetFn returns the function used to reset corrupted executions through the frontend,
+// the domain names of the executions are read from the domains table
+func newExecutionResetFn(c *cli.Context, session *gocql.Session) executions.ResetFn {
+	frontendClient := cFactory.ServerFrontendClient(c)
+	domainNames := make(map[string]string)
+	return func(ctx context.Context, info *persistence.WorkflowExecutionInfo, decisionFinishEventID int64) error {
+		domainName, ok := domainNames[info.DomainID]
+		if !ok {
+			res, err := readOneRow(session.Query("select domain from domains where id = ? ", info.DomainID))
+			if err != nil {
+				return err
+			}
+			domainName = res["domain"].(map[string]interface{})["name"].(string)
+			domainNames[info.DomainID] = domainName
+		}
+
+		resetCtx, cancel := newContext()
+		defer cancel()
+		_, err := frontendClient.ResetWorkflowExecution(resetCtx, &shared.ResetWorkflowExecutionRequest{
+			Domain: common.StringPtr(domainName),
+			WorkflowExecution: &shared.WorkflowExecution{
+				WorkflowId: common.StringPtr(info.WorkflowID),
+				RunId:      common.StringPtr(info.RunID),
+			},
+			Reason:                common.StringPtr("reset by admin shard scan: corrupted mutable state"),
+			DecisionFinishEventId: common.Int64Ptr(decisionFinishEventID),
+			RequestId:             common.StringPtr(uuid.New()),
+		})
+		return err
+	}
+}
+
+func getRequiredShardID(c *cli.Context) int32 {
+	if !c.IsSet(FlagShardID) {
+		ErrorAndExit(fmt.Sprintf("Option %s is required", FlagShardID), nil)
+	}
+	return int32(c.Int(FlagShardID))
+}
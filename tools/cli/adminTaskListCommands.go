@@ -0,0 +1,53 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"fmt"
+
+	"github.com/uber/cadence/.gen/go/shared"
+	"github.com/uber/cadence/common"
+	"github.com/urfave/cli"
+)
+
+// AdminPromoteTaskListBuildID makes a worker build the default one for new workflows on a decision tasklist
+func AdminPromoteTaskListBuildID(c *cli.Context) {
+	adminClient := cFactory.ServerAdminClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	taskList := getRequiredOption(c, FlagTaskList)
+	buildID := getRequiredOption(c, FlagBuildID)
+	compatibleBuildID := c.String(FlagCompatibleBuildID)
+
+	ctx, cancel := newContext()
+	defer cancel()
+
+	err := adminClient.PromoteTaskListBuildID(ctx, &shared.PromoteTaskListBuildIDRequest{
+		Domain:            common.StringPtr(domain),
+		TaskList:          &shared.TaskList{Name: common.StringPtr(taskList)},
+		BuildID:           common.StringPtr(buildID),
+		CompatibleBuildID: getPtrOrNilIfEmpty(compatibleBuildID),
+	})
+	if err != nil {
+		ErrorAndExit("Promote tasklist build ID failed", err)
+	}
+	fmt.Printf("Build %v is promoted on tasklist %v\n", buildID, taskList)
+}
@@ -46,6 +46,36 @@ func NewCliApp() *cli.App {
 			Usage:  "cadence workflow domain",
 			EnvVar: "CADENCE_CLI_DOMAIN",
 		},
+		cli.StringFlag{
+			Name:   FlagOutputFormatWithAlias,
+			Value:  outputFormatTable,
+			Usage:  "output format [table|json|jsonl|csv]",
+			EnvVar: "CADENCE_CLI_OUTPUT",
+		},
+		cli.StringFlag{
+			Name:  FlagColumnsWithAlias,
+			Usage: "comma separated field names to output, e.g. workflow_id,run_id",
+		},
+		cli.StringFlag{
+			Name:   FlagTLSCertPath,
+			Usage:  "path to the client certificate, used when the frontend requires client authentication",
+			EnvVar: "CADENCE_CLI_TLS_CERT_PATH",
+		},
+		cli.StringFlag{
+			Name:   FlagTLSKeyPath,
+			Usage:  "path to the private key of the client certificate",
+			EnvVar: "CADENCE_CLI_TLS_KEY_PATH",
+		},
+		cli.StringFlag{
+			Name:   FlagTLSCaPath,
+			Usage:  "path to the CA certificate used to verify the frontend, enables TLS when set",
+			EnvVar: "CADENCE_CLI_TLS_CA_PATH",
+		},
+		cli.StringFlag{
+			Name:   FlagTLSServerName,
+			Usage:  "server name expected in the frontend certificate, defaults to the host of the address",
+			EnvVar: "CADENCE_CLI_TLS_SERVER_NAME",
+		},
 	}
 	app.Commands = []cli.Command{
 		{
@@ -83,6 +113,24 @@ func NewCliApp() *cli.App {
 					Usage:       "Run admin operation on history host",
 					Subcommands: newAdminHistoryHostCommands(),
 				},
+				{
+					Name:        "shard",
+					Aliases:     []string{"shar"},
+					Usage:       "Run admin operation on specific shard",
+					Subcommands: newAdminShardCommands(),
+				},
+				{
+					Name:        "queue",
+					Aliases:     []string{"q"},
+					Usage:       "Run admin operation on transfer, timer and replication queues",
+					Subcommands: newAdminQueueCommands(),
+				},
+				{
+					Name:        "tasklist",
+					Aliases:     []string{"tl"},
+					Usage:       "Run admin operation on tasklist",
+					Subcommands: newAdminTaskListCommands(),
+				},
 				{
 					Name:        "kafka",
 					Aliases:     []string{"ka"},
@@ -21,6 +21,8 @@
 package cli
 
 import (
+	"bytes"
+	"encoding/json"
 	"strings"
 	"testing"
 	"time"
@@ -47,6 +49,7 @@ type cliAppSuite struct {
 	app                  *cli.App
 	mockCtrl             *gomock.Controller
 	clientFrontendClient *clientFrontendTest.MockClient
+	serverFrontendClient *serverFrontendTest.MockClient
 	serverAdminClient    *serverAdminTest.MockClient
 }
 
@@ -92,10 +95,11 @@ func (s *cliAppSuite) SetupTest() {
 	s.mockCtrl = gomock.NewController(s.T())
 
 	s.clientFrontendClient = clientFrontendTest.NewMockClient(s.mockCtrl)
+	s.serverFrontendClient = serverFrontendTest.NewMockClient(s.mockCtrl)
 	s.serverAdminClient = serverAdminTest.NewMockClient(s.mockCtrl)
 	SetFactory(&clientFactoryMock{
 		clientFrontendClient: s.clientFrontendClient,
-		serverFrontendClient: serverFrontendTest.NewMockClient(s.mockCtrl),
+		serverFrontendClient: s.serverFrontendClient,
 		serverAdminClient:    s.serverAdminClient,
 	})
 }
@@ -115,6 +119,16 @@ func (s *cliAppSuite) RunErrorExitCode(arguments []string) int {
 	return errorCode
 }
 
+func (s *cliAppSuite) RunWithOutput(arguments []string) string {
+	oldStdout := stdout
+	defer func() { stdout = oldStdout }()
+	buf := &bytes.Buffer{}
+	stdout = buf
+	err := s.app.Run(arguments)
+	s.Nil(err)
+	return buf.String()
+}
+
 func (s *cliAppSuite) TestAppCommands() {
 	for _, test := range commands {
 		cmd := s.app.Command(test)
@@ -131,13 +145,13 @@ func (s *cliAppSuite) TestDomainRegister() {
 func (s *cliAppSuite) TestDomainRegister_DomainExist() {
 	s.clientFrontendClient.EXPECT().RegisterDomain(gomock.Any(), gomock.Any(), callOptions...).Return(&shared.DomainAlreadyExistsError{})
 	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "domain", "register"})
-	s.Equal(1, errorCode)
+	s.Equal(exitCodeAlreadyExists, errorCode)
 }
 
 func (s *cliAppSuite) TestDomainRegister_Failed() {
 	s.clientFrontendClient.EXPECT().RegisterDomain(gomock.Any(), gomock.Any(), callOptions...).Return(&shared.BadRequestError{"fake error"})
 	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "domain", "register"})
-	s.Equal(1, errorCode)
+	s.Equal(exitCodeBadRequest, errorCode)
 }
 
 var describeDomainResponse = &shared.DescribeDomainResponse{
@@ -178,13 +192,13 @@ func (s *cliAppSuite) TestDomainUpdate_DomainNotExist() {
 	s.clientFrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
 	s.clientFrontendClient.EXPECT().UpdateDomain(gomock.Any(), gomock.Any(), callOptions...).Return(nil, &shared.EntityNotExistsError{})
 	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "domain", "update"})
-	s.Equal(1, errorCode)
+	s.Equal(exitCodeEntityNotExists, errorCode)
 }
 
 func (s *cliAppSuite) TestDomainUpdate_ActiveClusterFlagNotSet_DomainNotExist() {
 	s.clientFrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any(), callOptions...).Return(nil, &shared.EntityNotExistsError{})
 	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "domain", "update"})
-	s.Equal(1, errorCode)
+	s.Equal(exitCodeEntityNotExists, errorCode)
 }
 
 func (s *cliAppSuite) TestDomainUpdate_Failed() {
@@ -192,7 +206,7 @@ func (s *cliAppSuite) TestDomainUpdate_Failed() {
 	s.clientFrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
 	s.clientFrontendClient.EXPECT().UpdateDomain(gomock.Any(), gomock.Any(), callOptions...).Return(nil, &shared.BadRequestError{"faked error"})
 	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "domain", "update"})
-	s.Equal(1, errorCode)
+	s.Equal(exitCodeBadRequest, errorCode)
 }
 
 func (s *cliAppSuite) TestDomainDescribe() {
@@ -206,14 +220,90 @@ func (s *cliAppSuite) TestDomainDescribe_DomainNotExist() {
 	resp := describeDomainResponse
 	s.clientFrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any(), callOptions...).Return(resp, &shared.EntityNotExistsError{})
 	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "domain", "describe"})
-	s.Equal(1, errorCode)
+	s.Equal(exitCodeEntityNotExists, errorCode)
 }
 
 func (s *cliAppSuite) TestDomainDescribe_Failed() {
 	resp := describeDomainResponse
 	s.clientFrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any(), callOptions...).Return(resp, &shared.BadRequestError{"faked error"})
 	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "domain", "describe"})
-	s.Equal(1, errorCode)
+	s.Equal(exitCodeBadRequest, errorCode)
+}
+
+func newServerDomainResponse(name string) *serverShared.DescribeDomainResponse {
+	return &serverShared.DescribeDomainResponse{
+		DomainInfo: &serverShared.DomainInfo{
+			Name:   common.StringPtr(name),
+			Status: serverShared.DomainStatusRegistered.Ptr(),
+		},
+		Configuration: &serverShared.DomainConfiguration{
+			WorkflowExecutionRetentionPeriodInDays: common.Int32Ptr(3),
+		},
+		ReplicationConfiguration: &serverShared.DomainReplicationConfiguration{
+			ActiveClusterName: common.StringPtr("active"),
+		},
+		IsGlobalDomain: common.BoolPtr(false),
+	}
+}
+
+func (s *cliAppSuite) TestDomainList() {
+	expected := &serverShared.ListDomainsRequest{
+		PageSize:          common.Int32Ptr(100),
+		Status:            serverShared.DomainStatusRegistered.Ptr(),
+		ActiveClusterName: common.StringPtr("active"),
+		IsGlobalDomain:    common.BoolPtr(false),
+		Data:              map[string]string{"team": "a"},
+	}
+	resp := &serverShared.ListDomainsResponse{
+		Domains: []*serverShared.DescribeDomainResponse{newServerDomainResponse("domain1"), newServerDomainResponse("domain2")},
+	}
+	s.serverFrontendClient.EXPECT().ListDomains(gomock.Any(), expected).Return(resp, nil)
+	output := s.RunWithOutput([]string{"", "--output", "csv", "--columns", "name,status", "domain", "list",
+		"--status", "registered", "--ac", "active", "--gd", "false", "--dmd", "team:a"})
+	s.Equal("name,status\ndomain1,REGISTERED\ndomain2,REGISTERED\n", output)
+}
+
+func (s *cliAppSuite) TestDomainList_SkipsEmptyPages() {
+	emptyPage := &serverShared.ListDomainsResponse{NextPageToken: []byte("next-page")}
+	resp := &serverShared.ListDomainsResponse{
+		Domains:       []*serverShared.DescribeDomainResponse{newServerDomainResponse("domain1")},
+		NextPageToken: []byte("last-page"),
+	}
+	gomock.InOrder(
+		s.serverFrontendClient.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(emptyPage, nil),
+		s.serverFrontendClient.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(resp, nil),
+	)
+	output := s.RunWithOutput([]string{"", "--output", "jsonl", "domain", "list", "--status", "deprecated"})
+	s.Equal(1, strings.Count(output, "\n"))
+}
+
+func (s *cliAppSuite) TestDomainList_All() {
+	firstPage := &serverShared.ListDomainsResponse{
+		Domains:       []*serverShared.DescribeDomainResponse{newServerDomainResponse("domain1")},
+		NextPageToken: []byte("next-page"),
+	}
+	lastPage := &serverShared.ListDomainsResponse{
+		Domains: []*serverShared.DescribeDomainResponse{newServerDomainResponse("domain2")},
+	}
+	gomock.InOrder(
+		s.serverFrontendClient.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(firstPage, nil),
+		s.serverFrontendClient.EXPECT().ListDomains(gomock.Any(), &serverShared.ListDomainsRequest{
+			PageSize:      common.Int32Ptr(1),
+			NextPageToken: []byte("next-page"),
+		}).Return(lastPage, nil),
+	)
+	output := s.RunWithOutput([]string{"", "--output", "json", "domain", "list", "--ps", "1", "--all"})
+
+	var records []map[string]interface{}
+	s.NoError(json.Unmarshal([]byte(output), &records))
+	s.Len(records, 2)
+	s.Equal("domain2", records[1]["name"])
+}
+
+func (s *cliAppSuite) TestDomainList_InvalidStatus() {
+	s.serverFrontendClient.EXPECT().ListDomains(gomock.Any(), gomock.Any()).Return(&serverShared.ListDomainsResponse{}, nil).AnyTimes()
+	errorCode := s.RunErrorExitCode([]string{"", "domain", "list", "--status", "unknown"})
+	s.Equal(exitCodeError, errorCode)
 }
 
 var (
@@ -266,6 +356,23 @@ func (s *cliAppSuite) TestShowHistory_PrintDateTime() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestShowHistory_OutputJSONL() {
+	resp := getWorkflowExecutionHistoryResponse
+	s.clientFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
+	output := s.RunWithOutput([]string{"", "--do", domainName, "--output", "jsonl", "workflow", "show", "-w", "wid"})
+
+	var event shared.HistoryEvent
+	s.NoError(json.Unmarshal([]byte(output), &event))
+	s.Equal(shared.EventTypeWorkflowExecutionStarted, event.GetEventType())
+}
+
+func (s *cliAppSuite) TestShowHistory_OutputCSVWithColumns() {
+	resp := getWorkflowExecutionHistoryResponse
+	s.clientFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
+	output := s.RunWithOutput([]string{"", "--do", domainName, "--output", "csv", "--columns", "event_type", "workflow", "show", "-w", "wid"})
+	s.Equal("event_type\nWorkflowExecutionStarted\n", output)
+}
+
 func (s *cliAppSuite) TestStartWorkflow() {
 	resp := &shared.StartWorkflowExecutionResponse{RunId: common.StringPtr(uuid.New())}
 	s.clientFrontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).Return(resp, nil).Times(2)
@@ -277,12 +384,25 @@ func (s *cliAppSuite) TestStartWorkflow() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestStartWorkflow_OutputJSON() {
+	resp := &shared.StartWorkflowExecutionResponse{RunId: common.StringPtr("rid")}
+	s.clientFrontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).Return(resp, nil)
+	output := s.RunWithOutput([]string{"", "--do", domainName, "--output", "json", "workflow", "start", "-tl", "testTaskList", "-wt", "testWorkflowType", "-et", "60", "-w", "wid"})
+
+	var records []map[string]interface{}
+	s.NoError(json.Unmarshal([]byte(output), &records))
+	s.Len(records, 1)
+	s.Equal("wid", records[0]["workflow_id"])
+	s.Equal("rid", records[0]["run_id"])
+	s.Equal("testTaskList", records[0]["task_list"])
+}
+
 func (s *cliAppSuite) TestStartWorkflow_Failed() {
 	resp := &shared.StartWorkflowExecutionResponse{RunId: common.StringPtr(uuid.New())}
 	s.clientFrontendClient.EXPECT().StartWorkflowExecution(gomock.Any(), gomock.Any()).Return(resp, &shared.BadRequestError{"faked error"})
 	// start with wid
 	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "workflow", "start", "-tl", "testTaskList", "-wt", "testWorkflowType", "-et", "60", "-w", "wid"})
-	s.Equal(1, errorCode)
+	s.Equal(exitCodeBadRequest, errorCode)
 }
 
 func (s *cliAppSuite) TestRunWorkflow() {
@@ -305,7 +425,7 @@ func (s *cliAppSuite) TestRunWorkflow_Failed() {
 	s.clientFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), callOptions...).Return(history, nil)
 	// start with wid
 	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "workflow", "run", "-tl", "testTaskList", "-wt", "testWorkflowType", "-et", "60", "-w", "wid"})
-	s.Equal(1, errorCode)
+	s.Equal(exitCodeBadRequest, errorCode)
 }
 
 func (s *cliAppSuite) TestTerminateWorkflow() {
@@ -317,7 +437,41 @@ func (s *cliAppSuite) TestTerminateWorkflow() {
 func (s *cliAppSuite) TestTerminateWorkflow_Failed() {
 	s.clientFrontendClient.EXPECT().TerminateWorkflowExecution(gomock.Any(), gomock.Any(), callOptions...).Return(&shared.BadRequestError{"faked error"})
 	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "workflow", "terminate", "-w", "wid"})
-	s.Equal(1, errorCode)
+	s.Equal(exitCodeBadRequest, errorCode)
+}
+
+func (s *cliAppSuite) TestPauseWorkflow() {
+	s.serverFrontendClient.EXPECT().PauseWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil)
+	err := s.app.Run([]string{"", "--do", domainName, "workflow", "pause", "-w", "wid", "-r", "rid", "--reason", "maintenance"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestPauseWorkflow_Failed() {
+	s.serverFrontendClient.EXPECT().PauseWorkflowExecution(gomock.Any(), gomock.Any()).Return(&serverShared.BadRequestError{"faked error"})
+	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "workflow", "pause", "-w", "wid"})
+	s.Equal(exitCodeBadRequest, errorCode)
+}
+
+func (s *cliAppSuite) TestUnpauseWorkflow() {
+	s.serverFrontendClient.EXPECT().UnpauseWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil)
+	err := s.app.Run([]string{"", "--do", domainName, "workflow", "unpause", "-w", "wid"})
+	s.Nil(err)
+}
+
+func (s *cliAppSuite) TestDescribeWorkflow() {
+	resp := &serverShared.DescribeWorkflowExecutionResponse{
+		WorkflowExecutionInfo: &serverShared.WorkflowExecutionInfo{
+			Execution: &serverShared.WorkflowExecution{
+				WorkflowId: common.StringPtr("wid"),
+				RunId:      common.StringPtr("rid"),
+			},
+			StartTime: common.Int64Ptr(time.Now().UnixNano()),
+			Paused:    common.BoolPtr(true),
+		},
+	}
+	s.serverFrontendClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(resp, nil)
+	err := s.app.Run([]string{"", "--do", domainName, "workflow", "describe", "-w", "wid"})
+	s.Nil(err)
 }
 
 func (s *cliAppSuite) TestCancelWorkflow() {
@@ -329,7 +483,7 @@ func (s *cliAppSuite) TestCancelWorkflow() {
 func (s *cliAppSuite) TestCancelWorkflow_Failed() {
 	s.clientFrontendClient.EXPECT().RequestCancelWorkflowExecution(gomock.Any(), gomock.Any(), callOptions...).Return(&shared.BadRequestError{"faked error"})
 	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "workflow", "cancel", "-w", "wid"})
-	s.Equal(1, errorCode)
+	s.Equal(exitCodeBadRequest, errorCode)
 }
 
 func (s *cliAppSuite) TestSignalWorkflow() {
@@ -341,7 +495,7 @@ func (s *cliAppSuite) TestSignalWorkflow() {
 func (s *cliAppSuite) TestSignalWorkflow_Failed() {
 	s.clientFrontendClient.EXPECT().SignalWorkflowExecution(gomock.Any(), gomock.Any()).Return(&shared.BadRequestError{"faked error"})
 	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "workflow", "signal", "-w", "wid", "-n", "signal-name"})
-	s.Equal(1, errorCode)
+	s.Equal(exitCodeBadRequest, errorCode)
 }
 
 func (s *cliAppSuite) TestQueryWorkflow() {
@@ -368,7 +522,7 @@ func (s *cliAppSuite) TestQueryWorkflow_Failed() {
 	}
 	s.clientFrontendClient.EXPECT().QueryWorkflow(gomock.Any(), gomock.Any()).Return(resp, &shared.BadRequestError{"faked error"})
 	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "workflow", "query", "-w", "wid", "-qt", "query-type-test"})
-	s.Equal(1, errorCode)
+	s.Equal(exitCodeBadRequest, errorCode)
 }
 
 var (
@@ -466,6 +620,64 @@ func (s *cliAppSuite) TestListWorkflow_Open_WithWorkflowType() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestListWorkflow_OutputJSON() {
+	resp := listClosedWorkflowExecutionsResponse
+	s.clientFrontendClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
+	output := s.RunWithOutput([]string{"", "--do", domainName, "--output", "json", "workflow", "list"})
+
+	var records []map[string]interface{}
+	s.NoError(json.Unmarshal([]byte(output), &records))
+	s.Len(records, 1)
+	s.Equal("test-list-workflow-type", records[0]["workflow_type"])
+	s.Equal("test-list-workflow-id", records[0]["workflow_id"])
+	s.Equal("COMPLETED", records[0]["close_status"])
+	s.Equal(float64(12), records[0]["history_length"])
+}
+
+func (s *cliAppSuite) TestListWorkflow_OutputCSVWithColumns() {
+	resp := listClosedWorkflowExecutionsResponse
+	s.clientFrontendClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
+	output := s.RunWithOutput([]string{"", "--do", domainName, "--output", "csv", "--columns", "workflow_id,close_status", "workflow", "list"})
+	s.Equal("workflow_id,close_status\ntest-list-workflow-id,COMPLETED\n", output)
+}
+
+func (s *cliAppSuite) TestListWorkflow_UnknownColumn() {
+	resp := listClosedWorkflowExecutionsResponse
+	s.clientFrontendClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil).AnyTimes()
+	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "--output", "csv", "--columns", "unknown", "workflow", "list"})
+	s.Equal(exitCodeError, errorCode)
+}
+
+func (s *cliAppSuite) TestListWorkflow_All() {
+	firstPage := &shared.ListClosedWorkflowExecutionsResponse{
+		Executions:    listClosedWorkflowExecutionsResponse.Executions,
+		NextPageToken: []byte("next-page"),
+	}
+	gomock.InOrder(
+		s.clientFrontendClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any(), callOptions...).Return(firstPage, nil),
+		s.clientFrontendClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any(), callOptions...).Return(listClosedWorkflowExecutionsResponse, nil),
+	)
+	output := s.RunWithOutput([]string{"", "--do", domainName, "--output", "jsonl", "workflow", "list", "--ps", "1", "--all"})
+	s.Equal(2, strings.Count(output, "\n"))
+}
+
+func (s *cliAppSuite) TestListWorkflow_Limit() {
+	firstPage := &shared.ListClosedWorkflowExecutionsResponse{
+		Executions:    listClosedWorkflowExecutionsResponse.Executions,
+		NextPageToken: []byte("next-page"),
+	}
+	s.clientFrontendClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any(), callOptions...).Return(firstPage, nil)
+	output := s.RunWithOutput([]string{"", "--do", domainName, "--output", "jsonl", "workflow", "list", "--ps", "1", "--limit", "1"})
+	s.Equal(1, strings.Count(output, "\n"))
+}
+
+func (s *cliAppSuite) TestListWorkflow_InvalidOutputFormat() {
+	resp := listClosedWorkflowExecutionsResponse
+	s.clientFrontendClient.EXPECT().ListClosedWorkflowExecutions(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil).AnyTimes()
+	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "--output", "xml", "workflow", "list"})
+	s.Equal(exitCodeError, errorCode)
+}
+
 var describeTaskListResponse = &shared.DescribeTaskListResponse{
 	Pollers: []*shared.PollerInfo{
 		{
@@ -487,10 +699,22 @@ func (s *cliAppSuite) TestAdminDescribeWorkflow() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestAdminDescribeWorkflow_OutputJSONL() {
+	resp := &admin.DescribeWorkflowExecutionResponse{
+		ShardId:                common.StringPtr("test-shard-id"),
+		HistoryAddr:            common.StringPtr("ip:port"),
+		MutableStateInDatabase: common.StringPtr("{}"),
+	}
+
+	s.serverAdminClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(resp, nil)
+	output := s.RunWithOutput([]string{"", "--do", domainName, "--output", "jsonl", "admin", "wf", "describe", "-w", "test-wf-id"})
+	s.Equal(`{"shardId":"test-shard-id","historyAddr":"ip:port","mutableStateInDatabase":"{}"}`+"\n", output)
+}
+
 func (s *cliAppSuite) TestAdminDescribeWorkflow_Failed() {
 	s.serverAdminClient.EXPECT().DescribeWorkflowExecution(gomock.Any(), gomock.Any()).Return(nil, &serverShared.BadRequestError{"faked error"})
 	errorCode := s.RunErrorExitCode([]string{"", "--do", domainName, "admin", "wf", "describe", "-w", "test-wf-id"})
-	s.Equal(1, errorCode)
+	s.Equal(exitCodeBadRequest, errorCode)
 }
 
 func (s *cliAppSuite) TestDescribeTaskList() {
@@ -507,6 +731,24 @@ func (s *cliAppSuite) TestDescribeTaskList_Activity() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestDescribeTaskList_OutputJSON() {
+	resp := describeTaskListResponse
+	s.clientFrontendClient.EXPECT().DescribeTaskList(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
+	output := s.RunWithOutput([]string{"", "--do", domainName, "--output", "json", "tasklist", "describe", "-tl", "test-taskList"})
+
+	var records []map[string]interface{}
+	s.NoError(json.Unmarshal([]byte(output), &records))
+	s.Len(records, 1)
+	s.Equal("tester", records[0]["identity"])
+}
+
+func (s *cliAppSuite) TestDomainDescribe_OutputCSVWithColumns() {
+	resp := describeDomainResponse
+	s.clientFrontendClient.EXPECT().DescribeDomain(gomock.Any(), gomock.Any(), callOptions...).Return(resp, nil)
+	output := s.RunWithOutput([]string{"", "--do", domainName, "--output", "csv", "--columns", "domainInfo.name,replicationConfiguration.clusters.1.clusterName", "domain", "describe"})
+	s.Equal("domainInfo.name,replicationConfiguration.clusters.1.clusterName\ntest-domain,standby\n", output)
+}
+
 func (s *cliAppSuite) TestObserveWorkflow() {
 	history := getWorkflowExecutionHistoryResponse
 	s.clientFrontendClient.EXPECT().GetWorkflowExecutionHistory(gomock.Any(), gomock.Any(), callOptions...).Return(history, nil).Times(2)
@@ -525,6 +767,16 @@ func (s *cliAppSuite) TestObserveWorkflowWithID() {
 	s.Nil(err)
 }
 
+func (s *cliAppSuite) TestGetExitCode() {
+	s.Equal(exitCodeError, getExitCode(nil))
+	s.Equal(exitCodeBadRequest, getExitCode(&shared.BadRequestError{}))
+	s.Equal(exitCodeBadRequest, getExitCode(&serverShared.BadRequestError{}))
+	s.Equal(exitCodeEntityNotExists, getExitCode(&shared.EntityNotExistsError{}))
+	s.Equal(exitCodeEntityNotExists, getExitCode(&serverShared.EntityNotExistsError{}))
+	s.Equal(exitCodeAlreadyExists, getExitCode(&shared.WorkflowExecutionAlreadyStartedError{}))
+	s.Equal(exitCodeServiceBusy, getExitCode(&shared.ServiceBusyError{}))
+}
+
 func (s *cliAppSuite) TestParseTime() {
 	s.Equal(int64(100), parseTime("", 100))
 	s.Equal(int64(1528383845000000000), parseTime("2018-06-07T15:04:05+00:00", 0))
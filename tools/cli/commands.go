@@ -65,6 +65,45 @@ const (
 	showErrorStackEnv    = `CADENCE_CLI_SHOW_STACKS`
 )
 
+var (
+	listWorkflowColumns = []outputColumn{
+		{Name: "workflow_type", Header: "Workflow Type"},
+		{Name: "workflow_id", Header: "Workflow ID"},
+		{Name: "run_id", Header: "Run ID"},
+		{Name: "start_time", Header: "Start Time"},
+		{Name: "close_time", Header: "End Time"},
+		{Name: "close_status", Header: "Close Status", Hidden: true},
+		{Name: "history_length", Header: "History Length", Hidden: true},
+	}
+	listDomainColumns = []outputColumn{
+		{Name: "name", Header: "Name"},
+		{Name: "status", Header: "Status"},
+		{Name: "is_global_domain", Header: "Global"},
+		{Name: "active_cluster", Header: "Active Cluster"},
+		{Name: "clusters", Header: "Clusters"},
+		{Name: "retention_days", Header: "Retention Days"},
+		{Name: "description", Header: "Description", Hidden: true},
+		{Name: "owner_email", Header: "Owner Email", Hidden: true},
+		{Name: "data", Header: "Data", Hidden: true},
+		{Name: "failover_version", Header: "Failover Version", Hidden: true},
+	}
+	historyEventColumns = []outputColumn{
+		{Name: "event_id", Header: "Event ID"},
+		{Name: "timestamp", Header: "Timestamp"},
+		{Name: "event_type", Header: "Event Type"},
+		{Name: "version", Header: "Version"},
+		{Name: "attributes", Header: "Attributes"},
+	}
+	startedWorkflowColumns = []outputColumn{
+		{Name: "workflow_id", Header: "Workflow Id"},
+		{Name: "run_id", Header: "Run Id"},
+		{Name: "workflow_type", Header: "Type"},
+		{Name: "domain", Header: "Domain"},
+		{Name: "task_list", Header: "Task List"},
+		{Name: "input", Header: "Args"},
+	}
+)
+
 // SetFactory is used to set the ClientFactory global
 func SetFactory(factory ClientFactory) {
 	cFactory = factory
@@ -99,22 +138,53 @@ var (
 		"cont":      s.WorkflowExecutionCloseStatusContinuedAsNew,
 		"timeout":   s.WorkflowExecutionCloseStatusTimedOut,
 	}
+	domainStatusMap = map[string]shared.DomainStatus{
+		"registered": shared.DomainStatusRegistered,
+		"deprecated": shared.DomainStatusDeprecated,
+	}
 )
 
-// ErrorAndExit print easy to understand error msg first then error detail in a new line
+// Exit codes of the cli, scripts can rely on them to tell failures apart
+const (
+	exitCodeError           = 1
+	exitCodeBadRequest      = 2
+	exitCodeEntityNotExists = 3
+	exitCodeAlreadyExists   = 4
+	exitCodeServiceBusy     = 5
+)
+
+// ErrorAndExit print easy to understand error msg first then error detail in a new line,
+// errors are written to stderr so that they never mix with the command output
 func ErrorAndExit(msg string, err error) {
 	if err != nil {
-		fmt.Printf("%s %s\n%s %+v\n", colorRed("Error:"), msg, colorMagenta("Error Details:"), err)
+		fmt.Fprintf(os.Stderr, "%s %s\n%s %+v\n", colorRed("Error:"), msg, colorMagenta("Error Details:"), err)
 		if os.Getenv(showErrorStackEnv) != `` {
-			fmt.Printf("Stack trace:\n")
+			fmt.Fprintf(os.Stderr, "Stack trace:\n")
 			debug.PrintStack()
 		} else {
-			fmt.Printf("('export %s=1' to see stack traces)\n", showErrorStackEnv)
+			fmt.Fprintf(os.Stderr, "('export %s=1' to see stack traces)\n", showErrorStackEnv)
 		}
 	} else {
-		fmt.Printf("%s %s\n", colorRed("Error:"), msg)
+		fmt.Fprintf(os.Stderr, "%s %s\n", colorRed("Error:"), msg)
+	}
+	osExit(getExitCode(err))
+}
+
+// getExitCode maps the error returned by cadence service to the exit code of the cli
+func getExitCode(err error) int {
+	switch err.(type) {
+	case *s.BadRequestError, *shared.BadRequestError:
+		return exitCodeBadRequest
+	case *s.EntityNotExistsError, *shared.EntityNotExistsError:
+		return exitCodeEntityNotExists
+	case *s.DomainAlreadyExistsError, *shared.DomainAlreadyExistsError,
+		*s.WorkflowExecutionAlreadyStartedError, *shared.WorkflowExecutionAlreadyStartedError:
+		return exitCodeAlreadyExists
+	case *s.ServiceBusyError, *shared.ServiceBusyError:
+		return exitCodeServiceBusy
+	default:
+		return exitCodeError
 	}
-	osExit(1)
 }
 
 // RegisterDomain register a domain
@@ -316,6 +386,8 @@ func DescribeDomain(c *cli.Context) {
 		} else {
 			ErrorAndExit(fmt.Sprintf("Domain %s does not exist.", domain), err)
 		}
+	} else if !isTableOutput(c) {
+		printObject(c, resp)
 	} else {
 		fmt.Printf("Name: %v\nDescription: %v\nOwnerEmail: %v\nDomainData: %v\nStatus: %v\nRetentionInDays: %v\n"+
 			"EmitMetrics: %v\nActiveClusterName: %v\nClusters: %v\n",
@@ -331,6 +403,86 @@ func DescribeDomain(c *cli.Context) {
 	}
 }
 
+// ListDomains lists the domains matching the filters given as options
+func ListDomains(c *cli.Context) {
+	frontendClient := cFactory.ServerFrontendClient(c)
+	more := c.Bool(FlagMore)
+	all := c.Bool(FlagAll)
+
+	request := &shared.ListDomainsRequest{
+		PageSize: common.Int32Ptr(int32(c.Int(FlagPageSize))),
+	}
+	if c.IsSet(FlagWorkflowStatus) {
+		request.Status = getDomainStatus(c.String(FlagWorkflowStatus)).Ptr()
+	}
+	if c.IsSet(FlagActiveClusterName) {
+		request.ActiveClusterName = common.StringPtr(c.String(FlagActiveClusterName))
+	}
+	if c.IsSet(FlagIsGlobalDomain) {
+		isGlobalDomain, err := strconv.ParseBool(c.String(FlagIsGlobalDomain))
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Option %s format is invalid.", FlagIsGlobalDomain), err)
+		}
+		request.IsGlobalDomain = common.BoolPtr(isGlobalDomain)
+	}
+	if c.IsSet(FlagDomainData) {
+		data, err := parseDomainDataKVs(c.String(FlagDomainData))
+		if err != nil {
+			ErrorAndExit(fmt.Sprintf("Option %s format is invalid.", FlagDomainData), err)
+		}
+		request.Data = data
+	}
+
+	writer := newRecordWriter(c, listDomainColumns, true)
+	for {
+		ctx, cancel := newContext()
+		resp, err := frontendClient.ListDomains(ctx, request)
+		cancel()
+		if err != nil {
+			ErrorAndExit("Failed to list domains.", err)
+		}
+		for _, d := range resp.Domains {
+			writer.Append(
+				d.DomainInfo.GetName(),
+				d.DomainInfo.GetStatus().String(),
+				d.GetIsGlobalDomain(),
+				d.ReplicationConfiguration.GetActiveClusterName(),
+				serverClustersToString(d.ReplicationConfiguration.Clusters),
+				d.Configuration.GetWorkflowExecutionRetentionPeriodInDays(),
+				d.DomainInfo.GetDescription(),
+				d.DomainInfo.GetOwnerEmail(),
+				d.DomainInfo.Data,
+				d.GetFailoverVersion(),
+			)
+		}
+		writer.Flush()
+
+		request.NextPageToken = resp.NextPageToken
+		if len(request.NextPageToken) == 0 {
+			break
+		}
+		// pages with no domain matching the filters are skipped without prompting
+		if len(resp.Domains) == 0 || all {
+			continue
+		}
+		if !more {
+			break
+		}
+		// only prompt for next page in table output, scripts use --all instead
+		if !isTableOutput(c) {
+			continue
+		}
+		fmt.Printf("Press %s to show next page, press %s to quit: ",
+			color.GreenString("Enter"), color.RedString("any other key then Enter"))
+		var input string
+		fmt.Scanln(&input)
+		if strings.Trim(input, " ") != "" {
+			break
+		}
+	}
+	writer.Close()
+}
+
 // ShowHistory shows the history of given workflow execution based on workflowID and runID.
 func ShowHistory(c *cli.Context) {
 	wid := getRequiredOption(c, FlagWorkflowID)
@@ -371,7 +523,9 @@ func showHistoryHelper(c *cli.Context, wid, rid string) {
 		ErrorAndExit(fmt.Sprintf("Failed to get history on workflow id: %s, run id: %s.", wid, rid), err)
 	}
 
-	if printFully { // dump everything
+	if !isTableOutput(c) || len(getOutputColumns(c)) > 0 {
+		printHistoryEvents(c, history.Events, maxFieldLength)
+	} else if printFully { // dump everything
 		for _, e := range history.Events {
 			fmt.Println(anyToString(e, true, maxFieldLength))
 		}
@@ -417,6 +571,54 @@ func showHistoryHelper(c *cli.Context, wid, rid string) {
 	}
 }
 
+// printHistoryEvents prints history events in the output format selected by the global flags,
+// json and jsonl output write the complete events unless columns are picked
+func printHistoryEvents(c *cli.Context, events []*s.HistoryEvent, maxFieldLength int) {
+	if c.IsSet(FlagEventID) { // only print that event
+		eventID := c.Int(FlagEventID)
+		if eventID <= 0 || eventID > len(events) {
+			ErrorAndExit("EventId out of range.", fmt.Errorf("number should be 1 - %d inclusive", len(events)))
+			return
+		}
+		events = events[eventID-1 : eventID]
+	}
+
+	if len(getOutputColumns(c)) == 0 {
+		switch getOutputFormat(c) {
+		case outputFormatJSON:
+			writeJSONIndent(events)
+			return
+		case outputFormatJSONL:
+			for _, e := range events {
+				writeJSONLine(e)
+			}
+			return
+		}
+	}
+
+	printRawTime := c.Bool(FlagPrintRawTime)
+	writer := newRecordWriter(c, historyEventColumns, false)
+	for _, e := range events {
+		writer.Append(e.GetEventId(), timeValue(e.GetTimestamp(), printRawTime), e.GetEventType().String(),
+			e.GetVersion(), &eventAttributes{event: e, maxFieldLength: maxFieldLength})
+	}
+	writer.Close()
+}
+
+// eventAttributes is printed as text in table and csv output and as the attributes object in json output
+type eventAttributes struct {
+	event          *s.HistoryEvent
+	maxFieldLength int
+}
+
+func (a *eventAttributes) String() string {
+	return HistoryEventToString(a.event, true, a.maxFieldLength)
+}
+
+func (a *eventAttributes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(getEventAttributes(a.event))
+}
+
 // StartWorkflow starts a new workflow execution
 func StartWorkflow(c *cli.Context) {
 	startWorkflowHelper(c, false)
@@ -475,6 +677,8 @@ func startWorkflowHelper(c *cli.Context, shouldPrintProgress bool) {
 
 		if err != nil {
 			ErrorAndExit("Failed to create workflow.", err)
+		} else if !isTableOutput(c) || len(getOutputColumns(c)) > 0 {
+			printStartedWorkflow(c, wid, resp.GetRunId(), workflowType, domain, taskList, input)
 		} else {
 			fmt.Printf("Started Workflow Id: %s, run Id: %s\n", wid, resp.GetRunId())
 		}
@@ -494,20 +698,24 @@ func startWorkflowHelper(c *cli.Context, shouldPrintProgress bool) {
 		}
 
 		// print execution summary
-		fmt.Println(colorMagenta("Running execution:"))
-		table := tablewriter.NewWriter(os.Stdout)
-		executionData := [][]string{
-			{"Workflow Id", wid},
-			{"Run Id", resp.GetRunId()},
-			{"Type", workflowType},
-			{"Domain", domain},
-			{"Task List", taskList},
-			{"Args", truncate(input)}, // in case of large input
+		if !isTableOutput(c) || len(getOutputColumns(c)) > 0 {
+			printStartedWorkflow(c, wid, resp.GetRunId(), workflowType, domain, taskList, truncate(input))
+		} else {
+			fmt.Println(colorMagenta("Running execution:"))
+			table := tablewriter.NewWriter(os.Stdout)
+			executionData := [][]string{
+				{"Workflow Id", wid},
+				{"Run Id", resp.GetRunId()},
+				{"Type", workflowType},
+				{"Domain", domain},
+				{"Task List", taskList},
+				{"Args", truncate(input)}, // in case of large input
+			}
+			table.SetBorder(false)
+			table.SetColumnSeparator(":")
+			table.AppendBulk(executionData) // Add Bulk Data
+			table.Render()
 		}
-		table.SetBorder(false)
-		table.SetColumnSeparator(":")
-		table.AppendBulk(executionData) // Add Bulk Data
-		table.Render()
 
 		printWorkflowProgress(c, wid, resp.GetRunId())
 	}
@@ -519,6 +727,13 @@ func startWorkflowHelper(c *cli.Context, shouldPrintProgress bool) {
 	}
 }
 
+// printStartedWorkflow prints the summary of a started workflow as a single record
+func printStartedWorkflow(c *cli.Context, wid, rid, workflowType, domain, taskList, input string) {
+	writer := newRecordWriter(c, startedWorkflowColumns, false)
+	writer.Append(wid, rid, workflowType, domain, taskList, input)
+	writer.Close()
+}
+
 // helper function to print workflow progress with time refresh every second
 func printWorkflowProgress(c *cli.Context, wid, rid string) {
 	fmt.Println(colorMagenta("Progress:"))
@@ -601,6 +816,62 @@ func TerminateWorkflow(c *cli.Context) {
 	}
 }
 
+// PauseWorkflow pauses a workflow execution
+func PauseWorkflow(c *cli.Context) {
+	frontendClient := cFactory.ServerFrontendClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	reason := c.String(FlagReason)
+
+	ctx, cancel := newContext()
+	defer cancel()
+	err := frontendClient.PauseWorkflowExecution(ctx, &shared.PauseWorkflowExecutionRequest{
+		Domain: common.StringPtr(domain),
+		WorkflowExecution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(wid),
+			RunId:      common.StringPtr(rid),
+		},
+		Reason:   common.StringPtr(reason),
+		Identity: common.StringPtr(getCliIdentity()),
+	})
+
+	if err != nil {
+		ErrorAndExit("Pause workflow failed.", err)
+	} else {
+		fmt.Println("Pause workflow succeeded.")
+	}
+}
+
+// UnpauseWorkflow resumes a paused workflow execution
+func UnpauseWorkflow(c *cli.Context) {
+	frontendClient := cFactory.ServerFrontendClient(c)
+
+	domain := getRequiredGlobalOption(c, FlagDomain)
+	wid := getRequiredOption(c, FlagWorkflowID)
+	rid := c.String(FlagRunID)
+	reason := c.String(FlagReason)
+
+	ctx, cancel := newContext()
+	defer cancel()
+	err := frontendClient.UnpauseWorkflowExecution(ctx, &shared.UnpauseWorkflowExecutionRequest{
+		Domain: common.StringPtr(domain),
+		WorkflowExecution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(wid),
+			RunId:      common.StringPtr(rid),
+		},
+		Reason:   common.StringPtr(reason),
+		Identity: common.StringPtr(getCliIdentity()),
+	})
+
+	if err != nil {
+		ErrorAndExit("Unpause workflow failed.", err)
+	} else {
+		fmt.Println("Unpause workflow succeeded.")
+	}
+}
+
 // CancelWorkflow cancels a workflow execution
 func CancelWorkflow(c *cli.Context) {
 	wfClient := getWorkflowClient(c)
@@ -692,6 +963,14 @@ func queryWorkflowHelper(c *cli.Context, queryType string) {
 		return
 	}
 
+	if !isTableOutput(c) {
+		if json.Valid(queryResponse.QueryResult) {
+			printObject(c, json.RawMessage(queryResponse.QueryResult))
+		} else {
+			printObject(c, string(queryResponse.QueryResult))
+		}
+		return
+	}
 	// assume it is json encoded
 	fmt.Printf("Query result as JSON:\n%v\n", string(queryResponse.QueryResult))
 }
@@ -699,52 +978,58 @@ func queryWorkflowHelper(c *cli.Context, queryType string) {
 // ListWorkflow list workflow executions based on filters
 func ListWorkflow(c *cli.Context) {
 	more := c.Bool(FlagMore)
+	all := c.Bool(FlagAll)
+	limit := c.Int(FlagLimit)
 	pageSize := c.Int(FlagPageSize)
 
-	table := createTableForListWorkflow(false)
-	prepareTable := listWorkflow(c, table)
+	writer := newRecordWriter(c, listWorkflowColumns, true)
+	prepareTable := listWorkflow(c, writer)
 
-	if !more { // default mode only show one page items
+	if !more && !all && limit <= 0 { // default mode only show one page items
 		prepareTable(nil)
-		table.Render()
-	} else { // require input Enter to view next page
-		var resultSize int
-		var nextPageToken []byte
-		for {
-			nextPageToken, resultSize = prepareTable(nextPageToken)
-			table.Render()
-			table.ClearRows()
+		writer.Close()
+		return
+	}
 
-			if resultSize < pageSize {
-				break
-			}
+	// only prompt for next page in table output, scripts use --all or --limit instead
+	interactive := more && !all && isTableOutput(c)
+	var resultSize int
+	var nextPageToken []byte
+	for {
+		nextPageToken, resultSize = prepareTable(nextPageToken)
+		writer.Flush()
 
-			fmt.Printf("Press %s to show next page, press %s to quit: ",
-				color.GreenString("Enter"), color.RedString("any other key then Enter"))
-			var input string
-			fmt.Scanln(&input)
-			if strings.Trim(input, " ") == "" {
-				continue
-			} else {
-				break
-			}
+		if resultSize < pageSize || len(nextPageToken) == 0 {
+			break
+		}
+		if !interactive {
+			continue
+		}
+
+		fmt.Printf("Press %s to show next page, press %s to quit: ",
+			color.GreenString("Enter"), color.RedString("any other key then Enter"))
+		var input string
+		fmt.Scanln(&input)
+		if strings.Trim(input, " ") != "" {
+			break
 		}
 	}
+	writer.Close()
 }
 
 // ListAllWorkflow list all workflow executions based on filters
 func ListAllWorkflow(c *cli.Context) {
-	table := createTableForListWorkflow(true)
-	prepareTable := listWorkflow(c, table)
+	writer := newRecordWriter(c, listWorkflowColumns, false)
+	prepareTable := listWorkflow(c, writer)
 	var resultSize int
 	var nextPageToken []byte
 	for {
 		nextPageToken, resultSize = prepareTable(nextPageToken)
-		if resultSize < defaultPageSizeForList {
+		if resultSize < defaultPageSizeForList || len(nextPageToken) == 0 {
 			break
 		}
 	}
-	table.Render()
+	writer.Close()
 }
 
 // DescribeWorkflow show information about the specified workflow execution
@@ -770,14 +1055,21 @@ func DescribeWorkflowWithID(c *cli.Context) {
 }
 
 func describeWorkflowHelper(c *cli.Context, wid, rid string) {
-	wfClient := getWorkflowClient(c)
+	frontendClient := cFactory.ServerFrontendClient(c)
+	domain := getRequiredGlobalOption(c, FlagDomain)
 
 	printRawTime := c.Bool(FlagPrintRawTime) // default show datetime instead of raw time
 
 	ctx, cancel := newContext()
 	defer cancel()
 
-	resp, err := wfClient.DescribeWorkflowExecution(ctx, wid, rid)
+	resp, err := frontendClient.DescribeWorkflowExecution(ctx, &shared.DescribeWorkflowExecutionRequest{
+		Domain: common.StringPtr(domain),
+		Execution: &shared.WorkflowExecution{
+			WorkflowId: common.StringPtr(wid),
+			RunId:      common.StringPtr(rid),
+		},
+	})
 	if err != nil {
 		ErrorAndExit("Describe workflow execution failed", err)
 	}
@@ -787,46 +1079,37 @@ func describeWorkflowHelper(c *cli.Context, wid, rid string) {
 	} else {
 		o = convertDescribeWorkflowExecutionResponse(resp)
 	}
-	prettyPrintJSONObject(o)
-}
-
-func prettyPrintJSONObject(o interface{}) {
-	b, err := json.MarshalIndent(o, "", "  ")
-	if err != nil {
-		fmt.Printf("Error when try to print pretty: %v\n", err)
-		fmt.Println(o)
-	}
-	os.Stdout.Write(b)
-	fmt.Println()
+	printObject(c, o)
 }
 
 // describeWorkflowExecutionResponse is used to print datetime instead of print raw time
 type describeWorkflowExecutionResponse struct {
-	ExecutionConfiguration *s.WorkflowExecutionConfiguration
-	WorkflowExecutionInfo  workflowExecutionInfo
-	PendingActivities      []*pendingActivityInfo
+	ExecutionConfiguration *shared.WorkflowExecutionConfiguration `json:"executionConfiguration,omitempty"`
+	WorkflowExecutionInfo  workflowExecutionInfo                  `json:"workflowExecutionInfo"`
+	PendingActivities      []*pendingActivityInfo                 `json:"pendingActivities,omitempty"`
 }
 
 // workflowExecutionInfo has same fields as shared.WorkflowExecutionInfo, but has datetime instead of raw time
 type workflowExecutionInfo struct {
-	Execution     *s.WorkflowExecution
-	Type          *s.WorkflowType
-	StartTime     *string // change from *int64
-	CloseTime     *string // change from *int64
-	CloseStatus   *s.WorkflowExecutionCloseStatus
-	HistoryLength *int64
+	Execution     *shared.WorkflowExecution            `json:"execution,omitempty"`
+	Type          *shared.WorkflowType                 `json:"type,omitempty"`
+	StartTime     *string                              `json:"startTime,omitempty"` // change from *int64
+	CloseTime     *string                              `json:"closeTime,omitempty"` // change from *int64
+	CloseStatus   *shared.WorkflowExecutionCloseStatus `json:"closeStatus,omitempty"`
+	HistoryLength *int64                               `json:"historyLength,omitempty"`
+	Paused        *bool                                `json:"paused,omitempty"`
 }
 
 // pendingActivityInfo has same fields as shared.PendingActivityInfo, but different field type for better display
 type pendingActivityInfo struct {
-	ActivityID             *string
-	ActivityType           *s.ActivityType
-	State                  *s.PendingActivityState
-	HeartbeatDetails       *string // change from byte[]
-	LastHeartbeatTimestamp *string // change from *int64
+	ActivityID             *string                      `json:"activityID,omitempty"`
+	ActivityType           *shared.ActivityType         `json:"activityType,omitempty"`
+	State                  *shared.PendingActivityState `json:"state,omitempty"`
+	HeartbeatDetails       *string                      `json:"heartbeatDetails,omitempty"`       // change from byte[]
+	LastHeartbeatTimestamp *string                      `json:"lastHeartbeatTimestamp,omitempty"` // change from *int64
 }
 
-func convertDescribeWorkflowExecutionResponse(resp *s.DescribeWorkflowExecutionResponse) *describeWorkflowExecutionResponse {
+func convertDescribeWorkflowExecutionResponse(resp *shared.DescribeWorkflowExecutionResponse) *describeWorkflowExecutionResponse {
 	info := resp.WorkflowExecutionInfo
 	executionInfo := workflowExecutionInfo{
 		Execution:     info.Execution,
@@ -835,6 +1118,7 @@ func convertDescribeWorkflowExecutionResponse(resp *s.DescribeWorkflowExecutionR
 		CloseTime:     common.StringPtr(convertTime(info.GetCloseTime(), false)),
 		CloseStatus:   info.CloseStatus,
 		HistoryLength: info.HistoryLength,
+		Paused:        info.Paused,
 	}
 	var pendingActs []*pendingActivityInfo
 	var tmpAct *pendingActivityInfo
@@ -856,19 +1140,7 @@ func convertDescribeWorkflowExecutionResponse(resp *s.DescribeWorkflowExecutionR
 	}
 }
 
-func createTableForListWorkflow(listAll bool) *tablewriter.Table {
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetBorder(false)
-	table.SetColumnSeparator("|")
-	table.SetHeader([]string{"Workflow Type", "Workflow ID", "Run ID", "Start Time", "End Time"})
-	if !listAll { // color is only friendly to ANSI terminal
-		table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue, tableHeaderBlue)
-	}
-	table.SetHeaderLine(false)
-	return table
-}
-
-func listWorkflow(c *cli.Context, table *tablewriter.Table) func([]byte) ([]byte, int) {
+func listWorkflow(c *cli.Context, writer *recordWriter) func([]byte) ([]byte, int) {
 	wfClient := getWorkflowClient(c)
 
 	queryOpen := c.Bool(FlagOpen)
@@ -882,6 +1154,10 @@ func listWorkflow(c *cli.Context, table *tablewriter.Table) func([]byte) ([]byte
 	if pageSize <= 0 {
 		pageSize = defaultPageSizeForList
 	}
+	limit := c.Int(FlagLimit)
+	if limit > 0 && limit < pageSize {
+		pageSize = limit
+	}
 	timeout := time.Duration(c.Int(FlagContextTimeout)) * time.Second
 
 	var workflowStatus s.WorkflowExecutionCloseStatus
@@ -898,6 +1174,7 @@ func listWorkflow(c *cli.Context, table *tablewriter.Table) func([]byte) ([]byte
 		ErrorAndExit(optionErr, errors.New("you can filter on workflow_id or workflow_type, but not on both"))
 	}
 
+	var count int
 	prepareTable := func(next []byte) ([]byte, int) {
 		var result []*s.WorkflowExecutionInfo
 		var nextPageToken []byte
@@ -907,7 +1184,20 @@ func listWorkflow(c *cli.Context, table *tablewriter.Table) func([]byte) ([]byte
 			result, nextPageToken = listClosedWorkflow(wfClient, pageSize, earliestTime, latestTime, workflowID, workflowType, workflowStatus, next, timeout)
 		}
 
+		if limit > 0 && count+len(result) >= limit {
+			result = result[:limit-count]
+			nextPageToken = nil
+		}
+		count += len(result)
+
 		for _, e := range result {
+			if writer.format != outputFormatTable {
+				writer.Append(e.Type.GetName(), e.Execution.GetWorkflowId(), e.Execution.GetRunId(),
+					timeValue(e.GetStartTime(), printRawTime), timeValue(e.GetCloseTime(), printRawTime),
+					closeStatusValue(e.CloseStatus), e.GetHistoryLength())
+				continue
+			}
+
 			var startTime, closeTime string
 			if printRawTime {
 				startTime = fmt.Sprintf("%d", e.GetStartTime())
@@ -916,7 +1206,8 @@ func listWorkflow(c *cli.Context, table *tablewriter.Table) func([]byte) ([]byte
 				startTime = convertTime(e.GetStartTime(), !printDateTime)
 				closeTime = convertTime(e.GetCloseTime(), !printDateTime)
 			}
-			table.Append([]string{trimWorkflowType(e.Type.GetName()), e.Execution.GetWorkflowId(), e.Execution.GetRunId(), startTime, closeTime})
+			writer.Append(trimWorkflowType(e.Type.GetName()), e.Execution.GetWorkflowId(), e.Execution.GetRunId(),
+				startTime, closeTime, closeStatusValue(e.CloseStatus), e.GetHistoryLength())
 		}
 
 		return nextPageToken, len(result)
@@ -995,24 +1286,22 @@ func DescribeTaskList(c *cli.Context) {
 	}
 
 	pollers := response.Pollers
-	if len(pollers) == 0 {
+	if len(pollers) == 0 && isTableOutput(c) {
 		ErrorAndExit(colorMagenta("No poller for tasklist: "+taskList), nil)
 	}
 
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetBorder(false)
-	table.SetColumnSeparator("|")
+	identityHeader := "Decision Poller Identity"
 	if taskListType == s.TaskListTypeActivity {
-		table.SetHeader([]string{"Activity Poller Identity", "Last Access Time"})
-	} else {
-		table.SetHeader([]string{"Decision Poller Identity", "Last Access Time"})
+		identityHeader = "Activity Poller Identity"
 	}
-	table.SetHeaderLine(false)
-	table.SetHeaderColor(tableHeaderBlue, tableHeaderBlue)
+	writer := newRecordWriter(c, []outputColumn{
+		{Name: "identity", Header: identityHeader},
+		{Name: "last_access_time", Header: "Last Access Time"},
+	}, true)
 	for _, poller := range pollers {
-		table.Append([]string{poller.GetIdentity(), convertTime(poller.GetLastAccessTime(), false)})
+		writer.Append(poller.GetIdentity(), convertTime(poller.GetLastAccessTime(), false))
 	}
-	table.Render()
+	writer.Close()
 }
 
 // ObserveHistory show the process of running workflow
@@ -1053,7 +1342,7 @@ func ResetWorkflow(c *cli.Context) {
 	if err != nil {
 		ErrorAndExit("reset failed", err)
 	}
-	prettyPrintJSONObject(resp)
+	printObject(c, resp)
 }
 
 // ObserveHistoryWithID show the process of running workflow
@@ -1106,6 +1395,24 @@ func convertTime(unixNano int64, onlyTime bool) string {
 	return result
 }
 
+// timeValue is used by json, jsonl and csv output, raw time stays a number and unset time is left empty
+func timeValue(unixNano int64, printRawTime bool) interface{} {
+	if printRawTime {
+		return unixNano
+	}
+	if unixNano == 0 {
+		return ""
+	}
+	return convertTime(unixNano, false)
+}
+
+func closeStatusValue(status *s.WorkflowExecutionCloseStatus) string {
+	if status == nil {
+		return ""
+	}
+	return status.String()
+}
+
 func parseTime(timeStr string, defaultValue int64) int64 {
 	if len(timeStr) == 0 {
 		return defaultValue
@@ -1262,6 +1569,14 @@ func getWorkflowStatus(statusStr string) s.WorkflowExecutionCloseStatus {
 	return 0
 }
 
+func getDomainStatus(statusStr string) shared.DomainStatus {
+	if status, ok := domainStatusMap[strings.ToLower(statusStr)]; ok {
+		return status
+	}
+	ErrorAndExit(optionErr, errors.New("option status is not one of allowed values [registered, deprecated]"))
+	return 0
+}
+
 func getWorkflowIDReusePolicy(value int) *s.WorkflowIdReusePolicy {
 	if value >= 0 && value <= len(s.WorkflowIdReusePolicy_Values()) {
 		return s.WorkflowIdReusePolicy(value).Ptr()
@@ -156,5 +156,44 @@ func newDomainCommands() []cli.Command {
 				DescribeDomain(c)
 			},
 		},
+		{
+			Name:    "list",
+			Aliases: []string{"l"},
+			Usage:   "List domains",
+			Flags: []cli.Flag{
+				cli.BoolFlag{
+					Name:  FlagMoreWithAlias,
+					Usage: "List more pages, default is to list one page",
+				},
+				cli.BoolFlag{
+					Name:  FlagAllWithAlias,
+					Usage: "List all pages without prompting, useful for scripting",
+				},
+				cli.IntFlag{
+					Name:  FlagPageSizeWithAlias,
+					Value: 100,
+					Usage: "Number of domains read per page, filters are applied to each page",
+				},
+				cli.StringFlag{
+					Name:  FlagWorkflowStatusWithAlias,
+					Usage: "Only list domains with this status [registered, deprecated]",
+				},
+				cli.StringFlag{
+					Name:  FlagActiveClusterNameWithAlias,
+					Usage: "Only list domains active in this cluster",
+				},
+				cli.StringFlag{
+					Name:  FlagIsGlobalDomainWithAlias,
+					Usage: "Only list global domains if true, only local domains if false",
+				},
+				cli.StringFlag{
+					Name:  FlagDomainDataWithAlias,
+					Usage: "Only list domains whose data contains all these key value pairs, in format of k1:v1,k2:v2",
+				},
+			},
+			Action: func(c *cli.Context) {
+				ListDomains(c)
+			},
+		},
 	}
 }
@@ -21,8 +21,12 @@
 package cli
 
 import (
+	"crypto/tls"
+
 	serverAdmin "github.com/uber/cadence/.gen/go/admin/adminserviceclient"
 	serverFrontend "github.com/uber/cadence/.gen/go/cadence/workflowserviceclient"
+	"github.com/uber/cadence/common/service/config"
+	tcg "github.com/uber/tchannel-go"
 	"github.com/urfave/cli"
 	clientFrontend "go.uber.org/cadence/.gen/go/cadence/workflowserviceclient"
 	"go.uber.org/yarpc"
@@ -88,7 +92,15 @@ func (b *clientFactory) ensureDispatcher(c *cli.Context) {
 		b.hostPort = addr
 	}
 
-	ch, err := tchannel.NewChannelTransport(tchannel.ServiceName(cadenceClientName), tchannel.ListenAddr("127.0.0.1:0"))
+	opts := []tchannel.TransportOption{tchannel.ServiceName(cadenceClientName), tchannel.ListenAddr("127.0.0.1:0")}
+	if tlsConfig := b.newTLSConfig(c); tlsConfig != nil {
+		tlsCh, err := tcg.NewChannel(cadenceClientName, &tcg.ChannelOptions{Dialer: config.NewTLSDialer(tlsConfig)})
+		if err != nil {
+			b.logger.Fatal("Failed to create TLS channel", zap.Error(err))
+		}
+		opts = append(opts, tchannel.WithChannel(tlsCh))
+	}
+	ch, err := tchannel.NewChannelTransport(opts...)
 	if err != nil {
 		b.logger.Fatal("Failed to create transport channel", zap.Error(err))
 	}
@@ -105,3 +117,26 @@ func (b *clientFactory) ensureDispatcher(c *cli.Context) {
 		b.logger.Fatal("Failed to create outbound transport channel: %v", zap.Error(err))
 	}
 }
+
+// newTLSConfig builds the TLS config used to connect to the frontend,
+// nil if neither a CA nor a client certificate is given
+func (b *clientFactory) newTLSConfig(c *cli.Context) *tls.Config {
+	cfg := config.TLS{
+		Enabled:    true,
+		CertFile:   c.GlobalString(FlagTLSCertPath),
+		KeyFile:    c.GlobalString(FlagTLSKeyPath),
+		CaFile:     c.GlobalString(FlagTLSCaPath),
+		ServerName: c.GlobalString(FlagTLSServerName),
+	}
+	if cfg.CaFile == "" && cfg.CertFile == "" {
+		return nil
+	}
+	if (cfg.CertFile == "") != (cfg.KeyFile == "") {
+		ErrorAndExit("Both --"+FlagTLSCertPath+" and --"+FlagTLSKeyPath+" are required for client authentication", nil)
+	}
+	tlsConfig, err := cfg.NewClientConfig()
+	if err != nil {
+		ErrorAndExit("Failed to load TLS config", err)
+	}
+	return tlsConfig
+}
@@ -140,6 +140,35 @@ const (
 	FlagIndex                       = "index"
 	FlagBatchSize                   = "batch_size"
 	FlagBatchSizeWithAlias          = FlagBatchSize + ", bs"
+	FlagDBEngine                    = "db_engine"
+	FlagRPS                         = "rps"
+	FlagProgressFile                = "progress_file"
+	FlagProgressFileWithAlias       = FlagProgressFile + ", pf"
+	FlagQueueTaskType               = "task_type"
+	FlagQueueTaskTypeWithAlias      = FlagQueueTaskType + ", tt"
+	FlagTaskID                      = "task_id"
+	FlagTaskIDWithAlias             = FlagTaskID + ", tid"
+	FlagVisibilityTimestamp         = "visibility_timestamp"
+	FlagVisibilityTimestampAlias    = FlagVisibilityTimestamp + ", vts"
+	FlagOutputFormat                = "output"
+	FlagOutputFormatWithAlias       = FlagOutputFormat + ", o"
+	FlagColumns                     = "columns"
+	FlagColumnsWithAlias            = FlagColumns + ", col"
+	FlagTLSCertPath                 = "tls_cert_path"
+	FlagTLSKeyPath                  = "tls_key_path"
+	FlagTLSCaPath                   = "tls_ca_path"
+	FlagTLSServerName               = "tls_server_name"
+	FlagAll                         = "all"
+	FlagAllWithAlias                = FlagAll + ", a"
+	FlagLimit                       = "limit"
+	FlagFix                         = "fix"
+	FlagFixMode                     = "fix_mode"
+	FlagIsGlobalDomain              = "global_domain"
+	FlagIsGlobalDomainWithAlias     = FlagIsGlobalDomain + ", gd"
+	FlagBuildID                     = "build_id"
+	FlagBuildIDWithAlias            = FlagBuildID + ", bid"
+	FlagCompatibleBuildID           = "compatible_build_id"
+	FlagCompatibleBuildIDWithAlias  = FlagCompatibleBuildID + ", cbid"
 )
 
 var flagsForExecution = []cli.Flag{
@@ -263,6 +292,10 @@ func getFlagsForList() []cli.Flag {
 			Name:  FlagMoreWithAlias,
 			Usage: "List more pages, default is to list one page of default page size 10",
 		},
+		cli.BoolFlag{
+			Name:  FlagAllWithAlias,
+			Usage: "List all pages without prompting, useful for scripting",
+		},
 		cli.IntFlag{
 			Name:  FlagPageSizeWithAlias,
 			Value: 10,
@@ -312,6 +345,10 @@ func getFlagsForListAll() []cli.Flag {
 			Name:  FlagWorkflowStatusWithAlias,
 			Usage: "Closed workflow status [completed, failed, canceled, terminated, continueasnew, timedout]",
 		},
+		cli.IntFlag{
+			Name:  FlagLimit,
+			Usage: "Optional maximum number of workflow executions to list, default is no limit",
+		},
 	}
 }
 
@@ -0,0 +1,360 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package cli
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/urfave/cli"
+)
+
+// Output formats supported by the global output flag
+const (
+	outputFormatTable = "table"
+	outputFormatJSON  = "json"
+	outputFormatJSONL = "jsonl"
+	outputFormatCSV   = "csv"
+)
+
+var outputFormats = []string{outputFormatTable, outputFormatJSON, outputFormatJSONL, outputFormatCSV}
+
+// stdout is where command output is written, tests replace it to capture the output
+var stdout io.Writer = os.Stdout
+
+// outputColumn is one field of a record printed by the cli
+type outputColumn struct {
+	// Name is the stable field name used by json, jsonl and csv output and by the columns flag
+	Name string
+	// Header is the human readable name used by table output
+	Header string
+	// Hidden columns are left out of table output unless picked with the columns flag
+	Hidden bool
+}
+
+// recordWriter prints records in the output format selected by the global flags.
+// json output is buffered and written as a single array on Close, all other formats
+// are written every time Flush is called so that long listings can be streamed page by page.
+type recordWriter struct {
+	format   string
+	columns  []outputColumn
+	selected []int
+
+	table         *tablewriter.Table
+	tableRows     int
+	tableRendered bool
+	csv           *csv.Writer
+	csvHeaderDone bool
+	records       []map[string]interface{}
+}
+
+func getOutputFormat(c *cli.Context) string {
+	format := strings.ToLower(c.GlobalString(FlagOutputFormat))
+	if format == "" {
+		return outputFormatTable
+	}
+	for _, f := range outputFormats {
+		if format == f {
+			return format
+		}
+	}
+	ErrorAndExit(optionErr, fmt.Errorf("option %s is not one of allowed values [%s]", FlagOutputFormat, strings.Join(outputFormats, ", ")))
+	return outputFormatTable
+}
+
+func isTableOutput(c *cli.Context) bool {
+	return getOutputFormat(c) == outputFormatTable
+}
+
+func getOutputColumns(c *cli.Context) []string {
+	var columns []string
+	for _, name := range strings.Split(c.GlobalString(FlagColumns), ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
+
+// newRecordWriter creates a recordWriter for the given columns, colorHeader only applies to table output
+func newRecordWriter(c *cli.Context, columns []outputColumn, colorHeader bool) *recordWriter {
+	return newRecordWriterWithFormat(getOutputFormat(c), columns, getOutputColumns(c), colorHeader)
+}
+
+func newRecordWriterWithFormat(format string, columns []outputColumn, names []string, colorHeader bool) *recordWriter {
+	w := &recordWriter{
+		format:   format,
+		columns:  columns,
+		selected: selectColumns(columns, names, format),
+	}
+
+	switch w.format {
+	case outputFormatTable:
+		var headers []string
+		var colors []tablewriter.Colors
+		for _, i := range w.selected {
+			headers = append(headers, columns[i].Header)
+			colors = append(colors, tableHeaderBlue)
+		}
+		w.table = tablewriter.NewWriter(stdout)
+		w.table.SetBorder(false)
+		w.table.SetColumnSeparator("|")
+		w.table.SetHeader(headers)
+		if colorHeader { // color is only friendly to ANSI terminal
+			w.table.SetHeaderColor(colors...)
+		}
+		w.table.SetHeaderLine(false)
+	case outputFormatCSV:
+		w.csv = csv.NewWriter(stdout)
+	}
+	return w
+}
+
+func selectColumns(columns []outputColumn, names []string, format string) []int {
+	var selected []int
+	if len(names) == 0 {
+		for i, col := range columns {
+			if !col.Hidden || format != outputFormatTable {
+				selected = append(selected, i)
+			}
+		}
+		return selected
+	}
+
+	available := make([]string, 0, len(columns))
+	for _, col := range columns {
+		available = append(available, col.Name)
+	}
+	for _, name := range names {
+		index := -1
+		for i, col := range columns {
+			if col.Name == name {
+				index = i
+				break
+			}
+		}
+		if index < 0 {
+			ErrorAndExit(optionErr, fmt.Errorf("unknown column %q, available columns are [%s]", name, strings.Join(available, ", ")))
+			continue
+		}
+		selected = append(selected, index)
+	}
+	return selected
+}
+
+// Append adds one record, values must be in the same order as the columns of the writer
+func (w *recordWriter) Append(values ...interface{}) {
+	switch w.format {
+	case outputFormatTable:
+		row := make([]string, 0, len(w.selected))
+		for _, i := range w.selected {
+			row = append(row, fmt.Sprint(values[i]))
+		}
+		w.table.Append(row)
+		w.tableRows++
+	case outputFormatCSV:
+		if !w.csvHeaderDone {
+			header := make([]string, 0, len(w.selected))
+			for _, i := range w.selected {
+				header = append(header, w.columns[i].Name)
+			}
+			w.csv.Write(header)
+			w.csvHeaderDone = true
+		}
+		row := make([]string, 0, len(w.selected))
+		for _, i := range w.selected {
+			row = append(row, fmt.Sprint(values[i]))
+		}
+		w.csv.Write(row)
+	case outputFormatJSON, outputFormatJSONL:
+		record := make(map[string]interface{}, len(w.selected))
+		for _, i := range w.selected {
+			record[w.columns[i].Name] = values[i]
+		}
+		if w.format == outputFormatJSONL {
+			writeJSONLine(record)
+		} else {
+			w.records = append(w.records, record)
+		}
+	}
+}
+
+// Flush writes out the records appended since the last flush
+func (w *recordWriter) Flush() {
+	switch w.format {
+	case outputFormatTable:
+		// header is always rendered once, even if there is no record at all
+		if w.tableRows > 0 || !w.tableRendered {
+			w.table.Render()
+			w.table.ClearRows()
+			w.tableRows = 0
+			w.tableRendered = true
+		}
+	case outputFormatCSV:
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			ErrorAndExit("Failed to write csv output.", err)
+		}
+	}
+}
+
+// Close flushes the writer and finishes the output, it must be called once all records are appended
+func (w *recordWriter) Close() {
+	w.Flush()
+	if w.format == outputFormatJSON {
+		records := w.records
+		if records == nil {
+			records = []map[string]interface{}{}
+		}
+		writeJSONIndent(records)
+	}
+}
+
+// printObject prints a single response object in the output format selected by the global flags.
+// Table output keeps the indented json used by the describe commands, csv output flattens the
+// object into one row with dot separated field names which can also be picked with the columns flag.
+func printObject(c *cli.Context, o interface{}) {
+	format := getOutputFormat(c)
+	columns := getOutputColumns(c)
+	if len(columns) == 0 {
+		switch format {
+		case outputFormatTable, outputFormatJSON:
+			writeJSONIndent(o)
+			return
+		case outputFormatJSONL:
+			writeJSONLine(o)
+			return
+		}
+	}
+
+	fields := flattenObject(o)
+	if len(columns) == 0 {
+		for name := range fields {
+			columns = append(columns, name)
+		}
+		sort.Strings(columns)
+	}
+	for _, name := range columns {
+		if _, ok := fields[name]; !ok {
+			ErrorAndExit(optionErr, fmt.Errorf("unknown column %q", name))
+		}
+	}
+
+	switch format {
+	case outputFormatTable:
+		// a single object reads better as one field per line than as a very wide table
+		w := newRecordWriterWithFormat(format, []outputColumn{{Name: "field", Header: "Field"}, {Name: "value", Header: "Value"}}, nil, true)
+		for _, name := range columns {
+			w.Append(name, fields[name])
+		}
+		w.Close()
+	case outputFormatCSV:
+		row := make([]string, 0, len(columns))
+		for _, name := range columns {
+			row = append(row, fmt.Sprint(fields[name]))
+		}
+		w := csv.NewWriter(stdout)
+		w.Write(columns)
+		w.Write(row)
+		w.Flush()
+		if err := w.Error(); err != nil {
+			ErrorAndExit("Failed to write csv output.", err)
+		}
+	default:
+		record := make(map[string]interface{}, len(columns))
+		for _, name := range columns {
+			record[name] = fields[name]
+		}
+		if format == outputFormatJSONL {
+			writeJSONLine(record)
+		} else {
+			writeJSONIndent(record)
+		}
+	}
+}
+
+// flattenObject converts o into a map from dot separated json field path to leaf value
+func flattenObject(o interface{}) map[string]interface{} {
+	data, err := json.Marshal(o)
+	if err != nil {
+		ErrorAndExit("Failed to encode output.", err)
+	}
+	var decoded interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&decoded); err != nil {
+		ErrorAndExit("Failed to encode output.", err)
+	}
+
+	fields := make(map[string]interface{})
+	flattenValue("", decoded, fields)
+	return fields
+}
+
+func flattenValue(prefix string, value interface{}, fields map[string]interface{}) {
+	join := func(key string) string {
+		if prefix == "" {
+			return key
+		}
+		return prefix + "." + key
+	}
+
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			flattenValue(join(key), item, fields)
+		}
+	case []interface{}:
+		for i, item := range v {
+			flattenValue(join(strconv.Itoa(i)), item, fields)
+		}
+	default:
+		if prefix != "" {
+			fields[prefix] = v
+		}
+	}
+}
+
+func writeJSONIndent(o interface{}) {
+	b, err := json.MarshalIndent(o, "", "  ")
+	if err != nil {
+		ErrorAndExit("Failed to encode output.", err)
+	}
+	stdout.Write(b)
+	fmt.Fprintln(stdout)
+}
+
+func writeJSONLine(o interface{}) {
+	b, err := json.Marshal(o)
+	if err != nil {
+		ErrorAndExit("Failed to encode output.", err)
+	}
+	stdout.Write(b)
+	fmt.Fprintln(stdout)
+}
@@ -0,0 +1,479 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package schema
+
+import (
+	"bufio"
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+type (
+	// DB is the database a schema update is applied to
+	DB interface {
+		// Exec executes a schema statement
+		Exec(stmt string) error
+		// ReadSchemaVersion returns the current schema version of the database
+		ReadSchemaVersion() (string, error)
+		// UpdateSchemaVersion updates the schema version of the database
+		UpdateSchemaVersion(newVersion string, minCompatibleVersion string) error
+		// WriteSchemaUpdateLog adds an entry to the schema update history
+		WriteSchemaUpdateLog(oldVersion string, newVersion string, manifestMD5 string, desc string) error
+	}
+
+	// ChecksumReader is implemented by the databases whose schema update history
+	// is checked against the schema files of the already applied versions
+	ChecksumReader interface {
+		// ReadSchemaUpdateChecksums returns the checksum recorded for each applied version
+		ReadSchemaUpdateChecksums() (map[string]string, error)
+	}
+
+	// UpdateTask represents a task that applies the
+	// versioned schema changes of a schema dir to a database
+	UpdateTask struct {
+		db            DB
+		schemaDir     string
+		targetVersion string
+	}
+
+	// manifest is a value type that represents
+	// the deserialized manifest.json file within
+	// a schema version directory
+	manifest struct {
+		CurrVersion          string
+		MinCompatibleVersion string
+		Description          string
+		SchemaUpdateCqlFiles []string
+		SchemaUpdateSQLFiles []string `json:"SchemaUpdateSqlFiles"`
+		// md5 is the checksum of the manifest and all of the
+		// schema files it refers to, in the order they are listed
+		md5 string
+	}
+
+	// changeSet represents all the changes
+	// corresponding to a single schema version
+	changeSet struct {
+		version  string
+		manifest *manifest
+		stmts    []string
+	}
+
+	// byVersion is a comparator type
+	// for sorting a set of version
+	// strings
+	byVersion []string
+)
+
+const (
+	manifestFileName = "manifest.json"
+	newLineDelim     = '\n'
+)
+
+var (
+	whitelistedPrefixes = [3]string{"CREATE", "ALTER", "INSERT"}
+	rmspaceRegex        = regexp.MustCompile("\\s+")
+)
+
+// NewUpdateTask returns a task updating the given database with the versions of the
+// schema dir up to the target version, or up to the latest version if it is empty
+func NewUpdateTask(db DB, schemaDir string, targetVersion string) *UpdateTask {
+	return &UpdateTask{
+		db:            db,
+		schemaDir:     schemaDir,
+		targetVersion: targetVersion,
+	}
+}
+
+// Run executes the task
+func (task *UpdateTask) Run() error {
+
+	currVer, err := task.db.ReadSchemaVersion()
+	if err != nil {
+		return fmt.Errorf("error reading current schema version:%v", err.Error())
+	}
+
+	if reader, ok := task.db.(ChecksumReader); ok {
+		err = task.validateChecksums(reader, currVer)
+		if err != nil {
+			return err
+		}
+	}
+
+	updates, err := task.buildChangeSet(currVer)
+	if err != nil {
+		return err
+	}
+
+	return task.executeUpdates(currVer, updates)
+}
+
+func (task *UpdateTask) executeUpdates(currVer string, updates []changeSet) error {
+
+	for _, cs := range updates {
+
+		err := task.execStmts(cs.version, cs.stmts)
+		if err != nil {
+			return err
+		}
+		err = task.updateSchemaVersion(currVer, &cs)
+		if err != nil {
+			return err
+		}
+
+		log.Printf("Schema updated from %v to %v\n", currVer, cs.version)
+		currVer = cs.version
+	}
+
+	return nil
+}
+
+func (task *UpdateTask) execStmts(ver string, stmts []string) error {
+	log.Printf("---- Executing updates for version %v ----\n", ver)
+	for _, stmt := range stmts {
+		log.Println(rmspaceRegex.ReplaceAllString(stmt, " "))
+		e := task.db.Exec(stmt)
+		if e != nil {
+			return fmt.Errorf("error executing statement:%v", e)
+		}
+	}
+	log.Printf("---- Done ----\n")
+	return nil
+}
+
+func (task *UpdateTask) updateSchemaVersion(oldVer string, cs *changeSet) error {
+
+	err := task.db.UpdateSchemaVersion(cs.version, cs.manifest.MinCompatibleVersion)
+	if err != nil {
+		return fmt.Errorf("failed to update schema_version table, err=%v", err.Error())
+	}
+
+	err = task.db.WriteSchemaUpdateLog(oldVer, cs.manifest.CurrVersion, cs.manifest.md5, cs.manifest.Description)
+	if err != nil {
+		return fmt.Errorf("failed to add entry to schema_update_history, err=%v", err.Error())
+	}
+
+	return nil
+}
+
+// validateChecksums makes sure that the schema files of the versions
+// already applied to the database were not modified afterwards
+func (task *UpdateTask) validateChecksums(reader ChecksumReader, currVer string) error {
+
+	checksums, err := reader.ReadSchemaUpdateChecksums()
+	if err != nil {
+		return fmt.Errorf("error reading schema update history:%v", err.Error())
+	}
+
+	subdirs, err := ioutil.ReadDir(task.schemaDir)
+	if err != nil {
+		return fmt.Errorf("error listing schema dir:%v", err.Error())
+	}
+
+	for _, dir := range subdirs {
+
+		if !dir.IsDir() || !versionStrRegex.MatchString(dir.Name()) {
+			continue
+		}
+
+		ver := dirToVersion(dir.Name())
+		if CmpVersion(ver, currVer) > 0 {
+			continue // not applied yet
+		}
+
+		// versions without a checksum were not applied
+		// by this tool, e.g. the initial version
+		expected := checksums[ver]
+		if len(expected) == 0 {
+			continue
+		}
+
+		m, err := readManifest(task.schemaDir + "/" + dir.Name())
+		if err != nil {
+			return fmt.Errorf("error processing manifest for version %v:%v", ver, err.Error())
+		}
+		if m.md5 != expected {
+			return fmt.Errorf("checksum mismatch for version %v, schema files were modified after being applied, "+
+				"expected=%v, actual=%v", ver, expected, m.md5)
+		}
+	}
+
+	return nil
+}
+
+func (task *UpdateTask) buildChangeSet(currVer string) ([]changeSet, error) {
+
+	verDirs, err := readSchemaDir(task.schemaDir, currVer, task.targetVersion)
+	if err != nil {
+		return nil, fmt.Errorf("error listing schema dir:%v", err.Error())
+	}
+	if len(verDirs) == 0 {
+		return nil, fmt.Errorf("no schema dirs in version range [%v-%v]", currVer, task.targetVersion)
+	}
+
+	var result []changeSet
+
+	for _, vd := range verDirs {
+
+		dirPath := task.schemaDir + "/" + vd
+
+		m, e := readManifest(dirPath)
+		if e != nil {
+			return nil, fmt.Errorf("error processing manifest for version %v:%v", vd, e.Error())
+		}
+
+		if m.CurrVersion != dirToVersion(vd) {
+			return nil, fmt.Errorf("manifest version doesn't match with dirname, dir=%v,manifest.version=%v",
+				vd, m.CurrVersion)
+		}
+
+		stmts, e := parseStmts(dirPath, m)
+		if e != nil {
+			return nil, e
+		}
+
+		e = validateStmts(stmts)
+		if e != nil {
+			return nil, fmt.Errorf("error processing version %v:%v", vd, e.Error())
+		}
+
+		cs := changeSet{}
+		cs.manifest = m
+		cs.stmts = stmts
+		cs.version = m.CurrVersion
+		result = append(result, cs)
+	}
+
+	return result, nil
+}
+
+func parseStmts(dir string, manifest *manifest) ([]string, error) {
+
+	result := make([]string, 0, 4)
+
+	for _, file := range manifest.schemaUpdateFiles() {
+		path := dir + "/" + file
+		stmts, err := ParseFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing file %v, err=%v", path, err)
+		}
+		result = append(result, stmts...)
+	}
+
+	if len(result) == 0 {
+		return nil, fmt.Errorf("found 0 updates in dir %v", dir)
+	}
+
+	return result, nil
+}
+
+func validateStmts(stmts []string) error {
+	for _, stmt := range stmts {
+		valid := false
+		for _, prefix := range whitelistedPrefixes {
+			if strings.HasPrefix(strings.ToUpper(stmt), prefix) {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return fmt.Errorf("statement prefix not in whitelist, stmt=%v", stmt)
+		}
+	}
+	return nil
+}
+
+func readManifest(dirPath string) (*manifest, error) {
+
+	filePath := dirPath + "/" + manifestFileName
+	jsonBlob, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifest manifest
+	err = json.Unmarshal(jsonBlob, &manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	currVer, err := ParseValidateVersion(manifest.CurrVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CurrVersion in manifest")
+	}
+	manifest.CurrVersion = currVer
+
+	minVer, err := ParseValidateVersion(manifest.MinCompatibleVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MinCompatibleVersion in manifest")
+	}
+	manifest.MinCompatibleVersion = minVer
+
+	if len(manifest.schemaUpdateFiles()) == 0 {
+		return nil, fmt.Errorf("manifest missing SchemaUpdateCqlFiles or SchemaUpdateSqlFiles")
+	}
+
+	hash := md5.New()
+	hash.Write(jsonBlob)
+	for _, file := range manifest.schemaUpdateFiles() {
+		content, err := ioutil.ReadFile(dirPath + "/" + file)
+		if err != nil {
+			return nil, err
+		}
+		hash.Write(content)
+	}
+	manifest.md5 = hex.EncodeToString(hash.Sum(nil))
+
+	return &manifest, nil
+}
+
+// schemaUpdateFiles returns the schema files of the version, cassandra
+// manifests list them as SchemaUpdateCqlFiles and sql manifests as
+// SchemaUpdateSqlFiles
+func (m *manifest) schemaUpdateFiles() []string {
+	if len(m.SchemaUpdateCqlFiles) > 0 {
+		return m.SchemaUpdateCqlFiles
+	}
+	return m.SchemaUpdateSQLFiles
+}
+
+// readSchemaDir returns a sorted list of subdir names that hold
+// the schema changes for versions in the range [startVer - endVer]
+// this method has an assumption that the subdirs containing the
+// schema changes will be of the form vx.x, where x.x is the version
+func readSchemaDir(dir string, startVer string, endVer string) ([]string, error) {
+
+	subdirs, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var endFound bool
+	var result []string
+
+	hasEndVer := len(endVer) > 0
+
+	for _, dir := range subdirs {
+
+		if !dir.IsDir() {
+			continue
+		}
+
+		dirname := dir.Name()
+
+		if !versionStrRegex.MatchString(dirname) {
+			continue
+		}
+
+		ver := dirToVersion(dirname)
+
+		highcmp := 0
+		lowcmp := CmpVersion(ver, startVer)
+		if hasEndVer {
+			highcmp = CmpVersion(ver, endVer)
+		}
+
+		if lowcmp <= 0 || highcmp > 0 {
+			continue // out of range
+		}
+
+		endFound = endFound || (highcmp == 0)
+		result = append(result, dirname)
+	}
+
+	if !endFound {
+		return nil, fmt.Errorf("version dir not found for target version %v", endVer)
+	}
+
+	sort.Sort(byVersion(result))
+
+	return result, nil
+}
+
+// ParseFile takes a cql or sql file path as input
+// and returns an array of statements on success.
+func ParseFile(filePath string) ([]string, error) {
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	reader := bufio.NewReader(f)
+
+	var line string
+	var currStmt string
+	var stmts = make([]string, 0, 4)
+
+	for err == nil {
+
+		line, err = reader.ReadString(newLineDelim)
+		line = strings.TrimSpace(line)
+		if len(line) < 1 {
+			continue
+		}
+
+		// Filter out the comment lines, the
+		// only recognized comment line format
+		// is any line that starts with double dashes
+		tokens := strings.Split(line, "--")
+		if len(tokens) > 0 && len(tokens[0]) > 0 {
+			if len(currStmt) > 0 {
+				currStmt += " "
+			}
+			currStmt += tokens[0]
+			// semi-colon is the end of statement delim
+			if strings.HasSuffix(currStmt, ";") {
+				stmts = append(stmts, currStmt)
+				currStmt = ""
+			}
+		}
+	}
+
+	if err == io.EOF {
+		return stmts, nil
+	}
+
+	return nil, err
+}
+
+func (v byVersion) Len() int {
+	return len(v)
+}
+
+func (v byVersion) Less(i, j int) bool {
+	v1 := dirToVersion(v[i])
+	v2 := dirToVersion(v[j])
+	return CmpVersion(v1, v2) < 0
+}
+
+func (v byVersion) Swap(i, j int) {
+	v[i], v[j] = v[j], v[i]
+}
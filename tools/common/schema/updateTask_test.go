@@ -0,0 +1,351 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package schema
+
+import (
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type (
+	UpdateTaskTestSuite struct {
+		*require.Assertions // override suite.Suite.Assertions with require.Assertions; this means that s.NotNil(nil) will stop the test, not merely log an error
+		suite.Suite
+	}
+
+	// fakeDB records the statements and the schema versions written by an update task
+	fakeDB struct {
+		version   string
+		stmts     []string
+		checksums map[string]string
+	}
+
+	// fakeChecksumDB is a fakeDB that also reads back the checksums of the applied versions
+	fakeChecksumDB struct {
+		*fakeDB
+	}
+)
+
+func TestUpdateTaskTestSuite(t *testing.T) {
+	suite.Run(t, new(UpdateTaskTestSuite))
+}
+
+func (s *UpdateTaskTestSuite) SetupTest() {
+	s.Assertions = require.New(s.T()) // Have to define our overridden assertions in the test setup. If we did it earlier, s.T() will return nil
+}
+
+func (s *UpdateTaskTestSuite) TestReadManifest() {
+
+	tmpDir, err := ioutil.TempDir("", "update_schema_test")
+	s.Nil(err)
+	defer os.RemoveAll(tmpDir)
+
+	files := []string{"base1.sql", "base2.sql", "base3.sql"}
+	for _, f := range files {
+		err = ioutil.WriteFile(tmpDir+"/"+f, []byte("CREATE TABLE "+f[:5]+" (id INT);"), os.FileMode(0644))
+		s.Nil(err)
+	}
+
+	input := `{
+		"CurrVersion": "0.4",
+		"MinCompatibleVersion": "0.1",
+		"Description": "base version of schema",
+		"SchemaUpdateSqlFiles": ["base1.sql", "base2.sql", "base3.sql"]
+	}`
+	s.runReadManifestTest(tmpDir, input, "0.4", "0.1", "base version of schema", files, false)
+
+	errInputs := []string{
+		`{
+			"MinCompatibleVersion": "0.1",
+			"Description": "base",
+			"SchemaUpdateSqlFiles": ["base1.sql"]
+		 }`,
+		`{
+			"CurrVersion": "0.4",
+			"Description": "base version of schema",
+			"SchemaUpdateSqlFiles": ["base1.sql", "base2.sql", "base3.sql"]
+		 }`,
+		`{
+			"CurrVersion": "0.4",
+			"MinCompatibleVersion": "0.1",
+			"Description": "base version of schema",
+		 }`,
+		`{
+			"CurrVersion": "",
+			"MinCompatibleVersion": "0.1",
+			"Description": "base version of schema",
+			"SchemaUpdateSqlFiles": ["base1.sql", "base2.sql", "base3.sql"]
+		 }`,
+		`{
+			"CurrVersion": "0.4",
+			"MinCompatibleVersion": "0.1",
+			"Description": "base version of schema",
+			"SchemaUpdateSqlFiles": []
+		 }`,
+		`{
+			"CurrVersion": "0.4",
+			"MinCompatibleVersion": "0.1",
+			"Description": "missing file",
+			"SchemaUpdateSqlFiles": ["base4.sql"]
+		 }`,
+	}
+
+	for _, in := range errInputs {
+		s.runReadManifestTest(tmpDir, in, "", "", "", nil, true)
+	}
+}
+
+func (s *UpdateTaskTestSuite) TestReadCQLManifest() {
+
+	tmpDir, err := ioutil.TempDir("", "update_schema_test")
+	s.Nil(err)
+	defer os.RemoveAll(tmpDir)
+
+	files := []string{"base1.cql", "base2.cql"}
+	for _, f := range files {
+		err = ioutil.WriteFile(tmpDir+"/"+f, []byte("CREATE TABLE "+f[:5]+" (id int, PRIMARY KEY (id));"),
+			os.FileMode(0644))
+		s.Nil(err)
+	}
+
+	input := `{
+		"CurrVersion": "0.4",
+		"MinCompatibleVersion": "0.1",
+		"Description": "base version of schema",
+		"SchemaUpdateCqlFiles": ["base1.cql", "base2.cql"]
+	}`
+	s.runReadManifestTest(tmpDir, input, "0.4", "0.1", "base version of schema", files, false)
+}
+
+func (s *UpdateTaskTestSuite) TestManifestChecksum() {
+
+	tmpDir, err := ioutil.TempDir("", "update_schema_test")
+	s.Nil(err)
+	defer os.RemoveAll(tmpDir)
+
+	input := `{
+		"CurrVersion": "0.1",
+		"MinCompatibleVersion": "0.1",
+		"Description": "base version of schema",
+		"SchemaUpdateSqlFiles": ["base.sql"]
+	}`
+	err = ioutil.WriteFile(tmpDir+"/manifest.json", []byte(input), os.FileMode(0644))
+	s.Nil(err)
+	err = ioutil.WriteFile(tmpDir+"/base.sql", []byte("CREATE TABLE foo (id INT);"), os.FileMode(0644))
+	s.Nil(err)
+
+	m1, err := readManifest(tmpDir)
+	s.Nil(err)
+	m2, err := readManifest(tmpDir)
+	s.Nil(err)
+	s.Equal(m1.md5, m2.md5)
+
+	err = ioutil.WriteFile(tmpDir+"/base.sql", []byte("CREATE TABLE foo (id BIGINT);"), os.FileMode(0644))
+	s.Nil(err)
+	m3, err := readManifest(tmpDir)
+	s.Nil(err)
+	s.NotEqual(m1.md5, m3.md5)
+}
+
+func (s *UpdateTaskTestSuite) TestReadSchemaDir() {
+
+	tmpDir, err := ioutil.TempDir("", "update_schema_test")
+	s.Nil(err)
+	defer os.RemoveAll(tmpDir)
+
+	subDirs := []string{"v0.5", "v1.5", "v2.5", "v3.5", "v10.2", "abc", "2.0", "3.0"}
+	for _, d := range subDirs {
+		os.Mkdir(tmpDir+"/"+d, os.FileMode(0444))
+	}
+
+	_, err = readSchemaDir(tmpDir, "11.0", "11.2")
+	s.NotNil(err)
+	_, err = readSchemaDir(tmpDir, "0.5", "10.3")
+	s.NotNil(err)
+
+	ans, err := readSchemaDir(tmpDir, "0.4", "10.2")
+	s.Nil(err)
+	s.Equal([]string{"v0.5", "v1.5", "v2.5", "v3.5", "v10.2"}, ans)
+
+	ans, err = readSchemaDir(tmpDir, "0.5", "3.5")
+	s.Nil(err)
+	s.Equal([]string{"v1.5", "v2.5", "v3.5"}, ans)
+}
+
+func (s *UpdateTaskTestSuite) TestValidateStmts() {
+	s.Nil(validateStmts([]string{
+		"CREATE TABLE foo (id INT);",
+		"alter table foo ADD COLUMN name VARCHAR(255);",
+		"INSERT INTO foo (id) VALUES (1);",
+	}))
+	s.NotNil(validateStmts([]string{"DROP TABLE foo;"}))
+	s.NotNil(validateStmts([]string{"CREATE TABLE foo (id INT);", "DELETE FROM foo;"}))
+}
+
+func (s *UpdateTaskTestSuite) TestParseFile() {
+
+	tmpDir, err := ioutil.TempDir("", "update_schema_test")
+	s.Nil(err)
+	defer os.RemoveAll(tmpDir)
+
+	content := `
+-- test file content
+
+CREATE TABLE foo (
+  id   int,
+  name text,
+  PRIMARY KEY (id)
+);
+
+-- another table
+CREATE TABLE bar (id int, PRIMARY KEY (id));
+`
+	err = ioutil.WriteFile(tmpDir+"/test.cql", []byte(content), os.FileMode(0644))
+	s.Nil(err)
+
+	stmts, err := ParseFile(tmpDir + "/test.cql")
+	s.Nil(err)
+	s.Equal([]string{
+		"CREATE TABLE foo ( id   int, name text, PRIMARY KEY (id) );",
+		"CREATE TABLE bar (id int, PRIMARY KEY (id));",
+	}, stmts)
+
+	_, err = ParseFile(tmpDir + "/missing.cql")
+	s.NotNil(err)
+}
+
+func (s *UpdateTaskTestSuite) TestUpdateTask() {
+
+	tmpDir, err := ioutil.TempDir("", "update_schema_test")
+	s.Nil(err)
+	defer os.RemoveAll(tmpDir)
+
+	s.makeSchemaVersionDir(tmpDir, "1.0", "CREATE TABLE foo (id INT);")
+	s.makeSchemaVersionDir(tmpDir, "2.0", "ALTER TABLE foo ADD COLUMN name VARCHAR(255);")
+	s.makeSchemaVersionDir(tmpDir, "3.0", "CREATE TABLE bar (id INT);")
+
+	db := newFakeDB("0.0")
+	err = NewUpdateTask(db, tmpDir, "2.0").Run()
+	s.Nil(err)
+	s.Equal("2.0", db.version)
+	s.Equal([]string{"CREATE TABLE foo (id INT);", "ALTER TABLE foo ADD COLUMN name VARCHAR(255);"}, db.stmts)
+	s.Len(db.checksums, 2)
+
+	err = NewUpdateTask(db, tmpDir, "").Run()
+	s.Nil(err)
+	s.Equal("3.0", db.version)
+	s.Len(db.stmts, 3)
+}
+
+func (s *UpdateTaskTestSuite) TestUpdateTaskChecksumMismatch() {
+
+	tmpDir, err := ioutil.TempDir("", "update_schema_test")
+	s.Nil(err)
+	defer os.RemoveAll(tmpDir)
+
+	s.makeSchemaVersionDir(tmpDir, "1.0", "CREATE TABLE foo (id INT);")
+	s.makeSchemaVersionDir(tmpDir, "2.0", "CREATE TABLE bar (id INT);")
+
+	db := &fakeChecksumDB{fakeDB: newFakeDB("0.0")}
+	err = NewUpdateTask(db, tmpDir, "1.0").Run()
+	s.Nil(err)
+
+	// the schema file of the applied version is modified afterwards
+	s.makeSchemaVersionDir(tmpDir, "1.0", "CREATE TABLE foo (id BIGINT);")
+	err = NewUpdateTask(db, tmpDir, "2.0").Run()
+	s.NotNil(err)
+	s.Contains(err.Error(), "checksum mismatch")
+	s.Equal("1.0", db.version)
+
+	// the fakeDB without a ChecksumReader does not verify the applied versions
+	err = NewUpdateTask(db.fakeDB, tmpDir, "2.0").Run()
+	s.Nil(err)
+	s.Equal("2.0", db.version)
+}
+
+func (s *UpdateTaskTestSuite) makeSchemaVersionDir(rootDir string, version string, stmt string) {
+	dir := rootDir + "/v" + version
+	os.Mkdir(dir, os.FileMode(0700))
+	manifest := `{
+		"CurrVersion": "` + version + `",
+		"MinCompatibleVersion": "1.0",
+		"Description": "v` + version + ` of schema",
+		"SchemaUpdateSqlFiles": ["schema.sql"]
+	}`
+	err := ioutil.WriteFile(dir+"/manifest.json", []byte(manifest), os.FileMode(0600))
+	s.Nil(err)
+	err = ioutil.WriteFile(dir+"/schema.sql", []byte(stmt), os.FileMode(0600))
+	s.Nil(err)
+}
+
+func (s *UpdateTaskTestSuite) runReadManifestTest(dir, input, currVer, minVer, desc string,
+	files []string, isErr bool) {
+
+	file := dir + "/manifest.json"
+	err := ioutil.WriteFile(file, []byte(input), os.FileMode(0644))
+	s.Nil(err)
+
+	m, err := readManifest(dir)
+	if isErr {
+		s.NotNil(err)
+		return
+	}
+	s.Nil(err)
+	s.Equal(currVer, m.CurrVersion)
+	s.Equal(minVer, m.MinCompatibleVersion)
+	s.Equal(desc, m.Description)
+	s.True(len(m.md5) > 0)
+	s.Equal(files, m.schemaUpdateFiles())
+}
+
+func newFakeDB(version string) *fakeDB {
+	return &fakeDB{
+		version:   version,
+		checksums: make(map[string]string),
+	}
+}
+
+func (db *fakeDB) Exec(stmt string) error {
+	db.stmts = append(db.stmts, stmt)
+	return nil
+}
+
+func (db *fakeDB) ReadSchemaVersion() (string, error) {
+	return db.version, nil
+}
+
+func (db *fakeDB) UpdateSchemaVersion(newVersion string, minCompatibleVersion string) error {
+	db.version = newVersion
+	return nil
+}
+
+func (db *fakeDB) WriteSchemaUpdateLog(oldVersion string, newVersion string, manifestMD5 string, desc string) error {
+	db.checksums[newVersion] = manifestMD5
+	return nil
+}
+
+func (db *fakeChecksumDB) ReadSchemaUpdateChecksums() (map[string]string, error) {
+	return db.checksums, nil
+}
@@ -0,0 +1,278 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"strconv"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+
+	// load the mysql driver so that it can be picked with the driver flag
+	_ "github.com/go-sql-driver/mysql"
+)
+
+type (
+	// SQLClient is the interface for implementations
+	// that provide a way to talk to a sql database
+	SQLClient interface {
+		// Exec executes a sql statement
+		Exec(stmt string) error
+		// ListTables lists the table names in the database
+		ListTables() ([]string, error)
+		// DropTable drops the given table
+		DropTable(name string) error
+		// CreateDatabase creates a database, if it doesn't exist
+		CreateDatabase(name string) error
+		// DropDatabase drops a database, if it exists
+		DropDatabase(name string) error
+		// CreateSchemaVersionTables sets up the schema version tables
+		CreateSchemaVersionTables() error
+		// ReadSchemaVersion returns the current schema version for the database
+		ReadSchemaVersion() (string, error)
+		// UpdateSchemaVersion updates the schema version for the database
+		UpdateSchemaVersion(newVersion string, minCompatibleVersion string) error
+		// WriteSchemaUpdateLog adds an entry to the schema update history table
+		WriteSchemaUpdateLog(oldVersion string, newVersion string, manifestMD5 string, desc string) error
+		// ReadSchemaUpdateChecksums returns the checksum recorded for each applied version
+		ReadSchemaUpdateChecksums() (map[string]string, error)
+		// Close gracefully closes the client object
+		Close()
+	}
+
+	sqlClient struct {
+		db       *sqlx.DB
+		driver   *driver
+		database string
+	}
+
+	// driver holds everything that differs between the databases supported by the tool,
+	// other sqldb plugins are supported by adding an entry to the drivers map
+	driver struct {
+		dataSourceName                 func(host string, port int, user, password, database string) string
+		defaultPort                    int
+		createDatabaseQuery            string
+		dropDatabaseQuery              string
+		listTablesQuery                string
+		createSchemaVersionTable       string
+		createSchemaUpdateHistoryTable string
+	}
+)
+
+var errGetSchemaVersion = errors.New("Failed to get current schema version from database")
+
+const (
+	defaultDriver    = "mysql"
+	defaultMySQLPort = 3306
+)
+
+const (
+	readSchemaVersionQuery         = `SELECT curr_version FROM schema_version WHERE db_name=?`
+	deleteSchemaVersionQuery       = `DELETE FROM schema_version WHERE db_name=?`
+	writeSchemaVersionQuery        = `INSERT INTO schema_version(db_name, creation_time, curr_version, min_compatible_version) VALUES (?,?,?,?)`
+	writeSchemaUpdateHistoryQuery  = `INSERT INTO schema_update_history(year, month, update_time, old_version, new_version, manifest_md5, description) VALUES(?,?,?,?,?,?,?)`
+	readSchemaUpdateChecksumsQuery = `SELECT new_version, manifest_md5 FROM schema_update_history`
+)
+
+var drivers = map[string]*driver{
+	"mysql": {
+		dataSourceName: func(host string, port int, user, password, database string) string {
+			return fmt.Sprintf("%v:%v@tcp(%v)/%v?parseTime=true", user, password, net.JoinHostPort(host, strconv.Itoa(port)), database)
+		},
+		defaultPort:         defaultMySQLPort,
+		createDatabaseQuery: "CREATE DATABASE IF NOT EXISTS %v",
+		dropDatabaseQuery:   "DROP DATABASE IF EXISTS %v",
+		listTablesQuery:     "SELECT table_name FROM information_schema.tables WHERE table_schema = ?",
+
+		createSchemaVersionTable: `CREATE TABLE schema_version(db_name VARCHAR(255) not null PRIMARY KEY, ` +
+			`creation_time DATETIME(6), ` +
+			`curr_version VARCHAR(64), ` +
+			`min_compatible_version VARCHAR(64));`,
+
+		createSchemaUpdateHistoryTable: `CREATE TABLE schema_update_history(` +
+			`year int not null, ` +
+			`month int not null, ` +
+			`update_time DATETIME(6) not null, ` +
+			`description VARCHAR(255), ` +
+			`manifest_md5 VARCHAR(64), ` +
+			`new_version VARCHAR(64), ` +
+			`old_version VARCHAR(64), ` +
+			`PRIMARY KEY (year, month, update_time));`,
+	},
+}
+
+func getDriver(name string) (*driver, error) {
+	d, ok := drivers[name]
+	if !ok {
+		names := make([]string, 0, len(drivers))
+		for n := range drivers {
+			names = append(names, n)
+		}
+		return nil, fmt.Errorf("unsupported driver %v, supported drivers are %v", name, names)
+	}
+	return d, nil
+}
+
+// newSQLClient returns a new instance of SQLClient, an empty
+// database name connects to the server without selecting a database
+func newSQLClient(cfg *BaseConfig, database string) (SQLClient, error) {
+	d, err := getDriver(cfg.DBDriver)
+	if err != nil {
+		return nil, err
+	}
+	port := cfg.DBPort
+	if port == 0 {
+		port = d.defaultPort
+	}
+	db, err := sqlx.Connect(cfg.DBDriver, d.dataSourceName(cfg.DBHost, port, cfg.DBUser, cfg.DBPassword, database))
+	if err != nil {
+		return nil, err
+	}
+	return &sqlClient{
+		db:       db,
+		driver:   d,
+		database: database,
+	}, nil
+}
+
+// CreateDatabase creates a database if it doesn't exist
+func (client *sqlClient) CreateDatabase(name string) error {
+	return client.Exec(fmt.Sprintf(client.driver.createDatabaseQuery, name))
+}
+
+// DropDatabase drops a database if it exists
+func (client *sqlClient) DropDatabase(name string) error {
+	return client.Exec(fmt.Sprintf(client.driver.dropDatabaseQuery, name))
+}
+
+// ListTables lists the table names in the database
+func (client *sqlClient) ListTables() ([]string, error) {
+	var names []string
+	err := client.db.Select(&names, client.db.Rebind(client.driver.listTablesQuery), client.database)
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
+
+// DropTable drops a given table from the database
+func (client *sqlClient) DropTable(name string) error {
+	return client.Exec(fmt.Sprintf("DROP TABLE %v", name))
+}
+
+// CreateSchemaVersionTables sets up the schema version tables
+func (client *sqlClient) CreateSchemaVersionTables() error {
+	if err := client.Exec(client.driver.createSchemaVersionTable); err != nil {
+		return err
+	}
+	return client.Exec(client.driver.createSchemaUpdateHistoryTable)
+}
+
+// ReadSchemaVersion returns the current schema version for the database
+func (client *sqlClient) ReadSchemaVersion() (string, error) {
+	var versions []string
+	err := client.db.Select(&versions, client.db.Rebind(readSchemaVersionQuery), client.database)
+	if err != nil {
+		return "", err
+	}
+	if len(versions) == 0 {
+		return "", errGetSchemaVersion
+	}
+	return versions[0], nil
+}
+
+// UpdateSchemaVersion updates the schema version for the database,
+// delete and insert are used instead of an upsert which is not portable across databases
+func (client *sqlClient) UpdateSchemaVersion(newVersion string, minCompatibleVersion string) error {
+	tx, err := client.db.Beginx()
+	if err != nil {
+		return err
+	}
+	if _, err := tx.Exec(tx.Rebind(deleteSchemaVersionQuery), client.database); err != nil {
+		tx.Rollback()
+		return err
+	}
+	if _, err := tx.Exec(tx.Rebind(writeSchemaVersionQuery), client.database, time.Now().UTC(), newVersion, minCompatibleVersion); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// WriteSchemaUpdateLog adds an entry to the schema update history table
+func (client *sqlClient) WriteSchemaUpdateLog(oldVersion string, newVersion string, manifestMD5 string, desc string) error {
+	now := time.Now().UTC()
+	_, err := client.db.Exec(client.db.Rebind(writeSchemaUpdateHistoryQuery),
+		now.Year(), int(now.Month()), now, oldVersion, newVersion, manifestMD5, desc)
+	return err
+}
+
+// ReadSchemaUpdateChecksums returns the checksum recorded for each applied version
+func (client *sqlClient) ReadSchemaUpdateChecksums() (map[string]string, error) {
+	rows, err := client.db.Query(readSchemaUpdateChecksumsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make(map[string]string)
+	for rows.Next() {
+		var version, checksum string
+		if err := rows.Scan(&version, &checksum); err != nil {
+			return nil, err
+		}
+		result[version] = checksum
+	}
+	return result, rows.Err()
+}
+
+// Exec executes a sql statement
+func (client *sqlClient) Exec(stmt string) error {
+	_, err := client.db.Exec(stmt)
+	return err
+}
+
+// Close closes the sql client
+func (client *sqlClient) Close() {
+	if client.db != nil {
+		client.db.Close()
+	}
+}
+
+// dropAllTables deletes all tables in the
+// database without deleting the database
+func dropAllTables(client SQLClient) {
+	tables, err := client.ListTables()
+	if err != nil {
+		return
+	}
+	log.Printf("Dropping following tables: %v\n", tables)
+	for _, table := range tables {
+		err1 := client.DropTable(table)
+		if err1 != nil {
+			log.Printf("Error dropping table %v, err=%v\n", table, err1)
+		}
+	}
+}
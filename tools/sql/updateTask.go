@@ -0,0 +1,119 @@
+// Copyright (c) 2017 Uber Technologies, Inc.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+package sql
+
+import (
+	"fmt"
+	"log"
+
+	"github.com/uber/cadence/tools/common/schema"
+)
+
+type (
+	// UpdateSchemaTask represents a task
+	// that executes a sql schema upgrade
+	UpdateSchemaTask struct {
+		client SQLClient
+		config *UpdateSchemaConfig
+	}
+)
+
+const (
+	dryrunDatabase = "dryrun_"
+)
+
+// NewUpdateSchemaTask returns a new instance of UpdateSchemaTask
+func NewUpdateSchemaTask(config *UpdateSchemaConfig) (*UpdateSchemaTask, error) {
+
+	database := config.DBName
+	if config.IsDryRun {
+		database = dryrunDatabase
+		err := setupDryrunDatabase(config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating dryrun database:%v", err.Error())
+		}
+	}
+
+	client, err := newSQLClient(&config.BaseConfig, database)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateSchemaTask{
+		client: client,
+		config: config,
+	}, nil
+}
+
+// run executes the task
+func (task *UpdateSchemaTask) run() error {
+
+	config := task.config
+
+	defer func() {
+		if config.IsDryRun {
+			task.client.DropDatabase(dryrunDatabase)
+		}
+		task.client.Close()
+	}()
+
+	log.Printf("UpdateSchemeTask started, config=%+v\n", config)
+
+	// the sql client reads back the recorded checksums, so the
+	// schema files of the applied versions are verified first
+	err := schema.NewUpdateTask(task.client, config.SchemaDir, config.TargetVersion).Run()
+	if err != nil {
+		return err
+	}
+
+	log.Printf("UpdateSchemeTask done\n")
+
+	return nil
+}
+
+// sets up a temporary dryrun database for
+// executing the sql schema update
+func setupDryrunDatabase(config *UpdateSchemaConfig) error {
+	client, err := newSQLClient(&config.BaseConfig, "")
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	err = client.CreateDatabase(dryrunDatabase)
+	if err != nil {
+		return err
+	}
+
+	setupConfig := &SetupSchemaConfig{
+		BaseConfig:     config.BaseConfig,
+		Overwrite:      true,
+		InitialVersion: "0.0",
+	}
+	setupConfig.DBName = dryrunDatabase
+
+	setupTask, err := newSetupSchemaTask(setupConfig)
+	if err != nil {
+		return err
+	}
+
+	return setupTask.run()
+}